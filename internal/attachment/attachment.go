@@ -0,0 +1,200 @@
+// Package attachment finds local files referenced from a comment and
+// replaces the references with the URLs the files were uploaded to.
+package attachment
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MaxSize is the largest file that is uploaded, in bytes.
+const MaxSize = 10 << 20
+
+// Reference is a local file referenced from a comment body, either as a
+// markdown image whose target is a local path, or as an image path pasted on
+// a line of its own (as terminals do when a file is dropped on them).
+type Reference struct {
+	// Start and End are the byte range of the body that is replaced.
+	Start int
+	End   int
+	// Path is the file on disk; Name is the alt text or the file name.
+	Path string
+	Name string
+}
+
+// UploadFunc uploads one file and returns the URL it is hosted at.
+type UploadFunc func(name string, content []byte) (string, error)
+
+var (
+	// ![alt](path) or ![alt](<path with spaces>)
+	markdownImage = regexp.MustCompile(`!\[([^\]]*)\]\(\s*(<[^>\n]+>|[^)\s]+)\s*\)`)
+
+	imageExtensions = map[string]bool{
+		".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true, ".svg": true,
+	}
+)
+
+// IsImage reports whether name has the extension of an image that renders
+// inline in markdown.
+func IsImage(name string) bool {
+	return imageExtensions[strings.ToLower(filepath.Ext(name))]
+}
+
+// Find returns the references to existing local files in body, in order.
+// Only absolute, ~/ and file:// paths are references; relative paths would
+// resolve against wherever the program was started, and are left alone like
+// URLs and files that do not exist.
+func Find(body string) []Reference {
+	var refs []Reference
+
+	for _, match := range markdownImage.FindAllStringSubmatchIndex(body, -1) {
+		target := strings.TrimSuffix(strings.TrimPrefix(body[match[4]:match[5]], "<"), ">")
+		if !isExplicitPath(target) {
+			continue
+		}
+		path, ok := localFile(target)
+		if !ok {
+			continue
+		}
+		name := body[match[2]:match[3]]
+		if name == "" {
+			name = filepath.Base(path)
+		}
+		refs = append(refs, Reference{Start: match[0], End: match[1], Path: path, Name: name})
+	}
+
+	offset := 0
+	for _, line := range strings.SplitAfter(body, "\n") {
+		start := offset
+		offset += len(line)
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || overlaps(refs, start, offset) {
+			continue
+		}
+		target := unquotePastedPath(trimmed)
+		if !isExplicitPath(target) || !IsImage(target) {
+			continue
+		}
+		path, ok := localFile(target)
+		if !ok {
+			continue
+		}
+		lead := strings.Index(line, trimmed)
+		refs = append(refs, Reference{Start: start + lead, End: start + lead + len(trimmed), Path: path, Name: filepath.Base(path)})
+	}
+
+	sort.Slice(refs, func(i, j int) bool { return refs[i].Start < refs[j].Start })
+	return refs
+}
+
+// UploadAll uploads every file referenced from body and returns the body
+// with the references replaced by links to the uploads, along with the
+// number of files uploaded. Nothing is replaced when an upload fails.
+func UploadAll(body string, upload UploadFunc) (string, int, error) {
+	refs := Find(body)
+	if len(refs) == 0 {
+		return body, 0, nil
+	}
+
+	urls := make([]string, len(refs))
+	for i, ref := range refs {
+		content, err := os.ReadFile(ref.Path)
+		if err != nil {
+			return body, 0, fmt.Errorf("failed to read attachment %s: %w", ref.Path, err)
+		}
+		if len(content) > MaxSize {
+			return body, 0, fmt.Errorf("attachment %s is larger than %d MB", ref.Path, MaxSize>>20)
+		}
+		url, err := upload(filepath.Base(ref.Path), content)
+		if err != nil {
+			return body, 0, fmt.Errorf("failed to upload %s: %w", filepath.Base(ref.Path), err)
+		}
+		urls[i] = url
+	}
+
+	return Replace(body, refs, urls), len(refs), nil
+}
+
+// Omit replaces each reference in body with its name, for providers that
+// cannot host the files, and returns the number of references left out.
+func Omit(body string) (string, int) {
+	refs := Find(body)
+	var b strings.Builder
+	last := 0
+	for _, ref := range refs {
+		b.WriteString(body[last:ref.Start])
+		b.WriteString(ref.Name)
+		last = ref.End
+	}
+	b.WriteString(body[last:])
+	return b.String(), len(refs)
+}
+
+// Replace substitutes each reference with a markdown image of its URL, or a
+// link for files that are not images. refs must be sorted as Find returns
+// them.
+func Replace(body string, refs []Reference, urls []string) string {
+	var b strings.Builder
+	last := 0
+	for i, ref := range refs {
+		b.WriteString(body[last:ref.Start])
+		if IsImage(ref.Path) {
+			b.WriteString("!")
+		}
+		fmt.Fprintf(&b, "[%s](%s)", ref.Name, urls[i])
+		last = ref.End
+	}
+	b.WriteString(body[last:])
+	return b.String()
+}
+
+// isExplicitPath reports whether target is an absolute, ~/ or file:// path.
+func isExplicitPath(target string) bool {
+	return filepath.IsAbs(target) || strings.HasPrefix(target, "~/") || strings.HasPrefix(target, "file://")
+}
+
+// localFile resolves target to an existing regular file. URLs are never
+// local files, except file:// URLs.
+func localFile(target string) (string, bool) {
+	if strings.HasPrefix(target, "file://") {
+		target = strings.TrimPrefix(target, "file://")
+	} else if strings.Contains(target, "://") || strings.HasPrefix(target, "data:") {
+		return "", false
+	}
+	if strings.HasPrefix(target, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", false
+		}
+		target = filepath.Join(home, target[2:])
+	}
+
+	info, err := os.Stat(target)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+	return target, true
+}
+
+// unquotePastedPath undoes the quoting terminals apply to dropped paths:
+// surrounding quotes, or backslash-escaped spaces.
+func unquotePastedPath(s string) string {
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return strings.ReplaceAll(s, `\ `, " ")
+}
+
+func overlaps(refs []Reference, start, end int) bool {
+	for _, ref := range refs {
+		if ref.Start < end && start < ref.End {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package attachment
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name string, size int) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, make([]byte, size), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+	shot := writeFile(t, dir, "shot.png", 10)
+	spaced := writeFile(t, dir, "Screen Shot.png", 10)
+	log := writeFile(t, dir, "build.log", 10)
+
+	body := strings.Join([]string{
+		"See ![the bug](" + shot + ") and ![](<" + log + ">).",
+		"  '" + spaced + "'",
+		strings.ReplaceAll(spaced, " ", `\ `),
+		"![remote](https://example.com/a.png) ![missing](" + filepath.Join(dir, "gone.png") + ")",
+		log,
+	}, "\n")
+
+	refs := Find(body)
+
+	if len(refs) != 4 {
+		t.Fatalf("expected 4 references, got %+v", refs)
+	}
+	want := []struct{ path, name string }{{shot, "the bug"}, {log, "build.log"}, {spaced, "Screen Shot.png"}, {spaced, "Screen Shot.png"}}
+	for i, w := range want {
+		if refs[i].Path != w.path || refs[i].Name != w.name {
+			t.Errorf("reference %d: expected %s (%s), got %+v", i, w.path, w.name, refs[i])
+		}
+	}
+	if got := body[refs[2].Start:refs[2].End]; got != "'"+spaced+"'" {
+		t.Errorf("expected the quoted path to be replaced, got %q", got)
+	}
+}
+
+func TestFind_IgnoresRelativePaths(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "shot.png", 10)
+	t.Chdir(dir)
+
+	if refs := Find("![shot](shot.png) ![shot](./shot.png)\nshot.png"); len(refs) != 0 {
+		t.Errorf("expected relative paths to be left alone, got %+v", refs)
+	}
+}
+
+func TestUploadAll(t *testing.T) {
+	dir := t.TempDir()
+	shot := writeFile(t, dir, "shot.png", 10)
+	log := writeFile(t, dir, "build.log", 10)
+
+	var uploaded []string
+	body, count, err := UploadAll("Before\n"+shot+"\n![log](<"+log+">) after", func(name string, content []byte) (string, error) {
+		uploaded = append(uploaded, name)
+		return "https://files.example.com/" + name, nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 2 || strings.Join(uploaded, ",") != "shot.png,build.log" {
+		t.Errorf("unexpected uploads %v (%d)", uploaded, count)
+	}
+	want := "Before\n![shot.png](https://files.example.com/shot.png)\n[log](https://files.example.com/build.log) after"
+	if body != want {
+		t.Errorf("expected %q, got %q", want, body)
+	}
+}
+
+func TestUploadAll_KeepsBodyOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	shot := writeFile(t, dir, "shot.png", 10)
+	large := writeFile(t, dir, "large.png", MaxSize+1)
+
+	body := "![a](" + shot + ")"
+	got, _, err := UploadAll(body, func(string, []byte) (string, error) { return "", errors.New("not supported") })
+	if err == nil || !strings.Contains(err.Error(), "shot.png") || got != body {
+		t.Errorf("expected the upload error and the original body, got %q, %v", got, err)
+	}
+
+	_, _, err = UploadAll(large, func(string, []byte) (string, error) { return "url", nil })
+	if err == nil {
+		t.Error("expected files over the size limit to be rejected")
+	}
+}
+
+func TestUploadAll_WithoutReferences(t *testing.T) {
+	body := "Plain comment with docs/diagram.png and ![x](https://example.com/x.png)"
+	got, count, err := UploadAll(body, func(string, []byte) (string, error) {
+		t.Error("expected no upload")
+		return "", nil
+	})
+	if err != nil || count != 0 || got != body {
+		t.Errorf("expected the body unchanged, got %q, %d, %v", got, count, err)
+	}
+}
+
+func TestOmit(t *testing.T) {
+	dir := t.TempDir()
+	shot := writeFile(t, dir, "shot.png", 10)
+	log := writeFile(t, dir, "build.log", 10)
+
+	body, count := Omit("See ![the bug](" + shot + ")\n" + shot + "\nand ![](<" + log + ">) ![x](https://example.com/x.png)")
+
+	want := "See the bug\nshot.png\nand build.log ![x](https://example.com/x.png)"
+	if count != 3 || body != want {
+		t.Errorf("expected %q with 3 references left out, got %q (%d)", want, body, count)
+	}
+}
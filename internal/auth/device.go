@@ -0,0 +1,227 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/endpoints"
+	"golang.org/x/oauth2/microsoft"
+)
+
+const (
+	defaultAzureTenant = "organizations"
+	// azureDevOpsScope requests a token for the Azure DevOps resource.
+	azureDevOpsScope = "499b84ac-1321-427f-aa17-267ca6975798/.default"
+)
+
+var githubScopes = []string{"repo", "read:org"}
+
+var ErrNotConfigured = errors.New("OAuth login not configured: set settings.OAuth.GitHubClientID or AzureClientID in config.json")
+
+// DeviceLogin is an in-progress OAuth 2.0 device authorization grant.
+type DeviceLogin struct {
+	provider domain.ProviderType
+	config   *oauth2.Config
+	tenant   string
+	response *oauth2.DeviceAuthResponse
+}
+
+// NewDeviceLogin prepares a device-flow login for the provider using the
+// client IDs from the settings.
+func NewDeviceLogin(provider domain.ProviderType, settings domain.OAuthSettings) (*DeviceLogin, error) {
+	switch provider {
+	case domain.ProviderGitHub:
+		if settings.GitHubClientID == "" {
+			return nil, ErrNotConfigured
+		}
+		return &DeviceLogin{
+			provider: provider,
+			config:   newConfig(provider, settings.GitHubClientID, ""),
+		}, nil
+	case domain.ProviderAzureDevOps:
+		if settings.AzureClientID == "" {
+			return nil, ErrNotConfigured
+		}
+		tenant := settings.AzureTenant
+		if tenant == "" {
+			tenant = defaultAzureTenant
+		}
+		return &DeviceLogin{
+			provider: provider,
+			config:   newConfig(provider, settings.AzureClientID, tenant),
+			tenant:   tenant,
+		}, nil
+	default:
+		return nil, fmt.Errorf("device login is not supported for provider %s", provider)
+	}
+}
+
+func newConfig(provider domain.ProviderType, clientID, tenant string) *oauth2.Config {
+	if provider == domain.ProviderAzureDevOps {
+		return &oauth2.Config{
+			ClientID: clientID,
+			Endpoint: microsoft.AzureADEndpoint(tenant),
+			Scopes:   []string{azureDevOpsScope, "offline_access"},
+		}
+	}
+	return &oauth2.Config{
+		ClientID: clientID,
+		Endpoint: endpoints.GitHub,
+		Scopes:   githubScopes,
+	}
+}
+
+// Start requests a device and user code. The user code has to be entered at
+// VerificationURI before Wait can succeed.
+func (d *DeviceLogin) Start(ctx context.Context) error {
+	response, err := d.config.DeviceAuth(ctx)
+	if err != nil {
+		logger.LogError("OAUTH_DEVICE_AUTH", string(d.provider), err)
+		return fmt.Errorf("failed to start device login: %w", err)
+	}
+	d.response = response
+	return nil
+}
+
+func (d *DeviceLogin) UserCode() string {
+	if d.response == nil {
+		return ""
+	}
+	return d.response.UserCode
+}
+
+func (d *DeviceLogin) VerificationURI() string {
+	if d.response == nil {
+		return ""
+	}
+	if d.response.VerificationURIComplete != "" {
+		return d.response.VerificationURIComplete
+	}
+	return d.response.VerificationURI
+}
+
+// Wait polls the token endpoint until the user approves or denies the login,
+// the code expires or ctx is cancelled.
+func (d *DeviceLogin) Wait(ctx context.Context) (*oauth2.Token, error) {
+	if d.response == nil {
+		return nil, errors.New("device login not started")
+	}
+	token, err := d.config.DeviceAccessToken(ctx, d.response)
+	if err != nil {
+		logger.LogError("OAUTH_DEVICE_TOKEN", string(d.provider), err)
+		return nil, fmt.Errorf("device login failed: %w", err)
+	}
+	return token, nil
+}
+
+// ApplyToken stores the token on the PAT, keeping the previous refresh token
+// when the server did not rotate it.
+func (d *DeviceLogin) ApplyToken(pat *domain.PAT, token *oauth2.Token) {
+	pat.Provider = d.provider
+	applyToken(pat, token, d.config.ClientID, d.tenant)
+}
+
+func applyToken(pat *domain.PAT, token *oauth2.Token, clientID, tenant string) {
+	refreshToken := token.RefreshToken
+	if refreshToken == "" && pat.OAuth != nil {
+		refreshToken = pat.OAuth.RefreshToken
+	}
+	pat.Token = token.AccessToken
+	pat.OAuth = &domain.OAuthToken{
+		RefreshToken: refreshToken,
+		Expiry:       token.Expiry,
+		ClientID:     clientID,
+		Tenant:       tenant,
+	}
+}
+
+// refreshTimeout bounds a token refresh, which runs inside whichever request
+// found the access token expired.
+const refreshTimeout = 30 * time.Second
+
+// TokenSource returns the access token of a device-flow PAT, refreshing it
+// when it expires and passing the updated PAT to save so that the refreshed
+// token outlives the session. It is safe for concurrent use.
+func TokenSource(pat domain.PAT, save func(domain.PAT)) oauth2.TokenSource {
+	return tokenSource(pat, newConfig(pat.Provider, pat.OAuth.ClientID, pat.OAuth.Tenant), save)
+}
+
+func tokenSource(pat domain.PAT, config *oauth2.Config, save func(domain.PAT)) oauth2.TokenSource {
+	current := &oauth2.Token{
+		AccessToken:  pat.Token,
+		RefreshToken: pat.OAuth.RefreshToken,
+		Expiry:       pat.OAuth.Expiry,
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: refreshTimeout})
+	return oauth2.ReuseTokenSource(current, &savingTokenSource{
+		pat:    pat,
+		source: config.TokenSource(ctx, current),
+		save:   save,
+	})
+}
+
+// savingTokenSource refreshes tokens through source and saves the PAT
+// whenever the access token changes.
+type savingTokenSource struct {
+	mu     sync.Mutex
+	pat    domain.PAT
+	source oauth2.TokenSource
+	save   func(domain.PAT)
+}
+
+func (s *savingTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, err := s.source.Token()
+	if err != nil {
+		logger.LogError("OAUTH_REFRESH", s.pat.Name, err)
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) && retrieveErr.ErrorCode == "invalid_grant" {
+			return nil, fmt.Errorf("%w: OAuth token for %s was revoked or expired, log in again", common.ErrUnauthorized, s.pat.Name)
+		}
+		return nil, fmt.Errorf("failed to refresh OAuth token for %s: %w", s.pat.Name, err)
+	}
+	if token.AccessToken == s.pat.Token {
+		return token, nil
+	}
+
+	logger.Log("OAuth: Refreshed access token for %s", s.pat.Name)
+	applyToken(&s.pat, token, s.pat.OAuth.ClientID, s.pat.OAuth.Tenant)
+	if s.save != nil {
+		s.save(s.pat)
+	}
+	return token, nil
+}
+
+// DisplayName extracts the "name" claim from a JWT access token, which is the
+// display name Azure DevOps uses to match PR authors and reviewers. The token
+// is not verified; it was just received from the token endpoint.
+func DisplayName(accessToken string) string {
+	parts := strings.Split(accessToken, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	var claims struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	return claims.Name
+}
@@ -0,0 +1,193 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+	"golang.org/x/oauth2"
+)
+
+func newTestEndpoint(t *testing.T, handler http.HandlerFunc) oauth2.Endpoint {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return oauth2.Endpoint{
+		DeviceAuthURL: server.URL + "/device",
+		TokenURL:      server.URL + "/token",
+		AuthStyle:     oauth2.AuthStyleInParams,
+	}
+}
+
+func TestNewDeviceLogin_NotConfigured(t *testing.T) {
+	for _, provider := range []domain.ProviderType{domain.ProviderGitHub, domain.ProviderAzureDevOps} {
+		if _, err := NewDeviceLogin(provider, domain.OAuthSettings{}); !errors.Is(err, ErrNotConfigured) {
+			t.Errorf("%s: expected ErrNotConfigured, got %v", provider, err)
+		}
+	}
+	if _, err := NewDeviceLogin("gitlab", domain.OAuthSettings{GitHubClientID: "id"}); err == nil {
+		t.Error("expected unsupported provider error")
+	}
+}
+
+func TestNewDeviceLogin_AzureDefaultsTenant(t *testing.T) {
+	login, err := NewDeviceLogin(domain.ProviderAzureDevOps, domain.OAuthSettings{AzureClientID: "client"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if login.tenant != defaultAzureTenant {
+		t.Errorf("expected default tenant, got %q", login.tenant)
+	}
+	if login.config.Endpoint.DeviceAuthURL != "https://login.microsoftonline.com/organizations/oauth2/v2.0/devicecode" {
+		t.Errorf("unexpected device URL: %s", login.config.Endpoint.DeviceAuthURL)
+	}
+}
+
+func TestDeviceLogin_StartAndWait(t *testing.T) {
+	polls := 0
+	endpoint := newTestEndpoint(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/device":
+			fmt.Fprint(w, `{"device_code":"dev","user_code":"ABCD-1234","verification_uri":"https://example.com/device","interval":1,"expires_in":60}`)
+		case "/token":
+			polls++
+			if r.FormValue("device_code") != "dev" {
+				t.Errorf("unexpected device code %q", r.FormValue("device_code"))
+			}
+			fmt.Fprint(w, `{"access_token":"access","refresh_token":"refresh","token_type":"bearer","expires_in":3600}`)
+		}
+	})
+
+	login, err := NewDeviceLogin(domain.ProviderGitHub, domain.OAuthSettings{GitHubClientID: "client"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	login.config.Endpoint = endpoint
+
+	if err := login.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+	if login.UserCode() != "ABCD-1234" || login.VerificationURI() != "https://example.com/device" {
+		t.Errorf("unexpected code %q at %q", login.UserCode(), login.VerificationURI())
+	}
+
+	token, err := login.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("failed to wait: %v", err)
+	}
+
+	var pat domain.PAT
+	login.ApplyToken(&pat, token)
+	if pat.Provider != domain.ProviderGitHub || pat.Token != "access" {
+		t.Errorf("unexpected PAT: %+v", pat)
+	}
+	if pat.OAuth == nil || pat.OAuth.RefreshToken != "refresh" || pat.OAuth.ClientID != "client" {
+		t.Errorf("unexpected OAuth state: %+v", pat.OAuth)
+	}
+	if polls != 1 {
+		t.Errorf("expected a single poll, got %d", polls)
+	}
+}
+
+func TestTokenSource_RefreshesAndSavesExpiredToken(t *testing.T) {
+	refreshes := 0
+	endpoint := newTestEndpoint(t, func(w http.ResponseWriter, r *http.Request) {
+		refreshes++
+		if r.FormValue("grant_type") != "refresh_token" || r.FormValue("refresh_token") != "old-refresh" {
+			t.Errorf("unexpected refresh request: %v", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"new-access","token_type":"bearer","expires_in":3600}`)
+	})
+
+	pat := domain.PAT{
+		Name:     "work",
+		Provider: domain.ProviderAzureDevOps,
+		Token:    "old-access",
+		OAuth: &domain.OAuthToken{
+			RefreshToken: "old-refresh",
+			Expiry:       time.Now().Add(-time.Minute),
+			ClientID:     "client",
+			Tenant:       "organizations",
+		},
+	}
+	config := newConfig(pat.Provider, "client", "organizations")
+	config.Endpoint = endpoint
+
+	var saved []domain.PAT
+	source := tokenSource(pat, config, func(pat domain.PAT) { saved = append(saved, pat) })
+	for i := 0; i < 2; i++ {
+		token, err := source.Token()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if token.AccessToken != "new-access" {
+			t.Errorf("expected the new access token, got %q", token.AccessToken)
+		}
+	}
+
+	if refreshes != 1 {
+		t.Errorf("expected a single refresh, got %d", refreshes)
+	}
+	if len(saved) != 1 {
+		t.Fatalf("expected the PAT to be saved once, got %d", len(saved))
+	}
+	if saved[0].Token != "new-access" || saved[0].OAuth.RefreshToken != "old-refresh" {
+		t.Errorf("expected the new access token and the kept refresh token, got %+v", saved[0])
+	}
+	if !saved[0].OAuth.Expiry.After(time.Now()) {
+		t.Error("expected a future expiry")
+	}
+}
+
+func TestTokenSource_ValidTokenUnchanged(t *testing.T) {
+	pat := domain.PAT{
+		Provider: domain.ProviderGitHub,
+		Token:    "access",
+		OAuth:    &domain.OAuthToken{ClientID: "client", Expiry: time.Now().Add(time.Hour)},
+	}
+
+	source := TokenSource(pat, func(domain.PAT) { t.Error("expected a valid token not to be saved") })
+	token, err := source.Token()
+	if err != nil || token.AccessToken != "access" {
+		t.Errorf("expected the current token, got %+v err=%v", token, err)
+	}
+}
+
+func TestTokenSource_RevokedRefreshTokenIsUnauthorized(t *testing.T) {
+	endpoint := newTestEndpoint(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"invalid_grant","error_description":"refresh token expired"}`)
+	})
+
+	pat := domain.PAT{
+		Provider: domain.ProviderAzureDevOps,
+		Token:    "old-access",
+		OAuth:    &domain.OAuthToken{RefreshToken: "old-refresh", Expiry: time.Now().Add(-time.Minute), ClientID: "client"},
+	}
+	config := newConfig(pat.Provider, "client", "organizations")
+	config.Endpoint = endpoint
+
+	if _, err := tokenSource(pat, config, nil).Token(); !errors.Is(err, common.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestDisplayName(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"name":"Jane Doe","upn":"jane@example.com"}`))
+	if got := DisplayName("header." + payload + ".signature"); got != "Jane Doe" {
+		t.Errorf("expected Jane Doe, got %q", got)
+	}
+	if got := DisplayName("not-a-jwt"); got != "" {
+		t.Errorf("expected empty name for opaque token, got %q", got)
+	}
+}
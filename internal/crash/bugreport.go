@@ -0,0 +1,114 @@
+package crash
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/network"
+	"github.com/johanforsgren/lgtmfaster/internal/version"
+)
+
+// BugReport holds the diagnostics gathered by the application for
+// WriteBugReport. Nothing in it is sent anywhere.
+type BugReport struct {
+	// Config is the configuration as JSON, with the credentials stripped.
+	Config []byte
+	// Terminal describes the terminal's capabilities, one per line.
+	Terminal string
+	// Secrets are redacted from every file of the bundle wherever they
+	// appear, in case one was missed when stripping the configuration.
+	Secrets []string
+}
+
+type bundleFile struct {
+	name    string
+	content string
+}
+
+// WriteBugReport bundles the report with the version, the recent log
+// entries and the last crash report into a gzipped tarball at path, for
+// attaching to an issue.
+func WriteBugReport(path string, report BugReport) error {
+	now := time.Now()
+	redactor := network.NewRedactor(report.Secrets...)
+
+	var logs strings.Builder
+	for _, entry := range logger.GetLogs() {
+		fmt.Fprintf(&logs, "%s %s\n", entry.Timestamp.Format("2006-01-02 15:04:05.000"), entry.Message)
+	}
+	files := []bundleFile{
+		{"version.txt", fmt.Sprintf("LGTMFaster %s (%s, built %s)\n%s %s/%s\nReported at %s\n",
+			version.Version, version.GitCommit, version.BuildDate, runtime.Version(), runtime.GOOS, runtime.GOARCH, now.Format(time.RFC3339))},
+		{"terminal.txt", report.Terminal},
+		{"config.json", string(report.Config)},
+		{"logs.txt", logs.String()},
+	}
+	if last, err := lastCrashReport(); err != nil {
+		logger.LogError("BUGREPORT_CRASH", "", err)
+	} else if last != "" {
+		data, err := os.ReadFile(last)
+		if err != nil {
+			return fmt.Errorf("failed to read crash report: %w", err)
+		}
+		files = append(files, bundleFile{filepath.Base(last), string(data)})
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create bug report directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create bug report: %w", err)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+	dir := "lgtmfaster-bugreport-" + now.Format("20060102-150405")
+	for _, f := range files {
+		content := []byte(redactor.Redact(f.content))
+		header := &tar.Header{
+			Name:    dir + "/" + f.name,
+			Mode:    0600,
+			Size:    int64(len(content)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write bug report: %w", err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			return fmt.Errorf("failed to write bug report: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write bug report: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write bug report: %w", err)
+	}
+	return file.Close()
+}
+
+// lastCrashReport returns the path of the newest crash report, or "" when
+// there is none.
+func lastCrashReport() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	reports, err := filepath.Glob(filepath.Join(dir, "crash-*.log"))
+	if err != nil || len(reports) == 0 {
+		return "", err
+	}
+	// The names hold the time of the crash, so they sort by it.
+	sort.Strings(reports)
+	return reports[len(reports)-1], nil
+}
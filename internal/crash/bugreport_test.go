@@ -0,0 +1,79 @@
+package crash
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+func readBundle(t *testing.T, path string) map[string]string {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open bundle: %v", err)
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("Failed to read bundle: %v", err)
+	}
+	files := make(map[string]string)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatalf("Failed to read bundle: %v", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", header.Name, err)
+		}
+		files[filepath.Base(header.Name)] = string(data)
+	}
+}
+
+func TestWriteBugReport_BundlesRedactedDiagnostics(t *testing.T) {
+	dir := useTestDir(t)
+	if err := os.WriteFile(filepath.Join(dir, "crash-20261001-100000.log"), []byte("older crash"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "crash-20261016-090000.log"), []byte("panic: boom"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	logger.Log("Loading PRs with token s3cr3t-token-value")
+
+	path := filepath.Join(t.TempDir(), "bugreport.tar.gz")
+	err := WriteBugReport(path, BugReport{
+		Config:   []byte(`{"Settings":{"Webhook":{"Port":8080}}}`),
+		Terminal: "TERM=xterm-256color\n",
+		Secrets:  []string{"s3cr3t-token-value"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to write bug report: %v", err)
+	}
+
+	files := readBundle(t, path)
+	for _, name := range []string{"version.txt", "terminal.txt", "config.json", "logs.txt", "crash-20261016-090000.log"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("Expected %s in the bundle, got %v", name, files)
+		}
+	}
+	if _, ok := files["crash-20261001-100000.log"]; ok {
+		t.Error("Expected only the last crash report to be bundled")
+	}
+	if logs := files["logs.txt"]; strings.Contains(logs, "s3cr3t-token-value") || !strings.Contains(logs, "[REDACTED]") {
+		t.Errorf("Expected the secret to be redacted from the logs, got %q", logs)
+	}
+	if files["terminal.txt"] != "TERM=xterm-256color\n" {
+		t.Errorf("Unexpected terminal description %q", files["terminal.txt"])
+	}
+}
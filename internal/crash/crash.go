@@ -0,0 +1,195 @@
+// Package crash turns panics into crash reports: the terminal is given back,
+// the panic, its stack and the recent log entries are written to
+// ~/.lgtmfaster/crashes, and the next launch is told about it.
+package crash
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/version"
+)
+
+// pendingFile names the report of a crash the next launch has not shown
+// yet.
+const pendingFile = "pending"
+
+var (
+	mu  sync.Mutex
+	dir string
+	// installed is set while the application runs under Install; panics are
+	// passed on untouched otherwise, as in tests.
+	installed bool
+	restore   func()
+	// reported is set once a report was written. The process is going down
+	// by then, and a panic passed on by one handler must not be reported
+	// again by the next.
+	reported bool
+)
+
+// Dir returns the directory crash reports are written to.
+func Dir() (string, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return reportDir()
+}
+
+func reportDir() (string, error) {
+	if dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".lgtmfaster", "crashes"), nil
+}
+
+// SetDir overrides the directory crash reports are written to.
+func SetDir(path string) {
+	mu.Lock()
+	defer mu.Unlock()
+	dir = path
+}
+
+// Install turns panics passed to Repanic and Guard into crash reports until
+// Uninstall. restore gives the terminal back to the shell before a panic
+// outside the UI loop ends the process.
+func Install(restoreTerminal func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	installed = true
+	restore = restoreTerminal
+}
+
+func Uninstall() {
+	mu.Lock()
+	defer mu.Unlock()
+	installed = false
+	restore = nil
+}
+
+func isInstalled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return installed
+}
+
+// Write records the panic value with its stack and returns the path of the
+// report. Only the first panic is recorded; later ones return "".
+func Write(value any, stack []byte) (string, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if reported {
+		return "", nil
+	}
+	reported = true
+
+	dir, err := reportDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create crash directory: %w", err)
+	}
+
+	now := time.Now()
+	var b strings.Builder
+	fmt.Fprintf(&b, "LGTMFaster %s (%s, built %s) crashed at %s\n", version.Version, version.GitCommit, version.BuildDate, now.Format(time.RFC3339))
+	fmt.Fprintf(&b, "%s %s/%s\n\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "panic: %v\n\n%s\n", value, stack)
+	b.WriteString("Recent log entries:\n")
+	for _, entry := range logger.GetLogs() {
+		fmt.Fprintf(&b, "%s %s\n", entry.Timestamp.Format("2006-01-02 15:04:05.000"), entry.Message)
+	}
+
+	path := filepath.Join(dir, "crash-"+now.Format("20060102-150405")+".log")
+	if err := os.WriteFile(path, []byte(b.String()), 0600); err != nil {
+		return "", fmt.Errorf("failed to write crash report: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, pendingFile), []byte(path), 0600); err != nil {
+		return path, fmt.Errorf("failed to record crash: %w", err)
+	}
+	return path, nil
+}
+
+// Repanic reports a panic recovered by the caller and panics again with the
+// same value, leaving the terminal to whoever recovers it next. Use it as
+//
+//	defer func() { crash.Repanic(recover()) }()
+func Repanic(value any) {
+	if value == nil {
+		return
+	}
+	if !isInstalled() {
+		panic(value)
+	}
+	if _, err := Write(value, debug.Stack()); err != nil {
+		logger.LogError("CRASH_REPORT", "", err)
+	}
+	panic(value)
+}
+
+// Guard ends the process cleanly when the goroutine it is deferred in
+// panics: the terminal is restored, the crash is reported and the process
+// exits. Use it as
+//
+//	defer crash.Guard()
+func Guard() {
+	value := recover()
+	if value == nil {
+		return
+	}
+	if !isInstalled() {
+		panic(value)
+	}
+	path, err := Write(value, debug.Stack())
+
+	mu.Lock()
+	fn := restore
+	mu.Unlock()
+	if fn != nil {
+		fn()
+	}
+
+	fmt.Fprintf(os.Stderr, "LGTMFaster crashed: %v\n", value)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write the crash report: %v\n", err)
+	} else if path != "" {
+		fmt.Fprintf(os.Stderr, "The crash report was saved to %s\n", path)
+	}
+	os.Exit(2)
+}
+
+// TakePending returns the path of the report of a crash not shown yet, or ""
+// when the last run did not crash. Each crash is returned once.
+func TakePending() string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	dir, err := reportDir()
+	if err != nil {
+		return ""
+	}
+	marker := filepath.Join(dir, pendingFile)
+	data, err := os.ReadFile(marker)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.LogError("CRASH_PENDING", marker, err)
+		}
+		return ""
+	}
+	if err := os.Remove(marker); err != nil {
+		logger.LogError("CRASH_PENDING", marker, err)
+	}
+	return strings.TrimSpace(string(data))
+}
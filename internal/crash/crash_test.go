@@ -0,0 +1,87 @@
+package crash
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+func useTestDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	SetDir(dir)
+	t.Cleanup(func() {
+		SetDir("")
+		Uninstall()
+		mu.Lock()
+		reported = false
+		mu.Unlock()
+	})
+	return dir
+}
+
+func TestWrite_ReportsPanicWithStackAndLogs(t *testing.T) {
+	dir := useTestDir(t)
+	logger.Log("opening PR 42")
+
+	path, err := Write("boom", []byte("goroutine 1 [running]:"))
+	if err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+	if filepath.Dir(path) != dir {
+		t.Errorf("Expected the report in %s, got %s", dir, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	for _, want := range []string{"panic: boom", "goroutine 1 [running]:", "opening PR 42"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected %q in the report:\n%s", want, data)
+		}
+	}
+
+	if again, err := Write("second", nil); err != nil || again != "" {
+		t.Errorf("Expected only the first panic to be reported, got %q (err %v)", again, err)
+	}
+
+	if pending := TakePending(); pending != path {
+		t.Errorf("Expected the crash to be pending, got %q", pending)
+	}
+	if pending := TakePending(); pending != "" {
+		t.Errorf("Expected the crash to be brought up once, got %q", pending)
+	}
+}
+
+func TestRepanic_ReportsOnlyWhenInstalled(t *testing.T) {
+	dir := useTestDir(t)
+
+	repanic := func() (value any) {
+		defer func() { value = recover() }()
+		func() {
+			defer func() { Repanic(recover()) }()
+			panic("boom")
+		}()
+		return nil
+	}
+
+	if value := repanic(); value != "boom" {
+		t.Fatalf("Expected the panic to be passed on, got %v", value)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Fatalf("Expected no report before Install, got %d file(s)", len(entries))
+	}
+
+	Install(nil)
+	if value := repanic(); value != "boom" {
+		t.Fatalf("Expected the panic to be passed on, got %v", value)
+	}
+	if TakePending() == "" {
+		t.Error("Expected the panic to be reported once installed")
+	}
+
+	Repanic(nil)
+}
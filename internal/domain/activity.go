@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+type ActivityType string
+
+const (
+	ActivityReviewSubmitted ActivityType = "review_submitted"
+	ActivityPRMerged        ActivityType = "pr_merged"
+	// ActivityReviewTime records the TimeInReview of a visit to a PR, when
+	// the review timer is on.
+	ActivityReviewTime ActivityType = "review_time"
+)
+
+type ActivityEvent struct {
+	Type         ActivityType
+	Provider     ProviderType
+	PRIdentifier string
+	Comments     int
+	TimeInReview time.Duration
+	Timestamp    time.Time
+}
@@ -0,0 +1,20 @@
+package domain
+
+// AnnotationSeverity is how serious a finding of a diff-analysis hook is.
+type AnnotationSeverity string
+
+const (
+	AnnotationError   AnnotationSeverity = "error"
+	AnnotationWarning AnnotationSeverity = "warning"
+	AnnotationInfo    AnnotationSeverity = "info"
+)
+
+// Annotation is a finding reported by a diff-analysis hook for a line of the
+// new version of a file. Source names the hook that reported it.
+type Annotation struct {
+	Source   string
+	Path     string
+	Line     int
+	Severity AnnotationSeverity
+	Message  string
+}
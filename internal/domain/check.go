@@ -0,0 +1,24 @@
+package domain
+
+// CheckFailure is a failing CI check of a pull request with the output it
+// reported, so that why it fails can be read without opening the CI system.
+// Conclusion is how the check ended, such as "failure" or "timed_out";
+// Summary is Markdown. URL links to the full log.
+type CheckFailure struct {
+	Name        string
+	Conclusion  string
+	Title       string
+	Summary     string
+	URL         string
+	Annotations []CheckAnnotation
+}
+
+// CheckAnnotation is a problem a check reported on a line of a file, such as
+// a failing test or a compile error.
+type CheckAnnotation struct {
+	Path     string
+	Line     int
+	Severity AnnotationSeverity
+	Title    string
+	Message  string
+}
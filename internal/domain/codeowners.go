@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CodeOwners holds the rules of a repository's CODEOWNERS file, and the
+// owner names that refer to the user: their @login and the @org/team names
+// of their teams.
+type CodeOwners struct {
+	Rules      []CodeOwnersRule
+	Identities []string
+}
+
+// CodeOwnersRule assigns Owners to the files matching Pattern, which uses
+// the gitignore syntax CODEOWNERS files use. A rule with no owners leaves
+// its files unowned.
+type CodeOwnersRule struct {
+	Pattern string
+	Owners  []string
+	pattern *regexp.Regexp
+}
+
+// ParseCodeOwners parses the rules of a CODEOWNERS file, skipping comments
+// and lines it cannot parse.
+func ParseCodeOwners(content string) []CodeOwnersRule {
+	var rules []CodeOwnersRule
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 && (i == 0 || line[i-1] != '\\') {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		pattern, err := codeOwnersPattern(fields[0])
+		if err != nil {
+			continue
+		}
+		rules = append(rules, CodeOwnersRule{Pattern: fields[0], Owners: fields[1:], pattern: pattern})
+	}
+	return rules
+}
+
+// codeOwnersPattern compiles a gitignore pattern: one containing a slash
+// other than a trailing one is anchored at the root, one without matches at
+// any depth, and a pattern matching a directory matches everything in it.
+func codeOwnersPattern(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "/**") && i+3 == len(pattern):
+			b.WriteString("/.*")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			i++
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	if dirOnly {
+		b.WriteString("/.*$")
+	} else {
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
+
+// Owners returns the owners of path, as given by the last rule matching it.
+func (c *CodeOwners) Owners(path string) []string {
+	if c == nil {
+		return nil
+	}
+	path = strings.TrimPrefix(path, "/")
+	for i := len(c.Rules) - 1; i >= 0; i-- {
+		rule := c.Rules[i]
+		if rule.pattern == nil {
+			compiled, err := codeOwnersPattern(rule.Pattern)
+			if err != nil {
+				continue
+			}
+			rule.pattern = compiled
+			c.Rules[i].pattern = compiled
+		}
+		if rule.pattern.MatchString(path) {
+			if len(rule.Owners) == 0 {
+				return nil
+			}
+			return rule.Owners
+		}
+	}
+	return nil
+}
+
+// Owns reports whether one of the owners of path refers to the user.
+// Owner names are compared case-insensitively.
+func (c *CodeOwners) Owns(path string) bool {
+	for _, owner := range c.Owners(path) {
+		for _, identity := range c.Identities {
+			if strings.EqualFold(owner, identity) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCodeOwners_LastMatchingRuleWins(t *testing.T) {
+	owners := CodeOwners{
+		Rules: ParseCodeOwners(`# Default owners
+*                 @acme/core
+*.js              @acme/frontend @dana
+/docs/            @acme/writers
+apps/             @acme/apps
+/build/**/out     @ci-bot
+internal/api/*.go @erik # handlers only
+/vendor/
+`),
+		Identities: []string{"@Dana", "@acme/writers"},
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"main.go", []string{"@acme/core"}},
+		{"web/app.js", []string{"@acme/frontend", "@dana"}},
+		{"docs/guide/intro.md", []string{"@acme/writers"}},
+		{"src/docs/notes.md", []string{"@acme/core"}},
+		{"apps/mobile/main.go", []string{"@acme/apps"}},
+		{"services/apps/worker.go", []string{"@acme/apps"}},
+		{"build/linux/amd64/out", []string{"@ci-bot"}},
+		{"build/out", []string{"@ci-bot"}},
+		{"internal/api/users.go", []string{"@erik"}},
+		{"internal/api/v2/users.go", []string{"@acme/core"}},
+		{"vendor/lib/lib.go", nil},
+	}
+	for _, tt := range tests {
+		if got := owners.Owners(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Owners(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+
+	if !owners.Owns("web/app.js") || !owners.Owns("docs/guide/intro.md") {
+		t.Error("expected the files owned by @dana and @acme/writers to be the user's")
+	}
+	if owners.Owns("main.go") || owners.Owns("vendor/lib/lib.go") {
+		t.Error("expected files owned by others or unowned not to be the user's")
+	}
+}
+
+func TestCodeOwners_NilHasNoOwners(t *testing.T) {
+	var owners *CodeOwners
+	if owners.Owners("main.go") != nil || owners.Owns("main.go") {
+		t.Error("expected no owners without a CODEOWNERS file")
+	}
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DependencyUpdate describes a pull request opened by a dependency bot such
+// as Dependabot or Renovate. From is empty when the title only names the new
+// version, and both versions are empty for grouped updates.
+type DependencyUpdate struct {
+	Bot          string
+	Package      string
+	From         string
+	To           string
+	ChangelogURL string
+}
+
+var (
+	// "Bump lodash from 4.17.20 to 4.17.21 in /web", optionally prefixed
+	// with a conventional commit type such as "build(deps): ".
+	dependabotTitle = regexp.MustCompile(`(?i)\bbump (\S+) from v?(\S+) to v?(\S+)`)
+	// "Bump the npm_and_yarn group ..." groups several updates in one PR.
+	dependabotGroupTitle = regexp.MustCompile(`(?i)\bbump the (\S+) group\b`)
+	// "Update dependency lodash to v4.17.21", "Update module golang.org/x/net
+	// to v0.20.0", "Update actions/checkout action to v4" or "Update golang
+	// Docker tag to v1.22".
+	renovateTitle = regexp.MustCompile(`(?i)\bupdate (?:dependency |module |rust crate |(?:[\w.-]+ )?)?(\S+?)(?: docker tag| digest| action)? to v?(\S+)`)
+	// "Update dependency lodash from 4.17.20 to v4.17.21", used when
+	// Renovate is configured to show the current version.
+	renovateFromTitle = regexp.MustCompile(`(?i)\bupdate (?:dependency |module )?(\S+) from v?(\S+) to v?(\S+)`)
+
+	markdownLink = regexp.MustCompile(`\[([^\]]+)\]\((https?://[^)\s]+)\)`)
+)
+
+// dependencyBots maps the accounts of dependency bots, lowercased, to the
+// bot's name: the GitHub app accounts, and the display names the bots are
+// usually set up with on Azure DevOps, where they run as service accounts.
+var dependencyBots = map[string]string{
+	"dependabot[bot]":         "Dependabot",
+	"dependabot-preview[bot]": "Dependabot",
+	"dependabot":              "Dependabot",
+	"renovate[bot]":           "Renovate",
+	"renovate bot":            "Renovate",
+	"renovate":                "Renovate",
+}
+
+// DependencyBot returns the name of the dependency bot that authored pr, or
+// "" when it was not opened by one or its title is not that of a dependency
+// update.
+func DependencyBot(pr PullRequest) string {
+	if update, ok := ParseDependencyUpdate(pr); ok {
+		return update.Bot
+	}
+	return ""
+}
+
+// ParseDependencyUpdate reports whether pr is a dependency update, opened by
+// a dependency bot with a title naming the updated package, and extracts the
+// package and versions from its title and the changelog link from its
+// description.
+func ParseDependencyUpdate(pr PullRequest) (DependencyUpdate, bool) {
+	bot := dependencyBots[strings.ToLower(pr.Author.Username)]
+	if bot == "" {
+		return DependencyUpdate{}, false
+	}
+
+	update := DependencyUpdate{Bot: bot}
+	if m := dependabotTitle.FindStringSubmatch(pr.Title); m != nil {
+		update.Package, update.From, update.To = m[1], m[2], m[3]
+	} else if m := renovateFromTitle.FindStringSubmatch(pr.Title); m != nil {
+		update.Package, update.From, update.To = m[1], m[2], m[3]
+	} else if m := dependabotGroupTitle.FindStringSubmatch(pr.Title); m != nil {
+		update.Package = m[1] + " group"
+	} else if m := renovateTitle.FindStringSubmatch(pr.Title); m != nil {
+		update.Package, update.To = m[1], m[2]
+	} else {
+		return DependencyUpdate{}, false
+	}
+	update.ChangelogURL = changelogURL(pr.Description)
+	return update, true
+}
+
+// changelogURL picks the most specific release notes link of a bot PR
+// description: a changelog first, then release notes, then a commit range.
+func changelogURL(description string) string {
+	links := markdownLink.FindAllStringSubmatch(description, -1)
+	for _, keywords := range [][]string{{"changelog"}, {"release"}, {"commits", "compare"}} {
+		for _, link := range links {
+			text := strings.ToLower(link[1] + " " + link[2])
+			for _, keyword := range keywords {
+				if strings.Contains(text, keyword) {
+					return link[2]
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+package domain
+
+import "testing"
+
+func TestParseDependencyUpdate(t *testing.T) {
+	tests := []struct {
+		author, title      string
+		pkg, from, to, bot string
+	}{
+		{"dependabot[bot]", "Bump lodash from 4.17.20 to 4.17.21 in /web", "lodash", "4.17.20", "4.17.21", "Dependabot"},
+		{"dependabot[bot]", "build(deps): bump golang.org/x/net from 0.17.0 to 0.23.0", "golang.org/x/net", "0.17.0", "0.23.0", "Dependabot"},
+		{"dependabot[bot]", "Bump the npm_and_yarn group across 1 directory with 3 updates", "npm_and_yarn group", "", "", "Dependabot"},
+		{"renovate[bot]", "Update dependency lodash to v4.17.21", "lodash", "", "4.17.21", "Renovate"},
+		{"renovate[bot]", "chore(deps): update module github.com/spf13/cobra to v1.8.1", "github.com/spf13/cobra", "", "1.8.1", "Renovate"},
+		{"renovate[bot]", "Update golang Docker tag to v1.22", "golang", "", "1.22", "Renovate"},
+		{"renovate[bot]", "Update actions/checkout action to v4", "actions/checkout", "", "4", "Renovate"},
+		{"Renovate Bot", "Update dependency react from 17.0.2 to v18.2.0", "react", "17.0.2", "18.2.0", "Renovate"},
+	}
+
+	for _, tt := range tests {
+		update, ok := ParseDependencyUpdate(PullRequest{Title: tt.title, Author: User{Username: tt.author}})
+		if !ok {
+			t.Errorf("%q: expected a dependency update", tt.title)
+			continue
+		}
+		if update.Package != tt.pkg || update.From != tt.from || update.To != tt.to || update.Bot != tt.bot {
+			t.Errorf("%q: got %+v, want package=%q from=%q to=%q bot=%q", tt.title, update, tt.pkg, tt.from, tt.to, tt.bot)
+		}
+	}
+}
+
+func TestParseDependencyUpdate_NotABot(t *testing.T) {
+	for _, author := range []string{"alice", "jrenovate", "dependabot-fan"} {
+		if _, ok := ParseDependencyUpdate(PullRequest{Title: "Bump lodash from 1.0.0 to 2.0.0", Author: User{Username: author}}); ok {
+			t.Errorf("%q: expected PRs by people not to be treated as dependency updates", author)
+		}
+	}
+}
+
+func TestParseDependencyUpdate_NotAnUpdateTitle(t *testing.T) {
+	for _, title := range []string{"Lock file maintenance", "Configure Renovate", "Fix flaky test"} {
+		pr := PullRequest{Title: title, Author: User{Username: "renovate[bot]"}}
+		if _, ok := ParseDependencyUpdate(pr); ok {
+			t.Errorf("%q: expected a bot PR without an update title not to be a dependency update", title)
+		}
+		if bot := DependencyBot(pr); bot != "" {
+			t.Errorf("%q: expected no dependency bot, got %q", title, bot)
+		}
+	}
+}
+
+func TestParseDependencyUpdate_ChangelogURL(t *testing.T) {
+	description := `Bumps [lodash](https://github.com/lodash/lodash) from 4.17.20 to 4.17.21.
+<details>
+- [Commits](https://github.com/lodash/lodash/compare/4.17.20...4.17.21)
+- [Release notes](https://github.com/lodash/lodash/releases)
+- [Changelog](https://github.com/lodash/lodash/blob/main/CHANGELOG.md)
+</details>`
+
+	update, _ := ParseDependencyUpdate(PullRequest{
+		Title:       "Bump lodash from 4.17.20 to 4.17.21",
+		Description: description,
+		Author:      User{Username: "dependabot[bot]"},
+	})
+
+	if want := "https://github.com/lodash/lodash/blob/main/CHANGELOG.md"; update.ChangelogURL != want {
+		t.Errorf("expected changelog %q, got %q", want, update.ChangelogURL)
+	}
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"net/url"
+	"time"
+)
+
+// DeploymentState is the state of the latest status of a deployment.
+type DeploymentState string
+
+const (
+	DeploymentSuccess    DeploymentState = "success"
+	DeploymentInProgress DeploymentState = "in_progress"
+	DeploymentQueued     DeploymentState = "queued"
+	DeploymentPending    DeploymentState = "pending"
+	DeploymentFailure    DeploymentState = "failure"
+	DeploymentError      DeploymentState = "error"
+	DeploymentInactive   DeploymentState = "inactive"
+)
+
+// Deployment is the latest deployment of a pull request's changes to an
+// environment, such as a preview. URL is where the environment can be
+// visited, empty when the deployment does not say.
+type Deployment struct {
+	Environment string
+	State       DeploymentState
+	URL         string
+	UpdatedAt   time.Time
+}
+
+// IsActive reports whether the deployment succeeded and can be visited. Only
+// web URLs can be visited; the URL comes from whatever tool reported the
+// deployment and is opened in a browser.
+func (d Deployment) IsActive() bool {
+	if d.State != DeploymentSuccess {
+		return false
+	}
+	u, err := url.Parse(d.URL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
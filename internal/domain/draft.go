@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// EditorDraft is the text of an editor that was left without sending it.
+// Key names the PR and the editor, such as the review or the inline comment
+// on one line. PRKey, PRTitle and Editor describe it when drafts are listed.
+type EditorDraft struct {
+	Key     string
+	PRKey   string
+	PRTitle string
+	Editor  string
+	Body    string
+	SavedAt time.Time
+}
+
+// DraftStore is implemented by repositories that keep unsent editor text, so
+// that a crash or an accidental Esc does not lose it.
+type DraftStore interface {
+	// SaveDraft replaces the draft with the same key. An empty body deletes
+	// it.
+	SaveDraft(draft EditorDraft) error
+
+	// GetDraft returns nil when there is no draft for key.
+	GetDraft(key string) (*EditorDraft, error)
+
+	DeleteDraft(key string) error
+
+	// ListDrafts returns the drafts, most recently saved first.
+	ListDrafts() ([]EditorDraft, error)
+}
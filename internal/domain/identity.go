@@ -0,0 +1,27 @@
+package domain
+
+import "strings"
+
+// Identity is the user a provider's credentials authenticate as. Username is
+// the name pull requests are matched against to tell the ones the user
+// authored or was asked to review from the rest; Aliases are other names
+// that match the same user.
+type Identity struct {
+	Username string
+	Aliases  []string
+}
+
+// Matches reports whether name, as entered for a PAT, refers to this
+// identity. Names are compared case-insensitively.
+func (i Identity) Matches(name string) bool {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return false
+	}
+	for _, candidate := range append([]string{i.Username}, i.Aliases...) {
+		if strings.EqualFold(candidate, name) {
+			return true
+		}
+	}
+	return false
+}
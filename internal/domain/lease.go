@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// PRLease marks a pull request as open in one lgtmfaster instance. Leases
+// are refreshed while the PR stays open and expire on their own if the
+// instance goes away without releasing them.
+type PRLease struct {
+	PRIdentifier  string
+	Hostname      string
+	PID           int
+	PendingDrafts int
+	UpdatedAt     time.Time
+}
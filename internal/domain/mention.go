@@ -0,0 +1,34 @@
+package domain
+
+import "strings"
+
+// MentionCandidate is a user that can be @-mentioned in a comment. Text is
+// the markup that mentions them, which differs per provider: "@login" on
+// GitHub and "@<identity id>" on Azure DevOps.
+type MentionCandidate struct {
+	User User
+	Text string
+}
+
+// MentionCandidates collects candidates in the order they are added, keeping
+// the first occurrence of each mention.
+type MentionCandidates struct {
+	candidates []MentionCandidate
+	seen       map[string]bool
+}
+
+func (c *MentionCandidates) Add(user User, text string) {
+	key := strings.ToLower(text)
+	if text == "" || c.seen[key] {
+		return
+	}
+	if c.seen == nil {
+		c.seen = make(map[string]bool)
+	}
+	c.seen[key] = true
+	c.candidates = append(c.candidates, MentionCandidate{User: user, Text: text})
+}
+
+func (c *MentionCandidates) List() []MentionCandidate {
+	return c.candidates
+}
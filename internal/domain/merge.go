@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeRequirements describes what the target branch demands before a pull
+// request can be merged and how much of it is already satisfied.
+// RequiredApprovals is zero when no approvals are required. FailingChecks and
+// PendingChecks name the checks that block the merge. AllowedMethods lists the
+// merge methods the repository accepts and is nil when that is unknown.
+// Blockers holds any other unmet requirement in readable form.
+type MergeRequirements struct {
+	Protected         bool
+	RequiredApprovals int
+	Approvals         int
+	FailingChecks     []string
+	PendingChecks     []string
+	AllowedMethods    []string
+	Blockers          []string
+}
+
+// Unmet returns the requirements that would currently reject a merge, e.g.
+// "needs 1 more approval" or "CI failing: build".
+func (r MergeRequirements) Unmet() []string {
+	var unmet []string
+	if missing := r.RequiredApprovals - r.Approvals; missing > 0 {
+		noun := "approval"
+		if missing > 1 {
+			noun = "approvals"
+		}
+		unmet = append(unmet, fmt.Sprintf("needs %d more %s", missing, noun))
+	}
+	if len(r.FailingChecks) > 0 {
+		unmet = append(unmet, "CI failing: "+strings.Join(r.FailingChecks, ", "))
+	}
+	if len(r.PendingChecks) > 0 {
+		unmet = append(unmet, "waiting for checks: "+strings.Join(r.PendingChecks, ", "))
+	}
+	return append(unmet, r.Blockers...)
+}
+
+// MethodAllowed reports whether the repository accepts the given merge method.
+func (r MergeRequirements) MethodAllowed(method string) bool {
+	if r.AllowedMethods == nil {
+		return true
+	}
+	for _, allowed := range r.AllowedMethods {
+		if allowed == method {
+			return true
+		}
+	}
+	return false
+}
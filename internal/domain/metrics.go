@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// EndpointMetrics summarizes the calls made to one API endpoint: how many
+// were made and failed, and the median, 95th percentile and slowest of their
+// latencies.
+type EndpointMetrics struct {
+	Endpoint string
+	Calls    int
+	Failures int
+	P50      time.Duration
+	P95      time.Duration
+	Max      time.Duration
+}
@@ -1,12 +1,17 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 type ProviderType string
 
 const (
 	ProviderGitHub      ProviderType = "github"
 	ProviderAzureDevOps ProviderType = "azuredevops"
+	ProviderGerrit      ProviderType = "gerrit"
 )
 
 type ReviewAction string
@@ -27,10 +32,14 @@ const (
 
 type PRCategory string
 
+// PRCategoryReviewRequested marks PRs where the user's review is explicitly
+// requested and not yet given. PRs the user is a reviewer on but has already
+// voted on stay PRCategoryAssigned.
 const (
-	PRCategoryAuthored PRCategory = "authored"
-	PRCategoryAssigned PRCategory = "assigned"
-	PRCategoryOther    PRCategory = "other"
+	PRCategoryReviewRequested PRCategory = "review_requested"
+	PRCategoryAuthored        PRCategory = "authored"
+	PRCategoryAssigned        PRCategory = "assigned"
+	PRCategoryOther           PRCategory = "other"
 )
 
 type ApprovalStatus string
@@ -66,6 +75,16 @@ type Repo struct {
 	URL      string
 }
 
+// RepositoryOwner returns the organization (GitHub) or project (Azure DevOps)
+// of the repository, falling back to the first FullName segment.
+func (r Repo) RepositoryOwner() string {
+	if r.Owner != "" {
+		return r.Owner
+	}
+	owner, _, _ := strings.Cut(r.FullName, "/")
+	return owner
+}
+
 type PullRequest struct {
 	ID             string
 	Number         int
@@ -85,6 +104,22 @@ type PullRequest struct {
 	Mergeable      bool
 	ProviderType   ProviderType
 	PATID          string
+	// Labels are the names of the PR's labels (GitHub) or tags (Azure
+	// DevOps).
+	Labels []string `json:",omitempty"`
+	// HeadSHA is the commit the PR's changes end at (the current patch set
+	// on Gerrit), when the provider reports it. A new one means new changes.
+	HeadSHA string `json:",omitempty"`
+}
+
+// Key identifies the pull request across providers and PATs.
+func (pr PullRequest) Key() string {
+	return fmt.Sprintf("%s:%s/%d", pr.ProviderType, pr.Repository.FullName, pr.Number)
+}
+
+// RepositoryKey identifies the repository of the PR across providers.
+func (pr PullRequest) RepositoryKey() string {
+	return fmt.Sprintf("%s:%s", pr.ProviderType, pr.Repository.FullName)
 }
 
 type Comment struct {
@@ -96,6 +131,13 @@ type Comment struct {
 	FilePath  string
 	Line      int
 	Side      string
+	// ThreadID is set by providers whose comment IDs are only unique within
+	// a thread (Azure DevOps).
+	ThreadID  string
+	Reactions []Reaction
+	// ThreadStatus is the status of the comment's thread, on providers
+	// that track it.
+	ThreadStatus ThreadStatus `json:",omitempty"`
 }
 
 type DiffLine struct {
@@ -120,6 +162,23 @@ type FileDiff struct {
 	IsRenamed bool
 	Hunks     []DiffHunk
 	Comments  []Comment
+	// Binary is set for images and other files without a line diff.
+	Binary *BinaryInfo
+	// Additions and Deletions count the changed lines of a file listed
+	// before its hunks were loaded, when the provider reports them.
+	Additions int `json:",omitempty"`
+	Deletions int `json:",omitempty"`
+	// Deferred is set while the hunks of the file are yet to be loaded,
+	// see FileDiffLoader.
+	Deferred bool `json:",omitempty"`
+}
+
+// BinaryInfo describes a changed binary file. Sizes are in bytes and -1 when
+// unknown; BlobURL points at the new (or, for deletions, old) content.
+type BinaryInfo struct {
+	OldSize int64
+	NewSize int64
+	BlobURL string
 }
 
 type Diff struct {
@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// CachedPRGroup is the last PR list loaded with a PAT.
+type CachedPRGroup struct {
+	Group     PRGroup
+	FetchedAt time.Time
+}
+
+// CachedPR is the last detail, diff and comments loaded for a pull request.
+// Each part is nil until it has been loaded once.
+type CachedPR struct {
+	PR             *PullRequest
+	Diff           *Diff
+	Comments       []Comment
+	CommentsLoaded bool
+	FetchedAt      time.Time
+}
+
+// QueuedReview is a review in the outbox: one whose submission failed, kept
+// until it is sent or discarded. Reviews that failed because the provider was
+// unreachable are sent again automatically once it is reachable; others wait
+// for the user to retry them. Activity is recorded once the review is sent.
+type QueuedReview struct {
+	ID              string
+	PATID           string
+	Identifier      PRIdentifier
+	Review          Review
+	Activity        ActivityEvent
+	QueuedAt        time.Time
+	Attempts        int
+	LastError       string
+	RetryWhenOnline bool
+}
+
+// OfflineStore is implemented by repositories that keep the data needed to
+// keep working while the providers cannot be reached: the last loaded PR
+// lists, PR details, diffs and comments, and the outbox of reviews waiting
+// to be sent.
+type OfflineStore interface {
+	SaveCachedPRGroup(group PRGroup, fetchedAt time.Time) error
+
+	// GetCachedPRGroup returns nil when nothing is cached for the PAT.
+	GetCachedPRGroup(patID string) (*CachedPRGroup, error)
+
+	SaveCachedPR(key string, pr PullRequest, fetchedAt time.Time) error
+
+	SaveCachedDiff(key string, diff *Diff, fetchedAt time.Time) error
+
+	SaveCachedComments(key string, comments []Comment, fetchedAt time.Time) error
+
+	// GetCachedPR returns nil when nothing is cached for the PR, which is
+	// identified by PullRequest.Key.
+	GetCachedPR(key string) (*CachedPR, error)
+
+	QueueReview(review QueuedReview) error
+
+	// UpdateQueuedReview replaces the queued review with the same ID.
+	UpdateQueuedReview(review QueuedReview) error
+
+	ListQueuedReviews() ([]QueuedReview, error)
+
+	RemoveQueuedReview(id string) error
+}
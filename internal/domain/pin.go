@@ -0,0 +1,26 @@
+package domain
+
+import "slices"
+
+// Pins are the repositories and pull requests whose PRs are listed first.
+// Repositories are identified by PullRequest.RepositoryKey and pull requests
+// by PullRequest.Key.
+type Pins struct {
+	Repositories []string
+	PullRequests []string
+}
+
+// RepositoryPinned reports whether the repository of pr is pinned.
+func (p Pins) RepositoryPinned(pr PullRequest) bool {
+	return slices.Contains(p.Repositories, pr.RepositoryKey())
+}
+
+// PRPinned reports whether pr itself is pinned.
+func (p Pins) PRPinned(pr PullRequest) bool {
+	return slices.Contains(p.PullRequests, pr.Key())
+}
+
+// IsPinned reports whether pr or its repository is pinned.
+func (p Pins) IsPinned(pr PullRequest) bool {
+	return p.PRPinned(pr) || p.RepositoryPinned(pr)
+}
@@ -0,0 +1,34 @@
+package domain
+
+// PolicyStatus is the outcome of evaluating a branch policy against a pull
+// request.
+type PolicyStatus string
+
+const (
+	PolicyApproved PolicyStatus = "approved"
+	PolicyRejected PolicyStatus = "rejected"
+	PolicyRunning  PolicyStatus = "running"
+	PolicyQueued   PolicyStatus = "queued"
+	PolicyBroken   PolicyStatus = "broken"
+)
+
+// PolicyEvaluation is a branch policy applied to a pull request, such as a
+// build, the required reviewers or comment resolution. Detail says what
+// the policy is waiting for when known, e.g. "1 of 2 approvals". Optional
+// policies never block the merge.
+type PolicyEvaluation struct {
+	Name     string
+	Status   PolicyStatus
+	Optional bool
+	Detail   string
+}
+
+// Passed reports whether the policy is satisfied.
+func (e PolicyEvaluation) Passed() bool {
+	return e.Status == PolicyApproved
+}
+
+// Failed reports whether the policy rejects the pull request.
+func (e PolicyEvaluation) Failed() bool {
+	return e.Status == PolicyRejected || e.Status == PolicyBroken
+}
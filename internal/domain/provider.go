@@ -13,7 +13,7 @@ type Provider interface {
 
 	GetComments(ctx context.Context, identifier PRIdentifier) ([]Comment, error)
 
-	AddComment(ctx context.Context, identifier PRIdentifier, body string, filePath string, line int) error
+	AddComment(ctx context.Context, identifier PRIdentifier, body string, filePath string, line int, side string) error
 
 	SubmitReview(ctx context.Context, review Review) error
 
@@ -23,3 +23,160 @@ type Provider interface {
 
 	ValidateCredentials(ctx context.Context) error
 }
+
+// PRSearcher is implemented by providers that can search pull requests beyond
+// the ones the user is involved in, using the provider's own query syntax.
+type PRSearcher interface {
+	SearchPullRequests(ctx context.Context, query string, username string) ([]PullRequest, error)
+}
+
+// RepositoryPRLister is implemented by providers that can list the open pull
+// requests of any repository, not just the ones the user is involved in.
+type RepositoryPRLister interface {
+	ListRepositoryPullRequests(ctx context.Context, repository string, username string) ([]PullRequest, error)
+}
+
+// AuthorPRLister is implemented by providers that can list the open pull
+// requests opened by another user, with their approval status.
+type AuthorPRLister interface {
+	ListAuthorPullRequests(ctx context.Context, author string, username string) ([]PullRequest, error)
+}
+
+// InvolvedSearcher is implemented by providers that can search the text of
+// the pull requests the user is involved in, their comments included.
+type InvolvedSearcher interface {
+	SearchInvolvedPullRequests(ctx context.Context, text string, username string) ([]PullRequest, error)
+}
+
+// ReviewerLister is implemented by providers that expose the review state of
+// each reviewer of a pull request.
+type ReviewerLister interface {
+	ListReviewers(ctx context.Context, identifier PRIdentifier) ([]Reviewer, error)
+}
+
+// ReviewManager is implemented by providers that can also dismiss a review or
+// ask a reviewer to review again.
+type ReviewManager interface {
+	ReviewerLister
+	DismissReview(ctx context.Context, identifier PRIdentifier, reviewID string, message string) error
+	RequestReview(ctx context.Context, identifier PRIdentifier, username string) error
+}
+
+// MergeRequirementsChecker is implemented by providers that can report the
+// branch protection and policy requirements a pull request must meet before
+// it can be merged.
+type MergeRequirementsChecker interface {
+	GetMergeRequirements(ctx context.Context, identifier PRIdentifier) (*MergeRequirements, error)
+}
+
+// ConflictLister is implemented by providers that can name the files that
+// keep a pull request from merging cleanly.
+type ConflictLister interface {
+	ListConflictFiles(ctx context.Context, identifier PRIdentifier) ([]string, error)
+}
+
+// CommentReactor is implemented by providers that let users react to
+// comments. Reactions lists the supported reaction contents in the order
+// they are offered.
+type CommentReactor interface {
+	Reactions() []string
+	AddReaction(ctx context.Context, identifier PRIdentifier, comment Comment, content string) error
+}
+
+// ThreadStatusSetter is implemented by providers whose comment threads have
+// a status, such as Azure DevOps. ThreadStatuses lists the statuses a thread
+// can be set to, in the order they are offered.
+type ThreadStatusSetter interface {
+	ThreadStatuses() []ThreadStatus
+	SetThreadStatus(ctx context.Context, identifier PRIdentifier, comment Comment, status ThreadStatus) error
+}
+
+// Mentioner is implemented by providers that can suggest users to @-mention
+// in the comments of a pull request: its participants first, then other
+// recent collaborators on the repository.
+type Mentioner interface {
+	ListMentionCandidates(ctx context.Context, identifier PRIdentifier) ([]MentionCandidate, error)
+}
+
+// AttachmentUploader is implemented by providers that can host files
+// attached to pull request comments. It returns the URL of the upload.
+type AttachmentUploader interface {
+	UploadAttachment(ctx context.Context, identifier PRIdentifier, fileName string, content []byte) (string, error)
+}
+
+// IdentityResolver is implemented by providers that can look up the user
+// their credentials authenticate as.
+type IdentityResolver interface {
+	ResolveIdentity(ctx context.Context) (Identity, error)
+}
+
+// FileDiffLoader is implemented by providers that can list the files a pull
+// request changes without their diffs, and then load the diff of one file at
+// a time. Large pull requests are loaded this way, so that opening one does
+// not wait for every file.
+type FileDiffLoader interface {
+	// ListDiffFiles returns the changed files with Deferred set and no hunks.
+	ListDiffFiles(ctx context.Context, identifier PRIdentifier) ([]FileDiff, error)
+	// GetFileDiff loads one of the files ListDiffFiles returned.
+	GetFileDiff(ctx context.Context, identifier PRIdentifier, file FileDiff) (*FileDiff, error)
+}
+
+// ProgressiveLister is implemented by providers that list pull requests in
+// several requests and can report how far along they are, so that listing a
+// long list does not seem stuck.
+type ProgressiveLister interface {
+	// ListPullRequestsWithProgress is ListPullRequests calling progress with
+	// the number of PRs listed so far and the number expected.
+	ListPullRequestsWithProgress(ctx context.Context, username string, progress func(listed, total int)) ([]PullRequest, error)
+}
+
+// CommentPage is one page of the comments of a pull request. Next is the
+// cursor of the following page, "" after the last, and Total counts the
+// comments of all pages, or is zero when the provider cannot tell.
+type CommentPage struct {
+	Comments []Comment
+	Next     string
+	Total    int
+}
+
+// CommentPager is implemented by providers that can load the comments of a
+// pull request a page at a time, so that the first comments show while the
+// rest are still loading.
+type CommentPager interface {
+	// GetCommentsPage returns the page at cursor, "" for the first page.
+	GetCommentsPage(ctx context.Context, identifier PRIdentifier, cursor string) (*CommentPage, error)
+}
+
+// CodeOwnersReader is implemented by providers that can read the CODEOWNERS
+// file of a pull request's repository, as of its target branch. It returns
+// nil when the repository has none.
+type CodeOwnersReader interface {
+	GetCodeOwners(ctx context.Context, identifier PRIdentifier) (*CodeOwners, error)
+}
+
+// PolicyEvaluator is implemented by providers with branch policies, such as
+// Azure DevOps, to report how each policy evaluates against a pull request.
+// Policies that do not apply to the pull request are left out.
+type PolicyEvaluator interface {
+	GetPolicyEvaluations(ctx context.Context, identifier PRIdentifier) ([]PolicyEvaluation, error)
+}
+
+// FileContentReader is implemented by providers that can read a file of a
+// pull request's repository at a commit, such as its head commit.
+type FileContentReader interface {
+	GetFileContent(ctx context.Context, identifier PRIdentifier, path, ref string) (string, error)
+}
+
+// DeploymentLister is implemented by providers that track deployments, such
+// as GitHub, to list the environments a pull request's head commit is
+// deployed to, newest first.
+type DeploymentLister interface {
+	ListDeployments(ctx context.Context, identifier PRIdentifier) ([]Deployment, error)
+}
+
+// CheckOutputReader is implemented by providers that keep the output of CI
+// checks, such as GitHub's checks API, to list the failing checks of a pull
+// request's head commit with what they reported.
+type CheckOutputReader interface {
+	GetFailingChecks(ctx context.Context, identifier PRIdentifier) ([]CheckFailure, error)
+}
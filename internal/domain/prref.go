@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PRReference points at a pull request that was given as a URL or as
+// "owner/repo/123". Provider is empty when the reference does not tell which
+// provider it belongs to, and Organization is only known for Azure DevOps
+// URLs. Repository has the same "owner/repo" or "project/repo" form as
+// Repo.FullName.
+type PRReference struct {
+	Provider     ProviderType
+	Organization string
+	Repository   string
+	Number       int
+}
+
+// ParsePRReference accepts GitHub pull request URLs (including GitHub
+// Enterprise hosts), Azure DevOps pull request URLs on dev.azure.com or
+// visualstudio.com, Gerrit change URLs, and the shorthands "owner/repo/123"
+// and "owner/repo#123".
+func ParsePRReference(input string) (PRReference, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return PRReference{}, fmt.Errorf("empty pull request reference")
+	}
+
+	if strings.Contains(input, "://") {
+		return parsePRURL(input)
+	}
+
+	shorthand := strings.Replace(input, "#", "/", 1)
+	parts := strings.Split(shorthand, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return PRReference{}, fmt.Errorf("expected a pull request URL or owner/repo/123, got %q", input)
+	}
+	number, err := parsePRNumber(parts[2])
+	if err != nil {
+		return PRReference{}, err
+	}
+	return PRReference{Repository: parts[0] + "/" + parts[1], Number: number}, nil
+}
+
+func parsePRURL(input string) (PRReference, error) {
+	u, err := url.Parse(input)
+	if err != nil {
+		return PRReference{}, fmt.Errorf("invalid pull request URL: %w", err)
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "dev.azure.com":
+		if len(segments) > 0 {
+			return parseAzureDevOpsPRPath(segments[0], segments[1:], input)
+		}
+	case strings.HasSuffix(host, ".visualstudio.com"):
+		organization := strings.TrimSuffix(host, ".visualstudio.com")
+		if len(segments) > 0 && strings.EqualFold(segments[0], "DefaultCollection") {
+			segments = segments[1:]
+		}
+		return parseAzureDevOpsPRPath(organization, segments, input)
+	case len(segments) >= 4 && segments[0] == "c" && slices.Contains(segments, "+"):
+		// Gerrit: /c/{project}/+/{number}, where the project may contain
+		// slashes.
+		plus := slices.Index(segments, "+")
+		if plus < 2 || plus+1 >= len(segments) {
+			break
+		}
+		number, err := parsePRNumber(segments[plus+1])
+		if err != nil {
+			return PRReference{}, err
+		}
+		return PRReference{Provider: ProviderGerrit, Repository: strings.Join(segments[1:plus], "/"), Number: number}, nil
+	default:
+		// github.com/owner/repo/pull/123, possibly followed by /files etc.
+		if len(segments) >= 4 && segments[2] == "pull" {
+			number, err := parsePRNumber(segments[3])
+			if err != nil {
+				return PRReference{}, err
+			}
+			return PRReference{Provider: ProviderGitHub, Repository: segments[0] + "/" + segments[1], Number: number}, nil
+		}
+	}
+
+	return PRReference{}, fmt.Errorf("not a pull request URL: %s", input)
+}
+
+// parseAzureDevOpsPRPath reads "{project}/_git/{repo}/pullrequest/{id}".
+func parseAzureDevOpsPRPath(organization string, segments []string, input string) (PRReference, error) {
+	if len(segments) < 5 || segments[1] != "_git" || !strings.EqualFold(segments[3], "pullrequest") {
+		return PRReference{}, fmt.Errorf("not a pull request URL: %s", input)
+	}
+	number, err := parsePRNumber(segments[4])
+	if err != nil {
+		return PRReference{}, err
+	}
+	return PRReference{
+		Provider:     ProviderAzureDevOps,
+		Organization: organization,
+		Repository:   segments[0] + "/" + segments[2],
+		Number:       number,
+	}, nil
+}
+
+func parsePRNumber(s string) (int, error) {
+	number, err := strconv.Atoi(s)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("invalid pull request number %q", s)
+	}
+	return number, nil
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "testing"
+
+func TestParsePRReference(t *testing.T) {
+	tests := []struct {
+		input string
+		want  PRReference
+	}{
+		{"https://github.com/acme/api/pull/42", PRReference{Provider: ProviderGitHub, Repository: "acme/api", Number: 42}},
+		{"https://github.com/acme/api/pull/42/files#diff-1", PRReference{Provider: ProviderGitHub, Repository: "acme/api", Number: 42}},
+		{"https://github.example.com/acme/api/pull/7", PRReference{Provider: ProviderGitHub, Repository: "acme/api", Number: 7}},
+		{"https://dev.azure.com/contoso/Web%20Team/_git/portal/pullrequest/123", PRReference{Provider: ProviderAzureDevOps, Organization: "contoso", Repository: "Web Team/portal", Number: 123}},
+		{"https://contoso.visualstudio.com/DefaultCollection/Web/_git/portal/pullrequest/9?_a=files", PRReference{Provider: ProviderAzureDevOps, Organization: "contoso", Repository: "Web/portal", Number: 9}},
+		{"https://review.example.com/c/platform/build/+/4711/3", PRReference{Provider: ProviderGerrit, Repository: "platform/build", Number: 4711}},
+		{"acme/api/42", PRReference{Repository: "acme/api", Number: 42}},
+		{"acme/api#42", PRReference{Repository: "acme/api", Number: 42}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePRReference(tt.input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q: got %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParsePRReference_Invalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"acme/api",
+		"acme/api/abc",
+		"acme/api/0",
+		"https://github.com/acme/api/issues/42",
+		"https://dev.azure.com/contoso/Web/_git/portal",
+		"https://review.example.com/c/+/4711",
+	} {
+		if _, err := ParsePRReference(input); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package domain
+
+// Reaction contents, named as in the GitHub API. Azure DevOps only supports
+// liking a comment, which maps to ReactionThumbsUp.
+const (
+	ReactionThumbsUp   = "+1"
+	ReactionThumbsDown = "-1"
+	ReactionLaugh      = "laugh"
+	ReactionHooray     = "hooray"
+	ReactionConfused   = "confused"
+	ReactionHeart      = "heart"
+	ReactionRocket     = "rocket"
+	ReactionEyes       = "eyes"
+)
+
+// Reaction counts the users who reacted to a comment with the same content.
+type Reaction struct {
+	Content string
+	Count   int
+}
+
+var reactionEmoji = map[string]string{
+	ReactionThumbsUp:   "👍",
+	ReactionThumbsDown: "👎",
+	ReactionLaugh:      "😄",
+	ReactionHooray:     "🎉",
+	ReactionConfused:   "😕",
+	ReactionHeart:      "❤️",
+	ReactionRocket:     "🚀",
+	ReactionEyes:       "👀",
+}
+
+// ReactionEmoji returns the emoji shown for a reaction content, or the
+// content itself when it is unknown.
+func ReactionEmoji(content string) string {
+	if emoji, ok := reactionEmoji[content]; ok {
+		return emoji
+	}
+	return content
+}
+
+// AddReaction returns reactions with one more reaction of content, keeping
+// the order of the existing ones. reactions itself is not modified.
+func AddReaction(reactions []Reaction, content string) []Reaction {
+	updated := make([]Reaction, 0, len(reactions)+1)
+	found := false
+	for _, reaction := range reactions {
+		if reaction.Content == content {
+			reaction.Count++
+			found = true
+		}
+		updated = append(updated, reaction)
+	}
+	if !found {
+		updated = append(updated, Reaction{Content: content, Count: 1})
+	}
+	return updated
+}
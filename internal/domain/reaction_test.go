@@ -0,0 +1,26 @@
+package domain
+
+import "testing"
+
+func TestAddReaction(t *testing.T) {
+	reactions := []Reaction{{Content: ReactionThumbsUp, Count: 2}}
+
+	updated := AddReaction(reactions, ReactionThumbsUp)
+	updated = AddReaction(updated, ReactionEyes)
+
+	if len(updated) != 2 || updated[0].Count != 3 || updated[1] != (Reaction{Content: ReactionEyes, Count: 1}) {
+		t.Errorf("unexpected reactions: %+v", updated)
+	}
+	if reactions[0].Count != 2 {
+		t.Error("expected the original reactions to be left unchanged")
+	}
+}
+
+func TestReactionEmoji(t *testing.T) {
+	if ReactionEmoji(ReactionHooray) != "🎉" {
+		t.Errorf("unexpected emoji %q", ReactionEmoji(ReactionHooray))
+	}
+	if ReactionEmoji("unknown") != "unknown" {
+		t.Error("expected unknown contents to be shown as is")
+	}
+}
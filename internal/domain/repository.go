@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"context"
+	"strings"
+	"time"
+)
+
 type PAT struct {
 	ID           string
 	Name         string
@@ -7,9 +13,76 @@ type PAT struct {
 	Provider     ProviderType
 	Username     string
 	Organization string
+	Repositories []string
 	IsActive     bool
 	IsSelected   bool
 	IsPrimary    bool
+	// ExpiresAt and Scopes are optional and only informational; a zero
+	// ExpiresAt means the expiry is unknown.
+	ExpiresAt time.Time
+	Scopes    []string
+	// IncludeOwners and ExcludeOwners restrict listed PRs to GitHub
+	// organizations (or Azure DevOps projects). An empty include list allows
+	// every owner that is not excluded.
+	IncludeOwners []string
+	ExcludeOwners []string
+	// OAuth is set for credentials obtained through device-flow login, in
+	// which case Token holds the current access token.
+	OAuth *OAuthToken
+}
+
+// OAuthToken is the refresh state of a device-flow credential. The client ID
+// and tenant are kept so the token can be refreshed even if the settings
+// change later.
+type OAuthToken struct {
+	RefreshToken string
+	Expiry       time.Time
+	ClientID     string
+	Tenant       string
+}
+
+// IsExpired reports whether the PAT has a known expiry date that has passed.
+func (p PAT) IsExpired(now time.Time) bool {
+	return !p.ExpiresAt.IsZero() && now.After(p.ExpiresAt)
+}
+
+// AllowsOwner reports whether PRs of the given organization or project pass
+// the PAT's include/exclude lists. Matching is case-insensitive.
+func (p PAT) AllowsOwner(owner string) bool {
+	for _, excluded := range p.ExcludeOwners {
+		if strings.EqualFold(excluded, owner) {
+			return false
+		}
+	}
+	if len(p.IncludeOwners) == 0 {
+		return true
+	}
+	for _, included := range p.IncludeOwners {
+		if strings.EqualFold(included, owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterPullRequests drops PRs whose repository owner is not allowed.
+func (p PAT) FilterPullRequests(prs []PullRequest) []PullRequest {
+	if len(p.IncludeOwners) == 0 && len(p.ExcludeOwners) == 0 {
+		return prs
+	}
+	filtered := make([]PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if p.AllowsOwner(pr.Repository.RepositoryOwner()) {
+			filtered = append(filtered, pr)
+		}
+	}
+	return filtered
+}
+
+// IsAnonymous reports whether the PAT is a token-less pseudo-PAT used to
+// browse explicitly listed public GitHub repositories read-only.
+func (p PAT) IsAnonymous() bool {
+	return p.Provider == ProviderGitHub && p.Token == ""
 }
 
 type Repository interface {
@@ -34,4 +107,81 @@ type Repository interface {
 	TogglePATSelection(id string) error
 
 	SetPrimaryPAT(id string) error
+
+	GetSettings() (Settings, error)
+
+	SaveSettings(settings Settings) error
+
+	RecordActivity(event ActivityEvent) error
+
+	ListActivity(since time.Time) ([]ActivityEvent, error)
+
+	// ClaimPR records or refreshes this instance's lease on a PR and returns
+	// the live leases other instances hold on it.
+	ClaimPR(lease PRLease) ([]PRLease, error)
+
+	ReleasePR(prIdentifier string) error
+
+	// SnoozePR stores or replaces the snooze of a PR.
+	SnoozePR(snooze PRSnooze) error
+
+	UnsnoozePR(prIdentifier string) error
+
+	ListSnoozes() ([]PRSnooze, error)
+
+	// GetSeenPRs returns the UpdatedAt of each PR as of when it was last
+	// viewed, keyed by PullRequest.Key.
+	GetSeenPRs() (map[string]time.Time, error)
+
+	// MarkPRsSeen records PRs as viewed. Older timestamps never replace newer
+	// ones.
+	MarkPRsSeen(seen map[string]time.Time) error
+
+	// GetPins returns the pinned repositories and PRs.
+	GetPins() (Pins, error)
+
+	// PinRepository pins or unpins the repository with the given
+	// PullRequest.RepositoryKey.
+	PinRepository(key string, pinned bool) error
+
+	// PinPR pins or unpins the PR with the given PullRequest.Key.
+	PinPR(key string, pinned bool) error
+
+	// GetSession returns the UI session saved on the last exit, or nil when
+	// there is none.
+	GetSession() (*Session, error)
+
+	SaveSession(session Session) error
+
+	ClearSession() error
+}
+
+// ConfigWatcher is implemented by repositories that pick up changes made to
+// their configuration outside the application, such as by hand or by a
+// dotfiles sync. WatchConfig reloads the configuration whenever it changes
+// until ctx is done, and reports each reload on the returned channel: nil
+// once it succeeded, or why the new configuration could not be loaded, in
+// which case the previous one is kept.
+type ConfigWatcher interface {
+	WatchConfig(ctx context.Context) (<-chan error, error)
+}
+
+// ProfileStore is implemented by repositories that keep several named
+// profiles, each with its own PATs and settings, of which one is in use.
+type ProfileStore interface {
+	Profile() string
+
+	ListProfiles() ([]string, error)
+
+	// SwitchProfile starts using the named profile, creating it when it
+	// does not exist.
+	SwitchProfile(name string) error
+}
+
+// ConfigRecoverer is implemented by repositories that recover from a corrupt
+// config file, by restoring a backup or starting empty, instead of failing.
+type ConfigRecoverer interface {
+	// TakeRecoveryNotice returns what was done to recover since it was last
+	// called, or "" when nothing was.
+	TakeRecoveryNotice() string
 }
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+type ReviewerState string
+
+const (
+	ReviewerApproved         ReviewerState = "approved"
+	ReviewerChangesRequested ReviewerState = "changes_requested"
+	ReviewerCommented        ReviewerState = "commented"
+	ReviewerDismissed        ReviewerState = "dismissed"
+	// ReviewerPending is a requested reviewer who has not reviewed yet, or
+	// whose review was requested again.
+	ReviewerPending ReviewerState = "pending"
+)
+
+// Reviewer is the current review state of one reviewer of a pull request.
+// ReviewID identifies the review that determined State and is empty when the
+// reviewer has not reviewed. Stale is set when that review predates the
+// latest commit. Vote is the raw Azure DevOps vote (10, 5, 0, -5, -10) and
+// Required marks reviewers whose approval a policy demands.
+type Reviewer struct {
+	User        User
+	State       ReviewerState
+	ReviewID    string
+	SubmittedAt time.Time
+	Stale       bool
+	Vote        int
+	Required    bool
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+// Views a saved session can return to.
+const (
+	SessionViewPRList    = "pr_list"
+	SessionViewPRInspect = "pr_inspect"
+)
+
+// Session is the UI state saved on exit so that the next launch can offer to
+// continue where the user left off. SelectedPR is the PullRequest.Key of the
+// PR under the list cursor, and PR is only set when a PR was open.
+type Session struct {
+	View       string
+	Filter     string
+	SelectedPR string
+	PR         *SessionPR
+	SavedAt    time.Time
+}
+
+// SessionPR identifies the open pull request of a session and the position in
+// it. FileIndex, LineIndex and ScrollOffset only apply when DiffMode is set.
+type SessionPR struct {
+	ProviderType ProviderType
+	PATID        string
+	Repository   string
+	Number       int
+	Title        string
+	DiffMode     bool
+	FileIndex    int
+	LineIndex    int
+	ScrollOffset int
+}
+
+// IsEmpty reports whether there is nothing in the session worth resuming.
+func (s Session) IsEmpty() bool {
+	return s.PR == nil && s.Filter == "" && s.SelectedPR == ""
+}
+
+// PullRequest returns the minimal pull request needed to reopen the PR; the
+// remaining details are loaded from the provider.
+func (p SessionPR) PullRequest() PullRequest {
+	return PullRequest{
+		Number:       p.Number,
+		Title:        p.Title,
+		Repository:   Repo{FullName: p.Repository},
+		ProviderType: p.ProviderType,
+		PATID:        p.PATID,
+	}
+}
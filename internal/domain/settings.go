@@ -0,0 +1,370 @@
+package domain
+
+import (
+	"path"
+	"strings"
+	"time"
+)
+
+type TranslationSettings struct {
+	Command        string
+	Endpoint       string
+	APIKey         string
+	TargetLanguage string
+}
+
+// SummarySettings configures the opt-in summary of a PR's changes. Command
+// receives the prompt on stdin and prints the summary; otherwise Endpoint is
+// called as an OpenAI-compatible chat completions API with APIKey and Model.
+// MaxDiffBytes caps the diff that is sent; zero uses a default of 100 KB.
+type SummarySettings struct {
+	Command      string
+	Endpoint     string
+	APIKey       string
+	Model        string
+	MaxDiffBytes int
+}
+
+type WebhookSettings struct {
+	ListenAddr string
+	Secret     string
+}
+
+// OAuthSettings holds the OAuth application client IDs used for device-flow
+// login. AzureTenant defaults to "organizations" when empty.
+type OAuthSettings struct {
+	GitHubClientID string
+	AzureClientID  string
+	AzureTenant    string
+}
+
+// GitHubSettings configures GitHub PATs. MaxPullRequests caps how many of
+// the open PRs a user is involved in are listed, newest first; zero uses
+// DefaultGitHubMaxPullRequests, and GitHub's search finds at most
+// GitHubSearchLimit.
+type GitHubSettings struct {
+	MaxPullRequests int `json:",omitempty"`
+}
+
+const (
+	// DefaultGitHubMaxPullRequests is the cap used when GitHubSettings
+	// leaves MaxPullRequests at zero.
+	DefaultGitHubMaxPullRequests = 300
+	// GitHubSearchLimit is the most results GitHub's search returns for a
+	// query.
+	GitHubSearchLimit = 1000
+)
+
+// PullRequestLimit returns how many PRs are listed per GitHub PAT.
+func (s GitHubSettings) PullRequestLimit() int {
+	switch {
+	case s.MaxPullRequests <= 0:
+		return DefaultGitHubMaxPullRequests
+	case s.MaxPullRequests > GitHubSearchLimit:
+		return GitHubSearchLimit
+	}
+	return s.MaxPullRequests
+}
+
+// DisplaySettings holds rendering preferences. DiffBackground shades added
+// and deleted lines in addition to coloring their text, WrapDiffLines wraps
+// diff lines wider than the terminal instead of scrolling them sideways,
+// Drafts controls how draft pull requests are listed, DetailedRows adds a
+// second line with the repository, branches and labels to each listed PR, and
+// PlainDiff renders diffs as labeled plain text for screen readers.
+type DisplaySettings struct {
+	DiffBackground bool
+	WrapDiffLines  bool
+	Drafts         DraftDisplay `json:",omitempty"`
+	DetailedRows   bool         `json:",omitempty"`
+	PlainDiff      bool         `json:",omitempty"`
+}
+
+// DraftDisplay controls how draft pull requests are listed: among the other
+// PRs, hidden, or in a collapsed section at the end of the list.
+type DraftDisplay string
+
+const (
+	DraftsShown   DraftDisplay = ""
+	DraftsHidden  DraftDisplay = "hide"
+	DraftsSection DraftDisplay = "section"
+)
+
+// ParseDraftDisplay returns the DraftDisplay named by s, which is one of
+// show, hide or section.
+func ParseDraftDisplay(s string) (DraftDisplay, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "show":
+		return DraftsShown, true
+	case string(DraftsHidden):
+		return DraftsHidden, true
+	case string(DraftsSection):
+		return DraftsSection, true
+	}
+	return DraftsShown, false
+}
+
+// String returns the name ParseDraftDisplay accepts for d.
+func (d DraftDisplay) String() string {
+	if d == DraftsShown {
+		return "show"
+	}
+	return string(d)
+}
+
+// UpdateSettings controls the opt-in startup check for new releases. The
+// remaining fields cache the result of the last check so that the releases
+// API is queried at most once per day.
+type UpdateSettings struct {
+	CheckOnStartup bool
+	LastChecked    time.Time
+	LatestVersion  string
+	ReleaseURL     string
+	Changelog      string
+}
+
+// DefaultReviewUndoSeconds is the undo window used when ReviewSettings leaves
+// UndoSeconds at zero.
+const DefaultReviewUndoSeconds = 5
+
+// DefaultReviewReminderMinutes is how often the review timer reminds of the
+// time spent on a PR when ReviewSettings leaves ReminderMinutes at zero.
+const DefaultReviewReminderMinutes = 45
+
+// ReviewSettings controls review submission. UndoSeconds is the grace period
+// during which a submitted review is held locally and can still be cancelled;
+// zero uses DefaultReviewUndoSeconds and a negative value submits immediately.
+// Timer shows the time spent reviewing the open PR in the status bar and
+// records it in the statistics, with a reminder every ReminderMinutes; zero
+// uses DefaultReviewReminderMinutes and a negative value turns reminders off.
+// Templates pre-fill the review editor per repository, and Checklists list
+// what to go through before approving. RefreshSeconds is how often the
+// details and comments of the open PR are reloaded; zero uses
+// DefaultPRRefreshSeconds and a negative value only reloads them on request.
+type ReviewSettings struct {
+	UndoSeconds     int
+	Timer           bool
+	ReminderMinutes int
+	RefreshSeconds  int `json:",omitempty"`
+	Templates       []ReviewTemplateSettings
+	Checklists      []ReviewChecklistSettings
+}
+
+// RepositoryPatterns select repositories by glob patterns on their full
+// name, such as "acme/*", or by a bare organization or project name.
+type RepositoryPatterns []string
+
+// Matches reports whether repo is selected by one of the patterns. Matching
+// is case-insensitive.
+func (p RepositoryPatterns) Matches(repo Repo) bool {
+	fullName := strings.ToLower(repo.FullName)
+	for _, pattern := range p {
+		pattern = strings.ToLower(pattern)
+		if !strings.Contains(pattern, "/") {
+			if pattern == strings.ToLower(repo.RepositoryOwner()) {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, fullName); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ReviewTemplateSettings is a default review body for the repositories
+// matching Repositories. Approve and RequestChanges pre-fill the editor
+// opened to approve or to request changes; either may be left empty.
+type ReviewTemplateSettings struct {
+	Repositories   RepositoryPatterns
+	Approve        string
+	RequestChanges string
+}
+
+// ReviewChecklistSettings lists what to check before approving a PR of the
+// repositories matching Repositories.
+type ReviewChecklistSettings struct {
+	Repositories RepositoryPatterns
+	Items        []ChecklistItem
+}
+
+// ChecklistItem is an item of a review checklist. An approval is not
+// submitted until every Required item is checked.
+type ChecklistItem struct {
+	Text     string
+	Required bool
+}
+
+// Checklist returns the items of every checklist for repo, in order, leaving
+// out repeated items.
+func (s ReviewSettings) Checklist(repo Repo) []ChecklistItem {
+	var items []ChecklistItem
+	seen := make(map[string]bool)
+	for _, checklist := range s.Checklists {
+		if !checklist.Repositories.Matches(repo) {
+			continue
+		}
+		for _, item := range checklist.Items {
+			if !seen[item.Text] {
+				seen[item.Text] = true
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// Template returns the body of the first template for repo with a body for
+// action, so that a repository's own template can come before an
+// organization-wide one. It is empty when none applies.
+func (s ReviewSettings) Template(repo Repo, action ReviewAction) string {
+	for _, template := range s.Templates {
+		var body string
+		switch action {
+		case ReviewActionApprove:
+			body = template.Approve
+		case ReviewActionRequestChanges:
+			body = template.RequestChanges
+		}
+		if body != "" && template.Repositories.Matches(repo) {
+			return body
+		}
+	}
+	return ""
+}
+
+// ReminderInterval returns how often the review timer reminds of the time
+// spent, or zero when it does not.
+func (s ReviewSettings) ReminderInterval() time.Duration {
+	switch {
+	case s.ReminderMinutes < 0:
+		return 0
+	case s.ReminderMinutes == 0:
+		return DefaultReviewReminderMinutes * time.Minute
+	}
+	return time.Duration(s.ReminderMinutes) * time.Minute
+}
+
+// DefaultPRRefreshSeconds is how often the open PR is reloaded when
+// ReviewSettings leaves RefreshSeconds at zero.
+const DefaultPRRefreshSeconds = 60
+
+// RefreshInterval returns how often the open PR is reloaded, or zero when it
+// is not.
+func (s ReviewSettings) RefreshInterval() time.Duration {
+	switch {
+	case s.RefreshSeconds < 0:
+		return 0
+	case s.RefreshSeconds == 0:
+		return DefaultPRRefreshSeconds * time.Second
+	}
+	return time.Duration(s.RefreshSeconds) * time.Second
+}
+
+// UndoWindow returns the grace period before a review is sent.
+func (s ReviewSettings) UndoWindow() time.Duration {
+	switch {
+	case s.UndoSeconds < 0:
+		return 0
+	case s.UndoSeconds == 0:
+		return DefaultReviewUndoSeconds * time.Second
+	}
+	return time.Duration(s.UndoSeconds) * time.Second
+}
+
+// MergeSettings controls merging. The source branch is deleted after a merge
+// unless KeepSourceBranch is set; either way it can be toggled per merge.
+type MergeSettings struct {
+	KeepSourceBranch bool
+}
+
+// TeamSettings configures the team board, which lists the open PRs of each
+// of Members, the usernames of the teammates on the providers.
+type TeamSettings struct {
+	Members []string
+}
+
+// SpellCheckSettings controls the optional spell-check of the review and
+// inline comment editors. Dictionary is a wordlist or hunspell .dic file;
+// when empty, the usual system locations are tried. Words are the words
+// added to the dictionary from the editors.
+type SpellCheckSettings struct {
+	Enabled    bool
+	Dictionary string
+	Words      []string
+}
+
+// DiffHookSettings configures an external command that analyzes the diff of
+// each changed file, such as a linter or semgrep wrapper. Command receives the
+// file's unified diff on stdin and prints one finding per line. Files, when
+// set, limits the hook to files whose path or name matches one of the glob
+// patterns.
+type DiffHookSettings struct {
+	Name    string
+	Command string
+	Files   []string
+}
+
+// NetworkSettings configures how every HTTP connection is made. Proxy is used
+// instead of the HTTP_PROXY and HTTPS_PROXY environment variables, except for
+// hosts in NoProxy, which also match their subdomains. CABundle is a PEM file
+// of certificates trusted in addition to the system ones, for networks that
+// intercept TLS. InsecureSkipVerify turns off certificate verification and is
+// only meant for diagnosing such networks. RequestTimeoutSeconds bounds each
+// provider call; zero uses DefaultRequestTimeoutSeconds and a negative value
+// lets calls run until they finish or are cancelled. MaxAttempts is how many
+// times a provider request failing with a transient error is tried; zero uses
+// DefaultMaxAttempts and 1 turns retries off.
+type NetworkSettings struct {
+	Proxy                 string
+	NoProxy               []string
+	CABundle              string
+	InsecureSkipVerify    bool
+	RequestTimeoutSeconds int
+	MaxAttempts           int `json:",omitempty"`
+}
+
+// DefaultMaxAttempts is how many times a request is tried when
+// NetworkSettings leaves MaxAttempts at zero.
+const DefaultMaxAttempts = 3
+
+// RequestAttempts returns how many times a provider request is tried.
+func (s NetworkSettings) RequestAttempts() int {
+	if s.MaxAttempts <= 0 {
+		return DefaultMaxAttempts
+	}
+	return s.MaxAttempts
+}
+
+// DefaultRequestTimeoutSeconds is the request timeout used when
+// NetworkSettings leaves RequestTimeoutSeconds at zero.
+const DefaultRequestTimeoutSeconds = 60
+
+// RequestTimeout returns how long a single provider call may take, or zero
+// for no limit.
+func (s NetworkSettings) RequestTimeout() time.Duration {
+	switch {
+	case s.RequestTimeoutSeconds < 0:
+		return 0
+	case s.RequestTimeoutSeconds == 0:
+		return DefaultRequestTimeoutSeconds * time.Second
+	}
+	return time.Duration(s.RequestTimeoutSeconds) * time.Second
+}
+
+type Settings struct {
+	Translation TranslationSettings
+	Summary     SummarySettings
+	Webhook     WebhookSettings
+	OAuth       OAuthSettings
+	GitHub      GitHubSettings
+	Display     DisplaySettings
+	Updates     UpdateSettings
+	Review      ReviewSettings
+	Merge       MergeSettings
+	Team        TeamSettings
+	SpellCheck  SpellCheckSettings
+	DiffHooks   []DiffHookSettings
+	Network     NetworkSettings
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "testing"
+
+func TestReviewSettingsTemplate(t *testing.T) {
+	settings := ReviewSettings{Templates: []ReviewTemplateSettings{
+		{Repositories: []string{"acme/api"}, RequestChanges: "API checklist"},
+		{Repositories: []string{"Acme"}, Approve: "LGTM, org template", RequestChanges: "Org checklist"},
+		{Repositories: []string{"tools/*"}, Approve: "Tools template"},
+	}}
+
+	tests := []struct {
+		repo   Repo
+		action ReviewAction
+		want   string
+	}{
+		{Repo{FullName: "acme/api"}, ReviewActionRequestChanges, "API checklist"},
+		{Repo{FullName: "acme/api"}, ReviewActionApprove, "LGTM, org template"},
+		{Repo{FullName: "acme/web", Owner: "ACME"}, ReviewActionRequestChanges, "Org checklist"},
+		{Repo{FullName: "Tools/cli"}, ReviewActionApprove, "Tools template"},
+		{Repo{FullName: "tools/cli"}, ReviewActionRequestChanges, ""},
+		{Repo{FullName: "acme/api"}, ReviewActionComment, ""},
+		{Repo{FullName: "other/api"}, ReviewActionApprove, ""},
+	}
+	for _, tt := range tests {
+		if got := settings.Template(tt.repo, tt.action); got != tt.want {
+			t.Errorf("Template(%s, %s) = %q, want %q", tt.repo.FullName, tt.action, got, tt.want)
+		}
+	}
+}
+
+func TestReviewSettingsChecklist(t *testing.T) {
+	settings := ReviewSettings{Checklists: []ReviewChecklistSettings{
+		{Repositories: RepositoryPatterns{"acme/db-*"}, Items: []ChecklistItem{{Text: "Checked the migration", Required: true}}},
+		{Repositories: RepositoryPatterns{"acme"}, Items: []ChecklistItem{{Text: "Ran tests locally", Required: true}, {Text: "Read the linked issue"}}},
+		{Repositories: RepositoryPatterns{"acme/*"}, Items: []ChecklistItem{{Text: "Ran tests locally", Required: true}}},
+	}}
+
+	items := settings.Checklist(Repo{FullName: "acme/db-core"})
+	if len(items) != 3 || items[0].Text != "Checked the migration" || items[1].Text != "Ran tests locally" || items[2].Required {
+		t.Errorf("unexpected checklist %+v", items)
+	}
+	if items := settings.Checklist(Repo{FullName: "other/db-core"}); len(items) != 0 {
+		t.Errorf("expected no checklist for other repositories, got %+v", items)
+	}
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// PRSnooze hides a pull request from the list until Until has passed or, when
+// UntilUpdated is set, until the PR is updated after it was snoozed.
+type PRSnooze struct {
+	PRIdentifier string
+	Until        time.Time
+	UntilUpdated bool
+	PRUpdatedAt  time.Time
+	CreatedAt    time.Time
+}
+
+// IsActive reports whether the snooze still hides pr at the given time.
+func (s PRSnooze) IsActive(pr PullRequest, now time.Time) bool {
+	if !s.Until.IsZero() && !now.Before(s.Until) {
+		return false
+	}
+	if s.UntilUpdated && pr.UpdatedAt.After(s.PRUpdatedAt) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StackMember is a pull request of a stack. Listed is false for a PR that is
+// only known from a "Depends on #N" marker; it then has just its number,
+// repository, provider and PAT, and its details load when it is opened.
+type StackMember struct {
+	PR     PullRequest
+	Listed bool
+}
+
+var (
+	// "Depends on #12", "depends on: #12, #14" or "Depends-On: acme/api#12".
+	dependsOnMarker = regexp.MustCompile(`(?im)\bdepends[ -]on:?[ \t]+((?:[\w.-]+/[\w.-]+)?#\d+(?:[ \t]*(?:,|and)[ \t]*(?:[\w.-]+/[\w.-]+)?#\d+)*)`)
+	dependsOnRef    = regexp.MustCompile(`(?:([\w.-]+/[\w.-]+))?#(\d+)`)
+	// "auth-1", "auth/part-2" or "auth_3": a branch prefix shared by the
+	// PRs of a stack followed by the position in it.
+	stackBranch = regexp.MustCompile(`^(.*[^-_./\d])[-_./](\d{1,2})$`)
+	// "PROJ" in "feature/PROJ-123" is an issue key rather than a stack.
+	issueKey = regexp.MustCompile(`(?:^|/)[A-Z][A-Z0-9]+$`)
+)
+
+// DependsOn returns the numbers of the PRs the description of pr says it
+// depends on. References to other repositories are left out.
+func DependsOn(pr PullRequest) []int {
+	var numbers []int
+	seen := make(map[int]bool)
+	for _, marker := range dependsOnMarker.FindAllStringSubmatch(pr.Description, -1) {
+		for _, ref := range dependsOnRef.FindAllStringSubmatch(marker[1], -1) {
+			if ref[1] != "" && !strings.EqualFold(ref[1], pr.Repository.FullName) {
+				continue
+			}
+			number, err := strconv.Atoi(ref[2])
+			if err != nil || number <= 0 || number == pr.Number || seen[number] {
+				continue
+			}
+			seen[number] = true
+			numbers = append(numbers, number)
+		}
+	}
+	return numbers
+}
+
+// stackPosition splits a branch such as "auth-2" into the prefix shared by
+// the PRs of its stack and its position in the stack.
+func stackPosition(branch string) (string, int) {
+	m := stackBranch.FindStringSubmatch(branch)
+	if m == nil || issueKey.MatchString(m[1]) {
+		return "", 0
+	}
+	position, err := strconv.Atoi(m[2])
+	if err != nil {
+		return "", 0
+	}
+	return m[1], position
+}
+
+// FindStack returns the stack pr belongs to among prs, bottom first, or nil
+// when it is not part of one. PRs of the same repository are related when one
+// targets the other's source branch, when the description of one depends on
+// the other, or when they have the same author and their source branches
+// share a prefix and end in their position, such as "auth-1" and "auth-2". A
+// PR that is depended on but not among prs is included as an unlisted member.
+func FindStack(pr PullRequest, prs []PullRequest) []StackMember {
+	nodes := map[int]*StackMember{pr.Number: {PR: pr, Listed: true}}
+	for _, other := range prs {
+		if other.RepositoryKey() == pr.RepositoryKey() && other.Number != pr.Number {
+			nodes[other.Number] = &StackMember{PR: other, Listed: true}
+		}
+	}
+
+	// parents holds the PRs each PR is stacked on, and related the PRs it
+	// is linked to in either direction.
+	parents := make(map[int][]int)
+	related := make(map[int][]int)
+	link := func(child, parent int) {
+		parents[child] = append(parents[child], parent)
+		related[child] = append(related[child], parent)
+		related[parent] = append(related[parent], child)
+	}
+
+	bySource := make(map[string][]int)
+	byPrefix := make(map[string][]int)
+	for number, node := range nodes {
+		if node.PR.SourceBranch != "" {
+			bySource[node.PR.SourceBranch] = append(bySource[node.PR.SourceBranch], number)
+		}
+		if prefix, _ := stackPosition(node.PR.SourceBranch); prefix != "" {
+			key := node.PR.Author.Username + "\x00" + prefix
+			byPrefix[key] = append(byPrefix[key], number)
+		}
+	}
+	for number, node := range nodes {
+		for _, parent := range bySource[node.PR.TargetBranch] {
+			if parent != number {
+				link(number, parent)
+			}
+		}
+		for _, parent := range DependsOn(node.PR) {
+			if _, ok := nodes[parent]; !ok {
+				nodes[parent] = &StackMember{PR: PullRequest{
+					Number:       parent,
+					Repository:   pr.Repository,
+					ProviderType: pr.ProviderType,
+					PATID:        pr.PATID,
+				}}
+			}
+			link(number, parent)
+		}
+	}
+	for _, numbers := range byPrefix {
+		for i := 1; i < len(numbers); i++ {
+			related[numbers[0]] = append(related[numbers[0]], numbers[i])
+			related[numbers[i]] = append(related[numbers[i]], numbers[0])
+		}
+	}
+
+	members := []int{pr.Number}
+	inStack := map[int]bool{pr.Number: true}
+	for i := 0; i < len(members); i++ {
+		for _, other := range related[members[i]] {
+			if !inStack[other] {
+				inStack[other] = true
+				members = append(members, other)
+			}
+		}
+	}
+	if len(members) < 2 {
+		return nil
+	}
+
+	// A PR sits above the longest chain of PRs it is stacked on; PRs at the
+	// same height are ordered by their branch position and then number.
+	depths := make(map[int]int)
+	var depth func(number int, visiting map[int]bool) int
+	depth = func(number int, visiting map[int]bool) int {
+		if d, ok := depths[number]; ok {
+			return d
+		}
+		visiting[number] = true
+		d := 0
+		for _, parent := range parents[number] {
+			if !visiting[parent] {
+				d = max(d, depth(parent, visiting)+1)
+			}
+		}
+		delete(visiting, number)
+		depths[number] = d
+		return d
+	}
+	for _, number := range members {
+		depth(number, make(map[int]bool))
+	}
+	sort.Slice(members, func(i, j int) bool {
+		a, b := members[i], members[j]
+		if depths[a] != depths[b] {
+			return depths[a] < depths[b]
+		}
+		_, posA := stackPosition(nodes[a].PR.SourceBranch)
+		_, posB := stackPosition(nodes[b].PR.SourceBranch)
+		if posA != posB {
+			return posA < posB
+		}
+		return a < b
+	})
+
+	stack := make([]StackMember, len(members))
+	for i, number := range members {
+		stack[i] = *nodes[number]
+	}
+	return stack
+}
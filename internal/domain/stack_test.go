@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func stackNumbers(stack []StackMember) []int {
+	var numbers []int
+	for _, member := range stack {
+		numbers = append(numbers, member.PR.Number)
+	}
+	return numbers
+}
+
+func TestDependsOn(t *testing.T) {
+	pr := PullRequest{
+		Number:      5,
+		Repository:  Repo{FullName: "acme/api"},
+		Description: "Adds the handlers.\n\nDepends on #3 and #4\ndepends-on: acme/api#2, other/repo#9, #5\nDepends on #3",
+	}
+	if got, want := DependsOn(pr), []int{3, 4, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := DependsOn(PullRequest{Number: 1, Description: "Fixes #12"}); got != nil {
+		t.Errorf("expected no dependencies for an issue reference, got %v", got)
+	}
+}
+
+func TestFindStack(t *testing.T) {
+	repo := Repo{FullName: "acme/api"}
+	base := PullRequest{Number: 10, Repository: repo, SourceBranch: "models", TargetBranch: "main"}
+	middle := PullRequest{Number: 12, Repository: repo, SourceBranch: "handlers", TargetBranch: "models"}
+	top := PullRequest{Number: 11, Repository: repo, SourceBranch: "ui", TargetBranch: "main", Description: "Depends on #12"}
+	unrelated := PullRequest{Number: 13, Repository: repo, SourceBranch: "docs", TargetBranch: "main"}
+	otherRepo := PullRequest{Number: 14, Repository: Repo{FullName: "acme/web"}, SourceBranch: "ui", TargetBranch: "handlers"}
+	prs := []PullRequest{unrelated, top, otherRepo, base, middle}
+
+	if got, want := stackNumbers(FindStack(middle, prs)), []int{10, 12, 11}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the stack bottom first, got %v", got)
+	}
+	if stack := FindStack(unrelated, prs); stack != nil {
+		t.Errorf("expected no stack for an unrelated PR, got %v", stackNumbers(stack))
+	}
+
+	withUnlisted := PullRequest{Number: 20, Repository: repo, ProviderType: ProviderGitHub, PATID: "pat-1", Description: "Depends on #7"}
+	stack := FindStack(withUnlisted, nil)
+	if got, want := stackNumbers(stack), []int{7, 20}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if stack[0].Listed || stack[0].PR.PATID != "pat-1" || stack[0].PR.Key() != "github:acme/api/7" || !stack[1].Listed {
+		t.Errorf("expected #7 to be an unlisted member that can be opened, got %+v", stack[0])
+	}
+}
+
+func TestFindStack_BranchPositions(t *testing.T) {
+	repo := Repo{FullName: "acme/api"}
+	alice := User{Username: "alice"}
+	prs := []PullRequest{
+		{Number: 3, Repository: repo, Author: alice, SourceBranch: "auth/part-2", TargetBranch: "main"},
+		{Number: 4, Repository: repo, Author: alice, SourceBranch: "auth/part-1", TargetBranch: "main"},
+		{Number: 5, Repository: repo, Author: User{Username: "bob"}, SourceBranch: "auth/part-3", TargetBranch: "main"},
+		{Number: 6, Repository: repo, Author: alice, SourceBranch: "feature/PROJ-12", TargetBranch: "main"},
+		{Number: 7, Repository: repo, Author: alice, SourceBranch: "feature/PROJ-14", TargetBranch: "main"},
+	}
+
+	if got, want := stackNumbers(FindStack(prs[0], prs)), []int{4, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the branches of one author ordered by position, got %v", got)
+	}
+	if stack := FindStack(prs[3], prs); stack != nil {
+		t.Errorf("expected issue keys not to form a stack, got %v", stackNumbers(stack))
+	}
+}
@@ -0,0 +1,40 @@
+package domain
+
+// ThreadStatus is the status of a comment thread on providers that track
+// whether feedback was addressed, such as Azure DevOps. It is empty for
+// providers without thread statuses.
+type ThreadStatus string
+
+const (
+	ThreadActive   ThreadStatus = "active"
+	ThreadPending  ThreadStatus = "pending"
+	ThreadFixed    ThreadStatus = "fixed"
+	ThreadWontFix  ThreadStatus = "wontFix"
+	ThreadClosed   ThreadStatus = "closed"
+	ThreadByDesign ThreadStatus = "byDesign"
+)
+
+// ThreadStatusLabel names status the way the Azure DevOps web UI does, where
+// "fixed" reads as "Resolved".
+func ThreadStatusLabel(status ThreadStatus) string {
+	switch status {
+	case ThreadActive:
+		return "Active"
+	case ThreadPending:
+		return "Pending"
+	case ThreadFixed:
+		return "Resolved"
+	case ThreadWontFix:
+		return "Won't fix"
+	case ThreadClosed:
+		return "Closed"
+	case ThreadByDesign:
+		return "By design"
+	}
+	return string(status)
+}
+
+// IsOpen reports whether a thread with status still awaits a response.
+func (s ThreadStatus) IsOpen() bool {
+	return s == ThreadActive || s == ThreadPending
+}
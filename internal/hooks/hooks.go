@@ -0,0 +1,213 @@
+// Package hooks runs user-configured commands over the diff of each changed
+// file and turns their output into annotations shown in the diff view.
+package hooks
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// Timeout bounds how long a single hook may run on one file.
+const Timeout = 30 * time.Second
+
+var ErrNotConfigured = errors.New("no diff hooks configured: add commands to settings.DiffHooks in config.json")
+
+// finding matches "path:line:col: severity: message", where the path, the
+// column and the severity are optional.
+var finding = regexp.MustCompile(`^(?:(.+?):)?(\d+)(?::\d+)?:\s*(?:(?i:(error|warning|warn|info|note))\s*:\s*)?(.+)$`)
+
+type Runner struct {
+	hooks []domain.DiffHookSettings
+}
+
+func NewRunner(hooks []domain.DiffHookSettings) (*Runner, error) {
+	var configured []domain.DiffHookSettings
+	for _, hook := range hooks {
+		if strings.TrimSpace(hook.Command) != "" {
+			configured = append(configured, hook)
+		}
+	}
+	if len(configured) == 0 {
+		return nil, ErrNotConfigured
+	}
+	return &Runner{hooks: configured}, nil
+}
+
+// Analyze runs every hook that applies to file and returns their findings.
+// Binary files are skipped. A failing hook does not stop the others; its
+// error is returned alongside the findings of the rest.
+func (r *Runner) Analyze(ctx context.Context, repository string, file domain.FileDiff) ([]domain.Annotation, error) {
+	if file.Binary != nil || len(file.Hunks) == 0 {
+		return nil, nil
+	}
+
+	filePath := file.NewPath
+	if filePath == "" {
+		filePath = file.OldPath
+	}
+	diff := UnifiedDiff(file)
+
+	var annotations []domain.Annotation
+	var errs []error
+	for _, hook := range r.hooks {
+		if !matches(hook.Files, filePath) {
+			continue
+		}
+		found, err := run(ctx, hook, repository, filePath, diff)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		annotations = append(annotations, found...)
+	}
+	return annotations, errors.Join(errs...)
+}
+
+func hookName(hook domain.DiffHookSettings) string {
+	if hook.Name != "" {
+		return hook.Name
+	}
+	return strings.Fields(hook.Command)[0]
+}
+
+// matches reports whether filePath or its base name matches one of
+// patterns. No patterns match every file.
+func matches(patterns []string, filePath string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, filePath); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(filePath)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// run pipes diff to the hook's command. Linters usually exit with an error
+// when they report findings, so a failing exit status is only an error when
+// nothing could be parsed from the output.
+func run(ctx context.Context, hook domain.DiffHookSettings, repository, filePath, diff string) ([]domain.Annotation, error) {
+	parts := strings.Fields(hook.Command)
+	name := hookName(hook)
+
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
+	cmd.Stdin = strings.NewReader(diff)
+	cmd.Env = append(os.Environ(),
+		"LGTMFASTER_FILE="+filePath,
+		"LGTMFASTER_REPO="+repository,
+		"LGTMFASTER_HOOK="+name,
+	)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	annotations := parse(name, filePath, string(out))
+	if err != nil && len(annotations) == 0 {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || strings.TrimSpace(stderr.String()) != "" {
+			logger.LogError("DIFF_HOOK", name, err)
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return nil, fmt.Errorf("diff hook %s failed on %s: %s", name, filePath, firstLine(msg))
+			}
+			return nil, fmt.Errorf("diff hook %s failed on %s: %w", name, filePath, err)
+		}
+	}
+	return annotations, nil
+}
+
+// parse reads one finding per line of output. Findings for another file and
+// lines that are not findings are ignored.
+func parse(source, filePath, output string) []domain.Annotation {
+	var annotations []domain.Annotation
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		match := finding.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match == nil {
+			continue
+		}
+		if reported := match[1]; reported != "" && reported != "-" && !samePath(reported, filePath) {
+			continue
+		}
+		line, err := strconv.Atoi(match[2])
+		if err != nil || line <= 0 {
+			continue
+		}
+		annotations = append(annotations, domain.Annotation{
+			Source:   source,
+			Path:     filePath,
+			Line:     line,
+			Severity: severity(match[3]),
+			Message:  strings.TrimSpace(match[4]),
+		})
+	}
+	return annotations
+}
+
+// samePath accepts a reported path that is the file's path relative to
+// another directory, or the other way round.
+func samePath(reported, filePath string) bool {
+	reported = strings.TrimPrefix(path.Clean(reported), "./")
+	filePath = strings.TrimPrefix(path.Clean(filePath), "/")
+	return reported == filePath ||
+		strings.HasSuffix(reported, "/"+filePath) ||
+		strings.HasSuffix(filePath, "/"+reported)
+}
+
+func severity(s string) domain.AnnotationSeverity {
+	switch strings.ToLower(s) {
+	case "error":
+		return domain.AnnotationError
+	case "info", "note":
+		return domain.AnnotationInfo
+	}
+	return domain.AnnotationWarning
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// UnifiedDiff renders file as a unified diff with "---" and "+++" headers,
+// which is what hooks receive on stdin.
+func UnifiedDiff(file domain.FileDiff) string {
+	oldPath, newPath := "a/"+file.OldPath, "b/"+file.NewPath
+	if file.IsNew || file.OldPath == "" {
+		oldPath = "/dev/null"
+	}
+	if file.IsDeleted || file.NewPath == "" {
+		newPath = "/dev/null"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldPath, newPath)
+	for _, hunk := range file.Hunks {
+		b.WriteString(hunk.Header)
+		b.WriteString("\n")
+		for _, line := range hunk.Lines {
+			b.WriteString(line.Content)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
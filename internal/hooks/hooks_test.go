@@ -0,0 +1,126 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+var testFile = domain.FileDiff{
+	OldPath: "internal/api/client.go",
+	NewPath: "internal/api/client.go",
+	Hunks: []domain.DiffHunk{{
+		Header: "@@ -10,2 +10,2 @@",
+		Lines: []domain.DiffLine{
+			{Type: "context", Content: " func call() {", OldLine: 10, NewLine: 10},
+			{Type: "delete", Content: "-\treturn nil", OldLine: 11},
+			{Type: "add", Content: "+\tpanic(\"todo\")", NewLine: 11},
+		},
+	}},
+}
+
+func TestNewRunner_NotConfigured(t *testing.T) {
+	_, err := NewRunner([]domain.DiffHookSettings{{Name: "empty", Command: "  "}})
+	if !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("expected ErrNotConfigured, got %v", err)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	want := "--- a/internal/api/client.go\n+++ b/internal/api/client.go\n" +
+		"@@ -10,2 +10,2 @@\n func call() {\n-\treturn nil\n+\tpanic(\"todo\")\n"
+	if got := UnifiedDiff(testFile); got != want {
+		t.Errorf("UnifiedDiff() = %q, want %q", got, want)
+	}
+
+	added := domain.FileDiff{NewPath: "new.go", IsNew: true}
+	if got := UnifiedDiff(added); got != "--- /dev/null\n+++ b/new.go\n" {
+		t.Errorf("UnifiedDiff(new file) = %q", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	output := strings.Join([]string{
+		"internal/api/client.go:11:2: error: panic in library code",
+		"./api/client.go:12: unchecked result",
+		"other.go:3: not this file",
+		"11: note: consider returning an error",
+		"-:4:1: WARNING: from stdin",
+		"summary: 3 issues",
+	}, "\n")
+
+	got := parse("semgrep", "internal/api/client.go", output)
+	want := []domain.Annotation{
+		{Source: "semgrep", Path: "internal/api/client.go", Line: 11, Severity: domain.AnnotationError, Message: "panic in library code"},
+		{Source: "semgrep", Path: "internal/api/client.go", Line: 12, Severity: domain.AnnotationWarning, Message: "unchecked result"},
+		{Source: "semgrep", Path: "internal/api/client.go", Line: 11, Severity: domain.AnnotationInfo, Message: "consider returning an error"},
+		{Source: "semgrep", Path: "internal/api/client.go", Line: 4, Severity: domain.AnnotationWarning, Message: "from stdin"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parse() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func writeScript(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "hook.sh")
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAnalyze_RunsMatchingHooks(t *testing.T) {
+	noPanic := writeScript(t, "grep -q panic && echo \"$LGTMFASTER_FILE:11: error: no panic in $LGTMFASTER_REPO\" && exit 1\n")
+	runner, err := NewRunner([]domain.DiffHookSettings{
+		{Name: "no-panic", Command: "sh " + noPanic},
+		{Name: "docs-only", Command: "echo 1: should not run", Files: []string{"*.md"}},
+		{Command: "echo 10: info: looked at it", Files: []string{"client.go"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	annotations, err := runner.Analyze(context.Background(), "acme/api", testFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []domain.Annotation{
+		{Source: "no-panic", Path: "internal/api/client.go", Line: 11, Severity: domain.AnnotationError, Message: "no panic in acme/api"},
+		{Source: "echo", Path: "internal/api/client.go", Line: 10, Severity: domain.AnnotationInfo, Message: "looked at it"},
+	}
+	if !reflect.DeepEqual(annotations, want) {
+		t.Errorf("Analyze() =\n%+v\nwant\n%+v", annotations, want)
+	}
+}
+
+func TestAnalyze_ReportsFailingHook(t *testing.T) {
+	broken := writeScript(t, "echo 'config not found' >&2\nexit 2\n")
+	runner, _ := NewRunner([]domain.DiffHookSettings{
+		{Name: "broken", Command: "sh " + broken},
+		{Name: "ok", Command: "echo 11: fine"},
+	})
+
+	annotations, err := runner.Analyze(context.Background(), "acme/api", testFile)
+	if err == nil || !strings.Contains(err.Error(), "diff hook broken failed on internal/api/client.go: config not found") {
+		t.Errorf("expected the hook's error, got %v", err)
+	}
+	if len(annotations) != 1 || annotations[0].Source != "ok" {
+		t.Errorf("expected the other hook's findings, got %+v", annotations)
+	}
+}
+
+func TestAnalyze_SkipsBinaryFiles(t *testing.T) {
+	runner, _ := NewRunner([]domain.DiffHookSettings{{Command: "echo 1: x"}})
+
+	annotations, err := runner.Analyze(context.Background(), "acme/api", domain.FileDiff{NewPath: "logo.png", Binary: &domain.BinaryInfo{}})
+	if err != nil || len(annotations) != 0 {
+		t.Errorf("expected binary files to be skipped, got %+v, %v", annotations, err)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 )
@@ -20,6 +22,31 @@ type LogEntry struct {
 	Message   string
 }
 
+// Level is the kind of a log entry, taken from the tag its message starts
+// with.
+type Level string
+
+const (
+	LevelInfo      Level = "INFO"
+	LevelError     Level = "ERROR"
+	LevelFileOpen  Level = "FILE_OPEN"
+	LevelFileWrite Level = "FILE_WRITE"
+)
+
+func (e LogEntry) Level() Level {
+	for _, level := range []Level{LevelError, LevelFileWrite, LevelFileOpen, LevelInfo} {
+		if strings.HasPrefix(e.Message, "["+string(level)+"]") {
+			return level
+		}
+	}
+	return LevelInfo
+}
+
+// String formats the entry the way the logs view and exports show it.
+func (e LogEntry) String() string {
+	return fmt.Sprintf("[%s] %s", e.Timestamp.Format("15:04:05.000"), e.Message)
+}
+
 type Logger struct {
 	file    *os.File
 	logger  *log.Logger
@@ -97,6 +124,22 @@ func GetLogs() []LogEntry {
 	return logs
 }
 
+// Export writes the entries kept in memory to path, one per line with the
+// full date, for attaching to bug reports.
+func Export(path string) error {
+	var b strings.Builder
+	for _, entry := range GetLogs() {
+		fmt.Fprintf(&b, "%s %s\n", entry.Timestamp.Format("2006-01-02 15:04:05.000"), entry.Message)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0600); err != nil {
+		return fmt.Errorf("failed to write logs: %w", err)
+	}
+	return nil
+}
+
 func LogFileOpen(path string) {
 	message := fmt.Sprintf("[FILE_OPEN] %s", path)
 	addToBuffer(message)
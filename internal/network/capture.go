@@ -0,0 +1,287 @@
+package network
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// maxCapturedBody is how much of each request and response body is written
+// to the capture file.
+const maxCapturedBody = 256 * 1024
+
+const redacted = "[REDACTED]"
+
+// LoggingTransport writes every request and response, with their bodies, to
+// the capture file while HTTP capture is on, and otherwise only passes them
+// on to Base. Credentials are redacted from headers, URLs and bodies before
+// anything is written.
+type LoggingTransport struct {
+	Base http.RoundTripper
+}
+
+var capture struct {
+	mu       sync.Mutex
+	file     *os.File
+	path     string
+	redactor *Redactor
+}
+
+// StartCapture starts writing HTTP traffic to path, replacing any capture in
+// progress. secrets are values, such as tokens and API keys, to redact
+// wherever they appear.
+func StartCapture(path string, secrets ...string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create capture directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open capture file: %w", err)
+	}
+
+	capture.mu.Lock()
+	defer capture.mu.Unlock()
+	if capture.file != nil {
+		capture.file.Close()
+	}
+	capture.file = file
+	capture.path = path
+	capture.redactor = NewRedactor(secrets...)
+
+	logger.Log("Network: Capturing HTTP traffic to %s", path)
+	return nil
+}
+
+// StopCapture stops capturing HTTP traffic and returns the path of the
+// capture file, or "" when capture was off.
+func StopCapture() (string, error) {
+	capture.mu.Lock()
+	defer capture.mu.Unlock()
+	if capture.file == nil {
+		return "", nil
+	}
+	path := capture.path
+	err := capture.file.Close()
+	capture.file = nil
+	capture.path = ""
+	capture.redactor = nil
+	logger.Log("Network: Stopped capturing HTTP traffic to %s", path)
+	return path, err
+}
+
+// CapturePath returns the file HTTP traffic is captured to, or "" when
+// capture is off.
+func CapturePath() string {
+	capture.mu.Lock()
+	defer capture.mu.Unlock()
+	return capture.path
+}
+
+func capturing() bool {
+	capture.mu.Lock()
+	defer capture.mu.Unlock()
+	return capture.file != nil
+}
+
+func (t *LoggingTransport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !capturing() {
+		return t.base().RoundTrip(req)
+	}
+
+	var b strings.Builder
+	start := time.Now()
+	fmt.Fprintf(&b, "=== %s %s %s\n", start.Format("2006-01-02 15:04:05.000"), req.Method, req.URL.String())
+	writeHeaders(&b, req.Header)
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		writeBody(&b, body, req.Header.Get("Content-Type"))
+	}
+
+	resp, err := t.base().RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		fmt.Fprintf(&b, "--- error after %s: %v\n\n", elapsed, err)
+		writeCapture(b.String())
+		return nil, err
+	}
+
+	fmt.Fprintf(&b, "--- %s (%s)\n", resp.Status, elapsed)
+	writeHeaders(&b, resp.Header)
+	if resp.Body != nil {
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		writeBody(&b, body, resp.Header.Get("Content-Type"))
+		if readErr != nil {
+			fmt.Fprintf(&b, "(reading the body failed: %v)\n", readErr)
+			resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{readErr}))
+		}
+	}
+	b.WriteString("\n")
+	writeCapture(b.String())
+	return resp, nil
+}
+
+// errReader returns err once the part of a body that could be read has
+// been consumed.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// writeCapture redacts the known secrets from an exchange and appends it to
+// the capture file, which may have been closed in the meantime.
+func writeCapture(exchange string) {
+	capture.mu.Lock()
+	defer capture.mu.Unlock()
+	if capture.file == nil {
+		return
+	}
+	exchange = capture.redactor.Redact(exchange)
+	if _, err := capture.file.WriteString(exchange); err != nil {
+		logger.LogError("HTTP_CAPTURE", capture.path, err)
+	}
+}
+
+// sensitiveHeaders are redacted whatever their value.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+	"Private-Token":       true,
+}
+
+func writeHeaders(b *strings.Builder, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range header[name] {
+			if sensitiveHeaders[name] || sensitiveName.MatchString(name) {
+				value = redacted
+			}
+			fmt.Fprintf(b, "%s: %s\n", name, value)
+		}
+	}
+}
+
+// writeBody writes text bodies, cut at maxCapturedBody, and only the size of
+// binary ones such as uploaded attachments.
+func writeBody(b *strings.Builder, body []byte, contentType string) {
+	if len(body) == 0 {
+		return
+	}
+	if !isText(body, contentType) {
+		fmt.Fprintf(b, "\n(%d bytes of %s)\n", len(body), contentType)
+		return
+	}
+	b.WriteString("\n")
+	if len(body) > maxCapturedBody {
+		b.Write(body[:maxCapturedBody])
+		fmt.Fprintf(b, "\n(truncated, %d bytes in total)\n", len(body))
+		return
+	}
+	b.Write(body)
+	if body[len(body)-1] != '\n' {
+		b.WriteString("\n")
+	}
+}
+
+func isText(body []byte, contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.Contains(mediaType, "json"),
+		strings.Contains(mediaType, "xml"),
+		mediaType == "application/x-www-form-urlencoded":
+		return true
+	case mediaType == "":
+		return utf8.Valid(body)
+	}
+	return false
+}
+
+// sensitiveName matches header names, JSON keys and form or query
+// parameters whose values are credentials.
+var sensitiveName = regexp.MustCompile(`(?i)token|secret|password|passwd|api[_-]?key|authorization|device_code`)
+
+var (
+	// "access_token": "value"
+	sensitiveJSONField = regexp.MustCompile(`(?i)("[^"]*(?:token|secret|password|passwd|api[_-]?key|authorization|device_code)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// access_token=value in URLs and form bodies
+	sensitiveParam = regexp.MustCompile(`(?i)((?:^|[?&\s])[^=&\s]*(?:token|secret|password|passwd|api[_-]?key|device_code)[^=&\s]*=)[^&\s]*`)
+	// Tokens recognizable by their format, wherever they appear.
+	knownTokens = regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b|(?i:bearer|basic)\s+[A-Za-z0-9._~+/=-]{8,}`)
+)
+
+// Redactor redacts credentials from text written for debugging: the given
+// secrets wherever they appear, and credentials recognizable without
+// knowing them.
+type Redactor struct {
+	secrets []string
+}
+
+// NewRedactor returns a Redactor of secrets, values such as tokens and API
+// keys.
+func NewRedactor(secrets ...string) *Redactor {
+	r := &Redactor{}
+	for _, secret := range secrets {
+		// Very short values would redact unrelated text.
+		if len(secret) >= 8 {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+	// Longer secrets first, so that one containing another is fully redacted.
+	sort.Slice(r.secrets, func(i, j int) bool { return len(r.secrets[i]) > len(r.secrets[j]) })
+	return r
+}
+
+// Redact returns s with the credentials it contains replaced.
+func (r *Redactor) Redact(s string) string {
+	s = redactPatterns(s)
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return s
+}
+
+// redactPatterns replaces credentials that can be recognized without
+// knowing them: values of fields and parameters with sensitive names, and
+// tokens with a known format.
+func redactPatterns(s string) string {
+	s = sensitiveJSONField.ReplaceAllString(s, `${1}"`+redacted+`"`)
+	s = sensitiveParam.ReplaceAllString(s, "${1}"+redacted)
+	return knownTokens.ReplaceAllString(s, redacted)
+}
@@ -0,0 +1,151 @@
+package network
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+const (
+	// metricsSamples is how many of the latest latencies of an endpoint
+	// its percentiles are computed from.
+	metricsSamples = 200
+	// slowCallThreshold is how long a call may take before it is logged.
+	slowCallThreshold = 2 * time.Second
+)
+
+var (
+	numberSegment = regexp.MustCompile(`^\d+$`)
+	uuidSegment   = regexp.MustCompile(`^(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	shaSegment    = regexp.MustCompile(`^(?i)[0-9a-f]*\d[0-9a-f]*$`)
+)
+
+// MetricsTransport times every request passed on to Base and counts those
+// that fail, per endpoint, for the :perf panel. Calls slower than
+// slowCallThreshold are also logged.
+type MetricsTransport struct {
+	Base http.RoundTripper
+}
+
+type endpointSamples struct {
+	calls     int
+	failures  int
+	latencies []time.Duration
+	next      int
+}
+
+var metrics struct {
+	mu        sync.Mutex
+	endpoints map[string]*endpointSamples
+}
+
+func (t *MetricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	endpoint := Endpoint(req)
+	failed := err != nil || resp.StatusCode >= 400
+	record(endpoint, elapsed, failed)
+	if elapsed >= slowCallThreshold {
+		status := "error"
+		if resp != nil {
+			status = resp.Status
+		}
+		logger.Log("Network: %s took %s (%s)", endpoint, elapsed.Round(time.Millisecond), status)
+	}
+	return resp, err
+}
+
+// Endpoint names the endpoint req calls: its method, host and path, with
+// the numbers, commit SHAs and IDs in the path replaced by placeholders so
+// that calls about different PRs add up.
+func Endpoint(req *http.Request) string {
+	segments := strings.Split(req.URL.Path, "/")
+	for i, segment := range segments {
+		switch {
+		case numberSegment.MatchString(segment):
+			segments[i] = ":n"
+		case uuidSegment.MatchString(segment):
+			segments[i] = ":id"
+		case len(segment) >= 7 && shaSegment.MatchString(segment):
+			segments[i] = ":sha"
+		}
+	}
+	return req.Method + " " + req.URL.Host + strings.Join(segments, "/")
+}
+
+func record(endpoint string, elapsed time.Duration, failed bool) {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	if metrics.endpoints == nil {
+		metrics.endpoints = make(map[string]*endpointSamples)
+	}
+	samples, ok := metrics.endpoints[endpoint]
+	if !ok {
+		samples = &endpointSamples{}
+		metrics.endpoints[endpoint] = samples
+	}
+	samples.calls++
+	if failed {
+		samples.failures++
+	}
+	if len(samples.latencies) < metricsSamples {
+		samples.latencies = append(samples.latencies, elapsed)
+	} else {
+		samples.latencies[samples.next] = elapsed
+		samples.next = (samples.next + 1) % metricsSamples
+	}
+}
+
+// Metrics returns the calls made to each endpoint since startup or the last
+// ResetMetrics, slowest first by their 95th percentile.
+func Metrics() []domain.EndpointMetrics {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	result := make([]domain.EndpointMetrics, 0, len(metrics.endpoints))
+	for endpoint, samples := range metrics.endpoints {
+		latencies := append([]time.Duration(nil), samples.latencies...)
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		result = append(result, domain.EndpointMetrics{
+			Endpoint: endpoint,
+			Calls:    samples.calls,
+			Failures: samples.failures,
+			P50:      percentile(latencies, 50),
+			P95:      percentile(latencies, 95),
+			Max:      latencies[len(latencies)-1],
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].P95 != result[j].P95 {
+			return result[i].P95 > result[j].P95
+		}
+		return result[i].Endpoint < result[j].Endpoint
+	})
+	return result
+}
+
+// ResetMetrics forgets the calls made so far.
+func ResetMetrics() {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	metrics.endpoints = nil
+}
+
+// percentile returns the nearest-rank pth percentile of sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
@@ -0,0 +1,78 @@
+package network
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEndpoint_ReplacesIDsInThePath(t *testing.T) {
+	tests := []struct {
+		method string
+		url    string
+		want   string
+	}{
+		{"GET", "https://api.github.com/repos/acme/api/pulls/42/files?page=2", "GET api.github.com/repos/acme/api/pulls/:n/files"},
+		{"GET", "https://api.github.com/repos/acme/api/commits/3f2a9c1d0b/status", "GET api.github.com/repos/acme/api/commits/:sha/status"},
+		{"POST", "https://dev.azure.com/acme/_apis/git/repositories/0b6f8a1e-7c2d-4e5f-9a3b-1c2d3e4f5a6b/pullRequests/7/threads", "POST dev.azure.com/acme/_apis/git/repositories/:id/pullRequests/:n/threads"},
+		{"GET", "https://api.github.com/repos/acme/deadbeef", "GET api.github.com/repos/acme/deadbeef"},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, tt.url, nil)
+		if got := Endpoint(req); got != tt.want {
+			t.Errorf("Endpoint(%s %s) = %q, want %q", tt.method, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestMetricsTransport_CountsCallsAndFailuresPerEndpoint(t *testing.T) {
+	ResetMetrics()
+	defer ResetMetrics()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: &MetricsTransport{Base: base.Clone()}}
+
+	for _, path := range []string{"/pulls/1", "/pulls/2", "/missing"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	byEndpoint := make(map[string][2]int)
+	for _, m := range Metrics() {
+		byEndpoint[m.Endpoint] = [2]int{m.Calls, m.Failures}
+		if m.P50 <= 0 || m.P95 < m.P50 || m.Max < m.P95 {
+			t.Errorf("unexpected latencies for %s: %+v", m.Endpoint, m)
+		}
+	}
+	host := server.Listener.Addr().String()
+	if got := byEndpoint["GET "+host+"/pulls/:n"]; got != [2]int{2, 0} {
+		t.Errorf("expected both PR calls counted together, got %v in %v", got, byEndpoint)
+	}
+	if got := byEndpoint["GET "+host+"/missing"]; got != [2]int{1, 1} {
+		t.Errorf("expected the 404 counted as a failure, got %v in %v", got, byEndpoint)
+	}
+}
+
+func TestPercentile_UsesTheNearestRank(t *testing.T) {
+	var latencies []time.Duration
+	for i := 1; i <= 20; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	if got := percentile(latencies, 50); got != 10*time.Millisecond {
+		t.Errorf("expected a p50 of 10ms, got %s", got)
+	}
+	if got := percentile(latencies, 95); got != 19*time.Millisecond {
+		t.Errorf("expected a p95 of 19ms, got %s", got)
+	}
+	if got := percentile(latencies[:1], 95); got != time.Millisecond {
+		t.Errorf("expected the only sample, got %s", got)
+	}
+}
@@ -0,0 +1,210 @@
+// Package network applies the proxy and TLS settings to the default HTTP
+// transport, times the calls through it and can capture the traffic for
+// debugging. The GitHub, Azure DevOps and Gerrit clients, as well as the
+// OAuth, update and summary requests, all send their requests through it.
+package network
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// base is the standard library's default transport, kept so that
+// configuring again starts from it rather than from the last configuration.
+var base = http.DefaultTransport.(*http.Transport).Clone()
+
+// configured is the transport using the current settings. Requests read it
+// on every call, so Configure can replace it while others are in flight.
+var configured atomic.Pointer[http.Transport]
+
+// configuredTransport sends requests through the configured transport.
+type configuredTransport struct{}
+
+func (configuredTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return configured.Load().RoundTrip(req)
+}
+
+func (configuredTransport) CloseIdleConnections() {
+	configured.Load().CloseIdleConnections()
+}
+
+// init replaces http.DefaultTransport, once and before any request is made,
+// with the configured transport wrapped in a LoggingTransport so that HTTP
+// capture can be turned on at any time and a MetricsTransport timing every
+// call.
+func init() {
+	configured.Store(base.Clone())
+	http.DefaultTransport = &LoggingTransport{Base: &MetricsTransport{Base: configuredTransport{}}}
+}
+
+// Configure makes http.DefaultTransport use settings from the next request
+// on, and closes the idle connections made with the previous settings. On
+// error the settings are not applied, but capture and timing still work.
+func Configure(settings domain.NetworkSettings) error {
+	transport, err := NewTransport(settings)
+	if err != nil {
+		transport = base.Clone()
+	}
+	if previous := configured.Swap(transport); previous != nil {
+		previous.CloseIdleConnections()
+	}
+	return err
+}
+
+// NewTransport returns a transport using settings. Without a configured
+// proxy, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are
+// respected as usual.
+func NewTransport(settings domain.NetworkSettings) (*http.Transport, error) {
+	transport := base.Clone()
+
+	if proxy := strings.TrimSpace(settings.Proxy); proxy != "" {
+		proxyURL, err := parseProxy(proxy)
+		if err != nil {
+			return nil, err
+		}
+		noProxy := settings.NoProxy
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			if bypassProxy(req.URL.Hostname(), noProxy) {
+				return nil, nil
+			}
+			return proxyURL, nil
+		}
+		logger.Log("Network: Using proxy %s", proxyURL.Redacted())
+	}
+
+	if settings.CABundle != "" || settings.InsecureSkipVerify {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if settings.CABundle != "" {
+			pool, err := certPool(settings.CABundle)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.RootCAs = pool
+			logger.Log("Network: Trusting certificates from %s", settings.CABundle)
+		}
+		if settings.InsecureSkipVerify {
+			tlsConfig.InsecureSkipVerify = true
+			logger.Log("Network: TLS certificate verification is disabled")
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return transport, nil
+}
+
+// parseProxy accepts a proxy URL, assuming http:// when no scheme is given.
+func parseProxy(proxy string) (*url.URL, error) {
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q", proxy)
+	}
+	return proxyURL, nil
+}
+
+// bypassProxy reports whether host is one of the noProxy hosts, one of
+// their subdomains or an IP address in one of their CIDR ranges. "*"
+// bypasses the proxy for every host.
+func bypassProxy(host string, noProxy []string) bool {
+	host = strings.ToLower(host)
+	for _, entry := range noProxy {
+		entry = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(entry), "."))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" || host == entry || strings.HasSuffix(host, "."+entry) {
+			return true
+		}
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if ip := net.ParseIP(host); ip != nil && network.Contains(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// certPool returns the system certificates together with those in the PEM
+// file at path.
+func certPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in CA bundle %s", path)
+	}
+	return pool, nil
+}
+
+// IsUnreachable reports whether err means that a server could not be reached
+// at all, as opposed to the server answering with an error: a failed DNS
+// lookup, a refused or reset connection, an unreachable network or a timeout.
+func IsUnreachable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	for _, errno := range []syscall.Errno{syscall.ECONNREFUSED, syscall.ECONNRESET, syscall.ENETUNREACH, syscall.EHOSTUNREACH} {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// NeverSent reports whether err means that a request never reached the
+// server: a failed DNS lookup or a connection that could not be made. Unlike
+// a reset connection or a timeout, which IsUnreachable also counts, the
+// server cannot have acted on the request, so a request that is not
+// idempotent can safely be sent again.
+func NeverSent(err error) bool {
+	if err == nil {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && (opErr.Op == "dial" || opErr.Op == "proxyconnect") {
+		return true
+	}
+	for _, errno := range []syscall.Errno{syscall.ECONNREFUSED, syscall.ENETUNREACH, syscall.EHOSTUNREACH} {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package network
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestBypassProxy(t *testing.T) {
+	noProxy := []string{"localhost", ".corp.example.com", "10.0.0.0/8"}
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"localhost", true},
+		{"git.corp.example.com", true},
+		{"corp.example.com", true},
+		{"notcorp.example.com", false},
+		{"10.1.2.3", true},
+		{"192.168.1.1", false},
+		{"github.com", false},
+	}
+	for _, tt := range tests {
+		if got := bypassProxy(tt.host, noProxy); got != tt.want {
+			t.Errorf("bypassProxy(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+	if !bypassProxy("github.com", []string{"*"}) {
+		t.Error("expected * to bypass the proxy for every host")
+	}
+}
+
+func TestNewTransport_UsesProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Write([]byte("via proxy"))
+	}))
+	defer proxy.Close()
+
+	transport, err := NewTransport(domain.NetworkSettings{Proxy: proxy.Listener.Addr().String(), NoProxy: []string{"internal.example.com"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Get("http://api.example.com/repos")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if proxied != "http://api.example.com/repos" {
+		t.Errorf("expected the request to go through the proxy, got %q", proxied)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://internal.example.com/", nil)
+	if proxyURL, _ := transport.Proxy(req); proxyURL != nil {
+		t.Errorf("expected NoProxy hosts to be reached directly, got %v", proxyURL)
+	}
+}
+
+func TestConfigure_KeepsTheDefaultTransport(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Write([]byte("via proxy"))
+	}))
+	defer proxy.Close()
+	defer Configure(domain.NetworkSettings{})
+
+	transport := http.DefaultTransport
+	if err := Configure(domain.NetworkSettings{Proxy: proxy.Listener.Addr().String()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if http.DefaultTransport != transport {
+		t.Fatal("expected http.DefaultTransport not to be replaced")
+	}
+
+	resp, err := http.Get("http://api.example.com/repos")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if proxied != "http://api.example.com/repos" {
+		t.Errorf("expected the request to use the new settings, got %q", proxied)
+	}
+}
+
+func TestNewTransport_InvalidProxy(t *testing.T) {
+	if _, err := NewTransport(domain.NetworkSettings{Proxy: "http://"}); err == nil {
+		t.Error("expected an error for a proxy URL without a host")
+	}
+}
+
+func TestNewTransport_TrustsCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	plain, _ := NewTransport(domain.NetworkSettings{})
+	if _, err := (&http.Client{Transport: plain}).Get(server.URL); err == nil {
+		t.Fatal("expected the test server's certificate to be untrusted by default")
+	}
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundle, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	transport, err := NewTransport(domain.NetworkSettings{CABundle: bundle})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the CA bundle to be trusted, got %v", err)
+	}
+	resp.Body.Close()
+
+	insecure, _ := NewTransport(domain.NetworkSettings{InsecureSkipVerify: true})
+	resp, err = (&http.Client{Transport: insecure}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected verification to be skipped, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestNewTransport_InvalidCABundle(t *testing.T) {
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	os.WriteFile(bundle, []byte("not a certificate"), 0o644)
+
+	if _, err := NewTransport(domain.NetworkSettings{CABundle: bundle}); err == nil {
+		t.Error("expected an error for a bundle without certificates")
+	}
+	if _, err := NewTransport(domain.NetworkSettings{CABundle: bundle + ".missing"}); err == nil {
+		t.Error("expected an error for a missing bundle")
+	}
+}
+
+func TestIsUnreachable(t *testing.T) {
+	refused := &url.Error{Op: "Get", URL: "https://example.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"refused", fmt.Errorf("failed to list PRs: %w", refused), true},
+		{"dns", &net.DNSError{Err: "no such host", Name: "example.com"}, true},
+		{"deadline", fmt.Errorf("wrapped: %w", context.DeadlineExceeded), true},
+		{"http status", errors.New("gerrit returned 500: internal error"), false},
+		{"cancelled", context.Canceled, false},
+	}
+	for _, tc := range cases {
+		if got := IsUnreachable(tc.err); got != tc.want {
+			t.Errorf("%s: IsUnreachable = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestNeverSent(t *testing.T) {
+	refused := &url.Error{Op: "Post", URL: "https://example.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}
+	reset := &url.Error{Op: "Post", URL: "https://example.com", Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}}
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"refused", fmt.Errorf("failed to submit review: %w", refused), true},
+		{"dns", &net.DNSError{Err: "no such host", Name: "example.com"}, true},
+		{"reset after sending", reset, false},
+		{"deadline", fmt.Errorf("wrapped: %w", context.DeadlineExceeded), false},
+		{"http status", errors.New("gerrit returned 500: internal error"), false},
+	}
+	for _, tc := range cases {
+		if got := NeverSent(tc.err); got != tc.want {
+			t.Errorf("%s: NeverSent = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
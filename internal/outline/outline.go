@@ -0,0 +1,202 @@
+// Package outline lists the functions and types a diff touches, so that a
+// review can go through a change symbol by symbol rather than hunk by hunk.
+// Go files are parsed when their new content is known; other files, and Go
+// files that could not be parsed, fall back to the enclosing function that
+// git names in each hunk header.
+package outline
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+// Symbol is a function, method or type the diff changes. FileIndex and
+// LineIndex locate its first changed line among the diff lines of its file,
+// and Changes counts its added and deleted lines.
+type Symbol struct {
+	Name      string
+	Kind      string
+	FilePath  string
+	FileIndex int
+	LineIndex int
+	Line      int
+	Changes   int
+}
+
+// declaration is the range of new-side lines a symbol spans.
+type declaration struct {
+	name  string
+	kind  string
+	start int
+	end   int
+}
+
+// IsGoFile reports whether the symbols of path are found by parsing it.
+func IsGoFile(filePath string) bool {
+	return path.Ext(filePath) == ".go"
+}
+
+// Build lists the symbols changed by diff, file by file and in the order
+// they appear. contents holds the new content of the files known, by path.
+func Build(diff *domain.Diff, contents map[string]string) []Symbol {
+	if diff == nil {
+		return nil
+	}
+	var symbols []Symbol
+	for fileIndex, file := range diff.Files {
+		filePath := file.NewPath
+		if filePath == "" {
+			filePath = file.OldPath
+		}
+		var declarations []declaration
+		if content, ok := contents[filePath]; ok && IsGoFile(filePath) {
+			declarations = goDeclarations(content)
+		}
+		symbols = append(symbols, fileSymbols(file, fileIndex, filePath, declarations)...)
+	}
+	return symbols
+}
+
+// fileSymbols groups the changed lines of file by the declaration holding
+// them, or by the heading of their hunk when none does.
+func fileSymbols(file domain.FileDiff, fileIndex int, filePath string, declarations []declaration) []Symbol {
+	var symbols []Symbol
+	byName := make(map[string]int)
+	add := func(name, kind string, lineIdx, line int) {
+		if i, ok := byName[name]; ok {
+			symbols[i].Changes++
+			return
+		}
+		byName[name] = len(symbols)
+		symbols = append(symbols, Symbol{
+			Name:      name,
+			Kind:      kind,
+			FilePath:  filePath,
+			FileIndex: fileIndex,
+			LineIndex: lineIdx,
+			Line:      line,
+			Changes:   1,
+		})
+	}
+
+	lineIdx := 0
+	for _, hunk := range file.Hunks {
+		heading := hunkHeading(hunk.Header)
+		// nextNew is the new-side line a deleted line would have been at.
+		nextNew := 0
+		for _, line := range hunk.Lines {
+			if line.Type != "delete" && line.NewLine > 0 {
+				nextNew = line.NewLine + 1
+			}
+			if line.Type == "add" || line.Type == "delete" {
+				at := line.NewLine
+				if line.Type == "delete" {
+					at = nextNew
+				}
+				switch decl, ok := enclosing(declarations, at); {
+				case ok:
+					add(decl.name, decl.kind, lineIdx, at)
+				case heading != "":
+					add(heading, "", lineIdx, at)
+				}
+			}
+			lineIdx++
+		}
+	}
+	return symbols
+}
+
+// hunkHeading returns the text git writes after a hunk's line ranges,
+// usually the signature of the function the hunk is in.
+func hunkHeading(header string) string {
+	if !strings.HasPrefix(header, "@@") {
+		return ""
+	}
+	end := strings.Index(header[2:], "@@")
+	if end < 0 {
+		return ""
+	}
+	return strings.TrimSpace(header[end+4:])
+}
+
+// enclosing returns the innermost declaration spanning line.
+func enclosing(declarations []declaration, line int) (declaration, bool) {
+	found := false
+	var best declaration
+	for _, decl := range declarations {
+		if line >= decl.start && line <= decl.end && (!found || decl.end-decl.start < best.end-best.start) {
+			best, found = decl, true
+		}
+	}
+	return best, found
+}
+
+// goDeclarations returns the functions, methods and types of a Go file,
+// including their doc comments. A file that does not parse has none.
+func goDeclarations(content string) []declaration {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", content, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil && file == nil {
+		return nil
+	}
+
+	var declarations []declaration
+	span := func(node ast.Node, doc *ast.CommentGroup) (int, int) {
+		start := node.Pos()
+		if doc != nil {
+			start = doc.Pos()
+		}
+		return fset.Position(start).Line, fset.Position(node.End()).Line
+	}
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			start, end := span(decl, decl.Doc)
+			name, kind := "func "+decl.Name.Name, "func"
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				name, kind = "func ("+receiverType(decl.Recv.List[0].Type)+") "+decl.Name.Name, "method"
+			}
+			declarations = append(declarations, declaration{name: name, kind: kind, start: start, end: end})
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				doc := typeSpec.Doc
+				if len(decl.Specs) == 1 {
+					doc = decl.Doc
+				}
+				start, end := span(typeSpec, doc)
+				if len(decl.Specs) == 1 {
+					_, end = span(decl, nil)
+				}
+				declarations = append(declarations, declaration{name: "type " + typeSpec.Name.Name, kind: "type", start: start, end: end})
+			}
+		}
+	}
+	sort.SliceStable(declarations, func(i, j int) bool { return declarations[i].start < declarations[j].start })
+	return declarations
+}
+
+// receiverType names a method receiver's type as it is written, without
+// type parameters.
+func receiverType(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return "*" + receiverType(expr.X)
+	case *ast.IndexExpr:
+		return receiverType(expr.X)
+	case *ast.IndexListExpr:
+		return receiverType(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	}
+	return "?"
+}
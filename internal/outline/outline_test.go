@@ -0,0 +1,109 @@
+package outline
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+const serverGo = `package server
+
+// Config holds the settings.
+type Config struct {
+	Addr    string
+	Timeout int
+}
+
+type Server struct{ cfg Config }
+
+// Start listens on the configured address.
+func (s *Server) Start() error {
+	if s.cfg.Addr == "" {
+		return errNoAddr
+	}
+	return nil
+}
+
+func New(cfg Config) *Server {
+	return &Server{cfg: cfg}
+}
+`
+
+const serverDiff = `diff --git a/server.go b/server.go
+--- a/server.go
++++ b/server.go
+@@ -3,5 +3,6 @@ package server
+ // Config holds the settings.
+ type Config struct {
+ 	Addr    string
++	Timeout int
+ }
+ 
+@@ -10,5 +11,8 @@ type Server struct{ cfg Config }
+ // Start listens on the configured address.
+ func (s *Server) Start() error {
++	if s.cfg.Addr == "" {
++		return errNoAddr
++	}
+ 	return nil
+ }
+ 
+@@ -15,3 +19,3 @@ func (s *Server) Start() error {
+ func New(cfg Config) *Server {
+-	return &Server{cfg}
++	return &Server{cfg: cfg}
+ }
+diff --git a/web/app.js b/web/app.js
+--- a/web/app.js
++++ b/web/app.js
+@@ -10,3 +10,3 @@ function render(state) {
+   const root = document.body;
+-  root.innerHTML = state.html;
++  root.textContent = state.text;
+ }
+`
+
+func TestBuild_ListsTheChangedSymbols(t *testing.T) {
+	diff := common.ParseUnifiedDiff(serverDiff)
+
+	symbols := Build(diff, map[string]string{"server.go": serverGo})
+
+	type summary struct {
+		Name      string
+		FileIndex int
+		LineIndex int
+		Changes   int
+	}
+	var got []summary
+	for _, s := range symbols {
+		got = append(got, summary{s.Name, s.FileIndex, s.LineIndex, s.Changes})
+	}
+	want := []summary{
+		{"type Config", 0, 3, 1},
+		{"func (*Server) Start", 0, 8, 3},
+		{"func New", 0, 15, 2},
+		{"function render(state) {", 1, 1, 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected outline:\n got %+v\nwant %+v", got, want)
+	}
+	if symbols[1].Kind != "method" || symbols[1].Line != 13 {
+		t.Errorf("expected Start to be a method changed from line 13, got %+v", symbols[1])
+	}
+}
+
+func TestBuild_FallsBackToHunkHeadingsWithoutContent(t *testing.T) {
+	diff := common.ParseUnifiedDiff(serverDiff)
+
+	symbols := Build(diff, nil)
+
+	var names []string
+	for _, s := range symbols {
+		names = append(names, s.Name)
+	}
+	want := []string{"package server", "type Server struct{ cfg Config }", "func (s *Server) Start() error {", "function render(state) {"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("expected the hunk headings, got %q", names)
+	}
+}
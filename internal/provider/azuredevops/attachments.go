@@ -0,0 +1,29 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// UploadAttachment attaches a file to the pull request. Attachment names must
+// be unique within a pull request, so the name is prefixed with the upload
+// time.
+func (p *Provider) UploadAttachment(ctx context.Context, identifier domain.PRIdentifier, fileName string, content []byte) (string, error) {
+	projectID, repoID, err := p.resolveProjectAndRepoWithCache(ctx, identifier.Repository)
+	if err != nil {
+		return "", err
+	}
+
+	name := strconv.FormatInt(time.Now().UnixNano(), 36) + "-" + fileName
+	url, err := p.client.CreateAttachment(ctx, projectID, repoID, identifier.Number, name, content)
+	if err != nil {
+		logger.LogError("AZURE_UPLOAD_ATTACHMENT", fmt.Sprintf("%s#%d %s", identifier.Repository, identifier.Number, fileName), err)
+		return "", err
+	}
+	return url, nil
+}
@@ -1,21 +1,35 @@
 package azuredevops
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"path"
 	"strings"
+	"sync"
 
+	"github.com/johanforsgren/lgtmfaster/internal/crash"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
 	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/policy"
 	"github.com/sergi/go-diff/diffmatchpatch"
+	"golang.org/x/oauth2"
 )
 
+const searchResultLimit = 200
+
 type Client struct {
 	connection   *azuredevops.Connection
 	coreClient   core.Client
 	gitClient    GitClientInterface
+	policyClient policy.Client
 	organization string
 	username     string
 	userID       string
@@ -23,36 +37,75 @@ type Client struct {
 
 func NewClient(token string, organization string, username string) (*Client, error) {
 	organizationURL := fmt.Sprintf("https://dev.azure.com/%s", organization)
-	connection := azuredevops.NewPatConnection(organizationURL, token)
+	client, err := newClient(azuredevops.NewPatConnection(organizationURL, token), common.NewRetryTransport(nil), organization, username)
+	if err != nil {
+		return nil, err
+	}
+	client.resolveUserID()
+	return client, nil
+}
+
+// NewOAuthClient creates a client authenticated with Azure AD access tokens
+// from ts instead of a PAT. The tokens are requested per call, so an expired
+// token is refreshed by the request that needs it; for the same reason the
+// user ID is resolved when first needed rather than up front.
+func NewOAuthClient(ts oauth2.TokenSource, organization string, username string) (*Client, error) {
+	organizationURL := fmt.Sprintf("https://dev.azure.com/%s", organization)
+	transport := &oauth2.Transport{Source: ts, Base: common.NewRetryTransport(nil)}
+	return newClient(azuredevops.NewAnonymousConnection(organizationURL), transport, organization, username)
+}
+
+func newClient(connection *azuredevops.Connection, transport http.RoundTripper, organization string, username string) (*Client, error) {
+	// The SDK gives no way to set the transport of its clients other than
+	// replacing their HTTP client, starting with the one that looks up the
+	// URLs of the others.
+	retry := azuredevops.WithHTTPClient(&http.Client{Transport: transport})
+	retry(connection.GetClientByUrl(connection.BaseUrl))
 
 	coreClient, err := core.NewClient(context.Background(), connection)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create core client: %w", err)
 	}
+	if impl, ok := coreClient.(*core.ClientImpl); ok {
+		retry(&impl.Client)
+	}
 
 	gitClient, err := git.NewClient(context.Background(), connection)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create git client: %w", err)
 	}
+	if impl, ok := gitClient.(*git.ClientImpl); ok {
+		retry(&impl.Client)
+	}
+
+	policyClient, err := policy.NewClient(context.Background(), connection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create policy client: %w", err)
+	}
+	if impl, ok := policyClient.(*policy.ClientImpl); ok {
+		retry(&impl.Client)
+	}
 
 	client := &Client{
 		connection:   connection,
 		coreClient:   coreClient,
 		gitClient:    gitClient,
+		policyClient: policyClient,
 		organization: organization,
 		username:     username,
 	}
+	return client, nil
+}
 
-	userID, err := client.getAuthenticatedUserID(context.Background())
+func (c *Client) resolveUserID() {
+	userID, err := c.getAuthenticatedUserID(context.Background())
 	if err != nil {
 		logger.Log("AzureDevOps: Warning - Could not determine user ID during initialization: %v", err)
 		logger.Log("AzureDevOps: User ID will be resolved when needed for review submission")
-	} else {
-		client.userID = userID
-		logger.Log("AzureDevOps: Authenticated user ID: %s (username: %s)", userID, username)
+		return
 	}
-
-	return client, nil
+	c.userID = userID
+	logger.Log("AzureDevOps: Authenticated user ID: %s (username: %s)", userID, c.username)
 }
 
 func (c *Client) ValidateCredentials(ctx context.Context) error {
@@ -60,7 +113,7 @@ func (c *Client) ValidateCredentials(ctx context.Context) error {
 		Top: intPtr(1),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to validate credentials: %w", err)
+		return common.AuthError(statusCode(err), fmt.Errorf("failed to validate credentials: %w", err))
 	}
 	if projects == nil {
 		return fmt.Errorf("failed to validate credentials: no response")
@@ -68,6 +121,20 @@ func (c *Client) ValidateCredentials(ctx context.Context) error {
 	return nil
 }
 
+// statusCode returns the HTTP status of a failed Azure DevOps request, or 0
+// when the request did not get a response.
+func statusCode(err error) int {
+	var wrapped azuredevops.WrappedError
+	if errors.As(err, &wrapped) && wrapped.StatusCode != nil {
+		return *wrapped.StatusCode
+	}
+	var wrappedPtr *azuredevops.WrappedError
+	if errors.As(err, &wrappedPtr) && wrappedPtr.StatusCode != nil {
+		return *wrappedPtr.StatusCode
+	}
+	return 0
+}
+
 func (c *Client) GetAuthenticatedUserID(ctx context.Context) (string, error) {
 	if c.userID != "" {
 		return c.userID, nil
@@ -189,6 +256,21 @@ func (c *Client) ListPullRequests(ctx context.Context, projectID string, repoID
 	return prs, nil
 }
 
+func (c *Client) SearchPullRequests(ctx context.Context, project string, criteria git.GitPullRequestSearchCriteria) (*[]git.GitPullRequest, error) {
+	prs, err := c.gitClient.GetPullRequestsByProject(ctx, git.GetPullRequestsByProjectArgs{
+		Project:        &project,
+		SearchCriteria: &criteria,
+		Top:            intPtr(searchResultLimit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search pull requests in project '%s': %w", project, err)
+	}
+	if prs == nil {
+		return &[]git.GitPullRequest{}, nil
+	}
+	return prs, nil
+}
+
 func (c *Client) GetPullRequest(ctx context.Context, projectID string, repoID string, pullRequestID int) (*git.GitPullRequest, error) {
 	pr, err := c.gitClient.GetPullRequest(ctx, git.GetPullRequestArgs{
 		RepositoryId:  &repoID,
@@ -201,6 +283,52 @@ func (c *Client) GetPullRequest(ctx context.Context, projectID string, repoID st
 	return pr, nil
 }
 
+// GetPolicyEvaluations returns the branch policies evaluated against a pull
+// request. projectID must be the project GUID, not its name.
+func (c *Client) GetPolicyEvaluations(ctx context.Context, projectID string, pullRequestID int) ([]policy.PolicyEvaluationRecord, error) {
+	artifactID := fmt.Sprintf("vstfs:///CodeReview/CodeReviewId/%s/%d", projectID, pullRequestID)
+	evaluations, err := c.policyClient.GetPolicyEvaluations(ctx, policy.GetPolicyEvaluationsArgs{
+		Project:    &projectID,
+		ArtifactId: &artifactID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy evaluations for pull request %d: %w", pullRequestID, err)
+	}
+	if evaluations == nil {
+		return nil, nil
+	}
+	return *evaluations, nil
+}
+
+// GetPullRequestConflicts returns the merge conflicts Azure DevOps found when
+// it last tried to merge the pull request. The git client of the SDK does not
+// cover this endpoint, so it is requested directly.
+func (c *Client) GetPullRequestConflicts(ctx context.Context, projectID string, repoID string, pullRequestID int) ([]git.GitConflict, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("no connection available")
+	}
+
+	baseURL := strings.TrimSuffix(c.connection.BaseUrl, "/")
+	requestURL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullRequests/%d/conflicts",
+		baseURL, url.PathEscape(projectID), url.PathEscape(repoID), pullRequestID)
+
+	client := c.connection.GetClientByUrl(baseURL)
+	request, err := client.CreateRequestMessage(ctx, http.MethodGet, requestURL, "7.1-preview.1", nil, "", azuredevops.MediaTypeApplicationJson, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create conflicts request: %w", err)
+	}
+	response, err := client.SendRequest(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conflicts for pull request %d: %w", pullRequestID, err)
+	}
+
+	var conflicts []git.GitConflict
+	if err := client.UnmarshalCollectionBody(response, &conflicts); err != nil {
+		return nil, fmt.Errorf("failed to decode conflicts for pull request %d: %w", pullRequestID, err)
+	}
+	return conflicts, nil
+}
+
 func (c *Client) GetPullRequestCommits(ctx context.Context, projectID string, repoID string, pullRequestID int) (*[]git.GitCommitRef, error) {
 	response, err := c.gitClient.GetPullRequestCommits(ctx, git.GetPullRequestCommitsArgs{
 		RepositoryId:  &repoID,
@@ -216,7 +344,91 @@ func (c *Client) GetPullRequestCommits(ctx context.Context, projectID string, re
 	return &response.Value, nil
 }
 
-func (c *Client) GetPullRequestIterationChanges(ctx context.Context, projectID string, repoID string, pullRequestID int) (string, error) {
+// ChangeEntry is a file changed by the latest iteration of a pull request.
+// Paths keep the leading slash Azure DevOps reports them with.
+type ChangeEntry struct {
+	Path             string
+	ChangeType       int
+	ObjectID         string
+	OriginalObjectID string
+}
+
+const (
+	changeTypeAdd    = 1
+	changeTypeEdit   = 2
+	changeTypeDelete = 16
+)
+
+// maxConcurrentBlobFetches bounds the files whose content is fetched at once
+// while building the diff of a PR.
+const maxConcurrentBlobFetches = 8
+
+// GetPullRequestIterationChanges returns the unified diff of the latest PR
+// iteration, fetching the contents of several files at once. Binary files appear as git "Binary files ... differ" entries;
+// their sizes and blob URLs are returned separately, keyed by path without the
+// leading slash.
+func (c *Client) GetPullRequestIterationChanges(ctx context.Context, projectID string, repoID string, pullRequestID int) (string, map[string]domain.BinaryInfo, error) {
+	entries, err := c.ListPullRequestChangeEntries(ctx, projectID, repoID, pullRequestID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	type entryDiff struct {
+		text   string
+		binary *domain.BinaryInfo
+		err    error
+	}
+	diffs := make([]entryDiff, len(entries))
+	sem := make(chan struct{}, maxConcurrentBlobFetches)
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int, entry ChangeEntry) {
+			defer crash.Guard()
+			defer wg.Done()
+			defer func() { <-sem }()
+			text, binary, err := c.GetChangeEntryDiff(ctx, projectID, repoID, entry)
+			diffs[i] = entryDiff{text: text, binary: binary, err: err}
+		}(i, entry)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return "", nil, fmt.Errorf("failed to get PR iteration changes: %w", err)
+	}
+
+	var diffText strings.Builder
+	binaries := make(map[string]domain.BinaryInfo)
+	processedFiles := 0
+	skippedFiles := 0
+
+	for i, entry := range entries {
+		if diffs[i].err != nil {
+			skippedFiles++
+			continue
+		}
+		processedFiles++
+		diffText.WriteString(diffs[i].text)
+		if diffs[i].binary != nil {
+			binaries[strings.TrimPrefix(entry.Path, "/")] = *diffs[i].binary
+		}
+	}
+
+	logger.Log("AzureDevOps: Processed %d file(s), skipped %d file(s) for PR #%d", processedFiles, skippedFiles, pullRequestID)
+
+	return diffText.String(), binaries, nil
+}
+
+// ListPullRequestChangeEntries lists the files changed by the latest PR
+// iteration without fetching their content. Folders and changes that cannot
+// be shown as a diff are left out.
+func (c *Client) ListPullRequestChangeEntries(ctx context.Context, projectID string, repoID string, pullRequestID int) ([]ChangeEntry, error) {
 	iterations, err := c.gitClient.GetPullRequestIterations(ctx, git.GetPullRequestIterationsArgs{
 		RepositoryId:  &repoID,
 		PullRequestId: &pullRequestID,
@@ -224,19 +436,19 @@ func (c *Client) GetPullRequestIterationChanges(ctx context.Context, projectID s
 	})
 	if err != nil {
 		logger.LogError("AZURE_GET_ITERATIONS", fmt.Sprintf("project=%s repo=%s PR=%d", projectID, repoID, pullRequestID), err)
-		return "", fmt.Errorf("failed to get PR iterations: %w", err)
+		return nil, fmt.Errorf("failed to get PR iterations: %w", err)
 	}
 
 	if iterations == nil || len(*iterations) == 0 {
 		logger.LogError("AZURE_NO_ITERATIONS", fmt.Sprintf("project=%s repo=%s PR=%d", projectID, repoID, pullRequestID), fmt.Errorf("PR has no iterations"))
-		return "", fmt.Errorf("no iterations found for PR #%d - this PR may not have any commits yet", pullRequestID)
+		return nil, fmt.Errorf("no iterations found for PR #%d - this PR may not have any commits yet", pullRequestID)
 	}
 
 	logger.Log("AzureDevOps: Found %d iteration(s) for PR #%d", len(*iterations), pullRequestID)
 
 	latestIteration := (*iterations)[len(*iterations)-1]
 	if latestIteration.Id == nil {
-		return "", fmt.Errorf("latest iteration has no ID")
+		return nil, fmt.Errorf("latest iteration has no ID")
 	}
 
 	changes, err := c.gitClient.GetPullRequestIterationChanges(ctx, git.GetPullRequestIterationChangesArgs{
@@ -247,39 +459,33 @@ func (c *Client) GetPullRequestIterationChanges(ctx context.Context, projectID s
 	})
 	if err != nil {
 		logger.LogError("AZURE_GET_ITERATION_CHANGES", fmt.Sprintf("project=%s repo=%s PR=%d iteration=%d", projectID, repoID, pullRequestID, *latestIteration.Id), err)
-		return "", fmt.Errorf("failed to get PR iteration changes: %w", err)
+		return nil, fmt.Errorf("failed to get PR iteration changes: %w", err)
 	}
 
 	if changes == nil || changes.ChangeEntries == nil || len(*changes.ChangeEntries) == 0 {
 		logger.LogError("AZURE_NO_CHANGES", fmt.Sprintf("project=%s repo=%s PR=%d iteration=%d", projectID, repoID, pullRequestID, *latestIteration.Id), fmt.Errorf("no change entries"))
-		return "", fmt.Errorf("no changes found in latest iteration %d for PR #%d", *latestIteration.Id, pullRequestID)
+		return nil, fmt.Errorf("no changes found in latest iteration %d for PR #%d", *latestIteration.Id, pullRequestID)
 	}
 
 	logger.Log("AzureDevOps: Found %d change(s) in iteration %d for PR #%d", len(*changes.ChangeEntries), *latestIteration.Id, pullRequestID)
 
-	diffText := ""
-	processedFiles := 0
-	skippedFiles := 0
-
+	var entries []ChangeEntry
 	for idx, change := range *changes.ChangeEntries {
 		itemMap, ok := change.Item.(map[string]interface{})
 		if !ok || itemMap == nil {
 			logger.Log("AzureDevOps: Change %d/%d - skipped (item is not a map or is nil)", idx+1, len(*changes.ChangeEntries))
-			skippedFiles++
 			continue
 		}
 
 		path, _ := itemMap["path"].(string)
 		if path == "" {
 			logger.Log("AzureDevOps: Change %d/%d - skipped (path is empty)", idx+1, len(*changes.ChangeEntries))
-			skippedFiles++
 			continue
 		}
 
 		isFolder, _ := itemMap["isFolder"].(bool)
 		if isFolder {
 			logger.Log("AzureDevOps: Change %d/%d - skipped folder: %s", idx+1, len(*changes.ChangeEntries), path)
-			skippedFiles++
 			continue
 		}
 
@@ -293,108 +499,124 @@ func (c *Client) GetPullRequestIterationChanges(ctx context.Context, projectID s
 			changeTypeStr = string(*change.ChangeType)
 			switch changeTypeStr {
 			case "add", "1":
-				changeType = 1
+				changeType = changeTypeAdd
 			case "edit", "2":
-				changeType = 2
+				changeType = changeTypeEdit
 			case "delete", "16":
-				changeType = 16
+				changeType = changeTypeDelete
 			}
 		}
 
 		logger.Log("AzureDevOps: Change %d/%d - path=%s, changeType=%s (%d), objectId=%s, originalObjectId=%s",
 			idx+1, len(*changes.ChangeEntries), path, changeTypeStr, changeType, objectId, originalObjectId)
 
-		const (
-			changeTypeAdd    = 1
-			changeTypeEdit   = 2
-			changeTypeDelete = 16
-		)
-
-		switch changeType {
-		case changeTypeAdd:
-			if objectId == "" {
-				logger.Log("AzureDevOps: Change %d/%d - skipped ADD (objectId is empty)", idx+1, len(*changes.ChangeEntries))
-				skippedFiles++
-				continue
-			}
-			content, err := c.getFileContent(ctx, projectID, repoID, objectId)
-			if err != nil {
-				logger.LogError("AZURE_GET_FILE_CONTENT", fmt.Sprintf("path=%s objectId=%s", path, objectId), err)
-				skippedFiles++
-				continue
-			}
-			processedFiles++
-			diffText += fmt.Sprintf("diff --git a%s b%s\n", path, path)
-			diffText += "--- /dev/null\n"
-			diffText += fmt.Sprintf("+++ b%s\n", path)
-			diffText += fmt.Sprintf("@@ -0,0 +1,%d @@\n", len(content))
-			for _, line := range content {
-				diffText += "+" + line + "\n"
-			}
+		switch {
+		case changeType == 0:
+			logger.Log("AzureDevOps: Change %d/%d - skipped UNKNOWN changeType=%s for path=%s",
+				idx+1, len(*changes.ChangeEntries), changeTypeStr, path)
+			continue
+		case changeType != changeTypeDelete && objectId == "",
+			changeType != changeTypeAdd && originalObjectId == "":
+			logger.Log("AzureDevOps: Change %d/%d - skipped changeType=%s (objectId=%s, originalObjectId=%s)",
+				idx+1, len(*changes.ChangeEntries), changeTypeStr, objectId, originalObjectId)
+			continue
+		}
 
-		case changeTypeDelete:
-			if originalObjectId == "" {
-				logger.Log("AzureDevOps: Change %d/%d - skipped DELETE (originalObjectId is empty)", idx+1, len(*changes.ChangeEntries))
-				skippedFiles++
-				continue
-			}
-			content, err := c.getFileContent(ctx, projectID, repoID, originalObjectId)
-			if err != nil {
-				logger.LogError("AZURE_GET_FILE_CONTENT", fmt.Sprintf("path=%s originalObjectId=%s", path, originalObjectId), err)
-				skippedFiles++
-				continue
-			}
-			processedFiles++
-			diffText += fmt.Sprintf("diff --git a%s b%s\n", path, path)
-			diffText += fmt.Sprintf("--- a%s\n", path)
-			diffText += "+++ /dev/null\n"
-			diffText += fmt.Sprintf("@@ -1,%d +0,0 @@\n", len(content))
-			for _, line := range content {
-				diffText += "-" + line + "\n"
-			}
+		entries = append(entries, ChangeEntry{
+			Path:             path,
+			ChangeType:       changeType,
+			ObjectID:         objectId,
+			OriginalObjectID: originalObjectId,
+		})
+	}
+	return entries, nil
+}
 
-		case changeTypeEdit:
-			if objectId == "" || originalObjectId == "" {
-				logger.Log("AzureDevOps: Change %d/%d - skipped EDIT (objectId=%s, originalObjectId=%s)",
-					idx+1, len(*changes.ChangeEntries), objectId, originalObjectId)
-				skippedFiles++
-				continue
-			}
+// GetChangeEntryDiff fetches the content of a changed file and returns its
+// unified diff. Binary files get a git "Binary files ... differ" entry and
+// their sizes and blob URL.
+func (c *Client) GetChangeEntryDiff(ctx context.Context, projectID string, repoID string, entry ChangeEntry) (string, *domain.BinaryInfo, error) {
+	path := entry.Path
+	diffText := ""
 
-			newContent, err := c.getFileContent(ctx, projectID, repoID, objectId)
-			if err != nil {
-				logger.LogError("AZURE_GET_FILE_CONTENT", fmt.Sprintf("path=%s objectId=%s (new)", path, objectId), err)
-				skippedFiles++
-				continue
-			}
-			oldContent, err := c.getFileContent(ctx, projectID, repoID, originalObjectId)
-			if err != nil {
-				logger.LogError("AZURE_GET_FILE_CONTENT", fmt.Sprintf("path=%s originalObjectId=%s (old)", path, originalObjectId), err)
-				skippedFiles++
-				continue
-			}
-			processedFiles++
+	switch entry.ChangeType {
+	case changeTypeAdd:
+		blob, err := c.getBlob(ctx, projectID, repoID, entry.ObjectID)
+		if err != nil {
+			logger.LogError("AZURE_GET_FILE_CONTENT", fmt.Sprintf("path=%s objectId=%s", path, entry.ObjectID), err)
+			return "", nil, err
+		}
+		if isBinaryContent(blob) {
+			return binaryFileDiff(path, true, false), &domain.BinaryInfo{
+				OldSize: -1,
+				NewSize: int64(len(blob)),
+				BlobURL: c.blobURL(projectID, repoID, entry.ObjectID, path),
+			}, nil
+		}
+		content := splitLines(blob)
+		diffText += fmt.Sprintf("diff --git a%s b%s\n", path, path)
+		diffText += "--- /dev/null\n"
+		diffText += fmt.Sprintf("+++ b%s\n", path)
+		diffText += fmt.Sprintf("@@ -0,0 +1,%d @@\n", len(content))
+		for _, line := range content {
+			diffText += "+" + line + "\n"
+		}
 
-			diffText += fmt.Sprintf("diff --git a%s b%s\n", path, path)
-			diffText += fmt.Sprintf("--- a%s\n", path)
-			diffText += fmt.Sprintf("+++ b%s\n", path)
+	case changeTypeDelete:
+		blob, err := c.getBlob(ctx, projectID, repoID, entry.OriginalObjectID)
+		if err != nil {
+			logger.LogError("AZURE_GET_FILE_CONTENT", fmt.Sprintf("path=%s originalObjectId=%s", path, entry.OriginalObjectID), err)
+			return "", nil, err
+		}
+		if isBinaryContent(blob) {
+			return binaryFileDiff(path, false, true), &domain.BinaryInfo{
+				OldSize: int64(len(blob)),
+				NewSize: -1,
+				BlobURL: c.blobURL(projectID, repoID, entry.OriginalObjectID, path),
+			}, nil
+		}
+		content := splitLines(blob)
+		diffText += fmt.Sprintf("diff --git a%s b%s\n", path, path)
+		diffText += fmt.Sprintf("--- a%s\n", path)
+		diffText += "+++ /dev/null\n"
+		diffText += fmt.Sprintf("@@ -1,%d +0,0 @@\n", len(content))
+		for _, line := range content {
+			diffText += "-" + line + "\n"
+		}
 
-			unifiedDiff := generateUnifiedDiff(oldContent, newContent)
-			diffText += unifiedDiff
+	case changeTypeEdit:
+		newBlob, err := c.getBlob(ctx, projectID, repoID, entry.ObjectID)
+		if err != nil {
+			logger.LogError("AZURE_GET_FILE_CONTENT", fmt.Sprintf("path=%s objectId=%s (new)", path, entry.ObjectID), err)
+			return "", nil, err
+		}
+		oldBlob, err := c.getBlob(ctx, projectID, repoID, entry.OriginalObjectID)
+		if err != nil {
+			logger.LogError("AZURE_GET_FILE_CONTENT", fmt.Sprintf("path=%s originalObjectId=%s (old)", path, entry.OriginalObjectID), err)
+			return "", nil, err
+		}
 
-		default:
-			logger.Log("AzureDevOps: Change %d/%d - skipped UNKNOWN changeType=%s (%d) for path=%s",
-				idx+1, len(*changes.ChangeEntries), changeTypeStr, changeType, path)
-			skippedFiles++
+		if isBinaryContent(oldBlob) || isBinaryContent(newBlob) {
+			return binaryFileDiff(path, false, false), &domain.BinaryInfo{
+				OldSize: int64(len(oldBlob)),
+				NewSize: int64(len(newBlob)),
+				BlobURL: c.blobURL(projectID, repoID, entry.ObjectID, path),
+			}, nil
 		}
-	}
 
-	logger.Log("AzureDevOps: Processed %d file(s), skipped %d file(s) for PR #%d", processedFiles, skippedFiles, pullRequestID)
+		diffText += fmt.Sprintf("diff --git a%s b%s\n", path, path)
+		diffText += fmt.Sprintf("--- a%s\n", path)
+		diffText += fmt.Sprintf("+++ b%s\n", path)
+		diffText += generateUnifiedDiff(splitLines(oldBlob), splitLines(newBlob))
+
+	default:
+		return "", nil, fmt.Errorf("unsupported change type %d for %s", entry.ChangeType, path)
+	}
 
-	return diffText, nil
+	return diffText, nil, nil
 }
 
-func (c *Client) getFileContent(ctx context.Context, projectID string, repoID string, objectId string) ([]string, error) {
+func (c *Client) getBlob(ctx context.Context, projectID string, repoID string, objectId string) ([]byte, error) {
 	stream, err := c.gitClient.GetBlobContent(ctx, git.GetBlobContentArgs{
 		RepositoryId: &repoID,
 		Sha1:         &objectId,
@@ -416,13 +638,46 @@ func (c *Client) getFileContent(ctx context.Context, projectID string, repoID st
 			break
 		}
 	}
+	return content, nil
+}
 
-	text := string(content)
-	lines := strings.Split(text, "\n")
+func splitLines(content []byte) []string {
+	lines := strings.Split(string(content), "\n")
 	if len(lines) > 0 && lines[len(lines)-1] == "" {
 		lines = lines[:len(lines)-1]
 	}
-	return lines, nil
+	return lines
+}
+
+// binaryDetectionLimit matches the number of bytes git inspects when deciding
+// whether a file is binary.
+const binaryDetectionLimit = 8000
+
+// isBinaryContent uses git's heuristic: a NUL byte near the start of the file.
+func isBinaryContent(content []byte) bool {
+	return bytes.IndexByte(content[:min(len(content), binaryDetectionLimit)], 0) >= 0
+}
+
+func binaryFileDiff(path string, isNew, isDeleted bool) string {
+	oldPath := "a" + path
+	newPath := "b" + path
+	if isNew {
+		oldPath = "/dev/null"
+	}
+	if isDeleted {
+		newPath = "/dev/null"
+	}
+	return fmt.Sprintf("diff --git a%s b%s\nBinary files %s and %s differ\n", path, path, oldPath, newPath)
+}
+
+// blobURL links to the raw blob so a browser can display images directly.
+func (c *Client) blobURL(projectID, repoID, objectId, filePath string) string {
+	if c.connection == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/_apis/git/repositories/%s/blobs/%s?$format=octetstream&download=false&fileName=%s",
+		strings.TrimSuffix(c.connection.BaseUrl, "/"), url.PathEscape(projectID), url.PathEscape(repoID), objectId,
+		url.QueryEscape(path.Base(filePath)))
 }
 
 func generateUnifiedDiff(oldLines, newLines []string) string {
@@ -515,7 +770,10 @@ func (c *Client) GetPullRequestThreads(ctx context.Context, projectID string, re
 	return threads, nil
 }
 
-func (c *Client) CreateCommentThread(ctx context.Context, projectID string, repoID string, pullRequestID int, body string, filePath string, line int) error {
+// CreateCommentThread starts a thread with body, anchored to line of the
+// source branch's version of filePath, or of the target branch's when side
+// is "LEFT", as for a deleted line.
+func (c *Client) CreateCommentThread(ctx context.Context, projectID string, repoID string, pullRequestID int, body string, filePath string, line int, side string) error {
 	thread := git.GitPullRequestCommentThread{
 		Comments: &[]git.Comment{
 			{
@@ -526,16 +784,15 @@ func (c *Client) CreateCommentThread(ctx context.Context, projectID string, repo
 	}
 
 	if filePath != "" && line > 0 {
-		thread.ThreadContext = &git.CommentThreadContext{
-			FilePath: &filePath,
-			RightFileStart: &git.CommentPosition{
-				Line:   &line,
-				Offset: intPtr(1),
-			},
-			RightFileEnd: &git.CommentPosition{
-				Line:   &line,
-				Offset: intPtr(1),
-			},
+		start := &git.CommentPosition{Line: &line, Offset: intPtr(1)}
+		end := &git.CommentPosition{Line: &line, Offset: intPtr(1)}
+		thread.ThreadContext = &git.CommentThreadContext{FilePath: &filePath}
+		if side == "LEFT" {
+			thread.ThreadContext.LeftFileStart = start
+			thread.ThreadContext.LeftFileEnd = end
+		} else {
+			thread.ThreadContext.RightFileStart = start
+			thread.ThreadContext.RightFileEnd = end
 		}
 	}
 
@@ -551,6 +808,55 @@ func (c *Client) CreateCommentThread(ctx context.Context, projectID string, repo
 	return nil
 }
 
+// LikeComment adds the authenticated user to the likes of a comment.
+func (c *Client) LikeComment(ctx context.Context, projectID string, repoID string, pullRequestID int, threadID int, commentID int) error {
+	err := c.gitClient.CreateLike(ctx, git.CreateLikeArgs{
+		RepositoryId:  &repoID,
+		PullRequestId: &pullRequestID,
+		ThreadId:      &threadID,
+		CommentId:     &commentID,
+		Project:       &projectID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to like comment: %w", err)
+	}
+	return nil
+}
+
+// UpdateThreadStatus changes the status of a comment thread, such as
+// resolving it.
+func (c *Client) UpdateThreadStatus(ctx context.Context, projectID string, repoID string, pullRequestID int, threadID int, status git.CommentThreadStatus) error {
+	_, err := c.gitClient.UpdateThread(ctx, git.UpdateThreadArgs{
+		CommentThread: &git.GitPullRequestCommentThread{Status: &status},
+		RepositoryId:  &repoID,
+		PullRequestId: &pullRequestID,
+		ThreadId:      &threadID,
+		Project:       &projectID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update thread status: %w", err)
+	}
+	return nil
+}
+
+// CreateAttachment uploads a file to a pull request and returns its URL.
+func (c *Client) CreateAttachment(ctx context.Context, projectID string, repoID string, pullRequestID int, fileName string, content []byte) (string, error) {
+	attachment, err := c.gitClient.CreateAttachment(ctx, git.CreateAttachmentArgs{
+		UploadStream:  bytes.NewReader(content),
+		FileName:      &fileName,
+		RepositoryId:  &repoID,
+		PullRequestId: &pullRequestID,
+		Project:       &projectID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload attachment: %w", err)
+	}
+	if attachment == nil || attachment.Url == nil {
+		return "", fmt.Errorf("failed to upload attachment: no URL returned")
+	}
+	return *attachment.Url, nil
+}
+
 func (c *Client) CreatePullRequestReview(ctx context.Context, projectID string, repoID string, pullRequestID int, reviewerID string, vote int) error {
 	reviewer := git.IdentityRefWithVote{
 		Vote: &vote,
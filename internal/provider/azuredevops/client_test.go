@@ -2,20 +2,34 @@ package azuredevops
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
 )
 
 type mockGitClient struct {
+	projectPRs       map[string][]git.GitPullRequest
+	lastSearchArgs   git.GetPullRequestsByProjectArgs
+	searchCriteria   []git.GitPullRequestSearchCriteria
+	searchMu         sync.Mutex
 	iterations       *[]git.GitPullRequestIteration
 	iterationChanges *git.GitPullRequestIterationChanges
 	blobContent      map[string]string
 	getIterationsErr error
 	getChangesErr    error
 	getBlobErr       error
+	threads          *[]git.GitPullRequestCommentThread
+	pr               *git.GitPullRequest
+	repoPRs          *[]git.GitPullRequest
+	likes            []git.CreateLikeArgs
+	threadUpdates    []git.UpdateThreadArgs
+	createdThreads   []git.CreateThreadArgs
+	attachments      map[string]string
 }
 
 func (m *mockGitClient) GetRepositories(ctx context.Context, args git.GetRepositoriesArgs) (*[]git.GitRepository, error) {
@@ -23,11 +37,20 @@ func (m *mockGitClient) GetRepositories(ctx context.Context, args git.GetReposit
 }
 
 func (m *mockGitClient) GetPullRequests(ctx context.Context, args git.GetPullRequestsArgs) (*[]git.GitPullRequest, error) {
-	return nil, nil
+	return m.repoPRs, nil
+}
+
+func (m *mockGitClient) GetPullRequestsByProject(ctx context.Context, args git.GetPullRequestsByProjectArgs) (*[]git.GitPullRequest, error) {
+	m.searchMu.Lock()
+	defer m.searchMu.Unlock()
+	m.lastSearchArgs = args
+	m.searchCriteria = append(m.searchCriteria, *args.SearchCriteria)
+	prs := m.projectPRs[*args.Project]
+	return &prs, nil
 }
 
 func (m *mockGitClient) GetPullRequest(ctx context.Context, args git.GetPullRequestArgs) (*git.GitPullRequest, error) {
-	return nil, nil
+	return m.pr, nil
 }
 
 func (m *mockGitClient) GetPullRequestCommits(ctx context.Context, args git.GetPullRequestCommitsArgs) (*git.GetPullRequestCommitsResponseValue, error) {
@@ -63,13 +86,37 @@ func (m *mockGitClient) GetBlobContent(ctx context.Context, args git.GetBlobCont
 }
 
 func (m *mockGitClient) GetThreads(ctx context.Context, args git.GetThreadsArgs) (*[]git.GitPullRequestCommentThread, error) {
-	return nil, nil
+	return m.threads, nil
 }
 
 func (m *mockGitClient) CreateThread(ctx context.Context, args git.CreateThreadArgs) (*git.GitPullRequestCommentThread, error) {
+	m.createdThreads = append(m.createdThreads, args)
 	return nil, nil
 }
 
+func (m *mockGitClient) UpdateThread(ctx context.Context, args git.UpdateThreadArgs) (*git.GitPullRequestCommentThread, error) {
+	m.threadUpdates = append(m.threadUpdates, args)
+	return args.CommentThread, nil
+}
+
+func (m *mockGitClient) CreateLike(ctx context.Context, args git.CreateLikeArgs) error {
+	m.likes = append(m.likes, args)
+	return nil
+}
+
+func (m *mockGitClient) CreateAttachment(ctx context.Context, args git.CreateAttachmentArgs) (*git.Attachment, error) {
+	content, err := io.ReadAll(args.UploadStream)
+	if err != nil {
+		return nil, err
+	}
+	if m.attachments == nil {
+		m.attachments = make(map[string]string)
+	}
+	m.attachments[*args.FileName] = string(content)
+	url := fmt.Sprintf("https://dev.azure.com/org/_apis/git/repositories/%s/pullRequests/%d/attachments/%s", *args.RepositoryId, *args.PullRequestId, *args.FileName)
+	return &git.Attachment{Url: &url}, nil
+}
+
 func (m *mockGitClient) CreatePullRequestReviewer(ctx context.Context, args git.CreatePullRequestReviewerArgs) (*git.IdentityRefWithVote, error) {
 	return nil, nil
 }
@@ -116,7 +163,7 @@ func TestGetPullRequestIterationChanges_AddedFile(t *testing.T) {
 		gitClient: mockClient,
 	}
 
-	result, err := client.GetPullRequestIterationChanges(context.Background(), "project1", "repo1", 42)
+	result, _, err := client.GetPullRequestIterationChanges(context.Background(), "project1", "repo1", 42)
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -174,7 +221,7 @@ func TestGetPullRequestIterationChanges_DeletedFile(t *testing.T) {
 		gitClient: mockClient,
 	}
 
-	result, err := client.GetPullRequestIterationChanges(context.Background(), "project1", "repo1", 42)
+	result, _, err := client.GetPullRequestIterationChanges(context.Background(), "project1", "repo1", 42)
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -235,7 +282,7 @@ func TestGetPullRequestIterationChanges_EditedFile(t *testing.T) {
 		gitClient: mockClient,
 	}
 
-	result, err := client.GetPullRequestIterationChanges(context.Background(), "project1", "repo1", 42)
+	result, _, err := client.GetPullRequestIterationChanges(context.Background(), "project1", "repo1", 42)
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -291,7 +338,7 @@ func TestGetPullRequestIterationChanges_SkipsFolders(t *testing.T) {
 		gitClient: mockClient,
 	}
 
-	result, err := client.GetPullRequestIterationChanges(context.Background(), "project1", "repo1", 42)
+	result, _, err := client.GetPullRequestIterationChanges(context.Background(), "project1", "repo1", 42)
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -358,7 +405,7 @@ func TestGetPullRequestIterationChanges_MultipleFiles(t *testing.T) {
 		gitClient: mockClient,
 	}
 
-	result, err := client.GetPullRequestIterationChanges(context.Background(), "project1", "repo1", 42)
+	result, _, err := client.GetPullRequestIterationChanges(context.Background(), "project1", "repo1", 42)
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -383,7 +430,7 @@ func TestGetPullRequestIterationChanges_EmptyIteration(t *testing.T) {
 		gitClient: mockClient,
 	}
 
-	_, err := client.GetPullRequestIterationChanges(context.Background(), "project1", "repo1", 42)
+	_, _, err := client.GetPullRequestIterationChanges(context.Background(), "project1", "repo1", 42)
 	if err == nil {
 		t.Errorf("Expected error for empty iterations")
 	}
@@ -412,7 +459,7 @@ func TestGetPullRequestIterationChanges_NoChanges(t *testing.T) {
 		gitClient: mockClient,
 	}
 
-	_, err := client.GetPullRequestIterationChanges(context.Background(), "project1", "repo1", 42)
+	_, _, err := client.GetPullRequestIterationChanges(context.Background(), "project1", "repo1", 42)
 	if err == nil {
 		t.Errorf("Expected error for no changes")
 	}
@@ -545,10 +592,31 @@ func TestCreateCommentThread_InlineComment(t *testing.T) {
 		gitClient: mockClient,
 	}
 
-	err := client.CreateCommentThread(context.Background(), "project1", "repo1", 42, "This is a comment", "/src/file.go", 10)
+	err := client.CreateCommentThread(context.Background(), "project1", "repo1", 42, "This is a comment", "/src/file.go", 10, "RIGHT")
 	if err != nil {
 		t.Errorf("Expected no error, got: %v", err)
 	}
+	thread := mockClient.createdThreads[0].CommentThread.ThreadContext
+	if thread.RightFileStart == nil || *thread.RightFileStart.Line != 10 || thread.LeftFileStart != nil {
+		t.Errorf("expected the comment on line 10 of the new file, got %+v", thread)
+	}
+}
+
+func TestCreateCommentThread_DeletedLine(t *testing.T) {
+	mockClient := &mockGitClient{}
+
+	client := &Client{
+		gitClient: mockClient,
+	}
+
+	err := client.CreateCommentThread(context.Background(), "project1", "repo1", 42, "Why remove this?", "/src/file.go", 7, "LEFT")
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+	thread := mockClient.createdThreads[0].CommentThread.ThreadContext
+	if thread.LeftFileStart == nil || *thread.LeftFileStart.Line != 7 || *thread.LeftFileEnd.Line != 7 || thread.RightFileStart != nil {
+		t.Errorf("expected the comment on line 7 of the old file, got %+v", thread)
+	}
 }
 
 func TestCreateCommentThread_ReviewBodyComment(t *testing.T) {
@@ -558,8 +626,156 @@ func TestCreateCommentThread_ReviewBodyComment(t *testing.T) {
 		gitClient: mockClient,
 	}
 
-	err := client.CreateCommentThread(context.Background(), "project1", "repo1", 42, "LGTM! Great work.", "", 0)
+	err := client.CreateCommentThread(context.Background(), "project1", "repo1", 42, "LGTM! Great work.", "", 0, "")
 	if err != nil {
 		t.Errorf("Expected no error, got: %v", err)
 	}
 }
+
+func TestGetPullRequestIterationChanges_BinaryFile(t *testing.T) {
+	iterationID := 1
+	changeType := git.VersionControlChangeTypeValues.Edit
+
+	iterations := []git.GitPullRequestIteration{
+		{Id: &iterationID},
+	}
+
+	item := map[string]interface{}{
+		"path":             "/assets/logo.png",
+		"objectId":         "new123",
+		"originalObjectId": "old123",
+		"isFolder":         false,
+	}
+
+	changes := git.GitPullRequestIterationChanges{
+		ChangeEntries: &[]git.GitPullRequestChange{
+			{
+				ChangeType: &changeType,
+				Item:       item,
+			},
+		},
+	}
+
+	mockClient := &mockGitClient{
+		iterations:       &iterations,
+		iterationChanges: &changes,
+		blobContent: map[string]string{
+			"old123": "\x89PNG\x00\x00",
+			"new123": "\x89PNG\x00\x00\x00\x00",
+		},
+	}
+
+	client := &Client{
+		gitClient: mockClient,
+	}
+
+	result, binaries, err := client.GetPullRequestIterationChanges(context.Background(), "project1", "repo1", 42)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !strings.Contains(result, "Binary files a/assets/logo.png and b/assets/logo.png differ") {
+		t.Errorf("Expected binary marker instead of diff lines, got: %s", result)
+	}
+	if strings.Contains(result, "@@") {
+		t.Errorf("Expected no hunks for binary file")
+	}
+
+	info, ok := binaries["assets/logo.png"]
+	if !ok {
+		t.Fatal("Expected binary info for assets/logo.png")
+	}
+	if info.OldSize != 6 || info.NewSize != 8 {
+		t.Errorf("Expected sizes 6 -> 8, got %d -> %d", info.OldSize, info.NewSize)
+	}
+}
+
+// slowBlobClient serves blobs after a delay and records how many are fetched
+// at once.
+type slowBlobClient struct {
+	*mockGitClient
+	delay       time.Duration
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (m *slowBlobClient) GetBlobContent(ctx context.Context, args git.GetBlobContentArgs) (io.ReadCloser, error) {
+	m.mu.Lock()
+	m.inFlight++
+	m.maxInFlight = max(m.maxInFlight, m.inFlight)
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.inFlight--
+		m.mu.Unlock()
+	}()
+	select {
+	case <-time.After(m.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return m.mockGitClient.GetBlobContent(ctx, args)
+}
+
+func addedFilesClient(count int) *mockGitClient {
+	iterationID := 1
+	changeType := git.VersionControlChangeTypeValues.Add
+	entries := make([]git.GitPullRequestChange, count)
+	blobs := make(map[string]string, count)
+	for i := range entries {
+		objectID := fmt.Sprintf("blob%d", i)
+		entries[i] = git.GitPullRequestChange{
+			ChangeType: &changeType,
+			Item:       map[string]interface{}{"path": fmt.Sprintf("/file%02d.go", i), "objectId": objectID},
+		}
+		blobs[objectID] = fmt.Sprintf("package file%d\n", i)
+	}
+	return &mockGitClient{
+		iterations:       &[]git.GitPullRequestIteration{{Id: &iterationID}},
+		iterationChanges: &git.GitPullRequestIterationChanges{ChangeEntries: &entries},
+		blobContent:      blobs,
+	}
+}
+
+func TestGetPullRequestIterationChanges_FetchesBlobsConcurrently(t *testing.T) {
+	slow := &slowBlobClient{mockGitClient: addedFilesClient(40), delay: 20 * time.Millisecond}
+	client := &Client{gitClient: slow}
+
+	started := time.Now()
+	result, _, err := client.GetPullRequestIterationChanges(context.Background(), "project1", "repo1", 42)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 40*20*time.Millisecond/2 {
+		t.Errorf("expected the blobs to be fetched concurrently, took %v", elapsed)
+	}
+	if slow.maxInFlight < 2 || slow.maxInFlight > maxConcurrentBlobFetches {
+		t.Errorf("expected between 2 and %d fetches at once, got %d", maxConcurrentBlobFetches, slow.maxInFlight)
+	}
+
+	// The files keep the order of the change entries.
+	last := -1
+	for i := 0; i < 40; i++ {
+		at := strings.Index(result, fmt.Sprintf("diff --git a/file%02d.go", i))
+		if at <= last {
+			t.Fatalf("expected file%02d.go after the previous file in the diff", i)
+		}
+		last = at
+	}
+}
+
+func TestGetPullRequestIterationChanges_StopsWhenCancelled(t *testing.T) {
+	slow := &slowBlobClient{mockGitClient: addedFilesClient(40), delay: time.Second}
+	client := &Client{gitClient: slow}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	if _, _, err := client.GetPullRequestIterationChanges(ctx, "project1", "repo1", 42); err == nil {
+		t.Fatal("expected an error once the context is cancelled")
+	}
+	if elapsed := time.Since(started); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the fetches to stop when cancelled, took %v", elapsed)
+	}
+}
@@ -0,0 +1,87 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+// ListDiffFiles lists the files changed by the latest iteration without
+// fetching their content. Azure DevOps reports no line counts, so the files
+// come without them.
+func (p *Provider) ListDiffFiles(ctx context.Context, identifier domain.PRIdentifier) ([]domain.FileDiff, error) {
+	projectID, repoID, err := p.resolveProjectAndRepoWithCache(ctx, identifier.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := p.client.ListPullRequestChangeEntries(ctx, projectID, repoID, identifier.Number)
+	if err != nil {
+		logger.LogError("AZURE_LIST_DIFF_FILES", fmt.Sprintf("project=%s repo=%s PR=%d", projectID, repoID, identifier.Number), err)
+		return nil, err
+	}
+
+	files := make([]domain.FileDiff, 0, len(entries))
+	for _, entry := range entries {
+		files = append(files, convertChangeEntry(entry))
+	}
+	return files, nil
+}
+
+// GetFileDiff fetches the content of a file listed by ListDiffFiles and
+// diffs it. The change entries are listed again to find its blobs.
+func (p *Provider) GetFileDiff(ctx context.Context, identifier domain.PRIdentifier, file domain.FileDiff) (*domain.FileDiff, error) {
+	projectID, repoID, err := p.resolveProjectAndRepoWithCache(ctx, identifier.Repository)
+	if err != nil {
+		return nil, err
+	}
+	path := file.NewPath
+	if path == "" {
+		path = file.OldPath
+	}
+
+	entries, err := p.client.ListPullRequestChangeEntries(ctx, projectID, repoID, identifier.Number)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if strings.TrimPrefix(entry.Path, "/") != path {
+			continue
+		}
+		diffText, binary, err := p.client.GetChangeEntryDiff(ctx, projectID, repoID, entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the diff of %s: %w", path, err)
+		}
+		diff := common.ParseUnifiedDiff(diffText)
+		if len(diff.Files) == 0 {
+			loaded := convertChangeEntry(entry)
+			loaded.Deferred = false
+			return &loaded, nil
+		}
+		loaded := diff.Files[0]
+		if binary != nil && loaded.Binary != nil {
+			loaded.Binary = binary
+		}
+		return &loaded, nil
+	}
+	return nil, fmt.Errorf("%s is no longer changed by PR #%d", path, identifier.Number)
+}
+
+// convertChangeEntry lists a changed file without its hunks.
+func convertChangeEntry(entry ChangeEntry) domain.FileDiff {
+	path := strings.TrimPrefix(entry.Path, "/")
+	file := domain.FileDiff{OldPath: path, NewPath: path, Deferred: true}
+	switch entry.ChangeType {
+	case changeTypeAdd:
+		file.OldPath = ""
+		file.IsNew = true
+	case changeTypeDelete:
+		file.NewPath = ""
+		file.IsDeleted = true
+	}
+	return file
+}
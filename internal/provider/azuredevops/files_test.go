@@ -0,0 +1,73 @@
+package azuredevops
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+func TestListDiffFiles_LoadsEachFileOnDemand(t *testing.T) {
+	iterationID := 1
+	add := git.VersionControlChangeTypeValues.Add
+	edit := git.VersionControlChangeTypeValues.Edit
+	iterations := []git.GitPullRequestIteration{{Id: &iterationID}}
+	changes := git.GitPullRequestIterationChanges{
+		ChangeEntries: &[]git.GitPullRequestChange{
+			{ChangeType: &add, Item: map[string]interface{}{"path": "/src", "isFolder": true}},
+			{ChangeType: &add, Item: map[string]interface{}{"path": "/src/new.go", "objectId": "new"}},
+			{ChangeType: &edit, Item: map[string]interface{}{"path": "/src/main.go", "objectId": "main2", "originalObjectId": "main1"}},
+		},
+	}
+	mockClient := &mockGitClient{
+		iterations:       &iterations,
+		iterationChanges: &changes,
+		blobContent: map[string]string{
+			"new":   "package src\n",
+			"main1": "package main\nvar a = 1\n",
+			"main2": "package main\nvar a = 2\n",
+		},
+	}
+	provider := &Provider{
+		client: &Client{gitClient: mockClient},
+		repoCache: map[string]*ResolvedRepository{
+			"Project/Repo": {ProjectID: "project1", RepoID: "repo1", CachedAt: time.Now()},
+		},
+		cacheTTL: 5 * time.Minute,
+	}
+	identifier := domain.PRIdentifier{Repository: "Project/Repo", Number: 42}
+
+	files, err := provider.ListDiffFiles(context.Background(), identifier)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected the two files without the folder, got %+v", files)
+	}
+	if !files[0].IsNew || files[0].NewPath != "src/new.go" || !files[0].Deferred {
+		t.Errorf("unexpected added file: %+v", files[0])
+	}
+
+	main, err := provider.GetFileDiff(context.Background(), identifier, files[1])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if main.Deferred || main.NewPath != "src/main.go" || len(main.Hunks) != 1 {
+		t.Fatalf("expected the hunks of src/main.go, got %+v", main)
+	}
+	var changed []string
+	for _, line := range main.Hunks[0].Lines {
+		if line.Type != "context" {
+			changed = append(changed, line.Type+" "+line.Content)
+		}
+	}
+	if len(changed) != 2 || changed[0] != "delete -var a = 1" || changed[1] != "add +var a = 2" {
+		t.Errorf("unexpected changed lines: %q", changed)
+	}
+
+	if _, err := provider.GetFileDiff(context.Background(), identifier, domain.FileDiff{NewPath: "src/gone.go"}); err == nil {
+		t.Error("expected an error for a file the pull request no longer changes")
+	}
+}
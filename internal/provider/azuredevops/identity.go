@@ -0,0 +1,87 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/location"
+)
+
+// authenticatedUser is who the connection data API reports the token
+// belongs to.
+type authenticatedUser struct {
+	DisplayName string
+	Account     string
+}
+
+// getAuthenticatedUser asks the connection data API who the token belongs
+// to.
+func (c *Client) getAuthenticatedUser(ctx context.Context) (authenticatedUser, error) {
+	locationClient := location.NewClient(ctx, c.connection)
+	data, err := locationClient.GetConnectionData(ctx, location.GetConnectionDataArgs{})
+	if err != nil {
+		return authenticatedUser{}, fmt.Errorf("failed to get connection data: %w", err)
+	}
+	if data == nil || data.AuthenticatedUser == nil {
+		return authenticatedUser{}, fmt.Errorf("connection data has no authenticated user")
+	}
+
+	user := authenticatedUser{
+		DisplayName: common.GetString(data.AuthenticatedUser.ProviderDisplayName),
+		Account:     identityProperty(data.AuthenticatedUser.Properties, "Account"),
+	}
+	if custom := common.GetString(data.AuthenticatedUser.CustomDisplayName); custom != "" {
+		user.DisplayName = custom
+	}
+	return user, nil
+}
+
+// identityProperty reads a string property, which the API wraps as
+// {"$type": "System.String", "$value": "..."}.
+func identityProperty(properties interface{}, name string) string {
+	values, ok := properties.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	switch value := values[name].(type) {
+	case string:
+		return value
+	case map[string]interface{}:
+		if s, ok := value["$value"].(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// ResolveIdentity returns the display name of the user the token belongs
+// to, which pull request authors and reviewers are matched against.
+func (p *Provider) ResolveIdentity(ctx context.Context) (domain.Identity, error) {
+	user, err := p.client.getAuthenticatedUser(ctx)
+	if err != nil {
+		logger.LogError("AZURE_RESOLVE_IDENTITY", p.client.organization, err)
+		return domain.Identity{}, err
+	}
+	return identityOf(user)
+}
+
+// identityOf makes the part of the account name before the "@" an alias,
+// since it matches the user too, and the username when there is no display
+// name.
+func identityOf(user authenticatedUser) (domain.Identity, error) {
+	identity := domain.Identity{Username: user.DisplayName}
+	if local, _, found := strings.Cut(user.Account, "@"); found && local != "" {
+		identity.Aliases = append(identity.Aliases, local)
+	}
+	if identity.Username == "" {
+		if len(identity.Aliases) == 0 {
+			return domain.Identity{}, fmt.Errorf("Azure DevOps returned no name for the authenticated user")
+		}
+		identity.Username = identity.Aliases[0]
+	}
+	return identity, nil
+}
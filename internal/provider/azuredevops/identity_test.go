@@ -0,0 +1,48 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIdentityProperty_ReadsWrappedAndPlainValues(t *testing.T) {
+	var properties interface{}
+	if err := json.Unmarshal([]byte(`{"Account":{"$type":"System.String","$value":"jane.doe@contoso.com"},"Plain":"value"}`), &properties); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := identityProperty(properties, "Account"); got != "jane.doe@contoso.com" {
+		t.Errorf("expected the wrapped account, got %q", got)
+	}
+	if got := identityProperty(properties, "Plain"); got != "value" {
+		t.Errorf("expected the plain value, got %q", got)
+	}
+	if got := identityProperty(properties, "Missing"); got != "" {
+		t.Errorf("expected nothing for a missing property, got %q", got)
+	}
+	if got := identityProperty(nil, "Account"); got != "" {
+		t.Errorf("expected nothing without properties, got %q", got)
+	}
+}
+
+func TestIdentityOf(t *testing.T) {
+	identity, err := identityOf(authenticatedUser{DisplayName: "Jane Doe", Account: "jane.doe@contoso.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.Username != "Jane Doe" {
+		t.Errorf("expected the display name as username, got %q", identity.Username)
+	}
+	if !identity.Matches("jane.doe") || !identity.Matches("jane doe") {
+		t.Errorf("expected the account name and display name to match, got %+v", identity)
+	}
+
+	identity, err = identityOf(authenticatedUser{Account: "jane.doe@contoso.com"})
+	if err != nil || identity.Username != "jane.doe" {
+		t.Errorf("expected the account name without a display name, got %+v, %v", identity, err)
+	}
+
+	if _, err := identityOf(authenticatedUser{}); err == nil {
+		t.Error("expected an error without any name")
+	}
+}
@@ -0,0 +1,85 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
+)
+
+// ListMentionCandidates lists the creator, reviewers and commenters of the
+// pull request, followed by the creators and reviewers of the repository's
+// other active pull requests. Azure DevOps mentions identities by ID as
+// "@<id>" and shows their display name. Groups are skipped, as they are
+// mentioned differently.
+func (p *Provider) ListMentionCandidates(ctx context.Context, identifier domain.PRIdentifier) ([]domain.MentionCandidate, error) {
+	projectID, repoID, err := p.resolveProjectAndRepoWithCache(ctx, identifier.Repository)
+	if err != nil {
+		return nil, err
+	}
+	target := fmt.Sprintf("%s#%d", identifier.Repository, identifier.Number)
+
+	pr, err := p.client.GetPullRequest(ctx, projectID, repoID, identifier.Number)
+	if err != nil {
+		logger.LogError("AZURE_MENTIONS", target, err)
+		return nil, err
+	}
+
+	var candidates domain.MentionCandidates
+	add := func(identity *webapi.IdentityRef) {
+		if identity == nil || identity.Id == nil || (identity.IsContainer != nil && *identity.IsContainer) {
+			return
+		}
+		user := convertIdentity(identity)
+		candidates.Add(user, "@<"+user.ID+">")
+	}
+	addReviewers := func(reviewers *[]git.IdentityRefWithVote) {
+		if reviewers == nil {
+			return
+		}
+		for _, reviewer := range *reviewers {
+			if reviewer.IsContainer != nil && *reviewer.IsContainer {
+				continue
+			}
+			add(&webapi.IdentityRef{
+				Id:          reviewer.Id,
+				DisplayName: reviewer.DisplayName,
+				UniqueName:  reviewer.UniqueName,
+				ImageUrl:    reviewer.ImageUrl,
+			})
+		}
+	}
+
+	add(pr.CreatedBy)
+	addReviewers(pr.Reviewers)
+
+	if threads, err := p.client.GetPullRequestThreads(ctx, projectID, repoID, identifier.Number); err == nil && threads != nil {
+		for _, thread := range *threads {
+			if thread.Comments == nil {
+				continue
+			}
+			for _, comment := range *thread.Comments {
+				if comment.CommentType != nil && *comment.CommentType == git.CommentTypeValues.System {
+					continue
+				}
+				add(comment.Author)
+			}
+		}
+	} else if err != nil {
+		logger.Log("AZURE_MENTIONS: threads unavailable for %s: %v", target, err)
+	}
+
+	if prs, err := p.client.ListPullRequests(ctx, projectID, repoID); err == nil && prs != nil {
+		for _, other := range *prs {
+			add(other.CreatedBy)
+			addReviewers(other.Reviewers)
+		}
+	} else if err != nil {
+		logger.Log("AZURE_MENTIONS: active pull requests unavailable for %s: %v", identifier.Repository, err)
+	}
+
+	return candidates.List(), nil
+}
@@ -0,0 +1,260 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/policy"
+)
+
+// Display names of the built-in branch policy types whose settings affect
+// how the requirements and evaluations are reported.
+const (
+	policyMinimumReviewers  = "Minimum number of reviewers"
+	policyRequiredReviewers = "Required reviewers"
+	policyComments          = "Comment requirements"
+	policyBuild             = "Build"
+	policyMergeStrategy     = "Require a merge strategy"
+)
+
+func (p *Provider) GetMergeRequirements(ctx context.Context, identifier domain.PRIdentifier) (*domain.MergeRequirements, error) {
+	reviewers, evaluations, err := p.fetchPolicyEvaluations(ctx, identifier, "AZURE_MERGE_REQUIREMENTS")
+	if err != nil {
+		return nil, err
+	}
+	return buildMergeRequirements(reviewers, evaluations), nil
+}
+
+func (p *Provider) GetPolicyEvaluations(ctx context.Context, identifier domain.PRIdentifier) ([]domain.PolicyEvaluation, error) {
+	reviewers, evaluations, err := p.fetchPolicyEvaluations(ctx, identifier, "AZURE_POLICY_EVALUATIONS")
+	if err != nil {
+		return nil, err
+	}
+	return convertPolicyEvaluations(reviewers, evaluations), nil
+}
+
+// fetchPolicyEvaluations returns the reviewers of a pull request and the
+// branch policies evaluated against it. The evaluations are looked up in
+// the project of the PR's repository, which the repository name may not
+// tell.
+func (p *Provider) fetchPolicyEvaluations(ctx context.Context, identifier domain.PRIdentifier, operation string) ([]domain.Reviewer, []policy.PolicyEvaluationRecord, error) {
+	projectID, repoID, err := p.resolveProjectAndRepoWithCache(ctx, identifier.Repository)
+	if err != nil {
+		return nil, nil, err
+	}
+	target := fmt.Sprintf("%s#%d", identifier.Repository, identifier.Number)
+
+	pr, err := p.client.GetPullRequest(ctx, projectID, repoID, identifier.Number)
+	if err != nil {
+		logger.LogError(operation, target, err)
+		return nil, nil, err
+	}
+	if pr.Repository != nil && pr.Repository.Project != nil && pr.Repository.Project.Id != nil {
+		projectID = pr.Repository.Project.Id.String()
+	}
+
+	evaluations, err := p.client.GetPolicyEvaluations(ctx, projectID, identifier.Number)
+	if err != nil {
+		logger.LogError(operation, target, err)
+		return nil, nil, err
+	}
+	return convertReviewers(pr.Reviewers), evaluations, nil
+}
+
+// convertPolicyEvaluations lists the enabled policies that apply to a pull
+// request, the blocking ones first, the way the web UI does under
+// "Policies".
+func convertPolicyEvaluations(reviewers []domain.Reviewer, evaluations []policy.PolicyEvaluationRecord) []domain.PolicyEvaluation {
+	var result []domain.PolicyEvaluation
+	for _, evaluation := range evaluations {
+		config := evaluation.Configuration
+		if config == nil || (config.IsEnabled != nil && !*config.IsEnabled) {
+			continue
+		}
+		status := policy.PolicyEvaluationStatus("")
+		if evaluation.Status != nil {
+			status = *evaluation.Status
+		}
+		if status == policy.PolicyEvaluationStatusValues.NotApplicable {
+			continue
+		}
+		settings, _ := config.Settings.(map[string]interface{})
+
+		converted := domain.PolicyEvaluation{
+			Status:   domain.PolicyStatus(status),
+			Optional: config.IsBlocking != nil && !*config.IsBlocking,
+		}
+		if config.Type != nil && config.Type.DisplayName != nil {
+			converted.Name = *config.Type.DisplayName
+		}
+
+		switch converted.Name {
+		case policyMinimumReviewers:
+			if count, ok := settings["minimumApproverCount"].(float64); ok {
+				converted.Detail = fmt.Sprintf("%d of %d approvals", approvedCount(reviewers, false), int(count))
+			}
+		case policyRequiredReviewers:
+			if required := requiredReviewerCount(reviewers); required > 0 {
+				converted.Detail = fmt.Sprintf("%d of %d required reviewers approved", approvedCount(reviewers, true), required)
+			}
+		case policyComments:
+			if converted.Failed() {
+				converted.Detail = "unresolved comments"
+			}
+		case policyBuild:
+			if displayName, ok := settings["displayName"].(string); ok && displayName != "" {
+				converted.Name = displayName
+			}
+			if context, ok := evaluation.Context.(map[string]interface{}); ok {
+				if expired, ok := context["isExpired"].(bool); ok && expired {
+					converted.Detail = "expired"
+				}
+			}
+		}
+		if converted.Name == "" {
+			continue
+		}
+		result = append(result, converted)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return !result[i].Optional && result[j].Optional
+	})
+	return result
+}
+
+// approvedCount counts the reviewers who approved, or only the required ones
+// among them.
+func approvedCount(reviewers []domain.Reviewer, requiredOnly bool) int {
+	count := 0
+	for _, reviewer := range reviewers {
+		if reviewer.State == domain.ReviewerApproved && (reviewer.Required || !requiredOnly) {
+			count++
+		}
+	}
+	return count
+}
+
+func requiredReviewerCount(reviewers []domain.Reviewer) int {
+	count := 0
+	for _, reviewer := range reviewers {
+		if reviewer.Required {
+			count++
+		}
+	}
+	return count
+}
+
+// buildMergeRequirements reads the blocking policies of a pull request.
+// Non-blocking (optional) policies are ignored, as they never reject a merge.
+func buildMergeRequirements(reviewers []domain.Reviewer, evaluations []policy.PolicyEvaluationRecord) *domain.MergeRequirements {
+	req := &domain.MergeRequirements{Approvals: approvedCount(reviewers, false)}
+
+	for _, evaluation := range evaluations {
+		config := evaluation.Configuration
+		if config == nil || (config.IsBlocking != nil && !*config.IsBlocking) {
+			continue
+		}
+		if config.IsEnabled != nil && !*config.IsEnabled {
+			continue
+		}
+		req.Protected = true
+
+		status := policy.PolicyEvaluationStatus("")
+		if evaluation.Status != nil {
+			status = *evaluation.Status
+		}
+		if status == policy.PolicyEvaluationStatusValues.NotApplicable {
+			continue
+		}
+		settings, _ := config.Settings.(map[string]interface{})
+
+		name := ""
+		if config.Type != nil && config.Type.DisplayName != nil {
+			name = *config.Type.DisplayName
+		}
+
+		switch name {
+		case policyMinimumReviewers:
+			if count, ok := settings["minimumApproverCount"].(float64); ok {
+				req.RequiredApprovals = int(count)
+			}
+			continue
+		case policyMergeStrategy:
+			req.AllowedMethods = allowedMergeMethods(settings)
+			continue
+		case policyBuild:
+			if displayName, ok := settings["displayName"].(string); ok && displayName != "" {
+				name = displayName
+			}
+			switch status {
+			case policy.PolicyEvaluationStatusValues.Rejected, policy.PolicyEvaluationStatusValues.Broken:
+				req.FailingChecks = append(req.FailingChecks, name)
+			case policy.PolicyEvaluationStatusValues.Queued, policy.PolicyEvaluationStatusValues.Running:
+				req.PendingChecks = append(req.PendingChecks, name)
+			}
+			continue
+		}
+
+		if status != policy.PolicyEvaluationStatusValues.Approved && name != "" {
+			req.Blockers = append(req.Blockers, name)
+		}
+	}
+
+	return req
+}
+
+func allowedMergeMethods(settings map[string]interface{}) []string {
+	methods := []string{}
+	for _, option := range []struct{ setting, method string }{
+		{"allowNoFastForward", "noFastForward"},
+		{"allowSquash", "squash"},
+		{"allowRebase", "rebase"},
+		{"allowRebaseMerge", "rebaseMerge"},
+	} {
+		if allowed, ok := settings[option.setting].(bool); ok && allowed {
+			methods = append(methods, option.method)
+		}
+	}
+	return methods
+}
+
+func (p *Provider) ListConflictFiles(ctx context.Context, identifier domain.PRIdentifier) ([]string, error) {
+	projectID, repoID, err := p.resolveProjectAndRepoWithCache(ctx, identifier.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts, err := p.client.GetPullRequestConflicts(ctx, projectID, repoID, identifier.Number)
+	if err != nil {
+		logger.LogError("AZURE_CONFLICT_FILES", fmt.Sprintf("%s#%d", identifier.Repository, identifier.Number), err)
+		return nil, err
+	}
+
+	return conflictPaths(conflicts), nil
+}
+
+// conflictPaths returns the sorted, de-duplicated paths of the conflicts
+// without their leading slash.
+func conflictPaths(conflicts []git.GitConflict) []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, conflict := range conflicts {
+		if conflict.ConflictPath == nil {
+			continue
+		}
+		path := strings.TrimPrefix(*conflict.ConflictPath, "/")
+		if path == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -7,14 +7,15 @@ import (
 	"sync"
 	"time"
 
+	"github.com/johanforsgren/lgtmfaster/internal/crash"
 	"github.com/johanforsgren/lgtmfaster/internal/domain"
 	"github.com/johanforsgren/lgtmfaster/internal/logger"
 	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
+	"golang.org/x/oauth2"
 )
 
-
 type ResolvedRepository struct {
 	ProjectID string
 	RepoID    string
@@ -33,11 +34,25 @@ func NewProvider(token string, organization string, username string) (*Provider,
 	if err != nil {
 		return nil, err
 	}
+	return newProvider(client), nil
+}
+
+// NewOAuthProvider creates a provider from a device-flow login whose tokens
+// come from ts.
+func NewOAuthProvider(ts oauth2.TokenSource, organization string, username string) (*Provider, error) {
+	client, err := NewOAuthClient(ts, organization, username)
+	if err != nil {
+		return nil, err
+	}
+	return newProvider(client), nil
+}
+
+func newProvider(client *Client) *Provider {
 	return &Provider{
 		client:    client,
 		repoCache: make(map[string]*ResolvedRepository),
 		cacheTTL:  5 * time.Minute,
-	}, nil
+	}
 }
 
 func (p *Provider) GetType() domain.ProviderType {
@@ -62,6 +77,7 @@ func (p *Provider) ListPullRequests(ctx context.Context, username string) ([]dom
 	for _, project := range *projects {
 		wg.Add(1)
 		go func(projectID, projectName string) {
+			defer crash.Guard()
 			defer wg.Done()
 
 			repos, err := p.client.ListRepositories(ctx, projectID)
@@ -153,7 +169,7 @@ func (p *Provider) GetDiff(ctx context.Context, identifier domain.PRIdentifier)
 	}
 
 	logger.Log("AzureDevOps: Requesting PR iteration changes for PR #%d", identifier.Number)
-	diffText, err := p.client.GetPullRequestIterationChanges(ctx, projectID, repoID, identifier.Number)
+	diffText, binaries, err := p.client.GetPullRequestIterationChanges(ctx, projectID, repoID, identifier.Number)
 	if err != nil {
 		logger.LogError("AZURE_GET_DIFF", fmt.Sprintf("project=%s repo=%s PR=%d", projectID, repoID, identifier.Number), err)
 		return nil, err
@@ -166,6 +182,16 @@ func (p *Provider) GetDiff(ctx context.Context, identifier domain.PRIdentifier)
 
 	diff := common.ParseUnifiedDiff(diffText)
 	logger.Log("AzureDevOps: Parsed diff with %d files", len(diff.Files))
+	for i := range diff.Files {
+		file := &diff.Files[i]
+		path := file.NewPath
+		if path == "" {
+			path = file.OldPath
+		}
+		if info, ok := binaries[path]; ok && file.Binary != nil {
+			file.Binary = &info
+		}
+	}
 	for i, file := range diff.Files {
 		logger.Log("AzureDevOps: File %d: %s -> %s (%d hunks)", i+1, file.OldPath, file.NewPath, len(file.Hunks))
 	}
@@ -200,6 +226,15 @@ func (p *Provider) GetComments(ctx context.Context, identifier domain.PRIdentifi
 				CreatedAt: comment.PublishedDate.Time,
 				UpdatedAt: comment.LastUpdatedDate.Time,
 			}
+			if thread.Id != nil {
+				domainComment.ThreadID = fmt.Sprintf("%d", *thread.Id)
+			}
+			if thread.Status != nil && *thread.Status != git.CommentThreadStatusValues.Unknown {
+				domainComment.ThreadStatus = domain.ThreadStatus(*thread.Status)
+			}
+			if comment.UsersLiked != nil && len(*comment.UsersLiked) > 0 {
+				domainComment.Reactions = []domain.Reaction{{Content: domain.ReactionThumbsUp, Count: len(*comment.UsersLiked)}}
+			}
 
 			if comment.Author != nil {
 				domainComment.Author = convertIdentity(comment.Author)
@@ -207,8 +242,12 @@ func (p *Provider) GetComments(ctx context.Context, identifier domain.PRIdentifi
 
 			if thread.ThreadContext != nil && thread.ThreadContext.FilePath != nil {
 				domainComment.FilePath = common.GetString(thread.ThreadContext.FilePath)
-				if thread.ThreadContext.RightFileStart != nil && thread.ThreadContext.RightFileStart.Line != nil {
-					domainComment.Line = *thread.ThreadContext.RightFileStart.Line
+				if start := thread.ThreadContext.RightFileStart; start != nil && start.Line != nil {
+					domainComment.Line = *start.Line
+					domainComment.Side = "RIGHT"
+				} else if start := thread.ThreadContext.LeftFileStart; start != nil && start.Line != nil {
+					domainComment.Line = *start.Line
+					domainComment.Side = "LEFT"
 				}
 			}
 
@@ -219,13 +258,13 @@ func (p *Provider) GetComments(ctx context.Context, identifier domain.PRIdentifi
 	return comments, nil
 }
 
-func (p *Provider) AddComment(ctx context.Context, identifier domain.PRIdentifier, body string, filePath string, line int) error {
+func (p *Provider) AddComment(ctx context.Context, identifier domain.PRIdentifier, body string, filePath string, line int, side string) error {
 	projectID, repoID, err := p.resolveProjectAndRepoWithCache(ctx, identifier.Repository)
 	if err != nil {
 		return err
 	}
 
-	return p.client.CreateCommentThread(ctx, projectID, repoID, identifier.Number, body, filePath, line)
+	return p.client.CreateCommentThread(ctx, projectID, repoID, identifier.Number, body, filePath, line, side)
 }
 
 func (p *Provider) SubmitReview(ctx context.Context, review domain.Review) error {
@@ -250,7 +289,7 @@ func (p *Provider) SubmitReview(ctx context.Context, review domain.Review) error
 	}
 	for i, comment := range review.Comments {
 		logger.Log("AzureDevOps: Creating comment %d/%d on %s:%d", i+1, len(review.Comments), comment.FilePath, comment.Line)
-		if err := p.client.CreateCommentThread(ctx, projectID, repoID, prNumber, comment.Body, comment.FilePath, comment.Line); err != nil {
+		if err := p.client.CreateCommentThread(ctx, projectID, repoID, prNumber, comment.Body, comment.FilePath, comment.Line, comment.Side); err != nil {
 			logger.LogError("AZDO_CREATE_COMMENT", fmt.Sprintf("%s#%d", repository, prNumber), err)
 			if createdComments > 0 {
 				return fmt.Errorf("%w: failed to create comment %d/%d (created %d comments before failure): %v",
@@ -283,7 +322,7 @@ func (p *Provider) SubmitReview(ctx context.Context, review domain.Review) error
 
 	if review.Body != "" {
 		logger.Log("AzureDevOps: Creating review body comment")
-		if err := p.client.CreateCommentThread(ctx, projectID, repoID, prNumber, review.Body, "", 0); err != nil {
+		if err := p.client.CreateCommentThread(ctx, projectID, repoID, prNumber, review.Body, "", 0, ""); err != nil {
 			logger.LogError("AZDO_CREATE_REVIEW_BODY", fmt.Sprintf("%s#%d", repository, prNumber), err)
 			if createdComments > 0 || review.Action != domain.ReviewActionComment {
 				return fmt.Errorf("%w: failed to create review body comment (created %d inline comments): %v",
@@ -365,10 +404,22 @@ func convertPullRequest(adoPR *git.GitPullRequest, currentUser string) domain.Pu
 		pr.Author = convertIdentity(adoPR.CreatedBy)
 	}
 
+	if adoPR.Labels != nil {
+		for _, label := range *adoPR.Labels {
+			if name := common.GetString(label.Name); name != "" && (label.Active == nil || *label.Active) {
+				pr.Labels = append(pr.Labels, name)
+			}
+		}
+	}
+
 	if adoPR.Repository != nil {
 		pr.Repository = convertRepository(adoPR.Repository)
 	}
 
+	if adoPR.LastMergeSourceCommit != nil {
+		pr.HeadSHA = common.GetString(adoPR.LastMergeSourceCommit.CommitId)
+	}
+
 	return pr
 }
 
@@ -461,6 +512,9 @@ func determinePRCategory(pr *git.GitPullRequest, currentUser string) domain.PRCa
 				ImageUrl:    reviewer.ImageUrl,
 			}
 			if matchesUser(&identity, currentUser) {
+				if reviewer.Vote == nil || *reviewer.Vote == 0 {
+					return domain.PRCategoryReviewRequested
+				}
 				return domain.PRCategoryAssigned
 			}
 		}
@@ -1,13 +1,18 @@
 package azuredevops
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/johanforsgren/lgtmfaster/internal/domain"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/policy"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
 )
 
 func mustParseUUID(s string) uuid.UUID {
@@ -243,3 +248,335 @@ func TestConvertReviewActionToVote(t *testing.T) {
 		})
 	}
 }
+
+func TestDeterminePRCategory_ReviewerVote(t *testing.T) {
+	name := "testuser"
+	pending := 0
+	approved := 10
+
+	tests := []struct {
+		name string
+		vote *int
+		want domain.PRCategory
+	}{
+		{"no vote yet", &pending, domain.PRCategoryReviewRequested},
+		{"nil vote", nil, domain.PRCategoryReviewRequested},
+		{"already approved", &approved, domain.PRCategoryAssigned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := createMockPR(1, "Test PR", nil)
+			pr.Reviewers = &[]git.IdentityRefWithVote{{DisplayName: &name, Vote: tt.vote}}
+
+			if got := determinePRCategory(pr, "testuser"); got != tt.want {
+				t.Errorf("determinePRCategory() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertReviewers(t *testing.T) {
+	reviewer := func(name string, vote int, required bool) git.IdentityRefWithVote {
+		return git.IdentityRefWithVote{
+			DisplayName: &name,
+			Vote:        &vote,
+			IsRequired:  &required,
+		}
+	}
+
+	reviewers := convertReviewers(&[]git.IdentityRefWithVote{
+		reviewer("Zoe", 10, false),
+		reviewer("Bob", 0, true),
+		reviewer("Amy", -5, false),
+		reviewer("Carl", 5, true),
+	})
+
+	want := []struct {
+		name     string
+		state    domain.ReviewerState
+		vote     int
+		required bool
+	}{
+		{"Bob", domain.ReviewerPending, 0, true},
+		{"Carl", domain.ReviewerApproved, 5, true},
+		{"Amy", domain.ReviewerChangesRequested, -5, false},
+		{"Zoe", domain.ReviewerApproved, 10, false},
+	}
+	if len(reviewers) != len(want) {
+		t.Fatalf("expected %d reviewers, got %d", len(want), len(reviewers))
+	}
+	for i, w := range want {
+		got := reviewers[i]
+		if got.User.Username != w.name || got.State != w.state || got.Vote != w.vote || got.Required != w.required {
+			t.Errorf("reviewer %d = %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+func TestBuildMergeRequirements(t *testing.T) {
+	evaluation := func(typeName string, status policy.PolicyEvaluationStatus, blocking bool, settings map[string]interface{}) policy.PolicyEvaluationRecord {
+		return policy.PolicyEvaluationRecord{
+			Status: &status,
+			Configuration: &policy.PolicyConfiguration{
+				Type:       &policy.PolicyTypeRef{DisplayName: &typeName},
+				IsBlocking: &blocking,
+				IsEnabled:  boolPtr(true),
+				Settings:   settings,
+			},
+		}
+	}
+
+	reviewers := []domain.Reviewer{
+		{State: domain.ReviewerApproved},
+		{State: domain.ReviewerPending},
+	}
+	req := buildMergeRequirements(reviewers, []policy.PolicyEvaluationRecord{
+		evaluation(policyMinimumReviewers, "rejected", true, map[string]interface{}{"minimumApproverCount": float64(2)}),
+		evaluation(policyBuild, "rejected", true, map[string]interface{}{"displayName": "CI"}),
+		evaluation(policyBuild, "running", false, map[string]interface{}{"displayName": "Nightly"}),
+		evaluation(policyMergeStrategy, "approved", true, map[string]interface{}{"allowSquash": true, "allowRebase": false}),
+		evaluation("Comment requirements", "rejected", true, nil),
+		evaluation("Work item linking", "notApplicable", true, nil),
+	})
+
+	if !req.Protected || req.RequiredApprovals != 2 || req.Approvals != 1 {
+		t.Errorf("unexpected approvals: %+v", req)
+	}
+	if len(req.FailingChecks) != 1 || req.FailingChecks[0] != "CI" || len(req.PendingChecks) != 0 {
+		t.Errorf("expected only the blocking CI build to fail, got failing=%v pending=%v", req.FailingChecks, req.PendingChecks)
+	}
+	if !req.MethodAllowed("squash") || req.MethodAllowed("noFastForward") {
+		t.Errorf("expected only squash to be allowed, got %v", req.AllowedMethods)
+	}
+	if len(req.Blockers) != 1 || req.Blockers[0] != "Comment requirements" {
+		t.Errorf("expected comment requirements blocker, got %v", req.Blockers)
+	}
+}
+
+func TestConvertPolicyEvaluations(t *testing.T) {
+	evaluation := func(typeName string, status policy.PolicyEvaluationStatus, blocking bool, settings map[string]interface{}) policy.PolicyEvaluationRecord {
+		return policy.PolicyEvaluationRecord{
+			Status: &status,
+			Configuration: &policy.PolicyConfiguration{
+				Type:       &policy.PolicyTypeRef{DisplayName: &typeName},
+				IsBlocking: &blocking,
+				IsEnabled:  boolPtr(true),
+				Settings:   settings,
+			},
+		}
+	}
+	expiredBuild := evaluation(policyBuild, "rejected", true, map[string]interface{}{"displayName": "CI"})
+	expiredBuild.Context = map[string]interface{}{"isExpired": true}
+	disabled := evaluation("Work item linking", "rejected", true, nil)
+	disabled.Configuration.IsEnabled = boolPtr(false)
+
+	reviewers := []domain.Reviewer{
+		{State: domain.ReviewerApproved, Required: true},
+		{State: domain.ReviewerPending, Required: true},
+		{State: domain.ReviewerApproved},
+	}
+	got := convertPolicyEvaluations(reviewers, []policy.PolicyEvaluationRecord{
+		evaluation(policyBuild, "running", false, map[string]interface{}{"displayName": "Nightly"}),
+		evaluation(policyMinimumReviewers, "approved", true, map[string]interface{}{"minimumApproverCount": float64(2)}),
+		evaluation(policyRequiredReviewers, "rejected", true, nil),
+		expiredBuild,
+		evaluation(policyComments, "rejected", true, nil),
+		evaluation("Work item linking", "notApplicable", true, nil),
+		disabled,
+	})
+
+	want := []domain.PolicyEvaluation{
+		{Name: policyMinimumReviewers, Status: domain.PolicyApproved, Detail: "2 of 2 approvals"},
+		{Name: policyRequiredReviewers, Status: domain.PolicyRejected, Detail: "1 of 2 required reviewers approved"},
+		{Name: "CI", Status: domain.PolicyRejected, Detail: "expired"},
+		{Name: policyComments, Status: domain.PolicyRejected, Detail: "unresolved comments"},
+		{Name: "Nightly", Status: domain.PolicyRunning, Optional: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d evaluations, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("evaluation %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestConflictPaths(t *testing.T) {
+	path := func(p string) git.GitConflict { return git.GitConflict{ConflictPath: &p} }
+
+	got := conflictPaths([]git.GitConflict{path("/src/b.go"), path("/src/a.go"), path("/src/b.go"), {}})
+
+	want := []string{"src/a.go", "src/b.go"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("conflictPaths() = %v, want %v", got, want)
+	}
+}
+
+func TestGetComments_ThreadIDAndLikes(t *testing.T) {
+	threadID, commentID := 5, 2
+	content := "Looks good"
+	now := azuredevops.Time{Time: time.Now()}
+	mockClient := &mockGitClient{
+		threads: &[]git.GitPullRequestCommentThread{{
+			Id: &threadID,
+			Comments: &[]git.Comment{{
+				Id:              &commentID,
+				Content:         &content,
+				PublishedDate:   &now,
+				LastUpdatedDate: &now,
+				UsersLiked:      &[]webapi.IdentityRef{{}, {}},
+			}},
+		}},
+	}
+	provider := &Provider{
+		client:    &Client{gitClient: mockClient, organization: "org"},
+		repoCache: map[string]*ResolvedRepository{"Platform/api": {ProjectID: "p", RepoID: "r", CachedAt: time.Now()}},
+		cacheTTL:  time.Minute,
+	}
+	identifier := domain.PRIdentifier{Repository: "Platform/api", Number: 9}
+
+	comments, err := provider.GetComments(context.Background(), identifier)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(comments) != 1 || comments[0].ThreadID != "5" || comments[0].ID != "2" {
+		t.Fatalf("unexpected comments: %+v", comments)
+	}
+	if len(comments[0].Reactions) != 1 || comments[0].Reactions[0] != (domain.Reaction{Content: domain.ReactionThumbsUp, Count: 2}) {
+		t.Errorf("expected two likes, got %+v", comments[0].Reactions)
+	}
+
+	if err := provider.AddReaction(context.Background(), identifier, comments[0], domain.ReactionThumbsUp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mockClient.likes) != 1 || *mockClient.likes[0].ThreadId != 5 || *mockClient.likes[0].CommentId != 2 || *mockClient.likes[0].PullRequestId != 9 {
+		t.Errorf("unexpected like: %+v", mockClient.likes)
+	}
+
+	if err := provider.AddReaction(context.Background(), identifier, comments[0], domain.ReactionHeart); err == nil {
+		t.Error("expected reactions other than a like to be rejected")
+	}
+}
+
+func TestThreadStatus_ReadAndChanged(t *testing.T) {
+	threadID, commentID := 5, 2
+	status := git.CommentThreadStatusValues.Active
+	now := azuredevops.Time{Time: time.Now()}
+	mockClient := &mockGitClient{
+		threads: &[]git.GitPullRequestCommentThread{{
+			Id:       &threadID,
+			Status:   &status,
+			Comments: &[]git.Comment{{Id: &commentID, PublishedDate: &now, LastUpdatedDate: &now}},
+		}},
+	}
+	provider := &Provider{
+		client:    &Client{gitClient: mockClient, organization: "org"},
+		repoCache: map[string]*ResolvedRepository{"Platform/api": {ProjectID: "p", RepoID: "r", CachedAt: time.Now()}},
+		cacheTTL:  time.Minute,
+	}
+	identifier := domain.PRIdentifier{Repository: "Platform/api", Number: 9}
+
+	comments, err := provider.GetComments(context.Background(), identifier)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(comments) != 1 || comments[0].ThreadStatus != domain.ThreadActive {
+		t.Fatalf("expected an active thread, got %+v", comments)
+	}
+
+	if err := provider.SetThreadStatus(context.Background(), identifier, comments[0], domain.ThreadFixed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mockClient.threadUpdates) != 1 {
+		t.Fatalf("expected one thread update, got %d", len(mockClient.threadUpdates))
+	}
+	update := mockClient.threadUpdates[0]
+	if *update.ThreadId != 5 || *update.PullRequestId != 9 || *update.CommentThread.Status != git.CommentThreadStatusValues.Fixed {
+		t.Errorf("unexpected thread update: %+v", update)
+	}
+}
+
+func TestListMentionCandidates(t *testing.T) {
+	identity := func(id, name string) *webapi.IdentityRef {
+		return &webapi.IdentityRef{Id: &id, DisplayName: &name}
+	}
+	reviewer := func(id, name string, container bool) git.IdentityRefWithVote {
+		return git.IdentityRefWithVote{Id: &id, DisplayName: &name, IsContainer: &container}
+	}
+	content := "Please check"
+	system := git.CommentTypeValues.System
+	mockClient := &mockGitClient{
+		pr: &git.GitPullRequest{
+			CreatedBy: identity("a1", "Alice"),
+			Reviewers: &[]git.IdentityRefWithVote{reviewer("b2", "Bob", false), reviewer("t9", "[Platform]\\Team", true)},
+		},
+		threads: &[]git.GitPullRequestCommentThread{{Comments: &[]git.Comment{
+			{Content: &content, Author: identity("c3", "Carol")},
+			{Content: &content, Author: identity("s0", "Project Collection Build Service"), CommentType: &system},
+			{Content: &content, Author: identity("a1", "Alice")},
+		}}},
+		repoPRs: &[]git.GitPullRequest{{CreatedBy: identity("d4", "Dave")}},
+	}
+	provider := &Provider{
+		client:    &Client{gitClient: mockClient, organization: "org"},
+		repoCache: map[string]*ResolvedRepository{"Platform/api": {ProjectID: "p", RepoID: "r", CachedAt: time.Now()}},
+		cacheTTL:  time.Minute,
+	}
+
+	candidates, err := provider.ListMentionCandidates(context.Background(), domain.PRIdentifier{Repository: "Platform/api", Number: 9})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, candidate := range candidates {
+		got = append(got, candidate.User.Username+"="+candidate.Text)
+	}
+	want := []string{"Alice=@<a1>", "Bob=@<b2>", "Carol=@<c3>", "Dave=@<d4>"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestUploadAttachment(t *testing.T) {
+	mockClient := &mockGitClient{}
+	provider := &Provider{
+		client:    &Client{gitClient: mockClient, organization: "org"},
+		repoCache: map[string]*ResolvedRepository{"Platform/api": {ProjectID: "p", RepoID: "r", CachedAt: time.Now()}},
+		cacheTTL:  time.Minute,
+	}
+
+	url, err := provider.UploadAttachment(context.Background(), domain.PRIdentifier{Repository: "Platform/api", Number: 9}, "shot.png", []byte("png"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(mockClient.attachments) != 1 {
+		t.Fatalf("expected one attachment, got %v", mockClient.attachments)
+	}
+	for name, content := range mockClient.attachments {
+		if !strings.HasSuffix(name, "-shot.png") || content != "png" {
+			t.Errorf("unexpected attachment %q: %q", name, content)
+		}
+		if !strings.HasSuffix(url, "/pullRequests/9/attachments/"+name) {
+			t.Errorf("unexpected URL %s", url)
+		}
+	}
+}
+
+func TestConvertPullRequest_ActiveLabels(t *testing.T) {
+	adoPR := createMockPR(42, "Test PR", nil)
+	active, inactive := true, false
+	adoPR.Labels = &[]core.WebApiTagDefinition{
+		{Name: strPtr("bug"), Active: &active},
+		{Name: strPtr("stale"), Active: &inactive},
+		{Name: strPtr("ui")},
+	}
+
+	labels := convertPullRequest(adoPR, "testuser").Labels
+	if len(labels) != 2 || labels[0] != "bug" || labels[1] != "ui" {
+		t.Errorf("expected the active labels, got %v", labels)
+	}
+}
@@ -0,0 +1,42 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// Reactions returns the only reaction Azure DevOps supports: liking a
+// comment.
+func (p *Provider) Reactions() []string {
+	return []string{domain.ReactionThumbsUp}
+}
+
+func (p *Provider) AddReaction(ctx context.Context, identifier domain.PRIdentifier, comment domain.Comment, content string) error {
+	if content != domain.ReactionThumbsUp {
+		return fmt.Errorf("Azure DevOps only supports liking comments")
+	}
+
+	threadID, err := strconv.Atoi(comment.ThreadID)
+	if err != nil {
+		return fmt.Errorf("invalid thread ID %q: %w", comment.ThreadID, err)
+	}
+	commentID, err := strconv.Atoi(comment.ID)
+	if err != nil {
+		return fmt.Errorf("invalid comment ID %q: %w", comment.ID, err)
+	}
+
+	projectID, repoID, err := p.resolveProjectAndRepoWithCache(ctx, identifier.Repository)
+	if err != nil {
+		return err
+	}
+
+	if err := p.client.LikeComment(ctx, projectID, repoID, identifier.Number, threadID, commentID); err != nil {
+		logger.LogError("AZURE_ADD_REACTION", fmt.Sprintf("%s#%d thread=%d comment=%d", identifier.Repository, identifier.Number, threadID, commentID), err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// ListRepositoryPullRequests lists the active PRs of a "project/repo"
+// repository, whoever they involve.
+func (p *Provider) ListRepositoryPullRequests(ctx context.Context, repository string, username string) ([]domain.PullRequest, error) {
+	projectID, repoID, err := p.resolveProjectAndRepoWithCache(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	adoPRs, err := p.client.ListPullRequests(ctx, projectID, repoID)
+	if err != nil {
+		logger.LogError("ADO_LIST_REPO_PRS", repository, err)
+		return nil, err
+	}
+	if adoPRs == nil {
+		return nil, nil
+	}
+
+	projectName, repoName, err := parseRepositoryIdentifier(repository)
+	if err != nil {
+		return nil, err
+	}
+	prs := make([]domain.PullRequest, 0, len(*adoPRs))
+	for _, adoPR := range *adoPRs {
+		pr := convertPullRequest(&adoPR, username)
+		if pr.URL == "" {
+			pr.URL = p.buildPRURL(projectName, repoName, pr.Number)
+		}
+		prs = append(prs, pr)
+	}
+	return prs, nil
+}
+
+// ListAuthorPullRequests lists the active PRs across the organization whose
+// author has author as their name or email address.
+func (p *Provider) ListAuthorPullRequests(ctx context.Context, author string, username string) ([]domain.PullRequest, error) {
+	return p.SearchPullRequests(ctx, "author:"+author, username)
+}
@@ -0,0 +1,31 @@
+package azuredevops
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+func TestListRepositoryPullRequests_ListsEveryActivePR(t *testing.T) {
+	mockClient := &mockGitClient{
+		repoPRs: &[]git.GitPullRequest{*createMockPR(4, "Community fix", nil), *createMockPR(5, "Docs", nil)},
+	}
+	provider := &Provider{
+		client:    &Client{gitClient: mockClient, organization: "org"},
+		repoCache: map[string]*ResolvedRepository{"Platform/TestRepo": {ProjectID: "proj", RepoID: "repo", CachedAt: time.Now()}},
+		cacheTTL:  time.Minute,
+	}
+
+	prs, err := provider.ListRepositoryPullRequests(context.Background(), "Platform/TestRepo", "someone")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 2 || prs[0].Number != 4 || prs[1].Number != 5 {
+		t.Fatalf("expected both PRs of the repository, got %+v", prs)
+	}
+	if prs[0].URL != "https://dev.azure.com/org/Platform/_git/TestRepo/pullrequest/4" {
+		t.Errorf("unexpected URL: %s", prs[0].URL)
+	}
+}
@@ -0,0 +1,76 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+// Azure DevOps votes range from 10 (approved) to -10 (rejected); these are
+// the boundaries of the approving and blocking votes.
+const (
+	voteApprovedSuggestions = 5
+	voteWaitingForAuthor    = -5
+)
+
+func (p *Provider) ListReviewers(ctx context.Context, identifier domain.PRIdentifier) ([]domain.Reviewer, error) {
+	projectID, repoID, err := p.resolveProjectAndRepoWithCache(ctx, identifier.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := p.client.GetPullRequest(ctx, projectID, repoID, identifier.Number)
+	if err != nil {
+		logger.LogError("AZURE_LIST_REVIEWERS", fmt.Sprintf("%s#%d", identifier.Repository, identifier.Number), err)
+		return nil, err
+	}
+
+	return convertReviewers(pr.Reviewers), nil
+}
+
+func convertReviewers(adoReviewers *[]git.IdentityRefWithVote) []domain.Reviewer {
+	if adoReviewers == nil {
+		return nil
+	}
+
+	reviewers := make([]domain.Reviewer, 0, len(*adoReviewers))
+	for _, adoReviewer := range *adoReviewers {
+		vote := 0
+		if adoReviewer.Vote != nil {
+			vote = *adoReviewer.Vote
+		}
+		reviewers = append(reviewers, domain.Reviewer{
+			User: domain.User{
+				ID:       common.GetString(adoReviewer.Id),
+				Username: common.GetString(adoReviewer.DisplayName),
+			},
+			State:    reviewerStateFromVote(vote),
+			Vote:     vote,
+			Required: adoReviewer.IsRequired != nil && *adoReviewer.IsRequired,
+		})
+	}
+
+	sort.SliceStable(reviewers, func(i, j int) bool {
+		if reviewers[i].Required != reviewers[j].Required {
+			return reviewers[i].Required
+		}
+		return strings.ToLower(reviewers[i].User.Username) < strings.ToLower(reviewers[j].User.Username)
+	})
+	return reviewers
+}
+
+func reviewerStateFromVote(vote int) domain.ReviewerState {
+	switch {
+	case vote >= voteApprovedSuggestions:
+		return domain.ReviewerApproved
+	case vote <= voteWaitingForAuthor:
+		return domain.ReviewerChangesRequested
+	}
+	return domain.ReviewerPending
+}
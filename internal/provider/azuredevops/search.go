@@ -0,0 +1,301 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/johanforsgren/lgtmfaster/internal/crash"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+// searchQuery is a parsed Azure DevOps PR search. Azure DevOps has no free
+// text PR search, so qualifiers map onto the API search criteria where
+// possible and everything else is matched locally.
+type searchQuery struct {
+	project string
+	repo    string
+	author  string
+	source  string
+	target  string
+	status  git.PullRequestStatus
+	terms   []string
+}
+
+func parseSearchQuery(query string) searchQuery {
+	q := searchQuery{status: git.PullRequestStatusValues.Active}
+
+	for _, field := range strings.Fields(query) {
+		key, value, found := strings.Cut(field, ":")
+		if !found || value == "" {
+			q.terms = append(q.terms, strings.ToLower(field))
+			continue
+		}
+
+		switch strings.ToLower(key) {
+		case "project":
+			q.project = value
+		case "repo":
+			q.repo = value
+		case "author":
+			q.author = value
+		case "source":
+			q.source = value
+		case "target", "base":
+			q.target = value
+		case "status", "is":
+			switch strings.ToLower(value) {
+			case "completed", "merged":
+				q.status = git.PullRequestStatusValues.Completed
+			case "abandoned", "closed":
+				q.status = git.PullRequestStatusValues.Abandoned
+			case "all":
+				q.status = git.PullRequestStatusValues.All
+			default:
+				q.status = git.PullRequestStatusValues.Active
+			}
+		default:
+			q.terms = append(q.terms, strings.ToLower(field))
+		}
+	}
+
+	return q
+}
+
+func (q searchQuery) criteria() git.GitPullRequestSearchCriteria {
+	status := q.status
+	criteria := git.GitPullRequestSearchCriteria{Status: &status}
+	if q.source != "" {
+		ref := "refs/heads/" + q.source
+		criteria.SourceRefName = &ref
+	}
+	if q.target != "" {
+		ref := "refs/heads/" + q.target
+		criteria.TargetRefName = &ref
+	}
+	return criteria
+}
+
+func (q searchQuery) matches(pr domain.PullRequest) bool {
+	return q.matchesScope(pr) && q.matchesText(pr.Title+"\n"+pr.Description+"\n"+pr.SourceBranch)
+}
+
+// matchesScope checks the qualifiers the API cannot filter on.
+func (q searchQuery) matchesScope(pr domain.PullRequest) bool {
+	if q.repo != "" && !strings.EqualFold(pr.Repository.Name, q.repo) {
+		return false
+	}
+	if q.author != "" {
+		author := strings.ToLower(q.author)
+		if !strings.Contains(strings.ToLower(pr.Author.Username), author) &&
+			!strings.HasPrefix(strings.ToLower(pr.Author.Email), author) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesText reports whether every free text term appears in text.
+func (q searchQuery) matchesText(text string) bool {
+	text = strings.ToLower(text)
+	for _, term := range q.terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// searchHit is a PR found by searchProjects with the project and repository
+// ID needed to fetch more of it.
+type searchHit struct {
+	pr      domain.PullRequest
+	project string
+	repoID  string
+}
+
+// maxConcurrentCommentSearches caps the PRs whose comments are fetched at
+// once by SearchInvolvedPullRequests.
+const maxConcurrentCommentSearches = 4
+
+// SearchPullRequests searches pull requests across the organization. The
+// query supports project:, repo:, author:, source:, target: and status:
+// qualifiers; remaining words must all appear in the title, description or
+// source branch.
+func (p *Provider) SearchPullRequests(ctx context.Context, query string, username string) ([]domain.PullRequest, error) {
+	logger.Log("AzureDevOps: Searching pull requests: %s", query)
+	q := parseSearchQuery(query)
+
+	hits, err := p.searchProjects(ctx, q, []git.GitPullRequestSearchCriteria{q.criteria()}, username)
+	var results []domain.PullRequest
+	for _, hit := range hits {
+		if q.matches(hit.pr) {
+			results = append(results, hit.pr)
+		}
+	}
+	if err != nil {
+		logger.LogError("ADO_SEARCH_PRS", query, err)
+		return results, err
+	}
+
+	logger.Log("AzureDevOps: Search returned %d pull requests", len(results))
+	return results, nil
+}
+
+// SearchInvolvedPullRequests searches the pull requests the user created or
+// is a reviewer of. It takes the qualifiers of SearchPullRequests, and the
+// remaining words must all appear in the title and description or in the
+// comments; comments are only fetched for the PRs the title and description
+// do not match.
+func (p *Provider) SearchInvolvedPullRequests(ctx context.Context, text string, username string) ([]domain.PullRequest, error) {
+	logger.Log("AzureDevOps: Searching involved pull requests: %s", text)
+	userID, err := p.client.GetAuthenticatedUserID(ctx)
+	if err != nil {
+		logger.LogError("ADO_SEARCH_INVOLVED", text, err)
+		return nil, err
+	}
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		err = fmt.Errorf("unexpected user ID %q: %w", userID, err)
+		logger.LogError("ADO_SEARCH_INVOLVED", text, err)
+		return nil, err
+	}
+
+	q := parseSearchQuery(text)
+	created, reviewing := q.criteria(), q.criteria()
+	created.CreatorId = &id
+	reviewing.ReviewerId = &id
+
+	hits, err := p.searchProjects(ctx, q, []git.GitPullRequestSearchCriteria{created, reviewing}, username)
+	if err != nil {
+		logger.LogError("ADO_SEARCH_INVOLVED", text, err)
+		return nil, err
+	}
+
+	matched := make([]bool, len(hits))
+	sem := make(chan struct{}, maxConcurrentCommentSearches)
+	var wg sync.WaitGroup
+	for i, hit := range hits {
+		if !q.matchesScope(hit.pr) {
+			continue
+		}
+		if q.matchesText(hit.pr.Title + "\n" + hit.pr.Description) {
+			matched[i] = true
+			continue
+		}
+		wg.Add(1)
+		go func(i int, hit searchHit) {
+			defer crash.Guard()
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			matched[i] = p.commentsMatch(ctx, q, hit)
+		}(i, hit)
+	}
+	wg.Wait()
+
+	var results []domain.PullRequest
+	for i, hit := range hits {
+		if matched[i] {
+			results = append(results, hit.pr)
+		}
+	}
+	logger.Log("AzureDevOps: Involved search returned %d pull requests", len(results))
+	return results, nil
+}
+
+// commentsMatch reports whether the comments of the PR of hit contain every
+// free text term. A PR whose comments cannot be fetched does not match.
+func (p *Provider) commentsMatch(ctx context.Context, q searchQuery, hit searchHit) bool {
+	threads, err := p.client.GetPullRequestThreads(ctx, hit.project, hit.repoID, hit.pr.Number)
+	if err != nil {
+		logger.LogError("ADO_SEARCH_COMMENTS", hit.pr.Key(), err)
+		return false
+	}
+	if threads == nil {
+		return false
+	}
+	var text strings.Builder
+	for _, thread := range *threads {
+		if thread.Comments == nil {
+			continue
+		}
+		for _, comment := range *thread.Comments {
+			text.WriteString(common.GetString(comment.Content))
+			text.WriteString("\n")
+		}
+	}
+	return q.matchesText(text.String())
+}
+
+// searchProjects runs each of criteria in the projects q covers, by default
+// all of them, and returns the PRs found once each.
+func (p *Provider) searchProjects(ctx context.Context, q searchQuery, criteria []git.GitPullRequestSearchCriteria, username string) ([]searchHit, error) {
+	var projects []string
+	if q.project != "" {
+		projects = []string{q.project}
+	} else {
+		refs, err := p.client.ListProjects(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range *refs {
+			projects = append(projects, common.GetString(project.Name))
+		}
+	}
+
+	var (
+		hits []searchHit
+		seen = make(map[string]bool)
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+	)
+	errChan := make(chan error, len(projects)*len(criteria))
+
+	for _, project := range projects {
+		for _, c := range criteria {
+			wg.Add(1)
+			go func(project string, c git.GitPullRequestSearchCriteria) {
+				defer crash.Guard()
+				defer wg.Done()
+
+				prs, err := p.client.SearchPullRequests(ctx, project, c)
+				if err != nil {
+					errChan <- err
+					return
+				}
+
+				for _, adoPR := range *prs {
+					pr := convertPullRequest(&adoPR, username)
+					if pr.URL == "" {
+						pr.URL = p.buildPRURL(project, pr.Repository.Name, pr.Number)
+					}
+					var repoID string
+					if adoPR.Repository != nil && adoPR.Repository.Id != nil {
+						repoID = adoPR.Repository.Id.String()
+					}
+					mu.Lock()
+					if !seen[pr.Key()] {
+						seen[pr.Key()] = true
+						hits = append(hits, searchHit{pr: pr, project: project, repoID: repoID})
+					}
+					mu.Unlock()
+				}
+			}(project, c)
+		}
+	}
+
+	wg.Wait()
+	close(errChan)
+
+	if len(errChan) > 0 {
+		return hits, <-errChan
+	}
+	return hits, nil
+}
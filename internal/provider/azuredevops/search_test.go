@@ -0,0 +1,149 @@
+package azuredevops
+
+import (
+	"context"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
+)
+
+func TestParseSearchQuery_Qualifiers(t *testing.T) {
+	q := parseSearchQuery("project:Platform repo:api author:jane target:main status:completed Fix Login")
+
+	if q.project != "Platform" || q.repo != "api" || q.author != "jane" || q.target != "main" {
+		t.Errorf("unexpected qualifiers: %+v", q)
+	}
+	if q.status != git.PullRequestStatusValues.Completed {
+		t.Errorf("expected completed status, got %v", q.status)
+	}
+	if len(q.terms) != 2 || q.terms[0] != "fix" || q.terms[1] != "login" {
+		t.Errorf("expected lowercased free text terms, got %v", q.terms)
+	}
+}
+
+func TestParseSearchQuery_DefaultsToActive(t *testing.T) {
+	q := parseSearchQuery("refactor")
+	if q.status != git.PullRequestStatusValues.Active {
+		t.Errorf("expected active status by default, got %v", q.status)
+	}
+
+	criteria := parseSearchQuery("source:feature/x target:main").criteria()
+	if criteria.SourceRefName == nil || *criteria.SourceRefName != "refs/heads/feature/x" {
+		t.Errorf("unexpected source ref: %v", criteria.SourceRefName)
+	}
+	if criteria.TargetRefName == nil || *criteria.TargetRefName != "refs/heads/main" {
+		t.Errorf("unexpected target ref: %v", criteria.TargetRefName)
+	}
+}
+
+func TestSearchQueryMatches(t *testing.T) {
+	pr := domain.PullRequest{
+		Title:        "Fix login redirect",
+		SourceBranch: "bugfix/auth",
+		Author:       domain.User{Username: "Jane Doe", Email: "jane@example.com"},
+		Repository:   domain.Repo{Name: "web"},
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"login", true},
+		{"login payments", false},
+		{"auth", true},
+		{"repo:WEB", true},
+		{"repo:api", false},
+		{"author:jane", true},
+		{"author:bob", false},
+	}
+
+	for _, tt := range tests {
+		if got := parseSearchQuery(tt.query).matches(pr); got != tt.want {
+			t.Errorf("matches(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSearchPullRequests_ProjectQualifier(t *testing.T) {
+	projectName := "Platform"
+	author := "Jane"
+
+	pr := createMockPR(12, "Add caching layer", nil)
+	pr.CreatedBy = &webapi.IdentityRef{DisplayName: &author}
+	pr.Repository.Project = &core.TeamProjectReference{Name: &projectName}
+
+	mockClient := &mockGitClient{
+		projectPRs: map[string][]git.GitPullRequest{"Platform": {*pr}},
+	}
+	provider := &Provider{client: &Client{gitClient: mockClient, organization: "org"}}
+
+	prs, err := provider.SearchPullRequests(context.Background(), "project:Platform caching", "someone")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(prs))
+	}
+	if prs[0].Repository.FullName != "Platform/TestRepo" {
+		t.Errorf("unexpected repository: %s", prs[0].Repository.FullName)
+	}
+	if prs[0].URL != "https://dev.azure.com/org/Platform/_git/TestRepo/pullrequest/12" {
+		t.Errorf("unexpected URL: %s", prs[0].URL)
+	}
+	if mockClient.lastSearchArgs.Top == nil || *mockClient.lastSearchArgs.Top != searchResultLimit {
+		t.Error("expected search to request the result limit")
+	}
+
+	prs, err = provider.SearchPullRequests(context.Background(), "project:Platform payments", "someone")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 0 {
+		t.Errorf("expected no results for non-matching terms, got %d", len(prs))
+	}
+}
+
+func TestSearchInvolvedPullRequests_MatchesTextAndComments(t *testing.T) {
+	userID := "11111111-2222-3333-4444-555555555555"
+	projectName := "Platform"
+	comment := "Could the retry use exponential backoff?"
+
+	titled := createMockPR(12, "Add retry backoff", nil)
+	commented := createMockPR(13, "Harden the client", nil)
+	for _, pr := range []*git.GitPullRequest{titled, commented} {
+		pr.Repository.Project = &core.TeamProjectReference{Name: &projectName}
+	}
+
+	mockClient := &mockGitClient{
+		projectPRs: map[string][]git.GitPullRequest{"Platform": {*titled, *commented}},
+		threads:    &[]git.GitPullRequestCommentThread{{Comments: &[]git.Comment{{Content: &comment}}}},
+	}
+	provider := &Provider{client: &Client{gitClient: mockClient, organization: "org", userID: userID}}
+
+	prs, err := provider.SearchInvolvedPullRequests(context.Background(), "project:Platform backoff", "someone")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 2 {
+		t.Fatalf("expected the PRs matched by title and by comment once each, got %+v", prs)
+	}
+	var creator, reviewer bool
+	for _, criteria := range mockClient.searchCriteria {
+		creator = creator || (criteria.CreatorId != nil && criteria.CreatorId.String() == userID)
+		reviewer = reviewer || (criteria.ReviewerId != nil && criteria.ReviewerId.String() == userID)
+	}
+	if !creator || !reviewer {
+		t.Errorf("expected the PRs the user created and reviews to be searched, got %+v", mockClient.searchCriteria)
+	}
+
+	prs, err = provider.SearchInvolvedPullRequests(context.Background(), "project:Platform payments", "someone")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 0 {
+		t.Errorf("expected no results for text found nowhere, got %+v", prs)
+	}
+}
@@ -0,0 +1,42 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+// ThreadStatuses returns the statuses the web UI offers for a comment
+// thread.
+func (p *Provider) ThreadStatuses() []domain.ThreadStatus {
+	return []domain.ThreadStatus{
+		domain.ThreadActive,
+		domain.ThreadPending,
+		domain.ThreadFixed,
+		domain.ThreadWontFix,
+		domain.ThreadClosed,
+		domain.ThreadByDesign,
+	}
+}
+
+func (p *Provider) SetThreadStatus(ctx context.Context, identifier domain.PRIdentifier, comment domain.Comment, status domain.ThreadStatus) error {
+	threadID, err := strconv.Atoi(comment.ThreadID)
+	if err != nil {
+		return fmt.Errorf("invalid thread ID %q: %w", comment.ThreadID, err)
+	}
+
+	projectID, repoID, err := p.resolveProjectAndRepoWithCache(ctx, identifier.Repository)
+	if err != nil {
+		return err
+	}
+
+	if err := p.client.UpdateThreadStatus(ctx, projectID, repoID, identifier.Number, threadID, git.CommentThreadStatus(status)); err != nil {
+		logger.LogError("AZURE_THREAD_STATUS", fmt.Sprintf("%s#%d thread=%d status=%s", identifier.Repository, identifier.Number, threadID, status), err)
+		return err
+	}
+	return nil
+}
@@ -33,6 +33,10 @@ func ParseUnifiedDiff(diffText string) *domain.Diff {
 			currentFile = &domain.FileDiff{
 				Hunks: []domain.DiffHunk{},
 			}
+		} else if strings.HasPrefix(line, "Binary files ") && currentHunk == nil {
+			if currentFile != nil {
+				parseBinaryLine(currentFile, line)
+			}
 		} else if strings.HasPrefix(line, "---") {
 			if currentFile != nil {
 				path := strings.TrimPrefix(line, "--- ")
@@ -94,3 +98,26 @@ func ParseUnifiedDiff(diffText string) *domain.Diff {
 
 	return &domain.Diff{Files: files}
 }
+
+// parseBinaryLine handles git's "Binary files a/x and b/x differ" line, which
+// replaces the ---/+++ headers and hunks of binary files.
+func parseBinaryLine(file *domain.FileDiff, line string) {
+	file.Binary = &domain.BinaryInfo{OldSize: -1, NewSize: -1}
+
+	paths := strings.TrimSuffix(strings.TrimPrefix(line, "Binary files "), " differ")
+	oldPath, newPath, ok := strings.Cut(paths, " and ")
+	if !ok {
+		return
+	}
+
+	if oldPath == "/dev/null" {
+		file.IsNew = true
+	} else {
+		file.OldPath = strings.TrimPrefix(oldPath, "a/")
+	}
+	if newPath == "/dev/null" {
+		file.IsDeleted = true
+	} else {
+		file.NewPath = strings.TrimPrefix(newPath, "b/")
+	}
+}
@@ -179,6 +179,41 @@ diff --git a/file2.txt b/file2.txt
 				},
 			},
 		},
+		{
+			name: "binary file",
+			diffText: `diff --git a/logo.png b/logo.png
+new file mode 100644
+index 0000000..3b18e51
+Binary files /dev/null and b/logo.png differ
+diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1 +1 @@
+-old
++new`,
+			want: &domain.Diff{
+				Files: []domain.FileDiff{
+					{
+						NewPath: "logo.png",
+						IsNew:   true,
+						Binary:  &domain.BinaryInfo{OldSize: -1, NewSize: -1},
+					},
+					{
+						OldPath: "main.go",
+						NewPath: "main.go",
+						Hunks: []domain.DiffHunk{
+							{
+								Header: "@@ -1 +1 @@",
+								Lines: []domain.DiffLine{
+									{Content: "-old", Type: "delete", OldLine: 1},
+									{Content: "+new", Type: "add", NewLine: 1},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -204,6 +239,9 @@ diff --git a/file2.txt b/file2.txt
 				if gotFile.IsDeleted != wantFile.IsDeleted {
 					t.Errorf("File %d IsDeleted = %v, want %v", i, gotFile.IsDeleted, wantFile.IsDeleted)
 				}
+				if (gotFile.Binary == nil) != (wantFile.Binary == nil) {
+					t.Errorf("File %d Binary = %v, want %v", i, gotFile.Binary, wantFile.Binary)
+				}
 				if len(gotFile.Hunks) != len(wantFile.Hunks) {
 					t.Errorf("File %d hunks count = %v, want %v", i, len(gotFile.Hunks), len(wantFile.Hunks))
 					continue
@@ -2,6 +2,8 @@ package common
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 )
@@ -10,8 +12,24 @@ var (
 	ErrInvalidIdentifierFormat = errors.New("invalid PR identifier format")
 	ErrProviderMismatch        = errors.New("provider type mismatch")
 	ErrPartialReviewSubmission = errors.New("review submission partially completed")
+	ErrReadOnly                = errors.New("read-only access: add a token to this PAT to perform this action")
+	ErrRateLimited             = errors.New("API rate limit reached")
+	ErrUnauthorized            = errors.New("authentication failed")
+	// ErrSourceBranchKept is returned after a successful merge when the
+	// source branch could not be deleted because it is in a fork.
+	ErrSourceBranchKept = errors.New("the source branch is in a fork and was kept")
 )
 
+// AuthError wraps err in ErrUnauthorized when statusCode says the provider
+// rejected the credentials, so that a bad token can be told apart from a
+// provider that cannot be reached.
+func AuthError(statusCode int, err error) error {
+	if err == nil || (statusCode != http.StatusUnauthorized && statusCode != http.StatusForbidden) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", ErrUnauthorized, err)
+}
+
 var messagePattern = regexp.MustCompile(`Message:([^}]+)`)
 
 func ExtractErrorMessage(err error) string {
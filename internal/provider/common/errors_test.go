@@ -47,3 +47,20 @@ func TestExtractErrorMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthError(t *testing.T) {
+	err := errors.New("GET https://api.github.com/user: 401 Bad credentials")
+	for _, status := range []int{401, 403} {
+		if wrapped := AuthError(status, err); !errors.Is(wrapped, ErrUnauthorized) {
+			t.Errorf("%d: expected ErrUnauthorized, got %v", status, wrapped)
+		}
+	}
+	for _, status := range []int{0, 404, 500} {
+		if wrapped := AuthError(status, err); wrapped != err {
+			t.Errorf("%d: expected the error unchanged, got %v", status, wrapped)
+		}
+	}
+	if AuthError(401, nil) != nil {
+		t.Error("expected no error without one")
+	}
+}
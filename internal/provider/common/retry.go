@@ -0,0 +1,160 @@
+package common
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/network"
+)
+
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 10 * time.Second
+)
+
+// maxAttempts is how many times a request is tried, shared by the clients
+// of all providers so that a settings change applies to them at once.
+var maxAttempts atomic.Int32
+
+func init() {
+	maxAttempts.Store(domain.DefaultMaxAttempts)
+}
+
+// SetMaxAttempts sets how many times a request failing with a transient
+// error is tried; 1 turns retries off.
+func SetMaxAttempts(attempts int) {
+	maxAttempts.Store(int32(max(attempts, 1)))
+}
+
+// RetryTransport retries requests that fail with a transient error: 429 Too
+// Many Requests, a 5xx other than 501 Not Implemented, or a server that could
+// not be reached. Attempts are spaced out with jittered exponential backoff,
+// or as long as the server's Retry-After asks for when it is short enough.
+// Requests that are not idempotent, such as submitting a review, are only
+// retried after a 429, which means they were not processed.
+type RetryTransport struct {
+	base      http.RoundTripper
+	baseDelay time.Duration
+	maxDelay  time.Duration
+}
+
+func NewRetryTransport(base http.RoundTripper) *RetryTransport {
+	return &RetryTransport{
+		base:      base,
+		baseDelay: retryBaseDelay,
+		maxDelay:  retryMaxDelay,
+	}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	attempts := int(maxAttempts.Load())
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := base.RoundTrip(req)
+		if attempt >= attempts || !t.retryable(req, resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok {
+				if after > t.maxDelay {
+					return resp, err
+				}
+				delay = after
+			}
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		var reason string
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+		}
+		logger.Log("Network: Retrying %s %s in %s after %s (attempt %d of %d)",
+			req.Method, req.URL.Redacted(), delay.Round(time.Millisecond), reason, attempt+1, attempts)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+func (t *RetryTransport) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return idempotent(req.Method) && network.IsUnreachable(err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented:
+		return idempotent(req.Method)
+	}
+	return false
+}
+
+// backoff returns a random delay between half and all of baseDelay doubled
+// for each attempt so far, so that clients failing together do not retry
+// together.
+func (t *RetryTransport) backoff(attempt int) time.Duration {
+	ceiling := t.baseDelay << (attempt - 1)
+	if ceiling <= 0 || ceiling > t.maxDelay {
+		ceiling = t.maxDelay
+	}
+	return ceiling/2 + rand.N(ceiling/2+1)
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the delay a Retry-After header asks for, given in
+// seconds or as a date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,136 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestRetryClient() *http.Client {
+	transport := NewRetryTransport(nil)
+	transport.baseDelay = time.Millisecond
+	return &http.Client{Transport: transport}
+}
+
+func TestRetryTransport_RetriesTransientFailures(t *testing.T) {
+	SetMaxAttempts(3)
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	resp, err := newTestRetryClient().Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls != 3 {
+		t.Errorf("expected success on the third attempt, got %d after %d call(s)", resp.StatusCode, calls)
+	}
+}
+
+func TestRetryTransport_GivesUpAfterMaxAttempts(t *testing.T) {
+	SetMaxAttempts(2)
+	defer SetMaxAttempts(3)
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	resp, err := newTestRetryClient().Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || calls != 2 {
+		t.Errorf("expected the last failure after 2 calls, got %d after %d call(s)", resp.StatusCode, calls)
+	}
+}
+
+func TestRetryTransport_RetriesPostsOnlyWhenTooManyRequests(t *testing.T) {
+	SetMaxAttempts(3)
+	var bodies []string
+	status := http.StatusInternalServerError
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+		}
+	}))
+	defer server.Close()
+	client := newTestRetryClient()
+
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("LGTM"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError || len(bodies) != 1 {
+		t.Errorf("expected a failed post not to be repeated, got %d after %q", resp.StatusCode, bodies)
+	}
+
+	bodies, status = nil, http.StatusTooManyRequests
+	resp, err = client.Post(server.URL, "text/plain", strings.NewReader("LGTM"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || len(bodies) != 2 || bodies[1] != "LGTM" {
+		t.Errorf("expected a rejected post to be sent again with its body, got %d after %q", resp.StatusCode, bodies)
+	}
+}
+
+func TestRetryTransport_DoesNotWaitLongerThanTheMaximumDelay(t *testing.T) {
+	SetMaxAttempts(3)
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	resp, err := newTestRetryClient().Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if calls != 1 {
+		t.Errorf("expected to give up rather than wait an hour, got %d call(s)", calls)
+	}
+}
+
+func TestRetryTransport_StopsWhenCancelled(t *testing.T) {
+	SetMaxAttempts(3)
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	transport := NewRetryTransport(nil)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if resp, err := transport.RoundTrip(req); err == nil {
+		resp.Body.Close()
+	}
+	if calls != 1 {
+		t.Errorf("expected no retries once cancelled, got %d call(s)", calls)
+	}
+}
@@ -0,0 +1,325 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+// xssiPrefix is prepended by Gerrit to every JSON response.
+const xssiPrefix = ")]}'"
+
+// timeLayout is the format of Gerrit timestamps, which are always UTC.
+const timeLayout = "2006-01-02 15:04:05.000000000"
+
+// Client talks to the Gerrit REST API. Requests are authenticated with the
+// username and HTTP password when a password is set, and anonymous
+// otherwise.
+type Client struct {
+	baseURL    string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+func NewClient(baseURL, username, password string) *Client {
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		username:   username,
+		password:   password,
+		httpClient: &http.Client{Timeout: 60 * time.Second, Transport: common.NewRetryTransport(nil)},
+	}
+}
+
+func (c *Client) authenticated() bool {
+	return c.password != ""
+}
+
+// do sends a request to path, which is relative to the REST root, and
+// decodes the JSON response into result unless it is nil.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, result any) error {
+	endpoint := c.baseURL
+	if c.authenticated() {
+		endpoint += "/a"
+	}
+	endpoint += path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.authenticated() {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("gerrit request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read gerrit response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Gerrit reports errors as plain text.
+		if msg := strings.TrimSpace(string(data)); msg != "" {
+			return common.AuthError(resp.StatusCode, fmt.Errorf("gerrit returned %d: %s", resp.StatusCode, msg))
+		}
+		return common.AuthError(resp.StatusCode, fmt.Errorf("gerrit returned %d", resp.StatusCode))
+	}
+	if result == nil {
+		return nil
+	}
+
+	data = bytes.TrimPrefix(data, []byte(xssiPrefix))
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("failed to decode gerrit response: %w", err)
+	}
+	return nil
+}
+
+// changeID is the "project~number" change identifier accepted by every
+// change endpoint, escaped for use in a path.
+func changeID(project string, number int) string {
+	return url.PathEscape(project + "~" + strconv.Itoa(number))
+}
+
+// Change options requested with every change so that it can be converted
+// without further calls.
+var changeOptions = []string{"DETAILED_ACCOUNTS", "DETAILED_LABELS", "CURRENT_REVISION", "CURRENT_COMMIT"}
+
+func (c *Client) QueryChanges(ctx context.Context, query string, limit int) ([]ChangeInfo, error) {
+	params := url.Values{"q": {query}, "o": changeOptions}
+	if limit > 0 {
+		params.Set("n", strconv.Itoa(limit))
+	}
+	var changes []ChangeInfo
+	if err := c.do(ctx, http.MethodGet, "/changes/", params, nil, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (c *Client) GetChange(ctx context.Context, project string, number int) (*ChangeInfo, error) {
+	params := url.Values{"o": append([]string{"MESSAGES"}, changeOptions...)}
+	var change ChangeInfo
+	if err := c.do(ctx, http.MethodGet, "/changes/"+changeID(project, number), params, nil, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// ListFiles returns the files changed by the current patch set, keyed by
+// path. The magic "/COMMIT_MSG" and "/MERGE_LIST" entries are included.
+func (c *Client) ListFiles(ctx context.Context, project string, number int) (map[string]FileInfo, error) {
+	var files map[string]FileInfo
+	path := "/changes/" + changeID(project, number) + "/revisions/current/files"
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// GetFileDiff returns the diff of a file in the current patch set against
+// its parent, with the whole file as context.
+func (c *Client) GetFileDiff(ctx context.Context, project string, number int, file string) (*DiffInfo, error) {
+	var diff DiffInfo
+	path := "/changes/" + changeID(project, number) + "/revisions/current/files/" + url.PathEscape(file) + "/diff"
+	if err := c.do(ctx, http.MethodGet, path, url.Values{"intraline": {"false"}}, nil, &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
+// ListComments returns the published comments on every patch set, keyed by
+// path.
+func (c *Client) ListComments(ctx context.Context, project string, number int) (map[string][]CommentInfo, error) {
+	var comments map[string][]CommentInfo
+	if err := c.do(ctx, http.MethodGet, "/changes/"+changeID(project, number)+"/comments", nil, nil, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// SetReview publishes a review of the current patch set.
+func (c *Client) SetReview(ctx context.Context, project string, number int, review ReviewInput) error {
+	path := "/changes/" + changeID(project, number) + "/revisions/current/review"
+	return c.do(ctx, http.MethodPost, path, nil, review, nil)
+}
+
+func (c *Client) Submit(ctx context.Context, project string, number int) error {
+	return c.do(ctx, http.MethodPost, "/changes/"+changeID(project, number)+"/submit", nil, struct{}{}, nil)
+}
+
+// SetCommitMessage uploads a new patch set that only changes the commit
+// message.
+func (c *Client) SetCommitMessage(ctx context.Context, project string, number int, message string) error {
+	body := map[string]string{"message": message}
+	return c.do(ctx, http.MethodPut, "/changes/"+changeID(project, number)+"/message", nil, body, nil)
+}
+
+func (c *Client) GetSelf(ctx context.Context) (*AccountInfo, error) {
+	var account AccountInfo
+	if err := c.do(ctx, http.MethodGet, "/accounts/self", nil, nil, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// Timestamp is a Gerrit timestamp.
+type Timestamp struct {
+	time.Time
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		return nil
+	}
+	parsed, err := time.Parse(timeLayout, s)
+	if err != nil {
+		return fmt.Errorf("invalid gerrit timestamp %q: %w", s, err)
+	}
+	t.Time = parsed
+	return nil
+}
+
+type AccountInfo struct {
+	AccountID int    `json:"_account_id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Username  string `json:"username"`
+}
+
+type ApprovalInfo struct {
+	AccountInfo
+	Value int `json:"value"`
+}
+
+type LabelInfo struct {
+	Approved    *AccountInfo   `json:"approved"`
+	Rejected    *AccountInfo   `json:"rejected"`
+	Recommended *AccountInfo   `json:"recommended"`
+	Disliked    *AccountInfo   `json:"disliked"`
+	All         []ApprovalInfo `json:"all"`
+}
+
+type CommitInfo struct {
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+}
+
+type RevisionInfo struct {
+	Number int         `json:"_number"`
+	Ref    string      `json:"ref"`
+	Commit *CommitInfo `json:"commit"`
+}
+
+type ChangeMessageInfo struct {
+	ID             string       `json:"id"`
+	Author         *AccountInfo `json:"author"`
+	Date           Timestamp    `json:"date"`
+	Message        string       `json:"message"`
+	Tag            string       `json:"tag"`
+	RevisionNumber int          `json:"_revision_number"`
+}
+
+type ChangeInfo struct {
+	ID              string                   `json:"id"`
+	Project         string                   `json:"project"`
+	Branch          string                   `json:"branch"`
+	ChangeID        string                   `json:"change_id"`
+	Subject         string                   `json:"subject"`
+	Status          string                   `json:"status"`
+	Created         Timestamp                `json:"created"`
+	Updated         Timestamp                `json:"updated"`
+	Number          int                      `json:"_number"`
+	Owner           AccountInfo              `json:"owner"`
+	Labels          map[string]LabelInfo     `json:"labels"`
+	PermittedLabels map[string][]string      `json:"permitted_labels"`
+	Reviewers       map[string][]AccountInfo `json:"reviewers"`
+	CurrentRevision string                   `json:"current_revision"`
+	Revisions       map[string]RevisionInfo  `json:"revisions"`
+	Mergeable       *bool                    `json:"mergeable"`
+	Submittable     bool                     `json:"submittable"`
+	WorkInProgress  bool                     `json:"work_in_progress"`
+	Messages        []ChangeMessageInfo      `json:"messages"`
+}
+
+type FileInfo struct {
+	Status        string `json:"status"`
+	Binary        bool   `json:"binary"`
+	OldPath       string `json:"old_path"`
+	Size          int64  `json:"size"`
+	LinesInserted int    `json:"lines_inserted"`
+	LinesDeleted  int    `json:"lines_deleted"`
+}
+
+type DiffContent struct {
+	A    []string `json:"a"`
+	B    []string `json:"b"`
+	AB   []string `json:"ab"`
+	Skip int      `json:"skip"`
+}
+
+type DiffInfo struct {
+	ChangeType string        `json:"change_type"`
+	Binary     bool          `json:"binary"`
+	Content    []DiffContent `json:"content"`
+}
+
+type CommentInfo struct {
+	ID        string      `json:"id"`
+	Path      string      `json:"path"`
+	Side      string      `json:"side"`
+	PatchSet  int         `json:"patch_set"`
+	Line      int         `json:"line"`
+	InReplyTo string      `json:"in_reply_to"`
+	Message   string      `json:"message"`
+	Updated   Timestamp   `json:"updated"`
+	Author    AccountInfo `json:"author"`
+}
+
+type CommentInput struct {
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+	// Side is "PARENT" for a line of the base the patch set is compared
+	// with, and empty for one of the patch set.
+	Side string `json:"side,omitempty"`
+}
+
+type ReviewInput struct {
+	Message  string                    `json:"message,omitempty"`
+	Labels   map[string]int            `json:"labels,omitempty"`
+	Comments map[string][]CommentInput `json:"comments,omitempty"`
+}
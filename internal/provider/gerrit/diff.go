@@ -0,0 +1,105 @@
+package gerrit
+
+import (
+	"fmt"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+// contextLines is the number of unchanged lines kept around each change,
+// matching git's default.
+const contextLines = 3
+
+// convertDiff turns Gerrit's side-by-side diff content into unified hunks.
+// Gerrit returns the whole file, so unchanged lines further than
+// contextLines from a change are dropped and split the hunks.
+func convertDiff(diff *DiffInfo) []domain.DiffHunk {
+	type numbered struct {
+		line domain.DiffLine
+		gap  bool
+	}
+
+	var lines []numbered
+	oldLine, newLine := 1, 1
+	gap := false
+	for _, content := range diff.Content {
+		if content.Skip > 0 {
+			oldLine += content.Skip
+			newLine += content.Skip
+			gap = true
+			continue
+		}
+		for _, text := range content.AB {
+			lines = append(lines, numbered{line: domain.DiffLine{Type: "context", Content: " " + text, OldLine: oldLine, NewLine: newLine}, gap: gap})
+			oldLine++
+			newLine++
+			gap = false
+		}
+		for _, text := range content.A {
+			lines = append(lines, numbered{line: domain.DiffLine{Type: "delete", Content: "-" + text, OldLine: oldLine}, gap: gap})
+			oldLine++
+			gap = false
+		}
+		for _, text := range content.B {
+			lines = append(lines, numbered{line: domain.DiffLine{Type: "add", Content: "+" + text, NewLine: newLine}, gap: gap})
+			newLine++
+			gap = false
+		}
+	}
+
+	keep := make([]bool, len(lines))
+	for i, l := range lines {
+		if l.line.Type == "context" {
+			continue
+		}
+		for j := max(0, i-contextLines); j <= min(len(lines)-1, i+contextLines); j++ {
+			keep[j] = true
+		}
+	}
+
+	var hunks []domain.DiffHunk
+	var current []domain.DiffLine
+	flush := func() {
+		if len(current) > 0 {
+			hunks = append(hunks, domain.DiffHunk{Header: hunkHeader(current), Lines: current})
+			current = nil
+		}
+	}
+	for i, l := range lines {
+		if !keep[i] || l.gap {
+			flush()
+		}
+		if keep[i] {
+			current = append(current, l.line)
+		}
+	}
+	flush()
+	return hunks
+}
+
+func hunkHeader(lines []domain.DiffLine) string {
+	oldStart, newStart := 0, 0
+	oldCount, newCount := 0, 0
+	for _, line := range lines {
+		if line.Type != "add" {
+			if oldCount == 0 {
+				oldStart = line.OldLine
+			}
+			oldCount++
+		}
+		if line.Type != "delete" {
+			if newCount == 0 {
+				newStart = line.NewLine
+			}
+			newCount++
+		}
+	}
+	// An empty side starts at the line before the change, as in git.
+	if oldCount == 0 {
+		oldStart = max(0, lines[0].NewLine-1)
+	}
+	if newCount == 0 {
+		newStart = max(0, lines[0].OldLine-1)
+	}
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", oldStart, oldCount, newStart, newCount)
+}
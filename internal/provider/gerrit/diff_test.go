@@ -0,0 +1,67 @@
+package gerrit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestConvertDiff_KeepsContextAroundChanges(t *testing.T) {
+	diff := &DiffInfo{Content: []DiffContent{
+		{AB: []string{"l1", "l2", "l3", "l4", "l5"}},
+		{A: []string{"old6"}, B: []string{"new6", "new7"}},
+		{AB: []string{"l7", "l8", "l9", "l10", "l11", "l12", "l13", "l14", "l15"}},
+		{B: []string{"added"}},
+	}}
+
+	hunks := convertDiff(diff)
+	if len(hunks) != 2 {
+		t.Fatalf("expected two hunks, got %+v", hunks)
+	}
+
+	first := hunks[0]
+	if first.Header != "@@ -3,7 +3,8 @@" {
+		t.Errorf("first header = %q", first.Header)
+	}
+	wantFirst := []domain.DiffLine{
+		{Type: "context", Content: " l3", OldLine: 3, NewLine: 3},
+		{Type: "context", Content: " l4", OldLine: 4, NewLine: 4},
+		{Type: "context", Content: " l5", OldLine: 5, NewLine: 5},
+		{Type: "delete", Content: "-old6", OldLine: 6},
+		{Type: "add", Content: "+new6", NewLine: 6},
+		{Type: "add", Content: "+new7", NewLine: 7},
+		{Type: "context", Content: " l7", OldLine: 7, NewLine: 8},
+		{Type: "context", Content: " l8", OldLine: 8, NewLine: 9},
+		{Type: "context", Content: " l9", OldLine: 9, NewLine: 10},
+	}
+	if !reflect.DeepEqual(first.Lines, wantFirst) {
+		t.Errorf("first hunk lines =\n%+v\nwant\n%+v", first.Lines, wantFirst)
+	}
+
+	second := hunks[1]
+	if second.Header != "@@ -13,3 +14,4 @@" || len(second.Lines) != 4 || second.Lines[3].Content != "+added" {
+		t.Errorf("unexpected second hunk: %+v", second)
+	}
+}
+
+func TestConvertDiff_NewFile(t *testing.T) {
+	hunks := convertDiff(&DiffInfo{Content: []DiffContent{{B: []string{"a", "b"}}}})
+	if len(hunks) != 1 || hunks[0].Header != "@@ -0,0 +1,2 @@" {
+		t.Errorf("unexpected hunks for a new file: %+v", hunks)
+	}
+}
+
+func TestConvertDiff_SkipSplitsHunks(t *testing.T) {
+	hunks := convertDiff(&DiffInfo{Content: []DiffContent{
+		{A: []string{"x"}},
+		{Skip: 100},
+		{B: []string{"y"}},
+	}})
+	if len(hunks) != 2 {
+		t.Fatalf("expected the skipped lines to split the hunks, got %+v", hunks)
+	}
+	if got := hunks[1].Lines[0]; got.NewLine != 101 {
+		t.Errorf("expected line numbers to continue after the skip, got %+v", got)
+	}
+}
@@ -0,0 +1,563 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+// codeReview is the label review actions are voted on.
+const codeReview = "Code-Review"
+
+// listQuery selects the open changes the user owns or reviews.
+const listQuery = "is:open (owner:self OR reviewer:self)"
+
+// listLimit bounds the number of changes listed or found by a search.
+const listLimit = 200
+
+// autogenerated marks change messages Gerrit writes itself, such as
+// "Uploaded patch set 2.".
+const autogenerated = "autogenerated:"
+
+// Provider maps Gerrit changes to pull requests. A change's project is the
+// repository and its change number the PR number; the diff and new comments
+// are always those of the current patch set.
+type Provider struct {
+	client       *Client
+	baseURL      string
+	username     string
+	repositories []string
+}
+
+// NewProvider creates a provider for the Gerrit server at baseURL. The
+// token is the user's HTTP password; without one the provider is read-only
+// and lists the open changes of the given projects.
+func NewProvider(baseURL, token, username string, repositories []string) (*Provider, error) {
+	baseURL = strings.TrimSuffix(strings.TrimSpace(baseURL), "/")
+	if baseURL == "" {
+		return nil, fmt.Errorf("gerrit server URL is required: set it as the PAT's organization")
+	}
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "https://" + baseURL
+	}
+	return &Provider{
+		client:       NewClient(baseURL, username, token),
+		baseURL:      baseURL,
+		username:     username,
+		repositories: repositories,
+	}, nil
+}
+
+func (p *Provider) GetType() domain.ProviderType {
+	return domain.ProviderGerrit
+}
+
+func (p *Provider) ListPullRequests(ctx context.Context, username string) ([]domain.PullRequest, error) {
+	logger.Log("Gerrit: Listing changes for user %s", username)
+	query := listQuery
+	if !p.client.authenticated() {
+		if len(p.repositories) == 0 {
+			return nil, fmt.Errorf("no projects configured for anonymous Gerrit access")
+		}
+		projects := make([]string, 0, len(p.repositories))
+		for _, repository := range p.repositories {
+			projects = append(projects, "project:"+repository)
+		}
+		query = "is:open (" + strings.Join(projects, " OR ") + ")"
+	}
+
+	changes, err := p.client.QueryChanges(ctx, query, listLimit)
+	if err != nil {
+		logger.LogError("GERRIT_LIST_CHANGES", username, err)
+		return nil, err
+	}
+
+	prs := p.convertChanges(changes, username)
+	logger.Log("Gerrit: Found %d changes", len(prs))
+	return prs, nil
+}
+
+// SearchPullRequests searches changes using Gerrit's query syntax, e.g.
+// "project:core status:open label:Verified=-1".
+func (p *Provider) SearchPullRequests(ctx context.Context, query string, username string) ([]domain.PullRequest, error) {
+	logger.Log("Gerrit: Searching changes: %s", query)
+	changes, err := p.client.QueryChanges(ctx, query, listLimit)
+	if err != nil {
+		logger.LogError("GERRIT_SEARCH_CHANGES", query, err)
+		return nil, err
+	}
+
+	prs := p.convertChanges(changes, username)
+	logger.Log("Gerrit: Search returned %d changes", len(prs))
+	return prs, nil
+}
+
+// ListAuthorPullRequests lists the open changes owned by author.
+func (p *Provider) ListAuthorPullRequests(ctx context.Context, author string, username string) ([]domain.PullRequest, error) {
+	return p.SearchPullRequests(ctx, fmt.Sprintf("owner:%s status:open", author), username)
+}
+
+// ListRepositoryPullRequests lists the open changes of a project.
+func (p *Provider) ListRepositoryPullRequests(ctx context.Context, repository string, username string) ([]domain.PullRequest, error) {
+	return p.SearchPullRequests(ctx, fmt.Sprintf("project:%s status:open", repository), username)
+}
+
+func (p *Provider) GetPullRequest(ctx context.Context, identifier domain.PRIdentifier) (*domain.PullRequest, error) {
+	logger.Log("Gerrit: Getting change %d from %s", identifier.Number, identifier.Repository)
+	change, err := p.client.GetChange(ctx, identifier.Repository, identifier.Number)
+	if err != nil {
+		logger.LogError("GERRIT_GET_CHANGE", fmt.Sprintf("%s~%d", identifier.Repository, identifier.Number), err)
+		return nil, err
+	}
+
+	pr := p.convertChange(*change, p.username)
+	return &pr, nil
+}
+
+func (p *Provider) GetDiff(ctx context.Context, identifier domain.PRIdentifier) (*domain.Diff, error) {
+	logger.Log("Gerrit: Getting diff for change %d from %s", identifier.Number, identifier.Repository)
+	files, err := p.ListDiffFiles(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &domain.Diff{Files: make([]domain.FileDiff, 0, len(files))}
+	for _, file := range files {
+		loaded, err := p.GetFileDiff(ctx, identifier, file)
+		if err != nil {
+			return nil, err
+		}
+		diff.Files = append(diff.Files, *loaded)
+	}
+
+	logger.Log("Gerrit: Parsed diff with %d files", len(diff.Files))
+	return diff, nil
+}
+
+// ListDiffFiles returns the files of the current patch set, sorted by path,
+// with their line counts but without hunks.
+func (p *Provider) ListDiffFiles(ctx context.Context, identifier domain.PRIdentifier) ([]domain.FileDiff, error) {
+	files, err := p.client.ListFiles(ctx, identifier.Repository, identifier.Number)
+	if err != nil {
+		logger.LogError("GERRIT_GET_DIFF", fmt.Sprintf("%s~%d", identifier.Repository, identifier.Number), err)
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		// Skip the magic /COMMIT_MSG and /MERGE_LIST files.
+		if !strings.HasPrefix(path, "/") {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	listed := make([]domain.FileDiff, 0, len(paths))
+	for _, path := range paths {
+		info := files[path]
+		file := convertFile(path, info)
+		file.Additions = info.LinesInserted
+		file.Deletions = info.LinesDeleted
+		file.Deferred = true
+		if info.Binary {
+			file.Binary = &domain.BinaryInfo{OldSize: -1, NewSize: info.Size}
+			if file.IsDeleted {
+				file.Binary.NewSize = -1
+			}
+		}
+		listed = append(listed, file)
+	}
+	return listed, nil
+}
+
+// GetFileDiff loads the hunks of a file listed by ListDiffFiles. Binary
+// files have none to load.
+func (p *Provider) GetFileDiff(ctx context.Context, identifier domain.PRIdentifier, file domain.FileDiff) (*domain.FileDiff, error) {
+	file.Deferred = false
+	if file.Binary != nil {
+		return &file, nil
+	}
+
+	path := file.NewPath
+	if path == "" {
+		path = file.OldPath
+	}
+	fileDiff, err := p.client.GetFileDiff(ctx, identifier.Repository, identifier.Number, path)
+	if err != nil {
+		logger.LogError("GERRIT_GET_FILE_DIFF", path, err)
+		return nil, err
+	}
+	file.Hunks = convertDiff(fileDiff)
+	return &file, nil
+}
+
+func convertFile(path string, info FileInfo) domain.FileDiff {
+	file := domain.FileDiff{OldPath: path, NewPath: path}
+	switch info.Status {
+	case "A":
+		file.OldPath = ""
+		file.IsNew = true
+	case "D":
+		file.NewPath = ""
+		file.IsDeleted = true
+	case "R", "C":
+		file.OldPath = info.OldPath
+		file.IsRenamed = true
+	}
+	return file
+}
+
+// GetComments returns the inline comments of every patch set followed by
+// the review messages. Comments on the commit message and patch set level
+// comments are shown as general comments.
+func (p *Provider) GetComments(ctx context.Context, identifier domain.PRIdentifier) ([]domain.Comment, error) {
+	byPath, err := p.client.ListComments(ctx, identifier.Repository, identifier.Number)
+	if err != nil {
+		logger.LogError("GERRIT_GET_COMMENTS", fmt.Sprintf("%s~%d", identifier.Repository, identifier.Number), err)
+		return nil, err
+	}
+	change, err := p.client.GetChange(ctx, identifier.Repository, identifier.Number)
+	if err != nil {
+		logger.LogError("GERRIT_GET_COMMENTS", fmt.Sprintf("%s~%d", identifier.Repository, identifier.Number), err)
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(byPath))
+	for path := range byPath {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var comments []domain.Comment
+	for _, path := range paths {
+		for _, c := range byPath[path] {
+			comments = append(comments, convertComment(path, c))
+		}
+	}
+	for _, message := range change.Messages {
+		if comment, ok := convertMessage(message); ok {
+			comments = append(comments, comment)
+		}
+	}
+	return comments, nil
+}
+
+func convertComment(path string, c CommentInfo) domain.Comment {
+	comment := domain.Comment{
+		ID:        c.ID,
+		Author:    convertAccount(c.Author),
+		Body:      c.Message,
+		CreatedAt: c.Updated.Time,
+		UpdatedAt: c.Updated.Time,
+		FilePath:  path,
+		Line:      c.Line,
+		Side:      "RIGHT",
+	}
+	if c.Side == "PARENT" {
+		comment.Side = "LEFT"
+	}
+	if strings.HasPrefix(path, "/") {
+		comment.FilePath = ""
+		comment.Line = 0
+		comment.Side = ""
+	}
+	return comment
+}
+
+// convertMessage turns a review message, such as "Patch Set 2: Code-Review+2",
+// into a general comment. Messages Gerrit generates itself are skipped.
+func convertMessage(message ChangeMessageInfo) (domain.Comment, bool) {
+	if strings.HasPrefix(message.Tag, autogenerated) || message.Author == nil {
+		return domain.Comment{}, false
+	}
+	body := strings.TrimSpace(message.Message)
+	if body == "" {
+		return domain.Comment{}, false
+	}
+	return domain.Comment{
+		ID:        message.ID,
+		Author:    convertAccount(*message.Author),
+		Body:      body,
+		CreatedAt: message.Date.Time,
+		UpdatedAt: message.Date.Time,
+	}, true
+}
+
+func (p *Provider) AddComment(ctx context.Context, identifier domain.PRIdentifier, body string, filePath string, line int, side string) error {
+	if !p.client.authenticated() {
+		return common.ErrReadOnly
+	}
+
+	review := ReviewInput{}
+	if filePath != "" && line > 0 {
+		review.Comments = map[string][]CommentInput{filePath: {commentInput(line, side, body)}}
+	} else {
+		review.Message = body
+	}
+
+	if err := p.client.SetReview(ctx, identifier.Repository, identifier.Number, review); err != nil {
+		logger.LogError("GERRIT_ADD_COMMENT", fmt.Sprintf("%s~%d", identifier.Repository, identifier.Number), err)
+		return err
+	}
+	return nil
+}
+
+// commentInput anchors a comment to line of the patch set, or of its parent
+// when side is "LEFT", as for a deleted line.
+func commentInput(line int, side, body string) CommentInput {
+	input := CommentInput{Line: line, Message: body}
+	if side == "LEFT" {
+		input.Side = "PARENT"
+	}
+	return input
+}
+
+// SubmitReview publishes the review on the current patch set. Approving
+// votes the highest Code-Review value the user may give (+2 for maintainers,
+// +1 otherwise); requesting changes votes -1, leaving the blocking -2 to the
+// web UI.
+func (p *Provider) SubmitReview(ctx context.Context, review domain.Review) error {
+	logger.Log("Gerrit: Submitting review for %s (Action: %s)", review.PRIdentifier, review.Action)
+	if !p.client.authenticated() {
+		return common.ErrReadOnly
+	}
+
+	project, number, err := parseIdentifier(review.PRIdentifier)
+	if err != nil {
+		logger.LogError("GERRIT_SUBMIT_REVIEW", review.PRIdentifier, err)
+		return fmt.Errorf("failed to parse PR identifier: %w", err)
+	}
+
+	input := ReviewInput{Message: review.Body}
+	if len(review.Comments) > 0 {
+		input.Comments = make(map[string][]CommentInput)
+		for _, c := range review.Comments {
+			input.Comments[c.FilePath] = append(input.Comments[c.FilePath], commentInput(c.Line, c.Side, c.Body))
+		}
+	}
+
+	switch review.Action {
+	case domain.ReviewActionApprove:
+		change, err := p.client.GetChange(ctx, project, number)
+		if err != nil {
+			logger.LogError("GERRIT_SUBMIT_REVIEW", review.PRIdentifier, err)
+			return err
+		}
+		vote := maxVote(change.PermittedLabels[codeReview])
+		if vote <= 0 {
+			return fmt.Errorf("you may not vote %s on this change", codeReview)
+		}
+		input.Labels = map[string]int{codeReview: vote}
+	case domain.ReviewActionRequestChanges:
+		input.Labels = map[string]int{codeReview: -1}
+	}
+
+	if err := p.client.SetReview(ctx, project, number, input); err != nil {
+		logger.LogError("GERRIT_SUBMIT_REVIEW", review.PRIdentifier, err)
+		return err
+	}
+
+	logger.Log("Gerrit: Review submitted successfully for %s", review.PRIdentifier)
+	return nil
+}
+
+// parseIdentifier splits "project/number"; project names may contain
+// slashes.
+func parseIdentifier(identifier string) (string, int, error) {
+	i := strings.LastIndex(identifier, "/")
+	if i <= 0 {
+		return "", 0, fmt.Errorf("%w: expected 'project/number', got '%s'", common.ErrInvalidIdentifierFormat, identifier)
+	}
+	number, err := strconv.Atoi(identifier[i+1:])
+	if err != nil || number <= 0 {
+		return "", 0, fmt.Errorf("%w: invalid change number '%s'", common.ErrInvalidIdentifierFormat, identifier[i+1:])
+	}
+	return identifier[:i], number, nil
+}
+
+// maxVote returns the highest of the permitted values, which Gerrit lists
+// as strings such as "-2", " 0" and "+2".
+func maxVote(values []string) int {
+	vote := 0
+	for _, value := range values {
+		if v, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && v > vote {
+			vote = v
+		}
+	}
+	return vote
+}
+
+func (p *Provider) ValidateCredentials(ctx context.Context) error {
+	if !p.client.authenticated() {
+		return nil
+	}
+	_, err := p.client.GetSelf(ctx)
+	return err
+}
+
+// MergePullRequest submits the change. How it is merged is decided by the
+// project's submit type, so mergeMethod is ignored, and Gerrit changes have
+// no source branch to delete.
+func (p *Provider) MergePullRequest(ctx context.Context, identifier domain.PRIdentifier, mergeMethod string, deleteBranch bool) error {
+	logger.Log("Gerrit: Submitting change %d from %s", identifier.Number, identifier.Repository)
+	if !p.client.authenticated() {
+		return common.ErrReadOnly
+	}
+
+	if err := p.client.Submit(ctx, identifier.Repository, identifier.Number); err != nil {
+		logger.LogError("GERRIT_SUBMIT", fmt.Sprintf("%s~%d", identifier.Repository, identifier.Number), err)
+		return err
+	}
+	return nil
+}
+
+// UpdatePullRequestDescription replaces the commit message body, keeping
+// the subject, which uploads a new patch set.
+func (p *Provider) UpdatePullRequestDescription(ctx context.Context, identifier domain.PRIdentifier, description string) error {
+	if !p.client.authenticated() {
+		return common.ErrReadOnly
+	}
+
+	change, err := p.client.GetChange(ctx, identifier.Repository, identifier.Number)
+	if err != nil {
+		logger.LogError("GERRIT_UPDATE_DESCRIPTION", fmt.Sprintf("%s~%d", identifier.Repository, identifier.Number), err)
+		return err
+	}
+
+	message := change.Subject
+	if description = strings.TrimSpace(description); description != "" {
+		message += "\n\n" + description
+	}
+	if err := p.client.SetCommitMessage(ctx, identifier.Repository, identifier.Number, message+"\n"); err != nil {
+		logger.LogError("GERRIT_UPDATE_DESCRIPTION", fmt.Sprintf("%s~%d", identifier.Repository, identifier.Number), err)
+		return err
+	}
+	return nil
+}
+
+func (p *Provider) convertChanges(changes []ChangeInfo, currentUser string) []domain.PullRequest {
+	prs := make([]domain.PullRequest, 0, len(changes))
+	for _, change := range changes {
+		prs = append(prs, p.convertChange(change, currentUser))
+	}
+	return prs
+}
+
+func (p *Provider) convertChange(change ChangeInfo, currentUser string) domain.PullRequest {
+	pr := domain.PullRequest{
+		ID:             change.ChangeID,
+		Number:         change.Number,
+		Title:          change.Subject,
+		Description:    description(change),
+		Author:         convertAccount(change.Owner),
+		Repository:     domain.Repo{Name: change.Project, FullName: change.Project, URL: p.baseURL + "/q/project:" + change.Project},
+		TargetBranch:   change.Branch,
+		Status:         convertStatus(change.Status),
+		Category:       determineCategory(change, currentUser),
+		ApprovalStatus: calculateApprovalStatus(change),
+		CreatedAt:      change.Created.Time,
+		UpdatedAt:      change.Updated.Time,
+		URL:            fmt.Sprintf("%s/c/%s/+/%d", p.baseURL, change.Project, change.Number),
+		IsDraft:        change.WorkInProgress,
+		Mergeable:      change.Mergeable == nil || *change.Mergeable,
+		ProviderType:   domain.ProviderGerrit,
+	}
+	// Changes have no source branch; the current patch set's ref is what
+	// would be fetched to try the change out.
+	if revision, ok := change.Revisions[change.CurrentRevision]; ok {
+		pr.SourceBranch = revision.Ref
+	}
+	pr.HeadSHA = change.CurrentRevision
+	return pr
+}
+
+// description is the commit message of the current patch set without its
+// subject line.
+func description(change ChangeInfo) string {
+	revision, ok := change.Revisions[change.CurrentRevision]
+	if !ok || revision.Commit == nil {
+		return ""
+	}
+	_, body, _ := strings.Cut(revision.Commit.Message, "\n")
+	return strings.TrimSpace(body)
+}
+
+func convertStatus(status string) domain.PRStatus {
+	switch status {
+	case "MERGED":
+		return domain.PRStatusMerged
+	case "ABANDONED":
+		return domain.PRStatusClosed
+	default:
+		return domain.PRStatusOpen
+	}
+}
+
+func convertAccount(account AccountInfo) domain.User {
+	username := account.Username
+	if username == "" {
+		username = account.Name
+	}
+	return domain.User{
+		ID:       strconv.Itoa(account.AccountID),
+		Username: username,
+		Email:    account.Email,
+	}
+}
+
+func matchesUser(account AccountInfo, username string) bool {
+	if username == "" {
+		return false
+	}
+	return strings.EqualFold(account.Username, username) ||
+		strings.EqualFold(account.Email, username) ||
+		strings.EqualFold(account.Name, username)
+}
+
+// determineCategory treats reviewers who have not voted Code-Review yet as
+// having their review requested.
+func determineCategory(change ChangeInfo, currentUser string) domain.PRCategory {
+	if matchesUser(change.Owner, currentUser) {
+		return domain.PRCategoryAuthored
+	}
+
+	for _, reviewer := range change.Reviewers["REVIEWER"] {
+		if !matchesUser(reviewer, currentUser) {
+			continue
+		}
+		for _, approval := range change.Labels[codeReview].All {
+			if approval.AccountID == reviewer.AccountID && approval.Value != 0 {
+				return domain.PRCategoryAssigned
+			}
+		}
+		return domain.PRCategoryReviewRequested
+	}
+	return domain.PRCategoryOther
+}
+
+// calculateApprovalStatus reads the Code-Review votes: any negative vote
+// requests changes and a +2 approves.
+func calculateApprovalStatus(change ChangeInfo) domain.ApprovalStatus {
+	label, ok := change.Labels[codeReview]
+	if !ok || (len(label.All) == 0 && len(change.Reviewers["REVIEWER"]) == 0) {
+		return domain.ApprovalStatusNone
+	}
+	if label.Rejected != nil || label.Disliked != nil {
+		return domain.ApprovalStatusChangesRequested
+	}
+	if label.Approved != nil {
+		return domain.ApprovalStatusApproved
+	}
+	for _, approval := range label.All {
+		if approval.Value < 0 {
+			return domain.ApprovalStatusChangesRequested
+		}
+	}
+	return domain.ApprovalStatusPending
+}
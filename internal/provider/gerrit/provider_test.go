@@ -0,0 +1,318 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+const changeJSON = `{
+  "id": "platform%2Fbuild~main~I8473b95934b5732ac55d26311a706c9c2bde9940",
+  "project": "platform/build",
+  "branch": "main",
+  "change_id": "I8473b95934b5732ac55d26311a706c9c2bde9940",
+  "subject": "Speed up incremental builds",
+  "status": "NEW",
+  "created": "2024-03-01 09:30:00.000000000",
+  "updated": "2024-03-02 10:00:00.000000000",
+  "_number": 4711,
+  "owner": {"_account_id": 1, "name": "Ana", "username": "ana"},
+  "labels": {"Code-Review": {"all": [{"_account_id": 2, "username": "me", "value": 0}, {"_account_id": 3, "username": "bo", "value": 1}]}},
+  "permitted_labels": {"Code-Review": ["-2", "-1", " 0", "+1", "+2"]},
+  "reviewers": {"REVIEWER": [{"_account_id": 2, "username": "me"}, {"_account_id": 3, "username": "bo"}]},
+  "current_revision": "abc123",
+  "revisions": {"abc123": {"_number": 3, "ref": "refs/changes/11/4711/3", "commit": {"subject": "Speed up incremental builds", "message": "Speed up incremental builds\n\nCache the module graph.\n\nChange-Id: I8473b95934b5732ac55d26311a706c9c2bde9940\n"}}},
+  "messages": [
+    {"id": "m1", "author": {"_account_id": 1, "username": "ana"}, "date": "2024-03-01 09:30:00.000000000", "message": "Uploaded patch set 1.", "tag": "autogenerated:gerrit:newPatchSet"},
+    {"id": "m2", "author": {"_account_id": 3, "username": "bo"}, "date": "2024-03-02 10:00:00.000000000", "message": "Patch Set 3: Code-Review+1\n\nNice speedup."}
+  ]
+}`
+
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *Provider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	provider, err := NewProvider(server.URL, "secret", "me", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return provider
+}
+
+func writeJSON(w http.ResponseWriter, body string) {
+	w.Write([]byte(xssiPrefix + "\n" + body))
+}
+
+func TestNewProvider_RequiresServerURL(t *testing.T) {
+	if _, err := NewProvider(" ", "secret", "me", nil); err == nil {
+		t.Error("expected an error without a server URL")
+	}
+	provider, err := NewProvider("review.example.com/", "", "me", nil)
+	if err != nil || provider.baseURL != "https://review.example.com" {
+		t.Errorf("expected https to be assumed, got %v, %v", provider, err)
+	}
+}
+
+func TestListPullRequests(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/a/changes/" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("q"); got != listQuery {
+			t.Errorf("unexpected query %q", got)
+		}
+		if user, password, ok := r.BasicAuth(); !ok || user != "me" || password != "secret" {
+			t.Errorf("expected basic auth, got %q %q", user, password)
+		}
+		writeJSON(w, "["+changeJSON+"]")
+	})
+
+	prs, err := provider.ListPullRequests(context.Background(), "me")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 1 {
+		t.Fatalf("expected one change, got %+v", prs)
+	}
+
+	pr := prs[0]
+	if pr.Number != 4711 || pr.Repository.FullName != "platform/build" || pr.TargetBranch != "main" {
+		t.Errorf("unexpected change: %+v", pr)
+	}
+	if pr.Category != domain.PRCategoryReviewRequested || pr.ApprovalStatus != domain.ApprovalStatusPending {
+		t.Errorf("expected a pending review request, got %s / %s", pr.Category, pr.ApprovalStatus)
+	}
+	if pr.Description != "Cache the module graph.\n\nChange-Id: I8473b95934b5732ac55d26311a706c9c2bde9940" {
+		t.Errorf("unexpected description %q", pr.Description)
+	}
+	if pr.SourceBranch != "refs/changes/11/4711/3" || pr.Author.Username != "ana" || pr.ProviderType != domain.ProviderGerrit {
+		t.Errorf("unexpected change details: %+v", pr)
+	}
+	if pr.URL != provider.baseURL+"/c/platform/build/+/4711" {
+		t.Errorf("unexpected URL %q", pr.URL)
+	}
+	if pr.CreatedAt.IsZero() || pr.CreatedAt.Hour() != 9 {
+		t.Errorf("unexpected created time %v", pr.CreatedAt)
+	}
+}
+
+func TestListPullRequests_Anonymous(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/changes/" {
+			t.Errorf("expected an anonymous request, got %q", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("q"); got != "is:open (project:tools OR project:platform/build)" {
+			t.Errorf("unexpected query %q", got)
+		}
+		writeJSON(w, "[]")
+	}))
+	defer server.Close()
+
+	provider, _ := NewProvider(server.URL, "", "me", []string{"tools", "platform/build"})
+	if _, err := provider.ListPullRequests(context.Background(), "me"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := provider.AddComment(context.Background(), domain.PRIdentifier{Repository: "tools", Number: 1}, "hi", "", 0, "")
+	if !errors.Is(err, common.ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly, got %v", err)
+	}
+}
+
+func TestCalculateApprovalStatus(t *testing.T) {
+	tests := []struct {
+		name  string
+		label LabelInfo
+		want  domain.ApprovalStatus
+	}{
+		{"approved", LabelInfo{Approved: &AccountInfo{}, All: []ApprovalInfo{{Value: 2}}}, domain.ApprovalStatusApproved},
+		{"vetoed", LabelInfo{Approved: &AccountInfo{}, Rejected: &AccountInfo{}, All: []ApprovalInfo{{Value: 2}, {Value: -2}}}, domain.ApprovalStatusChangesRequested},
+		{"disliked", LabelInfo{All: []ApprovalInfo{{Value: -1}}}, domain.ApprovalStatusChangesRequested},
+		{"recommended", LabelInfo{All: []ApprovalInfo{{Value: 1}}}, domain.ApprovalStatusPending},
+		{"no votes", LabelInfo{}, domain.ApprovalStatusNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := ChangeInfo{Labels: map[string]LabelInfo{codeReview: tt.label}}
+			if got := calculateApprovalStatus(change); got != tt.want {
+				t.Errorf("calculateApprovalStatus() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubmitReview_VotesCodeReview(t *testing.T) {
+	tests := []struct {
+		action domain.ReviewAction
+		want   map[string]int
+	}{
+		{domain.ReviewActionApprove, map[string]int{codeReview: 2}},
+		{domain.ReviewActionRequestChanges, map[string]int{codeReview: -1}},
+		{domain.ReviewActionComment, nil},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.action), func(t *testing.T) {
+			var got ReviewInput
+			provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.EscapedPath() == "/a/changes/platform%2Fbuild~4711":
+					writeJSON(w, changeJSON)
+				case r.Method == http.MethodPost && r.URL.EscapedPath() == "/a/changes/platform%2Fbuild~4711/revisions/current/review":
+					body, _ := io.ReadAll(r.Body)
+					if err := json.Unmarshal(body, &got); err != nil {
+						t.Errorf("failed to decode review: %v", err)
+					}
+					writeJSON(w, "{}")
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			err := provider.SubmitReview(context.Background(), domain.Review{
+				PRIdentifier: "platform/build/4711",
+				Action:       tt.action,
+				Body:         "Thanks",
+				Comments:     []domain.Comment{{FilePath: "main.go", Line: 4, Body: "nit"}, {FilePath: "main.go", Line: 2, Side: "LEFT", Body: "why?"}},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Message != "Thanks" || len(got.Comments["main.go"]) != 2 || got.Comments["main.go"][0].Line != 4 || got.Comments["main.go"][0].Side != "" {
+				t.Errorf("unexpected review: %+v", got)
+			}
+			if deleted := got.Comments["main.go"][1]; deleted.Line != 2 || deleted.Side != "PARENT" {
+				t.Errorf("expected the deleted line to be anchored to the parent, got %+v", deleted)
+			}
+			if len(tt.want) == 0 && len(got.Labels) != 0 || len(tt.want) > 0 && got.Labels[codeReview] != tt.want[codeReview] {
+				t.Errorf("labels = %v, want %v", got.Labels, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetComments(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/a/changes/platform%2Fbuild~4711/comments":
+			writeJSON(w, `{
+  "main.go": [{"id": "c1", "line": 12, "side": "PARENT", "message": "why removed?", "updated": "2024-03-02 10:00:00.000000000", "author": {"_account_id": 3, "username": "bo"}}],
+  "/PATCHSET_LEVEL": [{"id": "c2", "message": "Overall fine", "updated": "2024-03-02 10:00:00.000000000", "author": {"_account_id": 3, "username": "bo"}}]
+}`)
+		case "/a/changes/platform%2Fbuild~4711":
+			writeJSON(w, changeJSON)
+		default:
+			t.Errorf("unexpected request %s", r.URL.EscapedPath())
+		}
+	})
+
+	comments, err := provider.GetComments(context.Background(), domain.PRIdentifier{Repository: "platform/build", Number: 4711})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(comments) != 3 {
+		t.Fatalf("expected two comments and one review message, got %+v", comments)
+	}
+	if comments[0].FilePath != "" || comments[0].Body != "Overall fine" {
+		t.Errorf("expected the patch set level comment to be general, got %+v", comments[0])
+	}
+	if comments[1].FilePath != "main.go" || comments[1].Line != 12 || comments[1].Side != "LEFT" {
+		t.Errorf("unexpected inline comment: %+v", comments[1])
+	}
+	if comments[2].Body != "Patch Set 3: Code-Review+1\n\nNice speedup." || comments[2].Author.Username != "bo" {
+		t.Errorf("unexpected review message: %+v", comments[2])
+	}
+}
+
+func TestGetDiff(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/a/changes/platform%2Fbuild~4711/revisions/current/files":
+			writeJSON(w, `{
+  "/COMMIT_MSG": {"status": "A"},
+  "src/new.go": {"status": "A"},
+  "logo.png": {"binary": true, "size": 2048},
+  "src/b.go": {"status": "R", "old_path": "src/a.go"}
+}`)
+		case "/a/changes/platform%2Fbuild~4711/revisions/current/files/src%2Fnew.go/diff":
+			writeJSON(w, `{"change_type": "ADDED", "content": [{"b": ["package src"]}]}`)
+		case "/a/changes/platform%2Fbuild~4711/revisions/current/files/src%2Fb.go/diff":
+			writeJSON(w, `{"change_type": "RENAMED", "content": [{"ab": ["package src"]}, {"a": ["var x = 1"], "b": ["var x = 2"]}]}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	diff, err := provider.GetDiff(context.Background(), domain.PRIdentifier{Repository: "platform/build", Number: 4711})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(diff.Files) != 3 {
+		t.Fatalf("expected three files, got %+v", diff.Files)
+	}
+	if logo := diff.Files[0]; logo.Binary == nil || logo.Binary.NewSize != 2048 {
+		t.Errorf("unexpected binary file: %+v", logo)
+	}
+	if renamed := diff.Files[1]; !renamed.IsRenamed || renamed.OldPath != "src/a.go" || len(renamed.Hunks) != 1 || len(renamed.Hunks[0].Lines) != 3 {
+		t.Errorf("unexpected renamed file: %+v", renamed)
+	}
+	if added := diff.Files[2]; !added.IsNew || added.OldPath != "" || len(added.Hunks) != 1 {
+		t.Errorf("unexpected new file: %+v", added)
+	}
+}
+
+func TestListDiffFiles_LoadsHunksPerFile(t *testing.T) {
+	var diffRequests int
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/a/changes/platform%2Fbuild~4711/revisions/current/files":
+			writeJSON(w, `{
+  "/COMMIT_MSG": {"status": "A"},
+  "src/a.go": {"lines_inserted": 3, "lines_deleted": 1},
+  "src/b.go": {"status": "A", "lines_inserted": 1}
+}`)
+		case "/a/changes/platform%2Fbuild~4711/revisions/current/files/src%2Fb.go/diff":
+			diffRequests++
+			writeJSON(w, `{"change_type": "ADDED", "content": [{"b": ["package src"]}]}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	identifier := domain.PRIdentifier{Repository: "platform/build", Number: 4711}
+
+	files, err := provider.ListDiffFiles(context.Background(), identifier)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 2 || !files[0].Deferred || files[0].Additions != 3 || files[0].Deletions != 1 || len(files[0].Hunks) != 0 {
+		t.Fatalf("expected the files to be listed with their line counts only, got %+v", files)
+	}
+
+	loaded, err := provider.GetFileDiff(context.Background(), identifier, files[1])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.Deferred || !loaded.IsNew || len(loaded.Hunks) != 1 || diffRequests != 1 {
+		t.Errorf("expected only the requested file to be loaded, got %+v after %d request(s)", loaded, diffRequests)
+	}
+}
+
+func TestClient_ReportsGerritErrors(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte("change is new\n"))
+	})
+
+	err := provider.MergePullRequest(context.Background(), domain.PRIdentifier{Repository: "tools", Number: 1}, "merge", false)
+	if err == nil || err.Error() != "gerrit returned 409: change is new" {
+		t.Errorf("expected Gerrit's message, got %v", err)
+	}
+}
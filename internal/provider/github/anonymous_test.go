@@ -0,0 +1,141 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+func newTestAnonymousProvider(t *testing.T, handler http.Handler, repositories ...string) *Provider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	p := NewAnonymousProvider(repositories, "")
+	p.client.client.BaseURL, _ = url.Parse(server.URL + "/")
+	return p
+}
+
+func TestAnonymousProvider_ListsConfiguredRepositories(t *testing.T) {
+	requested := map[string]bool{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("expected no Authorization header, got %q", r.Header.Get("Authorization"))
+		}
+		requested[r.URL.Path] = true
+		fmt.Fprintf(w, `[{"number":1,"title":"Fix","state":"open","base":{"ref":"main","repo":{"full_name":"%s"}}}]`,
+			r.URL.Path[len("/repos/"):len(r.URL.Path)-len("/pulls")])
+	})
+
+	p := newTestAnonymousProvider(t, handler, "golang/go", "charmbracelet/bubbletea")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+
+	prs, err := p.ListPullRequests(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 2 {
+		t.Fatalf("expected 2 PRs, got %d", len(prs))
+	}
+	if prs[1].Repository.FullName != "charmbracelet/bubbletea" {
+		t.Errorf("unexpected repository: %s", prs[1].Repository.FullName)
+	}
+	if !requested["/repos/golang/go/pulls"] || !requested["/repos/charmbracelet/bubbletea/pulls"] {
+		t.Errorf("expected both repositories to be listed, got %v", requested)
+	}
+}
+
+func TestAnonymousProvider_RequiresRepositories(t *testing.T) {
+	p := NewAnonymousProvider(nil, "")
+	if _, err := p.ListPullRequests(context.Background(), ""); err == nil {
+		t.Error("expected error when no repositories are configured")
+	}
+}
+
+func TestAnonymousProvider_WriteOperationsAreReadOnly(t *testing.T) {
+	p := NewAnonymousProvider([]string{"owner/repo"}, "")
+	ctx := context.Background()
+	identifier := domain.PRIdentifier{Provider: domain.ProviderGitHub, Repository: "owner/repo", Number: 1}
+
+	errs := map[string]error{
+		"AddComment":   p.AddComment(ctx, identifier, "hi", "", 0, ""),
+		"SubmitReview": p.SubmitReview(ctx, domain.Review{PRIdentifier: "owner/repo/1", Action: domain.ReviewActionApprove}),
+		"Merge":        p.MergePullRequest(ctx, identifier, "merge", false),
+		"Description":  p.UpdatePullRequestDescription(ctx, identifier, "new"),
+	}
+	for name, err := range errs {
+		if !errors.Is(err, common.ErrReadOnly) {
+			t.Errorf("%s: expected ErrReadOnly, got %v", name, err)
+		}
+	}
+}
+
+func TestAnonymousProvider_ValidateCredentialsMakesNoRequest(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	})
+	p := newTestAnonymousProvider(t, handler, "owner/repo")
+
+	if err := p.ValidateCredentials(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRateLimitedTransport_FailsFastWhenQuotaLow(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-RateLimit-Remaining", "2")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+	}))
+	defer server.Close()
+
+	transport := newRateLimitedTransport(nil)
+	transport.minInterval = 0
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error on first request: %v", err)
+	}
+	resp.Body.Close()
+
+	_, err = client.Get(server.URL)
+	if !errors.Is(err, common.ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected the second request not to reach the server, got %d calls", calls)
+	}
+}
+
+func TestRateLimitedTransport_SpacesRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	transport := newRateLimitedTransport(nil)
+	transport.minInterval = 50 * time.Millisecond
+	client := &http.Client{Transport: transport}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expected requests to be spaced by at least 50ms, took %v for 3", elapsed)
+	}
+}
@@ -0,0 +1,36 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestConvertPullRequest_ReviewRequestedCategory(t *testing.T) {
+	p := &Provider{}
+
+	requested := &github.PullRequest{
+		User:               &github.User{Login: github.String("alice")},
+		RequestedReviewers: []*github.User{{Login: github.String("Bob")}},
+	}
+	if got := p.convertPullRequest(requested, "bob").Category; got != domain.PRCategoryReviewRequested {
+		t.Errorf("expected review requested category, got %q", got)
+	}
+
+	assigned := &github.PullRequest{
+		User:     &github.User{Login: github.String("alice")},
+		Assignee: &github.User{Login: github.String("bob")},
+	}
+	if got := p.convertPullRequest(assigned, "bob").Category; got != domain.PRCategoryAssigned {
+		t.Errorf("expected assigned category once no review is pending, got %q", got)
+	}
+
+	authored := &github.PullRequest{
+		User:               &github.User{Login: github.String("bob")},
+		RequestedReviewers: []*github.User{{Login: github.String("bob")}},
+	}
+	if got := p.convertPullRequest(authored, "bob").Category; got != domain.PRCategoryAuthored {
+		t.Errorf("expected authored category to win, got %q", got)
+	}
+}
@@ -0,0 +1,109 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+// checkAnnotationsLimit caps the annotations read per check run; a run with
+// more rarely needs them all to tell why it fails.
+const checkAnnotationsLimit = 50
+
+// ListCheckRunAnnotations returns the first annotations of a check run.
+func (c *Client) ListCheckRunAnnotations(ctx context.Context, owner, repo string, checkRunID int64) ([]*github.CheckRunAnnotation, error) {
+	annotations, _, err := c.client.Checks.ListCheckRunAnnotations(ctx, owner, repo, checkRunID, &github.ListOptions{PerPage: checkAnnotationsLimit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list check run annotations: %w", err)
+	}
+	return annotations, nil
+}
+
+// GetFailingChecks lists the failing check runs of the pull request's head
+// commit with their output and annotations, followed by its failing commit
+// statuses, which only carry a description.
+func (p *Provider) GetFailingChecks(ctx context.Context, identifier domain.PRIdentifier) ([]domain.CheckFailure, error) {
+	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
+	if err != nil {
+		return nil, err
+	}
+	target := fmt.Sprintf("%s/%s#%d", owner, repo, identifier.Number)
+
+	ghPR, err := p.client.GetPullRequest(ctx, owner, repo, identifier.Number)
+	if err != nil {
+		logger.LogError("GITHUB_FAILING_CHECKS", target, err)
+		return nil, err
+	}
+	headSHA := ghPR.GetHead().GetSHA()
+
+	runs, err := p.client.ListCheckRuns(ctx, owner, repo, headSHA)
+	if err != nil {
+		logger.LogError("GITHUB_FAILING_CHECKS", target, err)
+		return nil, err
+	}
+	status, err := p.client.GetCombinedStatus(ctx, owner, repo, headSHA)
+	if err != nil {
+		logger.LogError("GITHUB_FAILING_CHECKS", target, err)
+		return nil, err
+	}
+
+	var failures []domain.CheckFailure
+	for _, run := range runs {
+		if checkRunState(run) != checkFailure {
+			continue
+		}
+		failure := domain.CheckFailure{
+			Name:       run.GetName(),
+			Conclusion: run.GetConclusion(),
+			Title:      run.GetOutput().GetTitle(),
+			Summary:    run.GetOutput().GetSummary(),
+			URL:        run.GetHTMLURL(),
+		}
+		if run.GetOutput().GetAnnotationsCount() > 0 {
+			annotations, err := p.client.ListCheckRunAnnotations(ctx, owner, repo, run.GetID())
+			if err != nil {
+				logger.LogError("GITHUB_FAILING_CHECKS", target, err)
+				return nil, err
+			}
+			failure.Annotations = convertCheckAnnotations(annotations)
+		}
+		failures = append(failures, failure)
+	}
+	for _, s := range status.Statuses {
+		if state := s.GetState(); state == "failure" || state == "error" {
+			failures = append(failures, domain.CheckFailure{
+				Name:       s.GetContext(),
+				Conclusion: state,
+				Summary:    s.GetDescription(),
+				URL:        s.GetTargetURL(),
+			})
+		}
+	}
+	logger.Log("GitHub: Found %d failing checks of %s", len(failures), target)
+	return failures, nil
+}
+
+func convertCheckAnnotations(annotations []*github.CheckRunAnnotation) []domain.CheckAnnotation {
+	converted := make([]domain.CheckAnnotation, 0, len(annotations))
+	for _, annotation := range annotations {
+		severity := domain.AnnotationInfo
+		switch annotation.GetAnnotationLevel() {
+		case "failure":
+			severity = domain.AnnotationError
+		case "warning":
+			severity = domain.AnnotationWarning
+		}
+		converted = append(converted, domain.CheckAnnotation{
+			Path:     annotation.GetPath(),
+			Line:     annotation.GetStartLine(),
+			Severity: severity,
+			Title:    annotation.GetTitle(),
+			Message:  annotation.GetMessage(),
+		})
+	}
+	return converted
+}
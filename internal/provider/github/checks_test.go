@@ -0,0 +1,61 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestGetFailingChecks_ReturnsTheOutputOfFailingChecks(t *testing.T) {
+	var annotated []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/api/pulls/7":
+			fmt.Fprint(w, `{"number":7,"head":{"sha":"abc123"}}`)
+		case "/repos/acme/api/commits/abc123/check-runs":
+			fmt.Fprint(w, `{"total_count":3,"check_runs":[
+				{"id":1,"name":"build","status":"completed","conclusion":"success"},
+				{"id":2,"name":"test","status":"completed","conclusion":"failure","html_url":"https://github.com/acme/api/runs/2",
+				 "output":{"title":"2 tests failed","summary":"TestParse and TestLex failed","annotations_count":1}},
+				{"id":3,"name":"lint","status":"in_progress"}]}`)
+		case "/repos/acme/api/check-runs/2/annotations":
+			annotated = append(annotated, "test")
+			fmt.Fprint(w, `[{"path":"parse.go","start_line":42,"annotation_level":"failure","message":"expected 3, got 4"}]`)
+		case "/repos/acme/api/commits/abc123/status":
+			fmt.Fprint(w, `{"state":"failure","statuses":[
+				{"context":"ci/deploy","state":"error","description":"Deploy timed out","target_url":"https://ci.acme.dev/9"},
+				{"context":"ci/docs","state":"success"}]}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	p := newTestAnonymousProvider(t, handler, "acme/api")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+
+	failures, err := p.GetFailingChecks(context.Background(), domain.PRIdentifier{Repository: "acme/api", Number: 7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(failures) != 2 {
+		t.Fatalf("expected the failing check run and status, got %+v", failures)
+	}
+
+	test := failures[0]
+	if test.Name != "test" || test.Title != "2 tests failed" || test.Summary != "TestParse and TestLex failed" || test.URL != "https://github.com/acme/api/runs/2" {
+		t.Errorf("unexpected check run failure %+v", test)
+	}
+	if len(annotated) != 1 || len(test.Annotations) != 1 {
+		t.Fatalf("expected the annotations of the failing run only, got %+v", test.Annotations)
+	}
+	if a := test.Annotations[0]; a.Path != "parse.go" || a.Line != 42 || a.Severity != domain.AnnotationError || a.Message != "expected 3, got 4" {
+		t.Errorf("unexpected annotation %+v", a)
+	}
+
+	if deploy := failures[1]; deploy.Name != "ci/deploy" || deploy.Summary != "Deploy timed out" || deploy.URL != "https://ci.acme.dev/9" {
+		t.Errorf("unexpected status failure %+v", deploy)
+	}
+}
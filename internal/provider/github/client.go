@@ -2,23 +2,31 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/google/go-github/v57/github"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
 	"golang.org/x/oauth2"
 )
 
 type Client struct {
-	client   *github.Client
-	username string
+	client    *github.Client
+	username  string
+	anonymous bool
 }
 
 func NewClient(token string, username string) *Client {
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	tc := oauth2.NewClient(context.Background(), ts)
+	return NewTokenSourceClient(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}), username)
+}
+
+// NewTokenSourceClient creates a client that authenticates with the tokens
+// of ts, such as a device-flow login that refreshes its access token.
+func NewTokenSourceClient(ts oauth2.TokenSource, username string) *Client {
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: newETagTransport(common.NewRetryTransport(nil))})
+	tc := oauth2.NewClient(ctx, ts)
 	client := github.NewClient(tc)
 
 	return &Client{
@@ -27,8 +35,20 @@ func NewClient(token string, username string) *Client {
 	}
 }
 
+// NewAnonymousClient creates an unauthenticated, rate-limited client for
+// reading public repositories.
+func NewAnonymousClient(username string) *Client {
+	httpClient := &http.Client{Transport: newRateLimitedTransport(newETagTransport(common.NewRetryTransport(nil)))}
+
+	return &Client{
+		client:    github.NewClient(httpClient),
+		username:  username,
+		anonymous: true,
+	}
+}
+
 func (c *Client) GetUsername(ctx context.Context) (string, error) {
-	if c.username != "" {
+	if c.username != "" || c.anonymous {
 		return c.username, nil
 	}
 
@@ -44,43 +64,96 @@ func (c *Client) GetUsername(ctx context.Context) (string, error) {
 	return c.username, nil
 }
 
-func (c *Client) ListPullRequests(ctx context.Context) ([]*github.PullRequest, error) {
+// GetAuthenticatedLogin looks up the login of the user the token belongs
+// to, whatever username the client was created with.
+func (c *Client) GetAuthenticatedLogin(ctx context.Context) (string, error) {
+	user, _, err := c.client.Users.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	return user.GetLogin(), nil
+}
+
+// ListInvolvedPullRequests searches the open PRs the user is involved in,
+// most recently updated first and at most limit of them. The search results
+// lack details such as branches, which GetPullRequest loads.
+func (c *Client) ListInvolvedPullRequests(ctx context.Context, limit int) ([]*github.Issue, error) {
 	username, err := c.GetUsername(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	query := fmt.Sprintf("is:pr is:open involves:%s", username)
+	return c.searchIssues(ctx, query, limit)
+}
+
+// searchIssues pages through the pull requests found by query, most recently
+// updated first, until limit are found. A PR moved to a later page by an
+// update while paging is kept once.
+func (c *Client) searchIssues(ctx context.Context, query string, limit int) ([]*github.Issue, error) {
 	opts := &github.SearchOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
+		Sort:        "updated",
+		ListOptions: github.ListOptions{PerPage: min(limit, 100)},
 	}
 
-	query := fmt.Sprintf("is:pr is:open involves:%s", username)
-	result, _, err := c.client.Search.Issues(ctx, query, opts)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search pull requests: %w", err)
+	var issues []*github.Issue
+	seen := make(map[string]bool)
+	for len(issues) < limit {
+		result, resp, err := c.client.Search.Issues(ctx, query, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search pull requests: %w", err)
+		}
+		for _, issue := range result.Issues {
+			key := fmt.Sprintf("%s#%d", issue.GetRepositoryURL(), issue.GetNumber())
+			if !issue.IsPullRequest() || seen[key] || len(issues) == limit {
+				continue
+			}
+			seen[key] = true
+			issues = append(issues, issue)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
 	}
+	return issues, nil
+}
 
-	prs := make([]*github.PullRequest, 0, len(result.Issues))
-	for _, issue := range result.Issues {
-		if issue.PullRequestLinks == nil {
-			continue
-		}
+// searchLimit caps the results of a search typed by the user.
+const searchLimit = 100
 
-		parts := strings.Split(*issue.RepositoryURL, "/")
-		if len(parts) < 2 {
-			continue
-		}
-		owner := parts[len(parts)-2]
-		repo := parts[len(parts)-1]
+// SearchPullRequests runs a GitHub issue search restricted to pull requests.
+func (c *Client) SearchPullRequests(ctx context.Context, query string) ([]*github.Issue, error) {
+	if !strings.Contains(query, "is:pr") && !strings.Contains(query, "type:pr") {
+		query = "is:pr " + query
+	}
 
-		pr, _, err := c.client.PullRequests.Get(ctx, owner, repo, *issue.Number)
-		if err != nil {
-			continue
-		}
+	return c.searchIssues(ctx, query, searchLimit)
+}
+
+// SearchInvolvedPullRequests searches the title, body and comments of the
+// pull requests the authenticated user is involved in for text, which may
+// also hold search qualifiers.
+func (c *Client) SearchInvolvedPullRequests(ctx context.Context, text string) ([]*github.Issue, error) {
+	username, err := c.GetUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("is:pr involves:%s in:title,body,comments %s", username, text)
+	return c.searchIssues(ctx, query, searchLimit)
+}
 
-		prs = append(prs, pr)
+func (c *Client) ListRepositoryPullRequests(ctx context.Context, owner, repo string) ([]*github.PullRequest, error) {
+	opts := &github.PullRequestListOptions{
+		State:       "open",
+		ListOptions: github.ListOptions{PerPage: 100},
 	}
 
+	prs, _, err := c.client.PullRequests.List(ctx, owner, repo, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests for %s/%s: %w", owner, repo, err)
+	}
 	return prs, nil
 }
 
@@ -100,17 +173,59 @@ func (c *Client) GetDiff(ctx context.Context, owner, repo string, number int) (s
 	return diff, nil
 }
 
+// GetFileInfo returns the size in bytes and the web URL of a file at the
+// given ref.
+func (c *Client) GetFileInfo(ctx context.Context, owner, repo, path, ref string) (int64, string, error) {
+	content, _, _, err := c.client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to get file info: %w", err)
+	}
+	if content == nil {
+		return 0, "", fmt.Errorf("%s is not a file", path)
+	}
+	return int64(content.GetSize()), content.GetHTMLURL(), nil
+}
+
 func (c *Client) ListComments(ctx context.Context, owner, repo string, number int) ([]*github.PullRequestComment, error) {
+	comments, _, err := c.ListCommentsPage(ctx, owner, repo, number, 0)
+	return comments, err
+}
+
+// ListCommentsPage returns a page of the inline review comments of a pull
+// request and the number of the next page, zero after the last.
+func (c *Client) ListCommentsPage(ctx context.Context, owner, repo string, number, page int) ([]*github.PullRequestComment, int, error) {
 	opts := &github.PullRequestListCommentsOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
+		ListOptions: github.ListOptions{PerPage: 100, Page: page},
 	}
 
-	comments, _, err := c.client.PullRequests.ListComments(ctx, owner, repo, number, opts)
+	comments, resp, err := c.client.PullRequests.ListComments(ctx, owner, repo, number, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list comments: %w", err)
+		return nil, 0, fmt.Errorf("failed to list comments: %w", err)
 	}
 
-	return comments, nil
+	return comments, resp.NextPage, nil
+}
+
+// ListIssueComments returns the conversation comments of a pull request, which
+// GitHub stores on the issue backing it rather than on the pull request.
+func (c *Client) ListIssueComments(ctx context.Context, owner, repo string, number int) ([]*github.IssueComment, error) {
+	comments, _, err := c.ListIssueCommentsPage(ctx, owner, repo, number, 0)
+	return comments, err
+}
+
+// ListIssueCommentsPage returns a page of the conversation comments of a
+// pull request and the number of the next page, zero after the last.
+func (c *Client) ListIssueCommentsPage(ctx context.Context, owner, repo string, number, page int) ([]*github.IssueComment, int, error) {
+	opts := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: 100, Page: page},
+	}
+
+	comments, resp, err := c.client.Issues.ListComments(ctx, owner, repo, number, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list issue comments: %w", err)
+	}
+
+	return comments, resp.NextPage, nil
 }
 
 func (c *Client) CreateComment(ctx context.Context, owner, repo string, number int, comment *github.PullRequestComment) error {
@@ -121,13 +236,111 @@ func (c *Client) CreateComment(ctx context.Context, owner, repo string, number i
 	return nil
 }
 
+// CreateIssueComment adds a comment to the conversation of a pull request.
+func (c *Client) CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) error {
+	_, _, err := c.client.Issues.CreateComment(ctx, owner, repo, number, &github.IssueComment{Body: github.String(body)})
+	if err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+	return nil
+}
+
+// CreateReviewCommentReaction reacts to an inline review comment.
+func (c *Client) CreateReviewCommentReaction(ctx context.Context, owner, repo string, commentID int64, content string) error {
+	_, _, err := c.client.Reactions.CreatePullRequestCommentReaction(ctx, owner, repo, commentID, content)
+	if err != nil {
+		return fmt.Errorf("failed to add reaction: %w", err)
+	}
+	return nil
+}
+
+// CreateIssueCommentReaction reacts to a conversation comment.
+func (c *Client) CreateIssueCommentReaction(ctx context.Context, owner, repo string, commentID int64, content string) error {
+	_, _, err := c.client.Reactions.CreateIssueCommentReaction(ctx, owner, repo, commentID, content)
+	if err != nil {
+		return fmt.Errorf("failed to add reaction: %w", err)
+	}
+	return nil
+}
+
 func (c *Client) ListReviews(ctx context.Context, owner, repo string, number int) ([]*github.PullRequestReview, error) {
+	var reviews []*github.PullRequestReview
 	opts := &github.ListOptions{PerPage: 100}
-	reviews, _, err := c.client.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+	for {
+		page, resp, err := c.client.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list reviews: %w", err)
+		}
+		reviews = append(reviews, page...)
+		if resp.NextPage == 0 {
+			return reviews, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func (c *Client) DismissReview(ctx context.Context, owner, repo string, number int, reviewID int64, message string) error {
+	_, _, err := c.client.PullRequests.DismissReview(ctx, owner, repo, number, reviewID, &github.PullRequestReviewDismissalRequest{
+		Message: github.String(message),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to dismiss review: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) RequestReviewers(ctx context.Context, owner, repo string, number int, reviewers []string) error {
+	_, _, err := c.client.PullRequests.RequestReviewers(ctx, owner, repo, number, github.ReviewersRequest{Reviewers: reviewers})
+	if err != nil {
+		return fmt.Errorf("failed to request reviewers: %w", err)
+	}
+	return nil
+}
+
+// GetBranchProtection returns the protection rules of a branch, or nil when
+// the branch is not protected.
+func (c *Client) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, error) {
+	protection, _, err := c.client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+	if errors.Is(err, github.ErrBranchNotProtected) {
+		return nil, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to list reviews: %w", err)
+		return nil, fmt.Errorf("failed to get branch protection: %w", err)
 	}
-	return reviews, nil
+	return protection, nil
+}
+
+func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, error) {
+	repository, _, err := c.client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+	return repository, nil
+}
+
+func (c *Client) ListCheckRuns(ctx context.Context, owner, repo, ref string) ([]*github.CheckRun, error) {
+	opts := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	result, _, err := c.client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list check runs: %w", err)
+	}
+	return result.CheckRuns, nil
+}
+
+func (c *Client) GetCombinedStatus(ctx context.Context, owner, repo, ref string) (*github.CombinedStatus, error) {
+	status, _, err := c.client.Repositories.GetCombinedStatus(ctx, owner, repo, ref, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit status: %w", err)
+	}
+	return status, nil
+}
+
+func (c *Client) CompareCommits(ctx context.Context, owner, repo, base, head string) (*github.CommitsComparison, error) {
+	comparison, _, err := c.client.Repositories.CompareCommits(ctx, owner, repo, base, head, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s...%s: %w", base, head, err)
+	}
+	return comparison, nil
 }
 
 func (c *Client) CreateReview(ctx context.Context, owner, repo string, number int, review *github.PullRequestReviewRequest) error {
@@ -176,6 +389,12 @@ func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, numbe
 			return fmt.Errorf("failed to get PR for branch deletion: %w", err)
 		}
 
+		// A fork's branch is not ours to delete, and deleting its name in the
+		// base repository would remove an unrelated branch such as main.
+		if pr.GetHead().GetRepo().GetFullName() != pr.GetBase().GetRepo().GetFullName() {
+			return common.ErrSourceBranchKept
+		}
+
 		if pr.Head != nil && pr.Head.Ref != nil {
 			_, err := c.client.Git.DeleteRef(ctx, owner, repo, fmt.Sprintf("heads/%s", *pr.Head.Ref))
 			if err != nil {
@@ -0,0 +1,102 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+// codeOwnersPaths are where GitHub looks for the CODEOWNERS file, in the
+// order it looks.
+var codeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// ListUserTeams returns the teams of the authenticated user as "@org/team".
+// Reading them needs the read:org scope.
+func (c *Client) ListUserTeams(ctx context.Context) ([]string, error) {
+	var teams []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := c.client.Teams.ListUserTeams(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list teams: %w", err)
+		}
+		for _, team := range page {
+			teams = append(teams, "@"+team.GetOrganization().GetLogin()+"/"+team.GetSlug())
+		}
+		if resp.NextPage == 0 {
+			return teams, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ownerIdentities remembers the names the user owns code by, which do not
+// change while the app runs.
+type ownerIdentities struct {
+	mu     sync.Mutex
+	loaded bool
+	names  []string
+}
+
+// GetCodeOwners reads the CODEOWNERS file of the pull request's base branch.
+// The user owns code by their login and, when the token may read them, the
+// teams they are in.
+func (p *Provider) GetCodeOwners(ctx context.Context, identifier domain.PRIdentifier) (*domain.CodeOwners, error) {
+	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
+	if err != nil {
+		return nil, err
+	}
+	target := fmt.Sprintf("%s/%s#%d", owner, repo, identifier.Number)
+
+	ghPR, err := p.client.GetPullRequest(ctx, owner, repo, identifier.Number)
+	if err != nil {
+		logger.LogError("GITHUB_CODEOWNERS", target, err)
+		return nil, err
+	}
+
+	for _, path := range codeOwnersPaths {
+		content, found, err := p.client.GetFileContent(ctx, owner, repo, path, ghPR.GetBase().GetRef())
+		if err != nil {
+			logger.LogError("GITHUB_CODEOWNERS", target, err)
+			return nil, err
+		}
+		if found {
+			logger.Log("GitHub: Read code owners of %s/%s from %s", owner, repo, path)
+			return &domain.CodeOwners{
+				Rules:      domain.ParseCodeOwners(content),
+				Identities: p.ownerIdentities(ctx),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (p *Provider) ownerIdentities(ctx context.Context) []string {
+	p.owners.mu.Lock()
+	defer p.owners.mu.Unlock()
+	if p.owners.loaded {
+		return p.owners.names
+	}
+
+	var names []string
+	if p.username != "" {
+		names = append(names, "@"+p.username)
+	}
+	if !p.anonymous {
+		// Without the read:org scope only the files owned by the user
+		// directly are theirs.
+		teams, err := p.client.ListUserTeams(ctx)
+		if err != nil {
+			logger.LogError("GITHUB_LIST_TEAMS", p.username, err)
+		}
+		names = append(names, teams...)
+	}
+	p.owners.loaded = true
+	p.owners.names = names
+	return names
+}
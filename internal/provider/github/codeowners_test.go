@@ -0,0 +1,69 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestGetCodeOwners_ReadsTheFirstCodeOwnersFileOfTheBaseBranch(t *testing.T) {
+	var refs []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/api/pulls/7":
+			fmt.Fprint(w, `{"number":7,"base":{"ref":"release/2.0"}}`)
+		case "/repos/acme/api/contents/.github/CODEOWNERS":
+			refs = append(refs, r.URL.Query().Get("ref"))
+			http.NotFound(w, r)
+		case "/repos/acme/api/contents/CODEOWNERS":
+			refs = append(refs, r.URL.Query().Get("ref"))
+			content := base64.StdEncoding.EncodeToString([]byte("*  @acme/core\n/docs/ @dana\n"))
+			fmt.Fprintf(w, `{"type":"file","encoding":"base64","path":"CODEOWNERS","content":%q}`, content)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	p := newTestAnonymousProvider(t, handler, "acme/api")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+	p.username = "dana"
+
+	owners, err := p.GetCodeOwners(context.Background(), domain.PRIdentifier{Repository: "acme/api", Number: 7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owners == nil {
+		t.Fatal("expected the code owners")
+	}
+	if !reflect.DeepEqual(refs, []string{"release/2.0", "release/2.0"}) {
+		t.Errorf("expected both locations read from the base branch, got %q", refs)
+	}
+	if got := owners.Owners("docs/intro.md"); !reflect.DeepEqual(got, []string{"@dana"}) {
+		t.Errorf("unexpected owners of docs/intro.md: %v", got)
+	}
+	if !owners.Owns("docs/intro.md") || owners.Owns("main.go") {
+		t.Errorf("expected only the docs to be owned by @dana, identities %v", owners.Identities)
+	}
+}
+
+func TestGetCodeOwners_ReturnsNilWithoutCodeOwnersFile(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/acme/api/pulls/7" {
+			fmt.Fprint(w, `{"number":7,"base":{"ref":"main"}}`)
+			return
+		}
+		http.NotFound(w, r)
+	})
+	p := newTestAnonymousProvider(t, handler, "acme/api")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+
+	owners, err := p.GetCodeOwners(context.Background(), domain.PRIdentifier{Repository: "acme/api", Number: 7})
+	if err != nil || owners != nil {
+		t.Errorf("expected no code owners and no error, got %+v, %v", owners, err)
+	}
+}
@@ -0,0 +1,89 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+// Comment page cursors name the list and the page number within it, such as
+// "review:2". The inline review comments are listed first, then the
+// conversation comments.
+const (
+	reviewCommentsCursor = "review"
+	issueCommentsCursor  = "issue"
+)
+
+// GetCommentsPage returns a page of the inline review comments, or once they
+// are all listed, of the conversation comments. The first page also looks up
+// the pull request for the number of comments of both kinds.
+func (p *Provider) GetCommentsPage(ctx context.Context, identifier domain.PRIdentifier, cursor string) (*domain.CommentPage, error) {
+	return p.commentsPage(ctx, identifier, cursor, cursor == "")
+}
+
+// commentsPage returns the page of comments at cursor, counting the comments
+// of all pages when withTotal is set.
+func (p *Provider) commentsPage(ctx context.Context, identifier domain.PRIdentifier, cursor string, withTotal bool) (*domain.CommentPage, error) {
+	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
+	if err != nil {
+		return nil, err
+	}
+	list, number, err := parseCommentsCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.CommentPage{}
+	if withTotal {
+		if pr, err := p.client.GetPullRequest(ctx, owner, repo, identifier.Number); err == nil {
+			page.Total = pr.GetReviewComments() + pr.GetComments()
+		}
+	}
+
+	var next int
+	if list == reviewCommentsCursor {
+		ghComments, nextPage, err := p.client.ListCommentsPage(ctx, owner, repo, identifier.Number, number)
+		if err != nil {
+			return nil, err
+		}
+		for _, ghComment := range ghComments {
+			page.Comments = append(page.Comments, convertComment(ghComment))
+		}
+		next = nextPage
+		if next == 0 {
+			// The conversation comments follow from their first page.
+			list, next = issueCommentsCursor, 1
+		}
+	} else {
+		issueComments, nextPage, err := p.client.ListIssueCommentsPage(ctx, owner, repo, identifier.Number, number)
+		if err != nil {
+			return nil, err
+		}
+		for _, issueComment := range issueComments {
+			page.Comments = append(page.Comments, convertIssueComment(issueComment))
+		}
+		next = nextPage
+	}
+	if next != 0 {
+		page.Next = fmt.Sprintf("%s:%d", list, next)
+	}
+	return page, nil
+}
+
+// parseCommentsCursor returns the list and page a cursor of GetCommentsPage
+// points at; "" is the first page of the review comments.
+func parseCommentsCursor(cursor string) (string, int, error) {
+	if cursor == "" {
+		return reviewCommentsCursor, 1, nil
+	}
+	list, page, _ := strings.Cut(cursor, ":")
+	number, err := strconv.Atoi(page)
+	if err != nil || number < 1 || (list != reviewCommentsCursor && list != issueCommentsCursor) {
+		return "", 0, fmt.Errorf("invalid comments cursor %q", cursor)
+	}
+	return list, number, nil
+}
@@ -0,0 +1,67 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestGetCommentsPage_WalksReviewThenConversationComments(t *testing.T) {
+	var server string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/api/pulls/7":
+			fmt.Fprint(w, `{"number":7,"comments":1,"review_comments":3}`)
+		case "/repos/acme/api/pulls/7/comments":
+			if r.URL.Query().Get("page") == "2" {
+				fmt.Fprint(w, `[{"id":3,"body":"Third","path":"b.go","line":1}]`)
+				return
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<%srepos/acme/api/pulls/7/comments?page=2>; rel="next"`, server))
+			fmt.Fprint(w, `[{"id":1,"body":"First","path":"a.go","line":1},{"id":2,"body":"Second","path":"a.go","line":1}]`)
+		case "/repos/acme/api/issues/7/comments":
+			fmt.Fprint(w, `[{"id":4,"body":"LGTM"}]`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	p := newTestAnonymousProvider(t, handler, "acme/api")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+	server = p.client.client.BaseURL.String()
+	identifier := domain.PRIdentifier{Repository: "acme/api", Number: 7}
+
+	var cursors []string
+	var bodies []string
+	cursor := ""
+	for {
+		page, err := p.GetCommentsPage(context.Background(), identifier, cursor)
+		if err != nil {
+			t.Fatalf("unexpected error at %q: %v", cursor, err)
+		}
+		if cursor == "" && page.Total != 4 {
+			t.Errorf("expected the first page to count all 4 comments, got %d", page.Total)
+		}
+		for _, comment := range page.Comments {
+			bodies = append(bodies, comment.Body)
+		}
+		if page.Next == "" {
+			break
+		}
+		cursor = page.Next
+		cursors = append(cursors, cursor)
+	}
+	if fmt.Sprint(cursors) != "[review:2 issue:1]" {
+		t.Errorf("unexpected cursors %v", cursors)
+	}
+	if fmt.Sprint(bodies) != "[First Second Third LGTM]" {
+		t.Errorf("unexpected comments %v", bodies)
+	}
+
+	if _, err := p.GetCommentsPage(context.Background(), identifier, "bogus"); err == nil {
+		t.Error("expected an error for an invalid cursor")
+	}
+}
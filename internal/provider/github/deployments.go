@@ -0,0 +1,97 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+// ListDeployments returns the newest deployments of a repository matching
+// opts, on a single page.
+func (c *Client) ListDeployments(ctx context.Context, owner, repo string, opts *github.DeploymentsListOptions) ([]*github.Deployment, error) {
+	deployments, _, err := c.client.Repositories.ListDeployments(ctx, owner, repo, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	return deployments, nil
+}
+
+// GetLatestDeploymentStatus returns the newest status of a deployment, or
+// nil when it has none yet.
+func (c *Client) GetLatestDeploymentStatus(ctx context.Context, owner, repo string, deployment int64) (*github.DeploymentStatus, error) {
+	statuses, _, err := c.client.Repositories.ListDeploymentStatuses(ctx, owner, repo, deployment, &github.ListOptions{PerPage: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployment statuses: %w", err)
+	}
+	if len(statuses) == 0 {
+		return nil, nil
+	}
+	return statuses[0], nil
+}
+
+// ListDeployments lists the latest deployment of the pull request to each
+// environment. Deployments are looked up by its head commit, and by its head
+// branch for the deployment tools that record the branch instead. The branch
+// is only looked up when it is in the repository itself: the branch of a
+// fork can share its name with a branch of the repository, such as main,
+// whose deployments are not the pull request's.
+func (p *Provider) ListDeployments(ctx context.Context, identifier domain.PRIdentifier) ([]domain.Deployment, error) {
+	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
+	if err != nil {
+		return nil, err
+	}
+	target := fmt.Sprintf("%s/%s#%d", owner, repo, identifier.Number)
+
+	ghPR, err := p.client.GetPullRequest(ctx, owner, repo, identifier.Number)
+	if err != nil {
+		logger.LogError("GITHUB_DEPLOYMENTS", target, err)
+		return nil, err
+	}
+
+	opts := &github.DeploymentsListOptions{SHA: ghPR.GetHead().GetSHA(), ListOptions: github.ListOptions{PerPage: 30}}
+	ghDeployments, err := p.client.ListDeployments(ctx, owner, repo, opts)
+	sameRepository := ghPR.GetHead().GetRepo().GetFullName() == ghPR.GetBase().GetRepo().GetFullName()
+	if err == nil && len(ghDeployments) == 0 && ghPR.GetHead().GetRef() != "" && sameRepository {
+		opts = &github.DeploymentsListOptions{Ref: ghPR.GetHead().GetRef(), ListOptions: github.ListOptions{PerPage: 30}}
+		ghDeployments, err = p.client.ListDeployments(ctx, owner, repo, opts)
+	}
+	if err != nil {
+		logger.LogError("GITHUB_DEPLOYMENTS", target, err)
+		return nil, err
+	}
+
+	// Deployments come newest first, so the first one of an environment is
+	// its latest.
+	var deployments []domain.Deployment
+	seen := make(map[string]bool)
+	for _, ghDeployment := range ghDeployments {
+		environment := ghDeployment.GetEnvironment()
+		if seen[environment] {
+			continue
+		}
+		seen[environment] = true
+
+		deployment := domain.Deployment{
+			Environment: environment,
+			State:       domain.DeploymentPending,
+			UpdatedAt:   ghDeployment.GetUpdatedAt().Time,
+		}
+		status, err := p.client.GetLatestDeploymentStatus(ctx, owner, repo, ghDeployment.GetID())
+		if err != nil {
+			logger.LogError("GITHUB_DEPLOYMENTS", target, err)
+			return nil, err
+		}
+		if status != nil {
+			deployment.State = domain.DeploymentState(status.GetState())
+			deployment.URL = status.GetEnvironmentURL()
+			deployment.UpdatedAt = status.GetUpdatedAt().Time
+		}
+		deployments = append(deployments, deployment)
+	}
+	logger.Log("GitHub: Found %d deployments of %s", len(deployments), target)
+	return deployments, nil
+}
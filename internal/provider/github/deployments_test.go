@@ -0,0 +1,111 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestListDeployments_ReturnsTheLatestDeploymentOfEachEnvironment(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/web/pulls/12":
+			fmt.Fprint(w, `{"number":12,"head":{"sha":"abc123","ref":"feature"}}`)
+		case "/repos/acme/web/deployments":
+			if sha := r.URL.Query().Get("sha"); sha != "abc123" {
+				t.Errorf("expected deployments of the head commit, got sha %q", sha)
+			}
+			fmt.Fprint(w, `[{"id":3,"environment":"preview"},{"id":2,"environment":"storybook"},{"id":1,"environment":"preview"}]`)
+		case "/repos/acme/web/deployments/3/statuses":
+			fmt.Fprint(w, `[{"state":"success","environment_url":"https://pr-12.preview.acme.dev"}]`)
+		case "/repos/acme/web/deployments/2/statuses":
+			fmt.Fprint(w, `[]`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	p := newTestAnonymousProvider(t, handler, "acme/web")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+
+	deployments, err := p.ListDeployments(context.Background(), domain.PRIdentifier{Repository: "acme/web", Number: 12})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deployments) != 2 {
+		t.Fatalf("expected one deployment per environment, got %+v", deployments)
+	}
+	if d := deployments[0]; d.Environment != "preview" || !d.IsActive() || d.URL != "https://pr-12.preview.acme.dev" {
+		t.Errorf("unexpected preview deployment %+v", d)
+	}
+	if d := deployments[1]; d.Environment != "storybook" || d.State != domain.DeploymentPending || d.IsActive() {
+		t.Errorf("expected the storybook deployment pending without a status, got %+v", d)
+	}
+}
+
+func TestListDeployments_FallsBackToTheHeadBranch(t *testing.T) {
+	var refs []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/web/pulls/12":
+			fmt.Fprint(w, `{"number":12,"head":{"sha":"abc123","ref":"feature","repo":{"full_name":"acme/web"}},"base":{"repo":{"full_name":"acme/web"}}}`)
+		case "/repos/acme/web/deployments":
+			if ref := r.URL.Query().Get("ref"); ref != "" {
+				refs = append(refs, ref)
+				fmt.Fprint(w, `[{"id":5,"environment":"preview"}]`)
+				return
+			}
+			fmt.Fprint(w, `[]`)
+		case "/repos/acme/web/deployments/5/statuses":
+			fmt.Fprint(w, `[{"state":"in_progress"}]`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	p := newTestAnonymousProvider(t, handler, "acme/web")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+
+	deployments, err := p.ListDeployments(context.Background(), domain.PRIdentifier{Repository: "acme/web", Number: 12})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(refs) != 1 || refs[0] != "feature" {
+		t.Errorf("expected the deployments of the head branch to be listed, got %q", refs)
+	}
+	if len(deployments) != 1 || deployments[0].State != domain.DeploymentInProgress {
+		t.Errorf("unexpected deployments %+v", deployments)
+	}
+}
+
+func TestListDeployments_IgnoresTheBranchOfAFork(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/web/pulls/12":
+			fmt.Fprint(w, `{"number":12,"head":{"sha":"abc123","ref":"main","repo":{"full_name":"contributor/web"}},"base":{"repo":{"full_name":"acme/web"}}}`)
+		case "/repos/acme/web/deployments":
+			if ref := r.URL.Query().Get("ref"); ref != "" {
+				t.Errorf("expected the fork's branch not to be looked up in the repository, got ref %q", ref)
+				fmt.Fprint(w, `[{"id":1,"environment":"production"}]`)
+				return
+			}
+			fmt.Fprint(w, `[]`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	p := newTestAnonymousProvider(t, handler, "acme/web")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+
+	deployments, err := p.ListDeployments(context.Background(), domain.PRIdentifier{Repository: "acme/web", Number: 12})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deployments) != 0 {
+		t.Errorf("expected no deployments, got %+v", deployments)
+	}
+}
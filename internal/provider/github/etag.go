@@ -0,0 +1,149 @@
+package github
+
+import (
+	"bytes"
+	"container/list"
+	"io"
+	"net/http"
+	"sync"
+)
+
+const (
+	// etagCacheSize is how many responses are kept to revalidate.
+	etagCacheSize = 500
+	// etagMaxBodySize is the largest response body kept; larger ones, such
+	// as the diffs of huge PRs, are fetched in full every time.
+	etagMaxBodySize = 2 << 20
+)
+
+// etagTransport makes GET requests conditional on the ETag or Last-Modified
+// of the response last received for the same URL, and answers a 304 Not
+// Modified with that response. GitHub does not count 304s against the rate
+// limit, so refreshing unchanged PRs costs nothing and needs no body to be
+// downloaded.
+type etagTransport struct {
+	base http.RoundTripper
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type cachedResponse struct {
+	key          string
+	etag         string
+	lastModified string
+	header       http.Header
+	body         []byte
+}
+
+func newETagTransport(base http.RoundTripper) *etagTransport {
+	return &etagTransport{
+		base:    base,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (t *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return base.RoundTrip(req)
+	}
+
+	// The same URL serves the JSON and the diff of a PR, told apart by the
+	// Accept header.
+	key := req.URL.String() + "\x00" + req.Header.Get("Accept")
+	cached := t.get(key)
+	if cached != nil {
+		req = req.Clone(req.Context())
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		resp.Body.Close()
+		header := cached.header.Clone()
+		// The rate limit headers of the 304 are the current ones.
+		for name, values := range resp.Header {
+			header[name] = values
+		}
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(cached.body)),
+			ContentLength: int64(len(cached.body)),
+			Request:       req,
+		}, nil
+
+	case resp.StatusCode == http.StatusOK:
+		etag := resp.Header.Get("ETag")
+		lastModified := resp.Header.Get("Last-Modified")
+		if etag == "" && lastModified == "" {
+			return resp, nil
+		}
+		if resp.ContentLength > etagMaxBodySize {
+			return resp, nil
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, etagMaxBodySize+1))
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		if len(body) > etagMaxBodySize {
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+			return resp, nil
+		}
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		t.put(&cachedResponse{key: key, etag: etag, lastModified: lastModified, header: resp.Header.Clone(), body: body})
+	}
+	return resp, nil
+}
+
+func (t *etagTransport) get(key string) *cachedResponse {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	element, ok := t.entries[key]
+	if !ok {
+		return nil
+	}
+	t.order.MoveToFront(element)
+	return element.Value.(*cachedResponse)
+}
+
+func (t *etagTransport) put(response *cachedResponse) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if element, ok := t.entries[response.key]; ok {
+		element.Value = response
+		t.order.MoveToFront(element)
+		return
+	}
+	t.entries[response.key] = t.order.PushFront(response)
+	for t.order.Len() > etagCacheSize {
+		oldest := t.order.Back()
+		t.order.Remove(oldest)
+		delete(t.entries, oldest.Value.(*cachedResponse).key)
+	}
+}
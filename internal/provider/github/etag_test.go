@@ -0,0 +1,46 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGetPullRequest_RevalidatesWithETag(t *testing.T) {
+	requests, notModified := 0, 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/repos/acme/api/pulls/7" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(60-requests))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"number":7,"title":"Add retries"}`)
+	})
+	p := newTestAnonymousProvider(t, handler, "acme/api")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+
+	for i := 0; i < 2; i++ {
+		pr, resp, err := p.client.client.PullRequests.Get(context.Background(), "acme", "api", 7)
+		if err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i+1, err)
+		}
+		if pr.GetTitle() != "Add retries" {
+			t.Errorf("request %d: expected the pull request, got %+v", i+1, pr)
+		}
+		if resp.Rate.Remaining != 60-requests {
+			t.Errorf("request %d: expected the current rate limit, got %d", i+1, resp.Rate.Remaining)
+		}
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("expected the refresh to be revalidated, got %d request(s), %d not modified", requests, notModified)
+	}
+}
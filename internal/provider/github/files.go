@@ -0,0 +1,166 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+// ListFiles returns the files a pull request changes, a page at a time, until
+// visit returns false. GitHub lists at most 3000 files.
+func (c *Client) ListFiles(ctx context.Context, owner, repo string, number int, visit func([]*github.CommitFile) bool) error {
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		files, resp, err := c.client.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+		if !visit(files) || resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// GetFileContent returns the content of a file at the given ref, and false
+// when there is no such file.
+func (c *Client) GetFileContent(ctx context.Context, owner, repo, path, ref string) (string, bool, error) {
+	file, _, _, err := c.client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+	if err != nil {
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to get %s: %w", path, err)
+	}
+	if file == nil {
+		return "", false, nil
+	}
+	content, err := file.GetContent()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return content, true, nil
+}
+
+// ListDiffFiles lists the changed files with their line counts. Their
+// patches are dropped and fetched again by GetFileDiff, so that a large pull
+// request is not parsed up front.
+func (p *Provider) ListDiffFiles(ctx context.Context, identifier domain.PRIdentifier) ([]domain.FileDiff, error) {
+	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	var listed []domain.FileDiff
+	err = p.client.ListFiles(ctx, owner, repo, identifier.Number, func(files []*github.CommitFile) bool {
+		for _, file := range files {
+			listed = append(listed, convertCommitFile(file))
+		}
+		return true
+	})
+	if err != nil {
+		logger.LogError("GITHUB_LIST_FILES", fmt.Sprintf("%s/%s#%d", owner, repo, identifier.Number), err)
+		return nil, err
+	}
+	return listed, nil
+}
+
+// GetFileDiff loads the hunks of a file listed by ListDiffFiles from its
+// patch. GitHub has no endpoint for a single file, so the file list is paged
+// through until the file is found.
+func (p *Provider) GetFileDiff(ctx context.Context, identifier domain.PRIdentifier, file domain.FileDiff) (*domain.FileDiff, error) {
+	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
+	if err != nil {
+		return nil, err
+	}
+	path := file.NewPath
+	if path == "" {
+		path = file.OldPath
+	}
+
+	var found *github.CommitFile
+	err = p.client.ListFiles(ctx, owner, repo, identifier.Number, func(files []*github.CommitFile) bool {
+		for _, candidate := range files {
+			if candidate.GetFilename() == path {
+				found = candidate
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		logger.LogError("GITHUB_GET_FILE_DIFF", path, err)
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%s is no longer changed by %s/%s#%d", path, owner, repo, identifier.Number)
+	}
+
+	loaded := convertCommitFile(found)
+	loaded.Deferred = false
+	if found.GetPatch() == "" {
+		// Binary files have no patch, and neither have files whose diff
+		// is too large for GitHub to show.
+		if found.GetChanges() == 0 && !loaded.IsRenamed {
+			loaded.Binary = &domain.BinaryInfo{OldSize: -1, NewSize: -1}
+			diff := &domain.Diff{Files: []domain.FileDiff{loaded}}
+			p.describeBinaryFiles(ctx, owner, repo, identifier.Number, diff)
+			loaded = diff.Files[0]
+		}
+		return &loaded, nil
+	}
+
+	parsed := common.ParseUnifiedDiff("diff --git\n" + found.GetPatch())
+	if len(parsed.Files) > 0 {
+		loaded.Hunks = parsed.Files[0].Hunks
+	}
+	return &loaded, nil
+}
+
+// convertCommitFile lists a changed file without its hunks.
+func convertCommitFile(file *github.CommitFile) domain.FileDiff {
+	converted := domain.FileDiff{
+		OldPath:   file.GetFilename(),
+		NewPath:   file.GetFilename(),
+		Additions: file.GetAdditions(),
+		Deletions: file.GetDeletions(),
+		Deferred:  true,
+	}
+	switch file.GetStatus() {
+	case "added":
+		converted.OldPath = ""
+		converted.IsNew = true
+	case "removed":
+		converted.NewPath = ""
+		converted.IsDeleted = true
+	case "renamed":
+		converted.OldPath = file.GetPreviousFilename()
+		converted.IsRenamed = true
+	}
+	return converted
+}
+
+// GetFileContent returns the content of a file of the pull request's
+// repository at ref.
+func (p *Provider) GetFileContent(ctx context.Context, identifier domain.PRIdentifier, path, ref string) (string, error) {
+	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
+	if err != nil {
+		return "", err
+	}
+	content, found, err := p.client.GetFileContent(ctx, owner, repo, path, ref)
+	if err != nil {
+		logger.LogError("GITHUB_FILE_CONTENT", fmt.Sprintf("%s/%s:%s", owner, repo, path), err)
+		return "", err
+	}
+	if !found {
+		return "", fmt.Errorf("%s does not exist at %s", path, ref)
+	}
+	return content, nil
+}
@@ -0,0 +1,77 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestListDiffFiles_AndGetFileDiff(t *testing.T) {
+	var pages []string
+	var server string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/api/pulls/7/files" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		if page == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%srepos/acme/api/pulls/7/files?page=2>; rel="next"`, server))
+			fmt.Fprint(w, `[{"filename":"main.go","status":"modified","additions":2,"deletions":1,"changes":3,"patch":"@@ -1,2 +1,3 @@\n package main\n-var a = 1\n+var a = 2\n+var b = 3"},
+{"filename":"new.go","previous_filename":"old.go","status":"renamed","changes":0}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"filename":"logo.png","status":"added","changes":0},
+{"filename":"gone.go","status":"removed","deletions":1,"changes":1,"patch":"@@ -1 +0,0 @@\n-package gone"}]`)
+	})
+	p := newTestAnonymousProvider(t, handler, "acme/api")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+	server = p.client.client.BaseURL.String()
+	identifier := domain.PRIdentifier{Repository: "acme/api", Number: 7}
+
+	files, err := p.ListDiffFiles(context.Background(), identifier)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 4 {
+		t.Fatalf("expected the files of both pages, got %+v", files)
+	}
+	if main := files[0]; !main.Deferred || main.Additions != 2 || main.Deletions != 1 || len(main.Hunks) != 0 {
+		t.Errorf("expected main.go listed with its line counts only, got %+v", main)
+	}
+	if renamed := files[1]; !renamed.IsRenamed || renamed.OldPath != "old.go" || renamed.NewPath != "new.go" {
+		t.Errorf("unexpected renamed file: %+v", renamed)
+	}
+	if gone := files[3]; !gone.IsDeleted || gone.NewPath != "" || gone.OldPath != "gone.go" {
+		t.Errorf("unexpected removed file: %+v", gone)
+	}
+
+	pages = nil
+	main, err := p.GetFileDiff(context.Background(), identifier, files[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if main.Deferred || len(main.Hunks) != 1 || len(main.Hunks[0].Lines) != 4 || main.Hunks[0].Lines[3].NewLine != 3 {
+		t.Errorf("expected the hunks of main.go, got %+v", main)
+	}
+	if len(pages) != 1 {
+		t.Errorf("expected to stop at the page listing the file, fetched %q", pages)
+	}
+
+	gone, err := p.GetFileDiff(context.Background(), identifier, files[3])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !gone.IsDeleted || len(gone.Hunks) != 1 || gone.Hunks[0].Lines[0].Type != "delete" {
+		t.Errorf("expected the hunks of the removed file, got %+v", gone)
+	}
+
+	if _, err := p.GetFileDiff(context.Background(), identifier, domain.FileDiff{NewPath: "missing.go"}); err == nil {
+		t.Error("expected an error for a file the pull request no longer changes")
+	}
+}
@@ -0,0 +1,27 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// ResolveIdentity returns the login of the user the token belongs to, which
+// is what pull request authors and reviewers are matched against.
+func (p *Provider) ResolveIdentity(ctx context.Context) (domain.Identity, error) {
+	if p.anonymous {
+		return domain.Identity{}, fmt.Errorf("public GitHub access has no authenticated user")
+	}
+
+	login, err := p.client.GetAuthenticatedLogin(ctx)
+	if err != nil {
+		logger.LogError("GITHUB_RESOLVE_IDENTITY", "", err)
+		return domain.Identity{}, err
+	}
+	if login == "" {
+		return domain.Identity{}, fmt.Errorf("GitHub returned no login for the token")
+	}
+	return domain.Identity{Username: login}, nil
+}
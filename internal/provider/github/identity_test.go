@@ -0,0 +1,70 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+func TestResolveIdentity_ReturnsTheTokensLogin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"login":"octocat"}`)
+	}))
+	defer server.Close()
+
+	p := NewProvider("token", "octocta")
+	p.client.client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	identity, err := p.ResolveIdentity(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if identity.Username != "octocat" {
+		t.Errorf("expected the login of the token rather than the configured username, got %q", identity.Username)
+	}
+	if identity.Matches("octocta") || !identity.Matches("OctoCat") {
+		t.Errorf("unexpected matching for %+v", identity)
+	}
+}
+
+func TestResolveIdentity_FailsWithoutToken(t *testing.T) {
+	p := NewAnonymousProvider([]string{"acme/api"}, "")
+	if _, err := p.ResolveIdentity(context.Background()); err == nil {
+		t.Error("expected an error for public access")
+	}
+}
+
+func TestValidateCredentials_TellsRejectedTokensFromUnreachableServers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message":"Bad credentials"}`)
+	}))
+	p := NewProvider("token", "")
+	p.client.client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	if err := p.ValidateCredentials(context.Background()); !errors.Is(err, common.ErrUnauthorized) {
+		t.Errorf("expected a rejected token to be reported as unauthorized, got %v", err)
+	}
+
+	withUsername := NewProvider("token", "octocat")
+	withUsername.client.client.BaseURL, _ = url.Parse(server.URL + "/")
+	if err := withUsername.ValidateCredentials(context.Background()); !errors.Is(err, common.ErrUnauthorized) {
+		t.Errorf("expected the token to be checked even with a username set, got %v", err)
+	}
+
+	server.Close()
+	if err := p.ValidateCredentials(context.Background()); err == nil || errors.Is(err, common.ErrUnauthorized) {
+		t.Errorf("expected an unreachable server not to be reported as unauthorized, got %v", err)
+	}
+}
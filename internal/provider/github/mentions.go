@@ -0,0 +1,80 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+// ListMentionCandidates lists the author, reviewers and commenters of the
+// pull request, followed by the authors and reviewers of the repository's
+// other open pull requests. Only the pull request itself is required; the
+// other lists are skipped when they cannot be read.
+func (p *Provider) ListMentionCandidates(ctx context.Context, identifier domain.PRIdentifier) ([]domain.MentionCandidate, error) {
+	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
+	if err != nil {
+		return nil, err
+	}
+	target := fmt.Sprintf("%s/%s#%d", owner, repo, identifier.Number)
+
+	ghPR, err := p.client.GetPullRequest(ctx, owner, repo, identifier.Number)
+	if err != nil {
+		logger.LogError("GITHUB_MENTIONS", target, err)
+		return nil, err
+	}
+
+	var candidates domain.MentionCandidates
+	add := func(user *github.User) {
+		if user == nil || user.GetLogin() == "" || user.GetType() == "Bot" {
+			return
+		}
+		candidates.Add(domain.User{
+			ID:       fmt.Sprintf("%d", user.GetID()),
+			Username: user.GetLogin(),
+			Avatar:   user.GetAvatarURL(),
+		}, "@"+user.GetLogin())
+	}
+
+	add(ghPR.User)
+	for _, reviewer := range ghPR.RequestedReviewers {
+		add(reviewer)
+	}
+	if reviews, err := p.client.ListReviews(ctx, owner, repo, identifier.Number); err == nil {
+		for _, review := range reviews {
+			add(review.User)
+		}
+	} else {
+		logger.Log("GITHUB_MENTIONS: reviews unavailable for %s: %v", target, err)
+	}
+	if comments, err := p.client.ListComments(ctx, owner, repo, identifier.Number); err == nil {
+		for _, comment := range comments {
+			add(comment.User)
+		}
+	} else {
+		logger.Log("GITHUB_MENTIONS: comments unavailable for %s: %v", target, err)
+	}
+	if comments, err := p.client.ListIssueComments(ctx, owner, repo, identifier.Number); err == nil {
+		for _, comment := range comments {
+			add(comment.User)
+		}
+	} else {
+		logger.Log("GITHUB_MENTIONS: conversation unavailable for %s: %v", target, err)
+	}
+
+	if prs, err := p.client.ListRepositoryPullRequests(ctx, owner, repo); err == nil {
+		for _, pr := range prs {
+			add(pr.User)
+			for _, reviewer := range pr.RequestedReviewers {
+				add(reviewer)
+			}
+		}
+	} else {
+		logger.Log("GITHUB_MENTIONS: open pull requests unavailable for %s/%s: %v", owner, repo, err)
+	}
+
+	return candidates.List(), nil
+}
@@ -0,0 +1,242 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+const (
+	checkSuccess = "success"
+	checkFailure = "failure"
+	checkPending = "pending"
+)
+
+// GetMergeRequirements combines the base branch protection, the repository's
+// allowed merge methods, the reviews and the head commit's checks. Reading
+// branch protection needs admin rights on the repository; without them the
+// protection is treated as unknown rather than failing the whole request.
+func (p *Provider) GetMergeRequirements(ctx context.Context, identifier domain.PRIdentifier) (*domain.MergeRequirements, error) {
+	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
+	if err != nil {
+		return nil, err
+	}
+	target := fmt.Sprintf("%s/%s#%d", owner, repo, identifier.Number)
+
+	ghPR, err := p.client.GetPullRequest(ctx, owner, repo, identifier.Number)
+	if err != nil {
+		logger.LogError("GITHUB_MERGE_REQUIREMENTS", target, err)
+		return nil, err
+	}
+	headSHA := ghPR.GetHead().GetSHA()
+
+	protection, err := p.client.GetBranchProtection(ctx, owner, repo, ghPR.GetBase().GetRef())
+	if err != nil {
+		logger.Log("GITHUB_MERGE_REQUIREMENTS: branch protection unavailable for %s: %v", target, err)
+		protection = nil
+	}
+
+	repository, err := p.client.GetRepository(ctx, owner, repo)
+	if err != nil {
+		logger.LogError("GITHUB_MERGE_REQUIREMENTS", target, err)
+		return nil, err
+	}
+
+	reviews, err := p.client.ListReviews(ctx, owner, repo, identifier.Number)
+	if err != nil {
+		logger.LogError("GITHUB_MERGE_REQUIREMENTS", target, err)
+		return nil, err
+	}
+	reviewers := buildReviewers(reviews, ghPR.RequestedReviewers, headSHA)
+
+	runs, err := p.client.ListCheckRuns(ctx, owner, repo, headSHA)
+	if err != nil {
+		logger.LogError("GITHUB_MERGE_REQUIREMENTS", target, err)
+		return nil, err
+	}
+	status, err := p.client.GetCombinedStatus(ctx, owner, repo, headSHA)
+	if err != nil {
+		logger.LogError("GITHUB_MERGE_REQUIREMENTS", target, err)
+		return nil, err
+	}
+
+	return buildMergeRequirements(protection, repository, reviewers, checkStates(runs, status), ghPR.GetMergeableState()), nil
+}
+
+// buildMergeRequirements evaluates the collected data. With required status
+// checks configured only those are considered, and a required check that has
+// not reported yet counts as pending; otherwise every check is reported.
+func buildMergeRequirements(protection *github.Protection, repository *github.Repository, reviewers []domain.Reviewer, checks map[string]string, mergeableState string) *domain.MergeRequirements {
+	req := &domain.MergeRequirements{
+		Protected:      protection != nil,
+		AllowedMethods: allowedMergeMethods(repository, protection),
+	}
+
+	dismissStale := false
+	if protection != nil && protection.RequiredPullRequestReviews != nil {
+		req.RequiredApprovals = protection.RequiredPullRequestReviews.RequiredApprovingReviewCount
+		dismissStale = protection.RequiredPullRequestReviews.DismissStaleReviews
+	}
+	for _, reviewer := range reviewers {
+		switch reviewer.State {
+		case domain.ReviewerApproved:
+			if !dismissStale || !reviewer.Stale {
+				req.Approvals++
+			}
+		case domain.ReviewerChangesRequested:
+			if req.Protected {
+				req.Blockers = append(req.Blockers, "changes requested by "+reviewer.User.Username)
+			}
+		}
+	}
+
+	var required []string
+	if protection != nil && protection.RequiredStatusChecks != nil {
+		for _, check := range protection.RequiredStatusChecks.Checks {
+			required = append(required, check.Context)
+		}
+		if len(required) == 0 {
+			required = protection.RequiredStatusChecks.Contexts
+		}
+	}
+	if len(required) == 0 {
+		for name := range checks {
+			required = append(required, name)
+		}
+	}
+	sort.Strings(required)
+	for _, name := range required {
+		switch checks[name] {
+		case checkSuccess:
+		case checkFailure:
+			req.FailingChecks = append(req.FailingChecks, name)
+		default:
+			req.PendingChecks = append(req.PendingChecks, name)
+		}
+	}
+
+	if mergeableState == "behind" && protection != nil && protection.RequiredStatusChecks != nil && protection.RequiredStatusChecks.Strict {
+		req.Blockers = append(req.Blockers, "branch is out of date with the base branch")
+	}
+
+	return req
+}
+
+// allowedMergeMethods returns nil when the repository settings are not
+// visible, which is the case for users without push access.
+func allowedMergeMethods(repository *github.Repository, protection *github.Protection) []string {
+	if repository == nil || (repository.AllowMergeCommit == nil && repository.AllowSquashMerge == nil && repository.AllowRebaseMerge == nil) {
+		return nil
+	}
+
+	linear := protection != nil && protection.RequireLinearHistory != nil && protection.RequireLinearHistory.Enabled
+	methods := []string{}
+	if repository.GetAllowMergeCommit() && !linear {
+		methods = append(methods, "merge")
+	}
+	if repository.GetAllowSquashMerge() {
+		methods = append(methods, "squash")
+	}
+	if repository.GetAllowRebaseMerge() {
+		methods = append(methods, "rebase")
+	}
+	return methods
+}
+
+// checkStates merges check runs and commit statuses into one state per check
+// name. Neutral and skipped check runs count as passed.
+func checkStates(runs []*github.CheckRun, status *github.CombinedStatus) map[string]string {
+	states := make(map[string]string)
+
+	for _, run := range runs {
+		states[run.GetName()] = checkRunState(run)
+	}
+
+	if status != nil {
+		for _, s := range status.Statuses {
+			switch s.GetState() {
+			case "success":
+				states[s.GetContext()] = checkSuccess
+			case "failure", "error":
+				states[s.GetContext()] = checkFailure
+			default:
+				states[s.GetContext()] = checkPending
+			}
+		}
+	}
+
+	return states
+}
+
+// checkRunState reduces a check run to its state; neutral and skipped runs
+// count as passed.
+func checkRunState(run *github.CheckRun) string {
+	if run.GetStatus() != "completed" {
+		return checkPending
+	}
+	switch run.GetConclusion() {
+	case "success", "neutral", "skipped":
+		return checkSuccess
+	}
+	return checkFailure
+}
+
+// ListConflictFiles approximates the conflicting files, since GitHub does not
+// report them: when the pull request is not mergeable, it returns the files
+// changed both in the pull request and on the base branch since the merge base.
+func (p *Provider) ListConflictFiles(ctx context.Context, identifier domain.PRIdentifier) ([]string, error) {
+	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
+	if err != nil {
+		return nil, err
+	}
+	target := fmt.Sprintf("%s/%s#%d", owner, repo, identifier.Number)
+
+	ghPR, err := p.client.GetPullRequest(ctx, owner, repo, identifier.Number)
+	if err != nil {
+		logger.LogError("GITHUB_CONFLICT_FILES", target, err)
+		return nil, err
+	}
+	if ghPR.GetMergeableState() != "dirty" {
+		return nil, nil
+	}
+
+	baseRef := ghPR.GetBase().GetRef()
+	prSide, err := p.client.CompareCommits(ctx, owner, repo, baseRef, ghPR.GetHead().GetSHA())
+	if err != nil {
+		logger.LogError("GITHUB_CONFLICT_FILES", target, err)
+		return nil, err
+	}
+	baseSide, err := p.client.CompareCommits(ctx, owner, repo, prSide.GetMergeBaseCommit().GetSHA(), baseRef)
+	if err != nil {
+		logger.LogError("GITHUB_CONFLICT_FILES", target, err)
+		return nil, err
+	}
+
+	return overlappingFiles(prSide.Files, baseSide.Files), nil
+}
+
+// overlappingFiles returns the sorted paths touched on both sides, matching
+// renamed files by their previous name as well.
+func overlappingFiles(prFiles, baseFiles []*github.CommitFile) []string {
+	touched := make(map[string]bool)
+	for _, file := range baseFiles {
+		touched[file.GetFilename()] = true
+		if previous := file.GetPreviousFilename(); previous != "" {
+			touched[previous] = true
+		}
+	}
+
+	var overlap []string
+	for _, file := range prFiles {
+		if touched[file.GetFilename()] || (file.GetPreviousFilename() != "" && touched[file.GetPreviousFilename()]) {
+			overlap = append(overlap, file.GetFilename())
+		}
+	}
+	sort.Strings(overlap)
+	return overlap
+}
@@ -0,0 +1,156 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+func TestCheckStates(t *testing.T) {
+	runs := []*github.CheckRun{
+		{Name: github.String("build"), Status: github.String("completed"), Conclusion: github.String("success")},
+		{Name: github.String("lint"), Status: github.String("completed"), Conclusion: github.String("failure")},
+		{Name: github.String("docs"), Status: github.String("completed"), Conclusion: github.String("skipped")},
+		{Name: github.String("e2e"), Status: github.String("in_progress")},
+	}
+	status := &github.CombinedStatus{Statuses: []*github.RepoStatus{
+		{Context: github.String("ci/legacy"), State: github.String("error")},
+	}}
+
+	want := map[string]string{
+		"build":     checkSuccess,
+		"lint":      checkFailure,
+		"docs":      checkSuccess,
+		"e2e":       checkPending,
+		"ci/legacy": checkFailure,
+	}
+	if got := checkStates(runs, status); !reflect.DeepEqual(got, want) {
+		t.Errorf("checkStates() = %v, want %v", got, want)
+	}
+}
+
+func TestBuildMergeRequirements_Protected(t *testing.T) {
+	protection := &github.Protection{
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+			RequiredApprovingReviewCount: 2,
+			DismissStaleReviews:          true,
+		},
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Strict: true,
+			Checks: []*github.RequiredStatusCheck{{Context: "build"}, {Context: "test"}},
+		},
+		RequireLinearHistory: &github.RequireLinearHistory{Enabled: true},
+	}
+	repository := &github.Repository{
+		AllowMergeCommit: github.Bool(true),
+		AllowSquashMerge: github.Bool(true),
+		AllowRebaseMerge: github.Bool(false),
+	}
+	reviewers := []domain.Reviewer{
+		{User: domain.User{Username: "alice"}, State: domain.ReviewerApproved},
+		{User: domain.User{Username: "bob"}, State: domain.ReviewerApproved, Stale: true},
+		{User: domain.User{Username: "carol"}, State: domain.ReviewerChangesRequested},
+	}
+	checks := map[string]string{"build": checkFailure, "lint": checkFailure}
+
+	req := buildMergeRequirements(protection, repository, reviewers, checks, "behind")
+
+	want := []string{
+		"needs 1 more approval",
+		"CI failing: build",
+		"waiting for checks: test",
+		"changes requested by carol",
+		"branch is out of date with the base branch",
+	}
+	if got := req.Unmet(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmet() = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(req.AllowedMethods, []string{"squash"}) {
+		t.Errorf("expected linear history to leave only squash, got %v", req.AllowedMethods)
+	}
+}
+
+func TestBuildMergeRequirements_Unprotected(t *testing.T) {
+	checks := map[string]string{"build": checkSuccess, "lint": checkFailure}
+
+	req := buildMergeRequirements(nil, &github.Repository{}, nil, checks, "clean")
+
+	if req.Protected || req.RequiredApprovals != 0 {
+		t.Errorf("expected no protection, got %+v", req)
+	}
+	if !reflect.DeepEqual(req.FailingChecks, []string{"lint"}) {
+		t.Errorf("expected all failing checks to be reported, got %v", req.FailingChecks)
+	}
+	if req.AllowedMethods != nil {
+		t.Errorf("expected unknown merge methods without repository settings, got %v", req.AllowedMethods)
+	}
+}
+
+func TestOverlappingFiles(t *testing.T) {
+	file := func(name, previous string) *github.CommitFile {
+		f := &github.CommitFile{Filename: github.String(name)}
+		if previous != "" {
+			f.PreviousFilename = github.String(previous)
+		}
+		return f
+	}
+
+	prFiles := []*github.CommitFile{file("b.go", ""), file("a.go", ""), file("new.go", "old.go"), file("only-pr.go", "")}
+	baseFiles := []*github.CommitFile{file("a.go", ""), file("b.go", ""), file("old.go", ""), file("only-base.go", "")}
+
+	want := []string{"a.go", "b.go", "new.go"}
+	if got := overlappingFiles(prFiles, baseFiles); !reflect.DeepEqual(got, want) {
+		t.Errorf("overlappingFiles() = %v, want %v", got, want)
+	}
+}
+
+func TestMergePullRequest_DeletesSourceBranchOfSameRepositoryOnly(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		headRepo string
+		wantErr  error
+		deleted  bool
+	}{
+		{name: "same repository", headRepo: "acme/api", deleted: true},
+		{name: "fork", headRepo: "contributor/api", wantErr: common.ErrSourceBranchKept},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var deletes []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPut && r.URL.Path == "/repos/acme/api/pulls/7/merge":
+					fmt.Fprint(w, `{"merged":true}`)
+				case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/api/pulls/7":
+					fmt.Fprintf(w, `{"number":7,"head":{"ref":"main","repo":{"full_name":%q}},"base":{"ref":"main","repo":{"full_name":"acme/api"}}}`, tc.headRepo)
+				case r.Method == http.MethodDelete:
+					deletes = append(deletes, r.URL.Path)
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+			p := NewProvider("token", "octocat")
+			p.client.client.BaseURL, _ = url.Parse(server.URL + "/")
+
+			err := p.MergePullRequest(context.Background(), domain.PRIdentifier{Repository: "acme/api", Number: 7}, "merge", true)
+
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+			if tc.deleted != (len(deletes) == 1 && deletes[0] == "/repos/acme/api/git/refs/heads/main") {
+				t.Errorf("expected deleted=%v, got deletes %v", tc.deleted, deletes)
+			}
+		})
+	}
+}
@@ -2,23 +2,52 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/go-github/v57/github"
 	"github.com/johanforsgren/lgtmfaster/internal/domain"
 	"github.com/johanforsgren/lgtmfaster/internal/logger"
 	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+	"golang.org/x/oauth2"
 )
 
 type Provider struct {
-	client   *Client
-	username string
+	client          *Client
+	username        string
+	repositories    []string
+	anonymous       bool
+	maxPullRequests int
+	owners          ownerIdentities
 }
 
 func NewProvider(token string, username string) *Provider {
 	return &Provider{
-		client:   NewClient(token, username),
-		username: username,
+		client:          NewClient(token, username),
+		username:        username,
+		maxPullRequests: domain.DefaultGitHubMaxPullRequests,
+	}
+}
+
+// NewOAuthProvider creates a provider from a device-flow login whose tokens
+// come from ts.
+func NewOAuthProvider(ts oauth2.TokenSource, username string) *Provider {
+	return &Provider{
+		client:          NewTokenSourceClient(ts, username),
+		username:        username,
+		maxPullRequests: domain.DefaultGitHubMaxPullRequests,
+	}
+}
+
+// NewAnonymousProvider creates a read-only provider that lists open pull
+// requests of the given public "owner/repo" repositories without a token.
+func NewAnonymousProvider(repositories []string, username string) *Provider {
+	return &Provider{
+		client:       NewAnonymousClient(username),
+		username:     username,
+		repositories: repositories,
+		anonymous:    true,
 	}
 }
 
@@ -27,33 +56,186 @@ func (p *Provider) GetType() domain.ProviderType {
 }
 
 func (p *Provider) ListPullRequests(ctx context.Context, username string) ([]domain.PullRequest, error) {
-	logger.Log("GitHub: Listing pull requests for user %s", username)
-	ghPRs, err := p.client.ListPullRequests(ctx)
+	return p.ListPullRequestsWithProgress(ctx, username, nil)
+}
+
+// ListPullRequestsWithProgress lists the open PRs the user is involved in,
+// up to the configured cap. Each PR found by the search is fetched with its
+// reviews, and progress is called as each is done.
+func (p *Provider) ListPullRequestsWithProgress(ctx context.Context, username string, progress func(listed, total int)) ([]domain.PullRequest, error) {
+	if p.anonymous {
+		return p.listPublicPullRequests(ctx, username)
+	}
+
+	logger.Log("GitHub: Listing up to %d pull requests for user %s", p.maxPullRequests, username)
+	issues, err := p.client.ListInvolvedPullRequests(ctx, p.maxPullRequests)
 	if err != nil {
 		logger.LogError("GITHUB_LIST_PRS", username, err)
 		return nil, err
 	}
 
-	prs := make([]domain.PullRequest, 0, len(ghPRs))
-	for _, ghPR := range ghPRs {
+	prs := make([]domain.PullRequest, 0, len(issues))
+	for i, issue := range issues {
+		if progress != nil {
+			progress(i, len(issues))
+		}
+		owner, repo, ok := issueRepository(issue)
+		if !ok {
+			continue
+		}
+		ghPR, err := p.client.GetPullRequest(ctx, owner, repo, issue.GetNumber())
+		if err != nil {
+			logger.LogError("GITHUB_GET_PR", fmt.Sprintf("%s/%s#%d", owner, repo, issue.GetNumber()), err)
+			continue
+		}
 		pr := p.convertPullRequest(ghPR, username)
 
-		if ghPR.Base != nil && ghPR.Base.Repo != nil {
-			owner := ghPR.Base.Repo.GetOwner().GetLogin()
-			repo := ghPR.Base.Repo.GetName()
-			reviews, err := p.client.ListReviews(ctx, owner, repo, ghPR.GetNumber())
-			if err == nil {
-				pr.ApprovalStatus = p.calculateApprovalStatus(reviews)
-			}
+		reviews, err := p.client.ListReviews(ctx, owner, repo, ghPR.GetNumber())
+		if err == nil {
+			pr.ApprovalStatus = p.calculateApprovalStatus(reviews)
 		}
 
 		prs = append(prs, pr)
 	}
+	if progress != nil {
+		progress(len(issues), len(issues))
+	}
 
 	logger.Log("GitHub: Found %d pull requests", len(prs))
 	return prs, nil
 }
 
+// SetMaxPullRequests caps how many PRs ListPullRequests lists, see
+// domain.GitHubSettings.
+func (p *Provider) SetMaxPullRequests(limit int) {
+	p.maxPullRequests = domain.GitHubSettings{MaxPullRequests: limit}.PullRequestLimit()
+}
+
+// listPublicPullRequests lists open PRs of the configured repositories. Review
+// states are not fetched per PR to stay within the anonymous rate limit.
+func (p *Provider) listPublicPullRequests(ctx context.Context, username string) ([]domain.PullRequest, error) {
+	logger.Log("GitHub: Listing public pull requests for %d repositories (anonymous)", len(p.repositories))
+	if len(p.repositories) == 0 {
+		return nil, fmt.Errorf("no repositories configured for anonymous GitHub access")
+	}
+
+	var prs []domain.PullRequest
+	for _, repository := range p.repositories {
+		repoPRs, err := p.ListRepositoryPullRequests(ctx, repository, username)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, repoPRs...)
+	}
+
+	logger.Log("GitHub: Found %d public pull requests", len(prs))
+	return prs, nil
+}
+
+// ListRepositoryPullRequests lists the open PRs of an "owner/repo"
+// repository, whoever they involve. Review states are not fetched per PR.
+func (p *Provider) ListRepositoryPullRequests(ctx context.Context, repository string, username string) ([]domain.PullRequest, error) {
+	owner, repo, err := common.ParseGitHubRepository(repository)
+	if err != nil {
+		logger.LogError("GITHUB_LIST_REPO_PRS", repository, err)
+		return nil, err
+	}
+
+	ghPRs, err := p.client.ListRepositoryPullRequests(ctx, owner, repo)
+	if err != nil {
+		logger.LogError("GITHUB_LIST_REPO_PRS", repository, err)
+		return nil, err
+	}
+
+	prs := make([]domain.PullRequest, 0, len(ghPRs))
+	for _, ghPR := range ghPRs {
+		prs = append(prs, p.convertPullRequest(ghPR, username))
+	}
+	return prs, nil
+}
+
+// SearchPullRequests searches pull requests using GitHub search syntax.
+// Results are built from the search response alone; full details such as
+// branches are loaded when a PR is opened.
+func (p *Provider) SearchPullRequests(ctx context.Context, query string, username string) ([]domain.PullRequest, error) {
+	logger.Log("GitHub: Searching pull requests: %s", query)
+	issues, err := p.client.SearchPullRequests(ctx, query)
+	if err != nil {
+		logger.LogError("GITHUB_SEARCH_PRS", query, err)
+		return nil, err
+	}
+
+	prs := make([]domain.PullRequest, 0, len(issues))
+	for _, issue := range issues {
+		prs = append(prs, convertIssueToPullRequest(issue, username))
+	}
+
+	logger.Log("GitHub: Search returned %d pull requests", len(prs))
+	return prs, nil
+}
+
+// SearchInvolvedPullRequests searches the title, description and comments
+// of the pull requests the user is involved in, open or not. Without a token
+// the configured repositories are searched instead.
+func (p *Provider) SearchInvolvedPullRequests(ctx context.Context, text string, username string) ([]domain.PullRequest, error) {
+	logger.Log("GitHub: Searching involved pull requests: %s", text)
+	var issues []*github.Issue
+	var err error
+	if p.anonymous {
+		scope := make([]string, len(p.repositories))
+		for i, repo := range p.repositories {
+			scope[i] = "repo:" + repo
+		}
+		issues, err = p.client.SearchPullRequests(ctx, fmt.Sprintf("in:title,body,comments %s %s", strings.Join(scope, " "), text))
+	} else {
+		issues, err = p.client.SearchInvolvedPullRequests(ctx, text)
+	}
+	if err != nil {
+		logger.LogError("GITHUB_SEARCH_INVOLVED", text, err)
+		return nil, err
+	}
+
+	prs := make([]domain.PullRequest, 0, len(issues))
+	for _, issue := range issues {
+		prs = append(prs, convertIssueToPullRequest(issue, username))
+	}
+
+	logger.Log("GitHub: Involved search returned %d pull requests", len(prs))
+	return prs, nil
+}
+
+// ListAuthorPullRequests lists the open PRs opened by author, with the
+// approval status from their reviews. Without a token only the configured
+// repositories are searched and reviews are not fetched.
+func (p *Provider) ListAuthorPullRequests(ctx context.Context, author string, username string) ([]domain.PullRequest, error) {
+	logger.Log("GitHub: Listing open pull requests of %s", author)
+	query := "is:open author:" + author
+	if p.anonymous {
+		for _, repo := range p.repositories {
+			query += " repo:" + repo
+		}
+	}
+	issues, err := p.client.SearchPullRequests(ctx, query)
+	if err != nil {
+		logger.LogError("GITHUB_LIST_AUTHOR_PRS", author, err)
+		return nil, err
+	}
+
+	prs := make([]domain.PullRequest, 0, len(issues))
+	for _, issue := range issues {
+		pr := convertIssueToPullRequest(issue, username)
+		if !p.anonymous {
+			if owner, repo, err := common.ParseGitHubRepository(pr.Repository.FullName); err == nil {
+				if reviews, err := p.client.ListReviews(ctx, owner, repo, pr.Number); err == nil {
+					pr.ApprovalStatus = p.calculateApprovalStatus(reviews)
+				}
+			}
+		}
+		prs = append(prs, pr)
+	}
+	return prs, nil
+}
+
 func (p *Provider) GetPullRequest(ctx context.Context, identifier domain.PRIdentifier) (*domain.PullRequest, error) {
 	logger.Log("GitHub: Getting PR #%d from %s", identifier.Number, identifier.Repository)
 	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
@@ -103,49 +285,123 @@ func (p *Provider) GetDiff(ctx context.Context, identifier domain.PRIdentifier)
 	for i, file := range diff.Files {
 		logger.Log("GitHub: File %d: %s -> %s (%d hunks)", i+1, file.OldPath, file.NewPath, len(file.Hunks))
 	}
+	p.describeBinaryFiles(ctx, owner, repo, identifier.Number, diff)
 	return diff, nil
 }
 
-func (p *Provider) GetComments(ctx context.Context, identifier domain.PRIdentifier) ([]domain.Comment, error) {
-	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
-	if err != nil {
-		return nil, err
+// describeBinaryFiles fills in the sizes and blob URL of binary files, which
+// the diff only reports as changed. Failures are logged and leave the sizes
+// unknown.
+func (p *Provider) describeBinaryFiles(ctx context.Context, owner, repo string, number int, diff *domain.Diff) {
+	hasBinary := false
+	for _, file := range diff.Files {
+		if file.Binary != nil {
+			hasBinary = true
+			break
+		}
+	}
+	if !hasBinary {
+		return
 	}
 
-	ghComments, err := p.client.ListComments(ctx, owner, repo, identifier.Number)
+	ghPR, err := p.client.GetPullRequest(ctx, owner, repo, number)
 	if err != nil {
-		return nil, err
+		logger.LogError("GITHUB_BINARY_INFO", fmt.Sprintf("%s/%s#%d", owner, repo, number), err)
+		return
 	}
+	baseSHA := ghPR.GetBase().GetSHA()
+	headSHA := ghPR.GetHead().GetSHA()
 
-	comments := make([]domain.Comment, 0, len(ghComments))
-	for _, ghComment := range ghComments {
-		comment := convertComment(ghComment)
-		comments = append(comments, comment)
+	for i := range diff.Files {
+		file := &diff.Files[i]
+		if file.Binary == nil {
+			continue
+		}
+
+		if !file.IsNew && file.OldPath != "" {
+			size, url, err := p.client.GetFileInfo(ctx, owner, repo, file.OldPath, baseSHA)
+			if err != nil {
+				logger.LogError("GITHUB_BINARY_INFO", file.OldPath, err)
+			} else {
+				file.Binary.OldSize = size
+				file.Binary.BlobURL = url
+			}
+		}
+		if !file.IsDeleted && file.NewPath != "" {
+			size, url, err := p.client.GetFileInfo(ctx, owner, repo, file.NewPath, headSHA)
+			if err != nil {
+				logger.LogError("GITHUB_BINARY_INFO", file.NewPath, err)
+			} else {
+				file.Binary.NewSize = size
+				file.Binary.BlobURL = url
+			}
+		}
 	}
+}
 
-	return comments, nil
+func (p *Provider) GetComments(ctx context.Context, identifier domain.PRIdentifier) ([]domain.Comment, error) {
+	var comments []domain.Comment
+	cursor := ""
+	for {
+		page, err := p.commentsPage(ctx, identifier, cursor, false)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, page.Comments...)
+		if page.Next == "" {
+			return comments, nil
+		}
+		cursor = page.Next
+	}
 }
 
-func (p *Provider) AddComment(ctx context.Context, identifier domain.PRIdentifier, body string, filePath string, line int) error {
+// AddComment comments on a line of the diff, or on the conversation when no
+// file is given. A review comment must name the commit it applies to, so
+// the PR's head commit is looked up first.
+func (p *Provider) AddComment(ctx context.Context, identifier domain.PRIdentifier, body string, filePath string, line int, side string) error {
+	if p.anonymous {
+		return common.ErrReadOnly
+	}
+
 	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
 	if err != nil {
 		return err
 	}
 
-	comment := &github.PullRequestComment{
-		Body: github.String(body),
+	if filePath == "" || line <= 0 {
+		return p.client.CreateIssueComment(ctx, owner, repo, identifier.Number, body)
 	}
 
-	if filePath != "" && line > 0 {
-		comment.Path = github.String(filePath)
-		comment.Line = github.Int(line)
+	pr, err := p.client.GetPullRequest(ctx, owner, repo, identifier.Number)
+	if err != nil {
+		return err
+	}
+	comment := &github.PullRequestComment{
+		Body:     github.String(body),
+		CommitID: github.String(pr.GetHead().GetSHA()),
+		Path:     github.String(filePath),
+		Line:     github.Int(line),
+		Side:     github.String(commentSide(side)),
 	}
-
 	return p.client.CreateComment(ctx, owner, repo, identifier.Number, comment)
 }
 
+// commentSide returns the side of the diff a review comment is on: "LEFT"
+// for a line of the base (a deleted line) and "RIGHT", the default, for a
+// line of the head.
+func commentSide(side string) string {
+	if side == "LEFT" {
+		return "LEFT"
+	}
+	return "RIGHT"
+}
+
 func (p *Provider) SubmitReview(ctx context.Context, review domain.Review) error {
 	logger.Log("GitHub: Submitting review for %s (Action: %s)", review.PRIdentifier, review.Action)
+	if p.anonymous {
+		return common.ErrReadOnly
+	}
+
 	owner, repo, prNumber, err := common.ParseGitHubIdentifier(review.PRIdentifier)
 	if err != nil {
 		logger.LogError("GITHUB_SUBMIT_REVIEW", review.PRIdentifier, err)
@@ -165,6 +421,7 @@ func (p *Provider) SubmitReview(ctx context.Context, review domain.Review) error
 			comments = append(comments, &github.DraftReviewComment{
 				Path: github.String(c.FilePath),
 				Line: github.Int(c.Line),
+				Side: github.String(commentSide(c.Side)),
 				Body: github.String(c.Body),
 			})
 		}
@@ -180,14 +437,26 @@ func (p *Provider) SubmitReview(ctx context.Context, review domain.Review) error
 	return nil
 }
 
+// ValidateCredentials asks GitHub who the token belongs to, which fails when
+// the token was revoked or has expired. Public access has no token to check.
 func (p *Provider) ValidateCredentials(ctx context.Context) error {
-	_, err := p.client.GetUsername(ctx)
+	if p.anonymous {
+		return nil
+	}
+	_, err := p.client.GetAuthenticatedLogin(ctx)
+	var ghErr *github.ErrorResponse
+	if errors.As(err, &ghErr) && ghErr.Response != nil {
+		return common.AuthError(ghErr.Response.StatusCode, err)
+	}
 	return err
 }
 
 func (p *Provider) MergePullRequest(ctx context.Context, identifier domain.PRIdentifier, mergeMethod string, deleteBranch bool) error {
 	logger.Log("GitHub: Merging PR #%d from %s (method: %s, deleteBranch: %v)",
 		identifier.Number, identifier.Repository, mergeMethod, deleteBranch)
+	if p.anonymous {
+		return common.ErrReadOnly
+	}
 
 	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
 	if err != nil {
@@ -196,6 +465,10 @@ func (p *Provider) MergePullRequest(ctx context.Context, identifier domain.PRIde
 	}
 
 	if err := p.client.MergePullRequest(ctx, owner, repo, identifier.Number, mergeMethod, deleteBranch); err != nil {
+		if errors.Is(err, common.ErrSourceBranchKept) {
+			logger.Log("GitHub: Merged PR #%d; its source branch is in a fork and was kept", identifier.Number)
+			return err
+		}
 		logger.LogError("GITHUB_MERGE_PR", fmt.Sprintf("%s/%s#%d", owner, repo, identifier.Number), err)
 		return fmt.Errorf("%s", common.ExtractErrorMessage(err))
 	}
@@ -206,6 +479,9 @@ func (p *Provider) MergePullRequest(ctx context.Context, identifier domain.PRIde
 
 func (p *Provider) UpdatePullRequestDescription(ctx context.Context, identifier domain.PRIdentifier, description string) error {
 	logger.Log("GitHub: Updating PR #%d description from %s", identifier.Number, identifier.Repository)
+	if p.anonymous {
+		return common.ErrReadOnly
+	}
 
 	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
 	if err != nil {
@@ -231,6 +507,8 @@ func (p *Provider) convertPullRequest(ghPR *github.PullRequest, currentUser stri
 	category := domain.PRCategoryOther
 	if ghPR.User != nil && ghPR.User.Login != nil && *ghPR.User.Login == currentUser {
 		category = domain.PRCategoryAuthored
+	} else if isReviewRequested(ghPR, currentUser) {
+		category = domain.PRCategoryReviewRequested
 	} else if ghPR.Assignee != nil && ghPR.Assignee.Login != nil && *ghPR.Assignee.Login == currentUser {
 		category = domain.PRCategoryAssigned
 	}
@@ -256,6 +534,7 @@ func (p *Provider) convertPullRequest(ghPR *github.PullRequest, currentUser stri
 		URL:         ghPR.GetHTMLURL(),
 		IsDraft:     ghPR.GetDraft(),
 		Mergeable:   ghPR.GetMergeable(),
+		Labels:      labelNames(ghPR.Labels),
 	}
 
 	if ghPR.User != nil {
@@ -279,11 +558,93 @@ func (p *Provider) convertPullRequest(ghPR *github.PullRequest, currentUser stri
 
 	if ghPR.Head != nil {
 		pr.SourceBranch = ghPR.Head.GetRef()
+		pr.HeadSHA = ghPR.Head.GetSHA()
+	}
+
+	return pr
+}
+
+// isReviewRequested reports whether currentUser is among the pending requested
+// reviewers. GitHub drops a reviewer from the list once they submit a review.
+func isReviewRequested(ghPR *github.PullRequest, currentUser string) bool {
+	if currentUser == "" {
+		return false
+	}
+	for _, reviewer := range ghPR.RequestedReviewers {
+		if strings.EqualFold(reviewer.GetLogin(), currentUser) {
+			return true
+		}
+	}
+	return false
+}
+
+func convertIssueToPullRequest(issue *github.Issue, currentUser string) domain.PullRequest {
+	category := domain.PRCategoryOther
+	if issue.GetUser().GetLogin() == currentUser && currentUser != "" {
+		category = domain.PRCategoryAuthored
+	} else if issue.GetAssignee().GetLogin() == currentUser && currentUser != "" {
+		category = domain.PRCategoryAssigned
+	}
+
+	status := domain.PRStatusOpen
+	if issue.GetState() == "closed" {
+		status = domain.PRStatusClosed
+	}
+
+	pr := domain.PullRequest{
+		ID:          fmt.Sprintf("%d", issue.GetID()),
+		Number:      issue.GetNumber(),
+		Title:       issue.GetTitle(),
+		Description: issue.GetBody(),
+		Status:      status,
+		Category:    category,
+		CreatedAt:   issue.GetCreatedAt().Time,
+		UpdatedAt:   issue.GetUpdatedAt().Time,
+		URL:         issue.GetHTMLURL(),
+		IsDraft:     issue.GetDraft(),
+		Labels:      labelNames(issue.Labels),
+	}
+
+	if issue.User != nil {
+		pr.Author = domain.User{
+			ID:       fmt.Sprintf("%d", issue.User.GetID()),
+			Username: issue.User.GetLogin(),
+			Avatar:   issue.User.GetAvatarURL(),
+		}
+	}
+
+	if owner, name, ok := issueRepository(issue); ok {
+		pr.Repository = domain.Repo{
+			Name:     name,
+			FullName: owner + "/" + name,
+			Owner:    owner,
+			URL:      fmt.Sprintf("https://github.com/%s/%s", owner, name),
+		}
 	}
 
 	return pr
 }
 
+// issueRepository returns the owner and name of the repository of an issue
+// found by a search, taken from its API URL.
+func issueRepository(issue *github.Issue) (owner, repo string, ok bool) {
+	parts := strings.Split(issue.GetRepositoryURL(), "/")
+	if len(parts) < 2 {
+		return "", "", false
+	}
+	return parts[len(parts)-2], parts[len(parts)-1], true
+}
+
+func labelNames(labels []*github.Label) []string {
+	var names []string
+	for _, label := range labels {
+		if name := label.GetName(); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 func convertComment(ghComment *github.PullRequestComment) domain.Comment {
 	comment := domain.Comment{
 		ID:        fmt.Sprintf("%d", ghComment.GetID()),
@@ -293,6 +654,29 @@ func convertComment(ghComment *github.PullRequestComment) domain.Comment {
 		FilePath:  ghComment.GetPath(),
 		Line:      ghComment.GetLine(),
 		Side:      ghComment.GetSide(),
+		Reactions: convertReactions(ghComment.Reactions),
+	}
+
+	if ghComment.User != nil {
+		comment.Author = domain.User{
+			ID:       fmt.Sprintf("%d", ghComment.User.GetID()),
+			Username: ghComment.User.GetLogin(),
+			Avatar:   ghComment.User.GetAvatarURL(),
+		}
+	}
+
+	return comment
+}
+
+// convertIssueComment converts a conversation comment, which has no file or
+// line since it is not attached to the diff.
+func convertIssueComment(ghComment *github.IssueComment) domain.Comment {
+	comment := domain.Comment{
+		ID:        fmt.Sprintf("%d", ghComment.GetID()),
+		Body:      ghComment.GetBody(),
+		CreatedAt: ghComment.GetCreatedAt().Time,
+		UpdatedAt: ghComment.GetUpdatedAt().Time,
+		Reactions: convertReactions(ghComment.Reactions),
 	}
 
 	if ghComment.User != nil {
@@ -0,0 +1,208 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+func TestGetComments_IncludesConversationComments(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/api/pulls/7/comments":
+			fmt.Fprint(w, `[{"id":1,"body":"Nit","path":"main.go","line":3,"side":"RIGHT","user":{"login":"alice"},"reactions":{"total_count":4,"+1":3,"hooray":1}}]`)
+		case "/repos/acme/api/issues/7/comments":
+			fmt.Fprint(w, `[{"id":2,"body":"LGTM overall","created_at":"2024-05-01T10:00:00Z","user":{"login":"bob"}}]`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	p := newTestAnonymousProvider(t, handler, "acme/api")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+
+	comments, err := p.GetComments(context.Background(), domain.PRIdentifier{Repository: "acme/api", Number: 7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("expected review and conversation comments, got %+v", comments)
+	}
+	if comments[0].FilePath != "main.go" || comments[0].Line != 3 {
+		t.Errorf("unexpected review comment: %+v", comments[0])
+	}
+	want := []domain.Reaction{{Content: domain.ReactionThumbsUp, Count: 3}, {Content: domain.ReactionHooray, Count: 1}}
+	if fmt.Sprint(comments[0].Reactions) != fmt.Sprint(want) {
+		t.Errorf("expected reactions %v, got %v", want, comments[0].Reactions)
+	}
+	conversation := comments[1]
+	if conversation.FilePath != "" || conversation.Line != 0 || conversation.Author.Username != "bob" || conversation.Body != "LGTM overall" {
+		t.Errorf("unexpected conversation comment: %+v", conversation)
+	}
+	if conversation.CreatedAt.IsZero() {
+		t.Error("expected the conversation comment's creation time")
+	}
+}
+
+func TestAddReaction_UsesTheCommentKindsEndpoint(t *testing.T) {
+	var requested []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":1,"content":"+1"}`)
+	})
+	p := newTestAnonymousProvider(t, handler, "acme/api")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+	p.anonymous = false
+
+	identifier := domain.PRIdentifier{Repository: "acme/api", Number: 7}
+	if err := p.AddReaction(context.Background(), identifier, domain.Comment{ID: "11", FilePath: "main.go"}, domain.ReactionThumbsUp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := p.AddReaction(context.Background(), identifier, domain.Comment{ID: "12"}, domain.ReactionHeart); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"POST /repos/acme/api/pulls/comments/11/reactions", "POST /repos/acme/api/issues/comments/12/reactions"}
+	if fmt.Sprint(requested) != fmt.Sprint(want) {
+		t.Errorf("expected requests %v, got %v", want, requested)
+	}
+}
+
+func TestAddComment_AnchorsDeletedLinesToTheLeftSide(t *testing.T) {
+	var bodies []map[string]interface{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/acme/api/pulls/7":
+			fmt.Fprint(w, `{"number":7,"head":{"sha":"abc123"}}`)
+		case "POST /repos/acme/api/pulls/7/comments", "POST /repos/acme/api/issues/7/comments":
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("invalid body: %v", err)
+			}
+			bodies = append(bodies, body)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":1}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	p := newTestAnonymousProvider(t, handler, "acme/api")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+	p.anonymous = false
+	identifier := domain.PRIdentifier{Repository: "acme/api", Number: 7}
+
+	if err := p.AddComment(context.Background(), identifier, "Why drop this?", "main.go", 12, "LEFT"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := p.AddComment(context.Background(), identifier, "Nice", "main.go", 30, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := p.AddComment(context.Background(), identifier, "Overall LGTM", "", 0, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(bodies) != 3 {
+		t.Fatalf("expected three comments, got %v", bodies)
+	}
+	if deleted := bodies[0]; deleted["side"] != "LEFT" || deleted["line"] != float64(12) || deleted["commit_id"] != "abc123" {
+		t.Errorf("expected the deleted line on the left side of the head commit, got %v", deleted)
+	}
+	if added := bodies[1]; added["side"] != "RIGHT" || added["line"] != float64(30) {
+		t.Errorf("expected the right side by default, got %v", added)
+	}
+	if conversation := bodies[2]; conversation["body"] != "Overall LGTM" || conversation["path"] != nil {
+		t.Errorf("expected a conversation comment, got %v", conversation)
+	}
+}
+
+func TestSubmitReview_SendsTheSideOfEachComment(t *testing.T) {
+	var review struct {
+		Comments []struct {
+			Path string `json:"path"`
+			Line int    `json:"line"`
+			Side string `json:"side"`
+		} `json:"comments"`
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method+" "+r.URL.Path != "POST /repos/acme/api/pulls/7/reviews" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+			t.Errorf("invalid body: %v", err)
+		}
+		fmt.Fprint(w, `{"id":1}`)
+	})
+	p := newTestAnonymousProvider(t, handler, "acme/api")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+	p.anonymous = false
+
+	err := p.SubmitReview(context.Background(), domain.Review{
+		PRIdentifier: "acme/api/7",
+		Action:       domain.ReviewActionComment,
+		Comments: []domain.Comment{
+			{Body: "Removed on purpose?", FilePath: "main.go", Line: 4, Side: "LEFT"},
+			{Body: "Typo", FilePath: "main.go", Line: 4, Side: "RIGHT"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(review.Comments) != 2 || review.Comments[0].Side != "LEFT" || review.Comments[1].Side != "RIGHT" {
+		t.Errorf("expected each comment on its own side, got %+v", review.Comments)
+	}
+}
+
+func TestAddReaction_AnonymousIsReadOnly(t *testing.T) {
+	p := NewAnonymousProvider([]string{"acme/api"}, "")
+
+	err := p.AddReaction(context.Background(), domain.PRIdentifier{Repository: "acme/api", Number: 7}, domain.Comment{ID: "1"}, domain.ReactionThumbsUp)
+	if !errors.Is(err, common.ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly, got %v", err)
+	}
+}
+
+func TestListMentionCandidates_ParticipantsBeforeCollaborators(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/api/pulls/7":
+			fmt.Fprint(w, `{"number":7,"user":{"login":"alice"},"requested_reviewers":[{"login":"bob"}]}`)
+		case "/repos/acme/api/pulls/7/reviews":
+			fmt.Fprint(w, `[{"id":1,"user":{"login":"carol"}}]`)
+		case "/repos/acme/api/pulls/7/comments":
+			fmt.Fprint(w, `[{"id":2,"user":{"login":"Bob"}}]`)
+		case "/repos/acme/api/issues/7/comments":
+			fmt.Fprint(w, `[{"id":3,"user":{"login":"github-actions[bot]","type":"Bot"}}]`)
+		case "/repos/acme/api/pulls":
+			fmt.Fprint(w, `[{"number":8,"user":{"login":"dave"}}]`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	p := newTestAnonymousProvider(t, handler, "acme/api")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+
+	candidates, err := p.ListMentionCandidates(context.Background(), domain.PRIdentifier{Repository: "acme/api", Number: 7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, candidate := range candidates {
+		got = append(got, candidate.Text)
+	}
+	want := []string{"@alice", "@bob", "@carol", "@dave"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
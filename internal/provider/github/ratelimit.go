@@ -0,0 +1,99 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+const (
+	anonymousMinRequestInterval = 500 * time.Millisecond
+	anonymousRateLimitReserve   = 5
+)
+
+// rateLimitedTransport spaces out requests and stops issuing them once the
+// remaining quota reported by GitHub drops to a small reserve. Unauthenticated
+// clients only get 60 requests per hour, so it is better to fail fast with a
+// clear message than to burn the quota on a single refresh.
+type rateLimitedTransport struct {
+	base        http.RoundTripper
+	minInterval time.Duration
+	reserve     int
+
+	mu        sync.Mutex
+	last      time.Time
+	remaining int
+	reset     time.Time
+}
+
+func newRateLimitedTransport(base http.RoundTripper) *rateLimitedTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &rateLimitedTransport{
+		base:        base,
+		minInterval: anonymousMinRequestInterval,
+		reserve:     anonymousRateLimitReserve,
+		remaining:   -1,
+	}
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.wait(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	t.record(resp)
+	return resp, nil
+}
+
+func (t *rateLimitedTransport) wait(req *http.Request) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if t.remaining >= 0 && t.remaining <= t.reserve && now.Before(t.reset) {
+		logger.LogError("GITHUB_RATE_LIMIT", req.URL.Path, common.ErrRateLimited)
+		return fmt.Errorf("%w: %d anonymous GitHub requests left, resets at %s",
+			common.ErrRateLimited, t.remaining, t.reset.Format("15:04"))
+	}
+
+	if delay := t.minInterval - now.Sub(t.last); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			return req.Context().Err()
+		}
+	}
+
+	t.last = time.Now()
+	return nil
+}
+
+func (t *rateLimitedTransport) record(resp *http.Response) {
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	t.remaining = remaining
+	t.reset = time.Unix(reset, 0)
+	t.mu.Unlock()
+}
@@ -0,0 +1,84 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+var supportedReactions = []string{
+	domain.ReactionThumbsUp,
+	domain.ReactionThumbsDown,
+	domain.ReactionLaugh,
+	domain.ReactionHooray,
+	domain.ReactionConfused,
+	domain.ReactionHeart,
+	domain.ReactionRocket,
+	domain.ReactionEyes,
+}
+
+func (p *Provider) Reactions() []string {
+	return supportedReactions
+}
+
+// AddReaction reacts to comment. Inline comments and conversation comments
+// live in different GitHub APIs; conversation comments have no file path.
+func (p *Provider) AddReaction(ctx context.Context, identifier domain.PRIdentifier, comment domain.Comment, content string) error {
+	if p.anonymous {
+		return common.ErrReadOnly
+	}
+
+	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(comment.ID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid comment ID %q: %w", comment.ID, err)
+	}
+
+	if comment.FilePath != "" {
+		err = p.client.CreateReviewCommentReaction(ctx, owner, repo, id, content)
+	} else {
+		err = p.client.CreateIssueCommentReaction(ctx, owner, repo, id, content)
+	}
+	if err != nil {
+		logger.LogError("GITHUB_ADD_REACTION", fmt.Sprintf("%s/%s#%d comment=%s", owner, repo, identifier.Number, comment.ID), err)
+		return fmt.Errorf("%s", common.ExtractErrorMessage(err))
+	}
+	return nil
+}
+
+// convertReactions lists the reactions with at least one user, in the order
+// GitHub shows them.
+func convertReactions(reactions *github.Reactions) []domain.Reaction {
+	if reactions == nil {
+		return nil
+	}
+
+	var result []domain.Reaction
+	for _, reaction := range []struct {
+		content string
+		count   int
+	}{
+		{domain.ReactionThumbsUp, reactions.GetPlusOne()},
+		{domain.ReactionThumbsDown, reactions.GetMinusOne()},
+		{domain.ReactionLaugh, reactions.GetLaugh()},
+		{domain.ReactionHooray, reactions.GetHooray()},
+		{domain.ReactionConfused, reactions.GetConfused()},
+		{domain.ReactionHeart, reactions.GetHeart()},
+		{domain.ReactionRocket, reactions.GetRocket()},
+		{domain.ReactionEyes, reactions.GetEyes()},
+	} {
+		if reaction.count > 0 {
+			result = append(result, domain.Reaction{Content: reaction.content, Count: reaction.count})
+		}
+	}
+	return result
+}
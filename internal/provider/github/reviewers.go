@@ -0,0 +1,141 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+)
+
+func (p *Provider) ListReviewers(ctx context.Context, identifier domain.PRIdentifier) ([]domain.Reviewer, error) {
+	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	ghPR, err := p.client.GetPullRequest(ctx, owner, repo, identifier.Number)
+	if err != nil {
+		logger.LogError("GITHUB_LIST_REVIEWERS", fmt.Sprintf("%s/%s#%d", owner, repo, identifier.Number), err)
+		return nil, err
+	}
+
+	reviews, err := p.client.ListReviews(ctx, owner, repo, identifier.Number)
+	if err != nil {
+		logger.LogError("GITHUB_LIST_REVIEWERS", fmt.Sprintf("%s/%s#%d", owner, repo, identifier.Number), err)
+		return nil, err
+	}
+
+	return buildReviewers(reviews, ghPR.RequestedReviewers, ghPR.GetHead().GetSHA()), nil
+}
+
+// buildReviewers reduces the review history to one entry per reviewer. As on
+// GitHub, a later comment-only review does not replace an approval or change
+// request, and requested reviewers are pending even if they reviewed before.
+func buildReviewers(reviews []*github.PullRequestReview, requested []*github.User, headSHA string) []domain.Reviewer {
+	byLogin := make(map[string]*domain.Reviewer)
+
+	for _, review := range reviews {
+		login := review.GetUser().GetLogin()
+		state := convertReviewState(review.GetState())
+		if login == "" || state == "" {
+			continue
+		}
+
+		existing, ok := byLogin[login]
+		if ok && state == domain.ReviewerCommented && existing.State != domain.ReviewerCommented {
+			continue
+		}
+
+		byLogin[login] = &domain.Reviewer{
+			User:        domain.User{Username: login, Avatar: review.GetUser().GetAvatarURL()},
+			State:       state,
+			ReviewID:    strconv.FormatInt(review.GetID(), 10),
+			SubmittedAt: review.GetSubmittedAt().Time,
+			Stale:       headSHA != "" && review.GetCommitID() != "" && review.GetCommitID() != headSHA,
+		}
+	}
+
+	for _, user := range requested {
+		login := user.GetLogin()
+		if login == "" {
+			continue
+		}
+		if existing, ok := byLogin[login]; ok {
+			existing.State = domain.ReviewerPending
+			existing.Stale = false
+			continue
+		}
+		byLogin[login] = &domain.Reviewer{
+			User:  domain.User{Username: login, Avatar: user.GetAvatarURL()},
+			State: domain.ReviewerPending,
+		}
+	}
+
+	reviewers := make([]domain.Reviewer, 0, len(byLogin))
+	for _, reviewer := range byLogin {
+		reviewers = append(reviewers, *reviewer)
+	}
+	sort.Slice(reviewers, func(i, j int) bool {
+		return strings.ToLower(reviewers[i].User.Username) < strings.ToLower(reviewers[j].User.Username)
+	})
+	return reviewers
+}
+
+func convertReviewState(state string) domain.ReviewerState {
+	switch state {
+	case "APPROVED":
+		return domain.ReviewerApproved
+	case "CHANGES_REQUESTED":
+		return domain.ReviewerChangesRequested
+	case "COMMENTED":
+		return domain.ReviewerCommented
+	case "DISMISSED":
+		return domain.ReviewerDismissed
+	}
+	return ""
+}
+
+func (p *Provider) DismissReview(ctx context.Context, identifier domain.PRIdentifier, reviewID string, message string) error {
+	if p.anonymous {
+		return common.ErrReadOnly
+	}
+
+	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(reviewID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid review ID %q: %w", reviewID, err)
+	}
+
+	if err := p.client.DismissReview(ctx, owner, repo, identifier.Number, id, message); err != nil {
+		logger.LogError("GITHUB_DISMISS_REVIEW", fmt.Sprintf("%s/%s#%d review=%s", owner, repo, identifier.Number, reviewID), err)
+		return fmt.Errorf("%s", common.ExtractErrorMessage(err))
+	}
+	return nil
+}
+
+func (p *Provider) RequestReview(ctx context.Context, identifier domain.PRIdentifier, username string) error {
+	if p.anonymous {
+		return common.ErrReadOnly
+	}
+
+	owner, repo, err := common.ParseGitHubRepository(identifier.Repository)
+	if err != nil {
+		return err
+	}
+
+	if err := p.client.RequestReviewers(ctx, owner, repo, identifier.Number, []string{username}); err != nil {
+		logger.LogError("GITHUB_REQUEST_REVIEW", fmt.Sprintf("%s/%s#%d reviewer=%s", owner, repo, identifier.Number, username), err)
+		return fmt.Errorf("%s", common.ExtractErrorMessage(err))
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestBuildReviewers(t *testing.T) {
+	review := func(id int64, login, state, commit string) *github.PullRequestReview {
+		return &github.PullRequestReview{
+			ID:       github.Int64(id),
+			User:     &github.User{Login: github.String(login)},
+			State:    github.String(state),
+			CommitID: github.String(commit),
+		}
+	}
+
+	reviews := []*github.PullRequestReview{
+		review(1, "alice", "APPROVED", "old"),
+		review(2, "alice", "COMMENTED", "head"),
+		review(3, "bob", "CHANGES_REQUESTED", "head"),
+		review(4, "carol", "APPROVED", "old"),
+		review(5, "dave", "PENDING", "head"),
+	}
+	requested := []*github.User{
+		{Login: github.String("carol")},
+		{Login: github.String("erin")},
+	}
+
+	reviewers := buildReviewers(reviews, requested, "head")
+
+	want := []struct {
+		login    string
+		state    domain.ReviewerState
+		reviewID string
+		stale    bool
+	}{
+		{"alice", domain.ReviewerApproved, "1", true},
+		{"bob", domain.ReviewerChangesRequested, "3", false},
+		{"carol", domain.ReviewerPending, "4", false},
+		{"erin", domain.ReviewerPending, "", false},
+	}
+	if len(reviewers) != len(want) {
+		t.Fatalf("expected %d reviewers, got %+v", len(want), reviewers)
+	}
+	for i, w := range want {
+		got := reviewers[i]
+		if got.User.Username != w.login || got.State != w.state || got.ReviewID != w.reviewID || got.Stale != w.stale {
+			t.Errorf("reviewer %d = %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+func TestListReviews_FetchesEveryPage(t *testing.T) {
+	var server string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/api/pulls/7/reviews" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%srepos/acme/api/pulls/7/reviews?page=2>; rel="next"`, server))
+			fmt.Fprint(w, `[{"id":1,"state":"COMMENTED"}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":2,"state":"APPROVED"}]`)
+	})
+	p := newTestAnonymousProvider(t, handler, "acme/api")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+	server = p.client.client.BaseURL.String()
+
+	reviews, err := p.client.ListReviews(context.Background(), "acme", "api", 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reviews) != 2 || reviews[1].GetState() != "APPROVED" {
+		t.Errorf("expected the reviews of both pages, got %+v", reviews)
+	}
+}
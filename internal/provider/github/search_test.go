@@ -0,0 +1,193 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestSearchPullRequests_ConvertsIssues(t *testing.T) {
+	var gotQuery string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query().Get("q")
+		fmt.Fprint(w, `{"total_count":2,"items":[
+			{"number":5,"title":"Add search","state":"open","user":{"login":"jane"},
+			 "repository_url":"https://api.github.com/repos/acme/api","pull_request":{"url":"x"}},
+			{"number":6,"title":"Plain issue","state":"open","repository_url":"https://api.github.com/repos/acme/api"}
+		]}`)
+	})
+
+	p := newTestAnonymousProvider(t, handler, "acme/api")
+	p.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+
+	prs, err := p.SearchPullRequests(context.Background(), "org:acme search", "jane")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotQuery != "is:pr org:acme search" {
+		t.Errorf("expected query to be restricted to PRs, got %q", gotQuery)
+	}
+	if len(prs) != 1 {
+		t.Fatalf("expected only the pull request to be returned, got %d", len(prs))
+	}
+	if prs[0].Repository.FullName != "acme/api" || prs[0].Number != 5 {
+		t.Errorf("unexpected PR: %+v", prs[0])
+	}
+	if prs[0].Category != domain.PRCategoryAuthored {
+		t.Errorf("expected authored category, got %s", prs[0].Category)
+	}
+}
+
+func TestSearchInvolvedPullRequests_SearchesTextAndComments(t *testing.T) {
+	var gotQuery string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"login":"jane"}`)
+	})
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query().Get("q")
+		fmt.Fprint(w, `{"total_count":1,"items":[
+			{"number":5,"title":"Add search","state":"closed","user":{"login":"bob"},
+			 "repository_url":"https://api.github.com/repos/acme/api","pull_request":{"url":"x"}}
+		]}`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	p := NewProvider("token", "")
+	p.client.client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	prs, err := p.SearchInvolvedPullRequests(context.Background(), "retry backoff", "jane")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotQuery != "is:pr involves:jane in:title,body,comments retry backoff" {
+		t.Errorf("expected the PRs the user is involved in to be searched, got %q", gotQuery)
+	}
+	if len(prs) != 1 || prs[0].Number != 5 || prs[0].Repository.FullName != "acme/api" {
+		t.Errorf("unexpected results: %+v", prs)
+	}
+
+	anonymous := newTestAnonymousProvider(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query().Get("q")
+		fmt.Fprint(w, `{"total_count":0,"items":[]}`)
+	}), "acme/api")
+	anonymous.client.client.Client().Transport.(*rateLimitedTransport).minInterval = 0
+	if _, err := anonymous.SearchInvolvedPullRequests(context.Background(), "retry", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotQuery != "is:pr in:title,body,comments repo:acme/api retry" {
+		t.Errorf("expected the configured repositories to be searched without a token, got %q", gotQuery)
+	}
+}
+
+func TestListAuthorPullRequests_IncludesApprovalStatus(t *testing.T) {
+	var gotQuery string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query().Get("q")
+		fmt.Fprint(w, `{"total_count":1,"items":[
+			{"number":5,"title":"Add search","state":"open","user":{"login":"alice"},
+			 "repository_url":"https://api.github.com/repos/acme/api","pull_request":{"url":"x"}}
+		]}`)
+	})
+	mux.HandleFunc("/repos/acme/api/pulls/5/reviews", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"state":"APPROVED","user":{"login":"bob"},"submitted_at":"2026-01-02T10:00:00Z"}]`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	p := NewProvider("token", "jane")
+	p.client.client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	prs, err := p.ListAuthorPullRequests(context.Background(), "alice", "jane")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotQuery != "is:pr is:open author:alice" {
+		t.Errorf("unexpected query %q", gotQuery)
+	}
+	if len(prs) != 1 || prs[0].ApprovalStatus != domain.ApprovalStatusApproved {
+		t.Errorf("expected the PR with its approval status, got %+v", prs)
+	}
+}
+
+func TestListPullRequests_PagesThroughSearchResults(t *testing.T) {
+	var server *httptest.Server
+	var searches int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		searches++
+		if got := r.URL.Query().Get("q"); got != "is:pr is:open involves:jane" {
+			t.Errorf("unexpected query %q", got)
+		}
+		if r.URL.Query().Get("page") != "2" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/search/issues?page=2>; rel="next"`, server.URL))
+			fmt.Fprint(w, `{"total_count":4,"items":[
+				{"number":1,"repository_url":"https://api.github.com/repos/acme/api","pull_request":{"url":"x"}},
+				{"number":2,"repository_url":"https://api.github.com/repos/acme/api","pull_request":{"url":"x"}}
+			]}`)
+			return
+		}
+		// PR 2 was updated while paging and shows up again.
+		fmt.Fprint(w, `{"total_count":4,"items":[
+			{"number":2,"repository_url":"https://api.github.com/repos/acme/api","pull_request":{"url":"x"}},
+			{"number":3,"repository_url":"https://api.github.com/repos/acme/web","pull_request":{"url":"x"}},
+			{"number":4,"repository_url":"https://api.github.com/repos/acme/web","pull_request":{"url":"x"}}
+		]}`)
+	})
+	mux.HandleFunc("/repos/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/reviews") {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		parts := strings.Split(r.URL.Path, "/")
+		fmt.Fprintf(w, `{"number":%s,"title":"PR","state":"open","user":{"login":"jane"},"base":{"ref":"main","repo":{"full_name":"acme/%s"}}}`,
+			parts[len(parts)-1], parts[3])
+	})
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	p := NewProvider("token", "jane")
+	p.client.client.BaseURL, _ = url.Parse(server.URL + "/")
+	p.SetMaxPullRequests(3)
+
+	var reported [][2]int
+	prs, err := p.ListPullRequestsWithProgress(context.Background(), "jane", func(listed, total int) {
+		reported = append(reported, [2]int{listed, total})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, pr := range prs {
+		got = append(got, fmt.Sprintf("%s#%d", pr.Repository.FullName, pr.Number))
+	}
+	if strings.Join(got, " ") != "acme/api#1 acme/api#2 acme/web#3" {
+		t.Errorf("expected the first three distinct PRs of both pages, got %v", got)
+	}
+	if searches != 2 {
+		t.Errorf("expected two search pages, got %d", searches)
+	}
+	if len(reported) != 4 || reported[0] != [2]int{0, 3} || reported[3] != [2]int{3, 3} {
+		t.Errorf("unexpected progress %v", reported)
+	}
+}
+
+func TestGitHubSettings_PullRequestLimit(t *testing.T) {
+	for _, tt := range []struct{ max, want int }{
+		{0, domain.DefaultGitHubMaxPullRequests},
+		{50, 50},
+		{5000, domain.GitHubSearchLimit},
+	} {
+		if got := (domain.GitHubSettings{MaxPullRequests: tt.max}).PullRequestLimit(); got != tt.want {
+			t.Errorf("PullRequestLimit() with %d = %d, want %d", tt.max, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+// Package plugin lets executables in ~/.lgtmfaster/plugins act as providers
+// for systems LGTMFaster has no built-in support for. A PAT whose provider
+// is "phabricator" is served by the plugin named phabricator.
+//
+// Each provider call runs the plugin once: a Request is written to its
+// stdin and a Response is read from its stdout, both as JSON. Anything the
+// plugin writes to stderr is logged.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// Prefix may be put in front of a plugin's name in its file name so that
+// plugins can also be installed on PATH without clashing with other tools.
+const Prefix = "lgtmfaster-provider-"
+
+// Timeout bounds a single plugin call.
+const Timeout = 2 * time.Minute
+
+var ErrNotFound = errors.New("provider plugin not found")
+
+// Plugin is an executable discovered in the plugin directory.
+type Plugin struct {
+	Name string
+	Path string
+}
+
+// Dir returns the directory plugins are discovered in.
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".lgtmfaster", "plugins"), nil
+}
+
+// Discover lists the executables in dir, sorted by name. A missing
+// directory has no plugins.
+func Discover(dir string) ([]Plugin, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin directory: %w", err)
+	}
+
+	var plugins []Plugin
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.Mode()&0o111 == 0 {
+			continue
+		}
+		plugins = append(plugins, Plugin{
+			Name: pluginName(entry.Name()),
+			Path: filepath.Join(dir, entry.Name()),
+		})
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins, nil
+}
+
+func pluginName(fileName string) string {
+	name := strings.TrimPrefix(fileName, Prefix)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// Find returns the plugin in dir serving provider.
+func Find(dir string, provider domain.ProviderType) (Plugin, error) {
+	plugins, err := Discover(dir)
+	if err != nil {
+		return Plugin{}, err
+	}
+	for _, p := range plugins {
+		if strings.EqualFold(p.Name, string(provider)) {
+			return p, nil
+		}
+	}
+	return Plugin{}, fmt.Errorf("%w: no %s or %s%s in %s", ErrNotFound, provider, Prefix, provider, dir)
+}
+
+// Provider implements domain.Provider by calling a plugin.
+type Provider struct {
+	plugin       Plugin
+	providerType domain.ProviderType
+	credentials  Credentials
+}
+
+func NewProvider(p Plugin, pat domain.PAT) *Provider {
+	return &Provider{
+		plugin:       p,
+		providerType: pat.Provider,
+		credentials: Credentials{
+			Token:        pat.Token,
+			Username:     pat.Username,
+			Organization: pat.Organization,
+			Repositories: pat.Repositories,
+		},
+	}
+}
+
+// call runs the plugin for method and decodes its result into result,
+// which may be nil for methods without one.
+func (p *Provider) call(ctx context.Context, method string, params, result any) error {
+	input, err := json.Marshal(Request{
+		ProtocolVersion: ProtocolVersion,
+		Method:          method,
+		Credentials:     p.credentials,
+		Params:          params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", method, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	logger.Log("Plugin: Calling %s on %s", method, p.plugin.Name)
+
+	cmd := exec.CommandContext(ctx, p.plugin.Path)
+	cmd.Stdin = bytes.NewReader(input)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, runErr := cmd.Output()
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		logger.Log("Plugin: %s stderr: %s", p.plugin.Name, msg)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(out, &resp); err != nil {
+		if runErr != nil {
+			logger.LogError("PLUGIN_"+strings.ToUpper(method), p.plugin.Name, runErr)
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return fmt.Errorf("%s plugin failed: %s", p.plugin.Name, firstLine(msg))
+			}
+			return fmt.Errorf("%s plugin failed: %w", p.plugin.Name, runErr)
+		}
+		return fmt.Errorf("%s plugin returned an invalid response to %s: %w", p.plugin.Name, method, err)
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("%s: %s", p.plugin.Name, resp.Error.Message)
+	}
+	if result == nil || len(resp.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		return fmt.Errorf("%s plugin returned an invalid %s result: %w", p.plugin.Name, method, err)
+	}
+	return nil
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+func (p *Provider) GetType() domain.ProviderType {
+	return p.providerType
+}
+
+func (p *Provider) ValidateCredentials(ctx context.Context) error {
+	return p.call(ctx, MethodValidateCredentials, nil, nil)
+}
+
+func (p *Provider) ListPullRequests(ctx context.Context, username string) ([]domain.PullRequest, error) {
+	return p.listPullRequests(ctx, MethodListPullRequests, ListParams{Username: username})
+}
+
+// SearchPullRequests passes query to the plugin unchanged; plugins that do
+// not support searching answer with an error.
+func (p *Provider) SearchPullRequests(ctx context.Context, query string, username string) ([]domain.PullRequest, error) {
+	return p.listPullRequests(ctx, MethodSearchPullRequests, ListParams{Username: username, Query: query})
+}
+
+func (p *Provider) listPullRequests(ctx context.Context, method string, params ListParams) ([]domain.PullRequest, error) {
+	var prs []PullRequest
+	if err := p.call(ctx, method, params, &prs); err != nil {
+		return nil, err
+	}
+	result := make([]domain.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		result = append(result, pr.toDomain(p.providerType))
+	}
+	return result, nil
+}
+
+func (p *Provider) GetPullRequest(ctx context.Context, identifier domain.PRIdentifier) (*domain.PullRequest, error) {
+	var pr PullRequest
+	if err := p.call(ctx, MethodGetPullRequest, toPRRef(identifier), &pr); err != nil {
+		return nil, err
+	}
+	result := pr.toDomain(p.providerType)
+	return &result, nil
+}
+
+func (p *Provider) GetDiff(ctx context.Context, identifier domain.PRIdentifier) (*domain.Diff, error) {
+	var files []FileDiff
+	if err := p.call(ctx, MethodGetDiff, toPRRef(identifier), &files); err != nil {
+		return nil, err
+	}
+	diff := &domain.Diff{Files: make([]domain.FileDiff, 0, len(files))}
+	for _, file := range files {
+		diff.Files = append(diff.Files, file.toDomain())
+	}
+	return diff, nil
+}
+
+func (p *Provider) GetComments(ctx context.Context, identifier domain.PRIdentifier) ([]domain.Comment, error) {
+	var comments []Comment
+	if err := p.call(ctx, MethodGetComments, toPRRef(identifier), &comments); err != nil {
+		return nil, err
+	}
+	result := make([]domain.Comment, 0, len(comments))
+	for _, comment := range comments {
+		result = append(result, comment.toDomain())
+	}
+	return result, nil
+}
+
+func (p *Provider) AddComment(ctx context.Context, identifier domain.PRIdentifier, body string, filePath string, line int, side string) error {
+	return p.call(ctx, MethodAddComment, AddCommentParams{
+		PullRequest: toPRRef(identifier),
+		Body:        body,
+		FilePath:    filePath,
+		Line:        line,
+		Side:        side,
+	}, nil)
+}
+
+func (p *Provider) SubmitReview(ctx context.Context, review domain.Review) error {
+	comments := make([]Comment, 0, len(review.Comments))
+	for _, comment := range review.Comments {
+		comments = append(comments, fromDomainComment(comment))
+	}
+	return p.call(ctx, MethodSubmitReview, SubmitReviewParams{
+		PullRequest: parsePRRef(review.PRIdentifier),
+		Action:      string(review.Action),
+		Body:        review.Body,
+		Comments:    comments,
+	}, nil)
+}
+
+func (p *Provider) MergePullRequest(ctx context.Context, identifier domain.PRIdentifier, mergeMethod string, deleteBranch bool) error {
+	return p.call(ctx, MethodMergePullRequest, MergeParams{
+		PullRequest:  toPRRef(identifier),
+		Method:       mergeMethod,
+		DeleteBranch: deleteBranch,
+	}, nil)
+}
+
+func (p *Provider) UpdatePullRequestDescription(ctx context.Context, identifier domain.PRIdentifier, description string) error {
+	return p.call(ctx, MethodUpdateDescription, UpdateDescriptionParams{
+		PullRequest: toPRRef(identifier),
+		Description: description,
+	}, nil)
+}
@@ -0,0 +1,178 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func writePlugin(t *testing.T, dir, name, script string) Plugin {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return Plugin{Name: pluginName(name), Path: path}
+}
+
+func TestDiscover(t *testing.T) {
+	dir := t.TempDir()
+	writePlugin(t, dir, "lgtmfaster-provider-gerrit", "")
+	writePlugin(t, dir, "phabricator.sh", "")
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("not a plugin"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "lib"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	plugins, err := Discover(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, p := range plugins {
+		names = append(names, p.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"gerrit", "phabricator"}) {
+		t.Errorf("Discover() names = %v", names)
+	}
+
+	if _, err := Find(dir, "Gerrit"); err != nil {
+		t.Errorf("expected to find the gerrit plugin, got %v", err)
+	}
+	if _, err := Find(dir, "bitbucket"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	if plugins, err := Discover(filepath.Join(dir, "missing")); err != nil || len(plugins) != 0 {
+		t.Errorf("expected no plugins in a missing directory, got %v, %v", plugins, err)
+	}
+}
+
+func TestProvider_ListPullRequests(t *testing.T) {
+	dir := t.TempDir()
+	requestFile := filepath.Join(dir, "request.json")
+	p := writePlugin(t, dir, "gerrit", `cat > `+requestFile+`
+echo '{"result":[{"number":7,"title":"Fix login","repository":"core/auth","author":{"username":"ana"},"category":"review_requested"}]}'
+`)
+	provider := NewProvider(p, domain.PAT{Provider: "gerrit", Token: "secret", Username: "me", Organization: "review.example.com"})
+
+	prs, err := provider.ListPullRequests(context.Background(), "me")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 1 {
+		t.Fatalf("expected one PR, got %+v", prs)
+	}
+	pr := prs[0]
+	if pr.Number != 7 || pr.Repository.FullName != "core/auth" || pr.Repository.Owner != "core" || pr.Repository.Name != "auth" {
+		t.Errorf("unexpected PR: %+v", pr)
+	}
+	if pr.ProviderType != "gerrit" || pr.Status != domain.PRStatusOpen || pr.Category != domain.PRCategoryReviewRequested || pr.ApprovalStatus != domain.ApprovalStatusNone {
+		t.Errorf("unexpected PR state: %+v", pr)
+	}
+
+	data, err := os.ReadFile(requestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var req struct {
+		ProtocolVersion int         `json:"protocolVersion"`
+		Method          string      `json:"method"`
+		Credentials     Credentials `json:"credentials"`
+		Params          ListParams  `json:"params"`
+	}
+	if err := json.Unmarshal(data, &req); err != nil {
+		t.Fatalf("failed to decode request %s: %v", data, err)
+	}
+	if req.ProtocolVersion != ProtocolVersion || req.Method != MethodListPullRequests || req.Params.Username != "me" {
+		t.Errorf("unexpected request: %s", data)
+	}
+	if req.Credentials.Token != "secret" || req.Credentials.Organization != "review.example.com" {
+		t.Errorf("unexpected credentials: %+v", req.Credentials)
+	}
+}
+
+func TestProvider_SubmitReview(t *testing.T) {
+	dir := t.TempDir()
+	requestFile := filepath.Join(dir, "request.json")
+	p := writePlugin(t, dir, "gerrit", `cat > `+requestFile+`
+echo '{}'
+`)
+	provider := NewProvider(p, domain.PAT{Provider: "gerrit"})
+
+	err := provider.SubmitReview(context.Background(), domain.Review{
+		PRIdentifier: "core/auth/7",
+		Action:       domain.ReviewActionApprove,
+		Body:         "LGTM",
+		Comments:     []domain.Comment{{Body: "nit", FilePath: "main.go", Line: 3}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, _ := os.ReadFile(requestFile)
+	var req struct {
+		Params SubmitReviewParams `json:"params"`
+	}
+	if err := json.Unmarshal(data, &req); err != nil {
+		t.Fatal(err)
+	}
+	if req.Params.PullRequest != (PRRef{Repository: "core/auth", Number: 7}) || req.Params.Action != "approve" {
+		t.Errorf("unexpected params: %+v", req.Params)
+	}
+	if len(req.Params.Comments) != 1 || req.Params.Comments[0].FilePath != "main.go" || req.Params.Comments[0].Line != 3 {
+		t.Errorf("unexpected comments: %+v", req.Params.Comments)
+	}
+}
+
+func TestProvider_GetDiff(t *testing.T) {
+	p := writePlugin(t, t.TempDir(), "gerrit", `echo '{"result":[{"newPath":"a.go","oldPath":"a.go","hunks":[{"header":"@@ -1 +1 @@","lines":[{"type":"add","content":"+x","newLine":1}]}]},{"newPath":"logo.png","binary":true}]}'
+`)
+	provider := NewProvider(p, domain.PAT{Provider: "gerrit"})
+
+	diff, err := provider.GetDiff(context.Background(), domain.PRIdentifier{Repository: "core/auth", Number: 7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(diff.Files) != 2 {
+		t.Fatalf("expected two files, got %+v", diff.Files)
+	}
+	want := []domain.DiffLine{{Type: "add", Content: "+x", NewLine: 1}}
+	if !reflect.DeepEqual(diff.Files[0].Hunks[0].Lines, want) {
+		t.Errorf("unexpected lines: %+v", diff.Files[0].Hunks[0].Lines)
+	}
+	if diff.Files[1].Binary == nil || diff.Files[1].Binary.NewSize != -1 {
+		t.Errorf("expected a binary file of unknown size, got %+v", diff.Files[1].Binary)
+	}
+}
+
+func TestProvider_Errors(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{"error response", `echo '{"error":{"message":"not supported"}}'`, "gerrit: not supported"},
+		{"failing plugin", "echo 'cannot reach server' >&2\nexit 1", "gerrit plugin failed: cannot reach server"},
+		{"invalid output", "echo hello", "gerrit plugin returned an invalid response to validateCredentials"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := writePlugin(t, dir, "gerrit", tt.script+"\n")
+			err := NewProvider(p, domain.PAT{Provider: "gerrit"}).ValidateCredentials(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
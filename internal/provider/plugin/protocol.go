@@ -0,0 +1,261 @@
+package plugin
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+// ProtocolVersion is sent with every request. It is only increased for
+// changes that existing plugins cannot ignore, such as a renamed field.
+const ProtocolVersion = 1
+
+// Methods a plugin answers. A plugin that does not implement a method
+// answers with an error; the search method is optional.
+const (
+	MethodValidateCredentials = "validateCredentials"
+	MethodListPullRequests    = "listPullRequests"
+	MethodSearchPullRequests  = "searchPullRequests"
+	MethodGetPullRequest      = "getPullRequest"
+	MethodGetDiff             = "getDiff"
+	MethodGetComments         = "getComments"
+	MethodAddComment          = "addComment"
+	MethodSubmitReview        = "submitReview"
+	MethodMergePullRequest    = "mergePullRequest"
+	MethodUpdateDescription   = "updateDescription"
+)
+
+// Request is written to the plugin's stdin as a single JSON document.
+type Request struct {
+	ProtocolVersion int         `json:"protocolVersion"`
+	Method          string      `json:"method"`
+	Credentials     Credentials `json:"credentials"`
+	Params          any         `json:"params,omitempty"`
+}
+
+// Credentials are the fields of the PAT the plugin was selected by.
+type Credentials struct {
+	Token        string   `json:"token,omitempty"`
+	Username     string   `json:"username,omitempty"`
+	Organization string   `json:"organization,omitempty"`
+	Repositories []string `json:"repositories,omitempty"`
+}
+
+// Response is read from the plugin's stdout. Exactly one of Result and
+// Error is expected.
+type Response struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *ResponseError  `json:"error,omitempty"`
+}
+
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+type PRRef struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+}
+
+type ListParams struct {
+	Username string `json:"username"`
+	Query    string `json:"query,omitempty"`
+}
+
+type AddCommentParams struct {
+	PullRequest PRRef  `json:"pullRequest"`
+	Body        string `json:"body"`
+	FilePath    string `json:"filePath,omitempty"`
+	Line        int    `json:"line,omitempty"`
+	// Side is "LEFT" for a line of the old file (a deleted line) and
+	// "RIGHT" for one of the new file.
+	Side string `json:"side,omitempty"`
+}
+
+type SubmitReviewParams struct {
+	PullRequest PRRef     `json:"pullRequest"`
+	Action      string    `json:"action"`
+	Body        string    `json:"body"`
+	Comments    []Comment `json:"comments,omitempty"`
+}
+
+type MergeParams struct {
+	PullRequest  PRRef  `json:"pullRequest"`
+	Method       string `json:"method"`
+	DeleteBranch bool   `json:"deleteBranch"`
+}
+
+type UpdateDescriptionParams struct {
+	PullRequest PRRef  `json:"pullRequest"`
+	Description string `json:"description"`
+}
+
+type User struct {
+	ID       string `json:"id,omitempty"`
+	Username string `json:"username"`
+	Email    string `json:"email,omitempty"`
+}
+
+type PullRequest struct {
+	ID           string    `json:"id,omitempty"`
+	Number       int       `json:"number"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description,omitempty"`
+	Author       User      `json:"author"`
+	Repository   string    `json:"repository"`
+	URL          string    `json:"url,omitempty"`
+	SourceBranch string    `json:"sourceBranch,omitempty"`
+	TargetBranch string    `json:"targetBranch,omitempty"`
+	Status       string    `json:"status,omitempty"`
+	Category     string    `json:"category,omitempty"`
+	Approval     string    `json:"approval,omitempty"`
+	IsDraft      bool      `json:"isDraft,omitempty"`
+	Mergeable    bool      `json:"mergeable,omitempty"`
+	Labels       []string  `json:"labels,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+type DiffLine struct {
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	OldLine int    `json:"oldLine,omitempty"`
+	NewLine int    `json:"newLine,omitempty"`
+}
+
+type DiffHunk struct {
+	Header string     `json:"header"`
+	Lines  []DiffLine `json:"lines"`
+}
+
+type FileDiff struct {
+	OldPath   string     `json:"oldPath,omitempty"`
+	NewPath   string     `json:"newPath,omitempty"`
+	IsNew     bool       `json:"isNew,omitempty"`
+	IsDeleted bool       `json:"isDeleted,omitempty"`
+	IsRenamed bool       `json:"isRenamed,omitempty"`
+	Binary    bool       `json:"binary,omitempty"`
+	Hunks     []DiffHunk `json:"hunks,omitempty"`
+}
+
+type Comment struct {
+	ID        string    `json:"id,omitempty"`
+	ThreadID  string    `json:"threadId,omitempty"`
+	Author    User      `json:"author"`
+	Body      string    `json:"body"`
+	FilePath  string    `json:"filePath,omitempty"`
+	Line      int       `json:"line,omitempty"`
+	Side      string    `json:"side,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func toPRRef(identifier domain.PRIdentifier) PRRef {
+	return PRRef{Repository: identifier.Repository, Number: identifier.Number}
+}
+
+// parsePRRef splits the "repository/number" form used by domain.Review.
+func parsePRRef(identifier string) PRRef {
+	i := strings.LastIndex(identifier, "/")
+	if i < 0 {
+		return PRRef{Repository: identifier}
+	}
+	number, _ := strconv.Atoi(identifier[i+1:])
+	return PRRef{Repository: identifier[:i], Number: number}
+}
+
+func (u User) toDomain() domain.User {
+	return domain.User{ID: u.ID, Username: u.Username, Email: u.Email}
+}
+
+func (pr PullRequest) toDomain(providerType domain.ProviderType) domain.PullRequest {
+	owner, name, found := strings.Cut(pr.Repository, "/")
+	if !found {
+		name = pr.Repository
+	}
+	status := domain.PRStatus(pr.Status)
+	if status == "" {
+		status = domain.PRStatusOpen
+	}
+	category := domain.PRCategory(pr.Category)
+	if category == "" {
+		category = domain.PRCategoryOther
+	}
+	approval := domain.ApprovalStatus(pr.Approval)
+	if approval == "" {
+		approval = domain.ApprovalStatusNone
+	}
+
+	return domain.PullRequest{
+		ID:             pr.ID,
+		Number:         pr.Number,
+		Title:          pr.Title,
+		Description:    pr.Description,
+		Author:         pr.Author.toDomain(),
+		Repository:     domain.Repo{Name: name, FullName: pr.Repository, Owner: owner},
+		SourceBranch:   pr.SourceBranch,
+		TargetBranch:   pr.TargetBranch,
+		Status:         status,
+		Category:       category,
+		ApprovalStatus: approval,
+		CreatedAt:      pr.CreatedAt,
+		UpdatedAt:      pr.UpdatedAt,
+		URL:            pr.URL,
+		IsDraft:        pr.IsDraft,
+		Mergeable:      pr.Mergeable,
+		Labels:         pr.Labels,
+		ProviderType:   providerType,
+	}
+}
+
+func (f FileDiff) toDomain() domain.FileDiff {
+	file := domain.FileDiff{
+		OldPath:   f.OldPath,
+		NewPath:   f.NewPath,
+		IsNew:     f.IsNew,
+		IsDeleted: f.IsDeleted,
+		IsRenamed: f.IsRenamed,
+	}
+	if f.Binary {
+		file.Binary = &domain.BinaryInfo{OldSize: -1, NewSize: -1}
+	}
+	for _, hunk := range f.Hunks {
+		lines := make([]domain.DiffLine, 0, len(hunk.Lines))
+		for _, line := range hunk.Lines {
+			lines = append(lines, domain.DiffLine(line))
+		}
+		file.Hunks = append(file.Hunks, domain.DiffHunk{Header: hunk.Header, Lines: lines})
+	}
+	return file
+}
+
+func (c Comment) toDomain() domain.Comment {
+	return domain.Comment{
+		ID:        c.ID,
+		ThreadID:  c.ThreadID,
+		Author:    c.Author.toDomain(),
+		Body:      c.Body,
+		FilePath:  c.FilePath,
+		Line:      c.Line,
+		Side:      c.Side,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func fromDomainComment(c domain.Comment) Comment {
+	return Comment{
+		ID:        c.ID,
+		ThreadID:  c.ThreadID,
+		Author:    User{ID: c.Author.ID, Username: c.Author.Username, Email: c.Author.Email},
+		Body:      c.Body,
+		FilePath:  c.FilePath,
+		Line:      c.Line,
+		Side:      c.Side,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
@@ -0,0 +1,343 @@
+// Package spell flags misspelled words in comment text and suggests
+// corrections, using a plain wordlist or a hunspell dictionary.
+package spell
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// DefaultPaths are the dictionaries tried, in order, when none is configured.
+var DefaultPaths = []string{
+	"/usr/share/hunspell/en_US.dic",
+	"/usr/share/myspell/en_US.dic",
+	"/usr/share/myspell/dicts/en_US.dic",
+	"~/Library/Spelling/en_US.dic",
+	"/usr/share/dict/words",
+	"/usr/dict/words",
+}
+
+// ErrNoDictionary is returned by Load when no dictionary path is configured
+// and none of DefaultPaths exists.
+var ErrNoDictionary = errors.New("no dictionary found: set settings.SpellCheck.Dictionary to a wordlist or hunspell .dic file")
+
+// techWords are accepted on top of the dictionary because they are common in
+// code review but missing from general-purpose wordlists.
+var techWords = []string{
+	"api", "apis", "args", "async", "auth", "backend", "backport", "bool", "boolean", "btw",
+	"changelog", "cli", "codebase", "config", "configs", "const", "dedupe", "dev", "enum",
+	"env", "frontend", "func", "getter", "goroutine", "goroutines", "hardcode", "hardcoded",
+	"http", "https", "iirc", "imo", "impl", "init", "json", "lgtm", "linter", "lookup",
+	"middleware", "mutex", "namespace", "nit", "nits", "nullable", "param", "params", "prod",
+	"ptal", "readme", "rebase", "refactor", "refactoring", "regex", "repo", "repos", "runtime",
+	"setter", "stderr", "stdin", "stdout", "struct", "structs", "tbd", "timestamp", "todo",
+	"typo", "typos", "ui", "unmarshal", "url", "urls", "webhook", "wip", "workflow", "yaml",
+}
+
+// Dictionary is a set of correctly spelled words. Lookups ignore case.
+type Dictionary struct {
+	words map[string]struct{}
+}
+
+// New returns a dictionary of words and the built-in technical terms.
+func New(words ...string) *Dictionary {
+	d := &Dictionary{words: make(map[string]struct{}, len(words)+len(techWords))}
+	for _, word := range techWords {
+		d.Add(word)
+	}
+	for _, word := range words {
+		d.Add(word)
+	}
+	return d
+}
+
+// Load reads the dictionary at path, or the first of DefaultPaths that
+// exists when path is empty.
+func Load(path string) (*Dictionary, error) {
+	if path != "" {
+		return loadFile(expandHome(path))
+	}
+	for _, candidate := range DefaultPaths {
+		candidate = expandHome(candidate)
+		if _, err := os.Stat(candidate); err == nil {
+			return loadFile(candidate)
+		}
+	}
+	return nil, ErrNoDictionary
+}
+
+// loadFile reads a wordlist with one word per line. Hunspell .dic files are
+// read as well: the leading word count and the "/FLAGS" suffixes are
+// ignored, so affixed forms are only recognized through the few common
+// suffixes Correct knows about.
+func loadFile(path string) (*Dictionary, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dictionary: %w", err)
+	}
+	defer file.Close()
+
+	d := New()
+	scanner := bufio.NewScanner(file)
+	first := true
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if first {
+			first = false
+			if isCount(line) {
+				continue
+			}
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word, _, _ := strings.Cut(line, "/")
+		if fields := strings.Fields(word); len(fields) > 0 {
+			d.Add(fields[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dictionary %s: %w", path, err)
+	}
+	return d, nil
+}
+
+func isCount(line string) bool {
+	if line == "" {
+		return false
+	}
+	for _, r := range line {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// Add accepts word as correctly spelled.
+func (d *Dictionary) Add(word string) {
+	if word = normalize(word); word != "" {
+		d.words[word] = struct{}{}
+	}
+}
+
+func (d *Dictionary) has(word string) bool {
+	_, ok := d.words[word]
+	return ok
+}
+
+// Correct reports whether word is in the dictionary, directly or as a
+// common inflection or prefixed form of a word that is.
+func (d *Dictionary) Correct(word string) bool {
+	word = normalize(word)
+	if word == "" || d.has(word) {
+		return true
+	}
+	word = strings.TrimSuffix(word, "'s")
+	if d.has(word) || d.hasInflected(word) {
+		return true
+	}
+	for _, prefix := range []string{"un", "re", "non", "pre"} {
+		if base, ok := strings.CutPrefix(word, prefix); ok && len(base) > 2 {
+			if d.has(base) || d.hasInflected(base) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasInflected reports whether word is a plural, past tense, gerund,
+// adverb or comparative of a dictionary word.
+func (d *Dictionary) hasInflected(word string) bool {
+	for _, rule := range suffixRules {
+		base, ok := strings.CutSuffix(word, rule.suffix)
+		if !ok || len(base) < 2 {
+			continue
+		}
+		if d.has(base + rule.replacement) {
+			return true
+		}
+		// "stopped", "running": the final consonant is doubled.
+		if rule.doubled && len(base) > 2 && base[len(base)-1] == base[len(base)-2] && d.has(base[:len(base)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+var suffixRules = []struct {
+	suffix      string
+	replacement string
+	doubled     bool
+}{
+	{suffix: "s"},
+	{suffix: "es"},
+	{suffix: "ies", replacement: "y"},
+	{suffix: "ed", doubled: true},
+	{suffix: "ed", replacement: "e"},
+	{suffix: "ied", replacement: "y"},
+	{suffix: "ing", doubled: true},
+	{suffix: "ing", replacement: "e"},
+	{suffix: "ly"},
+	{suffix: "ily", replacement: "y"},
+	{suffix: "er", doubled: true},
+	{suffix: "er", replacement: "e"},
+	{suffix: "est", doubled: true},
+	{suffix: "est", replacement: "e"},
+	{suffix: "ness"},
+	{suffix: "ment"},
+	{suffix: "able"},
+}
+
+// normalize lowercases word and uses a plain apostrophe.
+func normalize(word string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(word), "’", "'"))
+}
+
+// Misspelling is a word of the checked text that is not in the dictionary.
+// Line and Column are zero-based; Column counts runes.
+type Misspelling struct {
+	Word   string
+	Line   int
+	Column int
+}
+
+// Check returns the misspelled words of a markdown comment. Fenced code
+// blocks, inline code, URLs, paths, identifiers, mentions, acronyms and
+// words containing digits are skipped.
+func (d *Dictionary) Check(text string) []Misspelling {
+	var misspellings []Misspelling
+	inFence := false
+	for lineIndex, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		for _, w := range words(line) {
+			if !d.Correct(w.text) {
+				misspellings = append(misspellings, Misspelling{Word: w.text, Line: lineIndex, Column: w.column})
+			}
+		}
+	}
+	return misspellings
+}
+
+type word struct {
+	text   string
+	column int
+}
+
+// words splits line into the words worth checking.
+func words(line string) []word {
+	runes := blankCodeSpans([]rune(line))
+
+	var result []word
+	for start := 0; start < len(runes); {
+		if unicode.IsSpace(runes[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(runes) && !unicode.IsSpace(runes[end]) {
+			end++
+		}
+		result = append(result, fieldWords(runes, start, end)...)
+		start = end
+	}
+	return result
+}
+
+// fieldWords returns the words of the whitespace-delimited field
+// runes[start:end], or nothing when the field looks like code, a link or a
+// reference rather than prose.
+func fieldWords(runes []rune, start, end int) []word {
+	for start < end && !unicode.IsLetter(runes[start]) {
+		start++
+	}
+	for end > start && !unicode.IsLetter(runes[end-1]) {
+		end--
+	}
+	if start == end {
+		return nil
+	}
+	if start > 0 && (runes[start-1] == '@' || runes[start-1] == '#' || runes[start-1] == '$') {
+		return nil
+	}
+	for _, r := range runes[start:end] {
+		if !unicode.IsLetter(r) && !isApostrophe(r) && r != '-' {
+			return nil
+		}
+	}
+
+	var result []word
+	for partStart := start; partStart < end; {
+		partEnd := partStart
+		for partEnd < end && runes[partEnd] != '-' {
+			partEnd++
+		}
+		if part := runes[partStart:partEnd]; len(part) > 1 && isProse(part) {
+			result = append(result, word{text: string(part), column: partStart})
+		}
+		partStart = partEnd + 1
+	}
+	return result
+}
+
+// isProse rejects acronyms ("HTTP") and identifiers ("camelCase"), which
+// have upper case letters after the first.
+func isProse(part []rune) bool {
+	for _, r := range part[1:] {
+		if unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return unicode.IsLetter(part[0]) && unicode.IsLetter(part[len(part)-1])
+}
+
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’'
+}
+
+// blankCodeSpans replaces `inline code`, including its backticks, with
+// spaces so that it is not checked while columns stay the same.
+func blankCodeSpans(runes []rune) []rune {
+	out := append([]rune(nil), runes...)
+	for i := 0; i < len(out); i++ {
+		if out[i] != '`' {
+			continue
+		}
+		closing := -1
+		for j := i + 1; j < len(out); j++ {
+			if out[j] == '`' {
+				closing = j
+				break
+			}
+		}
+		if closing < 0 {
+			break
+		}
+		for j := i; j <= closing; j++ {
+			out[j] = ' '
+		}
+		i = closing
+	}
+	return out
+}
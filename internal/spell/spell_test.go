@@ -0,0 +1,104 @@
+package spell
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoad_Hunspell(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "en_US.dic")
+	content := "3\nhello/MS\nworld\t po:noun\nreview/SDG\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	for _, word := range []string{"hello", "World", "reviews", "reviewed", "reviewing"} {
+		if !d.Correct(word) {
+			t.Errorf("Correct(%q) = false, want true", word)
+		}
+	}
+	if d.Correct("helo") {
+		t.Error("Correct(helo) = true, want false")
+	}
+}
+
+func TestLoad_Wordlist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words")
+	if err := os.WriteFile(path, []byte("# comment\nstop\nhappy\nfix\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	for _, word := range []string{"stopped", "happily", "fixes", "unhappy", "refix", "stop's"} {
+		if !d.Correct(word) {
+			t.Errorf("Correct(%q) = false, want true", word)
+		}
+	}
+	if d.Correct("comment") {
+		t.Error("comment lines should be skipped")
+	}
+}
+
+func TestLoad_NoDictionary(t *testing.T) {
+	saved := DefaultPaths
+	DefaultPaths = []string{filepath.Join(t.TempDir(), "missing.dic")}
+	t.Cleanup(func() { DefaultPaths = saved })
+
+	if _, err := Load(""); !errors.Is(err, ErrNoDictionary) {
+		t.Errorf("Load(\"\") error = %v, want ErrNoDictionary", err)
+	}
+	if _, err := Load(DefaultPaths[0]); err == nil {
+		t.Error("Load of a missing file should fail")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	d := New("this", "is", "a", "the", "for", "loop", "see", "and", "well", "known", "don't")
+
+	text := "This is teh loop, see `fxi` and @someone.\n" +
+		"```go\nfor qqq := range x {}\n```\n" +
+		"Wel-known HTTP getUser foo_bar v2 https://exmaple.com #123 don’t"
+
+	got := d.Check(text)
+	want := []Misspelling{
+		{Word: "teh", Line: 0, Column: 8},
+		{Word: "Wel", Line: 4, Column: 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Check() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCheck_ColumnsCountRunes(t *testing.T) {
+	d := New("café", "is", "open")
+
+	got := d.Check("café is opne")
+	want := []Misspelling{{Word: "opne", Line: 0, Column: 8}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Check() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	d := New("the", "then", "they", "tea", "test", "text", "best")
+
+	if got := d.Suggest("teh", 2); !reflect.DeepEqual(got, []string{"tea", "the"}) {
+		t.Errorf("Suggest(teh) = %v", got)
+	}
+	if got := d.Suggest("Tset", 2); !reflect.DeepEqual(got, []string{"Test", "Text"}) {
+		t.Errorf("Suggest(Tset) = %v", got)
+	}
+	if got := d.Suggest("zzzzzz", 3); len(got) != 0 {
+		t.Errorf("Suggest(zzzzzz) = %v, want none", got)
+	}
+}
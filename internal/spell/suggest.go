@@ -0,0 +1,105 @@
+package spell
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+const alphabet = "abcdefghijklmnopqrstuvwxyz"
+
+// Suggest returns up to limit dictionary words within two edits of word
+// (deletions, insertions, substitutions or swaps of adjacent letters).
+// Single-edit corrections come first, then words that keep the first letter
+// and the length of word. A capitalized word gets capitalized suggestions.
+func (d *Dictionary) Suggest(word string, limit int) []string {
+	lower := normalize(word)
+	if lower == "" || limit <= 0 {
+		return nil
+	}
+
+	distance := make(map[string]int)
+	first := edits(lower)
+	for _, candidate := range first {
+		if _, seen := distance[candidate]; !seen && d.Correct(candidate) {
+			distance[candidate] = 1
+		}
+	}
+	// Only exact dictionary words are accepted two edits away, which keeps
+	// the much larger second round cheap.
+	if len(distance) < limit {
+		for _, edit := range first {
+			for _, candidate := range edits(edit) {
+				if _, seen := distance[candidate]; !seen && d.has(candidate) {
+					distance[candidate] = 2
+				}
+			}
+		}
+	}
+	delete(distance, lower)
+
+	suggestions := make([]string, 0, len(distance))
+	for candidate := range distance {
+		suggestions = append(suggestions, candidate)
+	}
+	firstRune, _ := utf8.DecodeRuneInString(lower)
+	rank := func(candidate string) (int, int, int) {
+		sameStart := 1
+		if r, _ := utf8.DecodeRuneInString(candidate); r == firstRune {
+			sameStart = 0
+		}
+		lengthDiff := len(candidate) - len(lower)
+		if lengthDiff < 0 {
+			lengthDiff = -lengthDiff
+		}
+		return distance[candidate], sameStart, lengthDiff
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		di, si, li := rank(suggestions[i])
+		dj, sj, lj := rank(suggestions[j])
+		switch {
+		case di != dj:
+			return di < dj
+		case si != sj:
+			return si < sj
+		case li != lj:
+			return li < lj
+		}
+		return suggestions[i] < suggestions[j]
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	if r, _ := utf8.DecodeRuneInString(word); unicode.IsUpper(r) {
+		for i, suggestion := range suggestions {
+			r, size := utf8.DecodeRuneInString(suggestion)
+			suggestions[i] = string(unicode.ToUpper(r)) + suggestion[size:]
+		}
+	}
+	return suggestions
+}
+
+// edits returns every string one edit away from word.
+func edits(word string) []string {
+	runes := []rune(word)
+	result := make([]string, 0, len(runes)*(2*len(alphabet)+2)+len(alphabet))
+	for i := range len(runes) + 1 {
+		left, right := string(runes[:i]), string(runes[i:])
+		if i < len(runes) {
+			result = append(result, left+string(runes[i+1:]))
+			if i+1 < len(runes) {
+				result = append(result, left+string(runes[i+1])+string(runes[i])+string(runes[i+2:]))
+			}
+			for _, r := range alphabet {
+				if r != runes[i] {
+					result = append(result, left+string(r)+string(runes[i+1:]))
+				}
+			}
+		}
+		for _, r := range alphabet {
+			result = append(result, left+string(r)+right)
+		}
+	}
+	return result
+}
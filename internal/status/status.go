@@ -0,0 +1,148 @@
+// Package status implements `lgtmfaster status`, a summary of the PRs
+// waiting on you for embedding in tmux status lines or shell prompts. It
+// reads the PR lists cached by the last run of the application and never
+// contacts a provider, so it returns in milliseconds.
+package status
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+// Store is the part of the local repository the summary is read from.
+type Store interface {
+	GetSelectedPATs() ([]domain.PAT, error)
+	GetCachedPRGroup(patID string) (*domain.CachedPRGroup, error)
+	ListSnoozes() ([]domain.PRSnooze, error)
+}
+
+// Summary counts the cached PRs of the selected PATs.
+type Summary struct {
+	// NeedReview counts the PRs your review is requested on.
+	NeedReview int
+	// ChangesRequested counts your PRs with changes requested.
+	ChangesRequested int
+	// Approved counts your PRs that are approved.
+	Approved int
+	// FetchedAt is when the oldest of the cached lists was loaded; zero
+	// when nothing is cached.
+	FetchedAt time.Time
+}
+
+// Summarize counts the PRs of the lists cached for the selected PATs,
+// leaving out snoozed PRs as the PR list does. A PR listed by several PATs
+// is counted once.
+func Summarize(store Store, now time.Time) (Summary, error) {
+	var summary Summary
+
+	pats, err := store.GetSelectedPATs()
+	if err != nil {
+		return summary, fmt.Errorf("failed to load PATs: %w", err)
+	}
+	snoozes, err := store.ListSnoozes()
+	if err != nil {
+		return summary, fmt.Errorf("failed to load snoozes: %w", err)
+	}
+
+	snoozed := make(map[string]domain.PRSnooze, len(snoozes))
+	for _, snooze := range snoozes {
+		snoozed[snooze.PRIdentifier] = snooze
+	}
+
+	seen := make(map[string]bool)
+	for _, pat := range pats {
+		cached, err := store.GetCachedPRGroup(pat.ID)
+		if err != nil {
+			return summary, fmt.Errorf("failed to read the PRs cached for %s: %w", pat.Name, err)
+		}
+		if cached == nil {
+			continue
+		}
+		if summary.FetchedAt.IsZero() || cached.FetchedAt.Before(summary.FetchedAt) {
+			summary.FetchedAt = cached.FetchedAt
+		}
+
+		for _, pr := range cached.Group.PRs {
+			if seen[pr.Key()] {
+				continue
+			}
+			seen[pr.Key()] = true
+			if snooze, ok := snoozed[pr.Key()]; ok && snooze.IsActive(pr, now) {
+				continue
+			}
+
+			switch pr.Category {
+			case domain.PRCategoryReviewRequested:
+				summary.NeedReview++
+			case domain.PRCategoryAuthored:
+				switch pr.ApprovalStatus {
+				case domain.ApprovalStatusChangesRequested:
+					summary.ChangesRequested++
+				case domain.ApprovalStatusApproved:
+					summary.Approved++
+				}
+			}
+		}
+	}
+	return summary, nil
+}
+
+// Short renders the summary on one line, such as "3 need review, 1 changes
+// requested", leaving out zero counts. It is empty when nothing waits on
+// you, so that prompts hide it.
+func (s Summary) Short() string {
+	var parts []string
+	if s.NeedReview > 0 {
+		parts = append(parts, fmt.Sprintf("%d need review", s.NeedReview))
+	}
+	if s.ChangesRequested > 0 {
+		parts = append(parts, fmt.Sprintf("%d changes requested", s.ChangesRequested))
+	}
+	if s.Approved > 0 {
+		parts = append(parts, fmt.Sprintf("%d approved", s.Approved))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Long renders the summary with every count and the age of the data.
+func (s Summary) Long(now time.Time) string {
+	if s.FetchedAt.IsZero() {
+		return "No cached PRs; start lgtmfaster to load them\n"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Need review:       %d\n", s.NeedReview)
+	fmt.Fprintf(&b, "Changes requested: %d\n", s.ChangesRequested)
+	fmt.Fprintf(&b, "Approved:          %d\n", s.Approved)
+	fmt.Fprintf(&b, "As of %s ago\n", now.Sub(s.FetchedAt).Round(time.Minute))
+	return b.String()
+}
+
+// Run runs `lgtmfaster status` with the arguments following "status",
+// writing the summary to out.
+func Run(args []string, store Store, out io.Writer) error {
+	flags := flag.NewFlagSet("status", flag.ContinueOnError)
+	flags.SetOutput(out)
+	short := flags.Bool("short", false, "print a one-line summary for status lines and prompts")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	summary, err := Summarize(store, now)
+	if err != nil {
+		return err
+	}
+	if *short {
+		if line := summary.Short(); line != "" {
+			fmt.Fprintln(out, line)
+		}
+		return nil
+	}
+	_, err = io.WriteString(out, summary.Long(now))
+	return err
+}
@@ -0,0 +1,94 @@
+package status
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/storage"
+)
+
+var _ Store = (*storage.LocalRepository)(nil)
+
+type fakeStore struct {
+	pats    []domain.PAT
+	groups  map[string]*domain.CachedPRGroup
+	snoozes []domain.PRSnooze
+}
+
+func (s *fakeStore) GetSelectedPATs() ([]domain.PAT, error) {
+	return s.pats, nil
+}
+
+func (s *fakeStore) GetCachedPRGroup(patID string) (*domain.CachedPRGroup, error) {
+	return s.groups[patID], nil
+}
+
+func (s *fakeStore) ListSnoozes() ([]domain.PRSnooze, error) {
+	return s.snoozes, nil
+}
+
+func testPR(number int, category domain.PRCategory, status domain.ApprovalStatus) domain.PullRequest {
+	return domain.PullRequest{
+		Number:         number,
+		ProviderType:   domain.ProviderGitHub,
+		Repository:     domain.Repo{FullName: "acme/api"},
+		Category:       category,
+		ApprovalStatus: status,
+	}
+}
+
+func TestSummarize_CountsCachedPRsOnce(t *testing.T) {
+	now := time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC)
+	requested := testPR(1, domain.PRCategoryReviewRequested, domain.ApprovalStatusPending)
+	store := &fakeStore{
+		pats: []domain.PAT{{ID: "work"}, {ID: "oss"}, {ID: "uncached"}},
+		groups: map[string]*domain.CachedPRGroup{
+			"work": {FetchedAt: now.Add(-5 * time.Minute), Group: domain.PRGroup{PRs: []domain.PullRequest{
+				requested,
+				testPR(2, domain.PRCategoryReviewRequested, domain.ApprovalStatusPending),
+				testPR(3, domain.PRCategoryAuthored, domain.ApprovalStatusChangesRequested),
+				testPR(4, domain.PRCategoryAuthored, domain.ApprovalStatusApproved),
+				testPR(5, domain.PRCategoryReviewRequested, domain.ApprovalStatusPending),
+			}}},
+			"oss": {FetchedAt: now.Add(-20 * time.Minute), Group: domain.PRGroup{PRs: []domain.PullRequest{
+				requested,
+				testPR(6, domain.PRCategoryOther, domain.ApprovalStatusChangesRequested),
+			}}},
+		},
+		snoozes: []domain.PRSnooze{{PRIdentifier: testPR(5, "", "").Key(), Until: now.Add(time.Hour)}},
+	}
+
+	summary, err := Summarize(store, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Summary{NeedReview: 2, ChangesRequested: 1, Approved: 1, FetchedAt: now.Add(-20 * time.Minute)}
+	if summary != want {
+		t.Errorf("expected %+v, got %+v", want, summary)
+	}
+	if got := summary.Short(); got != "2 need review, 1 changes requested, 1 approved" {
+		t.Errorf("unexpected short summary %q", got)
+	}
+}
+
+func TestRun_ShortPrintsNothingWhenNothingWaits(t *testing.T) {
+	store := &fakeStore{pats: []domain.PAT{{ID: "work"}}}
+
+	var out bytes.Buffer
+	if err := Run([]string{"--short"}, store, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no output, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := Run(nil, store, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "No cached PRs; start lgtmfaster to load them\n" {
+		t.Errorf("unexpected long summary %q", out.String())
+	}
+}
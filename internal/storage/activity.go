@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// activityFile holds the activity log next to the config file, shared by all
+// profiles like the offline cache, so that config.json does not grow with
+// every review.
+const activityFile = "activity.json"
+
+const activityRetention = 90 * 24 * time.Hour
+
+func (r *LocalRepository) activityPath() string {
+	return filepath.Join(r.dir, activityFile)
+}
+
+// readActivity returns the events in the activity file, or none when there
+// is no file yet.
+func (r *LocalRepository) readActivity() ([]domain.ActivityEvent, error) {
+	path := r.activityPath()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read activity: %w", err)
+	}
+	var events []domain.ActivityEvent
+	if err := json.Unmarshal(data, &events); err != nil {
+		logger.LogError("ACTIVITY_UNMARSHAL", path, err)
+		return nil, fmt.Errorf("failed to decode activity: %w", err)
+	}
+	return events, nil
+}
+
+// RecordActivity appends event to the activity file and drops the events
+// older than activityRetention. Events that earlier versions kept in the
+// config file are moved to the activity file along the way.
+func (r *LocalRepository) RecordActivity(event domain.ActivityEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.activityMu.Lock()
+	defer r.activityMu.Unlock()
+
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	path := r.activityPath()
+	unlock, err := lockConfig(path, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	events, err := r.readActivity()
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-activityRetention)
+	kept := make([]domain.ActivityEvent, 0, len(r.config.Activity)+len(events)+1)
+	for _, e := range append(append(kept, r.config.Activity...), events...) {
+		if e.Timestamp.After(cutoff) {
+			kept = append(kept, e)
+		}
+	}
+	kept = append(kept, event)
+
+	data, err := json.Marshal(kept)
+	if err != nil {
+		return fmt.Errorf("failed to encode activity: %w", err)
+	}
+	logger.Log("Recording activity: %s on %s (Provider: %s)", event.Type, event.PRIdentifier, event.Provider)
+	if err := writeFileAtomic(path, data); err != nil {
+		logger.LogError("ACTIVITY_SAVE", path, err)
+		return err
+	}
+
+	if len(r.config.Activity) > 0 {
+		logger.Log("Moved %d activity events from %s to %s", len(r.config.Activity), r.configPath, path)
+		r.config.Activity = nil
+		return r.save()
+	}
+	return nil
+}
+
+// ListActivity returns the events recorded since the given time, including
+// those earlier versions kept in the config file.
+func (r *LocalRepository) ListActivity(since time.Time) ([]domain.ActivityEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	r.activityMu.Lock()
+	defer r.activityMu.Unlock()
+
+	unlock, err := lockConfig(r.activityPath(), false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	events, err := r.readActivity()
+	if err != nil {
+		return nil, err
+	}
+
+	all := append(append([]domain.ActivityEvent{}, r.config.Activity...), events...)
+	listed := make([]domain.ActivityEvent, 0, len(all))
+	for _, e := range all {
+		if !e.Timestamp.Before(since) {
+			listed = append(listed, e)
+		}
+	}
+	return listed, nil
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestRecordAndListActivity(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", oldHome)
+
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	now := time.Now()
+	events := []domain.ActivityEvent{
+		{Type: domain.ActivityReviewSubmitted, Provider: domain.ProviderGitHub, PRIdentifier: "owner/repo#1", Comments: 2, Timestamp: now.Add(-48 * time.Hour)},
+		{Type: domain.ActivityPRMerged, Provider: domain.ProviderAzureDevOps, PRIdentifier: "project/repo#7", Timestamp: now.Add(-time.Hour)},
+	}
+	for _, e := range events {
+		if err := repo.RecordActivity(e); err != nil {
+			t.Fatalf("Failed to record activity: %v", err)
+		}
+	}
+
+	reloaded, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to reload repository: %v", err)
+	}
+
+	all, err := reloaded.ListActivity(time.Time{})
+	if err != nil {
+		t.Fatalf("Failed to list activity: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("Expected 2 events after reload, got %d", len(all))
+	}
+	if all[0].Comments != 2 {
+		t.Errorf("Expected 2 comments on first event, got %d", all[0].Comments)
+	}
+
+	recent, err := reloaded.ListActivity(now.Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to list activity: %v", err)
+	}
+	if len(recent) != 1 || recent[0].Type != domain.ActivityPRMerged {
+		t.Errorf("Expected only the merge event in the last day, got %+v", recent)
+	}
+}
+
+func TestRecordActivity_PrunesExpiredEvents(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", oldHome)
+
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	old := domain.ActivityEvent{Type: domain.ActivityReviewSubmitted, Timestamp: time.Now().Add(-activityRetention - time.Hour)}
+	if err := repo.RecordActivity(old); err != nil {
+		t.Fatalf("Failed to record activity: %v", err)
+	}
+	if err := repo.RecordActivity(domain.ActivityEvent{Type: domain.ActivityPRMerged}); err != nil {
+		t.Fatalf("Failed to record activity: %v", err)
+	}
+
+	events, _ := repo.ListActivity(time.Time{})
+	if len(events) != 1 {
+		t.Fatalf("Expected expired event to be pruned, got %d events", len(events))
+	}
+	if events[0].Timestamp.IsZero() {
+		t.Error("Expected missing timestamp to be filled in")
+	}
+}
+
+func TestRecordActivity_KeepsActivityOutOfTheConfigFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(ProfileEnv, "")
+
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	// Written by an earlier version, which kept the activity in config.json.
+	legacy := domain.ActivityEvent{Type: domain.ActivityReviewSubmitted, PRIdentifier: "owner/repo#1", Timestamp: time.Now().Add(-time.Hour)}
+	repo.config.Activity = []domain.ActivityEvent{legacy}
+	if err := repo.save(); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	if err := repo.RecordActivity(domain.ActivityEvent{Type: domain.ActivityPRMerged, PRIdentifier: "owner/repo#2"}); err != nil {
+		t.Fatalf("Failed to record activity: %v", err)
+	}
+
+	data, err := os.ReadFile(repo.configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if strings.Contains(string(data), "owner/repo#") {
+		t.Errorf("Expected no activity in config.json, got %s", data)
+	}
+	if _, err := os.Stat(filepath.Join(repo.dir, activityFile)); err != nil {
+		t.Errorf("Expected the activity in its own file: %v", err)
+	}
+
+	events, err := repo.ListActivity(time.Time{})
+	if err != nil {
+		t.Fatalf("Failed to list activity: %v", err)
+	}
+	if len(events) != 2 || events[0].PRIdentifier != "owner/repo#1" || events[1].PRIdentifier != "owner/repo#2" {
+		t.Errorf("Expected the earlier event to be kept ahead of the new one, got %+v", events)
+	}
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// Every save first copies the config file it replaces to config.json.bak,
+// as long as that file is valid, so that a config file that gets truncated
+// or corrupted anyway, for example by a crash or a bad hand edit, can be
+// restored from the last good version.
+const backupSuffix = ".bak"
+
+// backupConfig copies the config file at path to its backup unless it is
+// missing, not valid JSON or the same as data, which is about to replace it.
+func backupConfig(path string, data []byte) {
+	current, err := os.ReadFile(path)
+	if err != nil || !json.Valid(current) || string(current) == string(data) {
+		return
+	}
+	backupPath := path + backupSuffix
+	logger.LogFileWrite(backupPath)
+	if err := writeFileAtomic(backupPath, current); err != nil {
+		logger.LogError("CONFIG_BACKUP", backupPath, err)
+	}
+}
+
+// recoverConfig returns the configuration to use instead of the config file
+// at path, which could not be parsed: its backup, or an empty configuration
+// when there is no usable backup. The corrupt file is kept next to it for
+// inspection, and what was done is left for TakeRecoveryNotice.
+func (r *LocalRepository) recoverConfig(path string, cause error) *Config {
+	name := filepath.Base(path)
+	logger.LogError("CONFIG_CORRUPT", path, cause)
+
+	aside := fmt.Sprintf("%s.corrupt-%s", path, time.Now().Format("20060102-150405"))
+	if err := os.Rename(path, aside); err != nil {
+		logger.LogError("CONFIG_SET_ASIDE", path, err)
+		aside = ""
+	}
+
+	var notice string
+	backupPath := path + backupSuffix
+	config := &Config{PATs: []domain.PAT{}}
+	backup, err := os.ReadFile(backupPath)
+	if err == nil && json.Unmarshal(backup, config) == nil {
+		notice = fmt.Sprintf("%s was corrupt and was restored from %s", name, filepath.Base(backupPath))
+		if info, err := os.Stat(backupPath); err == nil {
+			notice += " of " + info.ModTime().Format("2006-01-02 15:04")
+		}
+	} else {
+		config = &Config{PATs: []domain.PAT{}}
+		notice = fmt.Sprintf("%s was corrupt and had no usable backup, starting with an empty configuration", name)
+	}
+	if aside != "" {
+		notice += fmt.Sprintf("; the corrupt file is kept as %s", filepath.Base(aside))
+	}
+
+	logger.Log("Config recovery: %s", notice)
+	r.recoveryNotice = notice
+	return config
+}
+
+// TakeRecoveryNotice returns what was done to recover from a corrupt config
+// file since it was last called, or "" when nothing was.
+func (r *LocalRepository) TakeRecoveryNotice() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	notice := r.recoveryNotice
+	r.recoveryNotice = ""
+	return notice
+}
@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestSave_KeepsBackupOfPreviousConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(ProfileEnv, "")
+
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	if err := repo.SavePAT(domain.PAT{ID: "first", Name: "First", Provider: domain.ProviderGitHub}); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(repo.configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SavePAT(domain.PAT{ID: "second", Name: "Second", Provider: domain.ProviderGitHub}); err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := os.ReadFile(repo.configPath + backupSuffix)
+	if err != nil {
+		t.Fatalf("expected a backup to be written: %v", err)
+	}
+	if string(backup) != string(before) {
+		t.Errorf("expected the backup to hold the config before the last save, got %s", backup)
+	}
+}
+
+func TestLoad_RestoresCorruptConfigFromBackup(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(ProfileEnv, "")
+
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SavePAT(domain.PAT{ID: "first", Name: "First", Provider: domain.ProviderGitHub}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SavePAT(domain.PAT{ID: "second", Name: "Second", Provider: domain.ProviderGitHub}); err != nil {
+		t.Fatal(err)
+	}
+	if notice := repo.TakeRecoveryNotice(); notice != "" {
+		t.Fatalf("expected no recovery notice, got %q", notice)
+	}
+
+	// A truncated write leaves half a JSON document behind.
+	data, _ := os.ReadFile(repo.configPath)
+	if err := os.WriteFile(repo.configPath, data[:len(data)/2], 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("expected a corrupt config to be recovered from, got %v", err)
+	}
+	pats, _ := reopened.ListPATs()
+	if len(pats) != 1 || pats[0].ID != "first" {
+		t.Errorf("expected the PATs from the backup, got %+v", pats)
+	}
+	notice := reopened.TakeRecoveryNotice()
+	if !strings.Contains(notice, "restored from config.json.bak") || !strings.Contains(notice, "config.json.corrupt-") {
+		t.Errorf("unexpected recovery notice %q", notice)
+	}
+	if again := reopened.TakeRecoveryNotice(); again != "" {
+		t.Errorf("expected the notice to be taken only once, got %q", again)
+	}
+
+	corrupt, _ := filepath.Glob(reopened.configPath + ".corrupt-*")
+	if len(corrupt) != 1 {
+		t.Errorf("expected the corrupt file to be kept, got %v", corrupt)
+	}
+	if _, err := NewLocalRepository(); err != nil {
+		t.Errorf("expected the recovered config to be saved, got %v", err)
+	}
+}
+
+func TestLoad_StartsEmptyWithoutUsableBackup(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(ProfileEnv, "")
+
+	dir := filepath.Join(home, configDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"pats": [`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("expected a corrupt config to be recovered from, got %v", err)
+	}
+	if pats, _ := repo.ListPATs(); len(pats) != 0 {
+		t.Errorf("expected an empty configuration, got %+v", pats)
+	}
+	if notice := repo.TakeRecoveryNotice(); !strings.Contains(notice, "no usable backup") {
+		t.Errorf("unexpected recovery notice %q", notice)
+	}
+}
@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// draftDir holds the unsent editor text, one file per draft, shared by all
+// profiles like the cache since drafts are keyed by PR.
+const draftDir = "drafts"
+
+// Drafts not saved for this long are deleted; text left that long is not
+// coming back.
+const draftRetention = 30 * 24 * time.Hour
+
+func (r *LocalRepository) draftPath(key string) string {
+	return filepath.Join(r.dir, draftDir, "draft-"+cacheFileID(key)+".json")
+}
+
+func (r *LocalRepository) SaveDraft(draft domain.EditorDraft) error {
+	if draft.Body == "" {
+		return r.DeleteDraft(draft.Key)
+	}
+	if draft.SavedAt.IsZero() {
+		draft.SavedAt = time.Now()
+	}
+
+	r.draftMu.Lock()
+	defer r.draftMu.Unlock()
+
+	r.pruneDrafts(draft.SavedAt.Add(-draftRetention))
+
+	data, err := json.Marshal(draft)
+	if err != nil {
+		return fmt.Errorf("failed to encode draft: %w", err)
+	}
+	path := r.draftPath(draft.Key)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create draft directory: %w", err)
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		logger.LogError("DRAFT_SAVE", path, err)
+		return fmt.Errorf("failed to save draft: %w", err)
+	}
+	return nil
+}
+
+// pruneDrafts deletes the drafts last saved before cutoff.
+func (r *LocalRepository) pruneDrafts(cutoff time.Time) {
+	dir := filepath.Join(r.dir, draftDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), "draft-") {
+			continue
+		}
+		if info, err := entry.Info(); err == nil && info.ModTime().Before(cutoff) {
+			if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+				logger.LogError("DRAFT_PRUNE", entry.Name(), err)
+			}
+		}
+	}
+}
+
+func (r *LocalRepository) GetDraft(key string) (*domain.EditorDraft, error) {
+	r.draftMu.Lock()
+	defer r.draftMu.Unlock()
+
+	path := r.draftPath(key)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read draft: %w", err)
+	}
+	draft, err := decodeDraft(path, data)
+	if err != nil {
+		return nil, err
+	}
+	// Two keys could share a file name; never hand out someone else's text.
+	if draft.Key != key {
+		return nil, nil
+	}
+	return draft, nil
+}
+
+func decodeDraft(path string, data []byte) (*domain.EditorDraft, error) {
+	var draft domain.EditorDraft
+	if err := json.Unmarshal(data, &draft); err != nil {
+		logger.LogError("DRAFT_UNMARSHAL", path, err)
+		return nil, fmt.Errorf("failed to decode draft: %w", err)
+	}
+	return &draft, nil
+}
+
+func (r *LocalRepository) ListDrafts() ([]domain.EditorDraft, error) {
+	r.draftMu.Lock()
+	defer r.draftMu.Unlock()
+
+	dir := filepath.Join(r.dir, draftDir)
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read drafts: %w", err)
+	}
+	var drafts []domain.EditorDraft
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), "draft-") || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			logger.LogError("DRAFT_READ", path, err)
+			continue
+		}
+		// One unreadable draft must not hide the others.
+		if draft, err := decodeDraft(path, data); err == nil {
+			drafts = append(drafts, *draft)
+		}
+	}
+	sort.SliceStable(drafts, func(i, j int) bool {
+		return drafts[i].SavedAt.After(drafts[j].SavedAt)
+	})
+	return drafts, nil
+}
+
+func (r *LocalRepository) DeleteDraft(key string) error {
+	r.draftMu.Lock()
+	defer r.draftMu.Unlock()
+
+	path := r.draftPath(key)
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.LogError("DRAFT_DELETE", path, err)
+		return fmt.Errorf("failed to delete draft: %w", err)
+	}
+	return nil
+}
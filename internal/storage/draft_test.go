@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+// The UI only keeps drafts with repositories implementing this.
+var _ domain.DraftStore = (*LocalRepository)(nil)
+
+func TestDraftStore_SavesAndDeletesDrafts(t *testing.T) {
+	store := newTestOfflineStore(t)
+
+	if draft, err := store.GetDraft("github:o/r/1#review"); err != nil || draft != nil {
+		t.Fatalf("Expected no draft, got %+v (err %v)", draft, err)
+	}
+
+	if err := store.SaveDraft(domain.EditorDraft{Key: "github:o/r/1#review", Body: "Looks good"}); err != nil {
+		t.Fatalf("Failed to save draft: %v", err)
+	}
+	draft, err := store.GetDraft("github:o/r/1#review")
+	if err != nil || draft == nil || draft.Body != "Looks good" || draft.SavedAt.IsZero() {
+		t.Fatalf("Expected the saved draft, got %+v (err %v)", draft, err)
+	}
+	if other, _ := store.GetDraft("github:o/r/1#description"); other != nil {
+		t.Errorf("Expected drafts of other editors to be separate, got %+v", other)
+	}
+
+	if err := store.SaveDraft(domain.EditorDraft{Key: "github:o/r/1#review"}); err != nil {
+		t.Fatalf("Failed to save empty draft: %v", err)
+	}
+	if draft, _ := store.GetDraft("github:o/r/1#review"); draft != nil {
+		t.Errorf("Expected an empty draft to delete it, got %+v", draft)
+	}
+	if err := store.DeleteDraft("github:o/r/1#review"); err != nil {
+		t.Errorf("Expected deleting a missing draft to succeed, got %v", err)
+	}
+}
+
+func TestDraftStore_PrunesOldDrafts(t *testing.T) {
+	store := newTestOfflineStore(t)
+
+	if err := store.SaveDraft(domain.EditorDraft{Key: "old", Body: "stale"}); err != nil {
+		t.Fatalf("Failed to save draft: %v", err)
+	}
+	old := time.Now().Add(-draftRetention - time.Hour)
+	if err := os.Chtimes(store.draftPath("old"), old, old); err != nil {
+		t.Fatalf("Failed to age draft: %v", err)
+	}
+
+	if err := store.SaveDraft(domain.EditorDraft{Key: "new", Body: "fresh"}); err != nil {
+		t.Fatalf("Failed to save draft: %v", err)
+	}
+	if draft, _ := store.GetDraft("old"); draft != nil {
+		t.Errorf("Expected the old draft to be pruned, got %+v", draft)
+	}
+	if draft, _ := store.GetDraft("new"); draft == nil {
+		t.Error("Expected the new draft to be kept")
+	}
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+const (
+	leaseDir = "leases"
+	leaseTTL = 2 * time.Minute
+)
+
+// Leases live outside config.json so that several instances can write them
+// without racing on the shared config. Every instance owns one file per PR:
+// leases/<hash of PR>/<host>-<pid>.json.
+func (r *LocalRepository) leasePath(prIdentifier string) string {
+	sum := sha1.Sum([]byte(prIdentifier))
+	return filepath.Join(r.dir, leaseDir, hex.EncodeToString(sum[:8]))
+}
+
+func leaseFileName(hostname string, pid int) string {
+	return fmt.Sprintf("%s-%d.json", hostname, pid)
+}
+
+func (r *LocalRepository) ClaimPR(lease domain.PRLease) ([]domain.PRLease, error) {
+	if lease.Hostname == "" {
+		lease.Hostname, _ = os.Hostname()
+	}
+	if lease.PID == 0 {
+		lease.PID = os.Getpid()
+	}
+	if lease.UpdatedAt.IsZero() {
+		lease.UpdatedAt = time.Now()
+	}
+
+	dir := r.leasePath(lease.PRIdentifier)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		logger.LogError("LEASE_MKDIR", dir, err)
+		return nil, fmt.Errorf("failed to create lease directory: %w", err)
+	}
+
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal lease: %w", err)
+	}
+	own := leaseFileName(lease.Hostname, lease.PID)
+	if err := os.WriteFile(filepath.Join(dir, own), data, 0600); err != nil {
+		logger.LogError("LEASE_WRITE", dir, err)
+		return nil, fmt.Errorf("failed to write lease: %w", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read leases: %w", err)
+	}
+
+	var others []domain.PRLease
+	for _, entry := range entries {
+		if entry.Name() == own {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+
+		var other domain.PRLease
+		data, err := os.ReadFile(path)
+		if err != nil || json.Unmarshal(data, &other) != nil {
+			continue
+		}
+		if time.Since(other.UpdatedAt) > leaseTTL {
+			os.Remove(path)
+			continue
+		}
+		others = append(others, other)
+	}
+
+	return others, nil
+}
+
+func (r *LocalRepository) ReleasePR(prIdentifier string) error {
+	hostname, _ := os.Hostname()
+	dir := r.leasePath(prIdentifier)
+
+	err := os.Remove(filepath.Join(dir, leaseFileName(hostname, os.Getpid())))
+	if err != nil && !os.IsNotExist(err) {
+		logger.LogError("LEASE_RELEASE", dir, err)
+		return fmt.Errorf("failed to release lease: %w", err)
+	}
+
+	// Drop the PR directory once the last lease is gone.
+	os.Remove(dir)
+	return nil
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func newLeaseTestRepository(t *testing.T) *LocalRepository {
+	t.Helper()
+	tmpDir := t.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	t.Cleanup(func() { os.Setenv("HOME", oldHome) })
+
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	return repo
+}
+
+func TestClaimPR_ReportsOtherInstances(t *testing.T) {
+	repo := newLeaseTestRepository(t)
+	const pr = "github:owner/repo/1"
+
+	others, err := repo.ClaimPR(domain.PRLease{PRIdentifier: pr, Hostname: "laptop", PID: 100, PendingDrafts: 2})
+	if err != nil {
+		t.Fatalf("Failed to claim PR: %v", err)
+	}
+	if len(others) != 0 {
+		t.Errorf("Expected no other leases, got %v", others)
+	}
+
+	others, err = repo.ClaimPR(domain.PRLease{PRIdentifier: pr})
+	if err != nil {
+		t.Fatalf("Failed to claim PR: %v", err)
+	}
+	if len(others) != 1 || others[0].PID != 100 || others[0].PendingDrafts != 2 {
+		t.Fatalf("Expected the first instance's lease, got %v", others)
+	}
+
+	if err := repo.ReleasePR(pr); err != nil {
+		t.Fatalf("Failed to release PR: %v", err)
+	}
+	others, _ = repo.ClaimPR(domain.PRLease{PRIdentifier: pr, Hostname: "laptop", PID: 100})
+	if len(others) != 0 {
+		t.Errorf("Expected released lease to be gone, got %v", others)
+	}
+}
+
+func TestClaimPR_DropsStaleLeases(t *testing.T) {
+	repo := newLeaseTestRepository(t)
+	const pr = "azuredevops:project/repo/7"
+
+	if _, err := repo.ClaimPR(domain.PRLease{
+		PRIdentifier: pr,
+		Hostname:     "desktop",
+		PID:          42,
+		UpdatedAt:    time.Now().Add(-2 * leaseTTL),
+	}); err != nil {
+		t.Fatalf("Failed to claim PR: %v", err)
+	}
+
+	others, err := repo.ClaimPR(domain.PRLease{PRIdentifier: pr})
+	if err != nil {
+		t.Fatalf("Failed to claim PR: %v", err)
+	}
+	if len(others) != 0 {
+		t.Errorf("Expected stale lease to be ignored, got %v", others)
+	}
+	if _, err := os.Stat(filepath.Join(repo.leasePath(pr), leaseFileName("desktop", 42))); !os.IsNotExist(err) {
+		t.Error("Expected stale lease file to be removed")
+	}
+}
@@ -17,21 +17,50 @@ const (
 )
 
 type LocalRepository struct {
+	// dir holds the config files of all profiles and the data they share,
+	// such as the offline cache.
+	dir        string
+	profile    string
 	configPath string
 	config     *Config
-	mu         sync.RWMutex
+	// configData is the content of config.json as last loaded or saved, to
+	// tell changes made by something else from our own saves.
+	configData []byte
+	// recoveryNotice says how a corrupt config file was recovered from.
+	recoveryNotice string
+	mu             sync.RWMutex
+	// cacheMu guards the files in the cache directory.
+	cacheMu sync.Mutex
+	// draftMu guards the files in the drafts directory.
+	draftMu sync.Mutex
+	// activityMu guards the activity file.
+	activityMu sync.Mutex
 }
 
 func NewLocalRepository() (*LocalRepository, error) {
+	return NewProfileRepository("")
+}
+
+// NewProfileRepository opens the named profile. Without a name it opens the
+// profile named by LGTMFASTER_PROFILE, or else the one last switched to.
+func NewProfileRepository(profile string) (*LocalRepository, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	configPath := filepath.Join(homeDir, configDir, configFile)
+	dir := filepath.Join(homeDir, configDir)
+	if profile == "" {
+		profile = startupProfile(dir)
+	}
+	if err := ValidateProfileName(profile); err != nil {
+		return nil, err
+	}
 
 	repo := &LocalRepository{
-		configPath: configPath,
+		dir:        dir,
+		profile:    profile,
+		configPath: filepath.Join(dir, profileConfigFile(profile)),
 		config:     &Config{PATs: []domain.PAT{}},
 	}
 
@@ -49,8 +78,7 @@ func NewLocalRepository() (*LocalRepository, error) {
 }
 
 func (r *LocalRepository) ensureConfigDir() error {
-	dir := filepath.Dir(r.configPath)
-	return os.MkdirAll(dir, 0700)
+	return os.MkdirAll(r.dir, 0700)
 }
 
 func (r *LocalRepository) load() error {
@@ -58,15 +86,19 @@ func (r *LocalRepository) load() error {
 	defer r.mu.Unlock()
 
 	logger.LogFileOpen(r.configPath)
-	data, err := os.ReadFile(r.configPath)
+	data, err := r.readConfig(r.configPath)
 	if err != nil {
 		logger.LogError("LOAD", r.configPath, err)
 		return err
 	}
 
 	if err := json.Unmarshal(data, r.config); err != nil {
-		logger.LogError("UNMARSHAL", r.configPath, err)
-		return err
+		r.config = r.recoverConfig(r.configPath, err)
+		if err := r.save(); err != nil {
+			return err
+		}
+	} else {
+		r.configData = data
 	}
 
 	if len(r.config.SelectedPATs) == 0 && r.config.ActivePAT != "" {
@@ -87,6 +119,19 @@ func (r *LocalRepository) load() error {
 	return nil
 }
 
+// readConfig reads a config file under a shared lock. When another instance
+// keeps it locked for too long, the file is read anyway, since writes replace
+// it atomically and it is never seen half-written.
+func (r *LocalRepository) readConfig(path string) ([]byte, error) {
+	unlock, err := lockConfig(path, false)
+	if err != nil {
+		logger.Log("Reading %s without a lock: %v", path, err)
+	} else {
+		defer unlock()
+	}
+	return os.ReadFile(path)
+}
+
 func (r *LocalRepository) save() error {
 	data, err := json.MarshalIndent(r.config, "", "  ")
 	if err != nil {
@@ -94,8 +139,16 @@ func (r *LocalRepository) save() error {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
+	unlock, err := lockConfig(r.configPath, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	backupConfig(r.configPath, data)
 	logger.LogFileWrite(r.configPath)
-	if err := os.WriteFile(r.configPath, data, 0600); err != nil {
+	r.configData = data
+	if err := writeFileAtomic(r.configPath, data); err != nil {
 		logger.LogError("SAVE", r.configPath, err)
 		return err
 	}
@@ -431,3 +484,19 @@ func (r *LocalRepository) SetPrimaryPAT(id string) error {
 	logger.Log("Set primary PAT: %s", id)
 	return r.save()
 }
+
+func (r *LocalRepository) GetSettings() (domain.Settings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.config.Settings, nil
+}
+
+func (r *LocalRepository) SaveSettings(settings domain.Settings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.config.Settings = settings
+	logger.Log("Saving settings")
+	return r.save()
+}
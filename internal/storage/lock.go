@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// Several instances, for example in two tmux panes, share the config file.
+// Writes take an exclusive advisory lock on config.json.lock and reads a
+// shared one, so that no instance reads a half-written file or interleaves
+// its write with another's. The instance holding the exclusive lock records
+// itself in the lock file, so that one waiting in vain can say who to look
+// at.
+const lockSuffix = ".lock"
+
+var (
+	// lockTimeout is how long to wait for another instance to release the
+	// lock before giving up.
+	lockTimeout       = 2 * time.Second
+	lockRetryInterval = 20 * time.Millisecond
+	// staleLockAge is how long a lock may be held before the holder is
+	// considered hung.
+	staleLockAge = 30 * time.Second
+)
+
+// errLockBusy is returned by tryLock when another lock is in the way.
+var errLockBusy = errors.New("lock is held by another process")
+
+// LockHolder is the instance that last took the exclusive lock.
+type LockHolder struct {
+	PID      int       `json:"pid"`
+	Hostname string    `json:"hostname"`
+	Since    time.Time `json:"since"`
+}
+
+// ConfigLockedError reports that the config file stayed locked by another
+// instance for longer than the wait allows. Stale is set when that instance
+// is gone or has held the lock for so long that it is probably hung.
+type ConfigLockedError struct {
+	Path   string
+	Holder *LockHolder
+	Stale  bool
+}
+
+func (e *ConfigLockedError) Error() string {
+	name := filepath.Base(e.Path)
+	if e.Holder == nil {
+		return fmt.Sprintf("%s is locked by another instance", name)
+	}
+	holder := fmt.Sprintf("pid %d on %s", e.Holder.PID, e.Holder.Hostname)
+	held := time.Since(e.Holder.Since).Round(time.Second)
+	if e.Stale {
+		return fmt.Sprintf("%s has been locked by %s for %s; the lock looks stale, quit that instance or remove %s", name, holder, held, filepath.Base(e.Path+lockSuffix))
+	}
+	return fmt.Sprintf("%s is locked by %s (for %s)", name, holder, held)
+}
+
+// lockConfig locks the config file at path, exclusively for writing or
+// shared for reading, and returns the function that releases the lock.
+func lockConfig(path string, exclusive bool) (func(), error) {
+	lockPath := path + lockSuffix
+	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		err := tryLock(file, exclusive)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, errLockBusy) {
+			file.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", lockPath, err)
+		}
+		if time.Now().After(deadline) {
+			lockErr := lockedError(path, file)
+			file.Close()
+			logger.LogError("CONFIG_LOCK", lockPath, lockErr)
+			return nil, lockErr
+		}
+		time.Sleep(lockRetryInterval)
+	}
+
+	if exclusive {
+		hostname, _ := os.Hostname()
+		data, _ := json.Marshal(LockHolder{PID: os.Getpid(), Hostname: hostname, Since: time.Now()})
+		if err := file.Truncate(0); err == nil {
+			file.WriteAt(data, 0)
+		}
+	}
+
+	return func() {
+		unlockFile(file)
+		file.Close()
+	}, nil
+}
+
+// lockedError describes who holds the lock, as far as the lock file tells.
+func lockedError(path string, file *os.File) *ConfigLockedError {
+	lockErr := &ConfigLockedError{Path: path}
+
+	data, err := io.ReadAll(io.NewSectionReader(file, 0, 4096))
+	if err != nil || len(data) == 0 {
+		return lockErr
+	}
+	var holder LockHolder
+	if json.Unmarshal(data, &holder) != nil || holder.PID == 0 {
+		return lockErr
+	}
+	lockErr.Holder = &holder
+
+	hostname, _ := os.Hostname()
+	gone := holder.Hostname == hostname && !processAlive(holder.PID)
+	lockErr.Stale = gone || time.Since(holder.Since) > staleLockAge
+	return lockErr
+}
+
+// writeFileAtomic replaces path with data by renaming a temporary file over
+// it, so that readers never see a partly written file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,18 @@
+//go:build !unix
+
+package storage
+
+import "os"
+
+// Advisory locks are only taken on Unix; elsewhere writes are still atomic
+// but not serialized between instances.
+
+func tryLock(file *os.File, exclusive bool) error {
+	return nil
+}
+
+func unlockFile(file *os.File) {}
+
+func processAlive(pid int) bool {
+	return true
+}
@@ -0,0 +1,100 @@
+//go:build unix
+
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func shortLockTimeout(t *testing.T) {
+	t.Helper()
+	previous := lockTimeout
+	lockTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { lockTimeout = previous })
+}
+
+func TestSave_WaitsForAnotherInstancesLock(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(ProfileEnv, "")
+	shortLockTimeout(t)
+
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A separate open file description conflicts like another process would.
+	unlock, err := lockConfig(repo.configPath, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = repo.SavePAT(domain.PAT{ID: "blocked", Name: "Blocked", Provider: domain.ProviderGitHub})
+	var locked *ConfigLockedError
+	if !errors.As(err, &locked) {
+		t.Fatalf("expected a locked error while another instance holds the lock, got %v", err)
+	}
+	if locked.Holder == nil || locked.Holder.PID != os.Getpid() || locked.Stale {
+		t.Errorf("expected the live holder to be named, got %+v", locked)
+	}
+	if !strings.Contains(err.Error(), "locked by pid") {
+		t.Errorf("expected the holder in the message, got %q", err)
+	}
+
+	released := make(chan struct{})
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		unlock()
+		close(released)
+	}()
+	if err := repo.SavePAT(domain.PAT{ID: "waited", Name: "Waited", Provider: domain.ProviderGitHub}); err != nil {
+		t.Fatalf("expected the save to succeed once the lock is released, got %v", err)
+	}
+	<-released
+
+	data, err := os.ReadFile(repo.configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil || len(config.PATs) != 2 {
+		t.Errorf("expected a complete config with both PATs, got %d PATs, %v", len(config.PATs), err)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(repo.dir, ".config.json-*.tmp")); len(matches) != 0 {
+		t.Errorf("expected no temporary files to be left, got %v", matches)
+	}
+}
+
+func TestLockedError_DetectsStaleLock(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	shortLockTimeout(t)
+
+	unlock, err := lockConfig(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+
+	hostname, _ := os.Hostname()
+	old, _ := json.Marshal(LockHolder{PID: os.Getpid(), Hostname: hostname, Since: time.Now().Add(-time.Hour)})
+	if err := os.WriteFile(path+lockSuffix, old, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = lockConfig(path, true)
+	var locked *ConfigLockedError
+	if !errors.As(err, &locked) || !locked.Stale {
+		t.Fatalf("expected a lock held for an hour to be reported as stale, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "looks stale") {
+		t.Errorf("expected the message to say the lock looks stale, got %q", err)
+	}
+}
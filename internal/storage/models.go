@@ -1,10 +1,24 @@
 package storage
 
-import "github.com/johanforsgren/lgtmfaster/internal/domain"
+import (
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
 
 type Config struct {
-	PATs         []domain.PAT `json:"pats"`
-	ActivePAT    string       `json:"active_pat"`
-	SelectedPATs []string     `json:"selected_pats"`
-	PrimaryPAT   string       `json:"primary_pat"`
+	PATs          []domain.PAT          `json:"pats"`
+	ActivePAT     string                `json:"active_pat"`
+	SelectedPATs  []string              `json:"selected_pats"`
+	PrimaryPAT    string                `json:"primary_pat"`
+	Settings      domain.Settings       `json:"settings"`
+	Snoozes       []domain.PRSnooze     `json:"snoozes"`
+	Seen          map[string]time.Time  `json:"seen"`
+	Pins          domain.Pins           `json:"pins"`
+	Session       *domain.Session       `json:"session,omitempty"`
+	QueuedReviews []domain.QueuedReview `json:"queued_reviews,omitempty"`
+
+	// Activity is where earlier versions kept the activity log. It is only
+	// read, to move the events to the activity file.
+	Activity []domain.ActivityEvent `json:"activity,omitempty"`
 }
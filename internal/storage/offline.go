@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// cacheDir holds the data shown while offline, one file per PAT's PR list
+// and one per pull request, next to the config file.
+const cacheDir = "cache"
+
+// Cached PRs not loaded for this long are deleted; a PR nobody opened in a
+// month is unlikely to be needed offline.
+const cacheRetention = 30 * 24 * time.Hour
+
+func (r *LocalRepository) cachePath(name string) string {
+	return filepath.Join(r.dir, cacheDir, name)
+}
+
+func prGroupCacheFile(patID string) string {
+	return "prs-" + cacheFileID(patID) + ".json"
+}
+
+func prCacheFile(key string) string {
+	return "pr-" + cacheFileID(key) + ".json"
+}
+
+// cacheFileID turns an arbitrary key into something safe to use in a file
+// name.
+func cacheFileID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// readCache decodes the cache file name into v and reports whether it
+// exists.
+func (r *LocalRepository) readCache(name string, v any) (bool, error) {
+	path := r.cachePath(name)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read cache: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		logger.LogError("CACHE_UNMARSHAL", path, err)
+		return false, fmt.Errorf("failed to decode cache: %w", err)
+	}
+	return true, nil
+}
+
+// writeCache replaces the cache file name with v, going through a temporary
+// file so that a crash never leaves a truncated cache behind.
+func (r *LocalRepository) writeCache(name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache: %w", err)
+	}
+	path := r.cachePath(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		logger.LogError("CACHE_SAVE", path, err)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (r *LocalRepository) SaveCachedPRGroup(group domain.PRGroup, fetchedAt time.Time) error {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	r.pruneCache(fetchedAt.Add(-cacheRetention))
+	return r.writeCache(prGroupCacheFile(group.PATID), domain.CachedPRGroup{Group: group, FetchedAt: fetchedAt})
+}
+
+// pruneCache deletes the cached PRs last written before cutoff.
+func (r *LocalRepository) pruneCache(cutoff time.Time) {
+	dir := r.cachePath("")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), "pr-") {
+			continue
+		}
+		if info, err := entry.Info(); err == nil && info.ModTime().Before(cutoff) {
+			if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+				logger.LogError("CACHE_PRUNE", entry.Name(), err)
+			}
+		}
+	}
+}
+
+func (r *LocalRepository) GetCachedPRGroup(patID string) (*domain.CachedPRGroup, error) {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	var cached domain.CachedPRGroup
+	found, err := r.readCache(prGroupCacheFile(patID), &cached)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &cached, nil
+}
+
+// updateCachedPR applies update to the cached data of the PR identified by
+// key.
+func (r *LocalRepository) updateCachedPR(key string, fetchedAt time.Time, update func(*domain.CachedPR)) error {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	var cached domain.CachedPR
+	if _, err := r.readCache(prCacheFile(key), &cached); err != nil {
+		cached = domain.CachedPR{}
+	}
+	update(&cached)
+	if fetchedAt.After(cached.FetchedAt) {
+		cached.FetchedAt = fetchedAt
+	}
+	return r.writeCache(prCacheFile(key), cached)
+}
+
+func (r *LocalRepository) SaveCachedPR(key string, pr domain.PullRequest, fetchedAt time.Time) error {
+	return r.updateCachedPR(key, fetchedAt, func(cached *domain.CachedPR) {
+		cached.PR = &pr
+	})
+}
+
+func (r *LocalRepository) SaveCachedDiff(key string, diff *domain.Diff, fetchedAt time.Time) error {
+	return r.updateCachedPR(key, fetchedAt, func(cached *domain.CachedPR) {
+		cached.Diff = diff
+	})
+}
+
+func (r *LocalRepository) SaveCachedComments(key string, comments []domain.Comment, fetchedAt time.Time) error {
+	return r.updateCachedPR(key, fetchedAt, func(cached *domain.CachedPR) {
+		cached.Comments = comments
+		cached.CommentsLoaded = true
+	})
+}
+
+func (r *LocalRepository) GetCachedPR(key string) (*domain.CachedPR, error) {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	var cached domain.CachedPR
+	found, err := r.readCache(prCacheFile(key), &cached)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &cached, nil
+}
+
+func (r *LocalRepository) QueueReview(review domain.QueuedReview) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if review.QueuedAt.IsZero() {
+		review.QueuedAt = time.Now()
+	}
+	r.config.QueuedReviews = append(r.config.QueuedReviews, review)
+
+	logger.Log("Adding review %s for %s to the outbox", review.ID, review.Review.PRIdentifier)
+	return r.save()
+}
+
+func (r *LocalRepository) UpdateQueuedReview(review domain.QueuedReview) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, queued := range r.config.QueuedReviews {
+		if queued.ID == review.ID {
+			r.config.QueuedReviews[i] = review
+			return r.save()
+		}
+	}
+	return fmt.Errorf("queued review not found: %s", review.ID)
+}
+
+func (r *LocalRepository) ListQueuedReviews() ([]domain.QueuedReview, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reviews := make([]domain.QueuedReview, len(r.config.QueuedReviews))
+	copy(reviews, r.config.QueuedReviews)
+	return reviews, nil
+}
+
+func (r *LocalRepository) RemoveQueuedReview(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.config.QueuedReviews[:0]
+	found := false
+	for _, review := range r.config.QueuedReviews {
+		if review.ID == id {
+			found = true
+			continue
+		}
+		kept = append(kept, review)
+	}
+	r.config.QueuedReviews = kept
+	if !found {
+		return nil
+	}
+	return r.save()
+}
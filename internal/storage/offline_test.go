@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+// The UI only uses the offline data of repositories implementing this.
+var _ domain.OfflineStore = (*LocalRepository)(nil)
+
+func newTestOfflineStore(t *testing.T) *LocalRepository {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	return repo
+}
+
+func TestOfflineStore_CachesPRGroups(t *testing.T) {
+	store := newTestOfflineStore(t)
+
+	if cached, err := store.GetCachedPRGroup("pat-1"); err != nil || cached != nil {
+		t.Fatalf("Expected nothing cached, got %+v (err %v)", cached, err)
+	}
+
+	fetchedAt := time.Now().UTC().Truncate(time.Second)
+	group := domain.PRGroup{PATName: "work", PATID: "pat-1", PRs: []domain.PullRequest{{Number: 3, Title: "Fix"}}}
+	if err := store.SaveCachedPRGroup(group, fetchedAt); err != nil {
+		t.Fatalf("Failed to cache group: %v", err)
+	}
+
+	cached, err := store.GetCachedPRGroup("pat-1")
+	if err != nil || cached == nil {
+		t.Fatalf("Expected the cached group, got %+v (err %v)", cached, err)
+	}
+	if !cached.FetchedAt.Equal(fetchedAt) || len(cached.Group.PRs) != 1 || cached.Group.PRs[0].Title != "Fix" {
+		t.Errorf("Unexpected cached group: %+v", cached)
+	}
+}
+
+func TestOfflineStore_MergesPRParts(t *testing.T) {
+	store := newTestOfflineStore(t)
+	key := "github:acme/api/7"
+	earlier := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	later := earlier.Add(time.Minute)
+
+	if err := store.SaveCachedPR(key, domain.PullRequest{Number: 7}, earlier); err != nil {
+		t.Fatalf("Failed to cache PR: %v", err)
+	}
+	if err := store.SaveCachedDiff(key, &domain.Diff{Files: []domain.FileDiff{{NewPath: "main.go"}}}, later); err != nil {
+		t.Fatalf("Failed to cache diff: %v", err)
+	}
+
+	cached, err := store.GetCachedPR(key)
+	if err != nil || cached == nil {
+		t.Fatalf("Expected the cached PR, got %+v (err %v)", cached, err)
+	}
+	if cached.PR == nil || cached.PR.Number != 7 || cached.Diff == nil || len(cached.Diff.Files) != 1 {
+		t.Errorf("Expected both the PR and its diff, got %+v", cached)
+	}
+	if cached.CommentsLoaded || !cached.FetchedAt.Equal(later) {
+		t.Errorf("Expected no comments and the latest fetch time, got %+v", cached)
+	}
+}
+
+func TestOfflineStore_PrunesOldPRs(t *testing.T) {
+	store := newTestOfflineStore(t)
+	key := "github:acme/api/7"
+	if err := store.SaveCachedComments(key, nil, time.Now()); err != nil {
+		t.Fatalf("Failed to cache comments: %v", err)
+	}
+	old := time.Now().Add(-2 * cacheRetention)
+	if err := os.Chtimes(store.cachePath(prCacheFile(key)), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.SaveCachedPRGroup(domain.PRGroup{PATID: "pat-1"}, time.Now()); err != nil {
+		t.Fatalf("Failed to cache group: %v", err)
+	}
+	if cached, _ := store.GetCachedPR(key); cached != nil {
+		t.Errorf("Expected the old PR to be pruned, got %+v", cached)
+	}
+	if _, err := os.Stat(filepath.Join(store.cachePath(""), prGroupCacheFile("pat-1"))); err != nil {
+		t.Errorf("Expected the group to be kept: %v", err)
+	}
+}
+
+func TestOfflineStore_QueuedReviewsPersist(t *testing.T) {
+	store := newTestOfflineStore(t)
+
+	for _, id := range []string{"a", "b"} {
+		if err := store.QueueReview(domain.QueuedReview{ID: id, PATID: "pat-1", Review: domain.Review{Body: id}}); err != nil {
+			t.Fatalf("Failed to queue review: %v", err)
+		}
+	}
+	if err := store.RemoveQueuedReview("a"); err != nil {
+		t.Fatalf("Failed to remove review: %v", err)
+	}
+	if err := store.UpdateQueuedReview(domain.QueuedReview{ID: "b", PATID: "pat-1", Review: domain.Review{Body: "b"}, Attempts: 2, LastError: "rejected"}); err != nil {
+		t.Fatalf("Failed to update review: %v", err)
+	}
+	if err := store.UpdateQueuedReview(domain.QueuedReview{ID: "missing"}); err == nil {
+		t.Error("Expected an error when updating a review that is not queued")
+	}
+
+	reloaded, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to reload repository: %v", err)
+	}
+	queued, err := reloaded.ListQueuedReviews()
+	if err != nil {
+		t.Fatalf("Failed to list queued reviews: %v", err)
+	}
+	if len(queued) != 1 || queued[0].ID != "b" || queued[0].Attempts != 2 || queued[0].LastError != "rejected" {
+		t.Errorf("Expected only the updated review b, got %+v", queued)
+	}
+}
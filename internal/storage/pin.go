@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"slices"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+func (r *LocalRepository) GetPins() (domain.Pins, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return domain.Pins{
+		Repositories: slices.Clone(r.config.Pins.Repositories),
+		PullRequests: slices.Clone(r.config.Pins.PullRequests),
+	}, nil
+}
+
+func (r *LocalRepository) PinRepository(key string, pinned bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys, changed := setPinned(r.config.Pins.Repositories, key, pinned)
+	if !changed {
+		return nil
+	}
+	r.config.Pins.Repositories = keys
+
+	logger.Log("Pinning repository %s: %t", key, pinned)
+	return r.save()
+}
+
+func (r *LocalRepository) PinPR(key string, pinned bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys, changed := setPinned(r.config.Pins.PullRequests, key, pinned)
+	if !changed {
+		return nil
+	}
+	r.config.Pins.PullRequests = keys
+
+	logger.Log("Pinning PR %s: %t", key, pinned)
+	return r.save()
+}
+
+// setPinned adds key to or removes it from keys and reports whether that
+// changed anything.
+func setPinned(keys []string, key string, pinned bool) ([]string, bool) {
+	i := slices.Index(keys, key)
+	switch {
+	case pinned && i < 0:
+		return append(keys, key), true
+	case !pinned && i >= 0:
+		return slices.Delete(keys, i, i+1), true
+	}
+	return keys, false
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestPinRepositoryAndPR(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(ProfileEnv, "")
+
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	pr := domain.PullRequest{Number: 7, ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}}
+	if err := repo.PinRepository(pr.RepositoryKey(), true); err != nil {
+		t.Fatalf("Failed to pin repository: %v", err)
+	}
+	if err := repo.PinPR(pr.Key(), true); err != nil {
+		t.Fatalf("Failed to pin PR: %v", err)
+	}
+	if err := repo.PinPR(pr.Key(), true); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to reload repository: %v", err)
+	}
+	pins, err := reloaded.GetPins()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pins.Repositories) != 1 || len(pins.PullRequests) != 1 || !pins.RepositoryPinned(pr) || !pins.PRPinned(pr) {
+		t.Fatalf("expected the repository and PR to be pinned once, got %+v", pins)
+	}
+
+	if err := reloaded.PinRepository(pr.RepositoryKey(), false); err != nil {
+		t.Fatal(err)
+	}
+	pins, _ = reloaded.GetPins()
+	if pins.RepositoryPinned(pr) || !pins.IsPinned(pr) {
+		t.Errorf("expected only the PR to stay pinned, got %+v", pins)
+	}
+}
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// Profiles keep separate PATs and settings, for instance for work and
+// personal use or for each client of a contractor. The default profile is
+// config.json; any other profile is config.<name>.json next to it. Cached
+// data is shared, since it is keyed by PAT.
+const (
+	DefaultProfile = "default"
+
+	// ProfileEnv names the profile to start with, overriding the one last
+	// switched to.
+	ProfileEnv = "LGTMFASTER_PROFILE"
+
+	// profileFile remembers the profile last switched to.
+	profileFile = "profile"
+)
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// ValidateProfileName rejects names that cannot be part of a file name.
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, - and _", name)
+	}
+	return nil
+}
+
+func profileConfigFile(name string) string {
+	if name == DefaultProfile {
+		return configFile
+	}
+	return "config." + name + ".json"
+}
+
+// startupProfile returns the profile named by LGTMFASTER_PROFILE, or else
+// the one last switched to.
+func startupProfile(dir string) string {
+	if name := strings.TrimSpace(os.Getenv(ProfileEnv)); name != "" {
+		return name
+	}
+	data, err := os.ReadFile(filepath.Join(dir, profileFile))
+	if err != nil {
+		return DefaultProfile
+	}
+	if name := strings.TrimSpace(string(data)); ValidateProfileName(name) == nil {
+		return name
+	}
+	return DefaultProfile
+}
+
+// Profile returns the name of the profile in use.
+func (r *LocalRepository) Profile() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.profile
+}
+
+// ListProfiles returns the default profile followed by the others, sorted
+// by name.
+func (r *LocalRepository) ListProfiles() ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(r.dir, "config.*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := []string{DefaultProfile}
+	for _, match := range matches {
+		name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(match), "config."), ".json")
+		if ValidateProfileName(name) == nil && name != DefaultProfile {
+			profiles = append(profiles, name)
+		}
+	}
+	sort.Strings(profiles[1:])
+	return profiles, nil
+}
+
+// SwitchProfile starts using the named profile, creating it empty when it
+// does not exist yet, and remembers it for the next start.
+func (r *LocalRepository) SwitchProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if name == r.profile {
+		return nil
+	}
+
+	path := filepath.Join(r.dir, profileConfigFile(name))
+	config := &Config{PATs: []domain.PAT{}}
+	logger.LogFileOpen(path)
+	data, err := r.readConfig(path)
+	switch {
+	case os.IsNotExist(err):
+		data = nil
+	case err != nil:
+		logger.LogError("LOAD", path, err)
+		return err
+	default:
+		if err := json.Unmarshal(data, config); err != nil {
+			config = r.recoverConfig(path, err)
+			data = nil
+		}
+	}
+
+	r.profile = name
+	r.configPath = path
+	r.config = config
+	r.configData = data
+	if data == nil {
+		if err := r.save(); err != nil {
+			return err
+		}
+	}
+
+	profilePath := filepath.Join(r.dir, profileFile)
+	logger.LogFileWrite(profilePath)
+	if err := os.WriteFile(profilePath, []byte(name+"\n"), 0600); err != nil {
+		logger.LogError("SAVE_PROFILE", profilePath, err)
+	}
+
+	logger.Log("Switched to profile %s (%s)", name, path)
+	return nil
+}
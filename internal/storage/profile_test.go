@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestProfiles_KeepPATsAndSettingsSeparate(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(ProfileEnv, "")
+
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	if repo.Profile() != DefaultProfile {
+		t.Fatalf("expected to start with the default profile, got %q", repo.Profile())
+	}
+	if err := repo.SavePAT(domain.PAT{ID: "personal", Name: "Personal", Provider: domain.ProviderGitHub}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.SwitchProfile("client-a"); err != nil {
+		t.Fatalf("Failed to switch profile: %v", err)
+	}
+	if pats, _ := repo.ListPATs(); len(pats) != 0 {
+		t.Errorf("expected a new profile to start without PATs, got %+v", pats)
+	}
+	if err := repo.SavePAT(domain.PAT{ID: "client", Name: "Client A", Provider: domain.ProviderAzureDevOps}); err != nil {
+		t.Fatal(err)
+	}
+	settings, _ := repo.GetSettings()
+	settings.Display.WrapDiffLines = true
+	if err := repo.SaveSettings(settings); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(repo.dir, "config.client-a.json")); err != nil {
+		t.Errorf("expected the profile to have its own config file: %v", err)
+	}
+
+	profiles, err := repo.ListProfiles()
+	if err != nil || len(profiles) != 2 || profiles[0] != DefaultProfile || profiles[1] != "client-a" {
+		t.Errorf("unexpected profiles %v, %v", profiles, err)
+	}
+
+	// The profile switched to last is used on the next start.
+	reopened, err := NewLocalRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Profile() != "client-a" {
+		t.Errorf("expected the last profile to be remembered, got %q", reopened.Profile())
+	}
+
+	if err := repo.SwitchProfile(DefaultProfile); err != nil {
+		t.Fatal(err)
+	}
+	pats, _ := repo.ListPATs()
+	if len(pats) != 1 || pats[0].ID != "personal" {
+		t.Errorf("expected the default profile's PATs, got %+v", pats)
+	}
+	if settings, _ := repo.GetSettings(); settings.Display.WrapDiffLines {
+		t.Error("expected the default profile's settings")
+	}
+}
+
+func TestProfiles_StartupProfile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(ProfileEnv, "work")
+
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.Profile() != "work" || filepath.Base(repo.configPath) != "config.work.json" {
+		t.Errorf("expected the environment to select the profile, got %q at %s", repo.Profile(), repo.configPath)
+	}
+
+	explicit, err := NewProfileRepository("personal")
+	if err != nil || explicit.Profile() != "personal" {
+		t.Errorf("expected an explicit profile to win, got %v, %v", explicit, err)
+	}
+
+	if _, err := NewProfileRepository("../etc"); err == nil {
+		t.Error("expected an invalid profile name to be rejected")
+	}
+	if err := repo.SwitchProfile("a b"); err == nil {
+		t.Error("expected switching to an invalid profile name to fail")
+	}
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// PRs not updated for this long are dropped from the seen list; if one comes
+// back to life it simply shows as unread again.
+const seenRetention = 180 * 24 * time.Hour
+
+func (r *LocalRepository) GetSeenPRs() (map[string]time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]time.Time, len(r.config.Seen))
+	for key, updatedAt := range r.config.Seen {
+		seen[key] = updatedAt
+	}
+	return seen, nil
+}
+
+func (r *LocalRepository) MarkPRsSeen(seen map[string]time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.config.Seen == nil {
+		r.config.Seen = make(map[string]time.Time, len(seen))
+	}
+
+	changed := false
+	for key, updatedAt := range seen {
+		if current, ok := r.config.Seen[key]; ok && !updatedAt.After(current) {
+			continue
+		}
+		r.config.Seen[key] = updatedAt
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	cutoff := time.Now().Add(-seenRetention)
+	for key, updatedAt := range r.config.Seen {
+		if updatedAt.Before(cutoff) {
+			delete(r.config.Seen, key)
+		}
+	}
+
+	logger.Log("Marking %d PR(s) as seen", len(seen))
+	return r.save()
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMarkPRsSeen_KeepsNewestTimestamp(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", oldHome)
+
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	if err := repo.MarkPRsSeen(map[string]time.Time{
+		"github:acme/api/1": now,
+		"github:acme/api/2": now.Add(-seenRetention - time.Hour),
+	}); err != nil {
+		t.Fatalf("Failed to mark PRs seen: %v", err)
+	}
+	if err := repo.MarkPRsSeen(map[string]time.Time{"github:acme/api/1": now.Add(-time.Hour)}); err != nil {
+		t.Fatalf("Failed to mark PRs seen: %v", err)
+	}
+
+	reloaded, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to reload repository: %v", err)
+	}
+
+	seen, err := reloaded.GetSeenPRs()
+	if err != nil {
+		t.Fatalf("Failed to get seen PRs: %v", err)
+	}
+	if !seen["github:acme/api/1"].Equal(now) {
+		t.Errorf("Expected newest timestamp to be kept, got %v", seen["github:acme/api/1"])
+	}
+	if _, ok := seen["github:acme/api/2"]; ok {
+		t.Error("Expected entry older than the retention to be pruned")
+	}
+}
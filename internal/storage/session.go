@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+func (r *LocalRepository) GetSession() (*domain.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.config.Session == nil {
+		return nil, nil
+	}
+	session := *r.config.Session
+	if session.PR != nil {
+		pr := *session.PR
+		session.PR = &pr
+	}
+	return &session, nil
+}
+
+func (r *LocalRepository) SaveSession(session domain.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	logger.Log("Saving session (view %s)", session.View)
+	r.config.Session = &session
+	return r.save()
+}
+
+func (r *LocalRepository) ClearSession() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.config.Session == nil {
+		return nil
+	}
+	r.config.Session = nil
+	return r.save()
+}
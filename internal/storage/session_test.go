@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestSession_SaveReloadAndClear(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", oldHome)
+
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	if session, err := repo.GetSession(); err != nil || session != nil {
+		t.Fatalf("Expected no session initially, got %+v (err %v)", session, err)
+	}
+
+	saved := domain.Session{
+		View:   domain.SessionViewPRInspect,
+		Filter: "api",
+		PR: &domain.SessionPR{
+			ProviderType: domain.ProviderGitHub,
+			PATID:        "pat-1",
+			Repository:   "acme/api",
+			Number:       42,
+			DiffMode:     true,
+			FileIndex:    2,
+			LineIndex:    17,
+			ScrollOffset: 9,
+		},
+		SavedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	if err := repo.SaveSession(saved); err != nil {
+		t.Fatalf("Failed to save session: %v", err)
+	}
+
+	reloaded, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to reload repository: %v", err)
+	}
+	session, err := reloaded.GetSession()
+	if err != nil {
+		t.Fatalf("Failed to get session: %v", err)
+	}
+	if session == nil || session.PR == nil {
+		t.Fatalf("Expected session with PR, got %+v", session)
+	}
+	if session.View != saved.View || session.Filter != "api" || !session.SavedAt.Equal(saved.SavedAt) {
+		t.Errorf("Unexpected session: %+v", session)
+	}
+	if *session.PR != *saved.PR {
+		t.Errorf("Expected PR %+v, got %+v", *saved.PR, *session.PR)
+	}
+
+	if err := reloaded.ClearSession(); err != nil {
+		t.Fatalf("Failed to clear session: %v", err)
+	}
+	if session, _ := reloaded.GetSession(); session != nil {
+		t.Errorf("Expected session to be cleared, got %+v", session)
+	}
+}
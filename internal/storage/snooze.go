@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// Snoozes waiting for an update of a PR that was merged or closed in the
+// meantime would never wake up, so they are dropped after this long.
+const snoozeRetention = 30 * 24 * time.Hour
+
+func (r *LocalRepository) SnoozePR(snooze domain.PRSnooze) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if snooze.CreatedAt.IsZero() {
+		snooze.CreatedAt = now
+	}
+
+	kept := r.config.Snoozes[:0]
+	for _, s := range r.config.Snoozes {
+		if s.PRIdentifier == snooze.PRIdentifier || snoozeExpired(s, now) {
+			continue
+		}
+		kept = append(kept, s)
+	}
+	r.config.Snoozes = append(kept, snooze)
+
+	logger.Log("Snoozing %s (until: %s, until updated: %t)", snooze.PRIdentifier, snooze.Until.Format(time.RFC3339), snooze.UntilUpdated)
+	return r.save()
+}
+
+func (r *LocalRepository) UnsnoozePR(prIdentifier string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.config.Snoozes[:0]
+	found := false
+	for _, s := range r.config.Snoozes {
+		if s.PRIdentifier == prIdentifier {
+			found = true
+			continue
+		}
+		kept = append(kept, s)
+	}
+	r.config.Snoozes = kept
+	if !found {
+		return nil
+	}
+
+	logger.Log("Unsnoozing %s", prIdentifier)
+	return r.save()
+}
+
+func (r *LocalRepository) ListSnoozes() ([]domain.PRSnooze, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	snoozes := make([]domain.PRSnooze, 0, len(r.config.Snoozes))
+	for _, s := range r.config.Snoozes {
+		if !snoozeExpired(s, now) {
+			snoozes = append(snoozes, s)
+		}
+	}
+	return snoozes, nil
+}
+
+func snoozeExpired(s domain.PRSnooze, now time.Time) bool {
+	if !s.Until.IsZero() && !now.Before(s.Until) {
+		return true
+	}
+	return now.Sub(s.CreatedAt) > snoozeRetention
+}
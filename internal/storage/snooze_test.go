@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestSnoozeAndUnsnoozePR(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", oldHome)
+
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	now := time.Now()
+	snoozes := []domain.PRSnooze{
+		{PRIdentifier: "github:acme/api/1", Until: now.Add(time.Hour)},
+		{PRIdentifier: "github:acme/api/2", UntilUpdated: true, PRUpdatedAt: now},
+		{PRIdentifier: "github:acme/api/3", Until: now.Add(-time.Minute)},
+	}
+	for _, s := range snoozes {
+		if err := repo.SnoozePR(s); err != nil {
+			t.Fatalf("Failed to snooze PR: %v", err)
+		}
+	}
+
+	reloaded, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to reload repository: %v", err)
+	}
+
+	listed, err := reloaded.ListSnoozes()
+	if err != nil {
+		t.Fatalf("Failed to list snoozes: %v", err)
+	}
+	if len(listed) != 2 {
+		t.Fatalf("Expected 2 live snoozes, got %d: %+v", len(listed), listed)
+	}
+	if listed[0].CreatedAt.IsZero() {
+		t.Error("Expected CreatedAt to be set")
+	}
+
+	if err := reloaded.UnsnoozePR("github:acme/api/1"); err != nil {
+		t.Fatalf("Failed to unsnooze PR: %v", err)
+	}
+	listed, _ = reloaded.ListSnoozes()
+	if len(listed) != 1 || listed[0].PRIdentifier != "github:acme/api/2" {
+		t.Errorf("Expected only the until-updated snooze to remain, got %+v", listed)
+	}
+}
+
+func TestSnoozePR_ReplacesExisting(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", oldHome)
+
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	later := time.Now().Add(24 * time.Hour)
+	repo.SnoozePR(domain.PRSnooze{PRIdentifier: "github:acme/api/1", Until: time.Now().Add(time.Hour)})
+	repo.SnoozePR(domain.PRSnooze{PRIdentifier: "github:acme/api/1", Until: later})
+
+	listed, _ := repo.ListSnoozes()
+	if len(listed) != 1 || !listed[0].Until.Equal(later) {
+		t.Errorf("Expected the snooze to be replaced, got %+v", listed)
+	}
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/johanforsgren/lgtmfaster/internal/crash"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// configReloadDelay lets a burst of events, such as an editor writing a
+// file in several steps, settle before the config file is read.
+const configReloadDelay = 250 * time.Millisecond
+
+// WatchConfig watches the config file of the profile in use for changes
+// made by anything but this repository and reloads it. The directory is
+// watched rather than the file, since editors and sync tools often replace
+// the file instead of writing to it, and since switching profiles changes
+// the file.
+func (r *LocalRepository) WatchConfig(ctx context.Context) (<-chan error, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config watcher: %w", err)
+	}
+	if err := watcher.Add(r.dir); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch %s: %w", r.dir, err)
+	}
+	logger.Log("Watching %s for changes", r.dir)
+
+	reloads := make(chan error, 1)
+	go func() {
+		defer crash.Guard()
+		defer watcher.Close()
+
+		var (
+			timer   *time.Timer
+			pending <-chan time.Time
+		)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(r.currentConfigPath()) || event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+					continue
+				}
+				if timer == nil {
+					timer = time.NewTimer(configReloadDelay)
+				} else {
+					timer.Reset(configReloadDelay)
+				}
+				pending = timer.C
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.LogError("CONFIG_WATCH", r.configPath, err)
+			case <-pending:
+				pending = nil
+				changed, err := r.reload()
+				if !changed {
+					continue
+				}
+				select {
+				case reloads <- err:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return reloads, nil
+}
+
+func (r *LocalRepository) currentConfigPath() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.configPath
+}
+
+// reload loads the config file again unless it is unchanged since it was last
+// loaded or saved. A file that cannot be parsed, for instance while it is
+// being edited, leaves the current configuration in place.
+func (r *LocalRepository) reload() (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := r.readConfig(r.configPath)
+	if os.IsNotExist(err) {
+		// Replaced by a rename that has not completed yet, or deleted; either
+		// way there is nothing to load.
+		return false, nil
+	}
+	if err != nil {
+		logger.LogError("RELOAD", r.configPath, err)
+		return true, err
+	}
+	if bytes.Equal(data, r.configData) {
+		return false, nil
+	}
+
+	config := &Config{PATs: []domain.PAT{}}
+	if err := json.Unmarshal(data, config); err != nil {
+		logger.LogError("RELOAD", r.configPath, err)
+		r.configData = data
+		return true, fmt.Errorf("%s is not valid JSON: %w", filepath.Base(r.configPath), err)
+	}
+
+	r.config = config
+	r.configData = data
+	logger.Log("Config reloaded from %s after an external change", r.configPath)
+	return true, nil
+}
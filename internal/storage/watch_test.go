@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func waitForReload(t *testing.T, reloads <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-reloads:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the config to be reloaded")
+		return nil
+	}
+}
+
+func TestWatchConfig_ReloadsExternalChanges(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	repo, err := NewLocalRepository()
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	if err := repo.SavePAT(domain.PAT{ID: "ours", Name: "Ours", Provider: domain.ProviderGitHub}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloads, err := repo.WatchConfig(ctx)
+	if err != nil {
+		t.Fatalf("Failed to watch config: %v", err)
+	}
+
+	if err := repo.SavePAT(domain.PAT{ID: "ours", Name: "Ours renamed", Provider: domain.ProviderGitHub}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-reloads:
+		t.Fatalf("expected our own save not to be reported as a reload, got %v", err)
+	case <-time.After(4 * configReloadDelay):
+	}
+
+	// Replace the file the way editors and sync tools do.
+	external := `{"pats": [{"ID": "synced", "Name": "Synced", "Provider": "azuredevops", "Organization": "contoso"}]}`
+	tmp := filepath.Join(filepath.Dir(repo.configPath), "config.json.tmp")
+	if err := os.WriteFile(tmp, []byte(external), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, repo.configPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := waitForReload(t, reloads); err != nil {
+		t.Fatalf("unexpected reload error: %v", err)
+	}
+
+	pats, err := repo.ListPATs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pats) != 1 || pats[0].ID != "synced" || pats[0].Organization != "contoso" {
+		t.Errorf("expected the synced PAT after the reload, got %+v", pats)
+	}
+
+	if err := os.WriteFile(repo.configPath, []byte(`{"pats": [`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := waitForReload(t, reloads); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+	if pats, _ := repo.ListPATs(); len(pats) != 1 || pats[0].ID != "synced" {
+		t.Errorf("expected the previous config to be kept, got %+v", pats)
+	}
+}
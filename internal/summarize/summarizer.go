@@ -0,0 +1,238 @@
+// Package summarize asks an external command or an OpenAI-compatible
+// endpoint for a summary of a pull request's changes.
+package summarize
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+const defaultMaxDiffBytes = 100_000
+
+// requestTimeout bounds a request to the endpoint. It is generous because a
+// model can take a while to read a large diff and write the summary.
+const requestTimeout = 2 * time.Minute
+
+var ErrNotConfigured = errors.New("summaries not configured: set settings.Summary.Command or Endpoint in config.json")
+
+// instructions tell the model what to produce. The two headings are what the
+// summary panel is named after.
+const instructions = `You are helping a code reviewer. Read the pull request below and reply in markdown with exactly two sections:
+
+## Summary of changes
+A few bullet points describing what the change does and why, at the level of behavior rather than individual lines.
+
+## Suggested review focus
+Bullet points naming the files, functions or risks that deserve the closest look (correctness, security, concurrency, error handling, missing tests), each with a short reason.
+
+Do not repeat the diff and do not invent details that are not in it.`
+
+// Summary is the markdown returned by the summarizer. Truncated is set when
+// only the start of the diff was sent.
+type Summary struct {
+	Text      string
+	Truncated bool
+}
+
+type Summarizer struct {
+	command      string
+	endpoint     string
+	apiKey       string
+	model        string
+	maxDiffBytes int
+	httpClient   *http.Client
+}
+
+func NewSummarizer(settings domain.SummarySettings) (*Summarizer, error) {
+	if settings.Command == "" && settings.Endpoint == "" {
+		return nil, ErrNotConfigured
+	}
+
+	maxDiffBytes := settings.MaxDiffBytes
+	if maxDiffBytes <= 0 {
+		maxDiffBytes = defaultMaxDiffBytes
+	}
+
+	return &Summarizer{
+		command:      settings.Command,
+		endpoint:     settings.Endpoint,
+		apiKey:       settings.APIKey,
+		model:        settings.Model,
+		maxDiffBytes: maxDiffBytes,
+		httpClient:   &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// Summarize sends the PR's title, description and diff to the summarizer.
+func (s *Summarizer) Summarize(ctx context.Context, pr domain.PullRequest, diff string) (Summary, error) {
+	content, truncated := buildContent(pr, diff, s.maxDiffBytes)
+
+	var text string
+	var err error
+	if s.command != "" {
+		text, err = s.summarizeWithCommand(ctx, content)
+	} else {
+		text, err = s.summarizeWithEndpoint(ctx, content)
+	}
+	if err != nil {
+		return Summary{}, err
+	}
+	if strings.TrimSpace(text) == "" {
+		return Summary{}, errors.New("summarizer returned an empty summary")
+	}
+	return Summary{Text: strings.TrimSpace(text), Truncated: truncated}, nil
+}
+
+// buildContent describes the PR for the model, keeping at most maxDiffBytes
+// of the diff. The diff is cut at a line boundary.
+func buildContent(pr domain.PullRequest, diff string, maxDiffBytes int) (string, bool) {
+	truncated := false
+	if len(diff) > maxDiffBytes {
+		cut := diff[:maxDiffBytes]
+		if i := strings.LastIndex(cut, "\n"); i > 0 {
+			cut = cut[:i+1]
+		}
+		diff = cut + fmt.Sprintf("\n[diff truncated: %d of %d bytes shown]\n", len(cut), len(diff))
+		truncated = true
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Title: %s\n", pr.Title)
+	fmt.Fprintf(&b, "Repository: %s\n", pr.Repository.FullName)
+	fmt.Fprintf(&b, "Branches: %s -> %s\n\n", pr.SourceBranch, pr.TargetBranch)
+	if description := strings.TrimSpace(pr.Description); description != "" {
+		b.WriteString("Description:\n")
+		b.WriteString(description)
+		b.WriteString("\n\n")
+	}
+	b.WriteString("Diff:\n")
+	b.WriteString(diff)
+	return b.String(), truncated
+}
+
+func (s *Summarizer) summarizeWithCommand(ctx context.Context, content string) (string, error) {
+	parts := strings.Fields(s.command)
+	if len(parts) == 0 {
+		return "", ErrNotConfigured
+	}
+
+	logger.Log("Summarize: Running command %s", parts[0])
+
+	cmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
+	cmd.Stdin = strings.NewReader(instructions + "\n\n" + content)
+	cmd.Env = os.Environ()
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		logger.LogError("SUMMARIZE_COMMAND", parts[0], err)
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("summary command failed: %s", msg)
+		}
+		return "", fmt.Errorf("summary command failed: %w", err)
+	}
+
+	return string(out), nil
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model    string        `json:"model,omitempty"`
+	Messages []chatMessage `json:"messages"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// chatCompletionsURL accepts either the full chat completions URL or the
+// API base URL, such as "https://api.openai.com/v1".
+func (s *Summarizer) chatCompletionsURL() string {
+	url := strings.TrimRight(s.endpoint, "/")
+	if strings.HasSuffix(url, "/chat/completions") {
+		return url
+	}
+	return url + "/chat/completions"
+}
+
+// summarizeWithEndpoint speaks the OpenAI chat completions protocol, which
+// most hosted and local model servers implement.
+func (s *Summarizer) summarizeWithEndpoint(ctx context.Context, content string) (string, error) {
+	url := s.chatCompletionsURL()
+	logger.Log("Summarize: Calling endpoint %s (model: %s)", url, s.model)
+
+	payload, err := json.Marshal(chatRequest{
+		Model: s.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: instructions},
+			{Role: "user", Content: content},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode summary request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create summary request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		logger.LogError("SUMMARIZE_ENDPOINT", url, err)
+		return "", fmt.Errorf("summary request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read summary response: %w", err)
+	}
+
+	var result chatResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("summary failed with status %d", resp.StatusCode)
+		}
+		return "", fmt.Errorf("failed to decode summary response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if result.Error != nil && result.Error.Message != "" {
+			return "", fmt.Errorf("summary failed with status %d: %s", resp.StatusCode, result.Error.Message)
+		}
+		return "", fmt.Errorf("summary failed with status %d", resp.StatusCode)
+	}
+	if len(result.Choices) == 0 {
+		return "", errors.New("summary response contained no choices")
+	}
+
+	return result.Choices[0].Message.Content, nil
+}
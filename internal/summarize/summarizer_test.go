@@ -0,0 +1,140 @@
+package summarize
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+var testPR = domain.PullRequest{
+	Title:        "Add retries",
+	Description:  "Retries failed uploads.",
+	Repository:   domain.Repo{FullName: "acme/api"},
+	SourceBranch: "feature/retry",
+	TargetBranch: "main",
+}
+
+func TestNewSummarizer_NotConfigured(t *testing.T) {
+	_, err := NewSummarizer(domain.SummarySettings{})
+	if !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("expected ErrNotConfigured, got %v", err)
+	}
+}
+
+func TestBuildContent_TruncatesAtLineBoundary(t *testing.T) {
+	diff := "=== a.go ===\n+one\n+two\n+three\n"
+
+	content, truncated := buildContent(testPR, diff, 20)
+	if !truncated {
+		t.Fatal("expected the diff to be truncated")
+	}
+	if !strings.Contains(content, "=== a.go ===\n+one\n\n[diff truncated: 18 of 30 bytes shown]") {
+		t.Errorf("unexpected truncated content:\n%s", content)
+	}
+	if !strings.Contains(content, "Title: Add retries\n") || !strings.Contains(content, "Description:\nRetries failed uploads.") {
+		t.Errorf("expected the PR title and description, got:\n%s", content)
+	}
+
+	if _, truncated := buildContent(testPR, diff, 1000); truncated {
+		t.Error("expected a short diff to be sent whole")
+	}
+}
+
+func TestSummarize_Command(t *testing.T) {
+	summarizer, err := NewSummarizer(domain.SummarySettings{Command: "grep -e ^Title -e ^+two"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	summary, err := summarizer.Summarize(context.Background(), testPR, "+one\n+two\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Text != "Title: Add retries\n+two" || summary.Truncated {
+		t.Errorf("unexpected summary: %#v", summary)
+	}
+}
+
+func TestSummarize_CommandFailure(t *testing.T) {
+	summarizer, _ := NewSummarizer(domain.SummarySettings{Command: "false"})
+
+	if _, err := summarizer.Summarize(context.Background(), testPR, "+x\n"); err == nil {
+		t.Fatal("expected an error from a failing command")
+	}
+}
+
+func TestSummarize_Endpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("unexpected Authorization header %q", got)
+		}
+		var req chatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if req.Model != "small" || len(req.Messages) != 2 || req.Messages[0].Role != "system" {
+			t.Errorf("unexpected request: %#v", req)
+		}
+		if !strings.Contains(req.Messages[1].Content, "+retry()") {
+			t.Errorf("expected the diff in the user message, got %q", req.Messages[1].Content)
+		}
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"## Summary of changes\n- Retries\n"}}]}`))
+	}))
+	defer server.Close()
+
+	summarizer, err := NewSummarizer(domain.SummarySettings{Endpoint: server.URL + "/v1/", APIKey: "secret", Model: "small"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	summary, err := summarizer.Summarize(context.Background(), testPR, "+retry()\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Text != "## Summary of changes\n- Retries" {
+		t.Errorf("unexpected summary %q", summary.Text)
+	}
+}
+
+func TestSummarize_EndpointError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"message":"invalid api key"}}`))
+	}))
+	defer server.Close()
+
+	summarizer, _ := NewSummarizer(domain.SummarySettings{Endpoint: server.URL + "/chat/completions"})
+	_, err := summarizer.Summarize(context.Background(), testPR, "+x\n")
+	if err == nil || !strings.Contains(err.Error(), "invalid api key") {
+		t.Fatalf("expected the API error message, got %v", err)
+	}
+}
+
+func TestSummarize_EndpointTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	summarizer, _ := NewSummarizer(domain.SummarySettings{Endpoint: server.URL + "/chat/completions"})
+	if summarizer.httpClient.Timeout != requestTimeout {
+		t.Fatalf("expected the endpoint to be called with a %s timeout, got %s", requestTimeout, summarizer.httpClient.Timeout)
+	}
+	summarizer.httpClient.Timeout = 50 * time.Millisecond
+
+	if _, err := summarizer.Summarize(context.Background(), testPR, "+x\n"); err == nil {
+		t.Fatal("expected an unresponsive endpoint to time out")
+	}
+}
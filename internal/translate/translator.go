@@ -0,0 +1,214 @@
+package translate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+const defaultTargetLanguage = "en"
+
+// requestTimeout bounds a request to the endpoint, which translates one
+// segment of the text at a time.
+const requestTimeout = 30 * time.Second
+
+var ErrNotConfigured = errors.New("translation not configured: set settings.Translation.Command or Endpoint in config.json")
+
+type Translator struct {
+	command        string
+	endpoint       string
+	apiKey         string
+	targetLanguage string
+	httpClient     *http.Client
+}
+
+func NewTranslator(settings domain.TranslationSettings) (*Translator, error) {
+	if settings.Command == "" && settings.Endpoint == "" {
+		return nil, ErrNotConfigured
+	}
+
+	target := settings.TargetLanguage
+	if target == "" {
+		target = defaultTargetLanguage
+	}
+
+	return &Translator{
+		command:        settings.Command,
+		endpoint:       settings.Endpoint,
+		apiKey:         settings.APIKey,
+		targetLanguage: target,
+		httpClient:     &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+func (t *Translator) TargetLanguage() string {
+	return t.targetLanguage
+}
+
+// Translate translates markdown text segment by segment, leaving fenced code
+// blocks untouched so that code samples survive the round trip.
+func (t *Translator) Translate(ctx context.Context, text string) (string, error) {
+	var b strings.Builder
+	for _, segment := range splitSegments(text) {
+		if segment.code || strings.TrimSpace(segment.text) == "" {
+			b.WriteString(segment.text)
+			continue
+		}
+
+		translated, err := t.translateText(ctx, segment.text)
+		if err != nil {
+			return "", err
+		}
+
+		b.WriteString(strings.TrimRight(translated, "\n"))
+		if strings.HasSuffix(segment.text, "\n") {
+			b.WriteString("\n")
+		}
+	}
+	return b.String(), nil
+}
+
+func (t *Translator) translateText(ctx context.Context, text string) (string, error) {
+	if t.command != "" {
+		return t.translateWithCommand(ctx, text)
+	}
+	return t.translateWithEndpoint(ctx, text)
+}
+
+func (t *Translator) translateWithCommand(ctx context.Context, text string) (string, error) {
+	parts := strings.Fields(t.command)
+	if len(parts) == 0 {
+		return "", ErrNotConfigured
+	}
+
+	logger.Log("Translate: Running command %s (target: %s)", parts[0], t.targetLanguage)
+
+	cmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Env = append(os.Environ(), "LGTMFASTER_TARGET_LANG="+t.targetLanguage)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		logger.LogError("TRANSLATE_COMMAND", parts[0], err)
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("translation command stopped: %w", ctx.Err())
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("translation command failed: %s", msg)
+		}
+		return "", fmt.Errorf("translation command failed: %w", err)
+	}
+
+	return string(out), nil
+}
+
+type endpointRequest struct {
+	Q      string `json:"q"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Format string `json:"format"`
+	APIKey string `json:"api_key,omitempty"`
+}
+
+type endpointResponse struct {
+	TranslatedText string `json:"translatedText"`
+	Error          string `json:"error"`
+}
+
+// translateWithEndpoint speaks the LibreTranslate /translate protocol.
+func (t *Translator) translateWithEndpoint(ctx context.Context, text string) (string, error) {
+	logger.Log("Translate: Calling endpoint %s (target: %s)", t.endpoint, t.targetLanguage)
+
+	payload, err := json.Marshal(endpointRequest{
+		Q:      text,
+		Source: "auto",
+		Target: t.targetLanguage,
+		Format: "text",
+		APIKey: t.apiKey,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode translation request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create translation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		logger.LogError("TRANSLATE_ENDPOINT", t.endpoint, err)
+		return "", fmt.Errorf("translation request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read translation response: %w", err)
+	}
+
+	var result endpointResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to decode translation response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if result.Error != "" {
+			return "", fmt.Errorf("translation failed with status %d: %s", resp.StatusCode, result.Error)
+		}
+		return "", fmt.Errorf("translation failed with status %d", resp.StatusCode)
+	}
+
+	return result.TranslatedText, nil
+}
+
+type segment struct {
+	text string
+	code bool
+}
+
+func splitSegments(text string) []segment {
+	var segments []segment
+	var current strings.Builder
+	inCode := false
+
+	flush := func(code bool) {
+		if current.Len() > 0 {
+			segments = append(segments, segment{text: current.String(), code: code})
+			current.Reset()
+		}
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			if inCode {
+				current.WriteString(line)
+				flush(true)
+				inCode = false
+				continue
+			}
+			flush(false)
+			inCode = true
+		}
+		current.WriteString(line)
+	}
+	flush(inCode)
+
+	return segments
+}
@@ -0,0 +1,143 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestNewTranslator_NotConfigured(t *testing.T) {
+	_, err := NewTranslator(domain.TranslationSettings{})
+	if !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("expected ErrNotConfigured, got %v", err)
+	}
+}
+
+func TestNewTranslator_DefaultsTargetLanguage(t *testing.T) {
+	translator, err := NewTranslator(domain.TranslationSettings{Command: "cat"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if translator.TargetLanguage() != "en" {
+		t.Errorf("expected default target language 'en', got %q", translator.TargetLanguage())
+	}
+}
+
+func TestSplitSegments_SeparatesCodeBlocks(t *testing.T) {
+	text := "Hola mundo\n```go\nfunc main() {}\n```\nAdiós\n"
+
+	segments := splitSegments(text)
+	if len(segments) != 3 {
+		t.Fatalf("expected 3 segments, got %d: %#v", len(segments), segments)
+	}
+
+	if segments[0].code || segments[0].text != "Hola mundo\n" {
+		t.Errorf("unexpected first segment: %#v", segments[0])
+	}
+	if !segments[1].code || segments[1].text != "```go\nfunc main() {}\n```\n" {
+		t.Errorf("unexpected code segment: %#v", segments[1])
+	}
+	if segments[2].code || segments[2].text != "Adiós\n" {
+		t.Errorf("unexpected last segment: %#v", segments[2])
+	}
+}
+
+func TestTranslate_CommandPreservesCodeBlocks(t *testing.T) {
+	translator, err := NewTranslator(domain.TranslationSettings{Command: "tr a-z A-Z"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	text := "hello\n```\nkeep me\n```\nbye"
+	result, err := translator.Translate(context.Background(), text)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "HELLO\n```\nkeep me\n```\nBYE"
+	if result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+}
+
+func TestTranslate_Endpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req endpointRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if req.Target != "sv" {
+			t.Errorf("expected target 'sv', got %q", req.Target)
+		}
+		json.NewEncoder(w).Encode(endpointResponse{TranslatedText: strings.ToUpper(req.Q)})
+	}))
+	defer server.Close()
+
+	translator, err := NewTranslator(domain.TranslationSettings{Endpoint: server.URL, TargetLanguage: "sv"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := translator.Translate(context.Background(), "hej")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != "HEJ" {
+		t.Errorf("expected 'HEJ', got %q", result)
+	}
+}
+
+func TestTranslate_EndpointError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(endpointResponse{Error: "unsupported language"})
+	}))
+	defer server.Close()
+
+	translator, _ := NewTranslator(domain.TranslationSettings{Endpoint: server.URL})
+
+	_, err := translator.Translate(context.Background(), "hej")
+	if err == nil || !strings.Contains(err.Error(), "unsupported language") {
+		t.Errorf("expected error mentioning 'unsupported language', got %v", err)
+	}
+}
+
+func TestTranslate_EndpointTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	translator, _ := NewTranslator(domain.TranslationSettings{Endpoint: server.URL})
+	if translator.httpClient.Timeout != requestTimeout {
+		t.Fatalf("expected the endpoint to be called with a %s timeout, got %s", requestTimeout, translator.httpClient.Timeout)
+	}
+	translator.httpClient.Timeout = 50 * time.Millisecond
+
+	if _, err := translator.Translate(context.Background(), "hej"); err == nil {
+		t.Fatal("expected an unresponsive endpoint to time out")
+	}
+}
+
+func TestTranslate_CommandStopsAtDeadline(t *testing.T) {
+	translator, _ := NewTranslator(domain.TranslationSettings{Command: "sleep 10"})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := translator.Translate(ctx, "hej"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the command to be stopped at the deadline, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the command to stop at the deadline, took %s", elapsed)
+	}
+}
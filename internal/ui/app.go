@@ -2,22 +2,40 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/google/uuid"
+	"github.com/johanforsgren/lgtmfaster/internal/attachment"
+	"github.com/johanforsgren/lgtmfaster/internal/auth"
+	"github.com/johanforsgren/lgtmfaster/internal/crash"
 	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/hooks"
 	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/network"
+	"github.com/johanforsgren/lgtmfaster/internal/outline"
 	"github.com/johanforsgren/lgtmfaster/internal/provider/azuredevops"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/gerrit"
 	"github.com/johanforsgren/lgtmfaster/internal/provider/github"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/plugin"
+	"github.com/johanforsgren/lgtmfaster/internal/spell"
+	"github.com/johanforsgren/lgtmfaster/internal/summarize"
 	"github.com/johanforsgren/lgtmfaster/internal/ui/components"
 	"github.com/johanforsgren/lgtmfaster/internal/ui/views"
+	"github.com/johanforsgren/lgtmfaster/internal/update"
+	"github.com/johanforsgren/lgtmfaster/internal/version"
+	"github.com/johanforsgren/lgtmfaster/internal/webhook"
 )
 
 type ViewState int
@@ -32,10 +50,12 @@ const prCacheTTL = 30 * time.Second
 
 type LoadingState struct {
 	IsLoading         bool
+	LoadID            uint64
 	TotalPATs         int
 	LoadedPATs        int
 	AccumulatedGroups []domain.PRGroup
 	FailedPATs        []string
+	SkippedPATs       []string
 }
 
 type PRCache struct {
@@ -52,35 +72,137 @@ const (
 	EditorSourceDescriptionEdit
 )
 
+// prLeaseHeartbeat is how often the lease on the open PR is refreshed. It has
+// to stay well below the storage lease TTL.
+const prLeaseHeartbeat = 30 * time.Second
+
+const patValidationTimeout = 10 * time.Second
+
+const updateCheckTimeout = 10 * time.Second
+
+// Diffs listing more files, or changing more lines, than these are loaded a
+// file at a time as the files are navigated to, when the provider can.
+const (
+	lazyDiffMaxFiles = 100
+	lazyDiffMaxLines = 5000
+)
+
 type Model struct {
-	state             ViewState
-	width             int
-	height            int
-	topBar            *components.TopBarModel
-	statusBar         *components.StatusBarModel
-	commandBar        *components.CommandBarModel
-	patsView          *views.PATsViewModel
-	prListView        *views.PRListViewModel
-	prInspect         *views.PRInspectViewModel
+	state               ViewState
+	width               int
+	height              int
+	topBar              *components.TopBarModel
+	statusBar           *components.StatusBarModel
+	commandBar          *components.CommandBarModel
+	patsView            *views.PATsViewModel
+	prListView          *views.PRListViewModel
+	prInspect           *views.PRInspectViewModel
 	reviewView          *views.ReviewViewModel
 	mergeView           *views.MergeViewModel
+	snoozeView          *views.SnoozeViewModel
+	reviewersView       *views.ReviewersViewModel
+	dependenciesView    *views.DependenciesViewModel
 	inlineCommentView   *views.InlineCommentViewModel
 	commentDetailView   *views.CommentDetailViewModel
 	descriptionEditView *views.DescriptionEditViewModel
 	logsView            *views.LogsViewModel
-	repository        domain.Repository
-	provider          domain.Provider
-	providers         map[string]domain.Provider
-	primaryProvider   domain.Provider
-	primaryPATID      string
-	ctx               context.Context
-	commandRegistry   *CommandRegistry
-	isInitialStartup  bool
-	loadingState      LoadingState
-	spinner           spinner.Model
-	prCache           *PRCache
-	editorTempFile    string
-	editorSource      EditorSource
+	translationView     *views.TranslationViewModel
+	summaryView         *views.SummaryViewModel
+	checksView          *views.ChecksViewModel
+	statsView           *views.StatsViewModel
+	perfView            *views.PerfViewModel
+	changelogView       *views.ChangelogViewModel
+	resumeView          *views.ResumeViewModel
+	crashRecoveryView   *views.CrashRecoveryViewModel
+	outboxView          *views.OutboxViewModel
+	messagesView        *views.MessagesViewModel
+	outlineView         *views.OutlineViewModel
+	repoPickerView      *views.RepoPickerViewModel
+	teamBoardView       *views.TeamBoardViewModel
+	repository          domain.Repository
+	provider            domain.Provider
+	providers           map[string]domain.Provider
+	primaryProvider     domain.Provider
+	primaryPATID        string
+	ctx                 context.Context
+	requestTimeout      time.Duration
+	listLoad            *loadTracker
+	prLoad              *loadTracker
+	prData              *prDataCache
+	tasks               *taskTracker
+	offline             offlineState
+	flushingReviews     bool
+	commandRegistry     *CommandRegistry
+	isInitialStartup    bool
+	loadingState        LoadingState
+	spinner             spinner.Model
+	prCache             *PRCache
+	editorTempFile      string
+	editorSource        EditorSource
+	inspectStartedAt    time.Time
+	webhookServer       *webhook.Server
+	// configReloads reports reloads of the configuration after it was
+	// changed outside the application; nil when it is not watched.
+	configReloads   <-chan error
+	invalidPATs     map[string]string
+	leasedPR        string
+	leaseGeneration int
+	leaseWarned     bool
+	// refreshGeneration invalidates the refresh ticks of PRs no longer open.
+	refreshGeneration int
+	latestRelease     *update.Release
+	// offerResume is set on startup until the first PR load, when the saved
+	// session is offered for resuming.
+	offerResume bool
+	// lastSession is the session as of when the PR list was last left, so
+	// that quitting from the PATs view still saves it.
+	lastSession *domain.Session
+	// pendingSelection is the key of a resumed PR to select once it is loaded
+	// into the list, and pendingPosition the diff position to restore once
+	// the resumed PR's diff is loaded.
+	pendingSelection string
+	pendingPosition  *domain.SessionPR
+	// memoryHead is the head commit of the open PR whose diff and comments
+	// were shown from prData, until the PR's details confirm it is current.
+	memoryHead string
+	// commentStream is when the comments of the open PR being loaded a page
+	// at a time started loading; pages of streams started earlier are
+	// dropped.
+	commentStream time.Time
+	// fileDiffLoads holds the paths of the deferred diff files of the open
+	// PR whose hunks were requested, so that each is loaded once.
+	fileDiffLoads map[string]bool
+	// pendingKeys holds the keys typed so far of a multi-key binding.
+	pendingKeys string
+	heldReview  *heldReview
+	// triage is set while stepping through the PRs waiting for review.
+	triage *triageSession
+	// teamMembers are the teammates the team board was last opened for.
+	teamMembers []string
+	// reviewTimer times the open PR while settings.Review.Timer is on,
+	// reviewTimeSpent holds the time spent on each PR this session, and
+	// reviewTimerGeneration invalidates the ticks of stopped timers.
+	reviewTimer           *reviewTimer
+	reviewTimeSpent       map[string]time.Duration
+	reviewTimerGeneration int
+	// draftBase is what the open editor started with before any draft was
+	// restored, and draftGeneration invalidates pending autosaves.
+	draftBase       string
+	draftGeneration int
+	// crashReport is the report of the crash of the last run, brought up
+	// once the PR list is first loaded.
+	crashReport string
+}
+
+// heldReview is a submitted review waiting out its undo window. The editor
+// state is kept so that undoing reopens the review as it was.
+type heldReview struct {
+	id       int
+	prKey    string
+	mode     views.ReviewMode
+	body     string
+	deadline time.Time
+	submit   func(*task) tea.Msg
 }
 
 func NewModel(repository domain.Repository) Model {
@@ -88,31 +210,141 @@ func NewModel(repository domain.Repository) Model {
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED"))
 
-	return Model{
-		state:             ViewPATs,
-		topBar:            components.NewTopBar(),
-		statusBar:         components.NewStatusBar(),
-		commandBar:        components.NewCommandBar(),
-		patsView:          views.NewPATsView(),
-		prListView:        views.NewPRListView(),
-		prInspect:         views.NewPRInspectView(),
-		reviewView:          views.NewReviewView(),
+	settings, err := repository.GetSettings()
+	if err != nil {
+		logger.LogError("SETTINGS_LOAD", "", err)
+	}
+	if err := network.Configure(settings.Network); err != nil {
+		logger.LogError("NETWORK_INIT", "", err)
+	}
+	common.SetMaxAttempts(settings.Network.RequestAttempts())
+
+	var webhookServer *webhook.Server
+	if settings.Webhook.ListenAddr != "" {
+		webhookServer, err = webhook.NewServer(settings.Webhook)
+		if err != nil {
+			logger.LogError("WEBHOOK_INIT", settings.Webhook.ListenAddr, err)
+		}
+	}
+
+	var configReloads <-chan error
+	if watcher, ok := repository.(domain.ConfigWatcher); ok {
+		configReloads, err = watcher.WatchConfig(context.Background())
+		if err != nil {
+			logger.LogError("CONFIG_WATCH", "", err)
+		}
+	}
+
+	prInspect := views.NewPRInspectView()
+	prInspect.SetDiffStyles(DiffStyles)
+	prInspect.SetDiffShading(settings.Display.DiffBackground)
+	prInspect.SetWrapLines(settings.Display.WrapDiffLines)
+	prInspect.SetPlain(settings.Display.PlainDiff)
+
+	reviewView := views.NewReviewView()
+	inlineCommentView := views.NewInlineCommentView()
+	if settings.SpellCheck.Enabled {
+		if dict, err := loadDictionary(settings.SpellCheck); err != nil {
+			logger.LogError("SPELL_LOAD", settings.SpellCheck.Dictionary, err)
+		} else {
+			reviewView.SetDictionary(dict)
+			inlineCommentView.SetDictionary(dict)
+		}
+	}
+
+	prListView := views.NewPRListView()
+	prListView.SetDraftDisplay(settings.Display.Drafts)
+	prListView.SetDetailed(settings.Display.DetailedRows)
+	if snoozes, err := repository.ListSnoozes(); err != nil {
+		logger.LogError("SNOOZE_LOAD", "", err)
+	} else {
+		prListView.SetSnoozes(snoozes)
+	}
+	if seen, err := repository.GetSeenPRs(); err != nil {
+		logger.LogError("SEEN_LOAD", "", err)
+	} else {
+		prListView.SetSeen(seen)
+	}
+	if pins, err := repository.GetPins(); err != nil {
+		logger.LogError("PINS_LOAD", "", err)
+	} else {
+		prListView.SetPins(pins)
+	}
+
+	m := Model{
+		state:               ViewPATs,
+		topBar:              components.NewTopBar(),
+		statusBar:           components.NewStatusBar(),
+		commandBar:          components.NewCommandBar(),
+		patsView:            views.NewPATsView(),
+		prListView:          prListView,
+		prInspect:           prInspect,
+		reviewView:          reviewView,
 		mergeView:           views.NewMergeView(),
-		inlineCommentView:   views.NewInlineCommentView(),
+		snoozeView:          views.NewSnoozeView(),
+		reviewersView:       views.NewReviewersView(),
+		dependenciesView:    views.NewDependenciesView(),
+		inlineCommentView:   inlineCommentView,
 		commentDetailView:   views.NewCommentDetailView(),
 		descriptionEditView: views.NewDescriptionEditView(),
 		logsView:            views.NewLogsView(),
-		repository:        repository,
-		providers:         make(map[string]domain.Provider),
-		ctx:               context.Background(),
-		commandRegistry:   NewCommandRegistry(),
-		isInitialStartup:  true,
-		spinner:           s,
+		translationView:     views.NewTranslationView(),
+		summaryView:         views.NewSummaryView(),
+		checksView:          views.NewChecksView(),
+		statsView:           views.NewStatsView(),
+		perfView:            views.NewPerfView(),
+		changelogView:       views.NewChangelogView(),
+		resumeView:          views.NewResumeView(),
+		crashRecoveryView:   views.NewCrashRecoveryView(),
+		outboxView:          views.NewOutboxView(),
+		messagesView:        views.NewMessagesView(),
+		outlineView:         views.NewOutlineView(),
+		repoPickerView:      views.NewRepoPickerView(),
+		teamBoardView:       views.NewTeamBoardView(),
+		repository:          repository,
+		providers:           make(map[string]domain.Provider),
+		ctx:                 context.Background(),
+		requestTimeout:      settings.Network.RequestTimeout(),
+		listLoad:            newLoadTracker(),
+		tasks:               newTaskTracker(),
+		prLoad:              newLoadTracker(),
+		prData:              newPRDataCache(),
+		commandRegistry:     NewCommandRegistry(),
+		isInitialStartup:    true,
+		spinner:             s,
+		webhookServer:       webhookServer,
+		configReloads:       configReloads,
+	}
+	if store, ok := repository.(domain.ProfileStore); ok {
+		m.topBar.SetProfile(store.Profile())
+	}
+	m.notifyConfigRecovery()
+	m.reloadStartedReviews()
+	return m
+}
+
+// notifyConfigRecovery warns when the repository had to recover from a
+// corrupt config file.
+func (m Model) notifyConfigRecovery() {
+	if recoverer, ok := m.repository.(domain.ConfigRecoverer); ok {
+		if notice := recoverer.TakeRecoveryNotice(); notice != "" {
+			m.statusBar.Notify(notice, components.SeverityWarning)
+		}
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	return m.loadPATs()
+	cmds := []tea.Cmd{m.loadPATs()}
+	if m.webhookServer != nil {
+		cmds = append(cmds, m.startWebhookServer())
+	}
+	if m.configReloads != nil {
+		cmds = append(cmds, m.waitForConfigReload())
+	}
+	if settings, err := m.repository.GetSettings(); err == nil && update.Enabled(settings.Updates) {
+		cmds = append(cmds, m.checkForUpdates())
+	}
+	return guardCmd(tea.Batch(cmds...))
 }
 
 func (m Model) isInInputMode() bool {
@@ -125,6 +357,15 @@ func (m Model) isInInputMode() bool {
 	if m.mergeView.IsActive() {
 		return true
 	}
+	if m.snoozeView.IsActive() {
+		return true
+	}
+	if m.reviewersView.IsActive() {
+		return true
+	}
+	if m.dependenciesView.IsActive() {
+		return true
+	}
 	if m.inlineCommentView.IsActive() {
 		return true
 	}
@@ -134,9 +375,48 @@ func (m Model) isInInputMode() bool {
 	if m.logsView.IsActive() {
 		return true
 	}
+	if m.statsView.IsActive() {
+		return true
+	}
+	if m.perfView.IsActive() {
+		return true
+	}
 	if m.descriptionEditView.IsActive() {
 		return true
 	}
+	if m.translationView.IsActive() {
+		return true
+	}
+	if m.summaryView.IsActive() {
+		return true
+	}
+	if m.checksView.IsActive() {
+		return true
+	}
+	if m.changelogView.IsActive() {
+		return true
+	}
+	if m.resumeView.IsActive() {
+		return true
+	}
+	if m.crashRecoveryView.IsActive() {
+		return true
+	}
+	if m.outboxView.IsActive() {
+		return true
+	}
+	if m.outlineView.IsActive() {
+		return true
+	}
+	if m.repoPickerView.IsActive() {
+		return true
+	}
+	if m.teamBoardView.IsActive() {
+		return true
+	}
+	if m.messagesView.IsActive() {
+		return true
+	}
 	if m.state == ViewPATs && (m.patsView.Mode == views.PATModeAdd || m.patsView.Mode == views.PATModeEdit) {
 		return true
 	}
@@ -146,25 +426,35 @@ func (m Model) isInInputMode() bool {
 	return false
 }
 
+// Update handles msg, then schedules the expiry of the notifications left
+// stacked above the status bar, which any message handler may add to, and
+// loads the hunks of a deferred diff file navigated to.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer func() { crash.Repanic(recover()) }()
+
+	updated, cmd := m.update(msg)
+	model, ok := updated.(Model)
+	if !ok || model.statusBar == nil {
+		return updated, guardCmd(cmd)
+	}
+	var fileCmd tea.Cmd
+	model, fileCmd = model.loadCurrentFileDiff()
+	cmd = tea.Batch(cmd, fileCmd)
+	if expiry := model.statusBar.ScheduleExpiry(); !expiry.IsZero() {
+		cmd = tea.Batch(cmd, tea.Tick(time.Until(expiry), func(time.Time) tea.Msg {
+			return NotificationsExpiredMsg{}
+		}))
+	}
+	return model, guardCmd(cmd)
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-		m.topBar.SetWidth(msg.Width)
-		m.statusBar.SetWidth(msg.Width)
-		m.commandBar.SetWidth(msg.Width)
-		m.patsView.SetSize(msg.Width, msg.Height)
-		m.prListView.SetSize(msg.Width, msg.Height)
-		m.prInspect.SetSize(msg.Width, msg.Height)
-		m.reviewView.SetSize(msg.Width, msg.Height)
-		m.inlineCommentView.SetSize(msg.Width, msg.Height)
-		m.descriptionEditView.SetSize(msg.Width, msg.Height)
-		m.commentDetailView.SetSize(msg.Width, msg.Height)
-		m.logsView.SetSize(msg.Width, msg.Height)
+		m.resize(msg.Width, msg.Height)
 
 	case tea.KeyMsg:
 		key := msg.String()
@@ -183,26 +473,70 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
+			if m.reviewView.IsActive() && m.reviewView.IsConfirming() {
+				switch key {
+				case "enter", "y":
+					if unchecked := m.reviewView.UncheckedRequired(); unchecked > 0 && m.reviewView.GetMode() == views.ReviewModeApprove {
+						m.statusBar.SetMessage(fmt.Sprintf("Check the %d required checklist item(s) before approving", unchecked), true)
+						return m, nil
+					}
+					if pr := m.prInspect.GetPR(); pr != nil {
+						m.discardDraft(reviewDraftKey(*pr))
+					}
+					return m.confirmReview()
+				case "esc", "e", "n":
+					m.reviewView.BackToEdit()
+				case "left", "h", "shift+tab":
+					m.reviewView.CycleVerdict(-1)
+					m.reviewView.SetNote(m.reviewConfirmationNote())
+				case "right", "l", "tab":
+					m.reviewView.CycleVerdict(1)
+					m.reviewView.SetNote(m.reviewConfirmationNote())
+				case "a":
+					m.reviewView.CycleAccount(1)
+					m.reviewView.SetNote(m.reviewConfirmationNote())
+				case "up", "k":
+					m.reviewView.MoveChecklist(-1)
+				case "down", "j":
+					m.reviewView.MoveChecklist(1)
+				case " ":
+					m.reviewView.ToggleChecklistItem()
+				}
+				return m, nil
+			}
+
 			if m.reviewView.IsActive() {
+				if m.reviewView.HandleMentionKey(msg) {
+					return m, m.scheduleDraftSave()
+				}
+				if handled, word := m.reviewView.HandleSpellKey(msg); handled {
+					m.addDictionaryWord(word)
+					return m, m.scheduleDraftSave()
+				}
 				switch key {
 				case "ctrl+s":
-					return m, m.submitReview()
+					m.showReviewConfirmation()
+					return m, nil
 				case "ctrl+g":
 					content := m.reviewView.GetValue()
 					return m, m.openExternalEditor(content, EditorSourceReview)
 				case "esc":
-					m.reviewView.Deactivate()
+					m.closeEditorKeepingDraft(m.reviewView.Deactivate)
 					return m, nil
 				default:
 					cmd = m.reviewView.Update(msg)
-					return m, cmd
+					return m, tea.Batch(cmd, m.scheduleDraftSave())
 				}
 			}
 
 			if m.mergeView.IsActive() {
 				switch key {
 				case "enter":
-					return m, m.executeMerge()
+					if m.mergeView.GetSelectedMethod() == "" {
+						m.statusBar.SetMessage("The repository does not allow this merge method", true)
+						return m, nil
+					}
+					return m, m.withTask("Merging", m.executeMerge())
 				case "esc":
 					m.mergeView.Deactivate()
 					return m, nil
@@ -212,32 +546,166 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case "down", "j":
 					m.mergeView.NextOption()
 					return m, nil
+				case "d", " ":
+					m.mergeView.ToggleDeleteBranch()
+					return m, nil
 				default:
 					cmd = m.mergeView.Update(msg)
 					return m, cmd
 				}
 			}
 
+			if m.reviewersView.IsActive() && m.reviewersView.IsDismissing() {
+				switch key {
+				case "enter":
+					return m.dismissSelectedReview()
+				case "esc":
+					m.reviewersView.CancelDismiss()
+					return m, nil
+				default:
+					cmd = m.reviewersView.Update(msg)
+					return m, cmd
+				}
+			}
+
+			if m.reviewersView.IsActive() {
+				switch key {
+				case "esc", "q":
+					m.reviewersView.Deactivate()
+				case "up", "k":
+					m.reviewersView.Prev()
+				case "down", "j":
+					m.reviewersView.Next()
+				case "d":
+					if reviewer := m.reviewersView.GetSelectedReviewer(); reviewer != nil && reviewer.ReviewID != "" {
+						m.reviewersView.StartDismiss()
+					} else {
+						m.statusBar.SetMessage("The selected reviewer has no review to dismiss", true)
+					}
+				case "r":
+					return m.rerequestSelectedReview()
+				}
+				return m, nil
+			}
+
+			if m.dependenciesView.IsActive() {
+				switch {
+				case m.dependenciesView.IsRunning():
+				case m.dependenciesView.IsConfirming():
+					switch key {
+					case "enter", "y":
+						return m.approveAndMergeDependencies()
+					case "esc", "n":
+						m.dependenciesView.CancelConfirm()
+					}
+				default:
+					switch key {
+					case "esc", "q":
+						m.dependenciesView.Deactivate()
+					case "up", "k":
+						m.dependenciesView.Prev()
+					case "down", "j":
+						m.dependenciesView.Next()
+					case " ":
+						m.dependenciesView.ToggleSelected()
+					case "a":
+						m.dependenciesView.ToggleAll()
+					case "o", "ctrl+o":
+						if url := m.dependenciesView.CurrentChangelogURL(); url != "" {
+							if err := openBrowser(url); err != nil {
+								m.statusBar.SetMessage(fmt.Sprintf("Failed to open browser: %v", err), true)
+							}
+						}
+					case "enter":
+						if len(m.dependenciesView.SelectedPRs()) == 0 {
+							m.statusBar.SetMessage("No dependency updates selected", true)
+						} else {
+							m.dependenciesView.StartConfirm()
+						}
+					}
+				}
+				return m, nil
+			}
+
+			if m.snoozeView.IsActive() {
+				switch key {
+				case "enter":
+					return m.snoozeSelectedPR()
+				case "esc":
+					m.snoozeView.Deactivate()
+					return m, nil
+				case "up", "k":
+					m.snoozeView.PrevOption()
+					return m, nil
+				case "down", "j":
+					m.snoozeView.NextOption()
+					return m, nil
+				default:
+					cmd = m.snoozeView.Update(msg)
+					return m, cmd
+				}
+			}
+
 			if m.inlineCommentView.IsActive() {
+				if m.inlineCommentView.HandleMentionKey(msg) {
+					return m, m.scheduleDraftSave()
+				}
+				if handled, word := m.inlineCommentView.HandleSpellKey(msg); handled {
+					m.addDictionaryWord(word)
+					return m, m.scheduleDraftSave()
+				}
 				switch key {
 				case "ctrl+s":
+					if draft, editor := m.activeDraft(); editor != nil {
+						m.discardDraft(draft.Key)
+					}
 					comment := m.inlineCommentView.GetComment()
-					if comment != "" {
+					if m.inlineCommentView.IsEditing() {
+						if strings.TrimSpace(comment) == "" {
+							m.prInspect.DeletePendingComment()
+							m.statusBar.SetMessage("Pending comment deleted", false)
+						} else {
+							m.prInspect.UpdatePendingComment(comment)
+							m.statusBar.SetMessage("Pending comment updated", false)
+						}
+					} else if comment != "" {
 						m.prInspect.AddPendingComment(comment)
 						m.statusBar.SetMessage("Inline comment added. Submit review to post.", false)
 					}
 					m.inlineCommentView.Deactivate()
-					return m, nil
+					return m, m.claimPRLease()
 				case "ctrl+g":
 					content := m.inlineCommentView.GetValue()
 					return m, m.openExternalEditor(content, EditorSourceInlineComment)
 				case "esc":
-					m.inlineCommentView.Deactivate()
+					m.closeEditorKeepingDraft(m.inlineCommentView.Deactivate)
 					return m, nil
 				default:
 					cmd = m.inlineCommentView.Update(msg)
-					return m, cmd
+					return m, tea.Batch(cmd, m.scheduleDraftSave())
+				}
+			}
+
+			if m.commentDetailView.IsActive() && m.commentDetailView.IsPickingStatus() {
+				if key == "esc" {
+					m.commentDetailView.CancelStatusPicker()
+					return m, nil
+				}
+				if status, ok := m.commentDetailView.PickStatus(key); ok {
+					return m.setSelectedThreadStatus(status)
+				}
+				return m, nil
+			}
+
+			if m.commentDetailView.IsActive() && m.commentDetailView.IsPickingReaction() {
+				if key == "esc" {
+					m.commentDetailView.CancelReactionPicker()
+					return m, nil
 				}
+				if content, ok := m.commentDetailView.PickReaction(key); ok {
+					return m.addReactionToSelectedComment(content)
+				}
+				return m, nil
 			}
 
 			if m.commentDetailView.IsActive() {
@@ -245,6 +713,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case "esc", "q":
 					m.commentDetailView.Deactivate()
 					return m, nil
+				case "tab":
+					m.commentDetailView.SelectNext()
+					return m, nil
+				case "shift+tab":
+					m.commentDetailView.SelectPrev()
+					return m, nil
+				case "g":
+					return m.jumpToCodeLens()
+				case "+":
+					return m.startReactionPicker()
+				case "s":
+					return m.startThreadStatusPicker()
+				case "enter":
+					return m.jumpToSelectedComment()
 				default:
 					cmd = m.commentDetailView.Update(msg)
 					return m, cmd
@@ -252,8 +734,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			if m.logsView.IsActive() {
+				if m.logsView.IsSearching() {
+					cmd = m.logsView.Update(msg)
+					return m, cmd
+				}
 				switch key {
-				case "esc", "q":
+				case "esc":
+					if !m.logsView.ClearSearch() {
+						m.logsView.Deactivate()
+					}
+					return m, nil
+				case "q":
 					m.logsView.Deactivate()
 					return m, nil
 				default:
@@ -262,136 +753,347 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
-			if m.descriptionEditView.IsActive() {
+			if m.statsView.IsActive() {
 				switch key {
-				case "ctrl+s":
-					return m, m.saveDescription()
-				case "ctrl+g":
-					content := m.descriptionEditView.GetValue()
-					return m, m.openExternalEditor(content, EditorSourceDescriptionEdit)
-				case "esc":
-					m.descriptionEditView.Deactivate()
+				case "esc", "q":
+					m.statsView.Deactivate()
 					return m, nil
 				default:
-					cmd = m.descriptionEditView.Update(msg)
+					cmd = m.statsView.Update(msg)
 					return m, cmd
 				}
 			}
 
-			if m.state == ViewPATs && (m.patsView.Mode == views.PATModeAdd || m.patsView.Mode == views.PATModeEdit) {
+			if m.perfView.IsActive() {
 				switch key {
-				case "enter":
-					return m.handlePATEnter()
-				case "esc":
-					m.patsView.ExitEditMode()
+				case "esc", "q":
+					m.perfView.Deactivate()
+					return m, nil
+				case "r":
+					m.perfView.Refresh(network.Metrics())
 					return m, nil
 				default:
-					cmd = m.patsView.Update(msg)
+					cmd = m.perfView.Update(msg)
 					return m, cmd
 				}
 			}
 
-			if m.state == ViewPRList && m.prListView.IsFiltering() {
+			if m.translationView.IsActive() {
 				switch key {
-				case "enter", "esc":
-					m.prListView.DeactivateFilter()
+				case "esc", "q":
+					m.translationView.Deactivate()
 					return m, nil
 				default:
-					cmd = m.prListView.UpdateFilterInput(msg)
-					m.prListView.ApplyFilterFromInput()
+					cmd = m.translationView.Update(msg)
 					return m, cmd
 				}
 			}
-		}
 
-		newModel, cmd, handled := m.commandRegistry.HandleKey(m, key)
-		if handled {
-			return newModel, cmd
-		}
+			if m.summaryView.IsActive() {
+				switch key {
+				case "esc", "q":
+					m.summaryView.Deactivate()
+					return m, nil
+				case "r":
+					m.summaryView.Deactivate()
+					return m.summarizeChanges(true)
+				default:
+					cmd = m.summaryView.Update(msg)
+					return m, cmd
+				}
+			}
 
-	case PATsLoadedMsg:
-		m.patsView.SetPATs(msg.pats)
-		m.providers = make(map[string]domain.Provider)
-		m.primaryProvider = nil
-		m.primaryPATID = ""
-		m.provider = nil
-
-		selectedCount := 0
-		if len(msg.pats) > 0 {
-			for _, pat := range msg.pats {
-				if pat.IsActive && m.provider == nil {
-					provider, err := m.createProvider(pat)
-					if err != nil {
-						m.statusBar.SetMessage(fmt.Sprintf("Failed to create provider: %v", err), true)
-					} else {
-						m.provider = provider
+			if m.checksView.IsActive() {
+				switch key {
+				case "esc", "q":
+					m.checksView.Deactivate()
+					return m, nil
+				default:
+					cmd = m.checksView.Update(msg)
+					return m, cmd
+				}
+			}
+
+			if m.crashRecoveryView.IsActive() {
+				switch key {
+				case "up", "k":
+					m.crashRecoveryView.MoveUp()
+				case "down", "j":
+					m.crashRecoveryView.MoveDown()
+				case "enter":
+					return m.goToDraftPR()
+				case "esc", "q":
+					m.crashRecoveryView.Deactivate()
+				}
+				return m, nil
+			}
+
+			if m.resumeView.IsActive() {
+				switch key {
+				case "enter", "y":
+					return m.resumeSession()
+				case "esc", "n", "q":
+					m.resumeView.Deactivate()
+					if err := m.repository.ClearSession(); err != nil {
+						logger.LogError("SESSION_CLEAR", "", err)
 					}
 				}
+				return m, nil
+			}
 
-				if pat.IsSelected {
-					selectedCount++
-					provider, err := m.createProvider(pat)
-					if err != nil {
-						logger.LogError("CREATE_PROVIDER", pat.Name, err)
-						continue
+			if m.outboxView.IsActive() {
+				switch {
+				case m.outboxView.IsConfirmingDiscard():
+					switch key {
+					case "y":
+						return m.discardOutboxReview()
+					case "n", "esc":
+						m.outboxView.CancelDiscard()
 					}
-					m.providers[pat.ID] = provider
+				default:
+					switch key {
+					case "esc", "q":
+						m.outboxView.Deactivate()
+					case "up", "k":
+						m.outboxView.Prev()
+					case "down", "j":
+						m.outboxView.Next()
+					case "r", "enter":
+						return m.retryOutboxReview()
+					case "d":
+						m.outboxView.StartDiscard()
+					}
+				}
+				return m, nil
+			}
 
-					if pat.IsPrimary {
-						m.primaryProvider = provider
-						m.primaryPATID = pat.ID
-						m.topBar.SetActivePAT(pat.Name, string(pat.Provider))
+			if m.outlineView.IsActive() {
+				switch key {
+				case "esc", "q":
+					m.outlineView.Deactivate()
+				case "up", "k":
+					m.outlineView.Prev()
+				case "down", "j":
+					m.outlineView.Next()
+				case "enter":
+					if symbol := m.outlineView.GetSelected(); symbol != nil {
+						m.outlineView.Deactivate()
+						m.prInspect.JumpToLine(symbol.FileIndex, symbol.LineIndex)
 					}
 				}
+				return m, nil
 			}
-		}
 
-		m.topBar.SetPATCounts(selectedCount, len(msg.pats))
+			if m.repoPickerView.IsActive() {
+				switch key {
+				case "esc":
+					m.repoPickerView.Deactivate()
+				case "up", "ctrl+p":
+					m.repoPickerView.Prev()
+				case "down", "ctrl+n":
+					m.repoPickerView.Next()
+				case "enter":
+					if choice, ok := m.repoPickerView.Selected(); ok {
+						m.repoPickerView.Deactivate()
+						return m.browseRepository(choice)
+					}
+				default:
+					return m, m.repoPickerView.Update(msg)
+				}
+				return m, nil
+			}
 
-		if selectedCount > 0 && m.isInitialStartup {
-			m.isInitialStartup = false
-			m.state = ViewPRList
-			m.topBar.SetView("PRs")
-			m.updateShortcuts()
-			logger.Log("UI: Starting in PR list view with %d selected PAT(s)", selectedCount)
-			return m, m.loadPRsStreaming()
-		}
+			if m.teamBoardView.IsActive() {
+				switch key {
+				case "esc", "q":
+					m.teamBoardView.Deactivate()
+				case "up", "k":
+					m.teamBoardView.Prev()
+				case "down", "j":
+					m.teamBoardView.Next()
+				case "r":
+					if !m.teamBoardView.IsLoading() {
+						return m.showTeamBoard(m.teamMembers)
+					}
+				case "enter":
+					if pr := m.teamBoardView.GetSelected(); pr != nil {
+						selected := *pr
+						m.teamBoardView.Deactivate()
+						releaseCmd := m.releasePRLease()
+						m.prInspect.SetPR(&selected)
+						m, cmd := m.openPR(selected)
+						return m, tea.Batch(releaseCmd, cmd)
+					}
+				}
+				return m, nil
+			}
 
-		m.isInitialStartup = false
-		m.topBar.SetView("PATs")
-		m.updateShortcuts()
-		return m, nil
+			if m.messagesView.IsActive() {
+				switch key {
+				case "esc", "q":
+					m.messagesView.Deactivate()
+					return m, nil
+				default:
+					cmd = m.messagesView.Update(msg)
+					return m, cmd
+				}
+			}
 
-	case spinner.TickMsg:
-		if m.loadingState.IsLoading {
-			var cmd tea.Cmd
-			m.spinner, cmd = m.spinner.Update(msg)
-			return m, cmd
+			if m.changelogView.IsActive() {
+				switch key {
+				case "esc", "q":
+					m.changelogView.Deactivate()
+					return m, nil
+				default:
+					cmd = m.changelogView.Update(msg)
+					return m, cmd
+				}
+			}
+
+			if m.descriptionEditView.IsActive() {
+				if m.descriptionEditView.HandleMentionKey(msg) {
+					return m, m.scheduleDraftSave()
+				}
+				switch key {
+				case "ctrl+s":
+					// Kept until the provider accepts the new description.
+					m.saveDraft()
+					return m, m.saveDescription()
+				case "ctrl+g":
+					content := m.descriptionEditView.GetValue()
+					return m, m.openExternalEditor(content, EditorSourceDescriptionEdit)
+				case "esc":
+					m.closeEditorKeepingDraft(m.descriptionEditView.Deactivate)
+					return m, nil
+				default:
+					cmd = m.descriptionEditView.Update(msg)
+					return m, tea.Batch(cmd, m.scheduleDraftSave())
+				}
+			}
+
+			if m.state == ViewPATs && (m.patsView.Mode == views.PATModeAdd || m.patsView.Mode == views.PATModeEdit) {
+				switch key {
+				case "enter":
+					return m.handlePATEnter()
+				case "ctrl+t":
+					return m, m.testPATConnection(m.patsView.GetPATData())
+				case "esc":
+					m.patsView.ExitEditMode()
+					return m, nil
+				default:
+					cmd = m.patsView.Update(msg)
+					return m, cmd
+				}
+			}
+
+			if m.state == ViewPRList && m.prListView.IsFiltering() {
+				switch key {
+				case "enter", "esc":
+					m.prListView.ApplyFilterFromInput()
+					m.prListView.DeactivateFilter()
+					return m, nil
+				default:
+					cmd = m.prListView.UpdateFilterInput(msg)
+					return m, tea.Batch(cmd, prFilterTick(m.prListView.FilterGeneration()))
+				}
+			}
+		}
+
+		var handled bool
+		if m.triage != nil && m.pendingKeys == "" {
+			if m, cmd, handled = m.applyTriageKey(key); handled {
+				return m, cmd
+			}
+		}
+		m, cmd, handled = m.commandRegistry.HandleKey(m, key)
+		if handled {
+			return m, cmd
+		}
+
+	case PATsLoadedMsg:
+		var selectedCount int
+		m, selectedCount = m.applyPATs(msg.pats)
+
+		if selectedCount > 0 && m.isInitialStartup {
+			m.isInitialStartup = false
+			m.offerResume = true
+			m.state = ViewPRList
+			m.topBar.SetView("PRs")
+			m.updateShortcuts()
+			logger.Log("UI: Starting in PR list view with %d selected PAT(s)", selectedCount)
+			var selected []domain.PAT
+			for _, pat := range msg.pats {
+				if pat.IsSelected {
+					selected = append(selected, pat)
+				}
+			}
+			m.statusBar.SetMessage(fmt.Sprintf("Validating %d PAT(s)...", len(selected)), false)
+			return m, m.validatePATs(selected)
+		}
+
+		if m.isInitialStartup && m.crashReport != "" {
+			m.offerCrashRecovery()
+			m.crashReport = ""
+		}
+		m.isInitialStartup = false
+		m.topBar.SetView("PATs")
+		m.updateShortcuts()
+		return m, nil
+
+	case PATsValidatedMsg:
+		m.invalidPATs = msg.problems
+		m.patsView.SetPATProblems(msg.problems)
+		return m, m.loadPRsStreaming()
+
+	case spinner.TickMsg:
+		if m.loadingState.IsLoading || m.tasks.active() {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			m.updateTaskIndicator()
+			return m, cmd
 		}
+		m.updateTaskIndicator()
 		return m, nil
 
 	case PRLoadingStartedMsg:
 		m.loadingState = LoadingState{
 			IsLoading:         true,
+			LoadID:            msg.LoadID,
 			TotalPATs:         msg.TotalPATs,
 			LoadedPATs:        0,
 			AccumulatedGroups: []domain.PRGroup{},
 			FailedPATs:        []string{},
+			SkippedPATs:       msg.SkippedPATs,
 		}
+		m.offline.list = time.Time{}
+		m.updateOfflineBadge()
 		m.state = ViewPRList
 		m.topBar.SetView("PR List")
 		m.updateShortcuts()
 		m.statusBar.SetMessage(fmt.Sprintf("%s Loading PRs (0/%d PATs)...",
 			m.spinner.View(), msg.TotalPATs), false)
+		if m.offerResume {
+			m.offerResume = false
+			if m.crashReport != "" {
+				m.offerCrashRecovery()
+				m.crashReport = ""
+			} else {
+				m.offerSessionResume()
+			}
+		}
 		return m, m.spinner.Tick
 
 	case PRGroupLoadedMsg:
-		if !m.loadingState.IsLoading {
+		if !m.loadingState.IsLoading || msg.LoadID != m.loadingState.LoadID {
 			return m, nil
 		}
 
 		currentCursor := m.prListView.GetCursorIndex()
 		m.loadingState.LoadedPATs++
+		var flushCmd tea.Cmd
+		if msg.LoadError == nil {
+			m, flushCmd = m.noteListData(msg.CachedAt)
+		}
 
 		if msg.LoadError != nil {
 			logger.LogError("LOAD_PRS_STREAMING", msg.Group.PATName, msg.LoadError)
@@ -405,15 +1107,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		m.prListView.RestoreCursor(currentCursor)
+		if m.pendingSelection != "" && m.prListView.SelectPR(m.pendingSelection) {
+			m.pendingSelection = ""
+		}
 
 		totalPRs := 0
 		repoMap := make(map[string]bool)
-		authored, assigned, other := 0, 0, 0
+		reviewRequested, authored, assigned, other := 0, 0, 0, 0
 		for _, group := range m.loadingState.AccumulatedGroups {
 			for _, pr := range group.PRs {
 				totalPRs++
 				repoMap[pr.Repository.FullName] = true
 				switch pr.Category {
+				case domain.PRCategoryReviewRequested:
+					reviewRequested++
 				case domain.PRCategoryAuthored:
 					authored++
 				case domain.PRCategoryAssigned:
@@ -424,16 +1131,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		m.topBar.SetStats(totalPRs, len(repoMap))
-		m.topBar.SetPRBreakdown(authored, assigned, other)
+		m.topBar.SetPRBreakdown(reviewRequested, authored, assigned, other)
 
 		if m.loadingState.LoadedPATs < m.loadingState.TotalPATs {
 			progress := fmt.Sprintf("%d/%d", m.loadingState.LoadedPATs, m.loadingState.TotalPATs)
 			m.statusBar.SetMessage(fmt.Sprintf("%s Loading PRs (%s PATs)... %d PRs",
 				m.spinner.View(), progress, totalPRs), false)
-			return m, m.spinner.Tick
+			return m, tea.Batch(m.spinner.Tick, flushCmd)
 		}
 
 		m.loadingState.IsLoading = false
+		m.pendingSelection = ""
 
 		var allPRs []domain.PullRequest
 		for _, group := range m.loadingState.AccumulatedGroups {
@@ -451,8 +1159,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			finalMsg = fmt.Sprintf("Loaded %d pull requests", totalPRs)
 		}
-		m.statusBar.SetMessage(finalMsg, len(m.loadingState.FailedPATs) > 0)
-		return m, clearStatusAfterDelay(4 * time.Second)
+		if woken := m.wakeSnoozedPRs(); woken > 0 {
+			finalMsg += fmt.Sprintf(", %d snoozed PR(s) are back", woken)
+		}
+		if len(m.loadingState.SkippedPATs) > 0 {
+			finalMsg += fmt.Sprintf(" ⚠ skipped expired/invalid PAT(s): %s", strings.Join(m.loadingState.SkippedPATs, ", "))
+		}
+		if !m.offline.list.IsZero() {
+			finalMsg += " ⚠ offline, some PRs are cached"
+		}
+		hasProblems := len(m.loadingState.FailedPATs) > 0 || len(m.loadingState.SkippedPATs) > 0
+		m.statusBar.SetMessage(finalMsg, hasProblems)
+		return m, tea.Batch(clearStatusAfterDelay(4*time.Second), flushCmd)
 
 	case PRsLoadedMsg:
 		if msg.groups != nil && len(msg.groups) > 0 {
@@ -477,10 +1195,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		repoMap := make(map[string]bool)
-		authored, assigned, other := 0, 0, 0
+		reviewRequested, authored, assigned, other := 0, 0, 0, 0
 		for _, pr := range msg.prs {
 			repoMap[pr.Repository.FullName] = true
 			switch pr.Category {
+			case domain.PRCategoryReviewRequested:
+				reviewRequested++
 			case domain.PRCategoryAuthored:
 				authored++
 			case domain.PRCategoryAssigned:
@@ -490,19 +1210,91 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		m.topBar.SetStats(len(msg.prs), len(repoMap))
-		m.topBar.SetPRBreakdown(authored, assigned, other)
+		m.topBar.SetPRBreakdown(reviewRequested, authored, assigned, other)
 		m.topBar.SetView("PR List")
 
 		m.state = ViewPRList
+		m.reloadStartedReviews()
+		m.updateShortcuts()
+		loadedMsg := fmt.Sprintf("Loaded %d pull requests", len(msg.prs))
+		if woken := m.wakeSnoozedPRs(); woken > 0 {
+			loadedMsg += fmt.Sprintf(", %d snoozed PR(s) are back", woken)
+		}
+		m.statusBar.SetMessage(loadedMsg, false)
+		return m, clearStatusAfterDelay(4 * time.Second)
+
+	case DeviceLoginStartedMsg:
+		copied := ""
+		if err := clipboard.WriteAll(msg.login.UserCode()); err == nil {
+			copied = " (copied)"
+		}
+		m.statusBar.SetMessage(fmt.Sprintf("Open %s and enter code %s%s to finish logging in",
+			msg.login.VerificationURI(), msg.login.UserCode(), copied), false)
+		return m, m.waitForDeviceLogin(msg.login, msg.organization)
+
+	case PATIdentityResolvedMsg:
+		return m.handlePATIdentityResolved(msg)
+
+	case PATConnectionTestedMsg:
+		m.handlePATConnectionTested(msg)
+		return m, nil
+
+	case DeviceLoginCompletedMsg:
+		if err := m.repository.SavePAT(msg.pat); err != nil {
+			m.statusBar.SetMessage(fmt.Sprintf("Failed to save login: %v", err), true)
+			return m, nil
+		}
+		m.statusBar.SetMessage(fmt.Sprintf("Logged in as %s", msg.pat.Username), false)
+		return m, tea.Batch(m.loadPATs(), clearStatusAfterDelay(4*time.Second))
+
+	case TeamBoardLoadedMsg:
+		if !m.teamBoardView.IsActive() {
+			return m, nil
+		}
+		m.teamBoardView.SetGroups(msg.groups)
+		if msg.err != nil {
+			m.statusBar.SetMessage(fmt.Sprintf("Failed to load the team board: %v", msg.err), true)
+		}
+		return m, nil
+
+	case SearchResultsLoadedMsg:
+		m.prListView.SetSearchResults(msg.query, msg.groups)
+
+		total := 0
+		repoMap := make(map[string]bool)
+		for _, group := range msg.groups {
+			total += len(group.PRs)
+			for _, pr := range group.PRs {
+				repoMap[pr.Repository.FullName] = true
+			}
+		}
+		m.topBar.SetStats(total, len(repoMap))
+		m.topBar.SetPRBreakdown(0, 0, 0, 0)
+		m.topBar.SetView("Search: " + msg.query)
+
+		m.state = ViewPRList
+		m.reloadStartedReviews()
 		m.updateShortcuts()
-		m.statusBar.SetMessage(fmt.Sprintf("Loaded %d pull requests", len(msg.prs)), false)
+		m.statusBar.SetMessage(fmt.Sprintf("Found %d pull requests matching %q", total, msg.query), false)
 		return m, clearStatusAfterDelay(4 * time.Second)
 
 	case PRDetailLoadedMsg:
 		m.prInspect.SetPR(msg.pr)
+		m.prInspect.SetStack(domain.FindStack(*msg.pr, m.prListView.ListedPRs()))
 		m.topBar.SetPRStatus(string(msg.pr.Status), msg.pr.Mergeable)
 		m.topBar.SetPRApproval(string(msg.pr.ApprovalStatus))
-		return m, nil
+		var reloadCmd tea.Cmd
+		if msg.cachedAt.IsZero() {
+			m.prData.observe(*msg.pr)
+			if m.memoryHead != "" && msg.pr.HeadSHA != "" && msg.pr.HeadSHA != m.memoryHead {
+				logger.Log("UI: %s has a new head commit, reloading its diff and comments", msg.pr.Key())
+				reloadCmd = tea.Batch(m.loadDiff(*msg.pr), m.loadComments(*msg.pr))
+			}
+			m.memoryHead = ""
+		}
+		var offlineCmd tea.Cmd
+		m, offlineCmd = m.noteOpenPRData(msg.cachedAt)
+		return m, tea.Batch(reloadCmd, offlineCmd)
 
 	case DiffLoadedMsg:
 		logger.Log("UI: DiffLoadedMsg received - diff has %d files", len(msg.diff.Files))
@@ -517,27 +1309,171 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		m.prInspect.SetDiff(msg.diff)
+		m.fileDiffLoads = nil
 		logger.Log("UI: SetDiff called on prInspect view")
+		if position := m.pendingPosition; position != nil {
+			m.pendingPosition = nil
+			if pr := m.prInspect.GetPR(); pr != nil && pr.Key() == position.PullRequest().Key() {
+				m.prInspect.RestorePosition(position.FileIndex, position.LineIndex, position.ScrollOffset)
+				m.topBar.SetView("PR Diff")
+				m.updateShortcuts()
+			}
+		}
+		var offlineCmd tea.Cmd
+		m, offlineCmd = m.noteOpenPRData(msg.cachedAt)
+		return m, tea.Batch(m.analyzeDiff(msg.diff), offlineCmd)
+
+	case FileDiffLoadedMsg:
+		pr := m.prInspect.GetPR()
+		if pr == nil || pr.Key() != msg.prKey {
+			return m, nil
+		}
+		if msg.err != nil {
+			logger.LogError("LOAD_FILE_DIFF", msg.path, msg.err)
+			m.prInspect.SetFileDiffError(msg.path, msg.err)
+			return m, nil
+		}
+		m.prInspect.SetFileDiff(*msg.file)
+		return m, m.analyzeLoadedFile(*msg.file)
+
+	case DiffAnnotationsLoadedMsg:
+		pr := m.prInspect.GetPR()
+		if pr == nil || pr.Key() != msg.prKey {
+			return m, nil
+		}
+		m.prInspect.SetAnnotations(msg.path, msg.annotations)
+		if msg.err != nil {
+			m.statusBar.SetMessage(msg.err.Error(), true)
+			return m, nil
+		}
+		if msg.announce {
+			m.statusBar.SetMessage(fmt.Sprintf("%d finding(s) in %s", len(msg.annotations), msg.path), false)
+			return m, clearStatusAfterDelay(4 * time.Second)
+		}
 		return m, nil
 
 	case CommentsLoadedMsg:
 		m.prInspect.SetComments(msg.comments)
+		m.prInspect.SetCommentsLoading(false, 0)
+		return m.noteOpenPRData(msg.cachedAt)
+
+	case CommentsPageLoadedMsg:
+		pr := m.prInspect.GetPR()
+		if pr == nil || pr.Key() != msg.pr.Key() || msg.stream.Before(m.commentStream) {
+			return m, nil
+		}
+		m.commentStream = msg.stream
+		if msg.err != nil {
+			m.prInspect.SetCommentsLoading(false, 0)
+			m.statusBar.SetMessage(fmt.Sprintf("Failed to load the rest of the comments: %v", msg.err), true)
+			return m, nil
+		}
+		// Comments shown from memory stay until the pages loaded catch up.
+		if msg.next == "" || len(msg.comments) >= len(m.prInspect.GetComments()) {
+			m.prInspect.SetComments(msg.comments)
+		}
+		m.prInspect.SetCommentsLoading(msg.next != "", msg.total)
+		var offlineCmd tea.Cmd
+		if msg.pages == 1 {
+			m, offlineCmd = m.noteOpenPRData(time.Time{})
+		}
+		if msg.next == "" {
+			return m, offlineCmd
+		}
+		return m, tea.Batch(offlineCmd, m.loadMoreComments(msg))
+
+	case ReviewSavedToOutboxMsg:
+		m.prInspect.ClearPendingComments()
+		if network.IsUnreachable(msg.err) {
+			m.statusBar.Notify("Offline: the review is in the outbox and will be sent once the provider is reachable", components.SeverityWarning)
+		} else {
+			m.statusBar.SetMessage(fmt.Sprintf("Review failed: %v. It is kept in the outbox (:outbox)", msg.err), true)
+		}
+		return m, tea.Batch(m.loadComments(*msg.pr), m.loadReviewers(*msg.pr))
+
+	case OutboxReviewSentMsg:
+		m.outboxView.SetSending("")
+		m.refreshOutboxView()
+		if msg.err != nil {
+			m.statusBar.SetMessage(fmt.Sprintf("Failed to send the review for %s: %v", msg.prIdentifier, msg.err), true)
+			return m, nil
+		}
+		m.statusBar.Notify(fmt.Sprintf("Sent the review for %s", msg.prIdentifier), components.SeveritySuccess)
+		if pr := m.prInspect.GetPR(); m.state == ViewPRInspect && pr != nil && fmt.Sprintf("%s/%d", pr.Repository.FullName, pr.Number) == msg.prIdentifier {
+			return m, tea.Batch(clearStatusAfterDelay(4*time.Second), m.loadComments(*pr), m.loadReviewers(*pr), m.loadPolicies(*pr))
+		}
+		return m, clearStatusAfterDelay(4 * time.Second)
+
+	case QueuedReviewsSentMsg:
+		m.flushingReviews = false
+		if m.outboxView.IsActive() {
+			m.refreshOutboxView()
+		}
+		if msg.err != nil {
+			m.statusBar.SetMessage(msg.err.Error(), true)
+			return m, nil
+		}
+		if msg.sent == 0 {
+			return m, nil
+		}
+		status := fmt.Sprintf("Sent %d review(s) queued while offline", msg.sent)
+		if msg.remaining > 0 {
+			status += fmt.Sprintf(", %d still queued", msg.remaining)
+		}
+		m.statusBar.Notify(status, components.SeveritySuccess)
+		return m, clearStatusAfterDelay(4 * time.Second)
+
+	case TranslationLoadedMsg:
+		m.statusBar.ClearMessage()
+		m.translationView.Activate(msg.title, msg.language, msg.text)
+		return m, nil
+
+	case SummaryLoadedMsg:
+		m.statusBar.ClearMessage()
+		m.summaryView.Activate(msg.key, msg.title, msg.summary.Text, msg.summary.Truncated)
+		return m, nil
+
+	case UpdateAvailableMsg:
+		m.latestRelease = &msg.release
+		m.statusBar.SetNotice(fmt.Sprintf("%s available (:changelog)", msg.release.Version))
 		return m, nil
 
+	case WebhookServerStartedMsg:
+		m.statusBar.SetMessage(fmt.Sprintf("Listening for webhooks on %s", msg.addr), false)
+		return m, tea.Batch(m.waitForWebhookEvent(), clearStatusAfterDelay(4*time.Second))
+
+	case WebhookEventMsg:
+		return m.handleWebhookEvent(msg.event)
+
+	case ConfigReloadedMsg:
+		return m.handleConfigReloaded(msg.err)
+
 	case ErrorMsg:
+		if errors.Is(msg.err, context.Canceled) {
+			logger.Log("UI: Ignoring error from a cancelled load: %v", msg.err)
+			return m, nil
+		}
+		if errors.Is(msg.err, context.DeadlineExceeded) {
+			m.statusBar.SetMessage(requestTimeoutMessage(m.requestTimeout), true)
+			return m, nil
+		}
 		m.statusBar.SetMessage(msg.err.Error(), true)
 		return m, nil
 
 	case SuccessMsg:
-		m.statusBar.SetMessage(msg.message, false)
+		m.statusBar.Notify(msg.message, components.SeveritySuccess)
 		if msg.reloadComments && msg.reloadCommentsPR != nil {
 			m.prInspect.ClearPendingComments()
-			return m, m.loadComments(*msg.reloadCommentsPR)
+			return m, tea.Batch(m.loadComments(*msg.reloadCommentsPR), m.loadReviewers(*msg.reloadCommentsPR), m.loadPolicies(*msg.reloadCommentsPR), m.claimPRLease())
 		}
 		return m, nil
 
 	case MergeSuccessMsg:
-		m.statusBar.SetMessage(fmt.Sprintf("PR %s merged successfully", msg.prIdentifier), false)
+		if msg.branchKept {
+			m.statusBar.Notify(fmt.Sprintf("PR %s merged; %v", msg.prIdentifier, common.ErrSourceBranchKept), components.SeverityWarning)
+		} else {
+			m.statusBar.Notify(fmt.Sprintf("PR %s merged successfully", msg.prIdentifier), components.SeveritySuccess)
+		}
 		if pr := m.prInspect.GetPR(); pr != nil {
 			return m, tea.Batch(m.loadPRDetail(*pr), clearStatusAfterDelay(4*time.Second))
 		}
@@ -550,6 +1486,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case DescriptionUpdateSuccessMsg:
 		m.statusBar.SetMessage("PR description updated", false)
 		if pr := m.prInspect.GetPR(); pr != nil {
+			m.discardDraft(descriptionDraftKey(*pr))
 			pr.Description = msg.description
 			m.prInspect.SetPR(pr)
 		}
@@ -588,105 +1525,614 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.descriptionEditView.SetValue(editedContent)
 		}
 
-		return m, nil
+		return m, m.scheduleDraftSave()
 
 	case ClearStatusMsg:
 		m.statusBar.ClearMessage()
 		return m, nil
-	}
 
-	switch m.state {
-	case ViewPATs:
-		cmd = m.patsView.Update(msg)
-	case ViewPRList:
-		cmd = m.prListView.Update(msg)
-	case ViewPRInspect:
-		cmd = m.prInspect.Update(msg)
-	}
+	case NotificationsExpiredMsg:
+		m.statusBar.Expire(time.Now())
+		return m, nil
 
-	cmds = append(cmds, cmd)
-	return m, tea.Batch(cmds...)
-}
+	case DraftAutosaveMsg:
+		if msg.generation == m.draftGeneration {
+			m.saveDraft()
+		}
+		return m, nil
 
-func (m Model) View() string {
-	if m.width == 0 {
-		return "Loading..."
-	}
+	case PRFilterTickMsg:
+		if msg.generation == m.prListView.FilterGeneration() {
+			m.prListView.ApplyFilterFromInput()
+		}
+		return m, nil
 
-	var content string
+	case LogsTickMsg:
+		if !m.logsView.IsActive() || msg.generation != m.logsView.Generation() {
+			return m, nil
+		}
+		m.logsView.Refresh()
+		return m, logsTick(msg.generation)
 
-	if m.logsView.IsActive() {
-		content = m.logsView.View()
-	} else if m.reviewView.IsActive() {
-		content = m.reviewView.View()
-	} else if m.mergeView.IsActive() {
-		content = m.mergeView.View()
-	} else if m.inlineCommentView.IsActive() {
-		content = m.inlineCommentView.View()
-	} else if m.commentDetailView.IsActive() {
-		content = m.commentDetailView.View()
-	} else if m.descriptionEditView.IsActive() {
-		content = m.descriptionEditView.View()
-	} else {
-		switch m.state {
-		case ViewPATs:
-			content = m.patsView.View()
-		case ViewPRList:
-			content = m.prListView.View()
-		case ViewPRInspect:
-			content = m.prInspect.View()
+	case ReviewTimerTickMsg:
+		return m, m.tickReviewTimer(msg)
+
+	case PRRefreshTickMsg:
+		pr := m.prInspect.GetPR()
+		if m.state != ViewPRInspect || msg.generation != m.refreshGeneration || pr == nil || pr.Key() != msg.prKey {
+			return m, nil
 		}
-	}
+		m, cmd := m.refreshOpenPR(false)
+		return m, tea.Batch(cmd, m.prRefreshTick(msg.prKey, msg.generation))
 
-	topBar := m.topBar.View()
-	statusBar := m.statusBar.View()
-	commandBar := m.commandBar.View()
+	case PRLeaseTickMsg:
+		if msg.prIdentifier != m.leasedPR || msg.generation != m.leaseGeneration {
+			return m, nil
+		}
+		return m, tea.Batch(m.claimPRLease(), prLeaseTick(msg.prIdentifier, msg.generation))
 
-	if commandBar != "" {
-		return topBar + "\n" + content + "\n" + commandBar
-	}
+	case PRLeaseMsg:
+		return m.handlePRLease(msg)
 
-	return topBar + "\n" + content + "\n" + statusBar
-}
+	case ReviewUndoTickMsg:
+		return m.handleReviewUndoTick(msg)
 
-func (m Model) handleCommand() (tea.Model, tea.Cmd) {
-	input := m.commandBar.Value()
-	m.commandBar.Deactivate()
+	case ReviewersLoadedMsg:
+		if pr := m.reviewersView.GetPR(); m.reviewersView.IsActive() && pr != nil && pr.Key() == msg.prKey {
+			m.reviewersView.SetReviewers(msg.reviewers, msg.err)
+		}
+		if pr := m.prInspect.GetPR(); pr != nil && pr.Key() == msg.prKey {
+			if msg.err != nil {
+				logger.LogError("LOAD_REVIEWERS", msg.prKey, msg.err)
+			} else {
+				m.prInspect.SetReviewers(msg.reviewers, m.patUsername(*pr))
+			}
+		}
+		return m, nil
 
-	input = strings.TrimPrefix(input, ":")
-	parts := strings.Fields(input)
-	if len(parts) == 0 {
+	case MergeRequirementsLoadedMsg:
+		if pr := m.mergeView.GetPR(); m.mergeView.IsActive() && pr != nil && pr.Key() == msg.prKey {
+			if msg.err != nil {
+				logger.LogError("LOAD_MERGE_REQUIREMENTS", msg.prKey, msg.err)
+			}
+			m.mergeView.SetRequirements(msg.requirements, msg.err)
+		}
 		return m, nil
-	}
 
-	cmdName := parts[0]
-	args := parts[1:]
+	case ConflictFilesLoadedMsg:
+		if pr := m.mergeView.GetPR(); m.mergeView.IsActive() && pr != nil && pr.Key() == msg.prKey {
+			if msg.err != nil {
+				logger.LogError("LOAD_CONFLICT_FILES", msg.prKey, msg.err)
+			}
+			m.mergeView.SetConflicts(msg.files, msg.err)
+		}
+		return m, nil
 
-	logger.Log("UI: Executing command: %s %v", cmdName, args)
-	return m.commandRegistry.ExecuteCommand(m, cmdName, args)
-}
+	case DependencyBatchDoneMsg:
+		m.dependenciesView.Deactivate()
+		status := fmt.Sprintf("Approved and merged %d dependency update(s)", msg.merged)
+		if total := msg.merged + len(msg.skipped) + len(msg.failures); total > msg.merged {
+			status = fmt.Sprintf("Approved and merged %d of %d dependency update(s)", msg.merged, total)
+		}
+		if len(msg.skipped) > 0 {
+			status += "; skipped: " + strings.Join(msg.skipped, "; ")
+		}
+		if len(msg.failures) > 0 {
+			status += "; failed: " + strings.Join(msg.failures, "; ")
+		}
+		m.statusBar.SetMessage(status, len(msg.failures) > 0)
+		m.loadingState = LoadingState{}
+		m.prCache = nil
+		return m, tea.Batch(m.loadPRsStreaming(), clearStatusAfterDelay(8*time.Second))
+
+	case PRResolvedMsg:
+		m.statusBar.SetMessage("", false)
+		m.prInspect.SetPR(&msg.pr)
+		return m.openPR(msg.pr)
+
+	case MentionCandidatesLoadedMsg:
+		if msg.err != nil {
+			logger.LogError("MENTIONS_LOAD", msg.prKey, msg.err)
+			return m, nil
+		}
+		if pr := m.prInspect.GetPR(); pr != nil && pr.Key() == msg.prKey {
+			m.setMentionCandidates(msg.candidates)
+		}
+		return m, nil
+
+	case OutlineLoadedMsg:
+		if pr := m.prInspect.GetPR(); pr != nil && pr.Key() == msg.prKey && m.outlineView.IsActive() {
+			m.outlineView.SetSymbols(outline.Build(m.prInspect.GetDiff(), msg.contents))
+		}
+		return m, nil
+
+	case CodeOwnersLoadedMsg:
+		if msg.err != nil {
+			logger.LogError("CODEOWNERS_LOAD", msg.prKey, msg.err)
+			return m, nil
+		}
+		if pr := m.prInspect.GetPR(); pr != nil && pr.Key() == msg.prKey {
+			m.prInspect.SetCodeOwners(msg.owners)
+		}
+		return m, nil
+
+	case PoliciesLoadedMsg:
+		if msg.err != nil {
+			logger.LogError("POLICIES_LOAD", msg.prKey, msg.err)
+			return m, nil
+		}
+		if pr := m.prInspect.GetPR(); pr != nil && pr.Key() == msg.prKey {
+			m.prInspect.SetPolicies(msg.policies)
+		}
+		return m, nil
+
+	case FailingChecksLoadedMsg:
+		if msg.err != nil {
+			logger.LogError("FAILING_CHECKS_LOAD", msg.prKey, msg.err)
+		}
+		if pr := m.checksView.GetPR(); m.checksView.IsActive() && pr != nil && pr.Key() == msg.prKey {
+			m.checksView.SetChecks(msg.checks, msg.err)
+		}
+		return m, nil
+
+	case DeploymentsLoadedMsg:
+		if msg.err != nil {
+			logger.LogError("DEPLOYMENTS_LOAD", msg.prKey, msg.err)
+			return m, nil
+		}
+		if pr := m.prInspect.GetPR(); pr != nil && pr.Key() == msg.prKey {
+			m.prInspect.SetDeployments(msg.deployments)
+		}
+		return m, nil
+
+	case ReactionAddedMsg:
+		if msg.err != nil {
+			m.statusBar.SetMessage(fmt.Sprintf("Failed to add reaction: %v", msg.err), true)
+			return m, clearStatusAfterDelay(8 * time.Second)
+		}
+		if pr := m.prInspect.GetPR(); pr != nil && pr.Key() == msg.prKey {
+			m.prInspect.SetComments(m.commentDetailView.AddReaction(msg.comment, msg.content))
+		}
+		m.statusBar.SetMessage(fmt.Sprintf("Reacted with %s", domain.ReactionEmoji(msg.content)), false)
+		return m, clearStatusAfterDelay(4 * time.Second)
+
+	case ThreadStatusChangedMsg:
+		if msg.err != nil {
+			m.statusBar.SetMessage(fmt.Sprintf("Failed to change the thread status: %v", msg.err), true)
+			return m, clearStatusAfterDelay(8 * time.Second)
+		}
+		if pr := m.prInspect.GetPR(); pr != nil && pr.Key() == msg.prKey {
+			m.prInspect.SetComments(m.commentDetailView.SetThreadStatus(msg.comment, msg.status))
+		}
+		m.statusBar.SetMessage(fmt.Sprintf("Thread marked %s", domain.ThreadStatusLabel(msg.status)), false)
+		return m, clearStatusAfterDelay(4 * time.Second)
+
+	case ReviewerActionMsg:
+		if msg.err != nil {
+			m.statusBar.SetMessage(msg.err.Error(), true)
+			return m, clearStatusAfterDelay(8 * time.Second)
+		}
+		m.statusBar.SetMessage(msg.message, false)
+		if pr := m.reviewersView.GetPR(); m.reviewersView.IsActive() && pr != nil {
+			return m, tea.Batch(m.loadReviewers(*pr), m.loadPolicies(*pr), clearStatusAfterDelay(4*time.Second))
+		}
+		return m, clearStatusAfterDelay(4 * time.Second)
+	}
+
+	switch m.state {
+	case ViewPATs:
+		cmd = m.patsView.Update(msg)
+	case ViewPRList:
+		cmd = m.prListView.Update(msg)
+	case ViewPRInspect:
+		cmd = m.prInspect.Update(msg)
+	}
+
+	cmds = append(cmds, cmd)
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) View() string {
+	defer func() { crash.Repanic(recover()) }()
+
+	if m.width == 0 {
+		return "Loading..."
+	}
+	if m.terminalTooSmall() {
+		return m.terminalTooSmallView()
+	}
+
+	var content string
+
+	if m.logsView.IsActive() {
+		content = m.logsView.View()
+	} else if m.statsView.IsActive() {
+		content = m.statsView.View()
+	} else if m.perfView.IsActive() {
+		content = m.perfView.View()
+	} else if m.reviewView.IsActive() {
+		content = m.reviewView.View()
+	} else if m.mergeView.IsActive() {
+		content = m.mergeView.View()
+	} else if m.snoozeView.IsActive() {
+		content = m.snoozeView.View()
+	} else if m.reviewersView.IsActive() {
+		content = m.reviewersView.View()
+	} else if m.dependenciesView.IsActive() {
+		content = m.dependenciesView.View()
+	} else if m.inlineCommentView.IsActive() {
+		content = m.inlineCommentView.View()
+	} else if m.commentDetailView.IsActive() {
+		content = m.commentDetailView.View()
+	} else if m.descriptionEditView.IsActive() {
+		content = m.descriptionEditView.View()
+	} else if m.translationView.IsActive() {
+		content = m.translationView.View()
+	} else if m.summaryView.IsActive() {
+		content = m.summaryView.View()
+	} else if m.checksView.IsActive() {
+		content = m.checksView.View()
+	} else if m.changelogView.IsActive() {
+		content = m.changelogView.View()
+	} else if m.resumeView.IsActive() {
+		content = m.resumeView.View()
+	} else if m.crashRecoveryView.IsActive() {
+		content = m.crashRecoveryView.View()
+	} else if m.outboxView.IsActive() {
+		content = m.outboxView.View()
+	} else if m.outlineView.IsActive() {
+		content = m.outlineView.View()
+	} else if m.repoPickerView.IsActive() {
+		content = m.repoPickerView.View()
+	} else if m.teamBoardView.IsActive() {
+		content = m.teamBoardView.View()
+	} else if m.messagesView.IsActive() {
+		content = m.messagesView.View()
+	} else {
+		switch m.state {
+		case ViewPATs:
+			content = m.patsView.View()
+		case ViewPRList:
+			content = m.prListView.View()
+		case ViewPRInspect:
+			content = m.prInspect.View()
+		}
+	}
+
+	topBar := m.topBar.View()
+	statusBar := m.statusBar.View()
+	commandBar := m.commandBar.View()
+
+	if toasts := m.statusBar.ToastsView(); toasts != "" {
+		content = overlayBottom(content, toasts)
+	}
+
+	if commandBar != "" {
+		return topBar + "\n" + content + "\n" + commandBar
+	}
+
+	return topBar + "\n" + content + "\n" + statusBar
+}
+
+// overlayBottom replaces the last lines of content with overlay, so that the
+// overall height stays the same.
+func overlayBottom(content, overlay string) string {
+	lines := strings.Split(content, "\n")
+	overlayLines := strings.Split(overlay, "\n")
+	keep := max(len(lines)-len(overlayLines), 0)
+	return strings.Join(append(lines[:keep], overlayLines...), "\n")
+}
+
+func (m Model) handleCommand() (tea.Model, tea.Cmd) {
+	input := m.commandBar.Value()
+	m.commandBar.Deactivate()
+
+	input = strings.TrimPrefix(input, ":")
+	parts := strings.Fields(input)
+	if len(parts) == 0 {
+		return m, nil
+	}
+
+	cmdName := parts[0]
+	args := parts[1:]
+
+	logger.Log("UI: Executing command: %s %v", cmdName, args)
+	return m.commandRegistry.ExecuteCommand(m, cmdName, args)
+}
 
 func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 	switch m.state {
 	case ViewPATs:
 		return m.handlePATEnter()
 	case ViewPRList:
+		if m.prListView.DraftsSectionSelected() {
+			m.prListView.ToggleDrafts()
+			return m, nil
+		}
 		pr := m.prListView.GetSelectedPR()
 		if pr != nil {
-			m.state = ViewPRInspect
-			m.topBar.SetContext(pr.Repository.FullName, fmt.Sprintf("%d", pr.Number))
-			m.topBar.SetView("PR Inspect")
-			m.updateShortcuts()
-			return m, tea.Batch(
-				m.loadPRDetail(*pr),
-				m.loadDiff(*pr),
-				m.loadComments(*pr),
-			)
+			return m.openPR(*pr)
 		}
 	}
 	return m, nil
 }
 
+// openPR switches to the inspect view for pr and starts loading its details.
+func (m Model) openPR(pr domain.PullRequest) (Model, tea.Cmd) {
+	m.state = ViewPRInspect
+	m.inspectStartedAt = time.Now()
+	m.prInspect.SwitchToDescription()
+	m.topBar.SetContext(pr.Repository.FullName, fmt.Sprintf("%d", pr.Number))
+	m.topBar.SetView("PR Description")
+	m.topBar.SetPRLock("")
+	m.updateShortcuts()
+
+	m.leasedPR = prLeaseKey(pr)
+	m.leaseGeneration++
+	m.leaseWarned = false
+	m.refreshGeneration++
+
+	if seen, err := m.repository.GetSeenPRs(); err == nil {
+		m.prInspect.SetLastSeen(seen[pr.Key()])
+	} else {
+		logger.LogError("SEEN_LOAD", pr.Key(), err)
+		m.prInspect.SetLastSeen(time.Time{})
+	}
+	m.markPRsSeen([]domain.PullRequest{pr})
+	m.prInspect.SetReviewers(nil, "")
+	m.prInspect.SetStack(domain.FindStack(pr, m.prListView.ListedPRs()))
+	m.setMentionCandidates(nil)
+
+	m.offline.pr = time.Time{}
+	m.updateOfflineBadge()
+
+	// The loads below run in the context begun here, so leaving the PR or
+	// opening another one cancels whatever is still in flight.
+	m.prLoad.begin(m.ctx)
+	diffCmd := m.withTask("Loading diff", m.loadDiff(pr))
+	commentsCmd := m.withTask("Loading comments", m.loadComments(pr))
+	m.memoryHead = ""
+	if diff := m.prData.diff(pr); diff != nil {
+		// The diff of a head commit does not change, but its comments
+		// do, so they are shown from memory while they are refreshed.
+		m.memoryHead = pr.HeadSHA
+		diffCmd = func() tea.Msg { return DiffLoadedMsg{diff: diff} }
+		if comments, ok := m.prData.comments(pr); ok {
+			commentsCmd = tea.Sequence(func() tea.Msg { return CommentsLoadedMsg{comments: comments} }, commentsCmd)
+		}
+	}
+	return m, tea.Batch(
+		m.withTask("Loading PR", m.loadPRDetail(pr)),
+		diffCmd,
+		commentsCmd,
+		m.loadReviewers(pr),
+		m.loadMentionCandidates(pr),
+		m.loadCodeOwners(pr),
+		m.loadPolicies(pr),
+		m.loadDeployments(pr),
+		m.claimPRLease(),
+		prLeaseTick(m.leasedPR, m.leaseGeneration),
+		m.startReviewTimer(pr),
+		m.prRefreshTick(pr.Key(), m.refreshGeneration),
+	)
+}
+
+// refreshOpenPR reloads the details, comments and deployments of the open PR,
+// and its diff when full is set or the PR turns out to have a new head commit,
+// so that its mergeability, status and comments do not go stale while it
+// stays open.
+func (m Model) refreshOpenPR(full bool) (Model, tea.Cmd) {
+	pr := m.prInspect.GetPR()
+	if pr == nil {
+		return m, nil
+	}
+	logger.Log("UI: Refreshing %s (full: %t)", pr.Key(), full)
+	m.prCache = nil
+	if full {
+		m.memoryHead = ""
+		return m, tea.Batch(
+			m.withTask("Refreshing PR", m.loadPRDetail(*pr)),
+			m.withTask("Loading diff", m.loadDiff(*pr)),
+			m.withTask("Loading comments", m.loadComments(*pr)),
+			m.loadDeployments(*pr),
+		)
+	}
+	// A new head commit is noticed like one of a PR shown from memory.
+	m.memoryHead = pr.HeadSHA
+	return m, tea.Batch(m.loadPRDetail(*pr), m.loadComments(*pr), m.loadDeployments(*pr))
+}
+
+// prRefreshTick schedules the next automatic refresh of the open PR, unless
+// refreshes are turned off.
+func (m Model) prRefreshTick(prKey string, generation int) tea.Cmd {
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		logger.LogError("SETTINGS_LOAD", "", err)
+	}
+	interval := settings.Review.RefreshInterval()
+	if interval <= 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return PRRefreshTickMsg{prKey: prKey, generation: generation}
+	})
+}
+
+// openStackPR opens the PR delta positions up (positive) or down (negative)
+// the stack of the PR being inspected.
+func (m Model) openStackPR(delta int) (Model, tea.Cmd) {
+	pr, ok := m.prInspect.StackNeighbor(delta)
+	if !ok {
+		if delta > 0 {
+			m.statusBar.SetMessage("No PR above this one in the stack", false)
+		} else {
+			m.statusBar.SetMessage("No PR below this one in the stack", false)
+		}
+		return m, nil
+	}
+	releaseCmd := m.releasePRLease()
+	m.prInspect.SetPR(&pr)
+	m, cmd := m.openPR(pr)
+	return m, tea.Batch(releaseCmd, cmd)
+}
+
+// resolvePATIdentity looks up who the token of a PAT being added belongs to,
+// so that a missing username is filled in and a mistyped one is caught before
+// it silently breaks telling authored and assigned PRs apart.
+func (m Model) resolvePATIdentity(pat domain.PAT) tea.Cmd {
+	return m.runTask("Checking username", func(*task) tea.Msg {
+		provider, err := m.createProvider(pat)
+		if err != nil {
+			return PATIdentityResolvedMsg{pat: pat, err: err}
+		}
+		resolver, ok := provider.(domain.IdentityResolver)
+		if !ok {
+			return PATIdentityResolvedMsg{pat: pat, err: fmt.Errorf("%s cannot look up the authenticated user", pat.Provider)}
+		}
+
+		ctx, cancel := m.withRequestTimeout(m.ctx)
+		defer cancel()
+		identity, err := resolver.ResolveIdentity(ctx)
+		return PATIdentityResolvedMsg{pat: pat, identity: identity, err: err}
+	})
+}
+
+// handlePATIdentityResolved saves the added PAT, with the detected username
+// when none was entered, and warns when the username could not be checked or
+// does not belong to the token.
+func (m Model) handlePATIdentityResolved(msg PATIdentityResolvedMsg) (tea.Model, tea.Cmd) {
+	pat := msg.pat
+	message, severity := "PAT added successfully", components.SeverityInfo
+
+	switch {
+	case msg.err != nil && strings.TrimSpace(pat.Username) == "":
+		logger.LogError("RESOLVE_PAT_IDENTITY", pat.Name, msg.err)
+		message = fmt.Sprintf("PAT added, but its username could not be detected (%v); edit it to tell your own PRs apart", msg.err)
+		severity = components.SeverityWarning
+	case msg.err != nil:
+		logger.LogError("RESOLVE_PAT_IDENTITY", pat.Name, msg.err)
+		message = fmt.Sprintf("PAT added, but its username could not be checked: %v", msg.err)
+		severity = components.SeverityWarning
+	case strings.TrimSpace(pat.Username) == "":
+		pat.Username = msg.identity.Username
+		message = fmt.Sprintf("PAT added for %s", pat.Username)
+	case !msg.identity.Matches(pat.Username):
+		message = fmt.Sprintf("PAT added, but the token belongs to %s, not %s; edit the username if it is mistyped", msg.identity.Username, pat.Username)
+		severity = components.SeverityWarning
+	}
+
+	if err := m.repository.SavePAT(pat); err != nil {
+		return m, func() tea.Msg {
+			return ErrorMsg{err: err}
+		}
+	}
+
+	m.statusBar.Notify(message, severity)
+	return m, m.loadPATs()
+}
+
+// testPATConnection checks the credentials in the PAT form without saving
+// them, and looks up who they belong to where the provider can tell.
+// Device-flow logins are checked with the provider already created for them,
+// which owns refreshing and saving their token.
+func (m Model) testPATConnection(pat domain.PAT) tea.Cmd {
+	return m.runTask("Testing connection", func(*task) tea.Msg {
+		var provider domain.Provider
+		if pat.OAuth != nil {
+			provider = m.providers[pat.ID]
+			if provider == nil {
+				return PATConnectionTestedMsg{pat: pat, err: fmt.Errorf("select the PAT to test a device-flow login")}
+			}
+		} else {
+			created, err := m.createProvider(pat)
+			if err != nil {
+				return PATConnectionTestedMsg{pat: pat, err: err}
+			}
+			provider = created
+		}
+
+		ctx, cancel := m.withRequestTimeout(m.ctx)
+		defer cancel()
+		if err := provider.ValidateCredentials(ctx); err != nil {
+			return PATConnectionTestedMsg{pat: pat, err: err}
+		}
+
+		// Looking up the user is a request with the token too, so a rejected
+		// lookup fails the test even when validating the credentials did not.
+		result := PATConnectionTestedMsg{pat: pat}
+		if resolver, ok := provider.(domain.IdentityResolver); ok && !pat.IsAnonymous() {
+			identity, err := resolver.ResolveIdentity(ctx)
+			if err != nil {
+				return PATConnectionTestedMsg{pat: pat, err: err}
+			}
+			result.identity = &identity
+		}
+		return result
+	})
+}
+
+func (m Model) handlePATConnectionTested(msg PATConnectionTestedMsg) {
+	username := strings.TrimSpace(msg.pat.Username)
+	switch {
+	case msg.err != nil:
+		logger.LogError("TEST_PAT_CONNECTION", msg.pat.Name, msg.err)
+		m.statusBar.SetMessage(fmt.Sprintf("Connection failed: %v", msg.err), true)
+	case msg.identity == nil:
+		m.statusBar.Notify("Connection succeeded", components.SeveritySuccess)
+	case username != "" && !msg.identity.Matches(username):
+		m.statusBar.Notify(fmt.Sprintf("Connected, but the token belongs to %s, not %s", msg.identity.Username, username), components.SeverityWarning)
+	default:
+		m.statusBar.Notify(fmt.Sprintf("Connected as %s", msg.identity.Username), components.SeveritySuccess)
+	}
+}
+
+// applyPATs shows pats and creates the providers of the active and selected
+// ones, returning how many are selected.
+func (m Model) applyPATs(pats []domain.PAT) (Model, int) {
+	m.patsView.SetPATs(pats)
+	m.providers = make(map[string]domain.Provider)
+	m.primaryProvider = nil
+	m.primaryPATID = ""
+	m.provider = nil
+
+	selectedCount := 0
+	for _, pat := range pats {
+		var provider domain.Provider
+		var err error
+		if pat.IsActive && m.provider == nil {
+			provider, err = m.createProvider(pat)
+			if err != nil {
+				m.statusBar.SetMessage(fmt.Sprintf("Failed to create provider: %v", err), true)
+			} else {
+				m.provider = provider
+			}
+		}
+
+		if pat.IsSelected {
+			selectedCount++
+			// A PAT that is both active and selected shares one provider,
+			// so that its token is only ever refreshed once.
+			if provider == nil {
+				provider, err = m.createProvider(pat)
+			}
+			if err != nil {
+				logger.LogError("CREATE_PROVIDER", pat.Name, err)
+				continue
+			}
+			m.providers[pat.ID] = provider
+
+			if pat.IsPrimary {
+				m.primaryProvider = provider
+				m.primaryPATID = pat.ID
+				m.topBar.SetActivePAT(pat.Name, string(pat.Provider))
+			}
+		}
+	}
+
+	m.topBar.SetPATCounts(selectedCount, len(pats))
+	return m, selectedCount
+}
+
 func (m Model) handlePATSpaceToggle() (tea.Model, tea.Cmd) {
 	if m.patsView.Mode != views.PATModeList {
 		return m, nil
@@ -708,9 +2154,24 @@ func (m Model) handlePATSpaceToggle() (tea.Model, tea.Cmd) {
 
 func (m Model) handlePATEnter() (tea.Model, tea.Cmd) {
 	if m.patsView.Mode == views.PATModeAdd {
+		if err := m.patsView.ValidateForm(); err != nil {
+			m.statusBar.SetMessage(err.Error(), true)
+			return m, nil
+		}
+
 		newPAT := m.patsView.GetPATData()
 		newPAT.ID = uuid.New().String()
 
+		if newPAT.IsAnonymous() && len(newPAT.Repositories) == 0 {
+			m.statusBar.SetMessage("Public GitHub without a token needs at least one repository (owner/repo)", true)
+			return m, nil
+		}
+
+		if !newPAT.IsAnonymous() && (newPAT.Provider == domain.ProviderGitHub || newPAT.Provider == domain.ProviderAzureDevOps) {
+			m.patsView.ExitEditMode()
+			return m, m.resolvePATIdentity(newPAT)
+		}
+
 		if err := m.repository.SavePAT(newPAT); err != nil {
 			return m, func() tea.Msg {
 				return ErrorMsg{err: err}
@@ -723,14 +2184,27 @@ func (m Model) handlePATEnter() (tea.Model, tea.Cmd) {
 	}
 
 	if m.patsView.Mode == views.PATModeEdit {
+		if err := m.patsView.ValidateForm(); err != nil {
+			m.statusBar.SetMessage(err.Error(), true)
+			return m, nil
+		}
+
 		updatedPAT := m.patsView.GetPATData()
 
+		if updatedPAT.IsAnonymous() && len(updatedPAT.Repositories) == 0 {
+			m.statusBar.SetMessage("Public GitHub without a token needs at least one repository (owner/repo)", true)
+			return m, nil
+		}
+
 		if err := m.repository.SavePAT(updatedPAT); err != nil {
 			return m, func() tea.Msg {
 				return ErrorMsg{err: err}
 			}
 		}
 
+		// The edited token gets a fresh chance instead of staying marked invalid.
+		delete(m.invalidPATs, updatedPAT.ID)
+
 		m.patsView.ExitEditMode()
 		m.topBar.SetActivePAT(updatedPAT.Name, string(updatedPAT.Provider))
 		m.statusBar.SetMessage("PAT updated successfully", false)
@@ -780,51 +2254,1136 @@ func (m Model) handleDeletePAT() (tea.Model, tea.Cmd) {
 	return m, m.loadPATs()
 }
 
-func (m Model) navigateBack() (tea.Model, tea.Cmd) {
-	switch m.state {
-	case ViewPRList:
-		logger.Log("UI: Navigating back from PR List to PATs")
-		m.state = ViewPATs
-		m.topBar.SetContext("", "")
-		m.topBar.SetStats(0, 0)
-		m.topBar.SetPRBreakdown(0, 0, 0)
-		m.topBar.SetView("PATs")
-		m.updateShortcuts()
-		return m, nil
-	case ViewPRInspect:
-		if m.prInspect.GetMode() == views.PRInspectModeDiff {
-			logger.Log("UI: Navigating back from PR Diff to PR Description")
-			m.prInspect.SwitchToDescription()
-			m.topBar.SetView("PR Description")
-			m.updateShortcuts()
-			return m, nil
-		}
-		logger.Log("UI: Navigating back from PR Inspect to PR List")
-		m.state = ViewPRList
-		m.topBar.SetContext("", "")
-		m.topBar.SetView("PR List")
-		m.updateShortcuts()
+func (m Model) jumpToCodeLens() (tea.Model, tea.Cmd) {
+	lens := m.commentDetailView.SelectedCodeLens()
+	if lens == nil {
+		m.statusBar.SetMessage("No referenced identifier found in the diff", true)
+		return m, clearStatusAfterDelay(3 * time.Second)
+	}
+
+	m.commentDetailView.Deactivate()
+	m.prInspect.JumpToLine(lens.FileIndex, lens.LineIndex)
+	m.topBar.SetView("PR Diff")
+	m.updateShortcuts()
+	m.statusBar.SetMessage(fmt.Sprintf("Jumped to `%s` in %s", lens.Identifier, lens.FilePath), false)
+	return m, clearStatusAfterDelay(3 * time.Second)
+}
+
+// jumpToSelectedComment closes the comment view and moves the diff cursor to
+// the line the selected comment is anchored to.
+func (m Model) jumpToSelectedComment() (tea.Model, tea.Cmd) {
+	comment := m.commentDetailView.GetSelectedComment()
+	if comment == nil {
 		return m, nil
 	}
-	return m, nil
+
+	fileIdx, lineIdx, ok := m.prInspect.LocateComment(*comment)
+	if !ok {
+		m.statusBar.SetMessage("Comment is not anchored to a line in the diff", true)
+		return m, clearStatusAfterDelay(3 * time.Second)
+	}
+
+	m.commentDetailView.Deactivate()
+	m.prInspect.JumpToLine(fileIdx, lineIdx)
+	m.topBar.SetView("PR Diff")
+	m.updateShortcuts()
+	m.statusBar.SetMessage(fmt.Sprintf("Jumped to %s:%d", comment.FilePath, comment.Line), false)
+	return m, clearStatusAfterDelay(3 * time.Second)
+}
+
+// startReactionPicker offers the reactions the provider of the open PR
+// supports for the selected comment.
+func (m Model) startReactionPicker() (tea.Model, tea.Cmd) {
+	pr := m.prInspect.GetPR()
+	if pr == nil {
+		return m, nil
+	}
+	reactor, ok := m.getProviderForPR(*pr).(domain.CommentReactor)
+	if !ok {
+		m.statusBar.SetMessage(fmt.Sprintf("Reactions are not supported for %s", pr.ProviderType), true)
+		return m, clearStatusAfterDelay(3 * time.Second)
+	}
+	m.commentDetailView.StartReactionPicker(reactor.Reactions())
+	return m, nil
+}
+
+func (m Model) addReactionToSelectedComment(content string) (tea.Model, tea.Cmd) {
+	pr := m.prInspect.GetPR()
+	comment := m.commentDetailView.GetSelectedComment()
+	if pr == nil || comment == nil {
+		return m, nil
+	}
+	reactor, ok := m.getProviderForPR(*pr).(domain.CommentReactor)
+	if !ok {
+		return m, nil
+	}
+
+	identifier := domain.PRIdentifier{Provider: pr.ProviderType, Repository: pr.Repository.FullName, Number: pr.Number}
+	target := *comment
+	logger.Log("UI: Adding %s reaction to comment %s on %s", content, target.ID, pr.Key())
+	return m, func() tea.Msg {
+		ctx, cancel := m.withRequestTimeout(m.ctx)
+		defer cancel()
+		err := reactor.AddReaction(ctx, identifier, target, content)
+		return ReactionAddedMsg{prKey: pr.Key(), comment: target, content: content, err: err}
+	}
+}
+
+// startThreadStatusPicker offers the thread statuses the provider of the
+// open PR supports for the thread of the selected comment.
+func (m Model) startThreadStatusPicker() (tea.Model, tea.Cmd) {
+	pr := m.prInspect.GetPR()
+	if pr == nil {
+		return m, nil
+	}
+	setter, ok := m.getProviderForPR(*pr).(domain.ThreadStatusSetter)
+	if !ok {
+		m.statusBar.SetMessage(fmt.Sprintf("Thread statuses are not supported for %s", pr.ProviderType), true)
+		return m, clearStatusAfterDelay(3 * time.Second)
+	}
+	if !m.commentDetailView.StartStatusPicker(setter.ThreadStatuses()) {
+		m.statusBar.SetMessage("The selected comment is not part of a thread", true)
+		return m, clearStatusAfterDelay(3 * time.Second)
+	}
+	return m, nil
+}
+
+func (m Model) setSelectedThreadStatus(status domain.ThreadStatus) (tea.Model, tea.Cmd) {
+	pr := m.prInspect.GetPR()
+	comment := m.commentDetailView.GetSelectedComment()
+	if pr == nil || comment == nil {
+		return m, nil
+	}
+	setter, ok := m.getProviderForPR(*pr).(domain.ThreadStatusSetter)
+	if !ok {
+		return m, nil
+	}
+
+	identifier := domain.PRIdentifier{Provider: pr.ProviderType, Repository: pr.Repository.FullName, Number: pr.Number}
+	target := *comment
+	logger.Log("UI: Setting thread %s on %s to %s", target.ThreadID, pr.Key(), status)
+	return m, func() tea.Msg {
+		ctx, cancel := m.withRequestTimeout(m.ctx)
+		defer cancel()
+		err := setter.SetThreadStatus(ctx, identifier, target, status)
+		return ThreadStatusChangedMsg{prKey: pr.Key(), comment: target, status: status, err: err}
+	}
+}
+
+func prLeaseKey(pr domain.PullRequest) string {
+	return pr.Key()
+}
+
+func prLeaseTick(prIdentifier string, generation int) tea.Cmd {
+	return tea.Tick(prLeaseHeartbeat, func(time.Time) tea.Msg {
+		return PRLeaseTickMsg{prIdentifier: prIdentifier, generation: generation}
+	})
+}
+
+// claimPRLease refreshes this instance's lease on the open PR, publishing the
+// current number of pending drafts to other instances.
+func (m Model) claimPRLease() tea.Cmd {
+	if m.leasedPR == "" {
+		return nil
+	}
+	lease := domain.PRLease{
+		PRIdentifier:  m.leasedPR,
+		PendingDrafts: m.prInspect.GetPendingCommentCount(),
+	}
+	return func() tea.Msg {
+		others, err := m.repository.ClaimPR(lease)
+		if err != nil {
+			logger.LogError("CLAIM_PR", lease.PRIdentifier, err)
+			return nil
+		}
+		return PRLeaseMsg{prIdentifier: lease.PRIdentifier, others: others}
+	}
+}
+
+func (m Model) releasePRLease() tea.Cmd {
+	if m.leasedPR == "" {
+		return nil
+	}
+	prIdentifier := m.leasedPR
+	return func() tea.Msg {
+		if err := m.repository.ReleasePR(prIdentifier); err != nil {
+			logger.LogError("RELEASE_PR", prIdentifier, err)
+		}
+		return nil
+	}
+}
+
+func (m Model) handlePRLease(msg PRLeaseMsg) (tea.Model, tea.Cmd) {
+	if msg.prIdentifier != m.leasedPR {
+		return m, nil
+	}
+
+	var withDrafts *domain.PRLease
+	drafts := 0
+	for i, other := range msg.others {
+		drafts += other.PendingDrafts
+		if other.PendingDrafts > 0 && withDrafts == nil {
+			withDrafts = &msg.others[i]
+		}
+	}
+
+	switch {
+	case drafts > 0:
+		m.topBar.SetPRLock(fmt.Sprintf("%d DRAFTS ELSEWHERE", drafts))
+	case len(msg.others) > 0:
+		m.topBar.SetPRLock("OPEN ELSEWHERE")
+	default:
+		m.topBar.SetPRLock("")
+	}
+
+	if withDrafts == nil {
+		m.leaseWarned = false
+		return m, nil
+	}
+	if m.leaseWarned {
+		return m, nil
+	}
+
+	m.leaseWarned = true
+	m.statusBar.SetMessage(fmt.Sprintf("⚠ This PR is open in another lgtmfaster instance (%s, pid %d) with %d pending draft(s)",
+		withDrafts.Hostname, withDrafts.PID, withDrafts.PendingDrafts), true)
+	return m, clearStatusAfterDelay(8 * time.Second)
+}
+
+func (m Model) navigateBack() (tea.Model, tea.Cmd) {
+	switch m.state {
+	case ViewPRList:
+		logger.Log("UI: Navigating back from PR List to PATs")
+		m.lastSession = m.sessionSnapshot()
+		m.state = ViewPATs
+		m.topBar.SetContext("", "")
+		m.topBar.SetStats(0, 0)
+		m.topBar.SetPRBreakdown(0, 0, 0, 0)
+		m.topBar.SetView("PATs")
+		m.updateShortcuts()
+		return m, nil
+	case ViewPRInspect:
+		if m.prInspect.GetMode() == views.PRInspectModeDiff {
+			logger.Log("UI: Navigating back from PR Diff to PR Description")
+			m.prInspect.SwitchToDescription()
+			m.topBar.SetView("PR Description")
+			m.updateShortcuts()
+			return m, nil
+		}
+		logger.Log("UI: Navigating back from PR Inspect to PR List")
+		m.prLoad.stop()
+		m.offline.pr = time.Time{}
+		m.updateOfflineBadge()
+		releaseCmd := m.releasePRLease()
+		m.leasedPR = ""
+		m.topBar.SetPRLock("")
+		m.stopReviewTimer()
+		m.state = ViewPRList
+		m.topBar.SetContext("", "")
+		if query := m.prListView.SearchQuery(); query != "" {
+			m.topBar.SetView("Search: " + query)
+		} else {
+			m.topBar.SetView("PR List")
+		}
+		m.reloadStartedReviews()
+		m.updateShortcuts()
+		return m, releaseCmd
+	}
+	return m, nil
+}
+
+// sessionSnapshot captures the state of the PR views for resuming it on the
+// next launch. Outside of them the snapshot taken when they were left is used.
+func (m Model) sessionSnapshot() *domain.Session {
+	if m.state != ViewPRList && m.state != ViewPRInspect {
+		return m.lastSession
+	}
+
+	session := &domain.Session{
+		View:    domain.SessionViewPRList,
+		Filter:  m.prListView.GetFilterText(),
+		SavedAt: time.Now(),
+	}
+	if pr := m.prListView.GetSelectedPR(); pr != nil {
+		session.SelectedPR = pr.Key()
+	}
+
+	if pr := m.prInspect.GetPR(); m.state == ViewPRInspect && pr != nil {
+		session.View = domain.SessionViewPRInspect
+		session.PR = &domain.SessionPR{
+			ProviderType: pr.ProviderType,
+			PATID:        pr.PATID,
+			Repository:   pr.Repository.FullName,
+			Number:       pr.Number,
+			Title:        pr.Title,
+		}
+		if m.prInspect.GetMode() == views.PRInspectModeDiff {
+			session.PR.DiffMode = true
+			session.PR.FileIndex, session.PR.LineIndex, session.PR.ScrollOffset = m.prInspect.Position()
+		}
+	}
+	return session
+}
+
+// saveSession stores the session on exit, or clears the saved one when there
+// is nothing to resume.
+func (m Model) saveSession() {
+	session := m.sessionSnapshot()
+	var err error
+	if session == nil || session.IsEmpty() {
+		err = m.repository.ClearSession()
+	} else {
+		err = m.repository.SaveSession(*session)
+	}
+	if err != nil {
+		logger.LogError("SESSION_SAVE", "", err)
+	}
+}
+
+// offerSessionResume shows the resume prompt when a session was saved. A PR
+// whose PAT is no longer selected cannot be reopened, so only the rest of the
+// session is offered then.
+func (m Model) offerSessionResume() {
+	session, err := m.repository.GetSession()
+	if err != nil {
+		logger.LogError("SESSION_LOAD", "", err)
+		return
+	}
+	if session == nil {
+		return
+	}
+	if session.PR != nil && m.providers[session.PR.PATID] == nil {
+		session.View = domain.SessionViewPRList
+		session.PR = nil
+	}
+	if session.IsEmpty() {
+		return
+	}
+	m.resumeView.Activate(*session)
+}
+
+// resumeSession restores the filter and selection of the PR list and reopens
+// the PR of the accepted session. The cursor and diff position are restored
+// once the list and the diff have loaded.
+func (m Model) resumeSession() (tea.Model, tea.Cmd) {
+	session := m.resumeView.GetSession()
+	m.resumeView.Deactivate()
+	if session == nil {
+		return m, nil
+	}
+	logger.Log("UI: Resuming session (view %s)", session.View)
+
+	if session.Filter != "" {
+		m.prListView.SetFilterText(session.Filter)
+	}
+	m.pendingSelection = session.SelectedPR
+	if m.pendingSelection != "" && m.prListView.SelectPR(m.pendingSelection) {
+		m.pendingSelection = ""
+	}
+
+	if session.View != domain.SessionViewPRInspect || session.PR == nil {
+		return m, nil
+	}
+	if session.PR.DiffMode {
+		m.pendingPosition = session.PR
+	}
+	pr := session.PR.PullRequest()
+	m.prInspect.SetPR(&pr)
+	return m.openPR(pr)
+}
+
+// holdReview starts the undo window of the confirmed review. The provider is
+// only called once the window has passed without the review being undone.
+func (m Model) holdReview() (Model, tea.Cmd) {
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		logger.LogError("SETTINGS_LOAD", "", err)
+	}
+	window := settings.Review.UndoWindow()
+	if window <= 0 {
+		return m, m.submitReview()
+	}
+
+	held := &heldReview{
+		mode:     m.reviewView.GetMode(),
+		body:     m.reviewView.GetValue(),
+		deadline: time.Now().Add(window),
+	}
+	var flush tea.Cmd
+	if m.heldReview != nil {
+		held.id = m.heldReview.id + 1
+		// A review still waiting out its undo window is sent right away
+		// instead of being dropped.
+		flush = m.runTask("Submitting review", m.heldReview.submit)
+	}
+	if pr := m.prInspect.GetPR(); pr != nil {
+		held.prKey = pr.Key()
+	}
+	held.submit = m.prepareReview()
+	m.heldReview = held
+
+	m.statusBar.SetMessage(reviewCountdownMessage(window), false)
+	return m, tea.Batch(flush, reviewUndoTick(held.id))
+}
+
+// confirmReview holds the confirmed review and, in triage, moves on to the
+// next PR.
+func (m Model) confirmReview() (Model, tea.Cmd) {
+	mode := m.reviewView.GetMode()
+	triaging := m.triaging()
+	m, cmd := m.holdReview()
+	if !triaging {
+		return m, cmd
+	}
+	m.triage.recordReview(mode)
+	m, next := m.advanceTriage("")
+	return m, tea.Batch(cmd, next)
+}
+
+// logsRefreshInterval is how often the open logs view picks up new entries.
+const logsRefreshInterval = 500 * time.Millisecond
+
+func logsTick(generation int) tea.Cmd {
+	return tea.Tick(logsRefreshInterval, func(time.Time) tea.Msg {
+		return LogsTickMsg{generation: generation}
+	})
+}
+
+// prFilterDebounce is how long typing in the PR list filter pauses before
+// the list is filtered, so that long lists do not rebuild on every key.
+const prFilterDebounce = 150 * time.Millisecond
+
+func prFilterTick(generation int) tea.Cmd {
+	return tea.Tick(prFilterDebounce, func(time.Time) tea.Msg {
+		return PRFilterTickMsg{generation: generation}
+	})
+}
+
+func reviewUndoTick(id int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return ReviewUndoTickMsg{id: id}
+	})
+}
+
+func reviewCountdownMessage(remaining time.Duration) string {
+	seconds := int((remaining + time.Second - 1) / time.Second)
+	return fmt.Sprintf("Submitting review in %ds (u: undo)", seconds)
+}
+
+// handleReviewUndoTick updates the countdown and sends the held review once
+// its undo window has passed.
+func (m Model) handleReviewUndoTick(msg ReviewUndoTickMsg) (Model, tea.Cmd) {
+	if m.heldReview == nil || m.heldReview.id != msg.id {
+		return m, nil
+	}
+
+	remaining := time.Until(m.heldReview.deadline)
+	if remaining > 0 {
+		m.statusBar.SetMessage(reviewCountdownMessage(remaining), false)
+		return m, reviewUndoTick(msg.id)
+	}
+
+	submit := m.heldReview.submit
+	m.heldReview = nil
+	m.statusBar.ClearMessage()
+	return m, m.runTask("Submitting review", submit)
+}
+
+// quit exits the app. A review still waiting out its undo window is sent
+// first rather than lost; when sending it fails it is kept in the outbox.
+func (m Model) quit() (Model, tea.Cmd) {
+	m.stopReviewTimer()
+	m.saveSession()
+	if m.webhookServer != nil {
+		if err := m.webhookServer.Close(); err != nil {
+			logger.LogError("WEBHOOK_CLOSE", m.webhookServer.Addr(), err)
+		}
+	}
+	held := m.heldReview
+	if held == nil {
+		return m, tea.Quit
+	}
+	m.heldReview = nil
+	m.statusBar.SetMessage("Submitting review before quitting...", false)
+	return m, func() tea.Msg {
+		if msg, ok := held.submit(nil).(ErrorMsg); ok {
+			logger.LogError("REVIEW_SUBMIT", held.prKey, msg.err)
+		}
+		return tea.QuitMsg{}
+	}
+}
+
+// undoReview cancels the held review. When its PR is still open the review
+// editor is reopened with the original body.
+func (m Model) undoReview() (Model, tea.Cmd) {
+	held := m.heldReview
+	if held == nil {
+		m.statusBar.SetMessage("Nothing to undo", false)
+		return m, clearStatusAfterDelay(3 * time.Second)
+	}
+	m.heldReview = nil
+
+	if pr := m.prInspect.GetPR(); m.state == ViewPRInspect && pr != nil && pr.Key() == held.prKey {
+		m.reviewView.Activate(held.mode)
+		m.restoreDraft()
+		m.reviewView.SetValue(held.body)
+	}
+	m.statusBar.SetMessage("Review cancelled", false)
+	return m, tea.Batch(clearStatusAfterDelay(3*time.Second), m.scheduleDraftSave())
+}
+
+// resolvePRReference looks ref up with each selected PAT that could own it,
+// in order, and opens the first match. A PR that is not in the list can still
+// be opened this way.
+func (m Model) resolvePRReference(ref domain.PRReference) tea.Cmd {
+	return func() tea.Msg {
+		selectedPATs, _, err := m.usableSelectedPATs()
+		if err != nil {
+			return ErrorMsg{err: err}
+		}
+
+		identifier := domain.PRIdentifier{Repository: ref.Repository, Number: ref.Number}
+		var lastErr error
+		tried := 0
+		for _, pat := range selectedPATs {
+			provider := m.providers[pat.ID]
+			if provider == nil || (ref.Provider != "" && pat.Provider != ref.Provider) {
+				continue
+			}
+			if ref.Organization != "" && !strings.EqualFold(pat.Organization, ref.Organization) {
+				continue
+			}
+			tried++
+
+			identifier.Provider = pat.Provider
+			ctx, cancel := m.withRequestTimeout(m.ctx)
+			pr, err := provider.GetPullRequest(ctx, identifier)
+			cancel()
+			if err == nil && pr == nil {
+				err = fmt.Errorf("pull request not found")
+			}
+			if err != nil {
+				logger.LogError("OPEN_PR", fmt.Sprintf("%s#%d (%s)", ref.Repository, ref.Number, pat.Name), err)
+				lastErr = err
+				continue
+			}
+			pr.ProviderType = pat.Provider
+			pr.PATID = pat.ID
+			return PRResolvedMsg{pr: *pr}
+		}
+
+		switch {
+		case tried == 0 && ref.Organization != "":
+			return ErrorMsg{err: fmt.Errorf("no selected PAT for Azure DevOps organization %s", ref.Organization)}
+		case tried == 0:
+			return ErrorMsg{err: fmt.Errorf("no selected PAT for %s", ref.Provider)}
+		}
+		return ErrorMsg{err: fmt.Errorf("could not open %s#%d: %w", ref.Repository, ref.Number, lastErr)}
+	}
+}
+
+// reviewManagerForPR returns the provider of pr if it can manage reviewers.
+func (m Model) reviewManagerForPR(pr domain.PullRequest) (domain.ReviewManager, bool) {
+	provider := m.getProviderForPR(pr)
+	if provider == nil {
+		return nil, false
+	}
+	manager, ok := provider.(domain.ReviewManager)
+	return manager, ok
+}
+
+func (m Model) loadReviewers(pr domain.PullRequest) tea.Cmd {
+	lister, ok := m.getProviderForPR(pr).(domain.ReviewerLister)
+	if !ok {
+		return func() tea.Msg {
+			return ReviewersLoadedMsg{prKey: pr.Key(), err: fmt.Errorf("reviewer management is not supported for %s", pr.ProviderType)}
+		}
+	}
+
+	identifier := domain.PRIdentifier{Provider: pr.ProviderType, Repository: pr.Repository.FullName, Number: pr.Number}
+	parent := m.prLoad.context(m.ctx)
+	return func() tea.Msg {
+		ctx, cancel := m.withRequestTimeout(parent)
+		defer cancel()
+		reviewers, err := lister.ListReviewers(ctx, identifier)
+		return ReviewersLoadedMsg{prKey: pr.Key(), reviewers: reviewers, err: err}
+	}
+}
+
+// loadMentionCandidates returns nil when the provider cannot suggest users
+// to mention, in which case typing "@" suggests nothing.
+func (m Model) loadMentionCandidates(pr domain.PullRequest) tea.Cmd {
+	mentioner, ok := m.getProviderForPR(pr).(domain.Mentioner)
+	if !ok {
+		return nil
+	}
+
+	identifier := domain.PRIdentifier{Provider: pr.ProviderType, Repository: pr.Repository.FullName, Number: pr.Number}
+	parent := m.prLoad.context(m.ctx)
+	return func() tea.Msg {
+		ctx, cancel := m.withRequestTimeout(parent)
+		defer cancel()
+		candidates, err := mentioner.ListMentionCandidates(ctx, identifier)
+		return MentionCandidatesLoadedMsg{prKey: pr.Key(), candidates: candidates, err: err}
+	}
+}
+
+// loadCodeOwners returns nil when the provider cannot read CODEOWNERS, in
+// which case file headers name no owners.
+func (m Model) loadCodeOwners(pr domain.PullRequest) tea.Cmd {
+	reader, ok := m.getProviderForPR(pr).(domain.CodeOwnersReader)
+	if !ok {
+		return nil
+	}
+
+	identifier := domain.PRIdentifier{Provider: pr.ProviderType, Repository: pr.Repository.FullName, Number: pr.Number}
+	parent := m.prLoad.context(m.ctx)
+	return func() tea.Msg {
+		ctx, cancel := m.withRequestTimeout(parent)
+		defer cancel()
+		owners, err := reader.GetCodeOwners(ctx, identifier)
+		return CodeOwnersLoadedMsg{prKey: pr.Key(), owners: owners, err: err}
+	}
+}
+
+// loadPolicies returns nil when the provider has no branch policies.
+func (m Model) loadPolicies(pr domain.PullRequest) tea.Cmd {
+	evaluator, ok := m.getProviderForPR(pr).(domain.PolicyEvaluator)
+	if !ok {
+		return nil
+	}
+
+	identifier := domain.PRIdentifier{Provider: pr.ProviderType, Repository: pr.Repository.FullName, Number: pr.Number}
+	parent := m.prLoad.context(m.ctx)
+	return func() tea.Msg {
+		ctx, cancel := m.withRequestTimeout(parent)
+		defer cancel()
+		policies, err := evaluator.GetPolicyEvaluations(ctx, identifier)
+		return PoliciesLoadedMsg{prKey: pr.Key(), policies: policies, err: err}
+	}
+}
+
+// loadFailingChecks returns nil when the provider keeps no check output.
+func (m Model) loadFailingChecks(pr domain.PullRequest) tea.Cmd {
+	reader, ok := m.getProviderForPR(pr).(domain.CheckOutputReader)
+	if !ok {
+		return nil
+	}
+
+	identifier := domain.PRIdentifier{Provider: pr.ProviderType, Repository: pr.Repository.FullName, Number: pr.Number}
+	parent := m.prLoad.context(m.ctx)
+	return func() tea.Msg {
+		ctx, cancel := m.withRequestTimeout(parent)
+		defer cancel()
+		checks, err := reader.GetFailingChecks(ctx, identifier)
+		return FailingChecksLoadedMsg{prKey: pr.Key(), checks: checks, err: err}
+	}
+}
+
+// loadDeployments returns nil when the provider does not track deployments.
+func (m Model) loadDeployments(pr domain.PullRequest) tea.Cmd {
+	lister, ok := m.getProviderForPR(pr).(domain.DeploymentLister)
+	if !ok {
+		return nil
+	}
+
+	identifier := domain.PRIdentifier{Provider: pr.ProviderType, Repository: pr.Repository.FullName, Number: pr.Number}
+	parent := m.prLoad.context(m.ctx)
+	return func() tea.Msg {
+		ctx, cancel := m.withRequestTimeout(parent)
+		defer cancel()
+		deployments, err := lister.ListDeployments(ctx, identifier)
+		return DeploymentsLoadedMsg{prKey: pr.Key(), deployments: deployments, err: err}
+	}
+}
+
+// outlineMaxFiles caps how many Go files are read to outline a diff; the
+// others fall back to the hunk headings.
+const outlineMaxFiles = 50
+
+// loadOutlineContents reads the Go files of diff at the PR's head commit so
+// that their symbols can be parsed. It returns nil when the provider cannot
+// read files or there are none to read. Files that fail to load are left
+// out and fall back to the hunk headings.
+func (m Model) loadOutlineContents(pr domain.PullRequest, diff *domain.Diff) tea.Cmd {
+	reader, ok := m.getProviderForPR(pr).(domain.FileContentReader)
+	if !ok || pr.HeadSHA == "" || diff == nil {
+		return nil
+	}
+	var paths []string
+	for _, file := range diff.Files {
+		if !file.IsDeleted && len(file.Hunks) > 0 && outline.IsGoFile(file.NewPath) && len(paths) < outlineMaxFiles {
+			paths = append(paths, file.NewPath)
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	identifier := domain.PRIdentifier{Provider: pr.ProviderType, Repository: pr.Repository.FullName, Number: pr.Number}
+	parent := m.prLoad.context(m.ctx)
+	return m.withTask("Reading the changed files", func() tea.Msg {
+		ctx, cancel := m.withRequestTimeout(parent)
+		defer cancel()
+		contents := make(map[string]string)
+		for _, path := range paths {
+			content, err := reader.GetFileContent(ctx, identifier, path, pr.HeadSHA)
+			if err != nil {
+				logger.LogError("OUTLINE_LOAD", path, err)
+				continue
+			}
+			contents[path] = content
+		}
+		return OutlineLoadedMsg{prKey: pr.Key(), contents: contents}
+	})
+}
+
+// setMentionCandidates offers candidates in every comment editor.
+func (m Model) setMentionCandidates(candidates []domain.MentionCandidate) {
+	m.reviewView.SetMentionCandidates(candidates)
+	m.inlineCommentView.SetMentionCandidates(candidates)
+	m.descriptionEditView.SetMentionCandidates(candidates)
+}
+
+// analyzeDiff runs the configured diff hooks over every file of diff, one
+// file after the other so that findings appear as each file is done. It
+// returns nil when no hooks are configured.
+func (m Model) analyzeDiff(diff *domain.Diff) tea.Cmd {
+	pr := m.prInspect.GetPR()
+	if pr == nil || diff == nil {
+		return nil
+	}
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		logger.LogError("SETTINGS_LOAD", "", err)
+		return nil
+	}
+	runner, err := hooks.NewRunner(settings.DiffHooks)
+	if err != nil {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for _, file := range diff.Files {
+		if file.Binary == nil {
+			cmds = append(cmds, m.analyzeFile(runner, *pr, file, false))
+		}
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	return tea.Sequence(cmds...)
+}
+
+// analyzeLoadedFile runs the diff hooks on a deferred file once its hunks are
+// loaded. It returns nil when no hooks are configured.
+func (m Model) analyzeLoadedFile(file domain.FileDiff) tea.Cmd {
+	pr := m.prInspect.GetPR()
+	if pr == nil || file.Binary != nil {
+		return nil
+	}
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		logger.LogError("SETTINGS_LOAD", "", err)
+		return nil
+	}
+	runner, err := hooks.NewRunner(settings.DiffHooks)
+	if err != nil {
+		return nil
+	}
+	return m.analyzeFile(runner, *pr, file, false)
+}
+
+// analyzeFile runs the diff hooks on one file of pr. announce reports the
+// number of findings in the status bar.
+func (m Model) analyzeFile(runner *hooks.Runner, pr domain.PullRequest, file domain.FileDiff, announce bool) tea.Cmd {
+	path := file.NewPath
+	if path == "" {
+		path = file.OldPath
+	}
+	ctx := m.ctx
+	return func() tea.Msg {
+		annotations, err := runner.Analyze(ctx, pr.Repository.FullName, file)
+		return DiffAnnotationsLoadedMsg{prKey: pr.Key(), path: path, annotations: annotations, err: err, announce: announce}
+	}
+}
+
+// loadDictionary loads the configured spell-check dictionary with the words
+// added from the editors.
+func loadDictionary(settings domain.SpellCheckSettings) (*spell.Dictionary, error) {
+	dict, err := spell.Load(settings.Dictionary)
+	if err != nil {
+		return nil, err
+	}
+	for _, word := range settings.Words {
+		dict.Add(word)
+	}
+	return dict, nil
+}
+
+// setDictionary turns spell-checking on in the review and inline comment
+// editors, or off when dict is nil.
+func (m Model) setDictionary(dict *spell.Dictionary) {
+	m.reviewView.SetDictionary(dict)
+	m.inlineCommentView.SetDictionary(dict)
+}
+
+// addDictionaryWord saves a word added to the dictionary from an editor so
+// that it is accepted in later sessions too. An empty word is ignored.
+func (m Model) addDictionaryWord(word string) {
+	if word == "" {
+		return
+	}
+
+	settings, err := m.repository.GetSettings()
+	if err == nil {
+		if !slices.Contains(settings.SpellCheck.Words, word) {
+			settings.SpellCheck.Words = append(settings.SpellCheck.Words, word)
+		}
+		err = m.repository.SaveSettings(settings)
+	}
+	if err != nil {
+		logger.LogError("SETTINGS_SAVE", "", err)
+		m.statusBar.SetMessage(fmt.Sprintf("Added %q to the dictionary for this session only: %v", word, err), true)
+		return
+	}
+	m.statusBar.SetMessage(fmt.Sprintf("Added %q to the dictionary", word), false)
+}
+
+// loadMergeRequirements returns nil when the provider cannot report merge
+// requirements, in which case the merge view simply omits them.
+func (m Model) loadMergeRequirements(pr domain.PullRequest) tea.Cmd {
+	checker, ok := m.getProviderForPR(pr).(domain.MergeRequirementsChecker)
+	if !ok {
+		return nil
+	}
+
+	identifier := domain.PRIdentifier{Provider: pr.ProviderType, Repository: pr.Repository.FullName, Number: pr.Number}
+	return func() tea.Msg {
+		ctx, cancel := m.withRequestTimeout(m.ctx)
+		defer cancel()
+		requirements, err := checker.GetMergeRequirements(ctx, identifier)
+		return MergeRequirementsLoadedMsg{prKey: pr.Key(), requirements: requirements, err: err}
+	}
+}
+
+// loadConflictFiles returns nil when the provider cannot list conflicts.
+func (m Model) loadConflictFiles(pr domain.PullRequest) tea.Cmd {
+	lister, ok := m.getProviderForPR(pr).(domain.ConflictLister)
+	if !ok {
+		return nil
+	}
+
+	identifier := domain.PRIdentifier{Provider: pr.ProviderType, Repository: pr.Repository.FullName, Number: pr.Number}
+	return func() tea.Msg {
+		ctx, cancel := m.withRequestTimeout(m.ctx)
+		defer cancel()
+		files, err := lister.ListConflictFiles(ctx, identifier)
+		return ConflictFilesLoadedMsg{prKey: pr.Key(), files: files, err: err}
+	}
+}
+
+// approveAndMergeDependencies approves and merges the selected dependency
+// updates one after another. A PR whose approval fails is not merged, and a
+// failure does not stop the remaining PRs. PRs that could not be merged
+// right away, such as ones with failing or pending checks, are skipped
+// without being approved, as are PRs of repositories with a review
+// checklist, which has to be gone through in the review editor.
+func (m Model) approveAndMergeDependencies() (Model, tea.Cmd) {
+	prs := m.dependenciesView.SelectedPRs()
+	m.dependenciesView.SetRunning()
+
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		logger.LogError("SETTINGS_LOAD", "", err)
+	}
+	deleteBranch := !settings.Merge.KeepSourceBranch
+
+	type batchItem struct {
+		pr        domain.PullRequest
+		provider  domain.Provider
+		checklist bool
+	}
+	items := make([]batchItem, 0, len(prs))
+	for _, pr := range prs {
+		items = append(items, batchItem{
+			pr:        pr,
+			provider:  m.getProviderForPR(pr),
+			checklist: len(settings.Review.Checklist(pr.Repository)) > 0,
+		})
+	}
+
+	return m, func() tea.Msg {
+		var done DependencyBatchDoneMsg
+		for _, item := range items {
+			pr := item.pr
+			prIdentifier := fmt.Sprintf("%s#%d", pr.Repository.FullName, pr.Number)
+			if item.checklist {
+				done.skipped = append(done.skipped, fmt.Sprintf("%s (review checklist)", prIdentifier))
+				continue
+			}
+			skipped, err := m.approveAndMergeDependency(pr, item.provider, deleteBranch)
+			if err != nil {
+				logger.LogError("DEPENDENCY_BATCH", prIdentifier, err)
+				done.failures = append(done.failures, fmt.Sprintf("%s: %v", prIdentifier, err))
+				continue
+			}
+			if skipped != "" {
+				logger.Log("UI: Skipped dependency update %s: %s", prIdentifier, skipped)
+				done.skipped = append(done.skipped, fmt.Sprintf("%s (%s)", prIdentifier, skipped))
+				continue
+			}
+			done.merged++
+		}
+		return done
+	}
+}
+
+// approveAndMergeDependency approves and merges one dependency update. It
+// returns why the PR was skipped instead, when its merge requirements are
+// unmet or cannot be checked.
+func (m Model) approveAndMergeDependency(pr domain.PullRequest, provider domain.Provider, deleteBranch bool) (string, error) {
+	if provider == nil {
+		return "", fmt.Errorf("no provider available")
+	}
+	identifier := domain.PRIdentifier{Provider: pr.ProviderType, Repository: pr.Repository.FullName, Number: pr.Number}
+	ctx, cancel := m.withRequestTimeout(m.ctx)
+	defer cancel()
+
+	checker, ok := provider.(domain.MergeRequirementsChecker)
+	if !ok {
+		return "merge requirements cannot be checked", nil
+	}
+	requirements, err := checker.GetMergeRequirements(ctx, identifier)
+	if err != nil {
+		logger.LogError("DEPENDENCY_BATCH", pr.Key(), err)
+		return "merge requirements could not be loaded", nil
+	}
+	// The approval about to be given counts towards the required ones.
+	pending := *requirements
+	pending.Approvals++
+	if unmet := pending.Unmet(); len(unmet) > 0 {
+		return strings.Join(unmet, ", "), nil
+	}
+	method := views.DefaultMergeMethod(pr.ProviderType, requirements)
+	if method == "" {
+		return "", fmt.Errorf("no merge method is allowed")
+	}
+
+	review := domain.Review{
+		PRIdentifier: fmt.Sprintf("%s/%d", pr.Repository.FullName, pr.Number),
+		Action:       domain.ReviewActionApprove,
+	}
+	if err := provider.SubmitReview(ctx, review); err != nil {
+		return "", fmt.Errorf("approve: %w", err)
+	}
+	m.recordActivity(domain.ActivityEvent{
+		Type:         domain.ActivityReviewSubmitted,
+		Provider:     pr.ProviderType,
+		PRIdentifier: review.PRIdentifier,
+	})
+
+	if err := provider.MergePullRequest(ctx, identifier, method, deleteBranch); err != nil && !errors.Is(err, common.ErrSourceBranchKept) {
+		return "", fmt.Errorf("merge: %w", err)
+	}
+	m.recordActivity(domain.ActivityEvent{
+		Type:         domain.ActivityPRMerged,
+		Provider:     pr.ProviderType,
+		PRIdentifier: fmt.Sprintf("%s#%d", pr.Repository.FullName, pr.Number),
+	})
+	return "", nil
+}
+
+func (m Model) dismissSelectedReview() (Model, tea.Cmd) {
+	pr := m.reviewersView.GetPR()
+	reviewer := m.reviewersView.GetSelectedReviewer()
+	message := m.reviewersView.DismissMessage()
+	if pr == nil || reviewer == nil {
+		m.reviewersView.CancelDismiss()
+		return m, nil
+	}
+	if message == "" {
+		m.statusBar.SetMessage("A dismissal message is required", true)
+		return m, nil
+	}
+	m.reviewersView.CancelDismiss()
+
+	manager, ok := m.reviewManagerForPR(*pr)
+	if !ok {
+		m.statusBar.SetMessage("Dismissing reviews is not supported for this provider", true)
+		return m, nil
+	}
+
+	identifier := domain.PRIdentifier{Provider: pr.ProviderType, Repository: pr.Repository.FullName, Number: pr.Number}
+	username := reviewer.User.Username
+	reviewID := reviewer.ReviewID
+	logger.Log("UI: Dismissing review %s of %s on %s", reviewID, username, pr.Key())
+	return m, func() tea.Msg {
+		ctx, cancel := m.withRequestTimeout(m.ctx)
+		defer cancel()
+		if err := manager.DismissReview(ctx, identifier, reviewID, message); err != nil {
+			return ReviewerActionMsg{err: fmt.Errorf("failed to dismiss review: %w", err)}
+		}
+		return ReviewerActionMsg{message: fmt.Sprintf("Dismissed the review of %s", username)}
+	}
+}
+
+func (m Model) rerequestSelectedReview() (Model, tea.Cmd) {
+	pr := m.reviewersView.GetPR()
+	reviewer := m.reviewersView.GetSelectedReviewer()
+	if pr == nil || reviewer == nil {
+		return m, nil
+	}
+	if reviewer.State == domain.ReviewerPending {
+		m.statusBar.SetMessage(fmt.Sprintf("A review from %s is already requested", reviewer.User.Username), false)
+		return m, clearStatusAfterDelay(4 * time.Second)
+	}
+
+	manager, ok := m.reviewManagerForPR(*pr)
+	if !ok {
+		m.statusBar.SetMessage("Requesting reviews is not supported for this provider", true)
+		return m, nil
+	}
+
+	identifier := domain.PRIdentifier{Provider: pr.ProviderType, Repository: pr.Repository.FullName, Number: pr.Number}
+	username := reviewer.User.Username
+	logger.Log("UI: Re-requesting review from %s on %s", username, pr.Key())
+	return m, func() tea.Msg {
+		ctx, cancel := m.withRequestTimeout(m.ctx)
+		defer cancel()
+		if err := manager.RequestReview(ctx, identifier, username); err != nil {
+			return ReviewerActionMsg{err: fmt.Errorf("failed to request review: %w", err)}
+		}
+		return ReviewerActionMsg{message: fmt.Sprintf("Requested a new review from %s", username)}
+	}
+}
+
+// isOwnPR reports whether pr was authored by the user of the PAT it was
+// loaded with.
+func (m Model) isOwnPR(pr domain.PullRequest) bool {
+	username := m.patUsername(pr)
+	return username != "" && pr.Author.Username == username
+}
+
+// patUsername returns the username of the PAT pr was loaded with.
+func (m Model) patUsername(pr domain.PullRequest) string {
+	if pr.PATID == "" {
+		return ""
+	}
+	pat, err := m.repository.GetPAT(pr.PATID)
+	if err != nil || pat == nil {
+		return ""
+	}
+	return pat.Username
+}
+
+// reviewAccounts returns the accounts a review of pr can be submitted as:
+// the PAT it was loaded with, then the other selected PATs of the same
+// provider and organization, such as a bot account next to a personal one.
+func (m Model) reviewAccounts(pr domain.PullRequest) []views.ReviewAccount {
+	own, err := m.repository.GetPAT(pr.PATID)
+	if err != nil || own == nil {
+		return nil
+	}
+	accounts := []views.ReviewAccount{{PATID: own.ID, Name: own.Name, Username: own.Username}}
+
+	pats, err := m.repository.GetSelectedPATs()
+	if err != nil {
+		logger.LogError("REVIEW_ACCOUNTS", "", err)
+		return accounts
+	}
+	for _, pat := range pats {
+		if pat.ID == own.ID || pat.Provider != own.Provider || pat.Organization != own.Organization ||
+			pat.IsAnonymous() || m.providers[pat.ID] == nil {
+			continue
+		}
+		accounts = append(accounts, views.ReviewAccount{PATID: pat.ID, Name: pat.Name, Username: pat.Username})
+	}
+	return accounts
+}
+
+// showReviewConfirmation replaces the review editor with the summary of what
+// is about to be submitted, with the accounts it can be submitted as and the
+// checklist of the PR's repository.
+func (m *Model) showReviewConfirmation() {
+	if pr := m.prInspect.GetPR(); pr != nil {
+		m.reviewView.SetAccounts(m.reviewAccounts(*pr))
+		m.reviewView.SetChecklist(m.reviewChecklist(*pr))
+	}
+	m.reviewView.ShowConfirmation(m.prInspect.GetPendingComments(), m.reviewConfirmationNote())
+}
+
+// reviewChecklist returns what to check before approving pr.
+func (m Model) reviewChecklist(pr domain.PullRequest) []domain.ChecklistItem {
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		logger.LogError("SETTINGS_LOAD", "", err)
+		return nil
+	}
+	return settings.Review.Checklist(pr.Repository)
+}
+
+// reviewerPR returns pr as seen by the account the review is submitted as.
+func (m Model) reviewerPR(pr domain.PullRequest) domain.PullRequest {
+	if account, ok := m.reviewView.SelectedAccount(); ok {
+		pr.PATID = account.PATID
+	}
+	return pr
+}
+
+// reviewConfirmationNote warns in the submission summary about what will not
+// be posted as written: an approval or change request that is going to be
+// posted as a plain comment, and local files the provider cannot host.
+func (m Model) reviewConfirmationNote() string {
+	pr := m.prInspect.GetPR()
+	if pr == nil {
+		return ""
+	}
+	submitAs := m.reviewerPR(*pr)
+	review := m.reviewView.GetReview()
+
+	var notes []string
+	if m.isOwnPR(submitAs) {
+		switch review.Action {
+		case domain.ReviewActionApprove, domain.ReviewActionRequestChanges:
+			notes = append(notes, "This is your own PR; the review will be posted as a comment")
+		}
+	}
+	if _, ok := m.getProviderForPR(submitAs).(domain.AttachmentUploader); !ok {
+		review.Comments = append(review.Comments, m.prInspect.GetPendingComments()...)
+		if _, omitted := omitReviewAttachments(review); omitted > 0 {
+			notes = append(notes, fmt.Sprintf("%s cannot host attachments; %d local file(s) will be left out and only named", pr.ProviderType, omitted))
+		}
+	}
+	return strings.Join(notes, "; ")
 }
 
 func (m Model) submitReview() tea.Cmd {
+	return m.runTask("Submitting review", m.prepareReview())
+}
+
+// prepareReview takes the review out of the editor, together with the
+// pending inline comments, and returns the function that sends it.
+func (m Model) prepareReview() func(*task) tea.Msg {
 	review := m.reviewView.GetReview()
-	m.reviewView.Deactivate()
 
 	pr := m.prInspect.GetPR()
 	if pr == nil {
+		m.reviewView.Deactivate()
 		logger.LogError("SUBMIT_REVIEW", "UI", fmt.Errorf("no PR selected"))
-		return func() tea.Msg {
+		return func(*task) tea.Msg {
 			return ErrorMsg{err: fmt.Errorf("no PR selected")}
 		}
 	}
+	// The review is sent, and queued when it fails, with the account chosen
+	// in the confirmation.
+	submitAs := m.reviewerPR(*pr)
+	m.reviewView.Deactivate()
 
-	provider := m.getProviderForPR(*pr)
+	provider := m.getProviderForPR(submitAs)
 	if provider == nil {
 		logger.LogError("SUBMIT_REVIEW", "UI", fmt.Errorf("no provider available for PR"))
-		return func() tea.Msg {
+		return func(*task) tea.Msg {
 			return ErrorMsg{err: fmt.Errorf("no provider available for PR")}
 		}
 	}
@@ -832,16 +3391,7 @@ func (m Model) submitReview() tea.Cmd {
 	pendingComments := m.prInspect.GetPendingComments()
 	review.Comments = append(review.Comments, pendingComments...)
 
-	var authenticatedUser string
-	if pr.PATID != "" {
-		pat, err := m.repository.GetPAT(pr.PATID)
-		if err == nil && pat != nil {
-			authenticatedUser = pat.Username
-		}
-	}
-
-	isOwnPR := authenticatedUser != "" && pr.Author.Username == authenticatedUser
-	if isOwnPR && (review.Action == domain.ReviewActionApprove || review.Action == domain.ReviewActionRequestChanges) {
+	if m.isOwnPR(submitAs) && (review.Action == domain.ReviewActionApprove || review.Action == domain.ReviewActionRequestChanges) {
 		logger.Log("UI: Cannot %s your own PR, converting to comment", review.Action)
 		review.Action = domain.ReviewActionComment
 	}
@@ -850,18 +3400,48 @@ func (m Model) submitReview() tea.Cmd {
 
 	commentCount := len(review.Comments)
 	inlineCount := len(pendingComments)
-	logger.Log("UI: Submitting review for %s using provider %s (PATID: %s, Action: %s, Comments: %d, Inline: %d)",
-		review.PRIdentifier, pr.ProviderType, pr.PATID, review.Action, commentCount, inlineCount)
 
-	return func() tea.Msg {
-		if err := provider.SubmitReview(m.ctx, review); err != nil {
+	activity := domain.ActivityEvent{
+		Type:         domain.ActivityReviewSubmitted,
+		Provider:     pr.ProviderType,
+		PRIdentifier: review.PRIdentifier,
+		Comments:     commentCount,
+	}
+	if strings.TrimSpace(review.Body) != "" {
+		activity.Comments++
+	}
+	if !m.inspectStartedAt.IsZero() {
+		activity.TimeInReview = time.Since(m.inspectStartedAt)
+	}
+	logger.Log("UI: Submitting review for %s using provider %s (PATID: %s, Action: %s, Comments: %d, Inline: %d)",
+		review.PRIdentifier, pr.ProviderType, submitAs.PATID, review.Action, commentCount, inlineCount)
+
+	identifier := domain.PRIdentifier{Provider: pr.ProviderType, Repository: pr.Repository.FullName, Number: pr.Number}
+	return func(t *task) tea.Msg {
+		submitted, attached, omitted, err := m.uploadReviewAttachments(provider, identifier, review, t)
+		if err == nil {
+			ctx, cancel := m.withRequestTimeout(m.ctx)
+			defer cancel()
+			err = provider.SubmitReview(ctx, submitted)
+		}
+		if err != nil {
+			if m.saveToOutbox(submitAs.PATID, identifier, review, activity, err) {
+				return ReviewSavedToOutboxMsg{pr: pr, err: err}
+			}
 			return ErrorMsg{err: err}
 		}
+		m.recordActivity(activity)
 
 		successMsg := "Review submitted successfully"
 		if inlineCount > 0 {
 			successMsg = fmt.Sprintf("Review submitted with %d inline comment(s). Press 'c' to view comments.", inlineCount)
 		}
+		if attached > 0 {
+			successMsg += fmt.Sprintf(" Uploaded %d attachment(s).", attached)
+		}
+		if omitted > 0 {
+			successMsg += fmt.Sprintf(" Left out %d attachment(s) %s cannot host.", omitted, identifier.Provider)
+		}
 
 		return SuccessMsg{
 			message:          successMsg,
@@ -871,8 +3451,183 @@ func (m Model) submitReview() tea.Cmd {
 	}
 }
 
+// uploadReviewAttachments uploads the local files referenced from the review
+// body and its inline comments, and points the references at the uploads,
+// returning how many were uploaded. The review is not submitted when a
+// referenced file cannot be uploaded, so that no comment goes out with a path
+// only the reviewer can open. Providers that cannot host files, such as
+// GitHub which has no API for it, get the review with the references
+// replaced by the file names instead, and the number left out is returned;
+// the submission summary warns about this beforehand. Progress is reported
+// on t counting the uploads and the submission that follows.
+func (m Model) uploadReviewAttachments(provider domain.Provider, identifier domain.PRIdentifier, review domain.Review, t *task) (domain.Review, int, int, error) {
+	uploader, ok := provider.(domain.AttachmentUploader)
+	if !ok {
+		t.setProgress(0, 1, "requests")
+		review, omitted := omitReviewAttachments(review)
+		if omitted > 0 {
+			logger.Log("UI: %s cannot host attachments, leaving %d out of the review of %s#%d", identifier.Provider, omitted, identifier.Repository, identifier.Number)
+		}
+		return review, 0, omitted, nil
+	}
+
+	requests := len(attachment.Find(review.Body)) + 1
+	for _, comment := range review.Comments {
+		requests += len(attachment.Find(comment.Body))
+	}
+	uploaded := 0
+	t.setProgress(uploaded, requests, "requests")
+
+	upload := func(name string, content []byte) (string, error) {
+		logger.Log("UI: Uploading attachment %s (%d bytes) to %s#%d", name, len(content), identifier.Repository, identifier.Number)
+		ctx, cancel := m.withRequestTimeout(m.ctx)
+		defer cancel()
+		url, err := uploader.UploadAttachment(ctx, identifier, name, content)
+		if err == nil {
+			uploaded++
+			t.setProgress(uploaded, requests, "requests")
+		}
+		return url, err
+	}
+
+	body, total, err := attachment.UploadAll(review.Body, upload)
+	if err != nil {
+		return review, 0, 0, err
+	}
+	review.Body = body
+
+	comments := make([]domain.Comment, len(review.Comments))
+	copy(comments, review.Comments)
+	for i := range comments {
+		body, count, err := attachment.UploadAll(comments[i].Body, upload)
+		if err != nil {
+			return review, 0, 0, fmt.Errorf("%s:%d: %w", comments[i].FilePath, comments[i].Line, err)
+		}
+		comments[i].Body = body
+		total += count
+	}
+	review.Comments = comments
+	return review, total, 0, nil
+}
+
+// omitReviewAttachments replaces the references to local files in the
+// review body and its inline comments with the file names.
+func omitReviewAttachments(review domain.Review) (domain.Review, int) {
+	body, total := attachment.Omit(review.Body)
+	review.Body = body
+
+	comments := make([]domain.Comment, len(review.Comments))
+	copy(comments, review.Comments)
+	for i := range comments {
+		body, count := attachment.Omit(comments[i].Body)
+		comments[i].Body = body
+		total += count
+	}
+	review.Comments = comments
+	return review, total
+}
+
+func (m Model) snoozeSelectedPR() (tea.Model, tea.Cmd) {
+	pr := m.snoozeView.GetPR()
+	snooze, ok := m.snoozeView.GetSnooze(time.Now())
+	m.snoozeView.Deactivate()
+	if !ok {
+		return m, nil
+	}
+
+	if err := m.repository.SnoozePR(snooze); err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to snooze PR: %v", err), true)
+		return m, nil
+	}
+	m.reloadSnoozes()
+
+	message := fmt.Sprintf("Snoozed #%d until %s", pr.Number, snooze.Until.Format("Mon Jan 2 15:04"))
+	if snooze.UntilUpdated {
+		message = fmt.Sprintf("Snoozed #%d until it is updated", pr.Number)
+	}
+	if m.triaging() && m.triage.current().Key() == pr.Key() {
+		m.triage.snoozed++
+		return m.advanceTriage(message)
+	}
+	m.statusBar.SetMessage(message, false)
+	return m, clearStatusAfterDelay(4 * time.Second)
+}
+
+// markPRsSeen records the PRs as read at their current UpdatedAt.
+func (m Model) markPRsSeen(prs []domain.PullRequest) error {
+	seen := make(map[string]time.Time, len(prs))
+	for _, pr := range prs {
+		seen[pr.Key()] = pr.UpdatedAt
+	}
+	if err := m.repository.MarkPRsSeen(seen); err != nil {
+		logger.LogError("SEEN_SAVE", "", err)
+		return err
+	}
+
+	all, err := m.repository.GetSeenPRs()
+	if err != nil {
+		logger.LogError("SEEN_LOAD", "", err)
+		return err
+	}
+	m.prListView.SetSeen(all)
+	return nil
+}
+
+func (m Model) reloadSnoozes() {
+	snoozes, err := m.repository.ListSnoozes()
+	if err != nil {
+		logger.LogError("SNOOZE_LOAD", "", err)
+		return
+	}
+	m.prListView.SetSnoozes(snoozes)
+}
+
+// reloadStartedReviews marks the PRs with pending comments or a draft of a
+// review or an inline comment in the PR list.
+func (m Model) reloadStartedReviews() {
+	keys := m.prInspect.PendingReviewKeys()
+	if store := m.draftStore(); store != nil {
+		drafts, err := store.ListDrafts()
+		if err != nil {
+			logger.LogError("DRAFT_LOAD", "", err)
+		}
+		for _, draft := range drafts {
+			if !strings.HasSuffix(draft.Key, "#description") {
+				keys = append(keys, draft.PRKey)
+			}
+		}
+	}
+	m.prListView.SetStartedReviews(keys)
+}
+
+func (m Model) reloadPins() {
+	pins, err := m.repository.GetPins()
+	if err != nil {
+		logger.LogError("PINS_LOAD", "", err)
+		return
+	}
+	m.prListView.SetPins(pins)
+}
+
+// wakeSnoozedPRs drops snoozes of listed PRs that no longer apply and returns
+// how many PRs came back.
+func (m Model) wakeSnoozedPRs() int {
+	woken := m.prListView.WokenSnoozes()
+	if len(woken) == 0 {
+		return 0
+	}
+	for _, s := range woken {
+		if err := m.repository.UnsnoozePR(s.PRIdentifier); err != nil {
+			logger.LogError("SNOOZE_WAKE", s.PRIdentifier, err)
+		}
+	}
+	m.reloadSnoozes()
+	return len(woken)
+}
+
 func (m Model) executeMerge() tea.Cmd {
 	selectedMethod := m.mergeView.GetSelectedMethod()
+	deleteBranch := m.mergeView.DeleteBranch()
 	pr := m.mergeView.GetPR()
 	m.mergeView.Deactivate()
 
@@ -896,13 +3651,30 @@ func (m Model) executeMerge() tea.Cmd {
 	}
 
 	prIdentifier := fmt.Sprintf("%s#%d", pr.Repository.FullName, pr.Number)
-	logger.Log("UI: Merging PR %s with method %s", prIdentifier, selectedMethod)
+	logger.Log("UI: Merging PR %s with method %s (delete branch: %v)", prIdentifier, selectedMethod, deleteBranch)
 
 	return func() tea.Msg {
-		if err := provider.MergePullRequest(m.ctx, identifier, selectedMethod, true); err != nil {
+		ctx, cancel := m.withRequestTimeout(m.ctx)
+		defer cancel()
+		err := provider.MergePullRequest(ctx, identifier, selectedMethod, deleteBranch)
+		if err != nil && !errors.Is(err, common.ErrSourceBranchKept) {
 			return MergeErrorMsg{err: err}
 		}
-		return MergeSuccessMsg{prIdentifier: prIdentifier}
+		m.recordActivity(domain.ActivityEvent{
+			Type:         domain.ActivityPRMerged,
+			Provider:     pr.ProviderType,
+			PRIdentifier: prIdentifier,
+		})
+		return MergeSuccessMsg{prIdentifier: prIdentifier, branchKept: err != nil}
+	}
+}
+
+// recordActivity stores a review statistics event. Failures are logged but
+// never surfaced, since statistics must not get in the way of reviewing.
+func (m Model) recordActivity(event domain.ActivityEvent) {
+	event.Timestamp = time.Now()
+	if err := m.repository.RecordActivity(event); err != nil {
+		logger.LogError("RECORD_ACTIVITY", event.PRIdentifier, err)
 	}
 }
 
@@ -934,7 +3706,9 @@ func (m Model) saveDescription() tea.Cmd {
 	logger.Log("UI: Updating description for PR %s", prIdentifier)
 
 	return func() tea.Msg {
-		if err := provider.UpdatePullRequestDescription(m.ctx, identifier, newDescription); err != nil {
+		ctx, cancel := m.withRequestTimeout(m.ctx)
+		defer cancel()
+		if err := provider.UpdatePullRequestDescription(ctx, identifier, newDescription); err != nil {
 			return DescriptionUpdateErrorMsg{err: err}
 		}
 		return DescriptionUpdateSuccessMsg{description: newDescription}
@@ -976,18 +3750,130 @@ func (m *Model) openExternalEditor(content string, source EditorSource) tea.Cmd
 	})
 }
 
+// createProvider creates the provider of pat. Device-flow PATs get a token
+// source that refreshes their access token on the request that finds it
+// expired, so creating the provider does not block on the token endpoint.
 func (m Model) createProvider(pat domain.PAT) (domain.Provider, error) {
 	switch pat.Provider {
 	case domain.ProviderGitHub:
-		return github.NewProvider(pat.Token, pat.Username), nil
+		if pat.IsAnonymous() {
+			return github.NewAnonymousProvider(pat.Repositories, pat.Username), nil
+		}
+		provider := github.NewProvider(pat.Token, pat.Username)
+		if pat.OAuth != nil {
+			provider = github.NewOAuthProvider(auth.TokenSource(pat, m.saveRefreshedToken), pat.Username)
+		}
+		if settings, err := m.repository.GetSettings(); err == nil {
+			provider.SetMaxPullRequests(settings.GitHub.MaxPullRequests)
+		}
+		return provider, nil
 	case domain.ProviderAzureDevOps:
-		provider, err := azuredevops.NewProvider(pat.Token, pat.Organization, pat.Username)
+		var provider *azuredevops.Provider
+		var err error
+		if pat.OAuth != nil {
+			provider, err = azuredevops.NewOAuthProvider(auth.TokenSource(pat, m.saveRefreshedToken), pat.Organization, pat.Username)
+		} else {
+			provider, err = azuredevops.NewProvider(pat.Token, pat.Organization, pat.Username)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to create Azure DevOps provider: %w", err)
 		}
 		return provider, nil
+	case domain.ProviderGerrit:
+		provider, err := gerrit.NewProvider(pat.Organization, pat.Token, pat.Username, pat.Repositories)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Gerrit provider: %w", err)
+		}
+		return provider, nil
 	default:
-		return nil, fmt.Errorf("unsupported provider type: %s", pat.Provider)
+		dir, err := plugin.Dir()
+		if err != nil {
+			return nil, err
+		}
+		p, err := plugin.Find(dir, pat.Provider)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported provider type %s: %w", pat.Provider, err)
+		}
+		return plugin.NewProvider(p, pat), nil
+	}
+}
+
+// saveRefreshedToken stores the access token a device-flow PAT was refreshed
+// to. It runs from the request that refreshed it, so the rest of the PAT is
+// read back from the repository rather than taken from the copy the provider
+// was created from, which may be stale by then.
+func (m Model) saveRefreshedToken(refreshed domain.PAT) {
+	pat, err := m.repository.GetPAT(refreshed.ID)
+	if err != nil {
+		logger.LogError("SAVE_REFRESHED_TOKEN", refreshed.Name, err)
+		return
+	}
+	pat.Token = refreshed.Token
+	pat.OAuth = refreshed.OAuth
+	if err := m.repository.SavePAT(*pat); err != nil {
+		logger.LogError("SAVE_REFRESHED_TOKEN", refreshed.Name, err)
+	}
+}
+
+// pluginProviderNames lists the installed provider plugins, to offer them
+// in the PAT form.
+func pluginProviderNames() []string {
+	dir, err := plugin.Dir()
+	if err != nil {
+		return nil
+	}
+	plugins, err := plugin.Discover(dir)
+	if err != nil {
+		logger.LogError("DISCOVER_PLUGINS", dir, err)
+		return nil
+	}
+	names := make([]string, len(plugins))
+	for i, p := range plugins {
+		names[i] = p.Name
+	}
+	return names
+}
+
+func (m Model) startDeviceLogin(login *auth.DeviceLogin, organization string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := m.withRequestTimeout(m.ctx)
+		defer cancel()
+		if err := login.Start(ctx); err != nil {
+			return ErrorMsg{err: err}
+		}
+		return DeviceLoginStartedMsg{login: login, organization: organization}
+	}
+}
+
+func (m Model) waitForDeviceLogin(login *auth.DeviceLogin, organization string) tea.Cmd {
+	return func() tea.Msg {
+		token, err := login.Wait(m.ctx)
+		if err != nil {
+			return ErrorMsg{err: err}
+		}
+
+		pat := domain.PAT{
+			ID:           uuid.New().String(),
+			Organization: organization,
+		}
+		login.ApplyToken(&pat, token)
+
+		switch pat.Provider {
+		case domain.ProviderGitHub:
+			ctx, cancel := m.withRequestTimeout(m.ctx)
+			defer cancel()
+			username, err := github.NewClient(pat.Token, "").GetUsername(ctx)
+			if err != nil {
+				return ErrorMsg{err: fmt.Errorf("logged in but failed to look up GitHub user: %w", err)}
+			}
+			pat.Username = username
+			pat.Name = username + " (GitHub OAuth)"
+		case domain.ProviderAzureDevOps:
+			pat.Username = auth.DisplayName(pat.Token)
+			pat.Name = organization + " (Azure DevOps OAuth)"
+		}
+
+		return DeviceLoginCompletedMsg{pat: pat}
 	}
 }
 
@@ -1008,6 +3894,7 @@ func (m Model) loadPRs() tea.Cmd {
 		}
 	}
 
+	parent, _ := m.listLoad.begin(m.ctx)
 	return func() tea.Msg {
 		if len(m.providers) == 0 && m.provider != nil {
 			pat, err := m.repository.GetActivePAT()
@@ -1015,14 +3902,16 @@ func (m Model) loadPRs() tea.Cmd {
 				return ErrorMsg{err: err}
 			}
 
-			prs, err := m.provider.ListPullRequests(m.ctx, pat.Username)
+			ctx, cancel := m.withRequestTimeout(parent)
+			defer cancel()
+			prs, err := m.provider.ListPullRequests(ctx, pat.Username)
 			if err != nil {
 				return ErrorMsg{err: err}
 			}
-			return PRsLoadedMsg{prs: prs, groups: nil}
+			return PRsLoadedMsg{prs: pat.FilterPullRequests(prs), groups: nil}
 		}
 
-		selectedPATs, err := m.repository.GetSelectedPATs()
+		selectedPATs, _, err := m.usableSelectedPATs()
 		if err != nil {
 			return ErrorMsg{err: err}
 		}
@@ -1037,66 +3926,344 @@ func (m Model) loadPRs() tea.Cmd {
 
 		for _, pat := range selectedPATs {
 			go func(p domain.PAT) {
+				defer crash.Guard()
 				provider := m.providers[p.ID]
 				if provider == nil {
 					results <- prResult{prs: nil, pat: p, err: fmt.Errorf("provider not found for PAT %s", p.Name)}
 					return
 				}
-				prs, err := provider.ListPullRequests(m.ctx, p.Username)
-				results <- prResult{prs: prs, pat: p, err: err}
+				ctx, cancel := m.withRequestTimeout(parent)
+				defer cancel()
+				prs, err := provider.ListPullRequests(ctx, p.Username)
+				results <- prResult{prs: p.FilterPullRequests(prs), pat: p, err: err}
+			}(pat)
+		}
+
+		var allGroups []domain.PRGroup
+		var allPRs []domain.PullRequest
+
+		for i := 0; i < len(selectedPATs); i++ {
+			result := <-results
+			if result.err != nil {
+				logger.LogError("LOAD_PRS", result.pat.Name, result.err)
+				continue
+			}
+
+			// Tag each PR with its provider and PAT ID
+			taggedPRs := make([]domain.PullRequest, len(result.prs))
+			for j, pr := range result.prs {
+				pr.ProviderType = result.pat.Provider
+				pr.PATID = result.pat.ID
+				taggedPRs[j] = pr
+			}
+
+			allGroups = append(allGroups, domain.PRGroup{
+				PATName:   result.pat.Name,
+				PATID:     result.pat.ID,
+				Provider:  result.pat.Provider,
+				Username:  result.pat.Username,
+				IsPrimary: result.pat.IsPrimary,
+				PRs:       taggedPRs,
+			})
+			allPRs = append(allPRs, taggedPRs...)
+		}
+
+		return PRsLoadedMsg{prs: allPRs, groups: allGroups}
+	}
+}
+
+// prSearch searches the PRs of a provider for query on behalf of username.
+type prSearch func(ctx context.Context, query string, username string) ([]domain.PullRequest, error)
+
+// searchPRs searches the providers of the selected PATs with query in their
+// own syntax, or when involved is set, the text of the PRs the user is
+// involved in.
+func (m Model) searchPRs(query string, involved bool) tea.Cmd {
+	return m.runSearch(query, "searching", func(pat domain.PAT) prSearch {
+		if involved {
+			if searcher, ok := m.providers[pat.ID].(domain.InvolvedSearcher); ok {
+				return searcher.SearchInvolvedPullRequests
+			}
+		} else if searcher, ok := m.providers[pat.ID].(domain.PRSearcher); ok {
+			return searcher.SearchPullRequests
+		}
+		return nil
+	})
+}
+
+// listRepositoryPRs lists the open PRs of the chosen repository as search
+// results, through the PATs the choice allows that can list them.
+func (m Model) listRepositoryPRs(choice views.RepoChoice) tea.Cmd {
+	return m.runSearch("repo:"+choice.Repository, "listing repositories", func(pat domain.PAT) prSearch {
+		if (choice.PATID != "" && pat.ID != choice.PATID) || (choice.Provider != "" && pat.Provider != choice.Provider) {
+			return nil
+		}
+		lister, ok := m.providers[pat.ID].(domain.RepositoryPRLister)
+		if !ok {
+			return nil
+		}
+		return func(ctx context.Context, _ string, username string) ([]domain.PullRequest, error) {
+			return lister.ListRepositoryPullRequests(ctx, choice.Repository, username)
+		}
+	})
+}
+
+// runSearch runs the search searcher returns for each selected PAT, nil for
+// the PATs that cannot search, and lists the results under query.
+// capability names what the PATs cannot do when none of them can search.
+func (m Model) runSearch(query string, capability string, searcher func(pat domain.PAT) prSearch) tea.Cmd {
+	parent, _ := m.listLoad.begin(m.ctx)
+	return func() tea.Msg {
+		selectedPATs, _, err := m.usableSelectedPATs()
+		if err != nil {
+			return ErrorMsg{err: err}
+		}
+
+		type searchResult struct {
+			prs []domain.PullRequest
+			pat domain.PAT
+			err error
+		}
+
+		results := make(chan searchResult, len(selectedPATs))
+		searching := 0
+
+		for _, pat := range selectedPATs {
+			search := searcher(pat)
+			if search == nil {
+				continue
+			}
+			searching++
+			go func(p domain.PAT) {
+				defer crash.Guard()
+				ctx, cancel := m.withRequestTimeout(parent)
+				defer cancel()
+				prs, err := search(ctx, query, p.Username)
+				results <- searchResult{prs: p.FilterPullRequests(prs), pat: p, err: err}
+			}(pat)
+		}
+
+		if searching == 0 {
+			return ErrorMsg{err: fmt.Errorf("none of the selected PATs support %s", capability)}
+		}
+
+		var groups []domain.PRGroup
+		var lastErr error
+		for i := 0; i < searching; i++ {
+			result := <-results
+			if result.err != nil {
+				logger.LogError("SEARCH_PRS", result.pat.Name, result.err)
+				lastErr = result.err
+				continue
+			}
+
+			taggedPRs := make([]domain.PullRequest, len(result.prs))
+			for j, pr := range result.prs {
+				pr.ProviderType = result.pat.Provider
+				pr.PATID = result.pat.ID
+				taggedPRs[j] = pr
+			}
+
+			groups = append(groups, domain.PRGroup{
+				PATName:   result.pat.Name,
+				PATID:     result.pat.ID,
+				Provider:  result.pat.Provider,
+				Username:  result.pat.Username,
+				IsPrimary: result.pat.IsPrimary,
+				PRs:       taggedPRs,
+			})
+		}
+
+		if groups == nil && lastErr != nil {
+			return ErrorMsg{err: fmt.Errorf("search failed: %w", lastErr)}
+		}
+
+		return SearchResultsLoadedMsg{query: query, groups: groups}
+	}
+}
+
+// showTeamBoard opens the team board and loads the open PRs of members.
+func (m Model) showTeamBoard(members []string) (Model, tea.Cmd) {
+	m.teamMembers = members
+	m.teamBoardView.Activate(members)
+	return m, m.loadTeamBoard(members)
+}
+
+// loadTeamBoard lists the open PRs of each of members through every selected
+// PAT that can, oldest first so that the longest waiting review comes first.
+func (m Model) loadTeamBoard(members []string) tea.Cmd {
+	parent := m.ctx
+	return func() tea.Msg {
+		selectedPATs, _, err := m.usableSelectedPATs()
+		if err != nil {
+			return TeamBoardLoadedMsg{err: err}
+		}
+
+		type memberResult struct {
+			member int
+			pat    domain.PAT
+			prs    []domain.PullRequest
+			err    error
+		}
+		results := make(chan memberResult, len(members)*len(selectedPATs))
+		listing := 0
+		for i, member := range members {
+			for _, pat := range selectedPATs {
+				lister, ok := m.providers[pat.ID].(domain.AuthorPRLister)
+				if !ok {
+					continue
+				}
+				listing++
+				go func(i int, member string, p domain.PAT) {
+					defer crash.Guard()
+					ctx, cancel := m.withRequestTimeout(parent)
+					defer cancel()
+					prs, err := lister.ListAuthorPullRequests(ctx, member, p.Username)
+					results <- memberResult{member: i, pat: p, prs: p.FilterPullRequests(prs), err: err}
+				}(i, member, pat)
+			}
+		}
+		if listing == 0 {
+			return TeamBoardLoadedMsg{err: fmt.Errorf("none of the selected PATs can list the PRs of teammates")}
+		}
+
+		groups := make([]views.TeamGroup, len(members))
+		seen := make([]map[string]bool, len(members))
+		for i, member := range members {
+			groups[i] = views.TeamGroup{Member: member}
+			seen[i] = make(map[string]bool)
+		}
+		var lastErr error
+		failed := 0
+		for i := 0; i < listing; i++ {
+			result := <-results
+			if result.err != nil {
+				logger.LogError("TEAM_BOARD", result.pat.Name, result.err)
+				lastErr = result.err
+				failed++
+				continue
+			}
+			for _, pr := range result.prs {
+				pr.ProviderType = result.pat.Provider
+				pr.PATID = result.pat.ID
+				if !seen[result.member][pr.Key()] {
+					seen[result.member][pr.Key()] = true
+					groups[result.member].PRs = append(groups[result.member].PRs, pr)
+				}
+			}
+		}
+		for _, group := range groups {
+			slices.SortStableFunc(group.PRs, func(a, b domain.PullRequest) int {
+				return a.CreatedAt.Compare(b.CreatedAt)
+			})
+		}
+		if failed == listing {
+			return TeamBoardLoadedMsg{groups: groups, err: lastErr}
+		}
+		return TeamBoardLoadedMsg{groups: groups}
+	}
+}
+
+// usableSelectedPATs returns the selected PATs without those that are expired
+// or failed validation at startup, plus the names of the skipped ones, so a
+// single bad token does not fail the whole load.
+func (m Model) usableSelectedPATs() ([]domain.PAT, []string, error) {
+	selectedPATs, err := m.repository.GetSelectedPATs()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	usable := make([]domain.PAT, 0, len(selectedPATs))
+	var skipped []string
+	for _, pat := range selectedPATs {
+		if pat.IsExpired(now) || m.invalidPATs[pat.ID] != "" {
+			logger.Log("UI: Skipping PAT %s (expired or invalid)", pat.Name)
+			skipped = append(skipped, pat.Name)
+			continue
+		}
+		usable = append(usable, pat)
+	}
+	return usable, skipped, nil
+}
+
+// validatePATs checks all given PATs concurrently. Expired PATs are reported
+// without a network round trip.
+func (m Model) validatePATs(pats []domain.PAT) tea.Cmd {
+	return func() tea.Msg {
+		var (
+			mu       sync.Mutex
+			wg       sync.WaitGroup
+			problems = make(map[string]string)
+		)
+
+		for _, pat := range pats {
+			wg.Add(1)
+			go func(pat domain.PAT) {
+				defer crash.Guard()
+				defer wg.Done()
+				if problem := m.validatePAT(pat); problem != "" {
+					mu.Lock()
+					problems[pat.ID] = problem
+					mu.Unlock()
+				}
 			}(pat)
 		}
+		wg.Wait()
 
-		var allGroups []domain.PRGroup
-		var allPRs []domain.PullRequest
+		return PATsValidatedMsg{problems: problems}
+	}
+}
 
-		for i := 0; i < len(selectedPATs); i++ {
-			result := <-results
-			if result.err != nil {
-				logger.LogError("LOAD_PRS", result.pat.Name, result.err)
-				continue
-			}
+func (m Model) validatePAT(pat domain.PAT) string {
+	if pat.IsExpired(time.Now()) {
+		return "expired " + pat.ExpiresAt.Format("2006-01-02")
+	}
 
-			// Tag each PR with its provider and PAT ID
-			taggedPRs := make([]domain.PullRequest, len(result.prs))
-			for j, pr := range result.prs {
-				pr.ProviderType = result.pat.Provider
-				pr.PATID = result.pat.ID
-				taggedPRs[j] = pr
-			}
+	provider := m.providers[pat.ID]
+	if provider == nil {
+		return "provider could not be created"
+	}
 
-			allGroups = append(allGroups, domain.PRGroup{
-				PATName:   result.pat.Name,
-				PATID:     result.pat.ID,
-				Provider:  result.pat.Provider,
-				Username:  result.pat.Username,
-				IsPrimary: result.pat.IsPrimary,
-				PRs:       taggedPRs,
-			})
-			allPRs = append(allPRs, taggedPRs...)
+	ctx, cancel := context.WithTimeout(m.ctx, patValidationTimeout)
+	defer cancel()
+	if err := provider.ValidateCredentials(ctx); err != nil {
+		logger.LogError("VALIDATE_PAT", pat.Name, err)
+		// Only a rejected token makes the PAT unusable. When the provider
+		// cannot be reached the PAT is still loaded with, so that its cached
+		// PRs are shown.
+		if errors.Is(err, common.ErrUnauthorized) {
+			return "authentication failed"
 		}
-
-		return PRsLoadedMsg{prs: allPRs, groups: allGroups}
 	}
+	return ""
 }
 
-func (m Model) loadPRsForPAT(pat domain.PAT) tea.Cmd {
+func (m Model) loadPRsForPAT(ctx context.Context, loadID uint64, pat domain.PAT) tea.Cmd {
 	return func() tea.Msg {
 		provider := m.providers[pat.ID]
 		if provider == nil {
 			return PRGroupLoadedMsg{
 				Group:     domain.PRGroup{PATName: pat.Name, PATID: pat.ID},
 				LoadError: fmt.Errorf("provider not found for PAT %s", pat.Name),
+				LoadID:    loadID,
 			}
 		}
 
-		prs, err := provider.ListPullRequests(m.ctx, pat.Username)
+		ctx, cancel := m.withRequestTimeout(ctx)
+		defer cancel()
+		prs, err := m.listPullRequests(ctx, provider, pat)
 		if err != nil {
+			if cached := m.cachedPRGroup(pat, err); cached != nil {
+				return PRGroupLoadedMsg{Group: cached.Group, LoadID: loadID, CachedAt: cached.FetchedAt}
+			}
 			return PRGroupLoadedMsg{
 				Group:     domain.PRGroup{PATName: pat.Name, PATID: pat.ID},
 				LoadError: err,
+				LoadID:    loadID,
 			}
 		}
+		prs = pat.FilterPullRequests(prs)
 
 		taggedPRs := make([]domain.PullRequest, len(prs))
 		for i, pr := range prs {
@@ -1105,20 +4272,38 @@ func (m Model) loadPRsForPAT(pat domain.PAT) tea.Cmd {
 			taggedPRs[i] = pr
 		}
 
+		group := domain.PRGroup{
+			PATName:   pat.Name,
+			PATID:     pat.ID,
+			Provider:  pat.Provider,
+			Username:  pat.Username,
+			IsPrimary: pat.IsPrimary,
+			PRs:       taggedPRs,
+		}
+		m.cachePRGroup(group)
+
 		return PRGroupLoadedMsg{
-			Group: domain.PRGroup{
-				PATName:   pat.Name,
-				PATID:     pat.ID,
-				Provider:  pat.Provider,
-				Username:  pat.Username,
-				IsPrimary: pat.IsPrimary,
-				PRs:       taggedPRs,
-			},
+			Group:     group,
 			LoadError: nil,
+			LoadID:    loadID,
 		}
 	}
 }
 
+// listPullRequests lists the PRs of pat, showing how many are listed so far
+// in the status bar when the provider reports it.
+func (m Model) listPullRequests(ctx context.Context, provider domain.Provider, pat domain.PAT) ([]domain.PullRequest, error) {
+	lister, ok := provider.(domain.ProgressiveLister)
+	if !ok {
+		return provider.ListPullRequests(ctx, pat.Username)
+	}
+	t := m.tasks.start("Listing the PRs of " + pat.Name)
+	defer t.finish()
+	return lister.ListPullRequestsWithProgress(ctx, pat.Username, func(listed, total int) {
+		t.setProgress(listed, total, "PRs")
+	})
+}
+
 func (m Model) loadPRsStreaming() tea.Cmd {
 	if len(m.providers) == 0 && m.provider == nil {
 		return func() tea.Msg {
@@ -1130,22 +4315,28 @@ func (m Model) loadPRsStreaming() tea.Cmd {
 		return m.loadPRs()
 	}
 
-	selectedPATs, err := m.repository.GetSelectedPATs()
+	selectedPATs, skipped, err := m.usableSelectedPATs()
 	if err != nil {
 		return func() tea.Msg {
 			return ErrorMsg{err: err}
 		}
 	}
+	if len(selectedPATs) == 0 && len(skipped) > 0 {
+		return func() tea.Msg {
+			return ErrorMsg{err: fmt.Errorf("all selected PATs are expired or invalid: %s", strings.Join(skipped, ", "))}
+		}
+	}
 
+	ctx, loadID := m.listLoad.begin(m.ctx)
 	cmds := []tea.Cmd{
 		func() tea.Msg {
-			return PRLoadingStartedMsg{TotalPATs: len(selectedPATs)}
+			return PRLoadingStartedMsg{TotalPATs: len(selectedPATs), SkippedPATs: skipped, LoadID: loadID}
 		},
 		m.spinner.Tick,
 	}
 
 	for _, pat := range selectedPATs {
-		cmds = append(cmds, m.loadPRsForPAT(pat))
+		cmds = append(cmds, m.loadPRsForPAT(ctx, loadID, pat))
 	}
 
 	return tea.Batch(cmds...)
@@ -1172,6 +4363,7 @@ func (m Model) loadPRsWithCache() tea.Cmd {
 }
 
 func (m Model) loadPRDetail(pr domain.PullRequest) tea.Cmd {
+	parent := m.prLoad.context(m.ctx)
 	return func() tea.Msg {
 		provider := m.getProviderForPR(pr)
 		if provider == nil {
@@ -1184,19 +4376,28 @@ func (m Model) loadPRDetail(pr domain.PullRequest) tea.Cmd {
 			Number:     pr.Number,
 		}
 
-		prDetail, err := provider.GetPullRequest(m.ctx, identifier)
+		ctx, cancel := m.withRequestTimeout(parent)
+		defer cancel()
+		prDetail, err := provider.GetPullRequest(ctx, identifier)
 		if err != nil {
+			if cached := m.cachedPR(pr, err); cached != nil && cached.PR != nil {
+				return PRDetailLoadedMsg{pr: cached.PR, cachedAt: cached.FetchedAt}
+			}
 			return ErrorMsg{err: err}
 		}
 
 		prDetail.ProviderType = pr.ProviderType
 		prDetail.PATID = pr.PATID
+		m.cachePR(pr, func(store domain.OfflineStore, key string, now time.Time) error {
+			return store.SaveCachedPR(key, *prDetail, now)
+		})
 
 		return PRDetailLoadedMsg{pr: prDetail}
 	}
 }
 
 func (m Model) loadDiff(pr domain.PullRequest) tea.Cmd {
+	parent := m.prLoad.context(m.ctx)
 	return func() tea.Msg {
 		provider := m.getProviderForPR(pr)
 		if provider == nil {
@@ -1211,16 +4412,97 @@ func (m Model) loadDiff(pr domain.PullRequest) tea.Cmd {
 			Number:     pr.Number,
 		}
 
-		diff, err := provider.GetDiff(m.ctx, identifier)
+		ctx, cancel := m.withRequestTimeout(parent)
+		defer cancel()
+		if loader, ok := provider.(domain.FileDiffLoader); ok {
+			files, err := loader.ListDiffFiles(ctx, identifier)
+			if err != nil {
+				logger.LogError("LIST_DIFF_FILES", fmt.Sprintf("PR #%d provider %s", pr.Number, pr.ProviderType), err)
+			} else if isLargeDiff(files) {
+				logger.Log("Deferring the hunks of the %d files of PR #%d", len(files), pr.Number)
+				return DiffLoadedMsg{diff: &domain.Diff{Files: files}}
+			}
+		}
+		diff, err := provider.GetDiff(ctx, identifier)
 		if err != nil {
 			logger.LogError("LOAD_DIFF", fmt.Sprintf("PR #%d provider %s", pr.Number, pr.ProviderType), err)
+			if cached := m.cachedPR(pr, err); cached != nil && cached.Diff != nil {
+				return DiffLoadedMsg{diff: cached.Diff, cachedAt: cached.FetchedAt}
+			}
 			return ErrorMsg{err: err}
 		}
+		m.cachePR(pr, func(store domain.OfflineStore, key string, now time.Time) error {
+			return store.SaveCachedDiff(key, diff, now)
+		})
+		m.prData.putDiff(pr, diff)
 		return DiffLoadedMsg{diff: diff}
 	}
 }
 
+// isLargeDiff reports whether the listed files of a diff are too many, or
+// change too many lines, to download and parse all of their hunks up front.
+func isLargeDiff(files []domain.FileDiff) bool {
+	lines := 0
+	for _, file := range files {
+		lines += file.Additions + file.Deletions
+	}
+	return len(files) > lazyDiffMaxFiles || lines > lazyDiffMaxLines
+}
+
+// loadCurrentFileDiff loads the hunks of the diff file on screen when they
+// were deferred and are not requested yet. A file that failed to load is not
+// retried until the diff is loaded again.
+func (m Model) loadCurrentFileDiff() (Model, tea.Cmd) {
+	if m.state != ViewPRInspect || m.prInspect == nil {
+		return m, nil
+	}
+	pr := m.prInspect.GetPR()
+	file, ok := m.prInspect.CurrentFile()
+	if pr == nil || !ok || !file.Deferred {
+		return m, nil
+	}
+	path := file.NewPath
+	if path == "" {
+		path = file.OldPath
+	}
+	if m.fileDiffLoads[path] {
+		return m, nil
+	}
+	if m.fileDiffLoads == nil {
+		m.fileDiffLoads = make(map[string]bool)
+	}
+	m.fileDiffLoads[path] = true
+	return m, m.loadFileDiff(*pr, file)
+}
+
+func (m Model) loadFileDiff(pr domain.PullRequest, file domain.FileDiff) tea.Cmd {
+	parent := m.prLoad.context(m.ctx)
+	path := file.NewPath
+	if path == "" {
+		path = file.OldPath
+	}
+	return func() tea.Msg {
+		provider := m.getProviderForPR(pr)
+		loader, ok := provider.(domain.FileDiffLoader)
+		if !ok {
+			return FileDiffLoadedMsg{prKey: pr.Key(), path: path, err: fmt.Errorf("the provider cannot load single files")}
+		}
+		identifier := domain.PRIdentifier{
+			Provider:   provider.GetType(),
+			Repository: pr.Repository.FullName,
+			Number:     pr.Number,
+		}
+
+		ctx, cancel := m.withRequestTimeout(parent)
+		defer cancel()
+		loaded, err := loader.GetFileDiff(ctx, identifier, file)
+		return FileDiffLoadedMsg{prKey: pr.Key(), path: path, file: loaded, err: err}
+	}
+}
+
 func (m Model) loadComments(pr domain.PullRequest) tea.Cmd {
+	parent := m.prLoad.context(m.ctx)
+	stream := time.Now()
 	return func() tea.Msg {
 		provider := m.getProviderForPR(pr)
 		if provider == nil {
@@ -1233,14 +4515,79 @@ func (m Model) loadComments(pr domain.PullRequest) tea.Cmd {
 			Number:     pr.Number,
 		}
 
-		comments, err := provider.GetComments(m.ctx, identifier)
+		if pager, ok := provider.(domain.CommentPager); ok {
+			return m.fetchCommentsPage(parent, pager, identifier, CommentsPageLoadedMsg{pr: pr, stream: stream})
+		}
+
+		ctx, cancel := m.withRequestTimeout(parent)
+		defer cancel()
+		comments, err := provider.GetComments(ctx, identifier)
 		if err != nil {
+			if cached := m.cachedPR(pr, err); cached != nil && cached.CommentsLoaded {
+				return CommentsLoadedMsg{comments: cached.Comments, cachedAt: cached.FetchedAt}
+			}
 			return ErrorMsg{err: err}
 		}
+		m.cachePR(pr, func(store domain.OfflineStore, key string, now time.Time) error {
+			return store.SaveCachedComments(key, comments, now)
+		})
+		m.prData.putComments(pr, comments)
 		return CommentsLoadedMsg{comments: comments}
 	}
 }
 
+// loadMoreComments loads the page of comments after those of loaded.
+func (m Model) loadMoreComments(loaded CommentsPageLoadedMsg) tea.Cmd {
+	parent := m.prLoad.context(m.ctx)
+	return func() tea.Msg {
+		provider := m.getProviderForPR(loaded.pr)
+		pager, ok := provider.(domain.CommentPager)
+		if !ok {
+			loaded.err = fmt.Errorf("no provider available for PR")
+			return loaded
+		}
+		identifier := domain.PRIdentifier{
+			Provider:   provider.GetType(),
+			Repository: loaded.pr.Repository.FullName,
+			Number:     loaded.pr.Number,
+		}
+		return m.fetchCommentsPage(parent, pager, identifier, loaded)
+	}
+}
+
+// fetchCommentsPage loads the page at loaded.next and adds its comments to
+// the loaded ones. Once the last page is in, all of them are cached.
+func (m Model) fetchCommentsPage(parent context.Context, pager domain.CommentPager, identifier domain.PRIdentifier, loaded CommentsPageLoadedMsg) tea.Msg {
+	ctx, cancel := m.withRequestTimeout(parent)
+	defer cancel()
+	page, err := pager.GetCommentsPage(ctx, identifier, loaded.next)
+	if err != nil {
+		if loaded.pages == 0 {
+			if cached := m.cachedPR(loaded.pr, err); cached != nil && cached.CommentsLoaded {
+				return CommentsLoadedMsg{comments: cached.Comments, cachedAt: cached.FetchedAt}
+			}
+			return ErrorMsg{err: err}
+		}
+		loaded.err = err
+		return loaded
+	}
+
+	loaded.comments = append(slices.Clip(loaded.comments), page.Comments...)
+	loaded.next = page.Next
+	if loaded.pages == 0 {
+		loaded.total = page.Total
+	}
+	loaded.pages++
+	if page.Next == "" {
+		comments := loaded.comments
+		m.cachePR(loaded.pr, func(store domain.OfflineStore, key string, now time.Time) error {
+			return store.SaveCachedComments(key, comments, now)
+		})
+		m.prData.putComments(loaded.pr, comments)
+	}
+	return loaded
+}
+
 func (m Model) getProviderForPR(pr domain.PullRequest) domain.Provider {
 	// If we have multiple providers, use the one that matches the PR's PATID
 	if len(m.providers) > 0 && pr.PATID != "" {
@@ -1263,6 +4610,151 @@ func (m Model) updateShortcuts() {
 	m.topBar.SetShortcuts(shortcuts)
 }
 
+// checkForUpdates looks for a newer release in the background. Failures are
+// only logged so that a blocked network never gets in the way at startup.
+func (m Model) checkForUpdates() tea.Cmd {
+	repository := m.repository
+	ctx := m.ctx
+	return func() tea.Msg {
+		settings, err := repository.GetSettings()
+		if err != nil {
+			logger.LogError("UPDATE_CHECK", "", err)
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, updateCheckTimeout)
+		defer cancel()
+
+		release, updates, err := update.NewChecker().Check(ctx, settings.Updates)
+		if err != nil {
+			logger.LogError("UPDATE_CHECK", "", err)
+			return nil
+		}
+		if updates != settings.Updates {
+			settings.Updates = updates
+			if err := repository.SaveSettings(settings); err != nil {
+				logger.LogError("UPDATE_CHECK", "", err)
+			}
+		}
+
+		if !update.IsNewer(release.Version, version.Version) {
+			logger.Log("Update: %s is up to date (latest %s)", version.Version, release.Version)
+			return nil
+		}
+		return UpdateAvailableMsg{release: release}
+	}
+}
+
+func (m Model) startWebhookServer() tea.Cmd {
+	server := m.webhookServer
+	return func() tea.Msg {
+		if err := server.Start(); err != nil {
+			return ErrorMsg{err: err}
+		}
+		return WebhookServerStartedMsg{addr: server.Addr()}
+	}
+}
+
+func (m Model) waitForWebhookEvent() tea.Cmd {
+	events := m.webhookServer.Events()
+	return func() tea.Msg {
+		return WebhookEventMsg{event: <-events}
+	}
+}
+
+func (m Model) waitForConfigReload() tea.Cmd {
+	reloads := m.configReloads
+	return func() tea.Msg {
+		return ConfigReloadedMsg{err: <-reloads}
+	}
+}
+
+// handleConfigReloaded applies PATs and settings that were changed outside
+// the application, and reloads the PRs of the selected PATs.
+func (m Model) handleConfigReloaded(err error) (tea.Model, tea.Cmd) {
+	cmds := []tea.Cmd{m.waitForConfigReload()}
+	if err != nil {
+		m.statusBar.Notify(fmt.Sprintf("config.json changed but could not be loaded, keeping the previous configuration: %v", err), components.SeverityWarning)
+		return m, tea.Batch(cmds...)
+	}
+
+	m, summary, cmd, err := m.applyConfig()
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to reload PATs: %v", err), true)
+		return m, tea.Batch(cmds...)
+	}
+	m.statusBar.SetMessage("Reloaded config.json: "+summary, false)
+	return m, tea.Batch(append(cmds, cmd)...)
+}
+
+// applyConfig applies the settings and PATs in the repository after they
+// changed under the running application, and returns how many PATs there
+// are and the command that validates the selected ones and then loads their
+// PRs.
+func (m Model) applyConfig() (Model, string, tea.Cmd, error) {
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		logger.LogError("SETTINGS_LOAD", "", err)
+	} else {
+		if err := network.Configure(settings.Network); err != nil {
+			logger.LogError("NETWORK_INIT", "", err)
+		}
+		common.SetMaxAttempts(settings.Network.RequestAttempts())
+		m.requestTimeout = settings.Network.RequestTimeout()
+		m.prInspect.SetDiffShading(settings.Display.DiffBackground)
+		m.prInspect.SetWrapLines(settings.Display.WrapDiffLines)
+		m.prInspect.SetPlain(settings.Display.PlainDiff)
+		m.prListView.SetDraftDisplay(settings.Display.Drafts)
+		m.prListView.SetDetailed(settings.Display.DetailedRows)
+	}
+	m.reloadPins()
+
+	pats, err := m.repository.ListPATs()
+	if err != nil {
+		return m, "", nil, err
+	}
+	m, selectedCount := m.applyPATs(pats)
+	summary := fmt.Sprintf("%d PAT(s), %d selected", len(pats), selectedCount)
+	if selectedCount == 0 {
+		return m, summary, nil, nil
+	}
+
+	var selected []domain.PAT
+	for _, pat := range pats {
+		if pat.IsSelected {
+			selected = append(selected, pat)
+		}
+	}
+	return m, summary, m.validatePATs(selected), nil
+}
+
+// handleWebhookEvent drops the PR cache and refreshes whatever is on screen
+// that the event touches: the PR list, or the PR currently being inspected.
+func (m Model) handleWebhookEvent(event webhook.Event) (tea.Model, tea.Cmd) {
+	cmds := []tea.Cmd{m.waitForWebhookEvent()}
+	m.prCache = nil
+
+	switch m.state {
+	case ViewPRList:
+		if !m.loadingState.IsLoading {
+			cmds = append(cmds, m.loadPRsStreaming())
+		}
+	case ViewPRInspect:
+		if pr := m.prInspect.GetPR(); pr != nil && event.Matches(*pr) {
+			logger.Log("UI: Refreshing %s#%d after %s webhook", pr.Repository.FullName, pr.Number, event.Kind)
+			m.statusBar.SetMessage(fmt.Sprintf("PR updated (%s)", event.Kind), false)
+			cmds = append(cmds,
+				m.loadPRDetail(*pr),
+				m.loadDiff(*pr),
+				m.loadComments(*pr),
+				clearStatusAfterDelay(4*time.Second),
+			)
+		}
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
 func clearStatusAfterDelay(delay time.Duration) tea.Cmd {
 	return tea.Tick(delay, func(time.Time) tea.Msg {
 		return ClearStatusMsg{}
@@ -1278,16 +4770,264 @@ type PRsLoadedMsg struct {
 	groups []domain.PRGroup
 }
 
+type TeamBoardLoadedMsg struct {
+	groups []views.TeamGroup
+	err    error
+}
+
+type SearchResultsLoadedMsg struct {
+	query  string
+	groups []domain.PRGroup
+}
+
+type DeviceLoginStartedMsg struct {
+	login        *auth.DeviceLogin
+	organization string
+}
+
+type DeviceLoginCompletedMsg struct {
+	pat domain.PAT
+}
+
+// PATConnectionTestedMsg reports a connection test from the PAT form.
+// identity is nil when the provider cannot tell who the token belongs to.
+type PATConnectionTestedMsg struct {
+	pat      domain.PAT
+	identity *domain.Identity
+	err      error
+}
+
+type PATIdentityResolvedMsg struct {
+	pat      domain.PAT
+	identity domain.Identity
+	err      error
+}
+
+type PATsValidatedMsg struct {
+	problems map[string]string
+}
+
+type ReviewersLoadedMsg struct {
+	prKey     string
+	reviewers []domain.Reviewer
+	err       error
+}
+
+type MergeRequirementsLoadedMsg struct {
+	prKey        string
+	requirements *domain.MergeRequirements
+	err          error
+}
+
+type ConflictFilesLoadedMsg struct {
+	prKey string
+	files []string
+	err   error
+}
+
+// DependencyBatchDoneMsg reports a batch approve and merge of dependency
+// updates. skipped describes the PRs left alone because they could not be
+// merged yet, and failures the PRs that could not be approved or merged.
+type DependencyBatchDoneMsg struct {
+	merged   int
+	skipped  []string
+	failures []string
+}
+
+// PRResolvedMsg carries a pull request opened with :open, tagged with the
+// PAT that can access it.
+type PRResolvedMsg struct {
+	pr domain.PullRequest
+}
+
+type MentionCandidatesLoadedMsg struct {
+	prKey      string
+	candidates []domain.MentionCandidate
+	err        error
+}
+
+type OutlineLoadedMsg struct {
+	prKey    string
+	contents map[string]string
+}
+
+type CodeOwnersLoadedMsg struct {
+	prKey  string
+	owners *domain.CodeOwners
+	err    error
+}
+
+type PoliciesLoadedMsg struct {
+	prKey    string
+	policies []domain.PolicyEvaluation
+	err      error
+}
+
+type FailingChecksLoadedMsg struct {
+	prKey  string
+	checks []domain.CheckFailure
+	err    error
+}
+
+type DeploymentsLoadedMsg struct {
+	prKey       string
+	deployments []domain.Deployment
+	err         error
+}
+
+type ReactionAddedMsg struct {
+	prKey   string
+	comment domain.Comment
+	content string
+	err     error
+}
+
+type ThreadStatusChangedMsg struct {
+	prKey   string
+	comment domain.Comment
+	status  domain.ThreadStatus
+	err     error
+}
+
+type ReviewerActionMsg struct {
+	message string
+	err     error
+}
+
+type ReviewUndoTickMsg struct {
+	id int
+}
+
+type NotificationsExpiredMsg struct{}
+
+type DraftAutosaveMsg struct {
+	generation int
+}
+
+type LogsTickMsg struct {
+	generation int
+}
+
+type ReviewTimerTickMsg struct {
+	generation int
+}
+
+type PRRefreshTickMsg struct {
+	prKey      string
+	generation int
+}
+
+type PRFilterTickMsg struct {
+	generation int
+}
+
+type PRLeaseTickMsg struct {
+	prIdentifier string
+	generation   int
+}
+
+type PRLeaseMsg struct {
+	prIdentifier string
+	others       []domain.PRLease
+}
+
+// cachedAt is set on data loaded from the offline cache because the provider
+// was unreachable.
 type PRDetailLoadedMsg struct {
-	pr *domain.PullRequest
+	pr       *domain.PullRequest
+	cachedAt time.Time
 }
 
 type DiffLoadedMsg struct {
-	diff *domain.Diff
+	diff     *domain.Diff
+	cachedAt time.Time
+}
+
+// FileDiffLoadedMsg carries the hunks of a deferred file of the diff of the
+// PR with key prKey.
+type FileDiffLoadedMsg struct {
+	prKey string
+	path  string
+	file  *domain.FileDiff
+	err   error
 }
 
 type CommentsLoadedMsg struct {
 	comments []domain.Comment
+	cachedAt time.Time
+}
+
+// CommentsPageLoadedMsg carries the comments of pr loaded so far by the
+// stream of pages started at stream. next is the cursor of the page to load
+// next, "" once all are loaded, and total the number of comments expected,
+// zero when unknown. err is set when a page after the first failed.
+type CommentsPageLoadedMsg struct {
+	pr       domain.PullRequest
+	stream   time.Time
+	comments []domain.Comment
+	pages    int
+	total    int
+	next     string
+	err      error
+}
+
+type QueuedReviewsSentMsg struct {
+	sent      int
+	remaining int
+	err       error
+}
+
+// ReviewSavedToOutboxMsg reports a review that failed with err and was kept
+// in the outbox.
+type ReviewSavedToOutboxMsg struct {
+	pr  *domain.PullRequest
+	err error
+}
+
+type OutboxReviewSentMsg struct {
+	prIdentifier string
+	err          error
+}
+
+type TranslationLoadedMsg struct {
+	title    string
+	language string
+	text     string
+}
+
+// DiffAnnotationsLoadedMsg carries the findings of the diff hooks for the
+// file at path.
+type DiffAnnotationsLoadedMsg struct {
+	prKey       string
+	path        string
+	annotations []domain.Annotation
+	err         error
+	announce    bool
+}
+
+// SummaryLoadedMsg carries the summary of the diff identified by key.
+type SummaryLoadedMsg struct {
+	key     string
+	title   string
+	summary summarize.Summary
+}
+
+type UpdateAvailableMsg struct {
+	release update.Release
+}
+
+type WebhookServerStartedMsg struct {
+	addr string
+}
+
+type WebhookEventMsg struct {
+	event webhook.Event
+}
+
+// ConfigReloadedMsg reports that the configuration changed outside the
+// application; err is set when the new one could not be loaded.
+type ConfigReloadedMsg struct {
+	err error
 }
 
 type ErrorMsg struct {
@@ -1295,13 +5035,16 @@ type ErrorMsg struct {
 }
 
 type SuccessMsg struct {
-	message             string
-	reloadComments      bool
-	reloadCommentsPR    *domain.PullRequest
+	message          string
+	reloadComments   bool
+	reloadCommentsPR *domain.PullRequest
 }
 
 type MergeSuccessMsg struct {
 	prIdentifier string
+	// branchKept is set when the source branch was to be deleted but is in
+	// a fork.
+	branchKept bool
 }
 
 type MergeErrorMsg struct {
@@ -1323,10 +5066,15 @@ type ExternalEditorFinishedMsg struct {
 }
 
 type PRLoadingStartedMsg struct {
-	TotalPATs int
+	TotalPATs   int
+	SkippedPATs []string
+	LoadID      uint64
 }
 
 type PRGroupLoadedMsg struct {
 	Group     domain.PRGroup
 	LoadError error
+	LoadID    uint64
+	// CachedAt is set when the group comes from the offline cache.
+	CachedAt time.Time
 }
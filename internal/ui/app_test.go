@@ -2,15 +2,38 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/common"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/components"
 	"github.com/johanforsgren/lgtmfaster/internal/ui/views"
+	"github.com/johanforsgren/lgtmfaster/internal/update"
+	"github.com/johanforsgren/lgtmfaster/internal/webhook"
 )
 
 type mockRepository struct {
-	pats map[string]*domain.PAT
+	pats     map[string]*domain.PAT
+	settings domain.Settings
+	activity []domain.ActivityEvent
+	leases   []domain.PRLease
+	claims   []domain.PRLease
+	snoozes  []domain.PRSnooze
+	seen     map[string]time.Time
+	pins     domain.Pins
+	session  *domain.Session
 }
 
 func (m *mockRepository) ListPATs() ([]domain.PAT, error) {
@@ -45,7 +68,13 @@ func (m *mockRepository) DeletePAT(id string) error {
 }
 
 func (m *mockRepository) GetSelectedPATs() ([]domain.PAT, error) {
-	return nil, nil
+	var selected []domain.PAT
+	for _, pat := range m.pats {
+		if pat.IsSelected {
+			selected = append(selected, *pat)
+		}
+	}
+	return selected, nil
 }
 
 func (m *mockRepository) SetSelectedPATs(ids []string, primaryID string) error {
@@ -77,16 +106,126 @@ func (m *mockRepository) SetActivePAT(id string) error {
 	return nil
 }
 
+func (m *mockRepository) GetSettings() (domain.Settings, error) {
+	return m.settings, nil
+}
+
+func (m *mockRepository) SaveSettings(settings domain.Settings) error {
+	m.settings = settings
+	return nil
+}
+
+func (m *mockRepository) RecordActivity(event domain.ActivityEvent) error {
+	m.activity = append(m.activity, event)
+	return nil
+}
+
+func (m *mockRepository) ListActivity(since time.Time) ([]domain.ActivityEvent, error) {
+	return m.activity, nil
+}
+
+func (m *mockRepository) ClaimPR(lease domain.PRLease) ([]domain.PRLease, error) {
+	m.claims = append(m.claims, lease)
+	return m.leases, nil
+}
+
+func (m *mockRepository) ReleasePR(prIdentifier string) error {
+	return nil
+}
+
+func (m *mockRepository) SnoozePR(snooze domain.PRSnooze) error {
+	m.UnsnoozePR(snooze.PRIdentifier)
+	m.snoozes = append(m.snoozes, snooze)
+	return nil
+}
+
+func (m *mockRepository) UnsnoozePR(prIdentifier string) error {
+	kept := m.snoozes[:0]
+	for _, s := range m.snoozes {
+		if s.PRIdentifier != prIdentifier {
+			kept = append(kept, s)
+		}
+	}
+	m.snoozes = kept
+	return nil
+}
+
+func (m *mockRepository) ListSnoozes() ([]domain.PRSnooze, error) {
+	return m.snoozes, nil
+}
+
+func (m *mockRepository) GetPins() (domain.Pins, error) {
+	return m.pins, nil
+}
+
+func (m *mockRepository) PinRepository(key string, pinned bool) error {
+	m.pins.Repositories = slices.DeleteFunc(m.pins.Repositories, func(k string) bool { return k == key })
+	if pinned {
+		m.pins.Repositories = append(m.pins.Repositories, key)
+	}
+	return nil
+}
+
+func (m *mockRepository) PinPR(key string, pinned bool) error {
+	m.pins.PullRequests = slices.DeleteFunc(m.pins.PullRequests, func(k string) bool { return k == key })
+	if pinned {
+		m.pins.PullRequests = append(m.pins.PullRequests, key)
+	}
+	return nil
+}
+
+func (m *mockRepository) GetSeenPRs() (map[string]time.Time, error) {
+	seen := make(map[string]time.Time, len(m.seen))
+	for key, updatedAt := range m.seen {
+		seen[key] = updatedAt
+	}
+	return seen, nil
+}
+
+func (m *mockRepository) MarkPRsSeen(seen map[string]time.Time) error {
+	if m.seen == nil {
+		m.seen = make(map[string]time.Time)
+	}
+	for key, updatedAt := range seen {
+		if updatedAt.After(m.seen[key]) {
+			m.seen[key] = updatedAt
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) GetSession() (*domain.Session, error) {
+	return m.session, nil
+}
+
+func (m *mockRepository) SaveSession(session domain.Session) error {
+	m.session = &session
+	return nil
+}
+
+func (m *mockRepository) ClearSession() error {
+	m.session = nil
+	return nil
+}
+
 type mockProvider struct {
 	submitReviewCalled bool
 	lastReview         domain.Review
+	validateErr        error
+	prs                []domain.PullRequest
+	mergeDeleteBranch  *bool
+	pr                 *domain.PullRequest
 }
 
 func (m *mockProvider) ListPullRequests(ctx context.Context, username string) ([]domain.PullRequest, error) {
-	return nil, nil
+	return m.prs, nil
 }
 
 func (m *mockProvider) GetPullRequest(ctx context.Context, identifier domain.PRIdentifier) (*domain.PullRequest, error) {
+	if m.pr != nil && m.pr.Repository.FullName == identifier.Repository && m.pr.Number == identifier.Number {
+		pr := *m.pr
+		return &pr, nil
+	}
 	return nil, nil
 }
 
@@ -98,7 +237,7 @@ func (m *mockProvider) GetComments(ctx context.Context, identifier domain.PRIden
 	return nil, nil
 }
 
-func (m *mockProvider) AddComment(ctx context.Context, identifier domain.PRIdentifier, body, filePath string, line int) error {
+func (m *mockProvider) AddComment(ctx context.Context, identifier domain.PRIdentifier, body, filePath string, line int, side string) error {
 	return nil
 }
 
@@ -109,10 +248,11 @@ func (m *mockProvider) SubmitReview(ctx context.Context, review domain.Review) e
 }
 
 func (m *mockProvider) ValidateCredentials(ctx context.Context) error {
-	return nil
+	return m.validateErr
 }
 
 func (m *mockProvider) MergePullRequest(ctx context.Context, identifier domain.PRIdentifier, mergeMethod string, deleteBranch bool) error {
+	m.mergeDeleteBranch = &deleteBranch
 	return nil
 }
 
@@ -165,8 +305,7 @@ func TestOwnPRValidation_ApproveConvertsToComment(t *testing.T) {
 	m.prInspect.SetPR(pr)
 	m.reviewView.Activate(views.ReviewModeApprove)
 
-	cmd := m.submitReview()
-	msg := cmd()
+	msg := taskResult(m.submitReview())
 
 	successMsg, ok := msg.(SuccessMsg)
 	if !ok {
@@ -231,8 +370,7 @@ func TestOwnPRValidation_RequestChangesConvertsToComment(t *testing.T) {
 	m.prInspect.SetPR(pr)
 	m.reviewView.Activate(views.ReviewModeRequestChanges)
 
-	cmd := m.submitReview()
-	msg := cmd()
+	msg := taskResult(m.submitReview())
 
 	successMsg, ok := msg.(SuccessMsg)
 	if !ok {
@@ -293,8 +431,7 @@ func TestOwnPRValidation_CommentRemainsComment(t *testing.T) {
 	m.prInspect.SetPR(pr)
 	m.reviewView.Activate(views.ReviewModeComment)
 
-	cmd := m.submitReview()
-	msg := cmd()
+	msg := taskResult(m.submitReview())
 
 	successMsg, ok := msg.(SuccessMsg)
 	if !ok {
@@ -355,8 +492,7 @@ func TestOwnPRValidation_OtherUserPRNotConverted(t *testing.T) {
 	m.prInspect.SetPR(pr)
 	m.reviewView.Activate(views.ReviewModeApprove)
 
-	cmd := m.submitReview()
-	msg := cmd()
+	msg := taskResult(m.submitReview())
 
 	successMsg, ok := msg.(SuccessMsg)
 	if !ok {
@@ -412,8 +548,7 @@ func TestOwnPRValidation_NoPATIDNoConversion(t *testing.T) {
 	m.prInspect.SetPR(pr)
 	m.reviewView.Activate(views.ReviewModeApprove)
 
-	cmd := m.submitReview()
-	msg := cmd()
+	msg := taskResult(m.submitReview())
 
 	_, ok := msg.(SuccessMsg)
 	if !ok {
@@ -470,8 +605,7 @@ func TestSubmitReview_ApproveOtherUserPR_SubmitsApproval(t *testing.T) {
 	m.prInspect.SetPR(pr)
 	m.reviewView.Activate(views.ReviewModeApprove)
 
-	cmd := m.submitReview()
-	msg := cmd()
+	msg := taskResult(m.submitReview())
 
 	_, ok := msg.(SuccessMsg)
 	if !ok {
@@ -532,8 +666,7 @@ func TestSubmitReview_RequestChanges_SubmitsCorrectAction(t *testing.T) {
 	m.prInspect.SetPR(pr)
 	m.reviewView.Activate(views.ReviewModeRequestChanges)
 
-	cmd := m.submitReview()
-	msg := cmd()
+	msg := taskResult(m.submitReview())
 
 	_, ok := msg.(SuccessMsg)
 	if !ok {
@@ -603,8 +736,7 @@ func TestSubmitReview_WithInlineComments_IncludesComments(t *testing.T) {
 	m.prInspect.SetPR(pr)
 	m.reviewView.Activate(views.ReviewModeApprove)
 
-	cmd := m.submitReview()
-	msg := cmd()
+	msg := taskResult(m.submitReview())
 
 	_, ok := msg.(SuccessMsg)
 	if !ok {
@@ -667,8 +799,7 @@ func TestSubmitReview_AzureDevOps_SubmitsCorrectly(t *testing.T) {
 	m.prInspect.SetPR(pr)
 	m.reviewView.Activate(views.ReviewModeApprove)
 
-	cmd := m.submitReview()
-	msg := cmd()
+	msg := taskResult(m.submitReview())
 
 	_, ok := msg.(SuccessMsg)
 	if !ok {
@@ -707,11 +838,3386 @@ func TestSubmitReview_NoPRSelected_ReturnsError(t *testing.T) {
 
 	m.reviewView.Activate(views.ReviewModeApprove)
 
-	cmd := m.submitReview()
-	msg := cmd()
+	msg := taskResult(m.submitReview())
 
 	_, ok := msg.(ErrorMsg)
 	if !ok {
 		t.Fatalf("Expected ErrorMsg when no PR selected, got %T", msg)
 	}
 }
+
+func TestSubmitReview_RecordsActivity(t *testing.T) {
+	repo := &mockRepository{
+		pats: map[string]*domain.PAT{
+			"pat-1": {
+				ID:       "pat-1",
+				Username: "reviewer",
+				Provider: domain.ProviderGitHub,
+			},
+		},
+	}
+
+	provider := &mockProvider{}
+
+	m := Model{
+		ctx:        context.Background(),
+		repository: repo,
+		prInspect:  views.NewPRInspectView(),
+		reviewView: views.NewReviewView(),
+		providers: map[string]domain.Provider{
+			"pat-1": provider,
+		},
+		inspectStartedAt: time.Now().Add(-5 * time.Minute),
+	}
+
+	pr := &domain.PullRequest{
+		Number:       42,
+		Author:       domain.User{Username: "prauthor"},
+		Repository:   domain.Repo{FullName: "owner/repo"},
+		PATID:        "pat-1",
+		ProviderType: domain.ProviderGitHub,
+	}
+
+	m.prInspect.SetPR(pr)
+	m.reviewView.Activate(views.ReviewModeComment)
+	m.reviewView.SetValue("Looks good overall")
+
+	if _, ok := taskResult(m.submitReview()).(SuccessMsg); !ok {
+		t.Fatal("Expected SuccessMsg")
+	}
+
+	if len(repo.activity) != 1 {
+		t.Fatalf("Expected 1 recorded activity event, got %d", len(repo.activity))
+	}
+
+	event := repo.activity[0]
+	if event.Type != domain.ActivityReviewSubmitted {
+		t.Errorf("Expected review_submitted event, got %s", event.Type)
+	}
+	if event.Provider != domain.ProviderGitHub {
+		t.Errorf("Expected github provider, got %s", event.Provider)
+	}
+	if event.Comments != 1 {
+		t.Errorf("Expected review body to count as 1 comment, got %d", event.Comments)
+	}
+	if event.TimeInReview < 5*time.Minute {
+		t.Errorf("Expected time in review of at least 5m, got %v", event.TimeInReview)
+	}
+}
+
+func TestHandleWebhookEvent_InvalidatesCacheAndRefreshesOpenPR(t *testing.T) {
+	server, err := webhook.NewServer(domain.WebhookSettings{ListenAddr: "127.0.0.1:0"})
+	if err != nil {
+		t.Fatalf("failed to create webhook server: %v", err)
+	}
+
+	m := createTestModel()
+	m.state = ViewPRInspect
+	m.webhookServer = server
+	m.prCache = &PRCache{FetchedAt: time.Now()}
+	m.statusBar.SetWidth(120)
+	m.prInspect.SetPR(&domain.PullRequest{
+		Number:       42,
+		ProviderType: domain.ProviderGitHub,
+		Repository:   domain.Repo{FullName: "owner/repo"},
+	})
+
+	updated, cmd := m.handleWebhookEvent(webhook.Event{
+		Provider:   domain.ProviderGitHub,
+		Kind:       "pull_request_review",
+		Repository: "owner/repo",
+		Number:     42,
+	})
+
+	if updated.(Model).prCache != nil {
+		t.Error("expected PR cache to be invalidated")
+	}
+	if cmd == nil {
+		t.Fatal("expected refresh commands")
+	}
+	if !contains(updated.(Model).statusBar.View(), "PR updated") {
+		t.Error("expected status bar to announce the update")
+	}
+}
+
+func TestPRRefreshTick_ReloadsTheOpenPR(t *testing.T) {
+	pr := domain.PullRequest{
+		Number:       42,
+		HeadSHA:      "aaa",
+		PATID:        "pat-1",
+		ProviderType: domain.ProviderGitHub,
+		Repository:   domain.Repo{FullName: "owner/repo"},
+	}
+	provider := &mockProvider{pr: &pr}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	m.state = ViewPRInspect
+	m.refreshGeneration = 3
+	m.prInspect.SetPR(&pr)
+
+	if _, cmd := m.Update(PRRefreshTickMsg{prKey: pr.Key(), generation: 2}); cmd != nil {
+		t.Fatal("expected the tick of an earlier visit to be dropped")
+	}
+
+	provider.pr = &domain.PullRequest{Number: 42, HeadSHA: "bbb", Mergeable: true, Repository: pr.Repository}
+	updated, cmd := m.Update(PRRefreshTickMsg{prKey: pr.Key(), generation: 3})
+	m = updated.(Model)
+	if cmd == nil || m.memoryHead != "aaa" {
+		t.Fatalf("expected the open PR to be reloaded, memory head %q", m.memoryHead)
+	}
+
+	updated, cmd = m.Update(m.loadPRDetail(pr)())
+	m = updated.(Model)
+	if got := m.prInspect.GetPR(); got == nil || !got.Mergeable || got.HeadSHA != "bbb" {
+		t.Errorf("expected the refreshed details, got %+v", got)
+	}
+	if cmd == nil {
+		t.Error("expected the diff to be reloaded for the new head commit")
+	}
+
+	m.state = ViewPRList
+	if _, cmd := m.Update(PRRefreshTickMsg{prKey: pr.Key(), generation: 3}); cmd != nil {
+		t.Error("expected no refresh once the PR is left")
+	}
+}
+
+func TestSearchResultsLoaded_ShowsResultsWithoutTouchingCache(t *testing.T) {
+	m := createTestModel()
+	m.state = ViewPATs
+	m.statusBar.SetWidth(120)
+	cache := &PRCache{FetchedAt: time.Now()}
+	m.prCache = cache
+
+	updated, _ := m.Update(SearchResultsLoadedMsg{
+		query: "caching",
+		groups: []domain.PRGroup{{
+			PATName: "work",
+			PRs: []domain.PullRequest{
+				{Number: 1, Title: "Add caching", Repository: domain.Repo{FullName: "org/api"}},
+				{Number: 2, Title: "Tune caching", Repository: domain.Repo{FullName: "org/web"}},
+			},
+		}},
+	})
+	result := updated.(Model)
+
+	if result.state != ViewPRList {
+		t.Errorf("expected PR list view, got %v", result.state)
+	}
+	if result.prCache != cache {
+		t.Error("expected search results not to replace the PR cache")
+	}
+	if result.prListView.SearchQuery() != "caching" {
+		t.Errorf("expected search query to be kept, got %q", result.prListView.SearchQuery())
+	}
+	if !contains(result.statusBar.View(), "Found 2 pull requests") {
+		t.Error("expected status bar to report the result count")
+	}
+}
+
+func TestHandleSearchPRsCommand_RequiresQuery(t *testing.T) {
+	m := createTestModel()
+	m.statusBar.SetWidth(120)
+
+	updated, cmd := handleSearchPRsCommand(m, nil)
+
+	if cmd != nil {
+		t.Error("expected no command without a query")
+	}
+	if !contains(updated.statusBar.View(), "Usage: :search-prs") {
+		t.Error("expected usage message")
+	}
+}
+
+// involvedSearchProvider is a mockProvider that searches the PRs the user
+// is involved in.
+type involvedSearchProvider struct {
+	*mockProvider
+	text string
+	prs  []domain.PullRequest
+}
+
+func (p *involvedSearchProvider) SearchInvolvedPullRequests(ctx context.Context, text string, username string) ([]domain.PullRequest, error) {
+	p.text = text
+	return p.prs, nil
+}
+
+func TestSearchCommand_SearchesThePRsYouAreInvolvedIn(t *testing.T) {
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.repository = &mockRepository{pats: map[string]*domain.PAT{
+		"work":  {ID: "work", Name: "work", Provider: domain.ProviderGitHub, IsSelected: true},
+		"other": {ID: "other", Name: "other", Provider: domain.ProviderGitHub, IsSelected: true},
+	}}
+	searcher := &involvedSearchProvider{
+		mockProvider: &mockProvider{},
+		prs:          []domain.PullRequest{{Number: 3, Title: "Retry with backoff", Repository: domain.Repo{FullName: "acme/api"}}},
+	}
+	m.providers = map[string]domain.Provider{"work": searcher, "other": &mockProvider{}}
+
+	m, cmd := handleSearchCommand(m, []string{"retry", "backoff"})
+	if cmd == nil {
+		t.Fatal("expected the search to start")
+	}
+	msg, ok := m.searchPRs("retry backoff", true)().(SearchResultsLoadedMsg)
+	if !ok {
+		t.Fatal("expected search results")
+	}
+	if searcher.text != "retry backoff" {
+		t.Errorf("expected the text to be searched, got %q", searcher.text)
+	}
+	if len(msg.groups) != 1 || msg.groups[0].PATID != "work" || len(msg.groups[0].PRs) != 1 || msg.groups[0].PRs[0].PATID != "work" {
+		t.Errorf("expected the results of the PAT that can search, got %+v", msg.groups)
+	}
+
+	m.statusBar.SetWidth(120)
+	if updated, cmd := handleSearchCommand(m, nil); cmd != nil || !contains(updated.statusBar.View(), "Usage: :search <text>") {
+		t.Error("expected no search without text")
+	}
+}
+
+// repoListingProvider is a mockProvider that lists the open PRs of any
+// repository.
+type repoListingProvider struct {
+	*mockProvider
+	listed []string
+}
+
+func (p *repoListingProvider) ListRepositoryPullRequests(ctx context.Context, repository string, username string) ([]domain.PullRequest, error) {
+	p.listed = append(p.listed, repository)
+	return []domain.PullRequest{{Number: 40, Title: "Community fix", Repository: domain.Repo{FullName: repository}}}, nil
+}
+
+func TestRepoCommand_PicksARepositoryAndListsItsOpenPRs(t *testing.T) {
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.repository = &mockRepository{pats: map[string]*domain.PAT{
+		"work": {ID: "work", Name: "work", Provider: domain.ProviderGitHub, IsSelected: true},
+	}}
+	lister := &repoListingProvider{mockProvider: &mockProvider{}}
+	m.providers = map[string]domain.Provider{"work": lister}
+	m.state = ViewPRList
+	m.prListView.SetPRs([]domain.PullRequest{
+		{Number: 1, ProviderType: domain.ProviderGitHub, PATID: "work", Repository: domain.Repo{FullName: "acme/web"}},
+		{Number: 2, ProviderType: domain.ProviderGitHub, PATID: "work", Repository: domain.Repo{FullName: "acme/api"}},
+		{Number: 3, ProviderType: domain.ProviderGitHub, PATID: "work", Repository: domain.Repo{FullName: "acme/api"}},
+	})
+
+	m, _ = handleRepoCommand(m, nil)
+	if !m.repoPickerView.IsActive() {
+		t.Fatal("expected the repo picker to open")
+	}
+	if choices := m.repoChoices(); len(choices) != 2 || choices[0].Repository != "acme/api" {
+		t.Fatalf("expected the listed repositories once each, sorted, got %+v", choices)
+	}
+
+	m, _ = pressKey(m, "web")
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.repoPickerView.IsActive() || cmd == nil {
+		t.Fatal("expected enter to close the picker and list the repository")
+	}
+	msg, ok := m.listRepositoryPRs(views.RepoChoice{Repository: "acme/web", Provider: domain.ProviderGitHub, PATID: "work"})().(SearchResultsLoadedMsg)
+	if !ok || msg.query != "repo:acme/web" || len(msg.groups) != 1 || msg.groups[0].PRs[0].PATID != "work" {
+		t.Fatalf("expected the open PRs of acme/web as search results, got %+v", msg)
+	}
+
+	m, _ = handleRepoCommand(m, nil)
+	m, _ = pressKey(m, "acme/cli")
+	if choice, ok := m.repoPickerView.Selected(); !ok || choice.Repository != "acme/cli" || choice.PATID != "" {
+		t.Errorf("expected a typed repository to be offered to every PAT, got %+v", choice)
+	}
+	m, _ = handleRepoCommand(m, []string{"acme/docs"})
+	if _, ok := m.listRepositoryPRs(views.RepoChoice{Repository: "acme/docs"})().(SearchResultsLoadedMsg); !ok || lister.listed[len(lister.listed)-1] != "acme/docs" {
+		t.Errorf("expected :repo with a name to list it, got %v", lister.listed)
+	}
+}
+
+// authorListingProvider is a mockProvider that lists the open PRs of
+// teammates from prs, keyed by author.
+type authorListingProvider struct {
+	*mockProvider
+	prs map[string][]domain.PullRequest
+}
+
+func (p *authorListingProvider) ListAuthorPullRequests(ctx context.Context, author string, username string) ([]domain.PullRequest, error) {
+	return p.prs[author], nil
+}
+
+func TestTeamBoard_GroupsTheOpenPRsOfTeammates(t *testing.T) {
+	now := time.Now()
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.teamBoardView.SetSize(120, 40)
+	m.statusBar.SetWidth(200)
+	repo := &mockRepository{pats: map[string]*domain.PAT{
+		"work": {ID: "work", Name: "work", Provider: domain.ProviderGitHub, IsSelected: true},
+	}}
+	m.repository = repo
+	m.providers = map[string]domain.Provider{"work": &authorListingProvider{
+		mockProvider: &mockProvider{},
+		prs: map[string][]domain.PullRequest{
+			"alice": {
+				{Number: 2, Title: "Newer", Repository: domain.Repo{FullName: "acme/api"}, CreatedAt: now.Add(-time.Hour), ApprovalStatus: domain.ApprovalStatusApproved},
+				{Number: 1, Title: "Older", Repository: domain.Repo{FullName: "acme/api"}, CreatedAt: now.Add(-48 * time.Hour)},
+			},
+		},
+	}}
+	m.state = ViewPRList
+
+	m, cmd := handleTeamCommand(m, nil)
+	if cmd != nil || !contains(m.statusBar.View(), "settings.Team.Members") {
+		t.Fatal("expected a hint when no teammates are configured")
+	}
+
+	repo.settings.Team.Members = []string{"alice", "bob"}
+	m, cmd = handleTeamCommand(m, nil)
+	if !m.teamBoardView.IsActive() || cmd == nil {
+		t.Fatal("expected the team board to open and load")
+	}
+	result, _ := m.Update(cmd())
+	m = result.(Model)
+
+	view := m.teamBoardView.View()
+	for _, want := range []string{"alice", "1 approved", "bob", "No open PRs"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q on the board:\n%s", want, view)
+		}
+	}
+	if pr := m.teamBoardView.GetSelected(); pr == nil || pr.Number != 1 || pr.PATID != "work" {
+		t.Fatalf("expected the oldest PR first, got %+v", pr)
+	}
+
+	m, _ = pressKey(m, "j")
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.teamBoardView.IsActive() || m.state != ViewPRInspect || m.prInspect.GetPR().Number != 2 {
+		t.Errorf("expected enter to open the selected PR, got state %v", m.state)
+	}
+}
+
+func TestDeviceLoginCompleted_SavesCredential(t *testing.T) {
+	repo := &mockRepository{pats: map[string]*domain.PAT{}}
+	m := createTestModel()
+	m.repository = repo
+	m.statusBar.SetWidth(120)
+
+	pat := domain.PAT{
+		ID:       "oauth-1",
+		Name:     "jane (GitHub OAuth)",
+		Provider: domain.ProviderGitHub,
+		Token:    "access",
+		Username: "jane",
+		OAuth:    &domain.OAuthToken{RefreshToken: "refresh", ClientID: "client"},
+	}
+
+	updated, cmd := m.Update(DeviceLoginCompletedMsg{pat: pat})
+
+	saved, ok := repo.pats["oauth-1"]
+	if !ok || saved.OAuth == nil || saved.OAuth.RefreshToken != "refresh" {
+		t.Fatalf("expected the OAuth credential to be saved, got %+v", saved)
+	}
+	if cmd == nil {
+		t.Error("expected PATs to be reloaded")
+	}
+	if !contains(updated.(Model).statusBar.View(), "Logged in as jane") {
+		t.Error("expected login confirmation")
+	}
+}
+
+func TestHandleLoginCommand_RequiresOrganizationForAzureDevOps(t *testing.T) {
+	m := createTestModel()
+	m.repository = &mockRepository{}
+	m.statusBar.SetWidth(120)
+
+	updated, cmd := handleLoginCommand(m, []string{"azuredevops"})
+
+	if cmd != nil {
+		t.Error("expected no command without an organization")
+	}
+	if !contains(updated.statusBar.View(), "<organization>") {
+		t.Error("expected usage message")
+	}
+}
+
+func TestHandleLoginCommand_NotConfigured(t *testing.T) {
+	m := createTestModel()
+	m.repository = &mockRepository{}
+	m.statusBar.SetWidth(200)
+
+	updated, cmd := handleLoginCommand(m, []string{"github"})
+
+	if cmd != nil {
+		t.Error("expected no command when no client ID is configured")
+	}
+	if !contains(updated.statusBar.View(), "GitHubClientID") {
+		t.Error("expected configuration hint")
+	}
+}
+
+func TestHandlePRLease_WarnsAboutDraftsInOtherInstance(t *testing.T) {
+	m := createTestModel()
+	m.statusBar.SetWidth(200)
+	m.leasedPR = "github:owner/repo/5"
+
+	msg := PRLeaseMsg{
+		prIdentifier: "github:owner/repo/5",
+		others:       []domain.PRLease{{Hostname: "laptop", PID: 4242, PendingDrafts: 3}},
+	}
+
+	updated, cmd := m.handlePRLease(msg)
+	result := updated.(Model)
+
+	if !result.leaseWarned {
+		t.Error("expected the warning to be recorded")
+	}
+	if cmd == nil {
+		t.Error("expected the warning to be cleared after a delay")
+	}
+	if !contains(result.statusBar.View(), "pid 4242") {
+		t.Error("expected the status bar to name the other instance")
+	}
+
+	result.statusBar.ClearMessage()
+	again, _ := result.handlePRLease(msg)
+	if contains(again.(Model).statusBar.View(), "pid 4242") {
+		t.Error("expected the warning not to repeat on every heartbeat")
+	}
+}
+
+func TestHandlePRLease_IgnoresOtherPRs(t *testing.T) {
+	m := createTestModel()
+	m.statusBar.SetWidth(200)
+	m.leasedPR = "github:owner/repo/5"
+
+	updated, _ := m.handlePRLease(PRLeaseMsg{
+		prIdentifier: "github:owner/repo/6",
+		others:       []domain.PRLease{{PendingDrafts: 1}},
+	})
+
+	if updated.(Model).leaseWarned {
+		t.Error("expected leases of a different PR to be ignored")
+	}
+}
+
+func TestClaimPRLease_PublishesPendingDrafts(t *testing.T) {
+	repo := &mockRepository{}
+	m := createTestModel()
+	m.repository = repo
+	m.leasedPR = "github:owner/repo/5"
+	m.prInspect.SetSize(80, 24)
+	m.prInspect.SetDiff(&domain.Diff{Files: []domain.FileDiff{{
+		NewPath: "main.go",
+		Hunks:   []domain.DiffHunk{{Lines: []domain.DiffLine{{Type: "add", Content: "+x", NewLine: 1}}}},
+	}}})
+	m.prInspect.AddPendingComment("draft")
+
+	msg := m.claimPRLease()()
+
+	if _, ok := msg.(PRLeaseMsg); !ok {
+		t.Fatalf("expected PRLeaseMsg, got %T", msg)
+	}
+	if len(repo.claims) != 1 || repo.claims[0].PendingDrafts != 1 || repo.claims[0].PRIdentifier != "github:owner/repo/5" {
+		t.Errorf("unexpected claims: %+v", repo.claims)
+	}
+}
+
+func TestValidatePATs_MarksExpiredAndFailingPATs(t *testing.T) {
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.providers = map[string]domain.Provider{
+		"ok":          &mockProvider{},
+		"failing":     &mockProvider{validateErr: fmt.Errorf("%w: 401 Bad credentials", common.ErrUnauthorized)},
+		"unreachable": &mockProvider{validateErr: fmt.Errorf("failed to get user: %w", errConnectionRefused)},
+		"erroring":    &mockProvider{validateErr: fmt.Errorf("502 Bad Gateway")},
+		"expired":     &mockProvider{},
+	}
+
+	msg := m.validatePATs([]domain.PAT{
+		{ID: "ok", Name: "ok"},
+		{ID: "failing", Name: "failing"},
+		{ID: "unreachable", Name: "unreachable"},
+		{ID: "erroring", Name: "erroring"},
+		{ID: "expired", Name: "expired", ExpiresAt: time.Now().Add(-time.Hour)},
+		{ID: "missing", Name: "missing"},
+	})()
+
+	validated, ok := msg.(PATsValidatedMsg)
+	if !ok {
+		t.Fatalf("expected PATsValidatedMsg, got %T", msg)
+	}
+	if _, ok := validated.problems["ok"]; ok {
+		t.Error("expected the valid PAT not to be flagged")
+	}
+	if validated.problems["failing"] != "authentication failed" {
+		t.Errorf("unexpected problem for failing PAT: %q", validated.problems["failing"])
+	}
+	for _, id := range []string{"unreachable", "erroring"} {
+		if problem, ok := validated.problems[id]; ok {
+			t.Errorf("expected a PAT that could not be checked to stay usable, got %q for %s", problem, id)
+		}
+	}
+	if !contains(validated.problems["expired"], "expired") {
+		t.Errorf("unexpected problem for expired PAT: %q", validated.problems["expired"])
+	}
+	if validated.problems["missing"] == "" {
+		t.Error("expected a PAT without provider to be flagged")
+	}
+}
+
+func TestUsableSelectedPATs_SkipsInvalidPATs(t *testing.T) {
+	m := createTestModel()
+	m.repository = &mockRepository{pats: map[string]*domain.PAT{
+		"good":    {ID: "good", Name: "good", IsSelected: true},
+		"bad":     {ID: "bad", Name: "bad", IsSelected: true},
+		"expired": {ID: "expired", Name: "expired", IsSelected: true, ExpiresAt: time.Now().Add(-time.Minute)},
+		"other":   {ID: "other", Name: "other"},
+	}}
+	m.invalidPATs = map[string]string{"bad": "authentication failed"}
+
+	usable, skipped, err := m.usableSelectedPATs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(usable) != 1 || usable[0].ID != "good" {
+		t.Errorf("expected only the good PAT, got %+v", usable)
+	}
+	if len(skipped) != 2 {
+		t.Errorf("expected two skipped PATs, got %v", skipped)
+	}
+}
+
+func TestApplyPATs_SharesProviderOfActiveSelectedPAT(t *testing.T) {
+	m := createTestModel()
+	pat := domain.PAT{
+		ID:         "oauth",
+		Name:       "oauth",
+		Provider:   domain.ProviderGitHub,
+		Token:      "access",
+		OAuth:      &domain.OAuthToken{RefreshToken: "refresh", ClientID: "client", Expiry: time.Now().Add(time.Hour)},
+		IsActive:   true,
+		IsSelected: true,
+		IsPrimary:  true,
+	}
+
+	m, selected := m.applyPATs([]domain.PAT{pat})
+
+	if selected != 1 {
+		t.Fatalf("expected one selected PAT, got %d", selected)
+	}
+	if m.provider == nil || m.provider != m.providers[pat.ID] || m.primaryProvider != m.provider {
+		t.Error("expected the active and selected PAT to share one provider")
+	}
+}
+
+func TestSaveRefreshedToken_KeepsCurrentPATSettings(t *testing.T) {
+	m := createTestModel()
+	m.repository = &mockRepository{pats: map[string]*domain.PAT{
+		"oauth": {ID: "oauth", Name: "renamed", Token: "old", OAuth: &domain.OAuthToken{RefreshToken: "refresh"}, IsSelected: false},
+	}}
+
+	m.saveRefreshedToken(domain.PAT{ID: "oauth", Name: "oauth", Token: "new", OAuth: &domain.OAuthToken{RefreshToken: "rotated"}, IsSelected: true})
+
+	pat, _ := m.repository.GetPAT("oauth")
+	if pat.Token != "new" || pat.OAuth.RefreshToken != "rotated" {
+		t.Errorf("expected the refreshed token to be saved, got %+v", pat)
+	}
+	if pat.Name != "renamed" || pat.IsSelected {
+		t.Errorf("expected the rest of the stored PAT to be kept, got %+v", pat)
+	}
+}
+
+func TestLoadPRsForPAT_AppliesOwnerFilter(t *testing.T) {
+	m := createTestModel()
+	pat := domain.PAT{
+		ID:            "pat-1",
+		Name:          "work",
+		Provider:      domain.ProviderGitHub,
+		IncludeOwners: []string{"acme", "tools"},
+		ExcludeOwners: []string{"Tools"},
+	}
+	m.providers = map[string]domain.Provider{
+		pat.ID: &mockProvider{prs: []domain.PullRequest{
+			{Number: 1, Repository: domain.Repo{Owner: "acme", FullName: "acme/api"}},
+			{Number: 2, Repository: domain.Repo{FullName: "ACME/web"}},
+			{Number: 3, Repository: domain.Repo{Owner: "tools", FullName: "tools/cli"}},
+			{Number: 4, Repository: domain.Repo{Owner: "personal", FullName: "personal/dotfiles"}},
+		}},
+	}
+
+	msg := m.loadPRsForPAT(context.Background(), 0, pat)().(PRGroupLoadedMsg)
+	if msg.LoadError != nil {
+		t.Fatalf("unexpected error: %v", msg.LoadError)
+	}
+	if len(msg.Group.PRs) != 2 {
+		t.Fatalf("expected 2 PRs after filtering, got %d", len(msg.Group.PRs))
+	}
+	for _, pr := range msg.Group.PRs {
+		if pr.Number != 1 && pr.Number != 2 {
+			t.Errorf("unexpected PR #%d in filtered group", pr.Number)
+		}
+	}
+}
+
+func TestUpdateAvailableMsg_EnablesChangelog(t *testing.T) {
+	m := createTestModel()
+
+	m, _ = handleChangelogCommand(m, nil)
+	if m.changelogView.IsActive() {
+		t.Fatal("expected changelog to stay closed without a known release")
+	}
+
+	model, _ := m.Update(UpdateAvailableMsg{release: update.Release{Version: "v9.9.9", Changelog: "- New things"}})
+	m = model.(Model)
+	if m.latestRelease == nil || m.latestRelease.Version != "v9.9.9" {
+		t.Fatalf("expected latest release to be recorded, got %+v", m.latestRelease)
+	}
+
+	m, _ = handleChangelogCommand(m, nil)
+	if !m.changelogView.IsActive() {
+		t.Error("expected changelog overlay to open")
+	}
+}
+
+func TestSnoozeKey_HidesAndWakesPR(t *testing.T) {
+	repo := &mockRepository{pats: map[string]*domain.PAT{}}
+	m := createTestModel()
+	m.repository = repo
+	m.snoozeView = views.NewSnoozeView()
+	m.state = ViewPRList
+	m.prListView.SetPRs([]domain.PullRequest{
+		{Number: 1, Title: "First", ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}, UpdatedAt: time.Now()},
+		{Number: 2, Title: "Second", ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}, UpdatedAt: time.Now().Add(-time.Hour)},
+	})
+
+	m, _ = handleSnoozeKey(m)
+	if !m.snoozeView.IsActive() {
+		t.Fatal("expected snooze picker to open")
+	}
+
+	model, _ := m.snoozeSelectedPR()
+	m = model.(Model)
+	if len(repo.snoozes) != 1 || repo.snoozes[0].PRIdentifier != "github:acme/api/1" {
+		t.Fatalf("expected PR #1 to be snoozed, got %+v", repo.snoozes)
+	}
+	if pr := m.prListView.GetSelectedPR(); pr == nil || pr.Number != 2 {
+		t.Fatalf("expected snoozed PR to be hidden, selected %+v", pr)
+	}
+
+	m, _ = handleToggleSnoozedKey(m)
+	m.prListView.RestoreCursor(1)
+	m, _ = handleSnoozeKey(m)
+	if m.snoozeView.IsActive() {
+		t.Error("expected z on a snoozed PR to wake it instead of opening the picker")
+	}
+	if len(repo.snoozes) != 0 {
+		t.Errorf("expected snooze to be removed, got %+v", repo.snoozes)
+	}
+}
+
+func TestPRListFilter_AppliesOnceTypingPauses(t *testing.T) {
+	m := createTestModel()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.state = ViewPRList
+	m.prListView.SetPRs([]domain.PullRequest{
+		{Number: 1, Title: "Fix login", ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}, UpdatedAt: time.Now()},
+		{Number: 2, Title: "Add search", ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}, UpdatedAt: time.Now()},
+	})
+	m.prListView.ActivateFilter()
+
+	m, _ = pressKey(m, "l")
+	stale := m.prListView.FilterGeneration()
+	m, cmd := pressKey(m, "o")
+	if cmd == nil || len(m.prListView.VisiblePRs()) != 2 {
+		t.Fatalf("expected the filter to wait for typing to pause, got %d PRs", len(m.prListView.VisiblePRs()))
+	}
+
+	result, _ := m.Update(PRFilterTickMsg{generation: stale})
+	m = result.(Model)
+	if len(m.prListView.VisiblePRs()) != 2 {
+		t.Error("expected a tick from before the last key to be ignored")
+	}
+	result, _ = m.Update(PRFilterTickMsg{generation: m.prListView.FilterGeneration()})
+	m = result.(Model)
+	if len(m.prListView.VisiblePRs()) != 1 {
+		t.Errorf("expected the filter to apply once typing paused, got %d PRs", len(m.prListView.VisiblePRs()))
+	}
+
+	m, _ = pressKey(m, "x")
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.prListView.IsFiltering() || m.prListView.GetFilterText() != "lox" || len(m.prListView.VisiblePRs()) != 0 {
+		t.Errorf("expected enter to apply the filter at once, got %q with %d PRs", m.prListView.GetFilterText(), len(m.prListView.VisiblePRs()))
+	}
+}
+
+func TestWakeSnoozedPRs_RemovesSnoozesOfUpdatedPRs(t *testing.T) {
+	updatedAt := time.Now().Add(-time.Hour)
+	repo := &mockRepository{
+		pats: map[string]*domain.PAT{},
+		snoozes: []domain.PRSnooze{
+			{PRIdentifier: "github:acme/api/1", UntilUpdated: true, PRUpdatedAt: updatedAt},
+		},
+	}
+	m := createTestModel()
+	m.repository = repo
+	m.reloadSnoozes()
+	m.prListView.SetPRs([]domain.PullRequest{
+		{Number: 1, ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}, UpdatedAt: time.Now()},
+	})
+
+	if woken := m.wakeSnoozedPRs(); woken != 1 {
+		t.Fatalf("expected 1 woken PR, got %d", woken)
+	}
+	if len(repo.snoozes) != 0 {
+		t.Errorf("expected woken snooze to be removed, got %+v", repo.snoozes)
+	}
+}
+
+func TestOpenPR_MarksPRSeen(t *testing.T) {
+	updatedAt := time.Now()
+	pr := domain.PullRequest{Number: 3, ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}, UpdatedAt: updatedAt}
+	repo := &mockRepository{pats: map[string]*domain.PAT{}, seen: map[string]time.Time{pr.Key(): updatedAt.Add(-time.Hour)}}
+
+	m := createTestModel()
+	m.repository = repo
+	m.state = ViewPRList
+	m.prListView.SetPRs([]domain.PullRequest{pr})
+
+	m, _ = handleEnterKey(m)
+
+	if !repo.seen[pr.Key()].Equal(updatedAt) {
+		t.Errorf("expected PR to be marked seen at %v, got %v", updatedAt, repo.seen[pr.Key()])
+	}
+	if m.leasedPR != pr.Key() {
+		t.Errorf("expected lease on %s, got %q", pr.Key(), m.leasedPR)
+	}
+	if m.prListView.IsUnread(pr) {
+		t.Error("expected list to show the PR as read")
+	}
+}
+
+func TestReviewSubmission_RequiresConfirmation(t *testing.T) {
+	provider := &mockProvider{}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.descriptionEditView = views.NewDescriptionEditView()
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	m.repository = &mockRepository{
+		pats: map[string]*domain.PAT{
+			"pat-1": {ID: "pat-1", Username: "reviewer", Provider: domain.ProviderGitHub},
+		},
+		settings: domain.Settings{Review: domain.ReviewSettings{UndoSeconds: -1}},
+	}
+	m.prInspect.SetPR(&domain.PullRequest{
+		Number:       1,
+		Author:       domain.User{Username: "author"},
+		Repository:   domain.Repo{FullName: "owner/repo"},
+		PATID:        "pat-1",
+		ProviderType: domain.ProviderGitHub,
+	})
+	m.reviewView.Activate(views.ReviewModeApprove)
+
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = result.(Model)
+	if cmd != nil || !m.reviewView.IsConfirming() {
+		t.Fatal("expected ctrl+s to show the confirmation instead of submitting")
+	}
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.reviewView.IsConfirming() || !m.reviewView.IsActive() {
+		t.Fatal("expected esc to return to the editor")
+	}
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = result.(Model)
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected enter to submit the review")
+	}
+	taskResult(cmd)
+	if !provider.submitReviewCalled || provider.lastReview.Action != domain.ReviewActionApprove {
+		t.Errorf("expected approval to be submitted, got %+v", provider.lastReview)
+	}
+}
+
+func TestReviewSubmission_SubmitsAsTheChosenAccount(t *testing.T) {
+	personal := &mockProvider{}
+	bot := &mockProvider{}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.descriptionEditView = views.NewDescriptionEditView()
+	m.providers = map[string]domain.Provider{"personal": personal, "bot": bot}
+	m.repository = &mockRepository{
+		pats: map[string]*domain.PAT{
+			"personal": {ID: "personal", Name: "Personal", Username: "jane", Token: "t1", Provider: domain.ProviderGitHub, IsSelected: true},
+			"bot":      {ID: "bot", Name: "Bot", Username: "ci-bot", Token: "t2", Provider: domain.ProviderGitHub, IsSelected: true},
+			"ado":      {ID: "ado", Name: "Work", Username: "jane", Token: "t3", Provider: domain.ProviderAzureDevOps, IsSelected: true},
+		},
+		settings: domain.Settings{Review: domain.ReviewSettings{UndoSeconds: -1}},
+	}
+	m.prInspect.SetPR(&domain.PullRequest{
+		Number:       1,
+		Author:       domain.User{Username: "jane"},
+		Repository:   domain.Repo{FullName: "owner/repo"},
+		PATID:        "personal",
+		ProviderType: domain.ProviderGitHub,
+	})
+	m.reviewView.SetSize(120, 40)
+	m.reviewView.Activate(views.ReviewModeApprove)
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = result.(Model)
+	view := m.reviewView.View()
+	if !strings.Contains(view, "(•) Personal (jane)") || !strings.Contains(view, "( ) Bot (ci-bot)") || strings.Contains(view, "Work") {
+		t.Fatalf("expected the GitHub accounts to be offered, got:\n%s", view)
+	}
+	if !strings.Contains(view, "your own PR") {
+		t.Errorf("expected approving your own PR to be flagged, got:\n%s", view)
+	}
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m = result.(Model)
+	if view := m.reviewView.View(); !strings.Contains(view, "(•) Bot (ci-bot)") || strings.Contains(view, "your own PR") {
+		t.Fatalf("expected the bot account to be chosen, got:\n%s", view)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected enter to submit the review")
+	}
+	taskResult(cmd)
+	if personal.submitReviewCalled {
+		t.Error("expected nothing to be submitted as the personal account")
+	}
+	if !bot.submitReviewCalled || bot.lastReview.Action != domain.ReviewActionApprove {
+		t.Errorf("expected the bot account to approve, got %+v", bot.lastReview)
+	}
+}
+
+func TestReviewSubmission_BlocksApprovalUntilTheChecklistIsChecked(t *testing.T) {
+	provider := &mockProvider{}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.descriptionEditView = views.NewDescriptionEditView()
+	m.statusBar.SetWidth(160)
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	m.repository = &mockRepository{
+		pats: map[string]*domain.PAT{
+			"pat-1": {ID: "pat-1", Username: "reviewer", Provider: domain.ProviderGitHub},
+		},
+		settings: domain.Settings{Review: domain.ReviewSettings{
+			UndoSeconds: -1,
+			Checklists: []domain.ReviewChecklistSettings{{
+				Repositories: domain.RepositoryPatterns{"owner"},
+				Items: []domain.ChecklistItem{
+					{Text: "Ran tests locally", Required: true},
+					{Text: "Read the linked issue"},
+					{Text: "Checked the migration", Required: true},
+				},
+			}},
+		}},
+	}
+	m.prInspect.SetPR(&domain.PullRequest{
+		Number:       1,
+		Author:       domain.User{Username: "author"},
+		Repository:   domain.Repo{FullName: "owner/repo"},
+		PATID:        "pat-1",
+		ProviderType: domain.ProviderGitHub,
+	})
+	m.reviewView.SetSize(120, 40)
+	m.reviewView.Activate(views.ReviewModeApprove)
+
+	press := func(msg tea.KeyMsg) tea.Cmd {
+		result, cmd := m.Update(msg)
+		m = result.(Model)
+		return cmd
+	}
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+
+	press(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if view := m.reviewView.View(); !strings.Contains(view, "[ ] Ran tests locally") || !strings.Contains(view, "required item(s) to approve") {
+		t.Fatalf("expected the checklist in the confirmation, got:\n%s", view)
+	}
+	if cmd := press(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || !m.reviewView.IsConfirming() {
+		t.Fatal("expected the approval to be blocked")
+	}
+	if !strings.Contains(m.statusBar.View(), "Check the 2 required checklist item(s)") {
+		t.Errorf("expected the blocked approval to be explained, got %q", m.statusBar.View())
+	}
+
+	press(space)
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	press(space)
+	// Going back to edit keeps the checked items.
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	press(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if view := m.reviewView.View(); !strings.Contains(view, "[x] Checked the migration") || strings.Contains(view, "to approve") {
+		t.Fatalf("expected the required items to be checked, got:\n%s", view)
+	}
+
+	cmd := press(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected enter to submit the approval")
+	}
+	taskResult(cmd)
+	if !provider.submitReviewCalled || provider.lastReview.Action != domain.ReviewActionApprove {
+		t.Errorf("expected the approval to be submitted, got %+v", provider.lastReview)
+	}
+}
+
+func TestReviewSubmission_ChoosesTheVerdictAtSubmitTime(t *testing.T) {
+	provider := &mockProvider{}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.descriptionEditView = views.NewDescriptionEditView()
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	m.repository = &mockRepository{
+		pats: map[string]*domain.PAT{
+			"pat-1": {ID: "pat-1", Username: "reviewer", Provider: domain.ProviderGitHub},
+		},
+		settings: domain.Settings{Review: domain.ReviewSettings{UndoSeconds: -1}},
+	}
+	m.prInspect.SetPR(&domain.PullRequest{
+		Number:       1,
+		Author:       domain.User{Username: "author"},
+		Repository:   domain.Repo{FullName: "owner/repo"},
+		PATID:        "pat-1",
+		ProviderType: domain.ProviderGitHub,
+	})
+	m.reviewView.Activate(views.ReviewModeRequestChanges)
+	m.reviewView.SetValue("Fine after all, one nit inline")
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = result.(Model)
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	m = result.(Model)
+	if !m.reviewView.IsConfirming() || m.reviewView.GetMode() != views.ReviewModeApprove {
+		t.Fatalf("expected left to select Approve in the confirmation, got %v", m.reviewView.GetMode())
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected enter to submit the review")
+	}
+	taskResult(cmd)
+	if provider.lastReview.Action != domain.ReviewActionApprove || provider.lastReview.Body != "Fine after all, one nit inline" {
+		t.Errorf("expected the approval with the body written, got %+v", provider.lastReview)
+	}
+}
+
+func TestReviewSubmission_UndoWindow(t *testing.T) {
+	provider := &mockProvider{}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	m.repository = &mockRepository{pats: map[string]*domain.PAT{
+		"pat-1": {ID: "pat-1", Username: "reviewer", Provider: domain.ProviderGitHub},
+	}}
+	pr := &domain.PullRequest{
+		Number:       1,
+		Author:       domain.User{Username: "author"},
+		Repository:   domain.Repo{FullName: "owner/repo"},
+		PATID:        "pat-1",
+		ProviderType: domain.ProviderGitHub,
+	}
+	m.prInspect.SetPR(pr)
+
+	m.reviewView.Activate(views.ReviewModeApprove)
+	m.reviewView.SetValue("LGTM")
+	m, _ = m.holdReview()
+	if m.heldReview == nil || m.reviewView.IsActive() {
+		t.Fatal("expected review to be held and the editor closed")
+	}
+
+	m, cmd := m.handleReviewUndoTick(ReviewUndoTickMsg{id: m.heldReview.id})
+	if m.heldReview == nil || cmd == nil {
+		t.Fatal("expected countdown to continue before the deadline")
+	}
+
+	m, _ = m.undoReview()
+	if m.heldReview != nil {
+		t.Fatal("expected undo to drop the held review")
+	}
+	if !m.reviewView.IsActive() || m.reviewView.GetValue() != "LGTM" {
+		t.Errorf("expected editor to reopen with the body, got %q", m.reviewView.GetValue())
+	}
+
+	m, _ = m.holdReview()
+	m.heldReview.deadline = time.Now().Add(-time.Second)
+	m, cmd = m.handleReviewUndoTick(ReviewUndoTickMsg{id: m.heldReview.id})
+	if m.heldReview != nil || cmd == nil {
+		t.Fatal("expected review to be sent after the deadline")
+	}
+	taskResult(cmd)
+	if !provider.submitReviewCalled || provider.lastReview.Body != "LGTM" {
+		t.Errorf("expected held review to be submitted, got %+v", provider.lastReview)
+	}
+}
+
+func TestQuit_SendsTheHeldReview(t *testing.T) {
+	provider := &mockProvider{}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	m.repository = &mockRepository{pats: map[string]*domain.PAT{
+		"pat-1": {ID: "pat-1", Username: "reviewer", Provider: domain.ProviderGitHub},
+	}}
+	m.prInspect.SetPR(&domain.PullRequest{
+		Number:       1,
+		Author:       domain.User{Username: "author"},
+		Repository:   domain.Repo{FullName: "owner/repo"},
+		PATID:        "pat-1",
+		ProviderType: domain.ProviderGitHub,
+	})
+
+	m.reviewView.Activate(views.ReviewModeApprove)
+	m.reviewView.SetValue("LGTM")
+	m, _ = m.holdReview()
+	if m.heldReview == nil {
+		t.Fatal("expected the review to be held")
+	}
+
+	m, cmd := m.commandRegistry.ExecuteCommand(m, "q", nil)
+	if cmd == nil {
+		t.Fatal("expected :q to quit")
+	}
+	if provider.submitReviewCalled {
+		t.Fatal("expected the review to be sent by the quit command, not before")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("expected the app to quit once the review is sent")
+	}
+	if !provider.submitReviewCalled || provider.lastReview.Body != "LGTM" || provider.lastReview.Action != domain.ReviewActionApprove {
+		t.Errorf("expected the held review to be submitted before quitting, got %+v", provider.lastReview)
+	}
+	if m.heldReview != nil {
+		t.Error("expected the held review to be cleared")
+	}
+}
+
+type mockReviewManagerProvider struct {
+	mockProvider
+	reviewers []domain.Reviewer
+	dismissed string
+	requested string
+}
+
+func (m *mockReviewManagerProvider) ListReviewers(ctx context.Context, identifier domain.PRIdentifier) ([]domain.Reviewer, error) {
+	return m.reviewers, nil
+}
+
+func (m *mockReviewManagerProvider) DismissReview(ctx context.Context, identifier domain.PRIdentifier, reviewID string, message string) error {
+	m.dismissed = reviewID
+	return nil
+}
+
+func (m *mockReviewManagerProvider) RequestReview(ctx context.Context, identifier domain.PRIdentifier, username string) error {
+	m.requested = username
+	return nil
+}
+
+func TestReviewersPanel_DismissAndRerequest(t *testing.T) {
+	provider := &mockReviewManagerProvider{reviewers: []domain.Reviewer{
+		{User: domain.User{Username: "alice"}, State: domain.ReviewerApproved, ReviewID: "11", Stale: true},
+		{User: domain.User{Username: "bob"}, State: domain.ReviewerPending},
+	}}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.descriptionEditView = views.NewDescriptionEditView()
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	m.prInspect.SetPR(&domain.PullRequest{
+		Number:       7,
+		Repository:   domain.Repo{FullName: "owner/repo"},
+		PATID:        "pat-1",
+		ProviderType: domain.ProviderGitHub,
+	})
+
+	m, cmd := handleReviewersKey(m)
+	result, _ := m.Update(cmd())
+	m = result.(Model)
+	if reviewer := m.reviewersView.GetSelectedReviewer(); reviewer == nil || reviewer.User.Username != "alice" {
+		t.Fatalf("expected reviewers to be loaded, got %+v", reviewer)
+	}
+
+	result, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("expected r to request a new review")
+	}
+	cmd()
+	if provider.requested != "alice" {
+		t.Errorf("expected review to be re-requested from alice, got %q", provider.requested)
+	}
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m = result.(Model)
+	if !m.reviewersView.IsDismissing() {
+		t.Fatal("expected d to ask for a dismissal message")
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected enter to dismiss the review")
+	}
+	cmd()
+	if provider.dismissed != "11" {
+		t.Errorf("expected review 11 to be dismissed, got %q", provider.dismissed)
+	}
+}
+
+type mockCheckOutputProvider struct {
+	mockProvider
+	checks []domain.CheckFailure
+}
+
+func (m *mockCheckOutputProvider) GetFailingChecks(ctx context.Context, identifier domain.PRIdentifier) ([]domain.CheckFailure, error) {
+	return m.checks, nil
+}
+
+func TestChecksPopup_ShowsTheOutputOfFailingChecks(t *testing.T) {
+	provider := &mockCheckOutputProvider{checks: []domain.CheckFailure{{
+		Name:        "test",
+		Conclusion:  "failure",
+		Title:       "2 tests failed",
+		Annotations: []domain.CheckAnnotation{{Path: "parse.go", Line: 42, Severity: domain.AnnotationError, Message: "expected 3, got 4"}},
+	}}}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	m.width, m.height = 120, 40
+	m.checksView.SetSize(120, 40)
+	m.prInspect.SetPR(&domain.PullRequest{
+		Number:       7,
+		Repository:   domain.Repo{FullName: "owner/repo"},
+		PATID:        "pat-1",
+		ProviderType: domain.ProviderGitHub,
+	})
+
+	m, cmd := handleChecksKey(m)
+	if !m.checksView.IsActive() || cmd == nil {
+		t.Fatal("expected C to open the popup and load the checks")
+	}
+	result, _ := m.Update(cmd())
+	m = result.(Model)
+	out := m.checksView.View()
+	for _, want := range []string{"test", "2 tests failed", "parse.go:42", "expected 3, got 4"} {
+		if !contains(out, want) {
+			t.Errorf("expected %q in the popup, got:\n%s", want, out)
+		}
+	}
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if result.(Model).checksView.IsActive() {
+		t.Error("expected esc to close the popup")
+	}
+}
+
+func TestChecksPopup_NotOpenedWithoutCheckOutput(t *testing.T) {
+	m := createTestModel()
+	m.providers = map[string]domain.Provider{"pat-1": &mockProvider{}}
+	m.prInspect.SetPR(&domain.PullRequest{Number: 7, PATID: "pat-1", ProviderType: domain.ProviderAzureDevOps})
+
+	m, _ = handleChecksKey(m)
+	if m.checksView.IsActive() {
+		t.Error("expected no popup for a provider without check output")
+	}
+}
+
+func TestMerge_DeleteBranchDefaultAndToggle(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		keep       bool
+		toggle     bool
+		wantDelete bool
+	}{
+		{"default deletes", false, false, true},
+		{"configured to keep", true, false, false},
+		{"toggled off", false, true, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := &mockProvider{}
+			m := createTestModel()
+			m.ctx = context.Background()
+			m.mergeView = views.NewMergeView()
+			m.snoozeView = views.NewSnoozeView()
+			m.descriptionEditView = views.NewDescriptionEditView()
+			m.providers = map[string]domain.Provider{"pat-1": provider}
+			m.repository = &mockRepository{
+				pats:     map[string]*domain.PAT{"pat-1": {ID: "pat-1", Provider: domain.ProviderGitHub}},
+				settings: domain.Settings{Merge: domain.MergeSettings{KeepSourceBranch: tc.keep}},
+			}
+			m.state = ViewPRInspect
+			m.prInspect.SetPR(&domain.PullRequest{
+				Number:       1,
+				Status:       domain.PRStatusOpen,
+				Mergeable:    true,
+				Repository:   domain.Repo{FullName: "owner/repo"},
+				PATID:        "pat-1",
+				ProviderType: domain.ProviderGitHub,
+			})
+
+			m, _ = handleMergeKey(m)
+			if tc.toggle {
+				result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+				m = result.(Model)
+			}
+			_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			if cmd == nil {
+				t.Fatal("expected enter to merge")
+			}
+			taskResult(cmd)
+
+			if provider.mergeDeleteBranch == nil || *provider.mergeDeleteBranch != tc.wantDelete {
+				t.Errorf("expected deleteBranch=%v, got %v", tc.wantDelete, provider.mergeDeleteBranch)
+			}
+		})
+	}
+}
+
+type mockMergeRequirementsProvider struct {
+	mockProvider
+	requirements map[int]*domain.MergeRequirements
+	approved     []string
+	merged       []int
+}
+
+func (m *mockMergeRequirementsProvider) GetMergeRequirements(ctx context.Context, identifier domain.PRIdentifier) (*domain.MergeRequirements, error) {
+	requirements, ok := m.requirements[identifier.Number]
+	if !ok {
+		return nil, errors.New("branch protection unavailable")
+	}
+	return requirements, nil
+}
+
+func (m *mockMergeRequirementsProvider) SubmitReview(ctx context.Context, review domain.Review) error {
+	m.approved = append(m.approved, review.PRIdentifier)
+	return m.mockProvider.SubmitReview(ctx, review)
+}
+
+func (m *mockMergeRequirementsProvider) MergePullRequest(ctx context.Context, identifier domain.PRIdentifier, mergeMethod string, deleteBranch bool) error {
+	m.merged = append(m.merged, identifier.Number)
+	return m.mockProvider.MergePullRequest(ctx, identifier, mergeMethod, deleteBranch)
+}
+
+func newDependencyBatchTestModel(provider domain.Provider, prs ...domain.PullRequest) Model {
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.descriptionEditView = views.NewDescriptionEditView()
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	m.repository = &mockRepository{
+		pats: map[string]*domain.PAT{"pat-1": {ID: "pat-1", Provider: domain.ProviderGitHub}},
+	}
+	m.state = ViewPRList
+	m.dependenciesView.Activate(prs)
+	return m
+}
+
+func dependencyPR(number int, repo string) domain.PullRequest {
+	return domain.PullRequest{
+		Number:       number,
+		Title:        "Bump lodash from 4.17.20 to 4.17.21",
+		Author:       domain.User{Username: "dependabot[bot]"},
+		Repository:   domain.Repo{FullName: repo},
+		PATID:        "pat-1",
+		ProviderType: domain.ProviderGitHub,
+	}
+}
+
+func TestDependencyBatch_ApprovesAndMergesSelected(t *testing.T) {
+	provider := &mockMergeRequirementsProvider{requirements: map[int]*domain.MergeRequirements{
+		7: {Protected: true, RequiredApprovals: 1},
+	}}
+	m := newDependencyBatchTestModel(provider, dependencyPR(7, "owner/repo"))
+
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if cmd != nil || !m.dependenciesView.IsConfirming() {
+		t.Fatal("expected enter to ask for confirmation first")
+	}
+
+	result, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = result.(Model)
+	if cmd == nil || !m.dependenciesView.IsRunning() {
+		t.Fatal("expected confirming to start the batch")
+	}
+
+	done, ok := cmd().(DependencyBatchDoneMsg)
+	if !ok || done.merged != 1 || len(done.failures) != 0 || len(done.skipped) != 0 {
+		t.Fatalf("expected one merged update, got %+v", done)
+	}
+	if provider.lastReview.Action != domain.ReviewActionApprove || provider.lastReview.PRIdentifier != "owner/repo/7" {
+		t.Errorf("expected approval of owner/repo/7, got %+v", provider.lastReview)
+	}
+	if provider.mergeDeleteBranch == nil {
+		t.Error("expected the PR to be merged")
+	}
+}
+
+func TestDependencyBatch_SkipsUpdatesThatCannotBeMerged(t *testing.T) {
+	provider := &mockMergeRequirementsProvider{requirements: map[int]*domain.MergeRequirements{
+		7: {},
+		8: {FailingChecks: []string{"build"}},
+		9: {PendingChecks: []string{"test"}},
+	}}
+	m := newDependencyBatchTestModel(provider,
+		dependencyPR(7, "owner/repo"), dependencyPR(8, "owner/repo"), dependencyPR(9, "owner/repo"), dependencyPR(10, "owner/repo"))
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	done := cmd().(DependencyBatchDoneMsg)
+
+	if done.merged != 1 || len(done.skipped) != 3 || len(done.failures) != 0 {
+		t.Fatalf("expected one merged and three skipped updates, got %+v", done)
+	}
+	if !slices.Equal(provider.approved, []string{"owner/repo/7"}) || !slices.Equal(provider.merged, []int{7}) {
+		t.Errorf("expected only #7 to be approved and merged, got approved %v, merged %v", provider.approved, provider.merged)
+	}
+	for i, want := range []string{"CI failing: build", "waiting for checks: test", "could not be loaded"} {
+		if !contains(done.skipped[i], want) {
+			t.Errorf("expected skipped update %d to say %q, got %q", i, want, done.skipped[i])
+		}
+	}
+
+	result, _ = m.Update(done)
+	history := result.(Model).statusBar.History()
+	if len(history) == 0 || !contains(history[len(history)-1].Text, "Approved and merged 1 of 4 dependency update(s); skipped: owner/repo#8 (CI failing: build)") {
+		t.Errorf("expected the skipped updates in the status, got %+v", history)
+	}
+}
+
+func TestDependencyBatch_SkipsRepositoriesWithReviewChecklist(t *testing.T) {
+	provider := &mockMergeRequirementsProvider{requirements: map[int]*domain.MergeRequirements{7: {}, 8: {}}}
+	m := newDependencyBatchTestModel(provider, dependencyPR(7, "acme/api"), dependencyPR(8, "acme/web"))
+	m.repository.(*mockRepository).settings.Review.Checklists = []domain.ReviewChecklistSettings{{
+		Repositories: domain.RepositoryPatterns{"acme/api"},
+		Items:        []domain.ChecklistItem{{Text: "Migration reviewed", Required: true}},
+	}}
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	done := cmd().(DependencyBatchDoneMsg)
+
+	if done.merged != 1 || len(done.skipped) != 1 || !contains(done.skipped[0], "acme/api#7 (review checklist)") {
+		t.Fatalf("expected the update of the repository with a checklist to be skipped, got %+v", done)
+	}
+	if !slices.Equal(provider.approved, []string{"acme/web/8"}) {
+		t.Errorf("expected only acme/web/8 to be approved, got %v", provider.approved)
+	}
+}
+
+func TestOpenCommand_ResolvesPRWithMatchingPAT(t *testing.T) {
+	github := &mockProvider{pr: &domain.PullRequest{Number: 42, Title: "Fix", Repository: domain.Repo{FullName: "acme/api"}}}
+	azure := &mockProvider{pr: &domain.PullRequest{Number: 42, Title: "Other", Repository: domain.Repo{FullName: "acme/api"}}}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.providers = map[string]domain.Provider{"gh": github, "ado": azure}
+	m.repository = &mockRepository{pats: map[string]*domain.PAT{
+		"gh":  {ID: "gh", Provider: domain.ProviderGitHub, IsSelected: true},
+		"ado": {ID: "ado", Provider: domain.ProviderAzureDevOps, Organization: "contoso", IsSelected: true},
+	}}
+
+	m, cmd := m.commandRegistry.ExecuteCommand(m, "open", []string{"https://github.com/acme/api/pull/42"})
+	if cmd == nil {
+		t.Fatal("expected :open to resolve the PR")
+	}
+	msg, ok := taskResult(cmd).(PRResolvedMsg)
+	if !ok {
+		t.Fatalf("expected PRResolvedMsg, got %T", taskResult(cmd))
+	}
+	if msg.pr.PATID != "gh" || msg.pr.ProviderType != domain.ProviderGitHub || msg.pr.Title != "Fix" {
+		t.Errorf("expected the PR from the GitHub PAT, got %+v", msg.pr)
+	}
+
+	_, cmd = m.commandRegistry.ExecuteCommand(m, "open", []string{"https://dev.azure.com/fabrikam/acme/_git/api/pullrequest/42"})
+	if errMsg, ok := taskResult(cmd).(ErrorMsg); !ok || !contains(errMsg.err.Error(), "fabrikam") {
+		t.Errorf("expected an error for an organization without a PAT, got %v", errMsg)
+	}
+}
+
+func TestOpenCommand_InvalidReference(t *testing.T) {
+	m := createTestModel()
+	m.statusBar.SetWidth(120)
+
+	m, cmd := m.commandRegistry.ExecuteCommand(m, "open", []string{"not-a-pr"})
+
+	if cmd != nil {
+		t.Error("expected no command for an invalid reference")
+	}
+	if !contains(m.statusBar.View(), "owner/repo/123") {
+		t.Errorf("expected the usage hint in the status bar, got %q", m.statusBar.View())
+	}
+}
+
+func sessionTestDiff() *domain.Diff {
+	lines := make([]domain.DiffLine, 6)
+	for i := range lines {
+		lines[i] = domain.DiffLine{Type: "add", Content: fmt.Sprintf("+line%d", i), NewLine: i + 1}
+	}
+	return &domain.Diff{Files: []domain.FileDiff{
+		{NewPath: "a.go", Hunks: []domain.DiffHunk{{Header: "@@ -0,0 +1,6 @@", Lines: lines}}},
+		{NewPath: "b.go", Hunks: []domain.DiffHunk{{Header: "@@ -0,0 +1,6 @@", Lines: lines}}},
+	}}
+}
+
+func TestQuit_ClosesTheWebhookServer(t *testing.T) {
+	server, err := webhook.NewServer(domain.WebhookSettings{ListenAddr: "127.0.0.1:0"})
+	if err != nil {
+		t.Fatalf("failed to create webhook server: %v", err)
+	}
+	if err := server.Start(); err != nil {
+		t.Fatalf("failed to start webhook server: %v", err)
+	}
+
+	m := createTestModel()
+	m.webhookServer = server
+	m.state = ViewPATs
+
+	if _, cmd := m.quit(); cmd == nil {
+		t.Fatal("expected the app to quit")
+	}
+	if conn, err := net.DialTimeout("tcp", server.Addr(), time.Second); err == nil {
+		conn.Close()
+		t.Error("expected the webhook listener to be closed on quit")
+	}
+}
+
+func TestQuit_SavesInspectSession(t *testing.T) {
+	repo := &mockRepository{pats: map[string]*domain.PAT{}}
+	m := createTestModel()
+	m.repository = repo
+	m.state = ViewPRInspect
+	m.prListView.SetFilterText("api")
+	m.prInspect.SetPR(&domain.PullRequest{Number: 7, Title: "Fix", Repository: domain.Repo{FullName: "acme/api"}, PATID: "pat-1", ProviderType: domain.ProviderGitHub})
+	m.prInspect.SetDiff(sessionTestDiff())
+	m.prInspect.JumpToLine(1, 3)
+
+	handleQuitCommand(m, nil)
+
+	session := repo.session
+	if session == nil || session.PR == nil {
+		t.Fatalf("expected session with PR to be saved, got %+v", session)
+	}
+	if session.View != domain.SessionViewPRInspect || session.Filter != "api" {
+		t.Errorf("unexpected session: %+v", session)
+	}
+	want := domain.SessionPR{ProviderType: domain.ProviderGitHub, PATID: "pat-1", Repository: "acme/api", Number: 7, Title: "Fix", DiffMode: true, FileIndex: 1, LineIndex: 3}
+	if *session.PR != want {
+		t.Errorf("expected %+v, got %+v", want, *session.PR)
+	}
+}
+
+func TestQuitFromPATs_SavesSessionOfPRList(t *testing.T) {
+	repo := &mockRepository{pats: map[string]*domain.PAT{}}
+	pr := domain.PullRequest{Number: 2, ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/web"}}
+	m := createTestModel()
+	m.repository = repo
+	m.state = ViewPRList
+	m.prListView.SetPRs([]domain.PullRequest{{Number: 1, ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}}, pr})
+	m.prListView.SelectPR(pr.Key())
+
+	m, _ = handleQuitKey(m)
+	if m.state != ViewPATs {
+		t.Fatalf("expected to be back in the PATs view, got %v", m.state)
+	}
+	_, cmd := handleQuitKey(m)
+	if cmd == nil {
+		t.Fatal("expected quit command")
+	}
+
+	if repo.session == nil || repo.session.View != domain.SessionViewPRList || repo.session.SelectedPR != pr.Key() {
+		t.Errorf("expected PR list session selecting %s, got %+v", pr.Key(), repo.session)
+	}
+}
+
+func TestResumeSession_ReopensPRAtDiffPosition(t *testing.T) {
+	session := &domain.Session{
+		View:       domain.SessionViewPRInspect,
+		SelectedPR: "github:acme/api/7",
+		PR: &domain.SessionPR{
+			ProviderType: domain.ProviderGitHub,
+			PATID:        "pat-1",
+			Repository:   "acme/api",
+			Number:       7,
+			DiffMode:     true,
+			FileIndex:    1,
+			LineIndex:    4,
+		},
+	}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.descriptionEditView = views.NewDescriptionEditView()
+	m.repository = &mockRepository{pats: map[string]*domain.PAT{}, session: session}
+	m.providers = map[string]domain.Provider{"pat-1": &mockProvider{}}
+	m.statusBar.SetWidth(120)
+	m.offerResume = true
+
+	updated, _ := m.Update(PRLoadingStartedMsg{TotalPATs: 1})
+	m = updated.(Model)
+	if !m.resumeView.IsActive() {
+		t.Fatal("expected the resume prompt on the first PR load")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = updated.(Model)
+	if m.resumeView.IsActive() || m.state != ViewPRInspect {
+		t.Fatalf("expected the PR to be reopened, state %v", m.state)
+	}
+	if pr := m.prInspect.GetPR(); pr == nil || pr.Number != 7 || pr.PATID != "pat-1" {
+		t.Fatalf("unexpected PR: %+v", pr)
+	}
+
+	updated, _ = m.Update(DiffLoadedMsg{diff: sessionTestDiff()})
+	m = updated.(Model)
+	if m.prInspect.GetMode() != views.PRInspectModeDiff {
+		t.Error("expected the diff to be shown")
+	}
+	if file, line, _ := m.prInspect.Position(); file != 1 || line != 4 {
+		t.Errorf("expected position 1:4, got %d:%d", file, line)
+	}
+
+	updated, _ = m.Update(PRGroupLoadedMsg{Group: domain.PRGroup{PATID: "pat-1", PRs: []domain.PullRequest{
+		{Number: 1, ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}},
+		{Number: 7, ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}},
+	}}})
+	m = updated.(Model)
+	if pr := m.prListView.GetSelectedPR(); pr == nil || pr.Number != 7 {
+		t.Errorf("expected the resumed PR to be selected in the list, got %+v", pr)
+	}
+}
+
+func TestResumeSession_DismissClearsSession(t *testing.T) {
+	repo := &mockRepository{pats: map[string]*domain.PAT{}, session: &domain.Session{View: domain.SessionViewPRList, Filter: "api"}}
+	m := createTestModel()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.descriptionEditView = views.NewDescriptionEditView()
+	m.repository = repo
+	m.state = ViewPRList
+	m.offerSessionResume()
+	if !m.resumeView.IsActive() {
+		t.Fatal("expected the resume prompt")
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+
+	if m.resumeView.IsActive() || repo.session != nil {
+		t.Errorf("expected the prompt to close and the session to be cleared, got %+v", repo.session)
+	}
+	if m.prListView.GetFilterText() != "" {
+		t.Error("expected the filter not to be restored")
+	}
+}
+
+func TestOfferSessionResume_SkipsPRWithoutProvider(t *testing.T) {
+	m := createTestModel()
+	m.repository = &mockRepository{pats: map[string]*domain.PAT{}, session: &domain.Session{
+		View: domain.SessionViewPRInspect,
+		PR:   &domain.SessionPR{PATID: "gone", Repository: "acme/api", Number: 1},
+	}}
+
+	m.offerSessionResume()
+
+	if m.resumeView.IsActive() {
+		t.Error("expected no prompt when the only thing to resume is a PR of an unselected PAT")
+	}
+}
+
+type mockReactorProvider struct {
+	mockProvider
+	reactedTo domain.Comment
+	reaction  string
+}
+
+func (m *mockReactorProvider) Reactions() []string {
+	return []string{domain.ReactionThumbsUp, domain.ReactionHooray}
+}
+
+func (m *mockReactorProvider) AddReaction(ctx context.Context, identifier domain.PRIdentifier, comment domain.Comment, content string) error {
+	m.reactedTo = comment
+	m.reaction = content
+	return nil
+}
+
+func TestCommentReaction_PickAndAdd(t *testing.T) {
+	provider := &mockReactorProvider{}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.descriptionEditView = views.NewDescriptionEditView()
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	m.prInspect.SetPR(&domain.PullRequest{
+		Number:       7,
+		Repository:   domain.Repo{FullName: "owner/repo"},
+		PATID:        "pat-1",
+		ProviderType: domain.ProviderGitHub,
+	})
+	m.prInspect.SetComments([]domain.Comment{{ID: "5", Body: "Nice", Reactions: []domain.Reaction{{Content: domain.ReactionHooray, Count: 1}}}})
+	m, _ = handleViewCommentsKey(m)
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	m = result.(Model)
+	if !m.commentDetailView.IsPickingReaction() {
+		t.Fatal("expected + to open the reaction picker")
+	}
+
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("expected picking a reaction to add it")
+	}
+	result, _ = m.Update(cmd())
+	m = result.(Model)
+
+	if provider.reactedTo.ID != "5" || provider.reaction != domain.ReactionHooray {
+		t.Errorf("expected a hooray on comment 5, got %q on %+v", provider.reaction, provider.reactedTo)
+	}
+	comments := m.prInspect.GetComments()
+	if len(comments[0].Reactions) != 1 || comments[0].Reactions[0].Count != 2 {
+		t.Errorf("expected the reaction to be counted, got %+v", comments[0].Reactions)
+	}
+}
+
+func TestCommentReaction_UnsupportedProvider(t *testing.T) {
+	m := createTestModel()
+	m.providers = map[string]domain.Provider{"pat-1": &mockProvider{}}
+	m.prInspect.SetPR(&domain.PullRequest{Number: 7, PATID: "pat-1"})
+	m.commentDetailView.Activate([]domain.Comment{{ID: "5", Body: "Nice"}}, nil)
+
+	result, _ := m.startReactionPicker()
+	m = result.(Model)
+
+	if m.commentDetailView.IsPickingReaction() {
+		t.Error("expected no picker when the provider does not support reactions")
+	}
+}
+
+type mockThreadStatusProvider struct {
+	mockProvider
+	thread string
+	status domain.ThreadStatus
+}
+
+func (m *mockThreadStatusProvider) ThreadStatuses() []domain.ThreadStatus {
+	return []domain.ThreadStatus{domain.ThreadActive, domain.ThreadFixed}
+}
+
+func (m *mockThreadStatusProvider) SetThreadStatus(ctx context.Context, identifier domain.PRIdentifier, comment domain.Comment, status domain.ThreadStatus) error {
+	m.thread = comment.ThreadID
+	m.status = status
+	return nil
+}
+
+func TestThreadStatus_PickAndSet(t *testing.T) {
+	provider := &mockThreadStatusProvider{}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.descriptionEditView = views.NewDescriptionEditView()
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	m.prInspect.SetPR(&domain.PullRequest{
+		Number:       7,
+		Repository:   domain.Repo{FullName: "project/repo"},
+		PATID:        "pat-1",
+		ProviderType: domain.ProviderAzureDevOps,
+	})
+	m.prInspect.SetComments([]domain.Comment{
+		{ID: "1", ThreadID: "4", Body: "Rename this", ThreadStatus: domain.ThreadActive},
+		{ID: "2", ThreadID: "4", Body: "Done", ThreadStatus: domain.ThreadActive},
+		{ID: "1", ThreadID: "5", Body: "Other", ThreadStatus: domain.ThreadActive},
+	})
+	m, _ = handleViewCommentsKey(m)
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m = result.(Model)
+	if !m.commentDetailView.IsPickingStatus() {
+		t.Fatal("expected s to open the thread status picker")
+	}
+
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("expected picking a status to set it")
+	}
+	result, _ = m.Update(cmd())
+	m = result.(Model)
+
+	if provider.thread != "4" || provider.status != domain.ThreadFixed {
+		t.Errorf("expected thread 4 to be resolved, got %q on %q", provider.status, provider.thread)
+	}
+	comments := m.prInspect.GetComments()
+	if comments[0].ThreadStatus != domain.ThreadFixed || comments[1].ThreadStatus != domain.ThreadFixed || comments[2].ThreadStatus != domain.ThreadActive {
+		t.Errorf("expected only the comments of thread 4 to be resolved, got %+v", comments)
+	}
+}
+
+func TestMentionCandidates_CompleteInInlineCommentEditor(t *testing.T) {
+	m := createTestModel()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	pr := domain.PullRequest{Number: 7, Repository: domain.Repo{FullName: "owner/repo"}, ProviderType: domain.ProviderGitHub}
+	m.prInspect.SetPR(&pr)
+
+	candidates := []domain.MentionCandidate{{User: domain.User{Username: "alice"}, Text: "@alice"}}
+	result, _ := m.Update(MentionCandidatesLoadedMsg{prKey: "github:other/repo/1", candidates: []domain.MentionCandidate{{User: domain.User{Username: "mallory"}, Text: "@mallory"}}})
+	m = result.(Model)
+	result, _ = m.Update(MentionCandidatesLoadedMsg{prKey: pr.Key(), candidates: candidates})
+	m = result.(Model)
+
+	m.inlineCommentView.Activate("main.go:3")
+	for _, key := range []string{"@", "a"} {
+		result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = result.(Model)
+	}
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = result.(Model)
+
+	if got := m.inlineCommentView.GetValue(); got != "@alice " {
+		t.Errorf("expected the mention to be completed, got %q", got)
+	}
+	if !m.inlineCommentView.IsActive() {
+		t.Error("expected the editor to stay open")
+	}
+}
+
+func TestCodeOwners_OwnedFilesKeyShowsOnlyTheFilesOfTheUser(t *testing.T) {
+	m := createTestModel()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.state = ViewPRInspect
+	pr := domain.PullRequest{Number: 7, Repository: domain.Repo{FullName: "owner/repo"}, ProviderType: domain.ProviderGitHub}
+	m.prInspect.SetPR(&pr)
+	m.prInspect.SetDiff(&domain.Diff{Files: []domain.FileDiff{{NewPath: "main.go"}, {NewPath: "docs/intro.md"}}})
+	m.prInspect.SwitchToDiff()
+
+	m, _ = handleOwnedFilesKey(m)
+	if m.prInspect.IsOwnedOnly() {
+		t.Fatal("expected no filter before the code owners load")
+	}
+
+	owners := &domain.CodeOwners{Rules: domain.ParseCodeOwners("/docs/ @alice"), Identities: []string{"@alice"}}
+	result, _ := m.Update(CodeOwnersLoadedMsg{prKey: "github:other/repo/1", owners: &domain.CodeOwners{}})
+	m = result.(Model)
+	if m.prInspect.HasCodeOwners() {
+		t.Fatal("expected the code owners of another PR to be ignored")
+	}
+	result, _ = m.Update(CodeOwnersLoadedMsg{prKey: pr.Key(), owners: owners})
+	m = result.(Model)
+
+	m, _ = handleOwnedFilesKey(m)
+	if file, _ := m.prInspect.CurrentFile(); !m.prInspect.IsOwnedOnly() || file.NewPath != "docs/intro.md" {
+		t.Errorf("expected only the owned file to be shown, got %s", file.NewPath)
+	}
+}
+
+type mockUploaderProvider struct {
+	mockProvider
+	uploaded []string
+}
+
+func (m *mockUploaderProvider) UploadAttachment(ctx context.Context, identifier domain.PRIdentifier, fileName string, content []byte) (string, error) {
+	m.uploaded = append(m.uploaded, fileName)
+	return "https://files.example.com/" + fileName, nil
+}
+
+func attachmentTestModel(provider domain.Provider) Model {
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	m.prInspect.SetPR(&domain.PullRequest{
+		Number:       1,
+		Repository:   domain.Repo{FullName: "owner/repo"},
+		PATID:        "pat-1",
+		ProviderType: domain.ProviderAzureDevOps,
+	})
+	return m
+}
+
+func TestSubmitReview_UploadsReferencedFiles(t *testing.T) {
+	shot := filepath.Join(t.TempDir(), "shot.png")
+	if err := os.WriteFile(shot, []byte("png"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	provider := &mockUploaderProvider{}
+	m := attachmentTestModel(provider)
+	m.prInspect.SetDiff(&domain.Diff{Files: []domain.FileDiff{{NewPath: "main.go", Hunks: []domain.DiffHunk{{Lines: []domain.DiffLine{{Type: "add", Content: "x", NewLine: 1}}}}}}})
+	m.prInspect.AddPendingComment("Renders like this:\n" + shot)
+	m.reviewView.Activate(views.ReviewModeComment)
+	m.reviewView.SetValue("Overview ![before](" + shot + ")")
+
+	msg := taskResult(m.submitReview())
+
+	success, ok := msg.(SuccessMsg)
+	if !ok {
+		t.Fatalf("expected SuccessMsg, got %#v", msg)
+	}
+	if len(provider.uploaded) != 2 || !strings.Contains(success.message, "Uploaded 2 attachment(s)") {
+		t.Errorf("expected both references to be uploaded, got %v (%q)", provider.uploaded, success.message)
+	}
+	if provider.lastReview.Body != "Overview ![before](https://files.example.com/shot.png)" {
+		t.Errorf("unexpected body %q", provider.lastReview.Body)
+	}
+	if len(provider.lastReview.Comments) != 1 || provider.lastReview.Comments[0].Body != "Renders like this:\n![shot.png](https://files.example.com/shot.png)" {
+		t.Errorf("unexpected inline comments %+v", provider.lastReview.Comments)
+	}
+	if pending := m.prInspect.GetPendingComments(); strings.Contains(pending[0].Body, "https://") {
+		t.Error("expected the pending comment itself to be left unchanged")
+	}
+}
+
+func TestSubmitReview_LeavesOutAttachmentsWithoutUploader(t *testing.T) {
+	shot := filepath.Join(t.TempDir(), "shot.png")
+	if err := os.WriteFile(shot, []byte("png"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	provider := &mockProvider{}
+	m := attachmentTestModel(provider)
+	m.reviewView.Activate(views.ReviewModeComment)
+	m.reviewView.SetValue("Looks off:\n" + shot)
+
+	m.showReviewConfirmation()
+	if view := m.reviewView.View(); !strings.Contains(view, "cannot host attachments; 1 local file(s) will be left out") {
+		t.Errorf("expected the summary to warn about the attachment, got:\n%s", view)
+	}
+
+	msg := taskResult(m.submitReview())
+
+	success, ok := msg.(SuccessMsg)
+	if !ok {
+		t.Fatalf("expected SuccessMsg, got %#v", msg)
+	}
+	if !strings.Contains(success.message, "Left out 1 attachment(s)") {
+		t.Errorf("expected the left out attachment to be reported, got %q", success.message)
+	}
+	if provider.lastReview.Body != "Looks off:\nshot.png" {
+		t.Errorf("expected the path to be replaced by the file name, got %q", provider.lastReview.Body)
+	}
+}
+
+// slowProvider lists pull requests only once its context is done.
+type slowProvider struct {
+	mockProvider
+}
+
+func (p *slowProvider) ListPullRequests(ctx context.Context, username string) ([]domain.PullRequest, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestLoadPRsForPAT_TimesOut(t *testing.T) {
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.requestTimeout = 10 * time.Millisecond
+	pat := domain.PAT{ID: "pat-1", Name: "work", Provider: domain.ProviderGitHub}
+	m.providers = map[string]domain.Provider{pat.ID: &slowProvider{}}
+
+	msg := m.loadPRsForPAT(m.ctx, 1, pat)().(PRGroupLoadedMsg)
+	if !errors.Is(msg.LoadError, context.DeadlineExceeded) {
+		t.Fatalf("expected the request to time out, got %v", msg.LoadError)
+	}
+
+	updated, _ := m.Update(ErrorMsg{err: msg.LoadError})
+	m = updated.(Model)
+	m.statusBar.SetWidth(120)
+	if view := m.statusBar.View(); !strings.Contains(view, "timed out after 10ms") {
+		t.Errorf("expected a timeout message, got %q", view)
+	}
+}
+
+func TestEsc_CancelsPRLoading(t *testing.T) {
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.listLoad = newLoadTracker()
+	m.statusBar.SetWidth(120)
+	pat := domain.PAT{ID: "pat-1", Name: "work", Provider: domain.ProviderGitHub}
+	m.providers = map[string]domain.Provider{pat.ID: &slowProvider{}}
+
+	ctx, loadID := m.listLoad.begin(m.ctx)
+	load := m.loadPRsForPAT(ctx, loadID, pat)
+	updated, _ := m.Update(PRLoadingStartedMsg{TotalPATs: 1, LoadID: loadID})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+
+	if m.state != ViewPRList || m.loadingState.IsLoading {
+		t.Fatalf("expected to stay in the PR list with loading stopped, state %v loading %v", m.state, m.loadingState.IsLoading)
+	}
+	if view := m.statusBar.View(); !strings.Contains(view, "Cancelled loading PRs (0/1") {
+		t.Errorf("expected a cancellation message, got %q", view)
+	}
+
+	msg := load().(PRGroupLoadedMsg)
+	if !errors.Is(msg.LoadError, context.Canceled) {
+		t.Fatalf("expected the load to be cancelled, got %v", msg.LoadError)
+	}
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	if m.loadingState.LoadedPATs != 0 || len(m.loadingState.FailedPATs) != 0 {
+		t.Errorf("expected the cancelled load to be ignored, got %+v", m.loadingState)
+	}
+}
+
+func TestPRGroupLoaded_IgnoresSupersededLoad(t *testing.T) {
+	m := createTestModel()
+	m.ctx = context.Background()
+
+	updated, _ := m.Update(PRLoadingStartedMsg{TotalPATs: 1, LoadID: 2})
+	m = updated.(Model)
+	updated, _ = m.Update(PRGroupLoadedMsg{LoadID: 1, Group: domain.PRGroup{PATID: "pat-1", PRs: []domain.PullRequest{
+		{Number: 1, ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}},
+	}}})
+	m = updated.(Model)
+
+	if m.loadingState.LoadedPATs != 0 || len(m.loadingState.AccumulatedGroups) != 0 {
+		t.Errorf("expected the group from the earlier load to be ignored, got %+v", m.loadingState)
+	}
+}
+
+func TestNavigateBack_CancelsPRLoads(t *testing.T) {
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.prLoad = newLoadTracker()
+	m.state = ViewPRList
+	m.prListView.SetPRGroups([]domain.PRGroup{{PATID: "pat-1", PRs: []domain.PullRequest{
+		{Number: 1, ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}},
+	}}})
+
+	m, _ = m.openPR(*m.prListView.GetSelectedPR())
+	ctx := m.prLoad.context(context.Background())
+	if ctx.Err() != nil {
+		t.Fatal("expected the load of the opened PR to be running")
+	}
+
+	updated, _ := m.navigateBack()
+	m = updated.(Model)
+	if m.state != ViewPRList || !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("expected leaving the PR to cancel its loads, state %v err %v", m.state, ctx.Err())
+	}
+}
+
+// offlineRepository is a mockRepository that also keeps offline data.
+type offlineRepository struct {
+	*mockRepository
+	groups map[string]domain.CachedPRGroup
+	prs    map[string]domain.CachedPR
+	queued []domain.QueuedReview
+}
+
+func newOfflineRepository() *offlineRepository {
+	return &offlineRepository{
+		mockRepository: &mockRepository{pats: map[string]*domain.PAT{}},
+		groups:         map[string]domain.CachedPRGroup{},
+		prs:            map[string]domain.CachedPR{},
+	}
+}
+
+func (r *offlineRepository) SaveCachedPRGroup(group domain.PRGroup, fetchedAt time.Time) error {
+	r.groups[group.PATID] = domain.CachedPRGroup{Group: group, FetchedAt: fetchedAt}
+	return nil
+}
+
+func (r *offlineRepository) GetCachedPRGroup(patID string) (*domain.CachedPRGroup, error) {
+	if cached, ok := r.groups[patID]; ok {
+		return &cached, nil
+	}
+	return nil, nil
+}
+
+func (r *offlineRepository) SaveCachedPR(key string, pr domain.PullRequest, fetchedAt time.Time) error {
+	cached := r.prs[key]
+	cached.PR, cached.FetchedAt = &pr, fetchedAt
+	r.prs[key] = cached
+	return nil
+}
+
+func (r *offlineRepository) SaveCachedDiff(key string, diff *domain.Diff, fetchedAt time.Time) error {
+	cached := r.prs[key]
+	cached.Diff, cached.FetchedAt = diff, fetchedAt
+	r.prs[key] = cached
+	return nil
+}
+
+func (r *offlineRepository) SaveCachedComments(key string, comments []domain.Comment, fetchedAt time.Time) error {
+	cached := r.prs[key]
+	cached.Comments, cached.CommentsLoaded, cached.FetchedAt = comments, true, fetchedAt
+	r.prs[key] = cached
+	return nil
+}
+
+func (r *offlineRepository) GetCachedPR(key string) (*domain.CachedPR, error) {
+	if cached, ok := r.prs[key]; ok {
+		return &cached, nil
+	}
+	return nil, nil
+}
+
+func (r *offlineRepository) QueueReview(review domain.QueuedReview) error {
+	r.queued = append(r.queued, review)
+	return nil
+}
+
+func (r *offlineRepository) UpdateQueuedReview(review domain.QueuedReview) error {
+	for i, queued := range r.queued {
+		if queued.ID == review.ID {
+			r.queued[i] = review
+			return nil
+		}
+	}
+	return fmt.Errorf("queued review not found: %s", review.ID)
+}
+
+func (r *offlineRepository) ListQueuedReviews() ([]domain.QueuedReview, error) {
+	return append([]domain.QueuedReview(nil), r.queued...), nil
+}
+
+func (r *offlineRepository) RemoveQueuedReview(id string) error {
+	for i, review := range r.queued {
+		if review.ID == id {
+			r.queued = append(r.queued[:i], r.queued[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// unreachableProvider fails every call as if the network were down.
+type unreachableProvider struct {
+	mockProvider
+}
+
+var errConnectionRefused = &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+
+func (p *unreachableProvider) ListPullRequests(ctx context.Context, username string) ([]domain.PullRequest, error) {
+	return nil, fmt.Errorf("failed to list PRs: %w", errConnectionRefused)
+}
+
+func (p *unreachableProvider) GetDiff(ctx context.Context, identifier domain.PRIdentifier) (*domain.Diff, error) {
+	return nil, errConnectionRefused
+}
+
+func (p *unreachableProvider) SubmitReview(ctx context.Context, review domain.Review) error {
+	return errConnectionRefused
+}
+
+func TestOffline_ListFallsBackToCache(t *testing.T) {
+	repo := newOfflineRepository()
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.repository = repo
+	m.topBar.SetWidth(160)
+	pat := domain.PAT{ID: "pat-1", Name: "work", Provider: domain.ProviderGitHub}
+	group := domain.PRGroup{PATName: "work", PATID: "pat-1", PRs: []domain.PullRequest{
+		{Number: 1, ProviderType: domain.ProviderGitHub, PATID: "pat-1", Repository: domain.Repo{FullName: "acme/api"}},
+	}}
+
+	m.providers = map[string]domain.Provider{pat.ID: &mockProvider{prs: group.PRs}}
+	if msg := m.loadPRsForPAT(m.ctx, 1, pat)().(PRGroupLoadedMsg); msg.LoadError != nil || !msg.CachedAt.IsZero() {
+		t.Fatalf("expected a live load, got %+v", msg)
+	}
+	if _, ok := repo.groups[pat.ID]; !ok {
+		t.Fatal("expected the loaded group to be cached")
+	}
+
+	m.providers = map[string]domain.Provider{pat.ID: &unreachableProvider{}}
+	msg := m.loadPRsForPAT(m.ctx, 1, pat)().(PRGroupLoadedMsg)
+	if msg.LoadError != nil || msg.CachedAt.IsZero() || len(msg.Group.PRs) != 1 {
+		t.Fatalf("expected the cached group, got %+v", msg)
+	}
+
+	updated, _ := m.Update(PRLoadingStartedMsg{TotalPATs: 1, LoadID: 1})
+	m = updated.(Model)
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	if len(m.loadingState.FailedPATs) != 0 || len(m.loadingState.AccumulatedGroups) != 1 {
+		t.Errorf("expected the cached PRs to be listed, got %+v", m.loadingState)
+	}
+	want := "offline, data from " + msg.CachedAt.Format("15:04")
+	if view := m.topBar.View(); !strings.Contains(view, want) {
+		t.Errorf("expected the offline badge %q in the top bar, got %q", want, view)
+	}
+
+	updated, _ = m.Update(PRLoadingStartedMsg{TotalPATs: 1, LoadID: 2})
+	m = updated.(Model)
+	if strings.Contains(m.topBar.View(), "offline") {
+		t.Error("expected a new load to clear the offline badge")
+	}
+}
+
+func TestOffline_StartupShowsCachedPRsWhenProviderIsUnreachable(t *testing.T) {
+	repo := newOfflineRepository()
+	repo.pats["pat-1"] = &domain.PAT{ID: "pat-1", Name: "work", Provider: domain.ProviderGitHub, IsSelected: true}
+	fetchedAt := time.Now().Add(-2 * time.Hour)
+	repo.groups["pat-1"] = domain.CachedPRGroup{FetchedAt: fetchedAt, Group: domain.PRGroup{PATName: "work", PATID: "pat-1", PRs: []domain.PullRequest{
+		{Number: 1, ProviderType: domain.ProviderGitHub, PATID: "pat-1", Repository: domain.Repo{FullName: "acme/api"}},
+	}}}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.repository = repo
+	m.topBar.SetWidth(160)
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.descriptionEditView = views.NewDescriptionEditView()
+	m.providers = map[string]domain.Provider{"pat-1": &unreachableProvider{
+		mockProvider{validateErr: fmt.Errorf("failed to get user: %w", errConnectionRefused)},
+	}}
+
+	selected, _ := repo.GetSelectedPATs()
+	result, cmd := m.Update(m.validatePATs(selected)())
+	m = result.(Model)
+	if m.invalidPATs["pat-1"] != "" {
+		t.Fatalf("expected the unreachable PAT to stay usable, got %q", m.invalidPATs["pat-1"])
+	}
+
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("expected the PRs to be loaded after validation")
+	}
+	for _, c := range batch {
+		if c == nil {
+			continue
+		}
+		if msg := c(); msg != nil {
+			if _, isTick := msg.(spinner.TickMsg); !isTick {
+				result, _ = m.Update(msg)
+				m = result.(Model)
+			}
+		}
+	}
+
+	if len(m.loadingState.FailedPATs) != 0 || len(m.loadingState.AccumulatedGroups) != 1 || len(m.loadingState.AccumulatedGroups[0].PRs) != 1 {
+		t.Fatalf("expected the cached PRs to be listed, got %+v", m.loadingState)
+	}
+	want := "offline, data from " + fetchedAt.Format("15:04")
+	if view := m.topBar.View(); !strings.Contains(view, want) {
+		t.Errorf("expected the offline badge %q in the top bar, got %q", want, view)
+	}
+}
+
+func TestOffline_DiffFallsBackToCache(t *testing.T) {
+	repo := newOfflineRepository()
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.repository = repo
+	pr := domain.PullRequest{Number: 7, ProviderType: domain.ProviderGitHub, PATID: "pat-1", Repository: domain.Repo{FullName: "acme/api"}}
+	m.providers = map[string]domain.Provider{"pat-1": &unreachableProvider{}}
+
+	if _, ok := m.loadDiff(pr)().(ErrorMsg); !ok {
+		t.Fatal("expected an error without cached data")
+	}
+
+	fetchedAt := time.Now().Add(-time.Hour)
+	repo.SaveCachedDiff(pr.Key(), sessionTestDiff(), fetchedAt)
+	msg, ok := m.loadDiff(pr)().(DiffLoadedMsg)
+	if !ok || !msg.cachedAt.Equal(fetchedAt) || len(msg.diff.Files) == 0 {
+		t.Fatalf("expected the cached diff, got %+v", msg)
+	}
+}
+
+func TestOffline_QueuesReviewAndSendsItWhenOnline(t *testing.T) {
+	repo := newOfflineRepository()
+	repo.pats["pat-1"] = &domain.PAT{ID: "pat-1", Username: "reviewer", Provider: domain.ProviderGitHub}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.repository = repo
+	m.statusBar.SetWidth(120)
+	m.providers = map[string]domain.Provider{"pat-1": &unreachableProvider{}}
+	pr := &domain.PullRequest{
+		Number:       1,
+		Author:       domain.User{Username: "author"},
+		Repository:   domain.Repo{FullName: "owner/repo"},
+		PATID:        "pat-1",
+		ProviderType: domain.ProviderGitHub,
+	}
+	m.prInspect.SetPR(pr)
+	m.reviewView.Activate(views.ReviewModeApprove)
+	m.reviewView.SetValue("LGTM")
+
+	if _, ok := taskResult(m.submitReview()).(ReviewSavedToOutboxMsg); !ok || len(repo.queued) != 1 {
+		t.Fatalf("expected the review to be queued, queue %+v", repo.queued)
+	}
+	if queued := repo.queued[0]; queued.Review.Body != "LGTM" || queued.Review.Action != domain.ReviewActionApprove || !queued.RetryWhenOnline {
+		t.Errorf("unexpected queued review: %+v", queued)
+	}
+
+	provider := &mockProvider{}
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	m, cmd := m.noteListData(time.Time{})
+	if cmd == nil || !m.flushingReviews {
+		t.Fatal("expected live data to send the queued review")
+	}
+	if m, again := m.noteListData(time.Time{}); again != nil {
+		t.Fatalf("expected only one flush at a time, flushing %v", m.flushingReviews)
+	}
+
+	updated, _ := m.Update(taskResult(cmd))
+	m = updated.(Model)
+	if !provider.submitReviewCalled || provider.lastReview.Body != "LGTM" || len(repo.queued) != 0 {
+		t.Errorf("expected the queued review to be sent, got %+v (queue %+v)", provider.lastReview, repo.queued)
+	}
+	if m.flushingReviews || !strings.Contains(m.statusBar.View(), "Sent 1 review(s)") {
+		t.Errorf("expected a confirmation, got %q", m.statusBar.View())
+	}
+	if len(repo.activity) != 1 {
+		t.Errorf("expected the review to be recorded once sent, got %+v", repo.activity)
+	}
+}
+
+// resetProvider loses the connection after a review was sent, so it cannot
+// tell whether the server posted it.
+type resetProvider struct {
+	mockProvider
+}
+
+func (p *resetProvider) SubmitReview(ctx context.Context, review domain.Review) error {
+	return fmt.Errorf("failed to submit review: %w", &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET})
+}
+
+func TestOutbox_DoesNotResendReviewThatMayHaveBeenPosted(t *testing.T) {
+	repo := newOfflineRepository()
+	repo.pats["pat-1"] = &domain.PAT{ID: "pat-1", Username: "reviewer", Provider: domain.ProviderGitHub}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.repository = repo
+	m.providers = map[string]domain.Provider{"pat-1": &resetProvider{}}
+	m.prInspect.SetPR(&domain.PullRequest{
+		Number:       1,
+		Author:       domain.User{Username: "author"},
+		Repository:   domain.Repo{FullName: "owner/repo"},
+		PATID:        "pat-1",
+		ProviderType: domain.ProviderGitHub,
+	})
+	m.reviewView.Activate(views.ReviewModeApprove)
+	m.reviewView.SetValue("LGTM")
+
+	if _, ok := taskResult(m.submitReview()).(ReviewSavedToOutboxMsg); !ok || len(repo.queued) != 1 {
+		t.Fatalf("expected the review to be kept in the outbox, queue %+v", repo.queued)
+	}
+	if repo.queued[0].RetryWhenOnline {
+		t.Error("expected a review that may have been posted not to be resent automatically")
+	}
+	m.providers = map[string]domain.Provider{"pat-1": &mockProvider{}}
+	if _, cmd := m.noteListData(time.Time{}); cmd != nil {
+		t.Error("expected no automatic resend")
+	}
+}
+
+// rejectingProvider answers every review with an error from the server.
+type rejectingProvider struct {
+	mockProvider
+}
+
+func (p *rejectingProvider) SubmitReview(ctx context.Context, review domain.Review) error {
+	return errors.New("422 Unprocessable Entity: line must be part of the diff")
+}
+
+func TestOutbox_KeepsRejectedReviewForRetry(t *testing.T) {
+	repo := newOfflineRepository()
+	repo.pats["pat-1"] = &domain.PAT{ID: "pat-1", Username: "reviewer", Provider: domain.ProviderGitHub}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.repository = repo
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.statusBar.SetWidth(160)
+	m.providers = map[string]domain.Provider{"pat-1": &rejectingProvider{}}
+	pr := &domain.PullRequest{
+		Number:       1,
+		Author:       domain.User{Username: "author"},
+		Repository:   domain.Repo{FullName: "owner/repo"},
+		PATID:        "pat-1",
+		ProviderType: domain.ProviderGitHub,
+	}
+	m.prInspect.SetPR(pr)
+	m.reviewView.Activate(views.ReviewModeComment)
+	m.reviewView.SetValue("Careful with this loop")
+
+	updated, _ := m.Update(taskResult(m.submitReview()))
+	m = updated.(Model)
+	if len(repo.queued) != 1 || repo.queued[0].RetryWhenOnline || !strings.Contains(repo.queued[0].LastError, "422") {
+		t.Fatalf("expected the rejected review in the outbox, got %+v", repo.queued)
+	}
+	if view := m.statusBar.View(); !strings.Contains(view, ":outbox") {
+		t.Errorf("expected the status to point at the outbox, got %q", view)
+	}
+	if _, cmd := m.noteListData(time.Time{}); cmd != nil {
+		t.Error("expected rejected reviews not to be retried automatically")
+	}
+
+	m, _ = handleOutboxCommand(m, nil)
+	if !m.outboxView.IsActive() || m.outboxView.GetSelected() == nil {
+		t.Fatal("expected the outbox to list the review")
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = updated.(Model)
+	updated, _ = m.Update(taskResult(cmd))
+	m = updated.(Model)
+	if len(repo.queued) != 1 || repo.queued[0].Attempts != 2 {
+		t.Fatalf("expected the failed retry to be counted, got %+v", repo.queued)
+	}
+
+	provider := &mockProvider{}
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	updated, _ = m.Update(taskResult(cmd))
+	m = updated.(Model)
+	if !provider.submitReviewCalled || provider.lastReview.Body != "Careful with this loop" || len(repo.queued) != 0 {
+		t.Errorf("expected the retry to send the review, got %+v (outbox %+v)", provider.lastReview, repo.queued)
+	}
+	if m.outboxView.GetSelected() != nil {
+		t.Error("expected the sent review to leave the outbox view")
+	}
+}
+
+func TestOutbox_DiscardAsksForConfirmation(t *testing.T) {
+	repo := newOfflineRepository()
+	repo.queued = []domain.QueuedReview{{ID: "a", Review: domain.Review{PRIdentifier: "owner/repo/1", Body: "draft"}}}
+	m := createTestModel()
+	m.repository = repo
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m, _ = handleOutboxCommand(m, nil)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = updated.(Model)
+	if len(repo.queued) != 1 || !m.outboxView.IsActive() {
+		t.Fatal("expected the review to be kept when not confirmed")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = updated.(Model)
+	if len(repo.queued) != 0 || m.outboxView.GetSelected() != nil {
+		t.Errorf("expected the review to be discarded, outbox %+v", repo.queued)
+	}
+}
+
+// taskResult runs cmd, which may be batched with the spinner of the task it
+// runs as, and returns its message.
+func taskResult(cmd tea.Cmd) tea.Msg {
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return msg
+	}
+	for _, c := range batch {
+		if c == nil {
+			continue
+		}
+		if msg := c(); msg != nil {
+			if _, isTick := msg.(spinner.TickMsg); !isTick {
+				return msg
+			}
+		}
+	}
+	return nil
+}
+
+func TestTaskTracker_DescribesOldestTask(t *testing.T) {
+	tracker := newTaskTracker()
+	if tracker.active() || tracker.describe() != "" {
+		t.Fatal("expected an empty tracker to be idle")
+	}
+
+	review := tracker.start("Submitting review")
+	diff := tracker.start("Loading diff")
+	if got := tracker.describe(); got != "Submitting review… (+1 more)" {
+		t.Errorf("unexpected description %q", got)
+	}
+
+	review.setProgress(2, 3, "requests")
+	if got := tracker.describe(); got != "Submitting review… 2/3 requests (+1 more)" {
+		t.Errorf("unexpected description %q", got)
+	}
+
+	review.finish()
+	if got := tracker.describe(); got != "Loading diff…" {
+		t.Errorf("unexpected description %q", got)
+	}
+	diff.finish()
+	diff.finish()
+	if tracker.active() {
+		t.Error("expected finished tasks to be gone")
+	}
+
+	var none *taskTracker
+	none.start("Nothing").finish()
+	if none.active() {
+		t.Error("expected a nil tracker to track nothing")
+	}
+}
+
+func TestRunTask_ShowsIndicatorUntilDone(t *testing.T) {
+	m := createTestModel()
+	m.tasks = newTaskTracker()
+	m.spinner = spinner.New()
+	m.statusBar.SetWidth(120)
+	m.statusBar.SetMessage("Ready", false)
+
+	release := make(chan struct{})
+	cmd := m.runTask("Loading diff", func(*task) tea.Msg {
+		<-release
+		return SuccessMsg{message: "done"}
+	})
+	if !strings.Contains(m.statusBar.View(), "Loading diff… │ Ready") {
+		t.Errorf("expected the task ahead of the message, got %q", m.statusBar.View())
+	}
+
+	result := make(chan tea.Msg)
+	go func() { result <- taskResult(cmd) }()
+
+	updated, tick := m.Update(m.spinner.Tick())
+	m = updated.(Model)
+	if tick == nil {
+		t.Error("expected the spinner to keep turning while the task runs")
+	}
+
+	close(release)
+	if _, ok := (<-result).(SuccessMsg); !ok {
+		t.Fatal("expected the task's message")
+	}
+	updated, tick = m.Update(m.spinner.Tick())
+	m = updated.(Model)
+	if tick != nil {
+		t.Error("expected the spinner to stop once nothing is in flight")
+	}
+	if strings.Contains(m.statusBar.View(), "Loading diff") {
+		t.Errorf("expected the indicator to be cleared, got %q", m.statusBar.View())
+	}
+}
+
+func TestPATIdentityResolved_FillsMissingUsername(t *testing.T) {
+	m := createTestModel()
+	m.statusBar.SetWidth(120)
+	repo := m.repository.(*mockRepository)
+
+	pat := domain.PAT{ID: "p1", Name: "Work", Provider: domain.ProviderGitHub, Token: "token"}
+	updated, _ := m.Update(PATIdentityResolvedMsg{pat: pat, identity: domain.Identity{Username: "octocat"}})
+	m = updated.(Model)
+
+	if saved := repo.pats["p1"]; saved == nil || saved.Username != "octocat" {
+		t.Fatalf("expected the PAT to be saved with the detected username, got %+v", saved)
+	}
+	if !strings.Contains(m.statusBar.View(), "PAT added for octocat") {
+		t.Errorf("expected the detected username in the status bar, got %q", m.statusBar.View())
+	}
+}
+
+func TestPATIdentityResolved_WarnsAboutMistypedUsername(t *testing.T) {
+	m := createTestModel()
+	m.statusBar.SetWidth(200)
+	repo := m.repository.(*mockRepository)
+
+	pat := domain.PAT{ID: "p1", Name: "Work", Provider: domain.ProviderAzureDevOps, Token: "token", Username: "jane.deo"}
+	identity := domain.Identity{Username: "Jane Doe", Aliases: []string{"jane.doe"}}
+	updated, _ := m.Update(PATIdentityResolvedMsg{pat: pat, identity: identity})
+	m = updated.(Model)
+
+	if saved := repo.pats["p1"]; saved == nil || saved.Username != "jane.deo" {
+		t.Fatalf("expected the PAT to be saved with the entered username, got %+v", saved)
+	}
+	history := m.statusBar.History()
+	last := history[len(history)-1]
+	if last.Severity != components.SeverityWarning || !strings.Contains(last.Text, "belongs to Jane Doe, not jane.deo") {
+		t.Errorf("expected a warning about the mistyped username, got %+v", last)
+	}
+
+	pat.Username = "JANE.DOE"
+	updated, _ = m.Update(PATIdentityResolvedMsg{pat: pat, identity: identity})
+	m = updated.(Model)
+	history = m.statusBar.History()
+	if last := history[len(history)-1]; last.Severity != components.SeverityInfo || last.Text != "PAT added successfully" {
+		t.Errorf("expected an alias to be accepted, got %+v", last)
+	}
+}
+
+func TestPATIdentityResolved_SavesWhenLookupFails(t *testing.T) {
+	m := createTestModel()
+	repo := m.repository.(*mockRepository)
+
+	pat := domain.PAT{ID: "p1", Name: "Work", Provider: domain.ProviderGitHub, Token: "token"}
+	updated, _ := m.Update(PATIdentityResolvedMsg{pat: pat, err: errors.New("401 Bad credentials")})
+	m = updated.(Model)
+
+	if repo.pats["p1"] == nil {
+		t.Fatal("expected the PAT to be saved even though the lookup failed")
+	}
+	history := m.statusBar.History()
+	if last := history[len(history)-1]; last.Severity != components.SeverityWarning || !strings.Contains(last.Text, "could not be detected") {
+		t.Errorf("expected a warning that the username is missing, got %+v", last)
+	}
+}
+
+func TestPATForm_TestConnectionKey(t *testing.T) {
+	m := createTestModel()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.tasks = newTaskTracker()
+	m.state = ViewPATs
+	m.patsView.EnterEditMode(domain.PAT{ID: "p1", Name: "Work", Provider: domain.ProviderAzureDevOps, Token: "token", OAuth: &domain.OAuthToken{}})
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	m = updated.(Model)
+	if m.patsView.Mode != views.PATModeEdit {
+		t.Fatal("expected the form to stay open while testing")
+	}
+	msg, ok := taskResult(cmd).(PATConnectionTestedMsg)
+	if !ok || msg.err == nil || msg.pat.ID != "p1" {
+		t.Fatalf("expected an unselected device-flow login to fail the test, got %#v", taskResult(cmd))
+	}
+
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	history := m.statusBar.History()
+	if last := history[len(history)-1]; last.Severity != components.SeverityError || !strings.Contains(last.Text, "Connection failed") {
+		t.Errorf("expected the failure in the status bar, got %+v", last)
+	}
+}
+
+// identityProvider is a provider that accepts its credentials but fails to
+// look up who they belong to.
+type identityProvider struct {
+	mockProvider
+	identityErr error
+}
+
+func (p *identityProvider) ResolveIdentity(ctx context.Context) (domain.Identity, error) {
+	return domain.Identity{}, p.identityErr
+}
+
+func TestTestPATConnection_FailsWhenTheTokenIsRejected(t *testing.T) {
+	m := createTestModel()
+	m.ctx = context.Background()
+	pat := domain.PAT{ID: "p1", Name: "Work", Provider: domain.ProviderGitHub, Token: "revoked", Username: "octocat", OAuth: &domain.OAuthToken{}}
+	m.providers = map[string]domain.Provider{"p1": &identityProvider{identityErr: fmt.Errorf("%w: 401 Bad credentials", common.ErrUnauthorized)}}
+
+	msg, ok := taskResult(m.testPATConnection(pat)).(PATConnectionTestedMsg)
+	if !ok || !errors.Is(msg.err, common.ErrUnauthorized) {
+		t.Fatalf("expected the rejected token to fail the test, got %#v", msg)
+	}
+
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	history := m.statusBar.History()
+	if last := history[len(history)-1]; last.Severity != components.SeverityError || !strings.Contains(last.Text, "Connection failed") {
+		t.Errorf("expected the failure in the status bar, got %+v", last)
+	}
+}
+
+func TestPATConnectionTested_ReportsIdentity(t *testing.T) {
+	m := createTestModel()
+
+	identity := &domain.Identity{Username: "octocat"}
+	updated, _ := m.Update(PATConnectionTestedMsg{pat: domain.PAT{Username: "OctoCat"}, identity: identity})
+	m = updated.(Model)
+	history := m.statusBar.History()
+	if last := history[len(history)-1]; last.Severity != components.SeveritySuccess || last.Text != "Connected as octocat" {
+		t.Errorf("expected the connected user, got %+v", last)
+	}
+
+	updated, _ = m.Update(PATConnectionTestedMsg{pat: domain.PAT{Username: "octocta"}, identity: identity})
+	m = updated.(Model)
+	history = m.statusBar.History()
+	if last := history[len(history)-1]; last.Severity != components.SeverityWarning || !strings.Contains(last.Text, "belongs to octocat, not octocta") {
+		t.Errorf("expected a warning about the username, got %+v", last)
+	}
+}
+
+func TestConfigReloaded_AppliesPATsAndSettings(t *testing.T) {
+	m := createTestModel()
+	repo := m.repository.(*mockRepository)
+	repo.pats["synced"] = &domain.PAT{ID: "synced", Name: "Synced", Provider: domain.ProviderGitHub, Token: "token", Username: "octocat", IsSelected: true, IsPrimary: true}
+	repo.pats["other"] = &domain.PAT{ID: "other", Name: "Other", Provider: domain.ProviderGitHub, Token: "token"}
+	repo.settings.Display.WrapDiffLines = true
+
+	reloads := make(chan error, 1)
+	m.configReloads = reloads
+	updated, cmd := m.Update(ConfigReloadedMsg{})
+	m = updated.(Model)
+
+	if m.providers["synced"] == nil || m.primaryPATID != "synced" {
+		t.Errorf("expected the selected PAT's provider to be created, got %v (primary %q)", m.providers, m.primaryPATID)
+	}
+	if !m.prInspect.WrapLines() {
+		t.Error("expected the reloaded display settings to be applied")
+	}
+	if history := m.statusBar.History(); !strings.Contains(history[len(history)-1].Text, "2 PAT(s), 1 selected") {
+		t.Errorf("expected the reload to be reported, got %+v", history[len(history)-1])
+	}
+	if cmd == nil {
+		t.Fatal("expected to keep waiting for reloads and to validate the selected PATs")
+	}
+
+	reloads <- errors.New("unexpected end of JSON input")
+	updated, _ = m.Update(m.waitForConfigReload()())
+	m = updated.(Model)
+	history := m.statusBar.History()
+	if last := history[len(history)-1]; last.Severity != components.SeverityWarning || !strings.Contains(last.Text, "keeping the previous configuration") {
+		t.Errorf("expected a warning for an invalid config, got %+v", last)
+	}
+	if m.providers["synced"] == nil {
+		t.Error("expected the previous PATs to be kept")
+	}
+}
+
+func TestPinKeys_PinPRAndRepository(t *testing.T) {
+	repo := &mockRepository{pats: map[string]*domain.PAT{}}
+	m := createTestModel()
+	m.repository = repo
+	m.state = ViewPRList
+	m.prListView.SetPRs([]domain.PullRequest{
+		{Number: 1, Title: "First", ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}, UpdatedAt: time.Now()},
+		{Number: 2, Title: "Second", ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/web"}, UpdatedAt: time.Now().Add(-time.Hour)},
+	})
+	m.prListView.RestoreCursor(2)
+
+	m, _ = handlePinPRKey(m)
+	if len(repo.pins.PullRequests) != 1 || repo.pins.PullRequests[0] != "github:acme/web/2" {
+		t.Fatalf("expected PR #2 to be pinned, got %+v", repo.pins)
+	}
+	if pr := m.prListView.GetSelectedPR(); pr == nil || pr.Number != 2 || m.prListView.GetCursorIndex() != 1 {
+		t.Errorf("expected the pinned PR to move to the top with the cursor, got %+v", pr)
+	}
+
+	m, _ = handlePinRepositoryKey(m)
+	if len(repo.pins.Repositories) != 1 || repo.pins.Repositories[0] != "github:acme/web" {
+		t.Errorf("expected the repository to be pinned, got %+v", repo.pins)
+	}
+
+	m, _ = handlePinPRKey(m)
+	if len(repo.pins.PullRequests) != 0 {
+		t.Errorf("expected * to unpin the PR, got %+v", repo.pins)
+	}
+	if pr := m.prListView.GetSelectedPR(); pr == nil || pr.Number != 2 {
+		t.Errorf("expected the PR to stay on top through its pinned repository, got %+v", pr)
+	}
+}
+
+func TestDetailedRowsKey_TogglesAndSaves(t *testing.T) {
+	m := createTestModel()
+	repo := m.repository.(*mockRepository)
+	m.state = ViewPRList
+
+	m, _ = handleToggleDetailedRowsKey(m)
+	if !m.prListView.Detailed() || !repo.settings.Display.DetailedRows {
+		t.Fatal("expected v to switch to detailed rows and save it")
+	}
+
+	m, _ = handleToggleDetailedRowsKey(m)
+	if m.prListView.Detailed() || repo.settings.Display.DetailedRows {
+		t.Error("expected v to switch back to compact rows")
+	}
+}
+
+func newTriageTestModel() Model {
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.tasks = newTaskTracker()
+	m.prLoad = newLoadTracker()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.state = ViewPRList
+
+	now := time.Now()
+	pr := func(number int, category domain.PRCategory, created time.Time) domain.PullRequest {
+		return domain.PullRequest{
+			Number:       number,
+			Title:        fmt.Sprintf("PR %d", number),
+			Category:     category,
+			ProviderType: domain.ProviderGitHub,
+			Repository:   domain.Repo{FullName: "acme/api"},
+			CreatedAt:    created,
+			UpdatedAt:    now,
+		}
+	}
+	m.prListView.SetPRs([]domain.PullRequest{
+		pr(1, domain.PRCategoryReviewRequested, now.Add(-2*time.Hour)),
+		pr(2, domain.PRCategoryAuthored, now),
+		pr(3, domain.PRCategoryReviewRequested, now.Add(-time.Hour)),
+		pr(4, domain.PRCategoryReviewRequested, now.Add(-3*time.Hour)),
+	})
+	return m
+}
+
+func pressKey(m Model, key string) (Model, tea.Cmd) {
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	return result.(Model), cmd
+}
+
+func TestTriage_StepsThroughReviewRequestsNewestFirst(t *testing.T) {
+	m := newTriageTestModel()
+
+	m, _ = handleTriageKey(m)
+	if m.state != ViewPRInspect || m.prInspect.GetPR().Number != 3 {
+		t.Fatalf("expected the newest review request to open, got state %v PR %+v", m.state, m.prInspect.GetPR())
+	}
+
+	m, _ = pressKey(m, "a")
+	if m.heldReview == nil || m.heldReview.prKey != "github:acme/api/3" {
+		t.Fatalf("expected a to approve #3 right away, held %+v", m.heldReview)
+	}
+	if m.prInspect.GetPR().Number != 1 {
+		t.Fatalf("expected to move on to #1, got %+v", m.prInspect.GetPR())
+	}
+
+	m, _ = pressKey(m, "r")
+	if !m.reviewView.IsActive() || m.reviewView.GetMode() != views.ReviewModeRequestChanges {
+		t.Fatal("expected r to open the request changes editor")
+	}
+	m.reviewView.SetValue("Please add tests")
+	m, cmd := m.confirmReview()
+	if cmd == nil || m.heldReview == nil || m.heldReview.prKey != "github:acme/api/1" {
+		t.Fatalf("expected the change request to be held and the approval sent, held %+v", m.heldReview)
+	}
+	if m.prInspect.GetPR().Number != 4 {
+		t.Fatalf("expected to move on to #4, got %+v", m.prInspect.GetPR())
+	}
+
+	m, _ = pressKey(m, "s")
+	if m.state != ViewPRList || m.triage != nil {
+		t.Fatalf("expected the triage to end after the last PR, got state %v", m.state)
+	}
+	history := m.statusBar.History()
+	if last := history[len(history)-1].Text; last != "Triage done: 1 approved, 1 changes requested, 1 skipped" {
+		t.Errorf("unexpected summary %q", last)
+	}
+}
+
+func TestTriage_SnoozeAdvancesAndQuitStops(t *testing.T) {
+	m := newTriageTestModel()
+	repo := m.repository.(*mockRepository)
+	m, _ = handleTriageKey(m)
+
+	m, _ = pressKey(m, "z")
+	if !m.snoozeView.IsActive() {
+		t.Fatal("expected z to open the snooze picker")
+	}
+	result, _ := m.snoozeSelectedPR()
+	m = result.(Model)
+	if len(repo.snoozes) != 1 || repo.snoozes[0].PRIdentifier != "github:acme/api/3" {
+		t.Fatalf("expected #3 to be snoozed, got %+v", repo.snoozes)
+	}
+	if m.prInspect.GetPR().Number != 1 {
+		t.Fatalf("expected to move on to #1, got %+v", m.prInspect.GetPR())
+	}
+
+	m, _ = pressKey(m, "q")
+	if m.state != ViewPRList || m.triage != nil {
+		t.Fatalf("expected q to stop the triage, got state %v", m.state)
+	}
+	history := m.statusBar.History()
+	if last := history[len(history)-1].Text; last != "Triage stopped: 1 snoozed" {
+		t.Errorf("unexpected summary %q", last)
+	}
+}
+
+// draftRepository is a mockRepository that also keeps editor drafts.
+type draftRepository struct {
+	*mockRepository
+	drafts map[string]domain.EditorDraft
+}
+
+func (r *draftRepository) SaveDraft(draft domain.EditorDraft) error {
+	if draft.Body == "" {
+		return r.DeleteDraft(draft.Key)
+	}
+	r.drafts[draft.Key] = draft
+	return nil
+}
+
+func (r *draftRepository) GetDraft(key string) (*domain.EditorDraft, error) {
+	if draft, ok := r.drafts[key]; ok {
+		return &draft, nil
+	}
+	return nil, nil
+}
+
+func (r *draftRepository) DeleteDraft(key string) error {
+	delete(r.drafts, key)
+	return nil
+}
+
+func (r *draftRepository) ListDrafts() ([]domain.EditorDraft, error) {
+	drafts := make([]domain.EditorDraft, 0, len(r.drafts))
+	for _, draft := range r.drafts {
+		drafts = append(drafts, draft)
+	}
+	return drafts, nil
+}
+
+func newDraftTestModel() (Model, *draftRepository) {
+	repo := &draftRepository{mockRepository: &mockRepository{pats: map[string]*domain.PAT{}}, drafts: map[string]domain.EditorDraft{}}
+	m := createTestModel()
+	m.repository = repo
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.prInspect.SetSize(120, 40)
+	pr := domain.PullRequest{Number: 7, Repository: domain.Repo{FullName: "owner/repo"}, ProviderType: domain.ProviderGitHub, Category: domain.PRCategoryAuthored}
+	m.prInspect.SetPR(&pr)
+	return m, repo
+}
+
+func TestDrafts_ReviewTextIsAutosavedAndRestored(t *testing.T) {
+	m, repo := newDraftTestModel()
+	key := reviewDraftKey(*m.prInspect.GetPR())
+
+	m, _ = handleApproveKey(m)
+	m, _ = pressKey(m, "Nice")
+	result, _ := m.Update(DraftAutosaveMsg{generation: m.draftGeneration - 1})
+	m = result.(Model)
+	if _, ok := repo.drafts[key]; ok {
+		t.Fatal("expected an outdated autosave to be ignored")
+	}
+	result, _ = m.Update(DraftAutosaveMsg{generation: m.draftGeneration})
+	m = result.(Model)
+	if repo.drafts[key].Body != "Nice" {
+		t.Fatalf("expected the review text to be autosaved, got %+v", repo.drafts)
+	}
+
+	m, _ = pressKey(m, "!")
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.reviewView.IsActive() || repo.drafts[key].Body != "Nice!" {
+		t.Fatalf("expected Esc to close the editor keeping the text, got %+v", repo.drafts)
+	}
+
+	m, _ = handleRequestChangesKey(m)
+	if got := m.reviewView.GetValue(); got != "Nice!" {
+		t.Errorf("expected the draft to be restored, got %q", got)
+	}
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = result.(Model)
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if _, ok := repo.drafts[key]; ok {
+		t.Error("expected submitting the review to discard its draft")
+	}
+}
+
+func TestDrafts_UnchangedDescriptionIsNotKept(t *testing.T) {
+	m, repo := newDraftTestModel()
+	pr := *m.prInspect.GetPR()
+
+	m, _ = handleEditDescriptionKey(m)
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if len(repo.drafts) != 0 {
+		t.Fatalf("expected no draft for an untouched description, got %+v", repo.drafts)
+	}
+
+	repo.drafts[descriptionDraftKey(pr)] = domain.EditorDraft{Key: descriptionDraftKey(pr), Body: "Rewritten"}
+	m, _ = handleEditDescriptionKey(m)
+	if got := m.descriptionEditView.GetValue(); got != "Rewritten" {
+		t.Fatalf("expected the draft to replace the description, got %q", got)
+	}
+
+	result, _ = m.Update(DescriptionUpdateSuccessMsg{description: "Rewritten"})
+	m = result.(Model)
+	if len(repo.drafts) != 0 {
+		t.Errorf("expected the saved description to discard its draft, got %+v", repo.drafts)
+	}
+}
+
+func TestStartedReviews_MarkedInThePRList(t *testing.T) {
+	m, repo := newDraftTestModel()
+	first := *m.prInspect.GetPR()
+	second := domain.PullRequest{Number: 8, Repository: domain.Repo{FullName: "owner/repo"}, ProviderType: domain.ProviderGitHub}
+	third := domain.PullRequest{Number: 9, Repository: domain.Repo{FullName: "owner/repo"}, ProviderType: domain.ProviderGitHub}
+	m.prListView.SetPRs([]domain.PullRequest{first, second, third})
+	repo.drafts[reviewDraftKey(second)] = domain.EditorDraft{Key: reviewDraftKey(second), PRKey: second.Key(), Body: "LGTM"}
+	repo.drafts[descriptionDraftKey(third)] = domain.EditorDraft{Key: descriptionDraftKey(third), PRKey: third.Key(), Body: "Reworded"}
+
+	m.prInspect.SetDiff(&domain.Diff{Files: []domain.FileDiff{{
+		NewPath: "main.go",
+		Hunks:   []domain.DiffHunk{{Lines: []domain.DiffLine{{Type: "add", Content: "+x", NewLine: 1}}}},
+	}}})
+	m.prInspect.AddPendingComment("draft")
+	m.state = ViewPRInspect
+	result, _ := m.navigateBack()
+	m = result.(Model)
+
+	if !m.prListView.HasStartedReview(first) || !m.prListView.HasStartedReview(second) {
+		t.Error("expected the PRs with pending comments or a draft review to be marked")
+	}
+	if m.prListView.HasStartedReview(third) {
+		t.Error("expected a description draft not to count as a started review")
+	}
+
+	m.prInspect.SetPR(&third)
+	if m.prInspect.GetPendingCommentCount() != 0 {
+		t.Fatal("expected the pending comments to stay with their PR")
+	}
+	m.prInspect.SetPR(&first)
+	if m.prInspect.GetPendingCommentCount() != 1 {
+		t.Fatal("expected the pending comments back when their PR is reopened")
+	}
+
+	m.prInspect.ClearPendingComments()
+	m.state = ViewPRInspect
+	result, _ = m.navigateBack()
+	m = result.(Model)
+	if m.prListView.HasStartedReview(first) {
+		t.Error("expected the mark to go once the review is submitted")
+	}
+}
+
+func TestCrashRecovery_OffersTheKeptDrafts(t *testing.T) {
+	m, repo := newDraftTestModel()
+	m.crashRecoveryView.SetSize(120, 40)
+	m.statusBar.SetWidth(200)
+	m.crashReport = "/tmp/crash.log"
+
+	m.offerCrashRecovery()
+	if m.crashRecoveryView.IsActive() {
+		t.Fatal("expected no offer without drafts")
+	}
+	if !strings.Contains(m.statusBar.View(), "/tmp/crash.log") {
+		t.Errorf("expected the crash report to be pointed to, got %q", m.statusBar.View())
+	}
+
+	repo.drafts["a"] = domain.EditorDraft{Key: "a", PRKey: "github:owner/repo/7", Editor: "review", Body: "Nice"}
+	m.offerCrashRecovery()
+	if !m.crashRecoveryView.IsActive() {
+		t.Fatal("expected the kept drafts to be offered")
+	}
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.crashRecoveryView.IsActive() || m.pendingSelection != "github:owner/repo/7" {
+		t.Errorf("expected the draft's PR to be selected once loaded, got %q", m.pendingSelection)
+	}
+}
+
+func TestResize_ShowsANoticeInATooSmallTerminal(t *testing.T) {
+	m := createTestModel()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.state = ViewPRList
+
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 40, Height: 10})
+	m = result.(Model)
+	if view := m.View(); !strings.Contains(view, "Terminal too small") || !strings.Contains(view, "40×10") {
+		t.Errorf("expected the too small notice, got %q", view)
+	}
+
+	result, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = result.(Model)
+	if strings.Contains(m.View(), "Terminal too small") {
+		t.Error("expected the views back once the terminal is large enough")
+	}
+}
+
+func TestResize_LaysOutOpenOverlays(t *testing.T) {
+	m := createTestModel()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	pr := domain.PullRequest{Number: 7, Title: "Add retries", Repository: domain.Repo{FullName: "owner/repo"}}
+	m.mergeView.Activate(&pr, domain.ProviderGitHub, false)
+
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 70, Height: 20})
+	m = result.(Model)
+	result, _ = m.Update(tea.WindowSizeMsg{Width: 140, Height: 50})
+	m = result.(Model)
+
+	if width := lipgloss.Width(m.mergeView.View()); width != 140 {
+		t.Errorf("expected the merge dialog to be laid out for the new width, got %d", width)
+	}
+}
+
+type mockFileDiffLoaderProvider struct {
+	mockProvider
+	files  []domain.FileDiff
+	loaded []string
+}
+
+func (p *mockFileDiffLoaderProvider) ListDiffFiles(ctx context.Context, identifier domain.PRIdentifier) ([]domain.FileDiff, error) {
+	return p.files, nil
+}
+
+func (p *mockFileDiffLoaderProvider) GetFileDiff(ctx context.Context, identifier domain.PRIdentifier, file domain.FileDiff) (*domain.FileDiff, error) {
+	p.loaded = append(p.loaded, file.NewPath)
+	if file.NewPath == "broken.go" {
+		return nil, fmt.Errorf("server error")
+	}
+	file.Deferred = false
+	file.Hunks = []domain.DiffHunk{{Header: "@@ -1 +1 @@", Lines: []domain.DiffLine{{Type: "add", Content: "+package main", NewLine: 1}}}}
+	return &file, nil
+}
+
+func TestLazyDiff_LoadsFilesAsTheyAreNavigatedTo(t *testing.T) {
+	provider := &mockFileDiffLoaderProvider{}
+	for i := 0; i < lazyDiffMaxFiles; i++ {
+		provider.files = append(provider.files, domain.FileDiff{NewPath: fmt.Sprintf("file%d.go", i), Additions: 1, Deferred: true})
+	}
+	provider.files = append(provider.files, domain.FileDiff{NewPath: "broken.go", Additions: 1, Deferred: true})
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	pr := domain.PullRequest{Number: 7, ProviderType: domain.ProviderGitHub, PATID: "pat-1", Repository: domain.Repo{FullName: "acme/api"}}
+	m.prInspect.SetPR(&pr)
+	m.prInspect.SetSize(120, 40)
+	m.prInspect.SwitchToDiff()
+
+	msg, ok := m.loadDiff(pr)().(DiffLoadedMsg)
+	if !ok || len(msg.diff.Files) != lazyDiffMaxFiles+1 || !msg.diff.Files[0].Deferred {
+		t.Fatalf("expected the file list with deferred hunks, got %+v", msg)
+	}
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	if !m.fileDiffLoads["file0.go"] {
+		t.Fatal("expected the hunks of the first file to be requested")
+	}
+	if view := m.prInspect.View(); !strings.Contains(view, "Loading the diff of this file") {
+		t.Errorf("expected a placeholder while the file loads, got %q", view)
+	}
+
+	first, _ := m.prInspect.CurrentFile()
+	updated, _ = m.Update(m.loadFileDiff(pr, first)())
+	m = updated.(Model)
+	if file, _ := m.prInspect.CurrentFile(); file.Deferred || len(file.Hunks) != 1 {
+		t.Fatalf("expected the loaded hunks in the diff, got %+v", file)
+	}
+	updated, _ = m.Update(NotificationsExpiredMsg{})
+	m = updated.(Model)
+	if len(provider.loaded) != 1 {
+		t.Errorf("expected a loaded file not to be requested again, got %q", provider.loaded)
+	}
+
+	for i := 0; i < lazyDiffMaxFiles; i++ {
+		m.prInspect.NextFile()
+	}
+	updated, _ = m.Update(NotificationsExpiredMsg{})
+	m = updated.(Model)
+	if !m.fileDiffLoads["broken.go"] {
+		t.Fatal("expected the file navigated to to be requested")
+	}
+	broken, _ := m.prInspect.CurrentFile()
+	updated, _ = m.Update(m.loadFileDiff(pr, broken)())
+	m = updated.(Model)
+	if view := m.prInspect.View(); !strings.Contains(view, "Could not load the diff of this file: server error") {
+		t.Errorf("expected the load error in place of the diff, got %q", view)
+	}
+}
+
+func TestLazyDiff_SmallDiffsLoadInFull(t *testing.T) {
+	provider := &mockFileDiffLoaderProvider{files: []domain.FileDiff{{NewPath: "main.go", Additions: 10, Deferred: true}}}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	pr := domain.PullRequest{Number: 7, ProviderType: domain.ProviderGitHub, PATID: "pat-1", Repository: domain.Repo{FullName: "acme/api"}}
+
+	if msg, ok := m.loadDiff(pr)().(DiffLoadedMsg); !ok || msg.diff != nil {
+		t.Fatalf("expected the full diff from GetDiff, got %+v", msg)
+	}
+}
+
+type mockCommentPagerProvider struct {
+	mockProvider
+	pages map[string]domain.CommentPage
+}
+
+func (p *mockCommentPagerProvider) GetCommentsPage(ctx context.Context, identifier domain.PRIdentifier, cursor string) (*domain.CommentPage, error) {
+	page, ok := p.pages[cursor]
+	if !ok {
+		return nil, fmt.Errorf("no page %q", cursor)
+	}
+	return &page, nil
+}
+
+func TestComments_StreamInAPageAtATime(t *testing.T) {
+	provider := &mockCommentPagerProvider{pages: map[string]domain.CommentPage{
+		"": {Total: 4, Next: "2", Comments: []domain.Comment{
+			{ID: "1", FilePath: "main.go", Line: 3, Body: "Nit"},
+			{ID: "2", FilePath: "main.go", Line: 3, Body: "Fixed"},
+		}},
+		"2": {Next: "3", Comments: []domain.Comment{{ID: "3", Body: "LGTM"}}},
+		"3": {Comments: []domain.Comment{{ID: "4", FilePath: "util.go", Line: 1, Body: "Why?"}}},
+	}}
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	pr := domain.PullRequest{Number: 7, Title: "Streaming", ProviderType: domain.ProviderGitHub, PATID: "pat-1", Repository: domain.Repo{FullName: "acme/api"}}
+	m.prInspect.SetPR(&pr)
+	m.prInspect.SetSize(120, 40)
+
+	stale, ok := m.loadComments(pr)().(CommentsPageLoadedMsg)
+	if !ok {
+		t.Fatal("expected the first page of comments")
+	}
+	first := m.loadComments(pr)().(CommentsPageLoadedMsg)
+	updated, cmd := m.Update(first)
+	m = updated.(Model)
+	if cmd == nil || len(m.prInspect.GetComments()) != 2 {
+		t.Fatalf("expected the first page shown and the next one requested, got %d comments", len(m.prInspect.GetComments()))
+	}
+	if view := m.prInspect.View(); !strings.Contains(view, "2 of 4 comments loaded, 1 thread(s) so far") {
+		t.Errorf("expected the counts while loading, got %q", view)
+	}
+
+	stale.comments = nil
+	updated, _ = m.Update(stale)
+	m = updated.(Model)
+	if len(m.prInspect.GetComments()) != 2 {
+		t.Error("expected a page of an earlier load to be dropped")
+	}
+
+	second := m.loadMoreComments(first)().(CommentsPageLoadedMsg)
+	updated, _ = m.Update(second)
+	m = updated.(Model)
+	last := m.loadMoreComments(second)().(CommentsPageLoadedMsg)
+	updated, _ = m.Update(last)
+	m = updated.(Model)
+	if len(m.prInspect.GetComments()) != 4 || last.next != "" {
+		t.Fatalf("expected all comments once the last page is in, got %+v", m.prInspect.GetComments())
+	}
+	if view := m.prInspect.View(); !strings.Contains(view, "4 comment(s) in 3 thread(s)") {
+		t.Errorf("expected the final counts, got %q", view)
+	}
+
+	failed := second
+	failed.next = "missing"
+	if msg := m.loadMoreComments(failed)().(CommentsPageLoadedMsg); msg.err == nil || len(msg.comments) != 3 {
+		t.Errorf("expected a failed page to keep the comments loaded so far, got %+v", msg)
+	}
+}
+
+func TestOpenPR_ShowsTheDiffOfAKnownHeadCommitFromMemory(t *testing.T) {
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.prData = newPRDataCache()
+	m.providers = map[string]domain.Provider{"pat-1": &mockProvider{}}
+	pr := domain.PullRequest{Number: 7, ProviderType: domain.ProviderGitHub, PATID: "pat-1", Repository: domain.Repo{FullName: "acme/api"}, HeadSHA: "aaa"}
+	m.prData.putDiff(pr, sessionTestDiff())
+
+	m, _ = m.openPR(pr)
+	if m.memoryHead != "aaa" {
+		t.Fatalf("expected the diff to be shown from memory, got head %q", m.memoryHead)
+	}
+
+	pushed := pr
+	pushed.HeadSHA = "bbb"
+	updated, cmd := m.Update(PRDetailLoadedMsg{pr: &pushed})
+	m = updated.(Model)
+	if cmd == nil || m.memoryHead != "" {
+		t.Error("expected a new head commit to reload the diff and comments")
+	}
+	if m.prData.diff(pr) != nil {
+		t.Error("expected the diff of the old head commit to be dropped")
+	}
+
+	m, _ = m.openPR(pr)
+	updated, _ = m.Update(PRDetailLoadedMsg{pr: &pr})
+	m = updated.(Model)
+	if m.memoryHead != "" {
+		t.Error("expected a PR without cached data to load live")
+	}
+}
+
+type mockFileContentProvider struct {
+	mockProvider
+	contents map[string]string
+}
+
+func (m *mockFileContentProvider) GetFileContent(ctx context.Context, identifier domain.PRIdentifier, path, ref string) (string, error) {
+	return m.contents[path], nil
+}
+
+func TestOutline_ListsTheChangedSymbolsAndJumpsToThem(t *testing.T) {
+	provider := &mockFileContentProvider{contents: map[string]string{
+		"main.go": "package main\n\nfunc Start() {\n\trun()\n}\n\nfunc Stop() {\n\thalt()\n}\n",
+	}}
+	m := createTestModel()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.ctx = context.Background()
+	m.providers = map[string]domain.Provider{"pat-1": provider}
+	m.state = ViewPRInspect
+	pr := domain.PullRequest{Number: 7, Repository: domain.Repo{FullName: "owner/repo"}, PATID: "pat-1", HeadSHA: "abc123", ProviderType: domain.ProviderGitHub}
+	m.prInspect.SetPR(&pr)
+	m.prInspect.SetDiff(&domain.Diff{Files: []domain.FileDiff{{NewPath: "main.go", Hunks: []domain.DiffHunk{{
+		Header: "@@ -7,2 +7,2 @@",
+		Lines: []domain.DiffLine{
+			{Type: "context", Content: "func Stop() {", OldLine: 7, NewLine: 7},
+			{Type: "add", Content: "\thalt()", NewLine: 8},
+			{Type: "delete", Content: "\tpanic()", OldLine: 8},
+		},
+	}}}}})
+
+	m, cmd := handleOutlineKey(m)
+	if !m.outlineView.IsActive() || cmd == nil {
+		t.Fatal("expected the outline to open and read the changed Go files")
+	}
+
+	result, _ := m.Update(taskResult(cmd))
+	m = result.(Model)
+	symbol := m.outlineView.GetSelected()
+	if symbol == nil || symbol.Name != "func Stop" {
+		t.Fatalf("expected the changed function to be listed, got %+v", symbol)
+	}
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if m.outlineView.IsActive() {
+		t.Error("expected enter to close the outline")
+	}
+	if _, line, _ := m.prInspect.Position(); line != symbol.LineIndex {
+		t.Errorf("expected the cursor on line %d, got %d", symbol.LineIndex, line)
+	}
+}
+
+func TestStack_MovesBetweenThePRsOfTheStack(t *testing.T) {
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.mergeView = views.NewMergeView()
+	m.snoozeView = views.NewSnoozeView()
+	m.providers = map[string]domain.Provider{"pat-1": &mockProvider{}}
+	repo := domain.Repo{FullName: "acme/api"}
+	base := domain.PullRequest{Number: 10, Title: "Add models", Repository: repo, ProviderType: domain.ProviderGitHub, PATID: "pat-1", SourceBranch: "models", TargetBranch: "main", Status: domain.PRStatusOpen}
+	top := domain.PullRequest{Number: 11, Title: "Add handlers", Repository: repo, ProviderType: domain.ProviderGitHub, PATID: "pat-1", SourceBranch: "handlers", TargetBranch: "models", Status: domain.PRStatusOpen}
+	m.prListView.SetPRs([]domain.PullRequest{top, base})
+	m.prInspect.SetSize(120, 60)
+
+	m.prInspect.SetPR(&base)
+	m, _ = m.openPR(base)
+	if !strings.Contains(m.prInspect.View(), "Stack (1 of 2)") {
+		t.Fatal("expected the description to show the stack")
+	}
+
+	m, _ = handlePrevStackPRKey(m)
+	if m.prInspect.GetPR().Number != 10 {
+		t.Error("expected to stay on the bottom of the stack")
+	}
+	m, cmd := handleNextStackPRKey(m)
+	if cmd == nil || m.state != ViewPRInspect || m.prInspect.GetPR().Number != 11 {
+		t.Fatalf("expected the PR above to open, got #%d", m.prInspect.GetPR().Number)
+	}
+	if !strings.Contains(m.prInspect.View(), "Stack (2 of 2)") {
+		t.Error("expected the stack to follow the opened PR")
+	}
+}
+
+type mockPolicyProvider struct {
+	mockProvider
+}
+
+func (m *mockPolicyProvider) GetPolicyEvaluations(ctx context.Context, identifier domain.PRIdentifier) ([]domain.PolicyEvaluation, error) {
+	return []domain.PolicyEvaluation{
+		{Name: "Minimum number of reviewers", Status: domain.PolicyApproved, Detail: "2 of 2 approvals"},
+		{Name: "CI", Status: domain.PolicyRejected},
+		{Name: "Nightly", Status: domain.PolicyRunning, Optional: true},
+	}, nil
+}
+
+func TestPolicies_ShownInTheDescriptionOfTheirPR(t *testing.T) {
+	m := createTestModel()
+	m.ctx = context.Background()
+	m.providers = map[string]domain.Provider{"pat-1": &mockPolicyProvider{}}
+	m.prInspect.SetSize(120, 60)
+	pr := domain.PullRequest{Number: 7, Repository: domain.Repo{FullName: "project/repo"}, PATID: "pat-1", ProviderType: domain.ProviderAzureDevOps}
+	m.prInspect.SetPR(&pr)
+
+	result, _ := m.Update(PoliciesLoadedMsg{prKey: "azuredevops:project/other/1", policies: []domain.PolicyEvaluation{{Name: "Other", Status: domain.PolicyRejected}}})
+	m = result.(Model)
+	if strings.Contains(m.prInspect.View(), "Policies") {
+		t.Fatal("expected the policies of another PR to be ignored")
+	}
+
+	result, _ = m.Update(m.loadPolicies(pr)())
+	m = result.(Model)
+	view := m.prInspect.View()
+	for _, want := range []string{"Policies (1/2 required passed)", "2 of 2 approvals", "✗ failed CI", "Nightly (optional)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the description, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestReviewTimer_RemindsAndRecordsTimeSpent(t *testing.T) {
+	m := createTestModel()
+	m.statusBar.SetWidth(160)
+	repo := m.repository.(*mockRepository)
+	pr := domain.PullRequest{Number: 7, ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}}
+
+	if cmd := m.startReviewTimer(pr); cmd != nil || m.reviewTimer != nil {
+		t.Fatal("expected no timer while it is off")
+	}
+
+	repo.settings.Review = domain.ReviewSettings{Timer: true, ReminderMinutes: 30}
+	if cmd := m.startReviewTimer(pr); cmd == nil || m.reviewTimer == nil {
+		t.Fatal("expected the timer to start")
+	}
+	if !contains(m.statusBar.View(), "⏱ 0 min") {
+		t.Errorf("expected the timer in the status bar, got %q", m.statusBar.View())
+	}
+
+	m.reviewTimer.startedAt = time.Now().Add(-31 * time.Minute)
+	if cmd := m.tickReviewTimer(ReviewTimerTickMsg{generation: m.reviewTimerGeneration}); cmd == nil {
+		t.Fatal("expected the timer to keep ticking")
+	}
+	if view := m.statusBar.View(); !contains(view, "reviewing this PR for 31 min") || !contains(view, "⏱ 31 min") {
+		t.Errorf("expected a reminder after 30 minutes, got %q", view)
+	}
+	m.statusBar.ClearMessage()
+	m.tickReviewTimer(ReviewTimerTickMsg{generation: m.reviewTimerGeneration})
+	if contains(m.statusBar.View(), "reviewing this PR") {
+		t.Error("expected a single reminder per interval")
+	}
+
+	stale := m.reviewTimerGeneration
+	m.stopReviewTimer()
+	if cmd := m.tickReviewTimer(ReviewTimerTickMsg{generation: stale}); cmd != nil {
+		t.Error("expected ticks of a stopped timer to be dropped")
+	}
+	if len(repo.activity) != 1 || repo.activity[0].Type != domain.ActivityReviewTime ||
+		repo.activity[0].PRIdentifier != "acme/api/7" || repo.activity[0].TimeInReview < 31*time.Minute {
+		t.Errorf("expected the time spent to be recorded, got %+v", repo.activity)
+	}
+	if contains(m.statusBar.View(), "⏱") {
+		t.Error("expected the timer to leave the status bar")
+	}
+
+	// Reopening the PR carries over the time spent without repeating the
+	// reminder already given.
+	m.startReviewTimer(pr)
+	m.tickReviewTimer(ReviewTimerTickMsg{generation: m.reviewTimerGeneration})
+	if view := m.statusBar.View(); !contains(view, "⏱ 31 min") || contains(view, "reviewing this PR") {
+		t.Errorf("expected the carried over time without a reminder, got %q", view)
+	}
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/muesli/termenv"
+)
+
+// strippedValue replaces the credentials stripped from a bug report, so
+// that it still shows which ones are set. It is the marker the redaction of
+// the whole bundle leaves too.
+const strippedValue = "[REDACTED]"
+
+// bugReportConfig returns the PATs and settings as JSON for a bug report,
+// with their tokens, keys and secrets stripped.
+func (m Model) bugReportConfig() ([]byte, error) {
+	pats, err := m.repository.ListPATs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PATs: %w", err)
+	}
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load settings: %w", err)
+	}
+
+	for i := range pats {
+		pats[i].Token = strip(pats[i].Token)
+		if pats[i].OAuth != nil {
+			oauth := *pats[i].OAuth
+			oauth.RefreshToken = strip(oauth.RefreshToken)
+			pats[i].OAuth = &oauth
+		}
+	}
+	settings.Translation.APIKey = strip(settings.Translation.APIKey)
+	settings.Summary.APIKey = strip(settings.Summary.APIKey)
+	settings.Webhook.Secret = strip(settings.Webhook.Secret)
+	if proxy, err := url.Parse(settings.Network.Proxy); err == nil && proxy.User != nil {
+		proxy.User = url.User("redacted")
+		settings.Network.Proxy = proxy.String()
+	}
+
+	config := struct {
+		Profile  string
+		PATs     []domain.PAT
+		Settings domain.Settings
+	}{PATs: pats, Settings: settings}
+	if store, ok := m.repository.(domain.ProfileStore); ok {
+		config.Profile = store.Profile()
+	}
+	return json.MarshalIndent(config, "", "  ")
+}
+
+func strip(value string) string {
+	if value == "" {
+		return ""
+	}
+	return strippedValue
+}
+
+// terminalDescription describes the terminal as far as rendering goes: its
+// size, color support and the environment variables terminals are told
+// apart by.
+func (m Model) terminalDescription() string {
+	profiles := map[termenv.Profile]string{
+		termenv.TrueColor: "true color",
+		termenv.ANSI256:   "256 colors",
+		termenv.ANSI:      "16 colors",
+		termenv.Ascii:     "no colors",
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Size: %dx%d\n", m.width, m.height)
+	fmt.Fprintf(&b, "Colors: %s\n", profiles[lipgloss.ColorProfile()])
+	for _, name := range []string{"TERM", "COLORTERM", "TERM_PROGRAM", "TERM_PROGRAM_VERSION", "TMUX", "LANG", "LC_ALL", "NO_COLOR"} {
+		if value, ok := os.LookupEnv(name); ok {
+			fmt.Fprintf(&b, "%s=%s\n", name, value)
+		}
+	}
+	return b.String()
+}
@@ -2,14 +2,30 @@ package ui
 
 import (
 	"fmt"
+	"hash/fnv"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/atotto/clipboard"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/johanforsgren/lgtmfaster/internal/auth"
+	"github.com/johanforsgren/lgtmfaster/internal/crash"
 	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/hooks"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/network"
+	"github.com/johanforsgren/lgtmfaster/internal/outline"
+	"github.com/johanforsgren/lgtmfaster/internal/provider/plugin"
+	"github.com/johanforsgren/lgtmfaster/internal/spell"
+	"github.com/johanforsgren/lgtmfaster/internal/summarize"
+	"github.com/johanforsgren/lgtmfaster/internal/translate"
 	"github.com/johanforsgren/lgtmfaster/internal/ui/views"
+	"github.com/johanforsgren/lgtmfaster/internal/version"
 )
 
 type CommandHandler func(Model, []string) (Model, tea.Cmd)
@@ -66,14 +82,188 @@ func (cr *CommandRegistry) registerCommands() {
 			Handler:     handlePRCommand,
 			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
 		},
+		{
+			Name:        "login",
+			Aliases:     []string{"oauth"},
+			Description: "Log in with the OAuth device flow instead of a PAT",
+			ShortHelp:   ":login",
+			Handler:     handleLoginCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "search-prs",
+			Aliases:     []string{"sp"},
+			Description: "Search pull requests across the organization",
+			ShortHelp:   ":sp",
+			Handler:     handleSearchPRsCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "search",
+			Description: "Search the titles, descriptions and comments of your pull requests",
+			ShortHelp:   ":search",
+			Handler:     handleSearchCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "team",
+			Description: "Show the open PRs of your teammates, grouped by author",
+			ShortHelp:   ":team",
+			Handler:     handleTeamCommand,
+			AvailableIn: []ViewState{ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "repo",
+			Aliases:     []string{"browse"},
+			Description: "List the open PRs of a repository, not just the ones involving you",
+			ShortHelp:   ":repo",
+			Handler:     handleRepoCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "open",
+			Aliases:     []string{"o", "goto"},
+			Description: "Open a pull request by URL or owner/repo/123",
+			ShortHelp:   ":open",
+			Handler:     handleOpenCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "diff-shading",
+			Aliases:     []string{"shade"},
+			Description: "Toggle background shading of added/deleted diff lines",
+			ShortHelp:   ":shade",
+			Handler:     handleDiffShadingCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "plain",
+			Aliases:     []string{"screen-reader"},
+			Description: "Toggle the plain text diff for screen readers",
+			ShortHelp:   ":plain",
+			Handler:     handlePlainDiffCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "triage",
+			Description: "Step through the PRs waiting for your review, newest first",
+			ShortHelp:   ":triage",
+			Handler:     handleTriageCommand,
+			AvailableIn: []ViewState{ViewPRList},
+		},
+		{
+			Name:        "drafts",
+			Description: "Show, hide or set apart draft PRs: :drafts [show|hide|section]",
+			ShortHelp:   ":drafts",
+			Handler:     handleDraftsCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "spell",
+			Aliases:     []string{"spellcheck"},
+			Description: "Toggle spell-checking in the review and inline comment editors",
+			ShortHelp:   ":spell",
+			Handler:     handleSpellCheckCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "timer",
+			Description: "Toggle the review timer and its reminders for the PRs you open",
+			ShortHelp:   ":timer",
+			Handler:     handleTimerCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "plugins",
+			Description: "List the provider plugins in ~/.lgtmfaster/plugins",
+			ShortHelp:   ":plugins",
+			Handler:     handlePluginsCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList},
+		},
+		{
+			Name:        "outbox",
+			Description: "List reviews that failed to send, to retry or discard them",
+			ShortHelp:   ":outbox",
+			Handler:     handleOutboxCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "summary",
+			Aliases:     []string{"summarize"},
+			Description: "Summarize the changes of the open PR",
+			ShortHelp:   ":summary",
+			Handler:     handleSummaryCommand,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Name:        "checks",
+			Aliases:     []string{"ci"},
+			Description: "Show the output of the open PR's failing checks",
+			ShortHelp:   ":checks",
+			Handler:     handleChecksCommand,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
 		{
 			Name:        "logs",
 			Aliases:     []string{"log"},
-			Description: "View session logs",
+			Description: "View session logs, or write them to a file with :logs export [path]",
 			ShortHelp:   ":logs",
 			Handler:     handleLogsCommand,
 			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
 		},
+		{
+			Name:        "bugreport",
+			Description: "Bundle redacted logs, config, version and terminal info for an issue: :bugreport [path]",
+			ShortHelp:   ":bugreport",
+			Handler:     handleBugReportCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "profile",
+			Aliases:     []string{"profiles"},
+			Description: "List profiles, or switch to (or create) one with :profile <name>",
+			ShortHelp:   ":profile",
+			Handler:     handleProfileCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "messages",
+			Aliases:     []string{"msgs"},
+			Description: "Show the status messages of this session",
+			ShortHelp:   ":messages",
+			Handler:     handleMessagesCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "debug",
+			Description: "Capture HTTP traffic with credentials redacted: :debug http on [path] | off",
+			ShortHelp:   ":debug",
+			Handler:     handleDebugCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "stats",
+			Aliases:     []string{"statistics"},
+			Description: "View review statistics",
+			ShortHelp:   ":stats",
+			Handler:     handleStatsCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "perf",
+			Description: "Show the latency of the API calls made per endpoint",
+			ShortHelp:   ":perf",
+			Handler:     handlePerfCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
+		{
+			Name:        "changelog",
+			Aliases:     []string{"whatsnew"},
+			Description: "Show the changelog of an available update",
+			ShortHelp:   ":changelog",
+			Handler:     handleChangelogCommand,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
 		{
 			Name:        "merge",
 			Aliases:     []string{"m"},
@@ -180,6 +370,69 @@ func (cr *CommandRegistry) registerKeyBindings() {
 			Handler:     handleRefreshKey,
 			AvailableIn: []ViewState{ViewPRList},
 		},
+		{
+			Keys:        []string{"R"},
+			Description: "Mark all PRs read",
+			ShortHelp:   "R",
+			Handler:     handleMarkAllReadKey,
+			AvailableIn: []ViewState{ViewPRList},
+		},
+		{
+			Keys:        []string{"z"},
+			Description: "Snooze/wake PR",
+			ShortHelp:   "z",
+			Handler:     handleSnoozeKey,
+			AvailableIn: []ViewState{ViewPRList},
+		},
+		{
+			Keys:        []string{"Z"},
+			Description: "Show/hide snoozed PRs",
+			ShortHelp:   "Z",
+			Handler:     handleToggleSnoozedKey,
+			AvailableIn: []ViewState{ViewPRList},
+		},
+		{
+			Keys:        []string{"B"},
+			Description: "Dependency updates",
+			ShortHelp:   "B",
+			Handler:     handleDependenciesKey,
+			AvailableIn: []ViewState{ViewPRList},
+		},
+		{
+			Keys:        []string{"T"},
+			Description: "Triage PRs waiting for review",
+			ShortHelp:   "T",
+			Handler:     handleTriageKey,
+			AvailableIn: []ViewState{ViewPRList},
+		},
+		{
+			Keys:        []string{"*"},
+			Description: "Pin/unpin PR",
+			ShortHelp:   "*",
+			Handler:     handlePinPRKey,
+			AvailableIn: []ViewState{ViewPRList},
+		},
+		{
+			Keys:        []string{"P"},
+			Description: "Pin/unpin repository",
+			ShortHelp:   "P",
+			Handler:     handlePinRepositoryKey,
+			AvailableIn: []ViewState{ViewPRList},
+		},
+		{
+			Keys:        []string{"v"},
+			Description: "Compact/detailed rows",
+			ShortHelp:   "v",
+			Handler:     handleToggleDetailedRowsKey,
+			AvailableIn: []ViewState{ViewPRList},
+		},
+		{
+			Keys:        []string{"D"},
+			Description: "Expand/collapse drafts",
+			ShortHelp:   "D",
+			Handler:     handleToggleDraftsKey,
+			AvailableIn: []ViewState{ViewPRList},
+		},
 		{
 			Keys:        []string{"/"},
 			Description: "Filter",
@@ -222,6 +475,27 @@ func (cr *CommandRegistry) registerKeyBindings() {
 			Handler:     handleRequestChangesKey,
 			AvailableIn: []ViewState{ViewPRInspect},
 		},
+		{
+			Keys:        []string{"R"},
+			Description: "Refresh PR",
+			ShortHelp:   "R",
+			Handler:     handleRefreshPRKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"o"},
+			Description: "Outline the changed functions and types",
+			ShortHelp:   "o",
+			Handler:     handleOutlineKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"O"},
+			Description: "Only show the files you own",
+			ShortHelp:   "O",
+			Handler:     handleOwnedFilesKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
 		{
 			Keys:        []string{"d"},
 			Description: "View diff",
@@ -250,6 +524,104 @@ func (cr *CommandRegistry) registerKeyBindings() {
 			Handler:     handleToggleDiffViewKey,
 			AvailableIn: []ViewState{ViewPRInspect},
 		},
+		{
+			Keys:        []string{"x"},
+			Description: "Expand/collapse comments on line",
+			ShortHelp:   "x",
+			Handler:     handleToggleThreadKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"g c"},
+			Description: "Open comment thread of line",
+			ShortHelp:   "gc",
+			Handler:     handleOpenLineThreadKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"] c"},
+			Description: "Next hunk",
+			ShortHelp:   "]c",
+			Handler:     handleNextHunkKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"[ c"},
+			Description: "Previous hunk",
+			ShortHelp:   "[c",
+			Handler:     handlePrevHunkKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"] n"},
+			Description: "Next commented line",
+			ShortHelp:   "]n",
+			Handler:     handleNextCommentedLineKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"[ n"},
+			Description: "Previous commented line",
+			ShortHelp:   "[n",
+			Handler:     handlePrevCommentedLineKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"] a"},
+			Description: "Next diff hook finding",
+			ShortHelp:   "]a",
+			Handler:     handleNextAnnotationKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"[ a"},
+			Description: "Previous diff hook finding",
+			ShortHelp:   "[a",
+			Handler:     handlePrevAnnotationKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"] s"},
+			Description: "Next PR up the stack",
+			ShortHelp:   "]s",
+			Handler:     handleNextStackPRKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"[ s"},
+			Description: "Previous PR down the stack",
+			ShortHelp:   "[s",
+			Handler:     handlePrevStackPRKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"A"},
+			Description: "Run diff hooks on current file",
+			ShortHelp:   "A",
+			Handler:     handleAnalyzeFileKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"] p"},
+			Description: "Next pending comment",
+			ShortHelp:   "]p",
+			Handler:     handleNextPendingCommentKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"[ p"},
+			Description: "Previous pending comment",
+			ShortHelp:   "[p",
+			Handler:     handlePrevPendingCommentKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"D"},
+			Description: "Delete pending comment",
+			ShortHelp:   "D",
+			Handler:     handleDeletePendingCommentKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
 		{
 			Keys:        []string{"y"},
 			Description: "Yank current file diff",
@@ -264,25 +636,67 @@ func (cr *CommandRegistry) registerKeyBindings() {
 			Handler:     handleYankAllFilesKey,
 			AvailableIn: []ViewState{ViewPRInspect},
 		},
+		{
+			Keys:        []string{"ctrl+y"},
+			Description: "Yank added lines of current hunk",
+			ShortHelp:   "ctrl+y",
+			Handler:     handleYankHunkAddedLinesKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"alt+y"},
+			Description: "Yank added lines of current file",
+			ShortHelp:   "alt+y",
+			Handler:     handleYankFileAddedLinesKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
 		{
 			Keys:        []string{"e"},
-			Description: "Edit PR description",
+			Description: "Edit PR description (pending comment in diff)",
 			ShortHelp:   "e",
 			Handler:     handleEditDescriptionKey,
 			AvailableIn: []ViewState{ViewPRInspect},
 		},
+		{
+			Keys:        []string{"t"},
+			Description: "Translate",
+			ShortHelp:   "t",
+			Handler:     handleTranslateKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"S"},
+			Description: "Summarize changes",
+			ShortHelp:   "S",
+			Handler:     handleSummaryKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"C"},
+			Description: "Show why checks fail",
+			ShortHelp:   "C",
+			Handler:     handleChecksKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
 		{
 			Keys:        []string{"left"},
-			Description: "Previous file",
+			Description: "Scroll diff left",
 			ShortHelp:   "",
-			Handler:     handlePrevFileKey,
+			Handler:     handleScrollLeftKey,
 			AvailableIn: []ViewState{ViewPRInspect},
 		},
 		{
 			Keys:        []string{"right"},
-			Description: "Next file",
+			Description: "Scroll diff right",
 			ShortHelp:   "",
-			Handler:     handleNextFileKey,
+			Handler:     handleScrollRightKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"w"},
+			Description: "Toggle line wrap in diff",
+			ShortHelp:   "w",
+			Handler:     handleToggleWrapKey,
 			AvailableIn: []ViewState{ViewPRInspect},
 		},
 		{
@@ -306,6 +720,20 @@ func (cr *CommandRegistry) registerKeyBindings() {
 			Handler:     handleEscKey,
 			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
 		},
+		{
+			Keys:        []string{"v"},
+			Description: "Reviewers",
+			ShortHelp:   "v",
+			Handler:     handleReviewersKey,
+			AvailableIn: []ViewState{ViewPRInspect},
+		},
+		{
+			Keys:        []string{"u"},
+			Description: "Undo review submission",
+			ShortHelp:   "",
+			Handler:     handleUndoReviewKey,
+			AvailableIn: []ViewState{ViewPATs, ViewPRList, ViewPRInspect},
+		},
 		{
 			Keys:        []string{"ctrl+o"},
 			Description: "Open PR in browser",
@@ -341,19 +769,61 @@ func (cr *CommandRegistry) ExecuteCommand(m Model, cmdName string, args []string
 	return cmd.Handler(m, args)
 }
 
+// HandleKey runs the binding for key in the current view. Bindings may list
+// space separated key sequences such as "g c"; a key that starts a sequence is
+// held in m.pendingKeys until the sequence completes or is broken.
 func (cr *CommandRegistry) HandleKey(m Model, key string) (Model, tea.Cmd, bool) {
+	if m.pendingKeys != "" {
+		sequence := m.pendingKeys + " " + key
+		m.pendingKeys = ""
+		if kb := cr.findKeyBinding(m.state, sequence); kb != nil {
+			newModel, cmd := kb.Handler(m)
+			return newModel, cmd, true
+		}
+		if cr.isKeyPrefix(m.state, sequence) {
+			m.pendingKeys = sequence
+			return m, nil, true
+		}
+	}
+
+	if kb := cr.findKeyBinding(m.state, key); kb != nil {
+		newModel, cmd := kb.Handler(m)
+		return newModel, cmd, true
+	}
+	if cr.isKeyPrefix(m.state, key) {
+		m.pendingKeys = key
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+func (cr *CommandRegistry) findKeyBinding(state ViewState, key string) *KeyBinding {
 	for _, kb := range cr.keyBindings {
-		if !isInViews(m.state, kb.AvailableIn) {
+		if !isInViews(state, kb.AvailableIn) {
 			continue
 		}
 		for _, k := range kb.Keys {
 			if k == key {
-				newModel, cmd := kb.Handler(m)
-				return newModel, cmd, true
+				return kb
 			}
 		}
 	}
-	return m, nil, false
+	return nil
+}
+
+// isKeyPrefix reports whether keys start a longer key sequence.
+func (cr *CommandRegistry) isKeyPrefix(state ViewState, keys string) bool {
+	for _, kb := range cr.keyBindings {
+		if !isInViews(state, kb.AvailableIn) {
+			continue
+		}
+		for _, k := range kb.Keys {
+			if strings.HasPrefix(k, keys+" ") {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 func (cr *CommandRegistry) GenerateHelpText() string {
@@ -438,12 +908,74 @@ func handlePATsCommand(m Model, args []string) (Model, tea.Cmd) {
 	m.topBar.SetView("PATs")
 	m.topBar.SetContext("", "")
 	m.topBar.SetStats(0, 0)
-	m.topBar.SetPRBreakdown(0, 0, 0)
+	m.topBar.SetPRBreakdown(0, 0, 0, 0)
 	m.updateShortcuts()
 	m.statusBar.SetMessage("Showing PATs", false)
 	return m, m.loadPATs()
 }
 
+// handleProfileCommand lists the profiles, or switches to another one and
+// shows its PRs, or its PATs when none are selected.
+func handleProfileCommand(m Model, args []string) (Model, tea.Cmd) {
+	store, ok := m.repository.(domain.ProfileStore)
+	if !ok {
+		m.statusBar.SetMessage("Profiles are not supported by this storage", true)
+		return m, nil
+	}
+
+	if len(args) == 0 {
+		profiles, err := store.ListProfiles()
+		if err != nil {
+			m.statusBar.SetMessage(fmt.Sprintf("Failed to list profiles: %v", err), true)
+			return m, nil
+		}
+		for i, profile := range profiles {
+			if profile == store.Profile() {
+				profiles[i] = "[" + profile + "]"
+			}
+		}
+		m.statusBar.SetMessage(fmt.Sprintf("Profiles: %s (:profile <name> to switch or create)", strings.Join(profiles, ", ")), false)
+		return m, nil
+	}
+
+	name := args[0]
+	if name == store.Profile() {
+		m.statusBar.SetMessage(fmt.Sprintf("Already using profile %s", name), false)
+		return m, nil
+	}
+	if err := store.SwitchProfile(name); err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to switch profile: %v", err), true)
+		return m, nil
+	}
+
+	m.prCache = nil
+	m.invalidPATs = nil
+	m.prListView.SetPRGroups(nil)
+	m.topBar.SetProfile(name)
+	m.topBar.SetActivePAT("", "")
+	m.topBar.SetContext("", "")
+	m.topBar.SetStats(0, 0)
+	m.topBar.SetPRBreakdown(0, 0, 0, 0)
+
+	m, summary, cmd, err := m.applyConfig()
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Switched to profile %s but failed to load its PATs: %v", name, err), true)
+		return m, nil
+	}
+
+	if cmd == nil {
+		m.state = ViewPATs
+		m.topBar.SetView("PATs")
+	} else {
+		m.state = ViewPRList
+		m.topBar.SetView("PRs")
+	}
+	m.updateShortcuts()
+	m.statusBar.SetMessage(fmt.Sprintf("Switched to profile %s: %s", name, summary), false)
+	m.notifyConfigRecovery()
+	return m, cmd
+}
+
 func handlePRCommand(m Model, args []string) (Model, tea.Cmd) {
 	if len(m.providers) == 0 && m.provider == nil {
 		m.statusBar.SetMessage("No active PAT. Please select a PAT first.", true)
@@ -452,61 +984,460 @@ func handlePRCommand(m Model, args []string) (Model, tea.Cmd) {
 	return m, m.loadPRsWithCache()
 }
 
-func handleLogsCommand(m Model, args []string) (Model, tea.Cmd) {
-	m.logsView.Activate()
-	return m, nil
-}
+func handleLoginCommand(m Model, args []string) (Model, tea.Cmd) {
+	if len(args) == 0 {
+		m.statusBar.SetMessage("Usage: :login github | :login azuredevops <organization>", true)
+		return m, nil
+	}
 
-func handleQuitCommand(m Model, args []string) (Model, tea.Cmd) {
-	return m, tea.Quit
-}
+	provider := domain.ProviderType(strings.ToLower(args[0]))
+	organization := ""
+	if provider == domain.ProviderAzureDevOps {
+		if len(args) < 2 {
+			m.statusBar.SetMessage("Usage: :login azuredevops <organization>", true)
+			return m, nil
+		}
+		organization = args[1]
+	}
 
-func handleQuitKey(m Model) (Model, tea.Cmd) {
-	if m.state == ViewPATs {
-		return m, tea.Quit
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to load settings: %v", err), true)
+		return m, nil
 	}
 
-	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
-		m.prInspect.SwitchToDescription()
-		m.topBar.SetView("PR Description")
-		m.updateShortcuts()
+	login, err := auth.NewDeviceLogin(provider, settings.OAuth)
+	if err != nil {
+		m.statusBar.SetMessage(err.Error(), true)
 		return m, nil
 	}
 
-	newModel, cmd := m.navigateBack()
-	return newModel.(Model), cmd
+	m.statusBar.SetMessage("Requesting device code...", false)
+	return m, m.startDeviceLogin(login, organization)
 }
 
-func handleSpaceKey(m Model) (Model, tea.Cmd) {
-	if m.state == ViewPATs {
-		newModel, cmd := m.handlePATSpaceToggle()
-		return newModel.(Model), cmd
+func handleSearchPRsCommand(m Model, args []string) (Model, tea.Cmd) {
+	query := strings.TrimSpace(strings.Join(args, " "))
+	if query == "" {
+		m.statusBar.SetMessage("Usage: :search-prs <query>", true)
+		return m, nil
 	}
-	return m, nil
+	if len(m.providers) == 0 {
+		m.statusBar.SetMessage("No active PAT. Please select a PAT first.", true)
+		return m, nil
+	}
+	m.statusBar.SetMessage(fmt.Sprintf("Searching pull requests for %q...", query), false)
+	return m, m.searchPRs(query, false)
 }
 
-func handleEnterKey(m Model) (Model, tea.Cmd) {
-	switch m.state {
-	case ViewPATs:
-		newModel, cmd := m.handlePATEnter()
+func handleSearchCommand(m Model, args []string) (Model, tea.Cmd) {
+	text := strings.TrimSpace(strings.Join(args, " "))
+	if text == "" {
+		m.statusBar.SetMessage("Usage: :search <text>", true)
+		return m, nil
+	}
+	if len(m.providers) == 0 {
+		m.statusBar.SetMessage("No active PAT. Please select a PAT first.", true)
+		return m, nil
+	}
+	m.statusBar.SetMessage(fmt.Sprintf("Searching your pull requests for %q...", text), false)
+	return m, m.searchPRs(text, true)
+}
+
+// handleTeamCommand opens the team board for the teammates given, or for
+// those configured in settings.Team.Members.
+func handleTeamCommand(m Model, args []string) (Model, tea.Cmd) {
+	members := args
+	if len(members) == 0 {
+		if settings, err := m.repository.GetSettings(); err == nil {
+			members = settings.Team.Members
+		}
+	}
+	if len(members) == 0 {
+		m.statusBar.SetMessage("No teammates configured: add their usernames to settings.Team.Members, or use :team <username>...", true)
+		return m, nil
+	}
+	if len(m.providers) == 0 {
+		m.statusBar.SetMessage("No active PAT. Please select a PAT first.", true)
+		return m, nil
+	}
+	return m.showTeamBoard(members)
+}
+
+// handleRepoCommand lists the open PRs of the repository given, or opens the
+// repo picker without one.
+func handleRepoCommand(m Model, args []string) (Model, tea.Cmd) {
+	if len(m.providers) == 0 {
+		m.statusBar.SetMessage("No active PAT. Please select a PAT first.", true)
+		return m, nil
+	}
+	if repository := strings.TrimSpace(strings.Join(args, " ")); repository != "" {
+		model, cmd := m.browseRepository(views.RepoChoice{Repository: repository})
+		return model.(Model), cmd
+	}
+	return m, m.repoPickerView.Activate(m.repoChoices())
+}
+
+// browseRepository lists the open PRs of the chosen repository.
+func (m Model) browseRepository(choice views.RepoChoice) (tea.Model, tea.Cmd) {
+	m.statusBar.SetMessage(fmt.Sprintf("Listing the open pull requests of %s...", choice.Repository), false)
+	return m, m.listRepositoryPRs(choice)
+}
+
+// repoChoices offers the repositories of the listed PRs, the pinned
+// repositories and those configured for PATs without a token, sorted by
+// name.
+func (m Model) repoChoices() []views.RepoChoice {
+	var choices []views.RepoChoice
+	seen := make(map[string]bool)
+	add := func(choice views.RepoChoice) {
+		key := string(choice.Provider) + ":" + choice.Repository
+		if choice.Repository == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		choices = append(choices, choice)
+	}
+
+	for _, pr := range m.prListView.ListedPRs() {
+		add(views.RepoChoice{Repository: pr.Repository.FullName, Provider: pr.ProviderType, PATID: pr.PATID})
+	}
+	for _, key := range m.prListView.Pins().Repositories {
+		if provider, repository, ok := strings.Cut(key, ":"); ok {
+			add(views.RepoChoice{Repository: repository, Provider: domain.ProviderType(provider)})
+		}
+	}
+	if m.repository != nil {
+		if pats, err := m.repository.GetSelectedPATs(); err == nil {
+			for _, pat := range pats {
+				for _, repository := range pat.Repositories {
+					add(views.RepoChoice{Repository: repository, Provider: pat.Provider, PATID: pat.ID})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(choices, func(i, j int) bool {
+		return strings.ToLower(choices[i].Repository) < strings.ToLower(choices[j].Repository)
+	})
+	return choices
+}
+
+func handleOpenCommand(m Model, args []string) (Model, tea.Cmd) {
+	if len(args) != 1 {
+		m.statusBar.SetMessage("Usage: :open <pull request URL | owner/repo/123>", true)
+		return m, nil
+	}
+	ref, err := domain.ParsePRReference(args[0])
+	if err != nil {
+		m.statusBar.SetMessage(err.Error(), true)
+		return m, nil
+	}
+	if len(m.providers) == 0 {
+		m.statusBar.SetMessage("No active PAT. Please select a PAT first.", true)
+		return m, nil
+	}
+	return m, m.withTask(fmt.Sprintf("Opening %s#%d", ref.Repository, ref.Number), m.resolvePRReference(ref))
+}
+
+func handleDiffShadingCommand(m Model, args []string) (Model, tea.Cmd) {
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to load settings: %v", err), true)
+		return m, nil
+	}
+
+	settings.Display.DiffBackground = !settings.Display.DiffBackground
+	if err := m.repository.SaveSettings(settings); err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to save settings: %v", err), true)
+		return m, nil
+	}
+	m.prInspect.SetDiffShading(settings.Display.DiffBackground)
+
+	switch {
+	case !settings.Display.DiffBackground:
+		m.statusBar.SetMessage("Diff background shading: off", false)
+	case !views.DiffShadingSupported():
+		m.statusBar.SetMessage("Diff background shading: on (not shown, terminal has too few colors)", false)
+	default:
+		m.statusBar.SetMessage("Diff background shading: on", false)
+	}
+	return m, nil
+}
+
+// handlePlainDiffCommand switches diffs between the colored rendering and
+// labeled plain text for screen readers.
+func handlePlainDiffCommand(m Model, args []string) (Model, tea.Cmd) {
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to load settings: %v", err), true)
+		return m, nil
+	}
+
+	settings.Display.PlainDiff = !settings.Display.PlainDiff
+	if err := m.repository.SaveSettings(settings); err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to save settings: %v", err), true)
+		return m, nil
+	}
+	m.prInspect.SetPlain(settings.Display.PlainDiff)
+
+	if settings.Display.PlainDiff {
+		m.statusBar.SetMessage("Plain text diff: on", false)
+	} else {
+		m.statusBar.SetMessage("Plain text diff: off", false)
+	}
+	return m, nil
+}
+
+// handleDraftsCommand sets how draft PRs are listed, or moves on to the next
+// of show, section and hide without an argument.
+func handleDraftsCommand(m Model, args []string) (Model, tea.Cmd) {
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to load settings: %v", err), true)
+		return m, nil
+	}
+
+	var drafts domain.DraftDisplay
+	if len(args) == 0 {
+		switch settings.Display.Drafts {
+		case domain.DraftsShown:
+			drafts = domain.DraftsSection
+		case domain.DraftsSection:
+			drafts = domain.DraftsHidden
+		default:
+			drafts = domain.DraftsShown
+		}
+	} else {
+		var ok bool
+		if drafts, ok = domain.ParseDraftDisplay(args[0]); !ok {
+			m.statusBar.SetMessage(fmt.Sprintf("Unknown drafts setting %q, use show, hide or section", args[0]), true)
+			return m, nil
+		}
+	}
+
+	settings.Display.Drafts = drafts
+	if err := m.repository.SaveSettings(settings); err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to save settings: %v", err), true)
+		return m, nil
+	}
+	m.prListView.SetDraftDisplay(drafts)
+
+	switch drafts {
+	case domain.DraftsHidden:
+		m.statusBar.SetMessage(fmt.Sprintf("Draft PRs: hidden (%d in the list)", m.prListView.DraftCount()), false)
+	case domain.DraftsSection:
+		m.statusBar.SetMessage("Draft PRs: in a collapsed section at the end of the list (D or enter to expand)", false)
+	default:
+		m.statusBar.SetMessage("Draft PRs: shown with the other PRs", false)
+	}
+	return m, nil
+}
+
+func handleLogsCommand(m Model, args []string) (Model, tea.Cmd) {
+	if len(args) > 0 && args[0] == "export" {
+		return exportLogs(m, strings.Join(args[1:], " "))
+	}
+	m.logsView.Activate()
+	return m, logsTick(m.logsView.Generation())
+}
+
+// exportLogs writes the session logs to path, by default a timestamped file
+// in ~/.lgtmfaster/logs, so that they can be attached to a bug report.
+func exportLogs(m Model, path string) (Model, tea.Cmd) {
+	if path == "" {
+		var err error
+		if path, err = debugFilePath("lgtmfaster", ".log"); err != nil {
+			m.statusBar.SetMessage(fmt.Sprintf("Failed to export logs: %v", err), true)
+			return m, nil
+		}
+	}
+	if err := logger.Export(path); err != nil {
+		logger.LogError("LOGS_EXPORT", path, err)
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to export logs: %v", err), true)
+		return m, nil
+	}
+	logger.LogFileWrite(path)
+	m.statusBar.SetMessage("Exported logs to "+path, false)
+	return m, nil
+}
+
+// debugFilePath returns a timestamped file in ~/.lgtmfaster/logs for logs,
+// HTTP captures and bug reports.
+func debugFilePath(prefix, ext string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".lgtmfaster", "logs", prefix+"-"+time.Now().Format("20060102-150405")+ext), nil
+}
+
+// handleBugReportCommand bundles the diagnostics for an issue into a
+// tarball, by default in ~/.lgtmfaster/logs. Nothing is sent anywhere.
+func handleBugReportCommand(m Model, args []string) (Model, tea.Cmd) {
+	path := strings.Join(args, " ")
+	if path == "" {
+		var err error
+		if path, err = debugFilePath("bugreport", ".tar.gz"); err != nil {
+			m.statusBar.SetMessage(fmt.Sprintf("Failed to write bug report: %v", err), true)
+			return m, nil
+		}
+	}
+
+	config, err := m.bugReportConfig()
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to write bug report: %v", err), true)
+		return m, nil
+	}
+	report := crash.BugReport{
+		Config:   config,
+		Terminal: m.terminalDescription(),
+		Secrets:  m.captureSecrets(),
+	}
+	if err := crash.WriteBugReport(path, report); err != nil {
+		logger.LogError("BUGREPORT", path, err)
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to write bug report: %v", err), true)
+		return m, nil
+	}
+	logger.LogFileWrite(path)
+	m.statusBar.SetMessage("Wrote bug report to "+path+"; check it before attaching it to an issue", false)
+	return m, nil
+}
+
+func handleDebugCommand(m Model, args []string) (Model, tea.Cmd) {
+	if len(args) == 0 || args[0] != "http" {
+		m.statusBar.SetMessage("Usage: :debug http [on [path] | off]", true)
+		return m, nil
+	}
+
+	switch {
+	case len(args) == 1:
+		if path := network.CapturePath(); path != "" {
+			m.statusBar.SetMessage("HTTP capture: on, writing to "+path, false)
+		} else {
+			m.statusBar.SetMessage("HTTP capture: off", false)
+		}
+	case args[1] == "on":
+		path := strings.Join(args[2:], " ")
+		if path == "" {
+			var err error
+			if path, err = debugFilePath("http", ".log"); err != nil {
+				m.statusBar.SetMessage(fmt.Sprintf("Failed to start HTTP capture: %v", err), true)
+				return m, nil
+			}
+		}
+		if err := network.StartCapture(path, m.captureSecrets()...); err != nil {
+			logger.LogError("HTTP_CAPTURE", path, err)
+			m.statusBar.SetMessage(fmt.Sprintf("Failed to start HTTP capture: %v", err), true)
+			return m, nil
+		}
+		m.statusBar.SetMessage("HTTP capture: on, writing to "+path+" (:debug http off to stop)", false)
+	case args[1] == "off":
+		path, err := network.StopCapture()
+		switch {
+		case err != nil:
+			m.statusBar.SetMessage(fmt.Sprintf("Failed to close HTTP capture: %v", err), true)
+		case path == "":
+			m.statusBar.SetMessage("HTTP capture: off", false)
+		default:
+			m.statusBar.SetMessage("HTTP capture: off, saved to "+path, false)
+		}
+	default:
+		m.statusBar.SetMessage("Usage: :debug http [on [path] | off]", true)
+	}
+	return m, nil
+}
+
+// captureSecrets returns the stored credentials, which are redacted from HTTP
+// captures wherever they appear, even where they cannot be recognized.
+func (m Model) captureSecrets() []string {
+	var secrets []string
+	if pats, err := m.repository.ListPATs(); err == nil {
+		for _, pat := range pats {
+			secrets = append(secrets, pat.Token)
+			if pat.OAuth != nil {
+				secrets = append(secrets, pat.OAuth.RefreshToken)
+			}
+		}
+	}
+	if settings, err := m.repository.GetSettings(); err == nil {
+		secrets = append(secrets, settings.Translation.APIKey, settings.Summary.APIKey, settings.Webhook.Secret)
+	}
+	return secrets
+}
+
+func handleMessagesCommand(m Model, args []string) (Model, tea.Cmd) {
+	m.messagesView.Activate(m.statusBar.History())
+	return m, nil
+}
+
+func handleStatsCommand(m Model, args []string) (Model, tea.Cmd) {
+	events, err := m.repository.ListActivity(time.Time{})
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to load statistics: %v", err), true)
+		return m, nil
+	}
+	m.statsView.Activate(events, time.Now())
+	return m, nil
+}
+
+func handlePerfCommand(m Model, args []string) (Model, tea.Cmd) {
+	m.perfView.Activate(network.Metrics())
+	return m, nil
+}
+
+func handleChangelogCommand(m Model, args []string) (Model, tea.Cmd) {
+	if m.latestRelease == nil {
+		m.statusBar.SetMessage(fmt.Sprintf("No update available (running %s)", version.Version), false)
+		return m, nil
+	}
+	release := m.latestRelease
+	m.changelogView.Activate(release.Version, version.Version, release.URL, release.Changelog)
+	return m, nil
+}
+
+func handleQuitCommand(m Model, args []string) (Model, tea.Cmd) {
+	return m.quit()
+}
+
+func handleQuitKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPATs {
+		return m.quit()
+	}
+
+	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
+		m.prInspect.SwitchToDescription()
+		m.topBar.SetView("PR Description")
+		m.updateShortcuts()
+		return m, nil
+	}
+
+	newModel, cmd := m.navigateBack()
+	return newModel.(Model), cmd
+}
+
+func handleSpaceKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPATs {
+		newModel, cmd := m.handlePATSpaceToggle()
+		return newModel.(Model), cmd
+	}
+	return m, nil
+}
+
+func handleEnterKey(m Model) (Model, tea.Cmd) {
+	switch m.state {
+	case ViewPATs:
+		newModel, cmd := m.handlePATEnter()
 		return newModel.(Model), cmd
 	case ViewPRList:
 		pr := m.prListView.GetSelectedPR()
 		if pr != nil {
-			m.state = ViewPRInspect
-			m.prInspect.SwitchToDescription()
-			m.topBar.SetContext(pr.Repository.FullName, fmt.Sprintf("%d", pr.Number))
-			m.topBar.SetView("PR Description")
-			m.updateShortcuts()
-			return m, tea.Batch(
-				m.loadPRDetail(*pr),
-				m.loadDiff(*pr),
-				m.loadComments(*pr),
-			)
+			return m.openPR(*pr)
 		}
 	case ViewPRInspect:
 		if m.prInspect.GetMode() == views.PRInspectModeDiff {
 			m.reviewView.Activate(views.ReviewModeComment)
+			m.restoreDraft()
 		}
 		return m, nil
 	}
@@ -558,6 +1489,7 @@ func handleDownKey(m Model) (Model, tea.Cmd) {
 
 func handleAddKey(m Model) (Model, tea.Cmd) {
 	if m.state == ViewPATs {
+		m.patsView.SetPluginProviders(pluginProviderNames())
 		m.patsView.EnterAddMode()
 		return m, nil
 	}
@@ -568,61 +1500,372 @@ func handleEditKey(m Model) (Model, tea.Cmd) {
 	if m.state == ViewPATs {
 		pat := m.patsView.GetSelectedPAT()
 		if pat != nil {
+			m.patsView.SetPluginProviders(pluginProviderNames())
 			m.patsView.EnterEditMode(*pat)
 			return m, nil
 		}
 		m.statusBar.SetMessage("No PAT selected to edit", true)
 		return m, nil
 	}
-	return m, nil
+	return m, nil
+}
+
+func handleDeleteKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPATs {
+		newModel, cmd := m.handleDeletePAT()
+		return newModel.(Model), cmd
+	}
+	return m, nil
+}
+
+func handleRefreshKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPRList {
+		m.loadingState = LoadingState{}
+		m.prCache = nil
+		return m, m.loadPRsStreaming()
+	}
+	return m, nil
+}
+
+// handleRefreshPRKey reloads the open PR, its diff and its comments.
+func handleRefreshPRKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRInspect {
+		return m, nil
+	}
+	return m.refreshOpenPR(true)
+}
+
+func handleFilterKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPRList {
+		m.prListView.ActivateFilter()
+		return m, nil
+	}
+	return m, nil
+}
+
+func handleMarkAllReadKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRList {
+		return m, nil
+	}
+	unread := m.prListView.UnreadPRs()
+	if len(unread) == 0 {
+		m.statusBar.SetMessage("No unread PRs", false)
+		return m, clearStatusAfterDelay(4 * time.Second)
+	}
+	if err := m.markPRsSeen(unread); err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to mark PRs read: %v", err), true)
+		return m, nil
+	}
+	m.statusBar.SetMessage(fmt.Sprintf("Marked %d PR(s) read", len(unread)), false)
+	return m, clearStatusAfterDelay(4 * time.Second)
+}
+
+func handleSnoozeKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRList {
+		return m, nil
+	}
+	pr := m.prListView.GetSelectedPR()
+	if pr == nil {
+		return m, nil
+	}
+
+	if m.prListView.IsSnoozed(*pr) {
+		if err := m.repository.UnsnoozePR(pr.Key()); err != nil {
+			m.statusBar.SetMessage(fmt.Sprintf("Failed to wake PR: %v", err), true)
+			return m, nil
+		}
+		number := pr.Number
+		m.reloadSnoozes()
+		m.statusBar.SetMessage(fmt.Sprintf("Woke #%d", number), false)
+		return m, clearStatusAfterDelay(4 * time.Second)
+	}
+
+	selected := *pr
+	m.snoozeView.Activate(&selected)
+	return m, nil
+}
+
+func handlePinPRKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRList {
+		return m, nil
+	}
+	pr := m.prListView.GetSelectedPR()
+	if pr == nil {
+		return m, nil
+	}
+
+	number := pr.Number
+	pinned := !m.prListView.Pins().PRPinned(*pr)
+	if err := m.repository.PinPR(pr.Key(), pinned); err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to pin PR: %v", err), true)
+		return m, nil
+	}
+	m.reloadPins()
+	if pinned {
+		m.statusBar.SetMessage(fmt.Sprintf("Pinned #%d", number), false)
+	} else {
+		m.statusBar.SetMessage(fmt.Sprintf("Unpinned #%d", number), false)
+	}
+	return m, clearStatusAfterDelay(4 * time.Second)
+}
+
+func handlePinRepositoryKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRList {
+		return m, nil
+	}
+	pr := m.prListView.GetSelectedPR()
+	if pr == nil {
+		return m, nil
+	}
+
+	name := pr.Repository.FullName
+	pinned := !m.prListView.Pins().RepositoryPinned(*pr)
+	if err := m.repository.PinRepository(pr.RepositoryKey(), pinned); err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to pin repository: %v", err), true)
+		return m, nil
+	}
+	m.reloadPins()
+	if pinned {
+		m.statusBar.SetMessage(fmt.Sprintf("Pinned %s", name), false)
+	} else {
+		m.statusBar.SetMessage(fmt.Sprintf("Unpinned %s", name), false)
+	}
+	return m, clearStatusAfterDelay(4 * time.Second)
+}
+
+func handleDependenciesKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRList {
+		return m, nil
+	}
+	prs := m.prListView.DependencyPRs()
+	if len(prs) == 0 {
+		m.statusBar.SetMessage("No dependency bot PRs in the list", false)
+		return m, clearStatusAfterDelay(4 * time.Second)
+	}
+	m.dependenciesView.Activate(prs)
+	return m, nil
+}
+
+func handleToggleSnoozedKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRList {
+		return m, nil
+	}
+	if m.prListView.ToggleShowSnoozed() {
+		m.statusBar.SetMessage("Showing snoozed PRs", false)
+	} else {
+		m.statusBar.SetMessage("Hiding snoozed PRs", false)
+	}
+	return m, clearStatusAfterDelay(4 * time.Second)
+}
+
+func handleTriageCommand(m Model, args []string) (Model, tea.Cmd) {
+	return handleTriageKey(m)
+}
+
+func handleTriageKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRList {
+		return m, nil
+	}
+	return m.startTriage()
+}
+
+// handleToggleDetailedRowsKey switches the PR list between one and two lines
+// per PR and saves the choice.
+func handleToggleDetailedRowsKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRList {
+		return m, nil
+	}
+	detailed := !m.prListView.Detailed()
+	m.prListView.SetDetailed(detailed)
+
+	settings, err := m.repository.GetSettings()
+	if err == nil {
+		settings.Display.DetailedRows = detailed
+		err = m.repository.SaveSettings(settings)
+	}
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to save settings: %v", err), true)
+		return m, nil
+	}
+	return m, nil
+}
+
+func handleToggleDraftsKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRList {
+		return m, nil
+	}
+	if m.prListView.DraftDisplay() != domain.DraftsSection {
+		m.statusBar.SetMessage("Drafts are not listed in a section, use :drafts section", false)
+		return m, clearStatusAfterDelay(4 * time.Second)
+	}
+	m.prListView.ToggleDrafts()
+	return m, nil
+}
+
+func handleViewDiffKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPRInspect {
+		m.prInspect.SwitchToDiff()
+		m.topBar.SetView("PR Diff")
+		m.updateShortcuts()
+	}
+	return m, nil
+}
+
+func handleNextFileKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
+		m.prInspect.NextFile()
+		return m, nil
+	}
+	return m, nil
+}
+
+func handlePrevFileKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
+		m.prInspect.PrevFile()
+		return m, nil
+	}
+	return m, nil
+}
+
+func handleScrollLeftKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
+		m.prInspect.ScrollLeft()
+	}
+	return m, nil
+}
+
+func handleScrollRightKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
+		m.prInspect.ScrollRight()
+	}
+	return m, nil
+}
+
+// handleSpellCheckCommand turns spell-checking on or off and remembers the
+// choice. It stays off when no dictionary can be loaded.
+func handlePluginsCommand(m Model, args []string) (Model, tea.Cmd) {
+	dir, err := plugin.Dir()
+	if err != nil {
+		m.statusBar.SetMessage(err.Error(), true)
+		return m, nil
+	}
+	plugins, err := plugin.Discover(dir)
+	if err != nil {
+		m.statusBar.SetMessage(err.Error(), true)
+		return m, nil
+	}
+	if len(plugins) == 0 {
+		m.statusBar.SetMessage(fmt.Sprintf("No provider plugins in %s", dir), false)
+		return m, nil
+	}
+
+	names := make([]string, 0, len(plugins))
+	for _, p := range plugins {
+		names = append(names, p.Name)
+	}
+	m.statusBar.SetMessage(fmt.Sprintf("Provider plugins: %s", strings.Join(names, ", ")), false)
+	return m, nil
+}
+
+func handleOutboxCommand(m Model, args []string) (Model, tea.Cmd) {
+	store := m.offlineStore()
+	if store == nil {
+		m.statusBar.SetMessage("The outbox is not available", true)
+		return m, nil
+	}
+	queued, err := store.ListQueuedReviews()
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to load the outbox: %v", err), true)
+		return m, nil
+	}
+	m.outboxView.Activate(queued)
+	return m, nil
+}
+
+// handleTimerCommand turns the review timer on or off, starting or stopping
+// it for the PR being inspected.
+func handleTimerCommand(m Model, args []string) (Model, tea.Cmd) {
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to load settings: %v", err), true)
+		return m, nil
+	}
+
+	settings.Review.Timer = !settings.Review.Timer
+	if err := m.repository.SaveSettings(settings); err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to save settings: %v", err), true)
+		return m, nil
+	}
+
+	if !settings.Review.Timer {
+		m.stopReviewTimer()
+		m.statusBar.SetMessage("Review timer: off", false)
+		return m, nil
+	}
+	var cmd tea.Cmd
+	if pr := m.prInspect.GetPR(); m.state == ViewPRInspect && pr != nil {
+		cmd = m.startReviewTimer(*pr)
+	}
+	if interval := settings.Review.ReminderInterval(); interval > 0 {
+		m.statusBar.SetMessage(fmt.Sprintf("Review timer: on, reminding every %s", formatReviewTime(interval)), false)
+	} else {
+		m.statusBar.SetMessage("Review timer: on", false)
+	}
+	return m, cmd
 }
 
-func handleDeleteKey(m Model) (Model, tea.Cmd) {
-	if m.state == ViewPATs {
-		newModel, cmd := m.handleDeletePAT()
-		return newModel.(Model), cmd
+func handleSpellCheckCommand(m Model, args []string) (Model, tea.Cmd) {
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to load settings: %v", err), true)
+		return m, nil
 	}
-	return m, nil
-}
 
-func handleRefreshKey(m Model) (Model, tea.Cmd) {
-	if m.state == ViewPRList {
-		m.loadingState = LoadingState{}
-		m.prCache = nil
-		return m, m.loadPRsStreaming()
+	var dict *spell.Dictionary
+	if !settings.SpellCheck.Enabled {
+		dict, err = loadDictionary(settings.SpellCheck)
+		if err != nil {
+			m.statusBar.SetMessage(fmt.Sprintf("Spell-check unavailable: %v", err), true)
+			return m, nil
+		}
 	}
-	return m, nil
-}
 
-func handleFilterKey(m Model) (Model, tea.Cmd) {
-	if m.state == ViewPRList {
-		m.prListView.ActivateFilter()
+	settings.SpellCheck.Enabled = !settings.SpellCheck.Enabled
+	if err := m.repository.SaveSettings(settings); err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to save settings: %v", err), true)
 		return m, nil
 	}
-	return m, nil
-}
+	m.setDictionary(dict)
 
-func handleViewDiffKey(m Model) (Model, tea.Cmd) {
-	if m.state == ViewPRInspect {
-		m.prInspect.SwitchToDiff()
-		m.topBar.SetView("PR Diff")
-		m.updateShortcuts()
+	if settings.SpellCheck.Enabled {
+		m.statusBar.SetMessage("Spell-check: on", false)
+	} else {
+		m.statusBar.SetMessage("Spell-check: off", false)
 	}
 	return m, nil
 }
 
-func handleNextFileKey(m Model) (Model, tea.Cmd) {
-	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
-		m.prInspect.NextFile()
+// handleToggleWrapKey switches between wrapping long diff lines and scrolling
+// them sideways, and remembers the choice for later sessions.
+func handleToggleWrapKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRInspect || m.prInspect.GetMode() != views.PRInspectModeDiff {
 		return m, nil
 	}
-	return m, nil
-}
 
-func handlePrevFileKey(m Model) (Model, tea.Cmd) {
-	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
-		m.prInspect.PrevFile()
-		return m, nil
+	m.prInspect.SetWrapLines(!m.prInspect.WrapLines())
+	state := "off"
+	if m.prInspect.WrapLines() {
+		state = "on"
+	}
+	m.statusBar.SetMessage(fmt.Sprintf("Line wrap: %s", state), false)
+
+	settings, err := m.repository.GetSettings()
+	if err == nil {
+		settings.Display.WrapDiffLines = m.prInspect.WrapLines()
+		err = m.repository.SaveSettings(settings)
+	}
+	if err != nil {
+		logger.LogError("SETTINGS_SAVE", "", err)
 	}
 	return m, nil
 }
@@ -637,9 +1880,29 @@ func handleViewCommentsKey(m Model) (Model, tea.Cmd) {
 	return m, nil
 }
 
+// fillReviewTemplate pre-fills the review editor just opened with the
+// template configured for the repository of the PR, if any. A draft restored
+// afterwards replaces it.
+func (m *Model) fillReviewTemplate() {
+	pr := m.prInspect.GetPR()
+	if pr == nil {
+		return
+	}
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		logger.LogError("SETTINGS_LOAD", "", err)
+		return
+	}
+	if body := settings.Review.Template(pr.Repository, m.reviewView.GetReview().Action); body != "" {
+		m.reviewView.SetValue(body)
+	}
+}
+
 func handleApproveKey(m Model) (Model, tea.Cmd) {
 	if m.state == ViewPRInspect {
 		m.reviewView.Activate(views.ReviewModeApprove)
+		m.fillReviewTemplate()
+		m.restoreDraft()
 		return m, nil
 	}
 	return m, nil
@@ -648,11 +1911,57 @@ func handleApproveKey(m Model) (Model, tea.Cmd) {
 func handleRequestChangesKey(m Model) (Model, tea.Cmd) {
 	if m.state == ViewPRInspect {
 		m.reviewView.Activate(views.ReviewModeRequestChanges)
+		m.fillReviewTemplate()
+		m.restoreDraft()
 		return m, nil
 	}
 	return m, nil
 }
 
+// handleOutlineKey lists the functions and types the diff changes. The hunk
+// headings are listed right away and replaced by the parsed symbols once the
+// changed Go files are read.
+func handleOutlineKey(m Model) (Model, tea.Cmd) {
+	pr := m.prInspect.GetPR()
+	diff := m.prInspect.GetDiff()
+	if m.state != ViewPRInspect || pr == nil || diff == nil {
+		return m, nil
+	}
+	load := m.loadOutlineContents(*pr, diff)
+	m.outlineView.Activate(outline.Build(diff, nil), load != nil)
+	return m, load
+}
+
+func handleNextStackPRKey(m Model) (Model, tea.Cmd) {
+	return m.openStackPR(1)
+}
+
+func handlePrevStackPRKey(m Model) (Model, tea.Cmd) {
+	return m.openStackPR(-1)
+}
+
+// handleOwnedFilesKey limits moving between files to the ones the user owns
+// according to CODEOWNERS, or shows all files again.
+func handleOwnedFilesKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRInspect {
+		return m, nil
+	}
+	if !m.prInspect.HasCodeOwners() {
+		m.statusBar.SetMessage("No CODEOWNERS file found for this repository", true)
+		return m, clearStatusAfterDelay(5 * time.Second)
+	}
+	on, owned := m.prInspect.ToggleOwnedOnly()
+	switch {
+	case on:
+		m.statusBar.SetMessage(fmt.Sprintf("Showing only the %d file(s) you own (O to show all)", owned), false)
+	case owned == 0:
+		m.statusBar.SetMessage("You own none of the files of this PR", false)
+	default:
+		m.statusBar.SetMessage("Showing all files", false)
+	}
+	return m, clearStatusAfterDelay(5 * time.Second)
+}
+
 func handleMergeCommand(m Model, args []string) (Model, tea.Cmd) {
 	return handleMergeKey(m)
 }
@@ -684,8 +1993,23 @@ func handleMergeKey(m Model) (Model, tea.Cmd) {
 		return m, nil
 	}
 
-	m.mergeView.Activate(pr, provider.GetType())
-	return m, nil
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		logger.LogError("SETTINGS_LOAD", "", err)
+	}
+	m.mergeView.Activate(pr, provider.GetType(), !settings.Merge.KeepSourceBranch)
+	var cmds []tea.Cmd
+	if cmd := m.loadMergeRequirements(*pr); cmd != nil {
+		m.mergeView.SetRequirementsLoading()
+		cmds = append(cmds, cmd)
+	}
+	if !pr.Mergeable {
+		if cmd := m.loadConflictFiles(*pr); cmd != nil {
+			m.mergeView.SetConflictsLoading()
+			cmds = append(cmds, cmd)
+		}
+	}
+	return m, tea.Batch(cmds...)
 }
 
 func handleColonKey(m Model) (Model, tea.Cmd) {
@@ -695,6 +2019,9 @@ func handleColonKey(m Model) (Model, tea.Cmd) {
 
 func handleReviewSubmitKey(m Model) (Model, tea.Cmd) {
 	if m.reviewView.IsActive() {
+		if pr := m.prInspect.GetPR(); pr != nil {
+			m.discardDraft(reviewDraftKey(*pr))
+		}
 		return m, m.submitReview()
 	}
 	return m, nil
@@ -706,9 +2033,12 @@ func handleEscKey(m Model) (Model, tea.Cmd) {
 		return m, nil
 	}
 	if m.reviewView.IsActive() {
-		m.reviewView.Deactivate()
+		m.closeEditorKeepingDraft(m.reviewView.Deactivate)
 		return m, nil
 	}
+	if m.state == ViewPRList && m.loadingState.IsLoading {
+		return cancelPRLoading(m), nil
+	}
 	if m.state == ViewPRList && m.prListView.GetFilterText() != "" {
 		m.prListView.ClearFilter()
 		return m, nil
@@ -717,6 +2047,17 @@ func handleEscKey(m Model) (Model, tea.Cmd) {
 	return newModel.(Model), cmd
 }
 
+// cancelPRLoading stops the PR list load in flight. Groups that already
+// arrived stay listed.
+func cancelPRLoading(m Model) Model {
+	m.listLoad.stop()
+	m.loadingState.IsLoading = false
+	logger.Log("UI: Cancelled loading PRs after %d/%d PATs", m.loadingState.LoadedPATs, m.loadingState.TotalPATs)
+	m.statusBar.SetMessage(fmt.Sprintf("Cancelled loading PRs (%d/%d PATs loaded)",
+		m.loadingState.LoadedPATs, m.loadingState.TotalPATs), false)
+	return m
+}
+
 func handleOpenBrowserKey(m Model) (Model, tea.Cmd) {
 	var url string
 
@@ -727,6 +2068,24 @@ func handleOpenBrowserKey(m Model) (Model, tea.Cmd) {
 			url = pr.URL
 		}
 	case ViewPRInspect:
+		if blobURL := m.prInspect.CurrentBinaryURL(); blobURL != "" {
+			if err := openBrowser(blobURL); err != nil {
+				m.statusBar.SetMessage(fmt.Sprintf("Failed to open browser: %v", err), true)
+				return m, nil
+			}
+			m.statusBar.SetMessage("Opening file in browser...", false)
+			return m, nil
+		}
+		if m.prInspect.GetMode() == views.PRInspectModeDescription {
+			if previewURL := m.prInspect.PreviewURL(); previewURL != "" {
+				if err := openBrowser(previewURL); err != nil {
+					m.statusBar.SetMessage(fmt.Sprintf("Failed to open browser: %v", err), true)
+					return m, nil
+				}
+				m.statusBar.SetMessage("Opening preview in browser...", false)
+				return m, nil
+			}
+		}
 		pr := m.prInspect.GetPR()
 		if pr != nil {
 			url = pr.URL
@@ -756,6 +2115,7 @@ func handleInlineCommentKey(m Model) (Model, tea.Cmd) {
 				lineDesc = fmt.Sprintf("Line %d (deleted)", lineInfo.OldLine)
 			}
 			m.inlineCommentView.Activate(lineDesc)
+			m.restoreDraft()
 		}
 	}
 	return m, nil
@@ -773,6 +2133,173 @@ func handleToggleDiffViewKey(m Model) (Model, tea.Cmd) {
 	return m, nil
 }
 
+func handleToggleThreadKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
+		if !m.prInspect.ToggleThread() {
+			m.statusBar.SetMessage("No comments on this line", false)
+			return m, clearStatusAfterDelay(4 * time.Second)
+		}
+	}
+	return m, nil
+}
+
+func handleReviewersKey(m Model) (Model, tea.Cmd) {
+	pr := m.prInspect.GetPR()
+	if m.state != ViewPRInspect || pr == nil {
+		return m, nil
+	}
+	m.reviewersView.Activate(pr)
+	return m, m.loadReviewers(*pr)
+}
+
+func handleUndoReviewKey(m Model) (Model, tea.Cmd) {
+	return m.undoReview()
+}
+
+func handleOpenLineThreadKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRInspect || m.prInspect.GetMode() != views.PRInspectModeDiff {
+		return m, nil
+	}
+	lineComments := m.prInspect.GetCurrentLineComments()
+	if len(lineComments) == 0 {
+		m.statusBar.SetMessage("No comments on this line", false)
+		return m, clearStatusAfterDelay(4 * time.Second)
+	}
+	m.commentDetailView.Activate(m.prInspect.GetComments(), m.prInspect.GetDiff())
+	m.commentDetailView.SelectComment(lineComments[0])
+	return m, nil
+}
+
+func handleNextPendingCommentKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
+		if !m.prInspect.NextPendingComment() {
+			m.statusBar.SetMessage("No further pending comments", false)
+			return m, clearStatusAfterDelay(4 * time.Second)
+		}
+	}
+	return m, nil
+}
+
+func handlePrevPendingCommentKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
+		if !m.prInspect.PrevPendingComment() {
+			m.statusBar.SetMessage("No earlier pending comments", false)
+			return m, clearStatusAfterDelay(4 * time.Second)
+		}
+	}
+	return m, nil
+}
+
+func handleNextHunkKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
+		if !m.prInspect.NextHunk() {
+			m.statusBar.SetMessage("No further hunks", false)
+			return m, clearStatusAfterDelay(4 * time.Second)
+		}
+	}
+	return m, nil
+}
+
+func handlePrevHunkKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
+		if !m.prInspect.PrevHunk() {
+			m.statusBar.SetMessage("No earlier hunks", false)
+			return m, clearStatusAfterDelay(4 * time.Second)
+		}
+	}
+	return m, nil
+}
+
+func handleNextCommentedLineKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
+		if !m.prInspect.NextCommentedLine() {
+			m.statusBar.SetMessage("No further commented lines", false)
+			return m, clearStatusAfterDelay(4 * time.Second)
+		}
+	}
+	return m, nil
+}
+
+func handlePrevCommentedLineKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
+		if !m.prInspect.PrevCommentedLine() {
+			m.statusBar.SetMessage("No earlier commented lines", false)
+			return m, clearStatusAfterDelay(4 * time.Second)
+		}
+	}
+	return m, nil
+}
+
+func handleNextAnnotationKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
+		if !m.prInspect.NextAnnotation() {
+			m.statusBar.SetMessage("No further findings", false)
+			return m, clearStatusAfterDelay(4 * time.Second)
+		}
+	}
+	return m, nil
+}
+
+func handlePrevAnnotationKey(m Model) (Model, tea.Cmd) {
+	if m.state == ViewPRInspect && m.prInspect.GetMode() == views.PRInspectModeDiff {
+		if !m.prInspect.PrevAnnotation() {
+			m.statusBar.SetMessage("No earlier findings", false)
+			return m, clearStatusAfterDelay(4 * time.Second)
+		}
+	}
+	return m, nil
+}
+
+// handleAnalyzeFileKey runs the diff hooks again on the current file and
+// reports what they found.
+func handleAnalyzeFileKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRInspect || m.prInspect.GetMode() != views.PRInspectModeDiff {
+		return m, nil
+	}
+	pr := m.prInspect.GetPR()
+	file, ok := m.prInspect.CurrentFile()
+	if pr == nil || !ok {
+		return m, nil
+	}
+
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to load settings: %v", err), true)
+		return m, nil
+	}
+	runner, err := hooks.NewRunner(settings.DiffHooks)
+	if err != nil {
+		m.statusBar.SetMessage(err.Error(), true)
+		return m, nil
+	}
+
+	m.statusBar.SetMessage("Running diff hooks...", false)
+	return m, m.analyzeFile(runner, *pr, file, true)
+}
+
+func handleEditPendingCommentKey(m Model) (Model, tea.Cmd) {
+	comment, ok := m.prInspect.CurrentPendingComment()
+	if !ok {
+		m.statusBar.SetMessage("No pending comment on this line", false)
+		return m, clearStatusAfterDelay(4 * time.Second)
+	}
+	m.inlineCommentView.ActivateEdit(fmt.Sprintf("Line %d", comment.Line), comment.Body)
+	m.restoreDraft()
+	return m, nil
+}
+
+func handleDeletePendingCommentKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRInspect || m.prInspect.GetMode() != views.PRInspectModeDiff {
+		return m, nil
+	}
+	if !m.prInspect.DeletePendingComment() {
+		m.statusBar.SetMessage("No pending comment on this line", false)
+		return m, clearStatusAfterDelay(4 * time.Second)
+	}
+	m.statusBar.SetMessage("Pending comment deleted", false)
+	return m, m.claimPRLease()
+}
+
 func handleYankCurrentFileKey(m Model) (Model, tea.Cmd) {
 	if m.state != ViewPRInspect || m.prInspect.GetMode() != views.PRInspectModeDiff {
 		return m, nil
@@ -818,10 +2345,43 @@ func handleYankAllFilesKey(m Model) (Model, tea.Cmd) {
 	return m, nil
 }
 
+func handleYankHunkAddedLinesKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRInspect || m.prInspect.GetMode() != views.PRInspectModeDiff {
+		return m, nil
+	}
+	return yankAddedLines(m, m.prInspect.GetCurrentHunkAddedLines(), "current hunk")
+}
+
+func handleYankFileAddedLinesKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRInspect || m.prInspect.GetMode() != views.PRInspectModeDiff {
+		return m, nil
+	}
+	return yankAddedLines(m, m.prInspect.GetCurrentFileAddedLines(), "current file")
+}
+
+func yankAddedLines(m Model, text, scope string) (Model, tea.Cmd) {
+	if text == "" {
+		m.statusBar.SetMessage(fmt.Sprintf("No added lines in %s", scope), true)
+		return m, nil
+	}
+
+	if err := clipboard.WriteAll(text); err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to copy: %v", err), true)
+		return m, nil
+	}
+
+	lineCount := strings.Count(text, "\n")
+	m.statusBar.SetMessage(fmt.Sprintf("Copied %d added line(s) from %s to clipboard", lineCount, scope), false)
+	return m, nil
+}
+
 func handleEditDescriptionKey(m Model) (Model, tea.Cmd) {
 	if m.state != ViewPRInspect {
 		return m, nil
 	}
+	if m.prInspect.GetMode() == views.PRInspectModeDiff {
+		return handleEditPendingCommentKey(m)
+	}
 
 	pr := m.prInspect.GetPR()
 	if pr == nil {
@@ -835,9 +2395,145 @@ func handleEditDescriptionKey(m Model) (Model, tea.Cmd) {
 	}
 
 	m.descriptionEditView.Activate(pr.Description)
+	m.restoreDraft()
 	return m, nil
 }
 
+func handleTranslateKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRInspect {
+		return m, nil
+	}
+
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to load settings: %v", err), true)
+		return m, nil
+	}
+
+	translator, err := translate.NewTranslator(settings.Translation)
+	if err != nil {
+		m.statusBar.SetMessage(err.Error(), true)
+		return m, nil
+	}
+
+	var title, text string
+	if m.prInspect.GetMode() == views.PRInspectModeDiff {
+		comments := m.prInspect.GetCurrentLineComments()
+		if len(comments) == 0 {
+			m.statusBar.SetMessage("No comments on the current line to translate", true)
+			return m, nil
+		}
+
+		var b strings.Builder
+		for i, comment := range comments {
+			if i > 0 {
+				b.WriteString("\n\n")
+			}
+			b.WriteString(fmt.Sprintf("**%s**:\n%s", comment.Author.Username, comment.Body))
+		}
+		title = fmt.Sprintf("%d comment(s) on %s:%d", len(comments), comments[0].FilePath, comments[0].Line)
+		text = b.String()
+	} else {
+		pr := m.prInspect.GetPR()
+		if pr == nil || strings.TrimSpace(pr.Description) == "" {
+			m.statusBar.SetMessage("No description to translate", true)
+			return m, nil
+		}
+		title = fmt.Sprintf("%s #%d description", pr.Repository.FullName, pr.Number)
+		text = pr.Description
+	}
+
+	m.statusBar.SetMessage(fmt.Sprintf("Translating to %s...", translator.TargetLanguage()), false)
+	parent := m.ctx
+	return m, func() tea.Msg {
+		ctx, cancel := m.withRequestTimeout(parent)
+		defer cancel()
+		translated, err := translator.Translate(ctx, text)
+		if err != nil {
+			return ErrorMsg{err: err}
+		}
+		return TranslationLoadedMsg{title: title, language: translator.TargetLanguage(), text: translated}
+	}
+}
+
+func handleSummaryKey(m Model) (Model, tea.Cmd) {
+	if m.state != ViewPRInspect {
+		return m, nil
+	}
+	return m.summarizeChanges(false)
+}
+
+func handleSummaryCommand(m Model, args []string) (Model, tea.Cmd) {
+	return handleSummaryKey(m)
+}
+
+// summarizeChanges sends the open PR's diff to the configured summarizer, or
+// reopens the last summary when it was made for the same diff and refresh is
+// not set.
+func (m Model) summarizeChanges(refresh bool) (Model, tea.Cmd) {
+	pr := m.prInspect.GetPR()
+	if pr == nil {
+		return m, nil
+	}
+
+	diffText := m.prInspect.GetAllFilesDiffText()
+	if diffText == "" {
+		m.statusBar.SetMessage("No diff to summarize yet", true)
+		return m, nil
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(diffText))
+	key := fmt.Sprintf("%s@%x", pr.Key(), hash.Sum64())
+	if !refresh && m.summaryView.Reopen(key) {
+		return m, nil
+	}
+
+	settings, err := m.repository.GetSettings()
+	if err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to load settings: %v", err), true)
+		return m, nil
+	}
+
+	summarizer, err := summarize.NewSummarizer(settings.Summary)
+	if err != nil {
+		m.statusBar.SetMessage(err.Error(), true)
+		return m, nil
+	}
+
+	m.statusBar.SetMessage("Summarizing changes...", false)
+	ctx := m.ctx
+	title := fmt.Sprintf("%s #%d", pr.Repository.FullName, pr.Number)
+	target := *pr
+	return m, func() tea.Msg {
+		summary, err := summarizer.Summarize(ctx, target, diffText)
+		if err != nil {
+			return ErrorMsg{err: err}
+		}
+		return SummaryLoadedMsg{key: key, title: title, summary: summary}
+	}
+}
+
+// handleChecksKey opens the output of the open PR's failing checks, for
+// providers that keep it.
+func handleChecksKey(m Model) (Model, tea.Cmd) {
+	pr := m.prInspect.GetPR()
+	if m.state != ViewPRInspect || pr == nil {
+		return m, nil
+	}
+	cmd := m.loadFailingChecks(*pr)
+	if cmd == nil {
+		m.statusBar.SetMessage("Check output is not supported for this provider", true)
+		return m, clearStatusAfterDelay(4 * time.Second)
+	}
+	m.checksView.Activate(pr)
+	return m, cmd
+}
+
+func handleChecksCommand(m Model, args []string) (Model, tea.Cmd) {
+	return handleChecksKey(m)
+}
+
 func openBrowser(url string) error {
 	var cmd *exec.Cmd
 
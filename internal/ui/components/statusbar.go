@@ -1,15 +1,128 @@
 package components
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/textwidth"
 )
 
+// Severity is how important a notification is, which decides its color and
+// how long it stays stacked above the status bar once a newer message
+// replaces it.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeveritySuccess
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeveritySuccess:
+		return "success"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return "info"
+}
+
+// Symbol marks the notification's severity ahead of its text.
+func (s Severity) Symbol() string {
+	switch s {
+	case SeveritySuccess:
+		return "✓"
+	case SeverityWarning:
+		return "⚠"
+	case SeverityError:
+		return "✗"
+	}
+	return "•"
+}
+
+// Background is the color of bars showing a notification of this severity.
+func (s Severity) Background() lipgloss.Color {
+	switch s {
+	case SeveritySuccess:
+		return lipgloss.Color("#065F46")
+	case SeverityWarning:
+		return lipgloss.Color("#92400E")
+	case SeverityError:
+		return lipgloss.Color("#991B1B")
+	}
+	return lipgloss.Color("#374151")
+}
+
+// Foreground is the color of notification text of this severity on the
+// default background.
+func (s Severity) Foreground() lipgloss.Color {
+	switch s {
+	case SeveritySuccess:
+		return lipgloss.Color("#10B981")
+	case SeverityWarning:
+		return lipgloss.Color("#F59E0B")
+	case SeverityError:
+		return lipgloss.Color("#EF4444")
+	}
+	return lipgloss.Color("#E5E7EB")
+}
+
+// toastDuration is how long a replaced notification stays stacked, counted
+// from when it was shown. Info messages are mostly progress and are not
+// stacked at all.
+func (s Severity) toastDuration() time.Duration {
+	switch s {
+	case SeveritySuccess:
+		return 4 * time.Second
+	case SeverityWarning:
+		return 8 * time.Second
+	case SeverityError:
+		return 12 * time.Second
+	}
+	return 0
+}
+
+// Notification is a message shown in the status bar. Count is how many
+// times in a row the same message was shown.
+type Notification struct {
+	Text     string
+	Severity Severity
+	At       time.Time
+	Count    int
+}
+
+type toast struct {
+	Notification
+	expires time.Time
+}
+
+const (
+	// maxToasts is how many replaced notifications are stacked above the
+	// status bar at most; older ones are dropped first.
+	maxToasts = 3
+	// maxHistory is how many notifications :messages keeps.
+	maxHistory = 200
+)
+
+// StatusBarModel shows the latest message in the status bar. Warnings,
+// errors and successes that a newer message replaces are stacked above it
+// for a few seconds, so that messages arriving in quick succession are not
+// lost, and every message is kept in a history.
 type StatusBarModel struct {
-	width   int
-	message string
-	isError bool
+	width     int
+	current   Notification
+	toasts    []toast
+	history   []Notification
+	scheduled time.Time
+	notice    string
+	timer     string
+	task      string
 }
 
 func NewStatusBar() *StatusBarModel {
@@ -21,29 +134,162 @@ func (m *StatusBarModel) SetWidth(width int) {
 }
 
 func (m *StatusBarModel) SetMessage(message string, isError bool) {
-	m.message = message
-	m.isError = isError
+	severity := SeverityInfo
+	if isError {
+		severity = SeverityError
+	}
+	m.Notify(message, severity)
+}
+
+// Notify shows message with severity, stacking the message it replaces when
+// that one should stay visible a little longer.
+func (m *StatusBarModel) Notify(message string, severity Severity) {
+	if message == "" {
+		m.ClearMessage()
+		return
+	}
+	now := time.Now()
+
+	if m.current.Text != "" && m.current.Text != message {
+		if expires := m.current.At.Add(m.current.Severity.toastDuration()); expires.After(now) {
+			m.toasts = append(m.toasts, toast{Notification: m.current, expires: expires})
+			if len(m.toasts) > maxToasts {
+				m.toasts = m.toasts[len(m.toasts)-maxToasts:]
+			}
+		}
+	}
+	m.current = Notification{Text: message, Severity: severity, At: now, Count: 1}
+
+	if last := len(m.history) - 1; last >= 0 && m.history[last].Text == message && m.history[last].Severity == severity {
+		m.history[last].Count++
+		m.history[last].At = now
+		m.current.Count = m.history[last].Count
+		return
+	}
+	m.history = append(m.history, m.current)
+	if len(m.history) > maxHistory {
+		m.history = m.history[len(m.history)-maxHistory:]
+	}
 }
 
 func (m *StatusBarModel) ClearMessage() {
-	m.message = ""
-	m.isError = false
+	m.current = Notification{}
+}
+
+// History returns the notifications shown so far, oldest first.
+func (m *StatusBarModel) History() []Notification {
+	history := make([]Notification, len(m.history))
+	copy(history, m.history)
+	return history
+}
+
+// Toasts returns the notifications stacked above the status bar, oldest
+// first.
+func (m *StatusBarModel) Toasts() []Notification {
+	toasts := make([]Notification, len(m.toasts))
+	for i, t := range m.toasts {
+		toasts[i] = t.Notification
+	}
+	return toasts
+}
+
+// Expire removes the stacked notifications whose time is up at now.
+func (m *StatusBarModel) Expire(now time.Time) {
+	kept := m.toasts[:0]
+	for _, t := range m.toasts {
+		if t.expires.After(now) {
+			kept = append(kept, t)
+		}
+	}
+	m.toasts = kept
+	if !m.scheduled.After(now) {
+		m.scheduled = time.Time{}
+	}
+}
+
+// ScheduleExpiry returns when the next stacked notification expires, unless
+// an expiry has already been scheduled for then, and the zero time when
+// there is nothing to schedule.
+func (m *StatusBarModel) ScheduleExpiry() time.Time {
+	var next time.Time
+	for _, t := range m.toasts {
+		if next.IsZero() || t.expires.Before(next) {
+			next = t.expires
+		}
+	}
+	if next.IsZero() || (!m.scheduled.IsZero() && !next.Before(m.scheduled)) {
+		return time.Time{}
+	}
+	m.scheduled = next
+	return next
+}
+
+// SetNotice sets a persistent right-aligned notice that stays visible while
+// messages come and go.
+func (m *StatusBarModel) SetNotice(notice string) {
+	m.notice = notice
+}
+
+// SetTimer sets the review timer shown right-aligned ahead of the notice; an
+// empty timer hides it.
+func (m *StatusBarModel) SetTimer(timer string) {
+	m.timer = timer
+}
+
+// SetTask shows the operation in flight, with its spinner, ahead of the
+// message; an empty task clears it.
+func (m *StatusBarModel) SetTask(task string) {
+	m.task = task
 }
 
 func (m *StatusBarModel) View() string {
-	content := " " + m.message
+	message := m.current.Text
+	if m.current.Count > 1 {
+		message += fmt.Sprintf(" (×%d)", m.current.Count)
+	}
 
-	if lipgloss.Width(content) > m.width {
-		content = content[:m.width-3] + "..."
-	} else if lipgloss.Width(content) < m.width {
-		padding := m.width - lipgloss.Width(content)
-		content += strings.Repeat(" ", padding)
+	content := " " + message
+	if m.task != "" {
+		content = " " + m.task
+		if message != "" {
+			content += " │ " + message
+		}
+	}
+
+	right := m.notice
+	if m.timer != "" {
+		right = strings.TrimSpace(m.timer + "  " + m.notice)
+	}
+	if right != "" {
+		notice := right + " "
+		if gap := m.width - lipgloss.Width(content) - lipgloss.Width(notice); gap > 0 {
+			content += strings.Repeat(" ", gap) + notice
+		}
 	}
 
 	bgColor := lipgloss.Color("#374151")
-	if m.isError {
-		bgColor = lipgloss.Color("#991B1B")
+	if m.current.Severity != SeverityInfo && m.current.Text != "" {
+		bgColor = m.current.Severity.Background()
+	}
+	return m.renderBar(content, bgColor)
+}
+
+// ToastsView renders the stacked notifications, newest at the bottom, as
+// bars to show right above the status bar, or "" when there are none.
+func (m *StatusBarModel) ToastsView() string {
+	if len(m.toasts) == 0 {
+		return ""
 	}
+	lines := make([]string, len(m.toasts))
+	for i, t := range m.toasts {
+		content := fmt.Sprintf(" %s %s  %s", t.Severity.Symbol(), t.At.Format("15:04:05"), t.Text)
+		lines[i] = m.renderBar(content, t.Severity.Background())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *StatusBarModel) renderBar(content string, bgColor lipgloss.Color) string {
+	content = textwidth.Pad(textwidth.Truncate(content, m.width), m.width)
 
 	style := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#F9FAFB")).
@@ -5,34 +5,39 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/textwidth"
 )
 
 type TopBarModel struct {
-	width          int
-	totalPRs       int
-	authoredPRs    int
-	assignedPRs    int
-	otherPRs       int
-	repoCount      int
-	currentRepo    string
-	currentPR      string
-	prStatus       string
-	prMergeable    bool
-	prApproval     string
-	activePAT      string
-	patProvider    string
-	selectedCount  int
-	totalPATCount  int
-	currentView    string
-	shortcuts      []string
+	width         int
+	totalPRs      int
+	reviewPRs     int
+	authoredPRs   int
+	assignedPRs   int
+	otherPRs      int
+	repoCount     int
+	currentRepo   string
+	currentPR     string
+	prStatus      string
+	prMergeable   bool
+	prApproval    string
+	prLock        string
+	offline       string
+	activePAT     string
+	patProvider   string
+	selectedCount int
+	totalPATCount int
+	currentView   string
+	shortcuts     []string
+	profile       string
 }
 
 var (
-	titleStyle       = lipgloss.NewStyle().Padding(1, 2)
-	titleOrangeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
-	valueWhiteStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	titleStyle        = lipgloss.NewStyle().Padding(1, 2)
+	titleOrangeStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+	valueWhiteStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
 	shortcutBlueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Bold(true)
-	descGrayStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+	descGrayStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
 )
 
 func NewTopBar() *TopBarModel {
@@ -48,7 +53,8 @@ func (m *TopBarModel) SetStats(totalPRs, repoCount int) {
 	m.repoCount = repoCount
 }
 
-func (m *TopBarModel) SetPRBreakdown(authored, assigned, other int) {
+func (m *TopBarModel) SetPRBreakdown(reviewRequested, authored, assigned, other int) {
+	m.reviewPRs = reviewRequested
 	m.authoredPRs = authored
 	m.assignedPRs = assigned
 	m.otherPRs = other
@@ -68,6 +74,19 @@ func (m *TopBarModel) SetPRApproval(approval string) {
 	m.prApproval = approval
 }
 
+// SetPRLock shows a warning badge when the open PR is also open in another
+// lgtmfaster instance. An empty string hides it.
+func (m *TopBarModel) SetPRLock(lock string) {
+	m.prLock = lock
+}
+
+// SetOffline shows a warning badge next to the title while providers cannot
+// be reached and cached data is shown, describing how old it is. An empty
+// string hides it.
+func (m *TopBarModel) SetOffline(offline string) {
+	m.offline = offline
+}
+
 func (m *TopBarModel) SetActivePAT(pat, provider string) {
 	m.activePAT = pat
 	m.patProvider = provider
@@ -82,6 +101,11 @@ func (m *TopBarModel) SetPATCounts(selected, total int) {
 	m.totalPATCount = total
 }
 
+// SetProfile shows the profile in use; the default profile is not shown.
+func (m *TopBarModel) SetProfile(profile string) {
+	m.profile = profile
+}
+
 func (m *TopBarModel) SetView(view string) {
 	m.currentView = view
 }
@@ -92,6 +116,10 @@ func (m *TopBarModel) SetShortcuts(shortcuts []string) {
 
 func (m *TopBarModel) View() string {
 	titleLine := titleOrangeStyle.Render("LGTMFaster")
+	if m.offline != "" {
+		offlineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
+		titleLine += "  " + offlineStyle.Render(fmt.Sprintf("[⚠ %s]", m.offline))
+	}
 
 	contextLines := m.buildContextInfo()
 	shortcutCol1, shortcutCol2, col1Width := m.buildShortcutsDisplay(len(contextLines))
@@ -157,9 +185,7 @@ func (m *TopBarModel) buildContextInfo() []string {
 		if m.selectedCount > 1 {
 			patName = fmt.Sprintf("%s + %d more", patName, m.selectedCount-1)
 		}
-		if len(patName) > 35 {
-			patName = patName[:32] + "..."
-		}
+		patName = textwidth.Truncate(patName, 35)
 	}
 
 	patLine := patEmoji + " " + titleOrangeStyle.Render("PAT: ") + valueWhiteStyle.Render(patName)
@@ -169,6 +195,10 @@ func (m *TopBarModel) buildContextInfo() []string {
 	}
 	lines = append(lines, patLine)
 
+	if m.profile != "" && m.profile != "default" {
+		lines = append(lines, "👤 "+titleOrangeStyle.Render("Profile: ")+valueWhiteStyle.Render(m.profile))
+	}
+
 	isPRView := m.currentView == "PR Description" || m.currentView == "PR Diff" || m.currentView == "PR Inspect"
 
 	if isPRView && m.currentRepo != "" {
@@ -234,6 +264,11 @@ func (m *TopBarModel) buildContextInfo() []string {
 				}
 			}
 
+			if m.prLock != "" {
+				lockStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
+				prValue = fmt.Sprintf("%s %s", prValue, lockStyle.Render(fmt.Sprintf("[🔒 %s]", m.prLock)))
+			}
+
 			lines = append(lines,
 				prEmoji+" "+
 					titleOrangeStyle.Render("PR: ")+
@@ -248,7 +283,9 @@ func (m *TopBarModel) buildContextInfo() []string {
 		lines = append(lines,
 			"👀 "+
 				titleOrangeStyle.Render("assigned: ")+
-				valueWhiteStyle.Render(fmt.Sprintf("%d", m.assignedPRs)))
+				valueWhiteStyle.Render(fmt.Sprintf("%d", m.assignedPRs))+
+				titleOrangeStyle.Render("  my turn: ")+
+				valueWhiteStyle.Render(fmt.Sprintf("%d", m.reviewPRs)))
 
 		lines = append(lines,
 			"⏳ "+
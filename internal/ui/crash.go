@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/johanforsgren/lgtmfaster/internal/crash"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// Run runs the application until it quits. A panic anywhere in it gives the
+// terminal back, leaves a crash report behind and is brought up on the next
+// launch, together with the drafts that were kept.
+func Run(repository domain.Repository, opts ...tea.ProgramOption) error {
+	m := NewModel(repository)
+	m.crashReport = crash.TakePending()
+
+	p := tea.NewProgram(m, opts...)
+	crash.Install(func() {
+		if err := p.ReleaseTerminal(); err != nil {
+			logger.LogError("CRASH_RESTORE_TERMINAL", "", err)
+		}
+	})
+	defer crash.Uninstall()
+
+	_, err := p.Run()
+	if errors.Is(err, tea.ErrProgramPanic) {
+		if dir, dirErr := crash.Dir(); dirErr == nil {
+			return fmt.Errorf("LGTMFaster crashed; the crash report was saved to %s: %w", dir, err)
+		}
+	}
+	return err
+}
+
+// guardCmd reports a panic in cmd before Bubble Tea, which runs it on its
+// own goroutine, recovers it and restores the terminal.
+func guardCmd(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		defer func() { crash.Repanic(recover()) }()
+		msg := cmd()
+		// The commands of a batch are run separately once it is returned.
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			guarded := make(tea.BatchMsg, len(batch))
+			for i, c := range batch {
+				guarded[i] = guardCmd(c)
+			}
+			return guarded
+		}
+		return msg
+	}
+}
+
+// offerCrashRecovery tells about the crash of the last run, listing the
+// drafts it left so that their PRs can be gone back to.
+func (m Model) offerCrashRecovery() {
+	report := m.crashReport
+	var drafts []domain.EditorDraft
+	if store := m.draftStore(); store != nil {
+		var err error
+		if drafts, err = store.ListDrafts(); err != nil {
+			logger.LogError("DRAFT_LIST", "", err)
+		}
+	}
+	if len(drafts) == 0 {
+		m.statusBar.SetMessage("LGTMFaster crashed last time; the crash report was saved to "+report, true)
+		return
+	}
+	m.crashRecoveryView.Activate(report, drafts)
+}
+
+// goToDraftPR selects the PR of the draft picked after a crash, once the
+// PR list has it.
+func (m Model) goToDraftPR() (tea.Model, tea.Cmd) {
+	draft := m.crashRecoveryView.SelectedDraft()
+	m.crashRecoveryView.Deactivate()
+	if draft == nil {
+		return m, nil
+	}
+	m.pendingSelection = draft.PRKey
+	if m.prListView.SelectPR(m.pendingSelection) {
+		m.pendingSelection = ""
+	}
+	m.statusBar.SetMessage(fmt.Sprintf("Open the PR and its %s to restore the draft", draft.Editor), false)
+	return m, clearStatusAfterDelay(8 * time.Second)
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// draftAutosaveDelay is how long typing has to pause before the editor text
+// is written to disk, so that not every key press touches the disk.
+const draftAutosaveDelay = time.Second
+
+// draftEditor is the part of the review, inline comment and description
+// editors that drafts need.
+type draftEditor interface {
+	GetValue() string
+	SetValue(value string)
+}
+
+func reviewDraftKey(pr domain.PullRequest) string {
+	return pr.Key() + "#review"
+}
+
+func descriptionDraftKey(pr domain.PullRequest) string {
+	return pr.Key() + "#description"
+}
+
+// inlineDraft names the inline comment on the diff line under the cursor,
+// which cannot move while the comment is being written.
+func (m Model) inlineDraft(pr domain.PullRequest) (domain.EditorDraft, bool) {
+	file, ok := m.prInspect.CurrentFile()
+	line := m.prInspect.GetCurrentLineInfo()
+	if !ok || line == nil {
+		return domain.EditorDraft{}, false
+	}
+	path := file.NewPath
+	if path == "" {
+		path = file.OldPath
+	}
+	lineNumber := line.NewLine
+	if line.Type == "delete" {
+		lineNumber = line.OldLine
+	}
+	return domain.EditorDraft{
+		Key:    fmt.Sprintf("%s#comment:%s:%d:%d", pr.Key(), path, line.OldLine, line.NewLine),
+		Editor: fmt.Sprintf("comment on %s:%d", path, lineNumber),
+	}, true
+}
+
+// draftStore returns nil when the repository keeps no drafts.
+func (m Model) draftStore() domain.DraftStore {
+	store, _ := m.repository.(domain.DraftStore)
+	return store
+}
+
+// activeDraft returns the open editor and the draft its text is kept in,
+// without the text, or a nil editor when none is open.
+func (m Model) activeDraft() (domain.EditorDraft, draftEditor) {
+	pr := m.prInspect.GetPR()
+	if pr == nil {
+		return domain.EditorDraft{}, nil
+	}
+	var draft domain.EditorDraft
+	var editor draftEditor
+	switch {
+	case m.reviewView.IsActive():
+		draft, editor = domain.EditorDraft{Key: reviewDraftKey(*pr), Editor: "review"}, m.reviewView
+	case m.inlineCommentView.IsActive():
+		if inline, ok := m.inlineDraft(*pr); ok {
+			draft, editor = inline, m.inlineCommentView
+		}
+	case m.descriptionEditView.IsActive():
+		draft, editor = domain.EditorDraft{Key: descriptionDraftKey(*pr), Editor: "description"}, m.descriptionEditView
+	}
+	if editor == nil {
+		return domain.EditorDraft{}, nil
+	}
+	draft.PRKey = pr.Key()
+	draft.PRTitle = pr.Title
+	return draft, editor
+}
+
+// restoreDraft puts the draft left in the editor that was just opened back
+// into it. Undoing goes back to what the editor opened with.
+func (m *Model) restoreDraft() {
+	active, editor := m.activeDraft()
+	if editor == nil {
+		return
+	}
+	m.draftBase = editor.GetValue()
+
+	store := m.draftStore()
+	if store == nil {
+		return
+	}
+	draft, err := store.GetDraft(active.Key)
+	if err != nil {
+		logger.LogError("DRAFT_LOAD", active.Key, err)
+		return
+	}
+	if draft == nil || draft.Body == m.draftBase {
+		return
+	}
+	editor.SetValue(draft.Body)
+	m.statusBar.SetMessage(fmt.Sprintf("Restored the unsent text from %s (Ctrl+Z to undo)", draft.SavedAt.Format("Jan 2 15:04")), false)
+}
+
+// scheduleDraftSave saves the open editor once typing pauses.
+func (m *Model) scheduleDraftSave() tea.Cmd {
+	if m.draftStore() == nil {
+		return nil
+	}
+	m.draftGeneration++
+	generation := m.draftGeneration
+	return tea.Tick(draftAutosaveDelay, func(time.Time) tea.Msg {
+		return DraftAutosaveMsg{generation: generation}
+	})
+}
+
+// saveDraft writes the text of the open editor to disk and reports whether a
+// draft was kept. Text the editor opened with is not worth keeping, so the
+// draft is deleted instead.
+func (m *Model) saveDraft() bool {
+	// Any autosave still pending would see the editor closed.
+	m.draftGeneration++
+
+	store := m.draftStore()
+	draft, editor := m.activeDraft()
+	if store == nil || editor == nil {
+		return false
+	}
+	draft.Body = editor.GetValue()
+	if draft.Body == m.draftBase {
+		draft.Body = ""
+	}
+	if err := store.SaveDraft(draft); err != nil {
+		logger.LogError("DRAFT_SAVE", draft.Key, err)
+		return false
+	}
+	return draft.Body != ""
+}
+
+// discardDraft deletes the draft under key once its text has been sent.
+func (m *Model) discardDraft(key string) {
+	m.draftGeneration++
+	if store := m.draftStore(); store != nil && key != "" {
+		if err := store.DeleteDraft(key); err != nil {
+			logger.LogError("DRAFT_DELETE", key, err)
+		}
+	}
+}
+
+// closeEditorKeepingDraft saves the open editor's text before close shuts
+// it, telling the user that it was kept.
+func (m *Model) closeEditorKeepingDraft(close func()) {
+	kept := m.saveDraft()
+	close()
+	if kept {
+		m.statusBar.SetMessage("Unsent text kept; it is restored when the editor is reopened", false)
+	}
+}
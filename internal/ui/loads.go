@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// loadTracker owns the context of the latest load of one kind, such as the
+// PR list or the details of the open PR. Beginning a load cancels the
+// previous one, so that a superseded or abandoned load stops instead of
+// finishing in the background and overwriting newer state. The tracker is
+// shared by all copies of the Model; a nil tracker tracks nothing.
+type loadTracker struct {
+	mu     sync.Mutex
+	id     uint64
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newLoadTracker() *loadTracker {
+	return &loadTracker{}
+}
+
+// begin cancels the current load and returns the context and ID of a new
+// one derived from parent.
+func (t *loadTracker) begin(parent context.Context) (context.Context, uint64) {
+	if t == nil {
+		return parent, 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cancel != nil {
+		t.cancel()
+	}
+	t.id++
+	t.ctx, t.cancel = context.WithCancel(parent)
+	return t.ctx, t.id
+}
+
+// context returns the context of the current load, or parent when there is
+// none.
+func (t *loadTracker) context(parent context.Context) context.Context {
+	if t == nil {
+		return parent
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ctx == nil {
+		return parent
+	}
+	return t.ctx
+}
+
+// stop cancels the current load, if any.
+func (t *loadTracker) stop() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cancel != nil {
+		t.cancel()
+		t.cancel = nil
+		t.ctx = nil
+	}
+}
+
+// withRequestTimeout bounds a single provider call by the configured
+// request timeout.
+func (m Model) withRequestTimeout(parent context.Context) (context.Context, context.CancelFunc) {
+	if m.requestTimeout <= 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, m.requestTimeout)
+}
+
+// requestTimeoutMessage explains a provider call that ran out of time.
+func requestTimeoutMessage(timeout time.Duration) string {
+	return "Request timed out after " + timeout.String() + " (settings.Network.RequestTimeoutSeconds)"
+}
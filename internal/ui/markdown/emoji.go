@@ -0,0 +1,183 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// emojiShortcodes maps the GitHub shortcodes most often seen in pull request
+// descriptions and comments. Unknown shortcodes are left as written.
+var emojiShortcodes = map[string]string{
+	"+1":                         "👍",
+	"thumbsup":                   "👍",
+	"-1":                         "👎",
+	"thumbsdown":                 "👎",
+	"smile":                      "😄",
+	"smiley":                     "😃",
+	"grinning":                   "😀",
+	"laughing":                   "😆",
+	"joy":                        "😂",
+	"sweat_smile":                "😅",
+	"wink":                       "😉",
+	"blush":                      "😊",
+	"slightly_smiling_face":      "🙂",
+	"upside_down_face":           "🙃",
+	"thinking":                   "🤔",
+	"confused":                   "😕",
+	"neutral_face":               "😐",
+	"disappointed":               "😞",
+	"cry":                        "😢",
+	"sob":                        "😭",
+	"scream":                     "😱",
+	"exploding_head":             "🤯",
+	"sunglasses":                 "😎",
+	"nerd_face":                  "🤓",
+	"facepalm":                   "🤦",
+	"shrug":                      "🤷",
+	"eyes":                       "👀",
+	"heart":                      "❤️",
+	"broken_heart":               "💔",
+	"tada":                       "🎉",
+	"hooray":                     "🎉",
+	"confetti_ball":              "🎊",
+	"rocket":                     "🚀",
+	"fire":                       "🔥",
+	"sparkles":                   "✨",
+	"star":                       "⭐",
+	"star2":                      "🌟",
+	"zap":                        "⚡",
+	"boom":                       "💥",
+	"100":                        "💯",
+	"clap":                       "👏",
+	"pray":                       "🙏",
+	"muscle":                     "💪",
+	"wave":                       "👋",
+	"ok_hand":                    "👌",
+	"raised_hands":               "🙌",
+	"point_right":                "👉",
+	"point_up":                   "☝️",
+	"white_check_mark":           "✅",
+	"heavy_check_mark":           "✔️",
+	"check":                      "✔️",
+	"x":                          "❌",
+	"heavy_multiplication_x":     "✖️",
+	"warning":                    "⚠️",
+	"no_entry":                   "⛔",
+	"no_entry_sign":              "🚫",
+	"stop_sign":                  "🛑",
+	"question":                   "❓",
+	"exclamation":                "❗",
+	"bangbang":                   "‼️",
+	"information_source":         "ℹ️",
+	"bulb":                       "💡",
+	"memo":                       "📝",
+	"pencil":                     "📝",
+	"pencil2":                    "✏️",
+	"books":                      "📚",
+	"book":                       "📖",
+	"bookmark":                   "🔖",
+	"pushpin":                    "📌",
+	"paperclip":                  "📎",
+	"link":                       "🔗",
+	"lock":                       "🔒",
+	"unlock":                     "🔓",
+	"key":                        "🔑",
+	"bug":                        "🐛",
+	"wrench":                     "🔧",
+	"hammer":                     "🔨",
+	"hammer_and_wrench":          "🛠️",
+	"gear":                       "⚙️",
+	"package":                    "📦",
+	"construction":               "🚧",
+	"recycle":                    "♻️",
+	"wastebasket":                "🗑️",
+	"fire_engine":                "🚒",
+	"ambulance":                  "🚑",
+	"lipstick":                   "💄",
+	"art":                        "🎨",
+	"chart_with_upwards_trend":   "📈",
+	"chart_with_downwards_trend": "📉",
+	"arrow_up":                   "⬆️",
+	"arrow_down":                 "⬇️",
+	"arrow_right":                "➡️",
+	"arrow_left":                 "⬅️",
+	"twisted_rightwards_arrows":  "🔀",
+	"rewind":                     "⏪",
+	"hourglass":                  "⌛",
+	"stopwatch":                  "⏱️",
+	"alarm_clock":                "⏰",
+	"calendar":                   "📆",
+	"mag":                        "🔍",
+	"label":                      "🏷️",
+	"speech_balloon":             "💬",
+	"thought_balloon":            "💭",
+	"mega":                       "📣",
+	"loudspeaker":                "📢",
+	"bell":                       "🔔",
+	"trophy":                     "🏆",
+	"medal_sports":               "🏅",
+	"gift":                       "🎁",
+	"coffee":                     "☕",
+	"beers":                      "🍻",
+	"pizza":                      "🍕",
+	"cake":                       "🍰",
+	"robot":                      "🤖",
+	"ghost":                      "👻",
+	"skull":                      "💀",
+	"poop":                       "💩",
+	"see_no_evil":                "🙈",
+	"rotating_light":             "🚨",
+	"green_heart":                "💚",
+	"blue_heart":                 "💙",
+	"purple_heart":               "💜",
+	"yellow_heart":               "💛",
+	"red_circle":                 "🔴",
+	"green_circle":               "🟢",
+	"yellow_circle":              "🟡",
+	"white_circle":               "⚪",
+	"black_circle":               "⚫",
+	"heavy_plus_sign":            "➕",
+	"heavy_minus_sign":           "➖",
+	"new":                        "🆕",
+	"up":                         "🆙",
+	"cool":                       "🆒",
+	"free":                       "🆓",
+	"globe_with_meridians":       "🌐",
+	"earth_americas":             "🌎",
+	"seedling":                   "🌱",
+	"evergreen_tree":             "🌲",
+	"sun_with_face":              "🌞",
+	"cloud":                      "☁️",
+	"snowflake":                  "❄️",
+	"umbrella":                   "☔",
+	"whale":                      "🐳",
+	"penguin":                    "🐧",
+	"snake":                      "🐍",
+	"crab":                       "🦀",
+}
+
+var shortcodeRegex = regexp.MustCompile(`:([a-z0-9_+\-]+):`)
+
+// renderShortcodes replaces known :shortcode: emoji outside of inline code
+// spans, which are kept verbatim.
+func renderShortcodes(text string) string {
+	if !strings.Contains(text, ":") {
+		return text
+	}
+
+	parts := strings.Split(text, "`")
+	for i := range parts {
+		// Odd parts are code spans, except the text after an unmatched
+		// trailing backtick.
+		if i%2 == 1 && (len(parts)%2 == 1 || i != len(parts)-1) {
+			continue
+		}
+		parts[i] = shortcodeRegex.ReplaceAllStringFunc(parts[i], func(match string) string {
+			if emoji, ok := emojiShortcodes[match[1:len(match)-1]]; ok {
+				return emoji
+			}
+			return match
+		})
+	}
+	return strings.Join(parts, "`")
+}
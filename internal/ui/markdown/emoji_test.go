@@ -0,0 +1,37 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderShortcodes(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Ship it :rocket: :+1:", "Ship it 🚀 👍"},
+		{"Keep :unknown_code: and 10:30:00", "Keep :unknown_code: and 10:30:00"},
+		{"Use `:tada:` for :tada:", "Use `:tada:` for 🎉"},
+		{"Odd ` backtick :fire:", "Odd ` backtick 🔥"},
+	}
+
+	for _, tt := range tests {
+		if got := renderShortcodes(tt.input); got != tt.want {
+			t.Errorf("renderShortcodes(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestRenderer_ShortcodesInTextButNotCodeBlocks(t *testing.T) {
+	r := NewRenderer(DefaultStyles())
+
+	result := r.Render("## Done :tada:\n- works :white_check_mark:\n```\n:tada:\n```")
+
+	if strings.Count(result, "🎉") != 1 || !strings.Contains(result, "✅") {
+		t.Errorf("expected shortcodes outside code to be replaced, got %q", result)
+	}
+	if !strings.Contains(result, ":tada:") {
+		t.Errorf("expected the code block to keep the shortcode, got %q", result)
+	}
+}
@@ -0,0 +1,274 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/textwidth"
+)
+
+// language describes just enough of a programming language's lexical syntax
+// to color keywords, strings, numbers and comments in fenced code blocks.
+type language struct {
+	keywords      map[string]bool
+	caseSensitive bool
+	lineComments  []string
+	blockComment  [2]string
+	quotes        string
+}
+
+func words(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		set[w] = true
+	}
+	return set
+}
+
+var (
+	cLike = [2]string{"/*", "*/"}
+
+	languages = map[string]*language{
+		"go": {
+			keywords: words(`break case chan const continue default defer else fallthrough for func go goto if
+				import interface map package range return select struct switch type var nil true false iota`),
+			caseSensitive: true, lineComments: []string{"//"}, blockComment: cLike, quotes: "\"'`",
+		},
+		"javascript": {
+			keywords: words(`async await break case catch class const continue debugger default delete do else
+				export extends finally for from function if import in instanceof let new of return static super
+				switch this throw try typeof var void while yield null undefined true false`),
+			caseSensitive: true, lineComments: []string{"//"}, blockComment: cLike, quotes: "\"'`",
+		},
+		"typescript": {
+			keywords: words(`abstract any as async await boolean break case catch class const continue declare
+				default delete do else enum export extends finally for from function if implements import in
+				instanceof interface keyof let namespace never new number of private protected public readonly
+				return static string super switch this throw try type typeof unknown var void while yield null
+				undefined true false`),
+			caseSensitive: true, lineComments: []string{"//"}, blockComment: cLike, quotes: "\"'`",
+		},
+		"python": {
+			keywords: words(`and as assert async await break class continue def del elif else except finally for
+				from global if import in is lambda nonlocal not or pass raise return try while with yield None
+				True False self`),
+			caseSensitive: true, lineComments: []string{"#"}, quotes: "\"'",
+		},
+		"rust": {
+			keywords: words(`as async await break const continue crate dyn else enum extern fn for if impl in let
+				loop match mod move mut pub ref return self Self static struct super trait type unsafe use where
+				while true false`),
+			caseSensitive: true, lineComments: []string{"//"}, blockComment: cLike, quotes: "\"",
+		},
+		"java": {
+			keywords: words(`abstract boolean break byte case catch char class const continue default do double
+				else enum extends final finally float for if implements import instanceof int interface long new
+				package private protected public return short static super switch synchronized this throw throws
+				try void volatile while var null true false`),
+			caseSensitive: true, lineComments: []string{"//"}, blockComment: cLike, quotes: "\"'",
+		},
+		"csharp": {
+			keywords: words(`abstract as async await base bool break case catch class const continue decimal
+				default delegate do double else enum event explicit false finally float for foreach get if
+				implicit in int interface internal is lock long namespace new null object out override params
+				private protected public readonly ref return sealed set static string struct switch this throw
+				true try typeof using var virtual void while`),
+			caseSensitive: true, lineComments: []string{"//"}, blockComment: cLike, quotes: "\"'",
+		},
+		"c": {
+			keywords: words(`auto break case char const continue default do double else enum extern float for
+				goto if inline int long register return short signed sizeof static struct switch typedef union
+				unsigned void volatile while class namespace template typename public private protected virtual
+				new delete nullptr true false`),
+			caseSensitive: true, lineComments: []string{"//"}, blockComment: cLike, quotes: "\"'",
+		},
+		"shell": {
+			keywords: words(`if then else elif fi for while until do done case esac in function return local
+				export readonly set unset echo exit`),
+			caseSensitive: true, lineComments: []string{"#"}, quotes: "\"'",
+		},
+		"yaml": {
+			keywords:      words(`true false null yes no on off`),
+			caseSensitive: false, lineComments: []string{"#"}, quotes: "\"'",
+		},
+		"json": {
+			keywords:      words(`true false null`),
+			caseSensitive: true, quotes: "\"",
+		},
+		"sql": {
+			keywords: words(`select from where insert into values update set delete create table alter drop
+				index join left right inner outer on group by order having limit offset as and or not null is
+				in like between distinct union all primary key foreign references default`),
+			caseSensitive: false, lineComments: []string{"--"}, blockComment: cLike, quotes: "'\"",
+		},
+	}
+
+	languageAliases = map[string]string{
+		"golang": "go", "js": "javascript", "jsx": "javascript", "mjs": "javascript",
+		"ts": "typescript", "tsx": "typescript", "py": "python", "rs": "rust",
+		"cs": "csharp", "c#": "csharp", "cpp": "c", "c++": "c", "h": "c", "hpp": "c",
+		"sh": "shell", "bash": "shell", "zsh": "shell", "console": "shell",
+		"yml": "yaml", "jsonc": "json",
+	}
+)
+
+// lookupLanguage returns the syntax of the language named in a code fence
+// such as "```go", or nil when it is not known.
+func lookupLanguage(info string) *language {
+	fields := strings.Fields(strings.ToLower(info))
+	if len(fields) == 0 {
+		return nil
+	}
+	name := fields[0]
+	if alias, ok := languageAliases[name]; ok {
+		name = alias
+	}
+	return languages[name]
+}
+
+// renderHighlightedCodeBlock renders a code block with every token styled on
+// the code block background, so that the background is not interrupted.
+func (r *Renderer) renderHighlightedCodeBlock(lang *language, lines []string) string {
+	background := r.styles.CodeBlock.GetBackground()
+	plain := r.styles.CodeBlock.UnsetPadding()
+	onBackground := func(style lipgloss.Style) lipgloss.Style {
+		return style.Background(background)
+	}
+	h := highlighter{
+		lang:    lang,
+		plain:   plain,
+		keyword: onBackground(r.styles.CodeKeyword),
+		str:     onBackground(r.styles.CodeString),
+		number:  onBackground(r.styles.CodeNumber),
+		comment: onBackground(r.styles.CodeComment),
+	}
+
+	width := 0
+	for i, line := range lines {
+		lines[i] = strings.ReplaceAll(line, "\t", "    ")
+		width = max(width, textwidth.Width(lines[i]))
+	}
+
+	rendered := make([]string, len(lines))
+	for i, line := range lines {
+		padding := strings.Repeat(" ", width-textwidth.Width(line)+1)
+		rendered[i] = plain.Render(" ") + h.line(line) + plain.Render(padding)
+	}
+	return strings.Join(rendered, "\n")
+}
+
+type highlighter struct {
+	lang                                 *language
+	plain, keyword, str, number, comment lipgloss.Style
+	// inBlockComment carries an unterminated block comment to the next line.
+	inBlockComment bool
+}
+
+func (h *highlighter) line(line string) string {
+	var b strings.Builder
+	plainStart := 0
+	flush := func(end int) {
+		if end > plainStart {
+			b.WriteString(h.plain.Render(line[plainStart:end]))
+		}
+	}
+	emit := func(start, end int, style lipgloss.Style) int {
+		flush(start)
+		b.WriteString(style.Render(line[start:end]))
+		plainStart = end
+		return end
+	}
+
+	open, close := h.lang.blockComment[0], h.lang.blockComment[1]
+	i := 0
+	if h.inBlockComment {
+		end := strings.Index(line, close)
+		if end < 0 {
+			return h.comment.Render(line)
+		}
+		h.inBlockComment = false
+		i = emit(0, end+len(close), h.comment)
+	}
+
+	for i < len(line) {
+		rest := line[i:]
+		if open != "" && strings.HasPrefix(rest, open) {
+			end := strings.Index(rest[len(open):], close)
+			if end < 0 {
+				h.inBlockComment = true
+				i = emit(i, len(line), h.comment)
+				break
+			}
+			i = emit(i, i+len(open)+end+len(close), h.comment)
+			continue
+		}
+		if h.startsLineComment(rest) {
+			i = emit(i, len(line), h.comment)
+			break
+		}
+
+		c := line[i]
+		switch {
+		case strings.IndexByte(h.lang.quotes, c) >= 0:
+			i = emit(i, stringEnd(line, i), h.str)
+		case isDigit(c) && (i == 0 || !isWordByte(line[i-1])):
+			end := i + 1
+			for end < len(line) && (isWordByte(line[end]) || line[end] == '.') {
+				end++
+			}
+			i = emit(i, end, h.number)
+		case isWordByte(c) && (i == 0 || !isWordByte(line[i-1])):
+			end := i + 1
+			for end < len(line) && isWordByte(line[end]) {
+				end++
+			}
+			word := line[i:end]
+			if !h.lang.caseSensitive {
+				word = strings.ToLower(word)
+			}
+			if h.lang.keywords[word] {
+				i = emit(i, end, h.keyword)
+			} else {
+				i = end
+			}
+		default:
+			i++
+		}
+	}
+	flush(len(line))
+	return b.String()
+}
+
+func (h *highlighter) startsLineComment(s string) bool {
+	for _, prefix := range h.lang.lineComments {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// stringEnd returns the index after the literal starting with the quote at
+// start, or the end of the line when it is not closed.
+func stringEnd(line string, start int) int {
+	quote := line[start]
+	for i := start + 1; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			return i + 1
+		}
+	}
+	return len(line)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,87 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func markerStyle(name string) lipgloss.Style {
+	return lipgloss.NewStyle().Transform(func(s string) string {
+		return "<" + name + ">" + s + "</" + name + ">"
+	})
+}
+
+func highlightTestRenderer() *Renderer {
+	styles := DefaultStyles()
+	styles.CodeKeyword = markerStyle("k")
+	styles.CodeString = markerStyle("s")
+	styles.CodeNumber = markerStyle("n")
+	styles.CodeComment = markerStyle("c")
+	return NewRenderer(styles)
+}
+
+func TestRenderer_HighlightsKnownLanguages(t *testing.T) {
+	r := highlightTestRenderer()
+
+	result := r.Render("```go\nfunc retries() int {\n\treturn 3 // \"max\"\n}\nvar s = \"a \\\" b\"\n```")
+
+	for _, want := range []string{
+		"<k>func</k>", "<k>return</k>", "<n>3</n>", `<c>// "max"</c>`, `<s>"a \" b"</s>`,
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in %q", want, result)
+		}
+	}
+	if strings.Contains(result, "<k>retries</k>") {
+		t.Error("expected identifiers not to be highlighted as keywords")
+	}
+}
+
+func TestRenderer_HighlightsBlockCommentsAcrossLines(t *testing.T) {
+	r := highlightTestRenderer()
+
+	result := r.Render("```ts\n/* start\n   const inside */ const after = 1\n```")
+
+	if !strings.Contains(result, "<c>   const inside */</c>") {
+		t.Errorf("expected the comment to continue on the next line, got %q", result)
+	}
+	if !strings.Contains(result, "<k>const</k>") {
+		t.Errorf("expected code after the comment to be highlighted, got %q", result)
+	}
+}
+
+func TestRenderer_HighlightsCaseInsensitiveKeywords(t *testing.T) {
+	r := highlightTestRenderer()
+
+	result := r.Render("```SQL\nSELECT name FROM users -- all\n```")
+
+	if !strings.Contains(result, "<k>SELECT</k>") || !strings.Contains(result, "<k>FROM</k>") || !strings.Contains(result, "<c>-- all</c>") {
+		t.Errorf("unexpected SQL highlighting: %q", result)
+	}
+}
+
+func TestRenderer_UnknownLanguageIsNotHighlighted(t *testing.T) {
+	r := highlightTestRenderer()
+
+	result := r.Render("```brainfuck\nfunc return\n```")
+
+	if strings.Contains(result, "<k>") {
+		t.Errorf("expected no highlighting for an unknown language, got %q", result)
+	}
+	if !strings.Contains(result, "func return") {
+		t.Errorf("expected the code to be kept, got %q", result)
+	}
+}
+
+func TestLookupLanguage_Aliases(t *testing.T) {
+	for _, info := range []string{"golang", "js", "tsx", "py", "c++", "bash", "yml", "go title=main.go"} {
+		if lookupLanguage(info) == nil {
+			t.Errorf("expected %q to be a known language", info)
+		}
+	}
+	if lookupLanguage("") != nil {
+		t.Error("expected no language for a bare fence")
+	}
+}
@@ -0,0 +1,68 @@
+package markdown
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	benchDescriptionLineCount = 2000
+
+	markdownRenderBudget = 100 * time.Millisecond
+)
+
+func buildBenchDescription(lineCount int) string {
+	templates := []string{
+		"## Section %d",
+		"Some **bold** text with `inline code` and a [link](https://example.com/%d).",
+		"- bullet item %d with *emphasis*",
+		"%d. numbered item",
+		"> quoted line %d",
+		"```",
+		"func example%d() {}",
+		"```",
+		"",
+		"Plain paragraph line %d describing the change in detail.",
+	}
+
+	lines := make([]string, 0, lineCount)
+	for i := 0; len(lines) < lineCount; i++ {
+		tmpl := templates[i%len(templates)]
+		if strings.Contains(tmpl, "%d") {
+			lines = append(lines, fmt.Sprintf(tmpl, i))
+		} else {
+			lines = append(lines, tmpl)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func BenchmarkRenderDescription(b *testing.B) {
+	r := NewRenderer(DefaultStyles())
+	r.SetWidth(120)
+	text := buildBenchDescription(benchDescriptionLineCount)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Render(text)
+	}
+}
+
+// Budgets are only enforced when LGTMFASTER_PERF_BUDGETS is set, so that
+// regular (and race-enabled) test runs are not sensitive to machine speed.
+func TestPerformanceBudgets(t *testing.T) {
+	if os.Getenv("LGTMFASTER_PERF_BUDGETS") == "" {
+		t.Skip("set LGTMFASTER_PERF_BUDGETS=1 to enforce performance budgets")
+	}
+
+	result := testing.Benchmark(BenchmarkRenderDescription)
+	perOp := time.Duration(result.NsPerOp())
+	t.Logf("RenderDescription: %v/op (budget %v)", perOp, markdownRenderBudget)
+	if perOp > markdownRenderBudget {
+		t.Errorf("RenderDescription exceeded performance budget: %v/op > %v", perOp, markdownRenderBudget)
+	}
+}
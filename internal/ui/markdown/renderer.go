@@ -31,21 +31,23 @@ func (r *Renderer) Render(text string) string {
 		return ""
 	}
 
-	lines := strings.Split(text, "\n")
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
 	var result []string
 	inCodeBlock := false
 	codeBlockLines := []string{}
+	codeBlockInfo := ""
 
 	for i := 0; i < len(lines); i++ {
 		line := lines[i]
 
 		if strings.HasPrefix(line, "```") {
 			if inCodeBlock {
-				result = append(result, r.renderCodeBlock(codeBlockLines))
+				result = append(result, r.renderCodeBlock(codeBlockInfo, codeBlockLines))
 				codeBlockLines = []string{}
 				inCodeBlock = false
 			} else {
 				inCodeBlock = true
+				codeBlockInfo = strings.TrimPrefix(line, "```")
 			}
 			continue
 		}
@@ -55,12 +57,25 @@ func (r *Renderer) Render(text string) string {
 			continue
 		}
 
+		if isTableStart(lines, i) {
+			header := splitTableRow(line)
+			aligns := parseAlignments(lines[i+1])
+			var rows [][]string
+			i += 2
+			for ; i < len(lines) && strings.Contains(lines[i], "|") && strings.TrimSpace(lines[i]) != ""; i++ {
+				rows = append(rows, splitTableRow(lines[i]))
+			}
+			i--
+			result = append(result, r.renderTable(header, aligns, rows))
+			continue
+		}
+
 		rendered := r.renderLine(line)
 		result = append(result, rendered)
 	}
 
 	if inCodeBlock && len(codeBlockLines) > 0 {
-		result = append(result, r.renderCodeBlock(codeBlockLines))
+		result = append(result, r.renderCodeBlock(codeBlockInfo, codeBlockLines))
 	}
 
 	return strings.Join(result, "\n")
@@ -78,16 +93,16 @@ func (r *Renderer) renderLine(line string) string {
 	}
 
 	if strings.HasPrefix(trimmed, "# ") {
-		return r.styles.H1.Render(strings.TrimPrefix(trimmed, "# "))
+		return r.styles.H1.Render(renderShortcodes(strings.TrimPrefix(trimmed, "# ")))
 	}
 	if strings.HasPrefix(trimmed, "## ") {
-		return r.styles.H2.Render(strings.TrimPrefix(trimmed, "## "))
+		return r.styles.H2.Render(renderShortcodes(strings.TrimPrefix(trimmed, "## ")))
 	}
 	if strings.HasPrefix(trimmed, "### ") {
-		return r.styles.H3.Render(strings.TrimPrefix(trimmed, "### "))
+		return r.styles.H3.Render(renderShortcodes(strings.TrimPrefix(trimmed, "### ")))
 	}
 	if strings.HasPrefix(trimmed, "#### ") {
-		return r.styles.H4.Render(strings.TrimPrefix(trimmed, "#### "))
+		return r.styles.H4.Render(renderShortcodes(strings.TrimPrefix(trimmed, "#### ")))
 	}
 
 	if strings.HasPrefix(trimmed, "> ") {
@@ -160,6 +175,8 @@ var (
 )
 
 func (r *Renderer) renderInlineStyles(text string) string {
+	text = renderShortcodes(text)
+
 	text = boldItalicRegex.ReplaceAllStringFunc(text, func(match string) string {
 		content := boldItalicRegex.FindStringSubmatch(match)[1]
 		return r.styles.BoldItalic.Render(content)
@@ -190,7 +207,12 @@ func (r *Renderer) renderInlineStyles(text string) string {
 	return text
 }
 
-func (r *Renderer) renderCodeBlock(lines []string) string {
+// renderCodeBlock highlights the block when the fence names a known
+// language, as in "```go".
+func (r *Renderer) renderCodeBlock(info string, lines []string) string {
+	if lang := lookupLanguage(info); lang != nil && len(lines) > 0 {
+		return r.renderHighlightedCodeBlock(lang, lines)
+	}
 	content := strings.Join(lines, "\n")
 	return r.styles.CodeBlock.Render(content)
 }
@@ -20,6 +20,16 @@ type Styles struct {
 	ListItem   lipgloss.Style
 	HRule      lipgloss.Style
 	Blockquote lipgloss.Style
+
+	// Syntax highlighting of fenced code blocks. The code block background
+	// is applied on top of these.
+	CodeKeyword lipgloss.Style
+	CodeString  lipgloss.Style
+	CodeNumber  lipgloss.Style
+	CodeComment lipgloss.Style
+
+	TableHeader lipgloss.Style
+	TableBorder lipgloss.Style
 }
 
 func DefaultStyles() Styles {
@@ -100,5 +110,26 @@ func DefaultStyles() Styles {
 			BorderLeft(true).
 			BorderStyle(lipgloss.NormalBorder()).
 			BorderForeground(gray),
+
+		CodeKeyword: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#C084FC")).
+			Bold(true),
+
+		CodeString: lipgloss.NewStyle().
+			Foreground(green),
+
+		CodeNumber: lipgloss.NewStyle().
+			Foreground(cyan),
+
+		CodeComment: lipgloss.NewStyle().
+			Foreground(gray).
+			Italic(true),
+
+		TableHeader: lipgloss.NewStyle().
+			Foreground(purple).
+			Bold(true),
+
+		TableBorder: lipgloss.NewStyle().
+			Foreground(gray),
 	}
 }
@@ -0,0 +1,135 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type alignment int
+
+const (
+	alignLeft alignment = iota
+	alignCenter
+	alignRight
+)
+
+var tableSeparatorRegex = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+
+// isTableStart reports whether lines[i] is the header row of a table, which
+// must be followed by a "| --- | :---: |" separator row.
+func isTableStart(lines []string, i int) bool {
+	if i+1 >= len(lines) || !strings.Contains(lines[i], "|") {
+		return false
+	}
+	separator := strings.TrimSpace(lines[i+1])
+	return strings.Contains(separator, "-") && tableSeparatorRegex.MatchString(separator)
+}
+
+// splitTableRow returns the trimmed cells of a row, ignoring the optional
+// outer pipes. Escaped pipes stay part of the cell.
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = strings.TrimSuffix(line, "|")
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+func parseAlignments(separator string) []alignment {
+	var aligns []alignment
+	for _, cell := range splitTableRow(separator) {
+		switch {
+		case strings.HasPrefix(cell, ":") && strings.HasSuffix(cell, ":"):
+			aligns = append(aligns, alignCenter)
+		case strings.HasSuffix(cell, ":"):
+			aligns = append(aligns, alignRight)
+		default:
+			aligns = append(aligns, alignLeft)
+		}
+	}
+	return aligns
+}
+
+// renderTable draws a table with box-drawing borders. Rows with fewer cells
+// than the header are padded, and extra cells are dropped.
+func (r *Renderer) renderTable(header []string, aligns []alignment, rows [][]string) string {
+	columns := len(header)
+	render := func(cells []string, style lipgloss.Style) []string {
+		rendered := make([]string, columns)
+		for i := 0; i < columns && i < len(cells); i++ {
+			rendered[i] = style.Render(r.renderInlineStyles(cells[i]))
+		}
+		return rendered
+	}
+
+	renderedHeader := render(header, r.styles.TableHeader)
+	renderedRows := make([][]string, len(rows))
+	for i, row := range rows {
+		renderedRows[i] = render(row, r.styles.Text)
+	}
+
+	widths := make([]int, columns)
+	for _, row := range append([][]string{renderedHeader}, renderedRows...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+
+	border := func(left, middle, right string) string {
+		parts := make([]string, columns)
+		for i, width := range widths {
+			parts[i] = strings.Repeat("─", width+2)
+		}
+		return r.styles.TableBorder.Render(left + strings.Join(parts, middle) + right)
+	}
+	line := func(cells []string) string {
+		pipe := r.styles.TableBorder.Render("│")
+		var b strings.Builder
+		b.WriteString(pipe)
+		for i, cell := range cells {
+			align := alignLeft
+			if i < len(aligns) {
+				align = aligns[i]
+			}
+			b.WriteString(" " + pad(cell, widths[i], align) + " " + pipe)
+		}
+		return b.String()
+	}
+
+	out := []string{border("┌", "┬", "┐"), line(renderedHeader), border("├", "┼", "┤")}
+	for _, row := range renderedRows {
+		out = append(out, line(row))
+	}
+	out = append(out, border("└", "┴", "┘"))
+	return strings.Join(out, "\n")
+}
+
+func pad(cell string, width int, align alignment) string {
+	space := width - lipgloss.Width(cell)
+	switch align {
+	case alignRight:
+		return strings.Repeat(" ", space) + cell
+	case alignCenter:
+		left := space / 2
+		return strings.Repeat(" ", left) + cell + strings.Repeat(" ", space-left)
+	}
+	return cell + strings.Repeat(" ", space)
+}
@@ -0,0 +1,60 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderer_Table(t *testing.T) {
+	r := NewRenderer(DefaultStyles())
+
+	result := r.Render("Results:\n\n| Name | Count |\n|:-----|------:|\n| alpha | 1 |\n| b | 200 |\n\nAfter")
+	lines := strings.Split(result, "\n")
+
+	want := []string{
+		"┌───────┬───────┐",
+		"│ Name  │ Count │",
+		"├───────┼───────┤",
+		"│ alpha │     1 │",
+		"│ b     │   200 │",
+		"└───────┴───────┘",
+	}
+	if len(lines) != 2+len(want)+2 {
+		t.Fatalf("unexpected line count %d:\n%s", len(lines), result)
+	}
+	for i, line := range want {
+		if got := lines[2+i]; got != line {
+			t.Errorf("line %d: expected %q, got %q", i, line, got)
+		}
+	}
+	if !strings.Contains(lines[len(lines)-1], "After") {
+		t.Error("expected the text after the table to be rendered")
+	}
+}
+
+func TestRenderer_TableRequiresSeparatorRow(t *testing.T) {
+	r := NewRenderer(DefaultStyles())
+
+	result := r.Render("a | b\nc | d")
+
+	if strings.Contains(result, "┌") {
+		t.Errorf("expected no table without a separator row, got %q", result)
+	}
+}
+
+func TestSplitTableRow(t *testing.T) {
+	cells := splitTableRow(`| a | b \| c |  |`)
+	if len(cells) != 3 || cells[0] != "a" || cells[1] != "b | c" || cells[2] != "" {
+		t.Errorf("unexpected cells: %q", cells)
+	}
+}
+
+func TestRenderer_TablePadsShortRows(t *testing.T) {
+	r := NewRenderer(DefaultStyles())
+
+	result := r.Render("| a | b |\n| - | - |\n| only |")
+
+	if !strings.Contains(result, "│ only │   │") {
+		t.Errorf("expected missing cells to be padded, got %q", result)
+	}
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/network"
+)
+
+// offlineState records when the cached data on screen was fetched, or the
+// zero time while it is live. The PR list and the open PR are tracked
+// separately because each is reloaded on its own.
+type offlineState struct {
+	list time.Time
+	pr   time.Time
+}
+
+// since returns when the oldest cached data on screen was fetched.
+func (s offlineState) since() time.Time {
+	switch {
+	case s.list.IsZero():
+		return s.pr
+	case s.pr.IsZero() || s.list.Before(s.pr):
+		return s.list
+	}
+	return s.pr
+}
+
+// offlineStore returns nil when the repository keeps no offline data.
+func (m Model) offlineStore() domain.OfflineStore {
+	store, _ := m.repository.(domain.OfflineStore)
+	return store
+}
+
+func (m Model) cachePRGroup(group domain.PRGroup) {
+	if store := m.offlineStore(); store != nil {
+		if err := store.SaveCachedPRGroup(group, time.Now()); err != nil {
+			logger.LogError("CACHE_PR_GROUP", group.PATName, err)
+		}
+	}
+}
+
+// cachedPRGroup returns the cached PR list of pat when err means that its
+// provider is unreachable, and nil otherwise.
+func (m Model) cachedPRGroup(pat domain.PAT, err error) *domain.CachedPRGroup {
+	store := m.offlineStore()
+	if store == nil || !network.IsUnreachable(err) {
+		return nil
+	}
+	cached, cacheErr := store.GetCachedPRGroup(pat.ID)
+	if cacheErr != nil {
+		logger.LogError("CACHE_PR_GROUP", pat.Name, cacheErr)
+		return nil
+	}
+	if cached != nil {
+		logger.Log("UI: %s is unreachable, showing PRs cached at %s", pat.Name, cached.FetchedAt.Format(time.RFC3339))
+	}
+	return cached
+}
+
+// cachePR stores part of a freshly loaded PR with save.
+func (m Model) cachePR(pr domain.PullRequest, save func(store domain.OfflineStore, key string, now time.Time) error) {
+	if store := m.offlineStore(); store != nil {
+		if err := save(store, pr.Key(), time.Now()); err != nil {
+			logger.LogError("CACHE_PR", pr.Key(), err)
+		}
+	}
+}
+
+// cachedPR returns the cached data of pr when err means that its provider
+// is unreachable, and nil otherwise.
+func (m Model) cachedPR(pr domain.PullRequest, err error) *domain.CachedPR {
+	store := m.offlineStore()
+	if store == nil || !network.IsUnreachable(err) {
+		return nil
+	}
+	cached, cacheErr := store.GetCachedPR(pr.Key())
+	if cacheErr != nil {
+		logger.LogError("CACHE_PR", pr.Key(), cacheErr)
+		return nil
+	}
+	return cached
+}
+
+// noteListData records whether PR list data came from the cache, given
+// when it was fetched, and noteOpenPRData does the same for the open PR.
+// Live data means the provider is reachable, so reviews queued while
+// offline are sent.
+func (m Model) noteListData(cachedAt time.Time) (Model, tea.Cmd) {
+	if cachedAt.IsZero() {
+		return m.flushQueuedReviews()
+	}
+	if m.offline.list.IsZero() || cachedAt.Before(m.offline.list) {
+		m.offline.list = cachedAt
+	}
+	m.updateOfflineBadge()
+	return m, nil
+}
+
+func (m Model) noteOpenPRData(cachedAt time.Time) (Model, tea.Cmd) {
+	if cachedAt.IsZero() {
+		return m.flushQueuedReviews()
+	}
+	if m.offline.pr.IsZero() || cachedAt.Before(m.offline.pr) {
+		m.offline.pr = cachedAt
+	}
+	m.updateOfflineBadge()
+	return m, nil
+}
+
+func (m Model) updateOfflineBadge() {
+	since := m.offline.since()
+	if since.IsZero() {
+		m.topBar.SetOffline("")
+		return
+	}
+	m.topBar.SetOffline("offline, data from " + formatCacheTime(since, time.Now()))
+}
+
+// formatCacheTime shows the time of day, and the date too when t is not
+// from today.
+func formatCacheTime(t, now time.Time) string {
+	t = t.Local()
+	if y, mo, d := t.Date(); y == now.Year() && mo == now.Month() && d == now.Day() {
+		return t.Format("15:04")
+	}
+	return t.Format("Jan 2 15:04")
+}
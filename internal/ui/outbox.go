@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/network"
+)
+
+// saveToOutbox keeps a review whose submission failed with err, and reports
+// whether it was saved. It is sent again automatically once the provider is
+// reachable only when err means that the review never reached it. After a
+// timeout or a reset connection the provider may already have posted the
+// review, so it waits in the outbox for the user to check and retry rather
+// than risk posting it twice.
+func (m Model) saveToOutbox(patID string, identifier domain.PRIdentifier, review domain.Review, activity domain.ActivityEvent, err error) bool {
+	store := m.offlineStore()
+	if store == nil {
+		return false
+	}
+	queued := domain.QueuedReview{
+		ID:              uuid.New().String(),
+		PATID:           patID,
+		Identifier:      identifier,
+		Review:          review,
+		Activity:        activity,
+		Attempts:        1,
+		LastError:       err.Error(),
+		RetryWhenOnline: network.NeverSent(err),
+	}
+	if saveErr := store.QueueReview(queued); saveErr != nil {
+		logger.LogError("OUTBOX_SAVE", review.PRIdentifier, saveErr)
+		return false
+	}
+	return true
+}
+
+// sendQueuedReview submits a review from the outbox, uploading its
+// attachments first, and removes it from the outbox once sent. On failure the
+// attempt is recorded on the queued review instead.
+func (m Model) sendQueuedReview(store domain.OfflineStore, q domain.QueuedReview, t *task) error {
+	provider := m.providers[q.PATID]
+	if provider == nil {
+		return fmt.Errorf("the PAT of the review for %s is not selected", q.Review.PRIdentifier)
+	}
+
+	review, _, _, err := m.uploadReviewAttachments(provider, q.Identifier, q.Review, t)
+	if err == nil {
+		ctx, cancel := m.withRequestTimeout(m.ctx)
+		err = provider.SubmitReview(ctx, review)
+		cancel()
+	}
+	if err != nil {
+		logger.LogError("OUTBOX_SEND", q.Review.PRIdentifier, err)
+		q.Attempts++
+		q.LastError = err.Error()
+		q.RetryWhenOnline = network.NeverSent(err)
+		if updateErr := store.UpdateQueuedReview(q); updateErr != nil {
+			logger.LogError("OUTBOX_UPDATE", q.ID, updateErr)
+		}
+		return err
+	}
+
+	if removeErr := store.RemoveQueuedReview(q.ID); removeErr != nil {
+		logger.LogError("OUTBOX_REMOVE", q.ID, removeErr)
+	}
+	m.recordActivity(q.Activity)
+	return nil
+}
+
+// flushQueuedReviews sends the reviews in the outbox that are waiting for
+// their provider to become reachable, in the order they were submitted.
+// Reviews whose PAT is not selected are left alone. A review the provider
+// rejects stays in the outbox for the user to retry or discard.
+func (m Model) flushQueuedReviews() (Model, tea.Cmd) {
+	store := m.offlineStore()
+	if store == nil || m.flushingReviews {
+		return m, nil
+	}
+	queued, err := store.ListQueuedReviews()
+	if err != nil {
+		logger.LogError("OUTBOX_LIST", "", err)
+		return m, nil
+	}
+
+	var pending []domain.QueuedReview
+	for _, q := range queued {
+		if q.RetryWhenOnline && m.providers[q.PATID] != nil {
+			pending = append(pending, q)
+		}
+	}
+	if len(pending) == 0 {
+		return m, nil
+	}
+
+	m.flushingReviews = true
+	return m, m.runTask("Sending queued reviews", func(t *task) tea.Msg {
+		var msg QueuedReviewsSentMsg
+		for i, q := range pending {
+			t.setProgress(i, len(pending), "reviews")
+			err := m.sendQueuedReview(store, q, nil)
+			switch {
+			case err == nil:
+				msg.sent++
+			case network.NeverSent(err):
+				msg.remaining++
+			default:
+				msg.err = fmt.Errorf("the review for %s failed and is kept in the outbox (:outbox): %w", q.Review.PRIdentifier, err)
+			}
+		}
+		return msg
+	})
+}
+
+// retryOutboxReview sends the review selected in the outbox view.
+func (m Model) retryOutboxReview() (Model, tea.Cmd) {
+	store := m.offlineStore()
+	selected := m.outboxView.GetSelected()
+	if store == nil || selected == nil || m.outboxView.IsSending() {
+		return m, nil
+	}
+
+	q := *selected
+	m.outboxView.SetSending(q.ID)
+	logger.Log("UI: Retrying review %s for %s from the outbox", q.ID, q.Review.PRIdentifier)
+	return m, m.runTask("Sending review", func(t *task) tea.Msg {
+		return OutboxReviewSentMsg{prIdentifier: q.Review.PRIdentifier, err: m.sendQueuedReview(store, q, t)}
+	})
+}
+
+// discardOutboxReview drops the review selected in the outbox view.
+func (m Model) discardOutboxReview() (Model, tea.Cmd) {
+	m.outboxView.CancelDiscard()
+	store := m.offlineStore()
+	selected := m.outboxView.GetSelected()
+	if store == nil || selected == nil {
+		return m, nil
+	}
+
+	logger.Log("UI: Discarding review %s for %s from the outbox", selected.ID, selected.Review.PRIdentifier)
+	prIdentifier := selected.Review.PRIdentifier
+	if err := store.RemoveQueuedReview(selected.ID); err != nil {
+		m.statusBar.SetMessage(fmt.Sprintf("Failed to discard the review: %v", err), true)
+		return m, nil
+	}
+	m.refreshOutboxView()
+	m.statusBar.SetMessage(fmt.Sprintf("Discarded the review for %s", prIdentifier), false)
+	return m, clearStatusAfterDelay(4 * time.Second)
+}
+
+// refreshOutboxView reloads the reviews listed in the outbox view.
+func (m Model) refreshOutboxView() {
+	store := m.offlineStore()
+	if store == nil {
+		return
+	}
+	queued, err := store.ListQueuedReviews()
+	if err != nil {
+		logger.LogError("OUTBOX_LIST", "", err)
+		return
+	}
+	m.outboxView.SetItems(queued)
+}
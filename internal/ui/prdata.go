@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+// prDataCacheSize is how many PR head commits the diffs and comments of are
+// kept for in memory.
+const prDataCacheSize = 20
+
+// prDataCache keeps the diffs and comments of the most recently opened PRs
+// per head commit, so that reopening a PR during the session shows them at
+// once. Entries of a PR are dropped once a newer head commit is seen. Like
+// loadTracker it is shared by all copies of the Model and used by commands
+// running in the background; a nil cache keeps nothing.
+type prDataCache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type prData struct {
+	prKey    string
+	headSHA  string
+	diff     *domain.Diff
+	comments []domain.Comment
+	// commentsLoaded tells comments loaded as none from comments not yet
+	// loaded.
+	commentsLoaded bool
+}
+
+func newPRDataCache() *prDataCache {
+	return &prDataCache{entries: make(map[string]*list.Element), order: list.New()}
+}
+
+// diff returns the cached diff of pr at its head commit.
+func (c *prDataCache) diff(pr domain.PullRequest) *domain.Diff {
+	if data := c.get(pr); data != nil {
+		return data.diff
+	}
+	return nil
+}
+
+// comments returns the cached comments of pr at its head commit.
+func (c *prDataCache) comments(pr domain.PullRequest) ([]domain.Comment, bool) {
+	if data := c.get(pr); data != nil && data.commentsLoaded {
+		return data.comments, true
+	}
+	return nil, false
+}
+
+func (c *prDataCache) putDiff(pr domain.PullRequest, diff *domain.Diff) {
+	c.put(pr, func(data *prData) { data.diff = diff })
+}
+
+func (c *prDataCache) putComments(pr domain.PullRequest, comments []domain.Comment) {
+	c.put(pr, func(data *prData) {
+		data.comments = comments
+		data.commentsLoaded = true
+	})
+}
+
+// observe drops what is cached of pr at head commits other than its
+// current one.
+func (c *prDataCache) observe(pr domain.PullRequest) {
+	if c == nil || pr.HeadSHA == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dropStale(pr)
+}
+
+func (c *prDataCache) get(pr domain.PullRequest) *prData {
+	if c == nil || pr.HeadSHA == "" {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[prDataKey(pr)]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*prData)
+}
+
+func (c *prDataCache) put(pr domain.PullRequest, set func(*prData)) {
+	if c == nil || pr.HeadSHA == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dropStale(pr)
+
+	key := prDataKey(pr)
+	element, ok := c.entries[key]
+	if ok {
+		c.order.MoveToFront(element)
+	} else {
+		element = c.order.PushFront(&prData{prKey: pr.Key(), headSHA: pr.HeadSHA})
+		c.entries[key] = element
+	}
+	set(element.Value.(*prData))
+
+	for c.order.Len() > prDataCacheSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		data := oldest.Value.(*prData)
+		delete(c.entries, data.prKey+"@"+data.headSHA)
+	}
+}
+
+// dropStale removes the entries of pr at other head commits. c.mu is held.
+func (c *prDataCache) dropStale(pr domain.PullRequest) {
+	for key, element := range c.entries {
+		data := element.Value.(*prData)
+		if data.prKey == pr.Key() && data.headSHA != pr.HeadSHA {
+			c.order.Remove(element)
+			delete(c.entries, key)
+		}
+	}
+}
+
+func prDataKey(pr domain.PullRequest) string {
+	return pr.Key() + "@" + pr.HeadSHA
+}
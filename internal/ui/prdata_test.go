@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestPRDataCache_KeepsDataPerHeadCommit(t *testing.T) {
+	cache := newPRDataCache()
+	pr := domain.PullRequest{Number: 1, ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}, HeadSHA: "aaa"}
+	diff := &domain.Diff{Files: []domain.FileDiff{{NewPath: "main.go"}}}
+	cache.putDiff(pr, diff)
+	cache.putComments(pr, nil)
+
+	if cache.diff(pr) != diff {
+		t.Fatal("expected the cached diff")
+	}
+	if _, ok := cache.comments(pr); !ok {
+		t.Error("expected comments loaded as none to be cached")
+	}
+
+	pushed := pr
+	pushed.HeadSHA = "bbb"
+	if cache.diff(pushed) != nil {
+		t.Error("expected no diff for a new head commit")
+	}
+	cache.observe(pushed)
+	if cache.diff(pr) != nil {
+		t.Error("expected the data of the old head commit to be dropped")
+	}
+
+	pr.HeadSHA = ""
+	cache.putDiff(pr, diff)
+	if cache.diff(pr) != nil {
+		t.Error("expected nothing to be cached without a head commit")
+	}
+}
+
+func TestPRDataCache_EvictsTheLeastRecentlyUsed(t *testing.T) {
+	cache := newPRDataCache()
+	prs := make([]domain.PullRequest, prDataCacheSize+1)
+	for i := range prs {
+		prs[i] = domain.PullRequest{Number: i, ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}, HeadSHA: fmt.Sprint(i)}
+	}
+	for _, pr := range prs[:prDataCacheSize] {
+		cache.putDiff(pr, &domain.Diff{})
+	}
+	cache.diff(prs[0])
+	cache.putDiff(prs[prDataCacheSize], &domain.Diff{})
+
+	if cache.diff(prs[0]) == nil {
+		t.Error("expected the recently used PR to be kept")
+	}
+	if cache.diff(prs[1]) != nil {
+		t.Error("expected the least recently used PR to be evicted")
+	}
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The smallest terminal the views are laid out for. The editors alone take
+// twelve lines of chrome around their text area.
+const (
+	minTerminalWidth  = 60
+	minTerminalHeight = 16
+)
+
+// sizedView is a view or overlay laid out for the terminal size.
+type sizedView interface {
+	SetSize(width, height int)
+}
+
+// sizedViews returns every view and overlay, so that they are all laid out
+// again whenever the terminal is resized, whether they are open or not.
+func (m Model) sizedViews() []sizedView {
+	return []sizedView{
+		m.patsView,
+		m.prListView,
+		m.prInspect,
+		m.reviewView,
+		m.mergeView,
+		m.snoozeView,
+		m.reviewersView,
+		m.dependenciesView,
+		m.inlineCommentView,
+		m.descriptionEditView,
+		m.commentDetailView,
+		m.logsView,
+		m.translationView,
+		m.summaryView,
+		m.checksView,
+		m.statsView,
+		m.perfView,
+		m.changelogView,
+		m.resumeView,
+		m.crashRecoveryView,
+		m.outboxView,
+		m.outlineView,
+		m.repoPickerView,
+		m.teamBoardView,
+		m.messagesView,
+	}
+}
+
+// resize lays the bars and all views out for a width x height terminal.
+// Views never get less than the minimum size; a smaller terminal shows
+// terminalTooSmallView instead of them.
+func (m *Model) resize(width, height int) {
+	m.width = width
+	m.height = height
+	m.topBar.SetWidth(width)
+	m.statusBar.SetWidth(width)
+	m.commandBar.SetWidth(width)
+
+	width = max(width, minTerminalWidth)
+	height = max(height, minTerminalHeight)
+	for _, view := range m.sizedViews() {
+		view.SetSize(width, height)
+	}
+}
+
+func (m Model) terminalTooSmall() bool {
+	return m.width < minTerminalWidth || m.height < minTerminalHeight
+}
+
+// terminalTooSmallView replaces the views in a terminal smaller than they
+// can be laid out in, rather than rendering them cut off.
+func (m Model) terminalTooSmallView() string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#EF4444")).
+		Bold(true)
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280"))
+
+	text := titleStyle.Render("Terminal too small") + "\n" +
+		mutedStyle.Render(fmt.Sprintf("%d×%d, needs %d×%d", m.width, m.height, minTerminalWidth, minTerminalHeight))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, text)
+}
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+// reviewTimerTick is how often the review timer in the status bar is
+// updated. It shows whole minutes, so a finer tick would only cost renders.
+const reviewTimerTick = 15 * time.Second
+
+// reviewTimer times the visit to the PR being inspected while
+// settings.Review.Timer is on.
+type reviewTimer struct {
+	pr        domain.PullRequest
+	startedAt time.Time
+	// spent is the time spent on the PR in earlier visits this session.
+	spent time.Duration
+	// reminder is the interval between reminders, zero for none, and
+	// reminded how many were given.
+	reminder time.Duration
+	reminded int
+}
+
+// elapsed is the time spent on the PR this session, this visit included.
+func (t *reviewTimer) elapsed(now time.Time) time.Duration {
+	return t.spent + now.Sub(t.startedAt)
+}
+
+// formatReviewTime renders a time spent reviewing as "45 min" or "1 h 05 min".
+func formatReviewTime(d time.Duration) string {
+	minutes := int(d.Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%d min", minutes)
+	}
+	return fmt.Sprintf("%d h %02d min", minutes/60, minutes%60)
+}
+
+// startReviewTimer starts timing pr when the timer is on, carrying over the
+// time already spent on it this session. Reopening the PR being timed keeps
+// its timer running.
+func (m *Model) startReviewTimer(pr domain.PullRequest) tea.Cmd {
+	if m.reviewTimer != nil && m.reviewTimer.pr.Key() == pr.Key() {
+		return nil
+	}
+	m.stopReviewTimer()
+
+	settings, err := m.repository.GetSettings()
+	if err != nil || !settings.Review.Timer {
+		return nil
+	}
+	if m.reviewTimeSpent == nil {
+		m.reviewTimeSpent = make(map[string]time.Duration)
+	}
+	spent := m.reviewTimeSpent[pr.Key()]
+	m.reviewTimer = &reviewTimer{
+		pr:        pr,
+		startedAt: time.Now(),
+		spent:     spent,
+		reminder:  settings.Review.ReminderInterval(),
+	}
+	if m.reviewTimer.reminder > 0 {
+		// Reminders already due from earlier visits are not repeated.
+		m.reviewTimer.reminded = int(spent / m.reviewTimer.reminder)
+	}
+	m.showReviewTimer()
+	return m.scheduleReviewTimerTick()
+}
+
+// stopReviewTimer stops timing the PR and records the time of the visit in
+// the statistics.
+func (m *Model) stopReviewTimer() {
+	timer := m.reviewTimer
+	if timer == nil {
+		return
+	}
+	m.reviewTimer = nil
+	m.reviewTimerGeneration++
+	m.statusBar.SetTimer("")
+
+	visit := time.Since(timer.startedAt)
+	m.reviewTimeSpent[timer.pr.Key()] = timer.spent + visit
+	if visit < time.Second {
+		return
+	}
+	logger.Log("UI: Spent %s reviewing %s", visit.Round(time.Second), timer.pr.Key())
+	m.recordActivity(domain.ActivityEvent{
+		Type:         domain.ActivityReviewTime,
+		Provider:     timer.pr.ProviderType,
+		PRIdentifier: fmt.Sprintf("%s/%d", timer.pr.Repository.FullName, timer.pr.Number),
+		TimeInReview: visit,
+	})
+}
+
+func (m *Model) showReviewTimer() {
+	m.statusBar.SetTimer("⏱ " + formatReviewTime(m.reviewTimer.elapsed(time.Now())))
+}
+
+func (m *Model) scheduleReviewTimerTick() tea.Cmd {
+	generation := m.reviewTimerGeneration
+	return tea.Tick(reviewTimerTick, func(time.Time) tea.Msg {
+		return ReviewTimerTickMsg{generation: generation}
+	})
+}
+
+// tickReviewTimer updates the timer and gives a reminder each time another
+// reminder interval has been spent on the PR.
+func (m *Model) tickReviewTimer(msg ReviewTimerTickMsg) tea.Cmd {
+	if m.reviewTimer == nil || msg.generation != m.reviewTimerGeneration {
+		return nil
+	}
+	m.showReviewTimer()
+
+	timer := m.reviewTimer
+	if elapsed := timer.elapsed(time.Now()); timer.reminder > 0 && elapsed >= timer.reminder*time.Duration(timer.reminded+1) {
+		timer.reminded = int(elapsed / timer.reminder)
+		m.statusBar.SetMessage(fmt.Sprintf("You've been reviewing this PR for %s", formatReviewTime(elapsed)), false)
+	}
+	return m.scheduleReviewTimerTick()
+}
@@ -1,20 +1,30 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/views"
+)
 
 var (
-	primaryColor   = lipgloss.Color("#7C3AED")
-	secondaryColor = lipgloss.Color("#10B981")
-	errorColor     = lipgloss.Color("#EF4444")
-	warningColor   = lipgloss.Color("#F59E0B")
-	infoColor      = lipgloss.Color("#3B82F6")
-	mutedColor     = lipgloss.Color("#6B7280")
+	primaryColor    = lipgloss.Color("#7C3AED")
+	secondaryColor  = lipgloss.Color("#10B981")
+	errorColor      = lipgloss.Color("#EF4444")
+	warningColor    = lipgloss.Color("#F59E0B")
+	infoColor       = lipgloss.Color("#3B82F6")
+	mutedColor      = lipgloss.Color("#6B7280")
 	backgroundColor = lipgloss.Color("#1F2937")
 	foregroundColor = lipgloss.Color("#F9FAFB")
 
-	authoredColor  = lipgloss.Color("#10B981")
-	assignedColor  = lipgloss.Color("#F59E0B")
-	otherColor     = lipgloss.Color("#6B7280")
+	authoredColor = lipgloss.Color("#10B981")
+	assignedColor = lipgloss.Color("#F59E0B")
+	otherColor    = lipgloss.Color("#6B7280")
+
+	// The diff shading has no 16-color fallback: the basic ANSI backgrounds
+	// are too loud to read text on, so those terminals keep foreground-only
+	// colors.
+	diffAddBackground    = lipgloss.CompleteColor{TrueColor: "#0F2A20", ANSI256: "22"}
+	diffDeleteBackground = lipgloss.CompleteColor{TrueColor: "#3A1517", ANSI256: "52"}
+	diffCursorBackground = lipgloss.Color("#374151")
 )
 
 var (
@@ -59,6 +69,10 @@ var (
 				Foreground(foregroundColor).
 				Padding(0, 1)
 
+	ReviewRequestedPRStyle = lipgloss.NewStyle().
+				Foreground(infoColor).
+				Bold(true)
+
 	AuthoredPRStyle = lipgloss.NewStyle().
 			Foreground(authoredColor).
 			Bold(true)
@@ -83,6 +97,16 @@ var (
 	DiffContextStyle = lipgloss.NewStyle().
 				Foreground(mutedColor)
 
+	// DiffStyles are the diff colors passed to the PR inspect view.
+	DiffStyles = views.DiffStyles{
+		Add:              DiffAddStyle,
+		Delete:           DiffDeleteStyle,
+		Context:          DiffContextStyle,
+		AddShaded:        DiffAddStyle.Background(diffAddBackground),
+		DeleteShaded:     DiffDeleteStyle.Background(diffDeleteBackground),
+		CursorBackground: diffCursorBackground,
+	}
+
 	ErrorStyle = lipgloss.NewStyle().
 			Foreground(errorColor).
 			Bold(true)
@@ -118,6 +142,8 @@ var (
 
 func GetCategoryStyle(category string) lipgloss.Style {
 	switch category {
+	case "review_requested":
+		return ReviewRequestedPRStyle
 	case "authored":
 		return AuthoredPRStyle
 	case "assigned":
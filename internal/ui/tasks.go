@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// taskTracker keeps the operations in flight so that the status bar can
+// show a spinner and what is being waited for, instead of the UI seeming
+// frozen. Like loadTracker it is shared by all copies of the Model, and the
+// commands running in the background report their progress and completion
+// through it; a nil tracker tracks nothing.
+type taskTracker struct {
+	mu     sync.Mutex
+	nextID int
+	tasks  []*task
+}
+
+// task is one operation in flight. done and total count its steps, such as
+// requests, when it has more than one.
+type task struct {
+	tracker *taskTracker
+	id      int
+	label   string
+	done    int
+	total   int
+	unit    string
+}
+
+func newTaskTracker() *taskTracker {
+	return &taskTracker{}
+}
+
+func (t *taskTracker) start(label string) *task {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.nextID++
+	started := &task{tracker: t, id: t.nextID, label: label}
+	t.tasks = append(t.tasks, started)
+	return started
+}
+
+func (t *taskTracker) active() bool {
+	if t == nil {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.tasks) > 0
+}
+
+// describe returns the label and progress of the oldest task in flight,
+// followed by how many others there are, or "" when there are none.
+func (t *taskTracker) describe() string {
+	if t == nil {
+		return ""
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.tasks) == 0 {
+		return ""
+	}
+
+	oldest := t.tasks[0]
+	description := oldest.label + "…"
+	if oldest.total > 1 {
+		description += fmt.Sprintf(" %d/%d %s", oldest.done, oldest.total, oldest.unit)
+	}
+	if others := len(t.tasks) - 1; others > 0 {
+		description += fmt.Sprintf(" (+%d more)", others)
+	}
+	return description
+}
+
+// setProgress records that done of the task's total steps, counted in
+// unit, have completed.
+func (t *task) setProgress(done, total int, unit string) {
+	if t == nil {
+		return
+	}
+	t.tracker.mu.Lock()
+	defer t.tracker.mu.Unlock()
+	t.done = done
+	t.total = total
+	t.unit = unit
+}
+
+func (t *task) finish() {
+	if t == nil {
+		return
+	}
+	t.tracker.mu.Lock()
+	defer t.tracker.mu.Unlock()
+	for i, other := range t.tracker.tasks {
+		if other == t {
+			t.tracker.tasks = append(t.tracker.tasks[:i], t.tracker.tasks[i+1:]...)
+			return
+		}
+	}
+}
+
+// runTask shows label in the status bar while fn runs in the background,
+// and lets fn report its progress on the task.
+func (m Model) runTask(label string, fn func(*task) tea.Msg) tea.Cmd {
+	t := m.tasks.start(label)
+	m.updateTaskIndicator()
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		defer t.finish()
+		return fn(t)
+	})
+}
+
+// withTask shows label in the status bar while cmd runs.
+func (m Model) withTask(label string, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return m.runTask(label, func(*task) tea.Msg {
+		return cmd()
+	})
+}
+
+// updateTaskIndicator shows the current spinner frame and task in the
+// status bar, or clears it when nothing is in flight.
+func (m Model) updateTaskIndicator() {
+	if m.tasks == nil {
+		return
+	}
+	description := m.tasks.describe()
+	if description == "" {
+		m.statusBar.SetTask("")
+		return
+	}
+	m.statusBar.SetTask(m.spinner.View() + " " + description)
+}
@@ -0,0 +1,90 @@
+// Package textwidth measures, truncates and pads text by the columns it takes
+// in the terminal. Widths are counted per grapheme cluster, so emoji, CJK and
+// combining characters line up, and ANSI escape sequences take no columns.
+// They agree with lipgloss, which lays out the rendered views.
+package textwidth
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/rivo/uniseg"
+)
+
+// Ellipsis is the tail Truncate adds to shortened text.
+const Ellipsis = "..."
+
+// Width returns the number of columns s takes on screen.
+func Width(s string) int {
+	return ansi.StringWidth(s)
+}
+
+// Truncate shortens s to at most width columns, ending it with Ellipsis when
+// anything was cut. Widths too narrow for the ellipsis cut without it.
+func Truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if Width(s) <= width {
+		return s
+	}
+	if width <= len(Ellipsis) {
+		return ansi.Truncate(s, width, "")
+	}
+	return ansi.Truncate(s, width, Ellipsis)
+}
+
+// Pad returns s cut or padded with spaces to exactly width columns. A wide
+// character cut by the edge is left out and its column padded.
+func Pad(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if Width(s) > width {
+		s = ansi.Truncate(s, width, "")
+	}
+	return s + strings.Repeat(" ", width-Width(s))
+}
+
+// Slice returns the part of the plain text s that is shown from column start
+// on in width columns. A wide character cut by either edge is left out.
+func Slice(s string, start, width int) string {
+	var b strings.Builder
+	col := 0
+	state := -1
+	for s != "" {
+		var cluster string
+		var w int
+		cluster, s, w, state = uniseg.FirstGraphemeClusterInString(s, state)
+		if col >= start && col+w <= start+width {
+			b.WriteString(cluster)
+		}
+		col += w
+		if col >= start+width {
+			break
+		}
+	}
+	return b.String()
+}
+
+// Wrap splits the plain text s into rows of at most width columns, moving a
+// character that does not fit to the next row whole.
+func Wrap(s string, width int) []string {
+	var rows []string
+	var b strings.Builder
+	col := 0
+	state := -1
+	for s != "" {
+		var cluster string
+		var w int
+		cluster, s, w, state = uniseg.FirstGraphemeClusterInString(s, state)
+		if col+w > width && col > 0 {
+			rows = append(rows, b.String())
+			b.Reset()
+			col = 0
+		}
+		b.WriteString(cluster)
+		col += w
+	}
+	return append(rows, b.String())
+}
@@ -0,0 +1,86 @@
+package textwidth
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want int
+	}{
+		{"ascii", "hello", 5},
+		{"cjk", "世界", 4},
+		{"emoji", "🚀 ship", 7},
+		{"zwj sequence", "👩‍💻", 2},
+		{"flag", "🇸🇪", 2},
+		{"combining", "e\u0301te\u0301", 3},
+		{"ansi", "\x1b[1mbold\x1b[0m", 4},
+	}
+	for _, tt := range tests {
+		if got := Width(tt.in); got != tt.want {
+			t.Errorf("%s: Width(%q) = %d, want %d", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"exactly", 7, "exactly"},
+		{"truncated title", 10, "truncat..."},
+		{"🚀🚀🚀🚀🚀", 7, "🚀🚀..."},
+		{"世界世界世界", 8, "世界..."},
+		{"e\u0301e\u0301e\u0301e\u0301e\u0301", 4, "e\u0301..."},
+		{"abcdef", 3, "abc"},
+		{"abcdef", 0, ""},
+	}
+	for _, tt := range tests {
+		got := Truncate(tt.in, tt.width)
+		if got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+		if Width(got) > tt.width {
+			t.Errorf("Truncate(%q, %d) is %d columns wide", tt.in, tt.width, Width(got))
+		}
+	}
+}
+
+func TestPadKeepsColumnsAligned(t *testing.T) {
+	for _, s := range []string{"plain", "🎉 party", "修正バグ", "cafe\u0301", "👩‍💻 dev", "a世界b", ""} {
+		got := Pad(s, 6)
+		if Width(got) != 6 || lipgloss.Width(got) != 6 {
+			t.Errorf("Pad(%q, 6) = %q is %d columns wide", s, got, Width(got))
+		}
+	}
+
+	styled := lipgloss.NewStyle().Bold(true).Render("Title")
+	if got := Pad(styled, 8); Width(got) != 8 {
+		t.Errorf("expected escape sequences to take no columns, got %d", Width(got))
+	}
+}
+
+func TestSliceAndWrap(t *testing.T) {
+	if got := Slice("abcdef", 2, 3); got != "cde" {
+		t.Errorf("expected %q, got %q", "cde", got)
+	}
+	if got := Slice("a世界b", 2, 4); got != "界b" {
+		t.Errorf("expected the wide character cut by the left edge to be dropped, got %q", got)
+	}
+	if got := Slice("x👩‍💻y", 1, 3); got != "👩‍💻y" {
+		t.Errorf("expected an emoji sequence to be kept whole, got %q", got)
+	}
+	if got := Wrap("ab世界", 4); len(got) != 2 || got[0] != "ab世" || got[1] != "界" {
+		t.Errorf("expected wide characters to move to the next row, got %q", got)
+	}
+	if got := Wrap("ae\u0301io", 2); len(got) != 2 || got[0] != "ae\u0301" || got[1] != "io" {
+		t.Errorf("expected combining marks to stay with their base, got %q", got)
+	}
+}
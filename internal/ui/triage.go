@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/views"
+)
+
+// triageHelp lists the single-key outcomes of a PR in triage.
+const triageHelp = "a: approve | r: request changes | z: snooze | s: skip | q: stop"
+
+// triageSession steps through the PRs waiting for the user's review one at
+// a time, moving on to the next one as soon as an outcome is chosen.
+type triageSession struct {
+	queue []domain.PullRequest
+	index int
+
+	approved         int
+	changesRequested int
+	commented        int
+	snoozed          int
+	skipped          int
+}
+
+func (t *triageSession) current() domain.PullRequest {
+	return t.queue[t.index]
+}
+
+// recordReview counts a review confirmed for the current PR.
+func (t *triageSession) recordReview(mode views.ReviewMode) {
+	switch mode {
+	case views.ReviewModeApprove:
+		t.approved++
+	case views.ReviewModeRequestChanges:
+		t.changesRequested++
+	default:
+		t.commented++
+	}
+}
+
+func (t *triageSession) summary() string {
+	var parts []string
+	for _, count := range []struct {
+		n     int
+		label string
+	}{
+		{t.approved, "approved"},
+		{t.changesRequested, "changes requested"},
+		{t.commented, "commented"},
+		{t.snoozed, "snoozed"},
+		{t.skipped, "skipped"},
+	} {
+		if count.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count.n, count.label))
+		}
+	}
+	if len(parts) == 0 {
+		return "nothing done"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// triageQueue returns the PRs among prs that wait for the user's review,
+// newest first. Drafts are left out as they are not ready for review yet.
+func triageQueue(prs []domain.PullRequest) []domain.PullRequest {
+	var queue []domain.PullRequest
+	for _, pr := range prs {
+		if pr.Category == domain.PRCategoryReviewRequested && !pr.IsDraft {
+			queue = append(queue, pr)
+		}
+	}
+	sort.SliceStable(queue, func(i, j int) bool {
+		return queue[i].CreatedAt.After(queue[j].CreatedAt)
+	})
+	return queue
+}
+
+// startTriage opens the first of the listed PRs that wait for the user's
+// review.
+func (m Model) startTriage() (Model, tea.Cmd) {
+	queue := triageQueue(m.prListView.VisiblePRs())
+	if len(queue) == 0 {
+		m.statusBar.SetMessage("No PRs waiting for your review", false)
+		return m, nil
+	}
+	logger.Log("UI: Starting triage of %d PR(s)", len(queue))
+	m.triage = &triageSession{queue: queue}
+	return m.openTriagePR("")
+}
+
+// openTriagePR opens the current PR of the triage, prefixing the progress
+// shown in the status bar with note.
+func (m Model) openTriagePR(note string) (Model, tea.Cmd) {
+	pr := m.triage.current()
+	// The PR is set right away so that an outcome chosen before its details
+	// are loaded applies to this PR and not the previous one.
+	m.prInspect.SetPR(&pr)
+	m, cmd := m.openPR(pr)
+	m.topBar.SetView(fmt.Sprintf("Triage %d/%d", m.triage.index+1, len(m.triage.queue)))
+
+	message := fmt.Sprintf("Triage %d/%d: %s", m.triage.index+1, len(m.triage.queue), triageHelp)
+	if note != "" {
+		message = note + " · " + message
+	}
+	m.statusBar.SetMessage(message, false)
+	return m, cmd
+}
+
+// triaging reports whether the PR being inspected is the current PR of a
+// triage.
+func (m Model) triaging() bool {
+	if m.triage == nil || m.state != ViewPRInspect {
+		return false
+	}
+	pr := m.prInspect.GetPR()
+	return pr != nil && pr.Key() == m.triage.current().Key()
+}
+
+// applyTriageKey applies the outcome chosen with key to the current PR of
+// the triage, and reports whether key was one.
+func (m Model) applyTriageKey(key string) (Model, tea.Cmd, bool) {
+	if !m.triaging() {
+		m.triage = nil
+		return m, nil, false
+	}
+	pr := m.triage.current()
+
+	switch key {
+	case "a":
+		m.reviewView.Activate(views.ReviewModeApprove)
+		if slices.ContainsFunc(m.reviewChecklist(pr), func(item domain.ChecklistItem) bool { return item.Required }) {
+			// The checklist is gone through in the confirmation first.
+			m.showReviewConfirmation()
+			return m, nil, true
+		}
+		m, cmd := m.confirmReview()
+		return m, cmd, true
+	case "r":
+		m.reviewView.Activate(views.ReviewModeRequestChanges)
+		m.fillReviewTemplate()
+		m.restoreDraft()
+		return m, nil, true
+	case "z":
+		m.snoozeView.Activate(&pr)
+		return m, nil, true
+	case "s":
+		m.triage.skipped++
+		m, cmd := m.advanceTriage(fmt.Sprintf("Skipped #%d", pr.Number))
+		return m, cmd, true
+	case "q", "esc", "h", "backspace":
+		m, cmd := m.stopTriage("Triage stopped")
+		return m, cmd, true
+	}
+	return m, nil, false
+}
+
+// advanceTriage opens the next PR of the triage, or ends it after the last.
+func (m Model) advanceTriage(note string) (Model, tea.Cmd) {
+	m.triage.index++
+	if m.triage.index < len(m.triage.queue) {
+		return m.openTriagePR(note)
+	}
+	return m.stopTriage("Triage done")
+}
+
+// stopTriage goes back to the PR list, summing up what was done.
+func (m Model) stopTriage(reason string) (Model, tea.Cmd) {
+	summary := m.triage.summary()
+	m.triage = nil
+	logger.Log("UI: %s: %s", reason, summary)
+
+	var cmd tea.Cmd
+	if m.state == ViewPRInspect {
+		m.prInspect.SwitchToDescription()
+		var model tea.Model
+		model, cmd = m.navigateBack()
+		m = model.(Model)
+	}
+	m.statusBar.SetMessage(fmt.Sprintf("%s: %s", reason, summary), false)
+	return m, cmd
+}
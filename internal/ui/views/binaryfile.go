@@ -0,0 +1,96 @@
+package views
+
+import (
+	"fmt"
+	"mime"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+// renderBinaryFile describes a changed binary file in place of its diff
+// lines, which would only be noise.
+func renderBinaryFile(file domain.FileDiff) string {
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280")).Width(10)
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280")).Italic(true)
+
+	kind := "Binary file"
+	fileType := binaryFileType(getFilePath(file))
+	if strings.HasPrefix(fileType, "image/") {
+		kind = "Image"
+	}
+
+	change := "modified"
+	switch {
+	case file.IsNew:
+		change = "added"
+	case file.IsDeleted:
+		change = "deleted"
+	}
+
+	var b strings.Builder
+	b.WriteString(valueStyle.Bold(true).Render(fmt.Sprintf("%s %s", kind, change)))
+	b.WriteString("\n\n")
+	b.WriteString(labelStyle.Render("Type") + valueStyle.Render(fileType) + "\n")
+	b.WriteString(labelStyle.Render("Size") + valueStyle.Render(binarySizeChange(file)) + "\n\n")
+
+	if file.Binary.BlobURL != "" {
+		b.WriteString(mutedStyle.Render("ctrl+o: Open file in browser"))
+	} else {
+		b.WriteString(mutedStyle.Render("No preview URL available"))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+func binaryFileType(path string) string {
+	if fileType := mime.TypeByExtension(strings.ToLower(filepath.Ext(path))); fileType != "" {
+		fileType, _, _ = strings.Cut(fileType, ";")
+		return fileType
+	}
+	return "application/octet-stream"
+}
+
+func binarySizeChange(file domain.FileDiff) string {
+	oldSize := formatFileSize(file.Binary.OldSize)
+	newSize := formatFileSize(file.Binary.NewSize)
+	switch {
+	case file.IsNew:
+		return newSize
+	case file.IsDeleted:
+		return oldSize
+	}
+
+	result := oldSize + " → " + newSize
+	if file.Binary.OldSize >= 0 && file.Binary.NewSize >= 0 {
+		delta := file.Binary.NewSize - file.Binary.OldSize
+		sign := "+"
+		if delta < 0 {
+			sign = "-"
+			delta = -delta
+		}
+		result += fmt.Sprintf(" (%s%s)", sign, formatFileSize(delta))
+	}
+	return result
+}
+
+func formatFileSize(size int64) string {
+	if size < 0 {
+		return "unknown"
+	}
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size)
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		value /= unit
+		if value < unit || suffix == "GB" {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+	}
+	return ""
+}
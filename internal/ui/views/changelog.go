@@ -0,0 +1,108 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/markdown"
+)
+
+type ChangelogViewModel struct {
+	viewport   viewport.Model
+	version    string
+	current    string
+	url        string
+	changelog  string
+	width      int
+	height     int
+	active     bool
+	mdRenderer *markdown.Renderer
+}
+
+func NewChangelogView() *ChangelogViewModel {
+	return &ChangelogViewModel{
+		viewport:   viewport.New(0, 0),
+		mdRenderer: markdown.NewRenderer(markdown.DefaultStyles()),
+	}
+}
+
+func (m *ChangelogViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.viewport.Width = width
+	m.viewport.Height = height - 10
+	m.mdRenderer.SetWidth(width)
+	if m.active {
+		m.updateViewport()
+	}
+}
+
+func (m *ChangelogViewModel) Activate(version, current, url, changelog string) {
+	m.active = true
+	m.version = version
+	m.current = current
+	m.url = url
+	m.changelog = changelog
+	m.viewport.GotoTop()
+	m.updateViewport()
+}
+
+func (m *ChangelogViewModel) Deactivate() {
+	m.active = false
+}
+
+func (m *ChangelogViewModel) IsActive() bool {
+	return m.active
+}
+
+func (m *ChangelogViewModel) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return cmd
+}
+
+func (m *ChangelogViewModel) View() string {
+	if !m.active {
+		return ""
+	}
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	help := helpStyle.Render("\nj/k: Scroll | q/Esc: Close changelog")
+
+	return m.viewport.View() + "\n" + help
+}
+
+func (m *ChangelogViewModel) updateViewport() {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true).
+		Padding(1, 0)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	b.WriteString(titleStyle.Render(fmt.Sprintf("%s available (running %s)", m.version, m.current)))
+	b.WriteString("\n")
+	if m.url != "" {
+		b.WriteString(mutedStyle.Render(m.url))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if strings.TrimSpace(m.changelog) == "" {
+		b.WriteString(mutedStyle.Render("No changelog provided"))
+	} else {
+		b.WriteString(m.mdRenderer.Render(m.changelog))
+	}
+
+	m.viewport.SetContent(b.String())
+}
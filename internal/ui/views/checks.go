@@ -0,0 +1,166 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/markdown"
+)
+
+// ChecksViewModel shows why the checks of a PR fail: the output each failing
+// check reported and the lines it annotated.
+type ChecksViewModel struct {
+	viewport   viewport.Model
+	pr         *domain.PullRequest
+	checks     []domain.CheckFailure
+	loading    bool
+	err        error
+	width      int
+	height     int
+	active     bool
+	mdRenderer *markdown.Renderer
+}
+
+func NewChecksView() *ChecksViewModel {
+	return &ChecksViewModel{
+		viewport:   viewport.New(0, 0),
+		mdRenderer: markdown.NewRenderer(markdown.DefaultStyles()),
+	}
+}
+
+func (m *ChecksViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.viewport.Width = width
+	m.viewport.Height = height - 10
+	m.mdRenderer.SetWidth(width)
+	if m.active {
+		m.updateViewport()
+	}
+}
+
+// Activate opens the popup for pr while its failing checks are loaded.
+func (m *ChecksViewModel) Activate(pr *domain.PullRequest) {
+	m.active = true
+	m.loading = true
+	m.err = nil
+	m.pr = pr
+	m.checks = nil
+	m.viewport.GotoTop()
+	m.updateViewport()
+}
+
+func (m *ChecksViewModel) Deactivate() {
+	m.active = false
+	m.pr = nil
+	m.checks = nil
+}
+
+func (m *ChecksViewModel) IsActive() bool {
+	return m.active
+}
+
+func (m *ChecksViewModel) GetPR() *domain.PullRequest {
+	return m.pr
+}
+
+func (m *ChecksViewModel) SetChecks(checks []domain.CheckFailure, err error) {
+	m.loading = false
+	m.checks = checks
+	m.err = err
+	m.updateViewport()
+}
+
+func (m *ChecksViewModel) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return cmd
+}
+
+func (m *ChecksViewModel) View() string {
+	if !m.active {
+		return ""
+	}
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	help := helpStyle.Render("\nj/k: Scroll | q/Esc: Close checks")
+
+	return m.viewport.View() + "\n" + help
+}
+
+func (m *ChecksViewModel) updateViewport() {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true).
+		Padding(1, 0)
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Bold(true)
+	headingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Bold(true)
+	pathStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#60A5FA"))
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	title := "Failing checks"
+	if m.pr != nil {
+		title = fmt.Sprintf("Failing checks: %s #%d", m.pr.Repository.FullName, m.pr.Number)
+	}
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n")
+
+	switch {
+	case m.loading:
+		b.WriteString(mutedStyle.Render("Loading checks..."))
+	case m.err != nil:
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).
+			Render(fmt.Sprintf("Failed to load checks: %v", m.err)))
+	case len(m.checks) == 0:
+		b.WriteString(mutedStyle.Render("No checks are failing"))
+	}
+
+	for i, check := range m.checks {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(nameStyle.Render("✗ " + check.Name))
+		if check.Conclusion != "" {
+			b.WriteString(mutedStyle.Render(" (" + strings.ReplaceAll(check.Conclusion, "_", " ") + ")"))
+		}
+		b.WriteString("\n")
+		if check.Title != "" {
+			b.WriteString(headingStyle.Render(check.Title))
+			b.WriteString("\n")
+		}
+		if strings.TrimSpace(check.Summary) != "" {
+			b.WriteString(m.mdRenderer.Render(check.Summary))
+			b.WriteString("\n")
+		}
+		for _, annotation := range check.Annotations {
+			location := annotation.Path
+			if annotation.Line > 0 {
+				location = fmt.Sprintf("%s:%d", annotation.Path, annotation.Line)
+			}
+			message := annotation.Message
+			if annotation.Title != "" {
+				message = annotation.Title + ": " + message
+			}
+			color, icon := annotationSeverity(annotation.Severity)
+			b.WriteString("  " + lipgloss.NewStyle().Foreground(color).Render(icon) + " " + pathStyle.Render(location) + " " + message)
+			b.WriteString("\n")
+		}
+		if check.URL != "" {
+			b.WriteString(mutedStyle.Render(check.URL))
+			b.WriteString("\n")
+		}
+	}
+
+	m.viewport.SetContent(b.String())
+}
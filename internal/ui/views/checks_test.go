@@ -0,0 +1,37 @@
+package views
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestChecksView_ShowsLoadingErrorsAndPassingChecks(t *testing.T) {
+	view := NewChecksView()
+	view.SetSize(100, 30)
+	view.Activate(&domain.PullRequest{Number: 3, Repository: domain.Repo{FullName: "acme/api"}})
+
+	if out := view.View(); !strings.Contains(out, "Loading checks") || !strings.Contains(out, "acme/api #3") {
+		t.Errorf("expected the popup to show it is loading, got:\n%s", out)
+	}
+
+	view.SetChecks(nil, errors.New("rate limited"))
+	if out := view.View(); !strings.Contains(out, "rate limited") {
+		t.Errorf("expected the error, got:\n%s", out)
+	}
+
+	view.SetChecks(nil, nil)
+	if out := view.View(); !strings.Contains(out, "No checks are failing") {
+		t.Errorf("expected passing checks to be pointed out, got:\n%s", out)
+	}
+
+	view.SetChecks([]domain.CheckFailure{{Name: "ci/deploy", Conclusion: "timed_out", URL: "https://ci.acme.dev/9"}}, nil)
+	out := view.View()
+	for _, want := range []string{"ci/deploy", "timed out", "https://ci.acme.dev/9"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the popup, got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package views
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+var inlineCodePattern = regexp.MustCompile("`([^`\n]+)`")
+
+// CodeLens links a backticked identifier in a comment to the first diff line
+// that contains it.
+type CodeLens struct {
+	Identifier string
+	FilePath   string
+	FileIndex  int
+	LineIndex  int
+	Line       int
+}
+
+// ReferencedIdentifiers returns the distinct backticked spans in a comment
+// body, in order of appearance. Fenced code blocks are skipped.
+func ReferencedIdentifiers(body string) []string {
+	var identifiers []string
+	seen := make(map[string]bool)
+	inFence := false
+
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		for _, match := range inlineCodePattern.FindAllStringSubmatch(line, -1) {
+			identifier := strings.TrimSpace(match[1])
+			if identifier == "" || seen[identifier] {
+				continue
+			}
+			seen[identifier] = true
+			identifiers = append(identifiers, identifier)
+		}
+	}
+
+	return identifiers
+}
+
+// FindCodeLenses resolves every referenced identifier in the comment body
+// against the diff. Identifiers that do not appear in the diff are dropped.
+func FindCodeLenses(body string, diff *domain.Diff) []CodeLens {
+	if diff == nil {
+		return nil
+	}
+
+	var lenses []CodeLens
+	for _, identifier := range ReferencedIdentifiers(body) {
+		if lens, ok := locateIdentifier(identifier, diff); ok {
+			lenses = append(lenses, lens)
+		}
+	}
+	return lenses
+}
+
+func locateIdentifier(identifier string, diff *domain.Diff) (CodeLens, bool) {
+	for fileIdx, file := range diff.Files {
+		lineIdx := 0
+		for _, hunk := range file.Hunks {
+			for _, line := range hunk.Lines {
+				if strings.Contains(line.Content, identifier) {
+					lineNumber := line.NewLine
+					if line.Type == "delete" {
+						lineNumber = line.OldLine
+					}
+					return CodeLens{
+						Identifier: identifier,
+						FilePath:   getFilePath(file),
+						FileIndex:  fileIdx,
+						LineIndex:  lineIdx,
+						Line:       lineNumber,
+					}, true
+				}
+				lineIdx++
+			}
+		}
+	}
+	return CodeLens{}, false
+}
@@ -0,0 +1,63 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func codeLensTestDiff() *domain.Diff {
+	return &domain.Diff{
+		Files: []domain.FileDiff{
+			{
+				NewPath: "cache.go",
+				Hunks: []domain.DiffHunk{{
+					Header: "@@ -1,2 +1,3 @@",
+					Lines: []domain.DiffLine{
+						{Type: "context", Content: " package cache", OldLine: 1, NewLine: 1},
+						{Type: "add", Content: "+func newStore() *Store {", NewLine: 2},
+					},
+				}},
+			},
+			{
+				NewPath: "server.go",
+				Hunks: []domain.DiffHunk{{
+					Header: "@@ -10,2 +10,2 @@",
+					Lines: []domain.DiffLine{
+						{Type: "delete", Content: "-\tstore := legacyStore()", OldLine: 10},
+						{Type: "add", Content: "+\tstore := newStore()", NewLine: 10},
+					},
+				}},
+			},
+		},
+	}
+}
+
+func TestReferencedIdentifiers(t *testing.T) {
+	body := "Why does `newStore` replace `legacyStore`? See `newStore` again.\n```go\nfoo := `ignored`\n```"
+	got := ReferencedIdentifiers(body)
+
+	if len(got) != 2 || got[0] != "newStore" || got[1] != "legacyStore" {
+		t.Errorf("unexpected identifiers: %v", got)
+	}
+}
+
+func TestFindCodeLenses_FirstMatchingLine(t *testing.T) {
+	lenses := FindCodeLenses("`legacyStore` vs `newStore` vs `missing`", codeLensTestDiff())
+
+	if len(lenses) != 2 {
+		t.Fatalf("expected 2 lenses, got %d", len(lenses))
+	}
+	if lenses[0].FilePath != "server.go" || lenses[0].FileIndex != 1 || lenses[0].LineIndex != 0 || lenses[0].Line != 10 {
+		t.Errorf("unexpected lens for deleted line: %+v", lenses[0])
+	}
+	if lenses[1].FilePath != "cache.go" || lenses[1].LineIndex != 1 || lenses[1].Line != 2 {
+		t.Errorf("expected first occurrence in diff order, got %+v", lenses[1])
+	}
+}
+
+func TestFindCodeLenses_NilDiff(t *testing.T) {
+	if lenses := FindCodeLenses("`newStore`", nil); lenses != nil {
+		t.Errorf("expected no lenses without a diff, got %v", lenses)
+	}
+}
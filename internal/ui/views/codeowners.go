@@ -0,0 +1,100 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+// SetCodeOwners sets the code owners of the PR's repository, or nil when it
+// has none.
+func (m *PRInspectViewModel) SetCodeOwners(owners *domain.CodeOwners) {
+	m.codeOwners = owners
+	if m.ownedOnly && m.diff != nil && !m.fileShown(m.currentFile) {
+		m.currentFile = m.firstOwnedFile()
+		m.currentLineIdx = 0
+	}
+	m.updateViewport()
+}
+
+// HasCodeOwners reports whether the repository's code owners are known.
+func (m *PRInspectViewModel) HasCodeOwners() bool {
+	return m.codeOwners != nil
+}
+
+// ToggleOwnedOnly turns on or off moving between only the files the user
+// owns, and returns whether it is on and how many files that is. It stays
+// off when the user owns none of the files.
+func (m *PRInspectViewModel) ToggleOwnedOnly() (bool, int) {
+	owned := m.ownedFileCount()
+	if m.ownedOnly || owned == 0 {
+		m.ownedOnly = false
+		m.updateViewport()
+		return false, owned
+	}
+	m.ownedOnly = true
+	if !m.fileShown(m.currentFile) {
+		m.currentFile = m.firstOwnedFile()
+		m.currentLineIdx = 0
+		m.hOffset = 0
+	}
+	m.updateViewport()
+	return true, owned
+}
+
+// IsOwnedOnly reports whether only the files the user owns are shown.
+func (m *PRInspectViewModel) IsOwnedOnly() bool {
+	return m.ownedOnly
+}
+
+func (m *PRInspectViewModel) ownedFileCount() int {
+	if m.diff == nil {
+		return 0
+	}
+	count := 0
+	for _, file := range m.diff.Files {
+		if m.codeOwners.Owns(getFilePath(file)) {
+			count++
+		}
+	}
+	return count
+}
+
+// fileShown reports whether the file at index is one moving between files
+// stops at.
+func (m *PRInspectViewModel) fileShown(index int) bool {
+	return !m.ownedOnly || m.codeOwners.Owns(getFilePath(m.diff.Files[index]))
+}
+
+// firstOwnedFile returns the index of the first file shown, or 0 when none
+// is.
+func (m *PRInspectViewModel) firstOwnedFile() int {
+	if m.diff == nil {
+		return 0
+	}
+	for i := range m.diff.Files {
+		if m.fileShown(i) {
+			return i
+		}
+	}
+	return 0
+}
+
+// ownersSummary names the owners of file for its header, highlighted when
+// the user is one of them.
+func (m *PRInspectViewModel) ownersSummary(file domain.FileDiff) string {
+	owners := m.codeOwners.Owners(getFilePath(file))
+	if len(owners) == 0 {
+		return ""
+	}
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	if m.codeOwners.Owns(getFilePath(file)) {
+		style = lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Bold(true)
+	}
+	summary := "👤 " + strings.Join(owners, " ")
+	if m.ownedOnly {
+		summary += " · yours only"
+	}
+	return style.Render(summary)
+}
@@ -0,0 +1,66 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestCodeOwners_AnnotateHeadersAndFilterOwnedFiles(t *testing.T) {
+	view := NewPRInspectView()
+	view.SetSize(120, 30)
+	view.SetPR(&domain.PullRequest{Number: 1, Repository: domain.Repo{FullName: "acme/api"}})
+	view.SetDiff(&domain.Diff{Files: []domain.FileDiff{
+		{NewPath: "main.go"},
+		{NewPath: "docs/intro.md"},
+		{NewPath: "web/app.js"},
+		{NewPath: "docs/api.md"},
+	}})
+	view.SwitchToDiff()
+
+	if on, _ := view.ToggleOwnedOnly(); on {
+		t.Fatal("expected the filter to stay off without code owners")
+	}
+
+	view.SetCodeOwners(&domain.CodeOwners{
+		Rules:      domain.ParseCodeOwners("* @acme/core\n/docs/ @dana\n"),
+		Identities: []string{"@dana"},
+	})
+	if out := view.View(); !strings.Contains(out, "@acme/core") {
+		t.Errorf("expected the owners in the file header, got:\n%s", out)
+	}
+
+	on, owned := view.ToggleOwnedOnly()
+	if !on || owned != 2 {
+		t.Fatalf("expected the filter on with 2 owned files, got %v, %d", on, owned)
+	}
+	if file, _ := view.CurrentFile(); file.NewPath != "docs/intro.md" {
+		t.Errorf("expected to move to the first owned file, got %s", file.NewPath)
+	}
+	view.NextFile()
+	if file, _ := view.CurrentFile(); file.NewPath != "docs/api.md" {
+		t.Errorf("expected next to skip files owned by others, got %s", file.NewPath)
+	}
+	view.NextFile()
+	if file, _ := view.CurrentFile(); file.NewPath != "docs/api.md" {
+		t.Errorf("expected to stay on the last owned file, got %s", file.NewPath)
+	}
+	view.PrevFile()
+	if file, _ := view.CurrentFile(); file.NewPath != "docs/intro.md" {
+		t.Errorf("expected previous to skip files owned by others, got %s", file.NewPath)
+	}
+
+	if on, _ := view.ToggleOwnedOnly(); on {
+		t.Fatal("expected the filter to turn off")
+	}
+	view.NextFile()
+	if file, _ := view.CurrentFile(); file.NewPath != "web/app.js" {
+		t.Errorf("expected every file again, got %s", file.NewPath)
+	}
+
+	view.SetPR(&domain.PullRequest{Number: 2, Repository: domain.Repo{FullName: "acme/api"}})
+	if view.HasCodeOwners() || view.IsOwnedOnly() {
+		t.Error("expected opening another PR to forget the code owners")
+	}
+}
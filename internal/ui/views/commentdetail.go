@@ -2,12 +2,14 @@ package views
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/markdown"
 )
 
 type CommentDetailViewModel struct {
@@ -17,14 +19,29 @@ type CommentDetailViewModel struct {
 	width    int
 	height   int
 	active   bool
+
+	mdRenderer *markdown.Renderer
+
+	// Comments in render order, with the viewport line each one starts on.
+	ordered  []domain.Comment
+	offsets  []int
+	selected int
+
+	// reactionChoices holds the reactions offered while picking one for
+	// the selected comment.
+	reactionChoices []string
+	// statusChoices holds the thread statuses offered while picking one
+	// for the thread of the selected comment.
+	statusChoices []domain.ThreadStatus
 }
 
 func NewCommentDetailView() *CommentDetailViewModel {
 	vp := viewport.New(0, 0)
 
 	return &CommentDetailViewModel{
-		viewport: vp,
-		active:   false,
+		viewport:   vp,
+		active:     false,
+		mdRenderer: markdown.NewRenderer(markdown.DefaultStyles()),
 	}
 }
 
@@ -33,23 +50,195 @@ func (m *CommentDetailViewModel) SetSize(width, height int) {
 	m.height = height
 	m.viewport.Width = width
 	m.viewport.Height = height - 10
+	// Comment bodies are rendered inside a bordered, padded box.
+	m.mdRenderer.SetWidth(width - 12)
+	if m.active {
+		m.updateViewport()
+		m.scrollToSelected()
+	}
 }
 
 func (m *CommentDetailViewModel) Activate(comments []domain.Comment, diff *domain.Diff) {
 	m.active = true
 	m.comments = comments
 	m.diff = diff
+	m.selected = 0
 	m.updateViewport()
+	m.viewport.GotoTop()
 }
 
 func (m *CommentDetailViewModel) Deactivate() {
 	m.active = false
+	m.reactionChoices = nil
+	m.statusChoices = nil
 }
 
 func (m *CommentDetailViewModel) IsActive() bool {
 	return m.active
 }
 
+func (m *CommentDetailViewModel) SelectNext() {
+	if m.selected < len(m.ordered)-1 {
+		m.selected++
+		m.updateViewport()
+		m.scrollToSelected()
+	}
+}
+
+func (m *CommentDetailViewModel) SelectPrev() {
+	if m.selected > 0 {
+		m.selected--
+		m.updateViewport()
+		m.scrollToSelected()
+	}
+}
+
+// SelectComment selects the first comment on the same file and line as
+// comment and scrolls to it.
+func (m *CommentDetailViewModel) SelectComment(comment domain.Comment) bool {
+	for i, c := range m.ordered {
+		if c.FilePath == comment.FilePath && c.Line == comment.Line {
+			m.selected = i
+			m.updateViewport()
+			m.scrollToSelected()
+			return true
+		}
+	}
+	return false
+}
+
+func (m *CommentDetailViewModel) GetSelectedComment() *domain.Comment {
+	if m.selected < 0 || m.selected >= len(m.ordered) {
+		return nil
+	}
+	return &m.ordered[m.selected]
+}
+
+// SelectedCodeLens returns the first identifier referenced by the selected
+// comment that can be found in the diff.
+func (m *CommentDetailViewModel) SelectedCodeLens() *CodeLens {
+	comment := m.GetSelectedComment()
+	if comment == nil {
+		return nil
+	}
+	lenses := FindCodeLenses(comment.Body, m.diff)
+	if len(lenses) == 0 {
+		return nil
+	}
+	return &lenses[0]
+}
+
+// StartReactionPicker offers choices as numbered reactions for the selected
+// comment. It reports false when no comment is selected.
+func (m *CommentDetailViewModel) StartReactionPicker(choices []string) bool {
+	if m.GetSelectedComment() == nil || len(choices) == 0 {
+		return false
+	}
+	m.reactionChoices = choices
+	return true
+}
+
+func (m *CommentDetailViewModel) IsPickingReaction() bool {
+	return len(m.reactionChoices) > 0
+}
+
+func (m *CommentDetailViewModel) CancelReactionPicker() {
+	m.reactionChoices = nil
+}
+
+// PickReaction ends the picker with the reaction numbered key ("1" for the
+// first one). It reports false, leaving the picker open, for other keys.
+func (m *CommentDetailViewModel) PickReaction(key string) (string, bool) {
+	if len(key) != 1 || key[0] < '1' || int(key[0]-'1') >= len(m.reactionChoices) {
+		return "", false
+	}
+	content := m.reactionChoices[key[0]-'1']
+	m.reactionChoices = nil
+	return content, true
+}
+
+// AddReaction counts one more reaction of content on comment and returns
+// the updated comments.
+func (m *CommentDetailViewModel) AddReaction(comment domain.Comment, content string) []domain.Comment {
+	updated := make([]domain.Comment, len(m.comments))
+	copy(updated, m.comments)
+	for i := range updated {
+		if sameComment(updated[i], comment) {
+			updated[i].Reactions = domain.AddReaction(updated[i].Reactions, content)
+		}
+	}
+	m.comments = updated
+	if m.active {
+		offset := m.viewport.YOffset
+		m.updateViewport()
+		m.viewport.SetYOffset(offset)
+	}
+	return updated
+}
+
+// StartStatusPicker offers choices as numbered statuses for the thread of
+// the selected comment. It reports false when no comment of a thread is
+// selected.
+func (m *CommentDetailViewModel) StartStatusPicker(choices []domain.ThreadStatus) bool {
+	comment := m.GetSelectedComment()
+	if comment == nil || comment.ThreadID == "" || len(choices) == 0 {
+		return false
+	}
+	m.statusChoices = choices
+	return true
+}
+
+func (m *CommentDetailViewModel) IsPickingStatus() bool {
+	return len(m.statusChoices) > 0
+}
+
+func (m *CommentDetailViewModel) CancelStatusPicker() {
+	m.statusChoices = nil
+}
+
+// PickStatus ends the picker with the status numbered key ("1" for the
+// first one). It reports false, leaving the picker open, for other keys.
+func (m *CommentDetailViewModel) PickStatus(key string) (domain.ThreadStatus, bool) {
+	if len(key) != 1 || key[0] < '1' || int(key[0]-'1') >= len(m.statusChoices) {
+		return "", false
+	}
+	status := m.statusChoices[key[0]-'1']
+	m.statusChoices = nil
+	return status, true
+}
+
+// SetThreadStatus sets the status of every comment in the thread of comment
+// and returns the updated comments.
+func (m *CommentDetailViewModel) SetThreadStatus(comment domain.Comment, status domain.ThreadStatus) []domain.Comment {
+	updated := make([]domain.Comment, len(m.comments))
+	copy(updated, m.comments)
+	for i := range updated {
+		if comment.ThreadID != "" && updated[i].ThreadID == comment.ThreadID {
+			updated[i].ThreadStatus = status
+		}
+	}
+	m.comments = updated
+	if m.active {
+		offset := m.viewport.YOffset
+		m.updateViewport()
+		m.viewport.SetYOffset(offset)
+	}
+	return updated
+}
+
+// sameComment compares the provider identity of two comments. GitHub
+// numbers inline and conversation comments separately, and Azure DevOps
+// numbers comments per thread.
+func sameComment(a, b domain.Comment) bool {
+	return a.ID == b.ID && a.ThreadID == b.ThreadID && (a.FilePath == "") == (b.FilePath == "")
+}
+
+func (m *CommentDetailViewModel) scrollToSelected() {
+	if m.selected < len(m.offsets) {
+		m.viewport.SetYOffset(m.offsets[m.selected])
+	}
+}
+
 func (m *CommentDetailViewModel) Update(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
 	m.viewport, cmd = m.viewport.Update(msg)
@@ -63,13 +252,31 @@ func (m *CommentDetailViewModel) View() string {
 		Foreground(lipgloss.Color("#6B7280")).
 		Italic(true)
 
-	help := helpStyle.Render("\nq/Esc: Back to Diff")
+	help := helpStyle.Render("\ntab/shift+tab: Select comment | Enter: Go to commented line | g: Go to referenced code | +: React | s: Thread status | q/Esc: Back to Diff")
+	if m.IsPickingStatus() {
+		choices := make([]string, len(m.statusChoices))
+		for i, status := range m.statusChoices {
+			choices[i] = fmt.Sprintf("%d %s", i+1, domain.ThreadStatusLabel(status))
+		}
+		pickerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Bold(true)
+		help = "\n" + pickerStyle.Render("Thread status: "+strings.Join(choices, "  ")) + helpStyle.Render(" | Esc: Cancel")
+	}
+	if m.IsPickingReaction() {
+		choices := make([]string, len(m.reactionChoices))
+		for i, content := range m.reactionChoices {
+			choices[i] = fmt.Sprintf("%d %s", i+1, domain.ReactionEmoji(content))
+		}
+		pickerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Bold(true)
+		help = "\n" + pickerStyle.Render("React: "+strings.Join(choices, "  ")) + helpStyle.Render(" | Esc: Cancel")
+	}
 
 	return content + "\n" + help
 }
 
 func (m *CommentDetailViewModel) updateViewport() {
 	var b strings.Builder
+	m.ordered = m.ordered[:0]
+	m.offsets = m.offsets[:0]
 
 	titleStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#7C3AED")).
@@ -100,7 +307,10 @@ func (m *CommentDetailViewModel) updateViewport() {
 		}
 	}
 
-	// Render general/review-level comments first
+	// The conversation comes first, oldest comment at the top as on the web.
+	sort.SliceStable(generalComments, func(i, j int) bool {
+		return generalComments[i].CreatedAt.Before(generalComments[j].CreatedAt)
+	})
 	if len(generalComments) > 0 {
 		sectionHeaderStyle := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#F59E0B")).
@@ -108,7 +318,7 @@ func (m *CommentDetailViewModel) updateViewport() {
 			Underline(true).
 			Padding(0, 0, 1, 0)
 
-		b.WriteString(sectionHeaderStyle.Render("Review Comments"))
+		b.WriteString(sectionHeaderStyle.Render(fmt.Sprintf("Conversation (%d)", len(generalComments))))
 		b.WriteString("\n\n")
 
 		for _, comment := range generalComments {
@@ -131,11 +341,16 @@ func (m *CommentDetailViewModel) updateViewport() {
 		b.WriteString("\n\n")
 
 		commentsByFile := make(map[string][]domain.Comment)
+		var filePaths []string
 		for _, comment := range inlineComments {
+			if _, ok := commentsByFile[comment.FilePath]; !ok {
+				filePaths = append(filePaths, comment.FilePath)
+			}
 			commentsByFile[comment.FilePath] = append(commentsByFile[comment.FilePath], comment)
 		}
 
-		for filePath, fileComments := range commentsByFile {
+		for _, filePath := range filePaths {
+			fileComments := commentsByFile[filePath]
 			fileHeaderStyle := lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#3B82F6")).
 				Bold(true).
@@ -158,6 +373,10 @@ func (m *CommentDetailViewModel) updateViewport() {
 }
 
 func (m *CommentDetailViewModel) renderComment(b *strings.Builder, comment domain.Comment) {
+	isSelected := len(m.ordered) == m.selected
+	m.offsets = append(m.offsets, strings.Count(b.String(), "\n"))
+	m.ordered = append(m.ordered, comment)
+
 	metaStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#6B7280")).
 		Italic(true)
@@ -167,7 +386,6 @@ func (m *CommentDetailViewModel) renderComment(b *strings.Builder, comment domai
 		Bold(true)
 
 	commentStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#10B981")).
 		Padding(0, 2)
 
 	codeStyle := lipgloss.NewStyle().
@@ -178,6 +396,13 @@ func (m *CommentDetailViewModel) renderComment(b *strings.Builder, comment domai
 		BorderForeground(lipgloss.Color("#374151")).
 		Padding(1, 2).
 		Width(m.width - 4)
+	if isSelected {
+		boxStyle = boxStyle.BorderForeground(lipgloss.Color("#F59E0B"))
+	}
+
+	lensStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#3B82F6")).
+		Italic(true)
 
 	var content strings.Builder
 
@@ -185,6 +410,9 @@ func (m *CommentDetailViewModel) renderComment(b *strings.Builder, comment domai
 	if comment.Line > 0 {
 		header += metaStyle.Render(fmt.Sprintf(" on line %d", comment.Line))
 	}
+	if badge := threadStatusBadge(comment.ThreadStatus); badge != "" {
+		header += " " + badge
+	}
 	content.WriteString(header)
 	content.WriteString("\n\n")
 
@@ -196,7 +424,16 @@ func (m *CommentDetailViewModel) renderComment(b *strings.Builder, comment domai
 		}
 	}
 
-	content.WriteString(commentStyle.Render(comment.Body))
+	content.WriteString(commentStyle.Render(m.mdRenderer.Render(comment.Body)))
+	if reactions := formatReactions(comment.Reactions); reactions != "" {
+		content.WriteString("\n\n")
+		content.WriteString(commentStyle.Render(reactions))
+	}
+
+	for _, lens := range FindCodeLenses(comment.Body, m.diff) {
+		content.WriteString("\n")
+		content.WriteString(lensStyle.Render(fmt.Sprintf("⌖ `%s` → %s:%d", lens.Identifier, lens.FilePath, lens.Line)))
+	}
 
 	b.WriteString(boxStyle.Render(content.String()))
 }
@@ -232,3 +469,30 @@ func (m *CommentDetailViewModel) getCodeContext(comment domain.Comment) string {
 
 	return ""
 }
+
+// threadStatusBadge renders the status of a comment thread, highlighting
+// the threads that still await a response.
+func threadStatusBadge(status domain.ThreadStatus) string {
+	if status == "" {
+		return ""
+	}
+	color := lipgloss.Color("#6B7280")
+	switch {
+	case status.IsOpen():
+		color = lipgloss.Color("#F59E0B")
+	case status == domain.ThreadFixed:
+		color = lipgloss.Color("#10B981")
+	}
+	return lipgloss.NewStyle().Foreground(color).Render("[" + domain.ThreadStatusLabel(status) + "]")
+}
+
+// formatReactions renders reaction counts as "👍 3  🎉 1".
+func formatReactions(reactions []domain.Reaction) string {
+	parts := make([]string, 0, len(reactions))
+	for _, reaction := range reactions {
+		if reaction.Count > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", domain.ReactionEmoji(reaction.Content), reaction.Count))
+		}
+	}
+	return strings.Join(parts, "  ")
+}
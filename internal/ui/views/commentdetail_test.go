@@ -3,6 +3,7 @@ package views
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/johanforsgren/lgtmfaster/internal/domain"
 )
@@ -184,3 +185,130 @@ func TestCommentDetailView_ShowsCodeContext(t *testing.T) {
 		t.Error("expected output to contain code context")
 	}
 }
+
+func TestCommentDetailView_SelectedCodeLens(t *testing.T) {
+	view := NewCommentDetailView()
+	view.SetSize(100, 40)
+	view.Activate([]domain.Comment{
+		{ID: "1", Body: "Looks good", Author: domain.User{Username: "a"}},
+		{ID: "2", Body: "Rename `newStore`?", FilePath: "cache.go", Line: 2, Author: domain.User{Username: "b"}},
+	}, codeLensTestDiff())
+
+	if lens := view.SelectedCodeLens(); lens != nil {
+		t.Errorf("expected no lens for the first comment, got %+v", lens)
+	}
+
+	view.SelectNext()
+	lens := view.SelectedCodeLens()
+	if lens == nil {
+		t.Fatal("expected a lens for the second comment")
+	}
+	if lens.FilePath != "cache.go" || lens.LineIndex != 1 {
+		t.Errorf("unexpected lens: %+v", lens)
+	}
+	if !strings.Contains(view.View(), "cache.go:2") {
+		t.Error("expected the lens to be rendered under the comment")
+	}
+
+	view.SelectNext()
+	if view.GetSelectedComment().ID != "2" {
+		t.Error("expected selection to stop at the last comment")
+	}
+	view.SelectPrev()
+	if view.GetSelectedComment().ID != "1" {
+		t.Error("expected selection to move back to the first comment")
+	}
+}
+
+func TestCommentDetailView_ConversationSectionInChronologicalOrder(t *testing.T) {
+	view := NewCommentDetailView()
+	view.SetSize(100, 40)
+
+	now := time.Now()
+	view.Activate([]domain.Comment{
+		{ID: "1", Body: "Inline", FilePath: "main.go", Line: 2, Author: domain.User{Username: "alice"}},
+		{ID: "2", Body: "Second", CreatedAt: now, Author: domain.User{Username: "bob"}},
+		{ID: "3", Body: "First", CreatedAt: now.Add(-time.Hour), Author: domain.User{Username: "carol"}},
+	}, nil)
+
+	content := view.viewport.View()
+	if !strings.Contains(content, "Conversation (2)") {
+		t.Error("expected a Conversation section")
+	}
+	if strings.Index(content, "First") > strings.Index(content, "Second") {
+		t.Error("expected the conversation to be in chronological order")
+	}
+	if strings.Index(content, "Second") > strings.Index(content, "Inline") {
+		t.Error("expected the conversation before the inline comments")
+	}
+	if selected := view.GetSelectedComment(); selected == nil || selected.ID != "3" {
+		t.Errorf("expected the oldest conversation comment to be selected first, got %+v", selected)
+	}
+}
+
+func TestCommentDetailView_ReactionPicker(t *testing.T) {
+	view := NewCommentDetailView()
+	view.SetSize(100, 40)
+	view.Activate([]domain.Comment{
+		{ID: "1", Body: "Ship it", Reactions: []domain.Reaction{{Content: domain.ReactionThumbsUp, Count: 3}}},
+	}, nil)
+
+	if !strings.Contains(view.viewport.View(), "👍 3") {
+		t.Error("expected the reaction count to be shown")
+	}
+
+	if !view.StartReactionPicker([]string{domain.ReactionThumbsUp, domain.ReactionRocket}) {
+		t.Fatal("expected the picker to open")
+	}
+	if !strings.Contains(view.View(), "2 🚀") {
+		t.Error("expected the numbered choices to be shown")
+	}
+	if _, ok := view.PickReaction("3"); ok || !view.IsPickingReaction() {
+		t.Error("expected an out of range choice to keep the picker open")
+	}
+	content, ok := view.PickReaction("2")
+	if !ok || content != domain.ReactionRocket || view.IsPickingReaction() {
+		t.Fatalf("expected the rocket to be picked, got %q", content)
+	}
+
+	updated := view.AddReaction(domain.Comment{ID: "1"}, content)
+	if len(updated[0].Reactions) != 2 || updated[0].Reactions[1] != (domain.Reaction{Content: domain.ReactionRocket, Count: 1}) {
+		t.Errorf("unexpected reactions: %+v", updated[0].Reactions)
+	}
+	if !strings.Contains(view.viewport.View(), "🚀 1") {
+		t.Error("expected the new reaction to be shown")
+	}
+}
+
+func TestCommentDetailView_ThreadStatus(t *testing.T) {
+	view := NewCommentDetailView()
+	view.SetSize(100, 40)
+	view.Activate([]domain.Comment{
+		{ID: "1", ThreadID: "4", Body: "Rename this", ThreadStatus: domain.ThreadActive},
+		{ID: "2", Body: "No thread"},
+	}, nil)
+
+	if !strings.Contains(view.viewport.View(), "[Active]") {
+		t.Error("expected the thread status to be shown")
+	}
+	if !view.StartStatusPicker([]domain.ThreadStatus{domain.ThreadActive, domain.ThreadFixed}) {
+		t.Fatal("expected the picker to open")
+	}
+	if !strings.Contains(view.View(), "2 Resolved") {
+		t.Error("expected the numbered statuses to be shown")
+	}
+	status, ok := view.PickStatus("2")
+	if !ok || status != domain.ThreadFixed || view.IsPickingStatus() {
+		t.Fatalf("expected resolved to be picked, got %q", status)
+	}
+
+	view.SetThreadStatus(domain.Comment{ThreadID: "4"}, status)
+	if !strings.Contains(view.viewport.View(), "[Resolved]") {
+		t.Error("expected the new status to be shown")
+	}
+
+	view.SelectNext()
+	if view.StartStatusPicker([]domain.ThreadStatus{domain.ThreadActive}) {
+		t.Error("expected no picker for a comment outside a thread")
+	}
+}
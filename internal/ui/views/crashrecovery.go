@@ -0,0 +1,140 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+// CrashRecoveryViewModel is shown on the launch after a crash. It points to
+// the crash report and offers to go back to the PRs of the drafts that were
+// kept.
+type CrashRecoveryViewModel struct {
+	active bool
+	width  int
+	height int
+	report string
+	drafts []domain.EditorDraft
+	cursor int
+}
+
+func NewCrashRecoveryView() *CrashRecoveryViewModel {
+	return &CrashRecoveryViewModel{}
+}
+
+func (m *CrashRecoveryViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *CrashRecoveryViewModel) Activate(report string, drafts []domain.EditorDraft) {
+	m.active = true
+	m.report = report
+	m.drafts = drafts
+	m.cursor = 0
+}
+
+func (m *CrashRecoveryViewModel) Deactivate() {
+	m.active = false
+	m.drafts = nil
+}
+
+func (m *CrashRecoveryViewModel) IsActive() bool {
+	return m.active
+}
+
+func (m *CrashRecoveryViewModel) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+func (m *CrashRecoveryViewModel) MoveDown() {
+	if m.cursor < len(m.drafts)-1 {
+		m.cursor++
+	}
+}
+
+// SelectedDraft returns nil when there are no drafts.
+func (m *CrashRecoveryViewModel) SelectedDraft() *domain.EditorDraft {
+	if m.cursor >= len(m.drafts) {
+		return nil
+	}
+	draft := m.drafts[m.cursor]
+	return &draft
+}
+
+func (m *CrashRecoveryViewModel) Update(msg tea.Msg) tea.Cmd {
+	return nil
+}
+
+func (m *CrashRecoveryViewModel) View() string {
+	if !m.active {
+		return ""
+	}
+
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#EF4444")).
+		Bold(true).
+		Padding(1, 0)
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("15"))
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280"))
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true)
+
+	b.WriteString(titleStyle.Render("LGTMFaster crashed last time"))
+	b.WriteString("\n\n")
+	if m.report != "" {
+		b.WriteString(infoStyle.Render("The crash report was saved to " + m.report))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(infoStyle.Render(fmt.Sprintf("Unsent text was kept in %d draft(s):", len(m.drafts))))
+	b.WriteString("\n")
+	for i, draft := range m.drafts {
+		line := describeDraft(draft)
+		if i == m.cursor {
+			b.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			b.WriteString(infoStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+		if !draft.SavedAt.IsZero() {
+			b.WriteString(mutedStyle.Render("    Saved " + formatAge(draft.SavedAt)))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	b.WriteString(helpStyle.Render("↑/↓: Select | Enter: Go to its PR | Esc: Later"))
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("Drafts are restored when their editor is reopened"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#EF4444")).
+		Padding(1, 2).
+		Width(min(80, m.width-4))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(b.String()))
+}
+
+func describeDraft(draft domain.EditorDraft) string {
+	pr := draft.PRKey
+	if draft.PRTitle != "" {
+		pr += " " + draft.PRTitle
+	}
+	return fmt.Sprintf("%s (%s)", pr, draft.Editor)
+}
@@ -0,0 +1,32 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestCrashRecoveryView_ListsDrafts(t *testing.T) {
+	v := NewCrashRecoveryView()
+	v.SetSize(120, 40)
+	v.Activate("/tmp/crash.log", []domain.EditorDraft{
+		{PRKey: "github:acme/api/42", PRTitle: "Add retries", Editor: "review", SavedAt: time.Now()},
+		{PRKey: "github:acme/web/7", Editor: "comment on main.go:12"},
+	})
+
+	view := v.View()
+	for _, want := range []string{"/tmp/crash.log", "2 draft(s)", "github:acme/api/42 Add retries (review)", "github:acme/web/7 (comment on main.go:12)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the view", want)
+		}
+	}
+
+	v.MoveUp()
+	v.MoveDown()
+	v.MoveDown()
+	if draft := v.SelectedDraft(); draft == nil || draft.PRKey != "github:acme/web/7" {
+		t.Errorf("expected the cursor to stop on the last draft, got %+v", draft)
+	}
+}
@@ -0,0 +1,38 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+// renderDeferredFile stands in for the diff lines of a file whose hunks are
+// still being loaded, or failed to load with loadErr.
+func renderDeferredFile(file domain.FileDiff, loadErr string) string {
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280")).Italic(true)
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+
+	var b strings.Builder
+	if loadErr != "" {
+		b.WriteString(errorStyle.Render("Could not load the diff of this file: " + loadErr))
+	} else {
+		b.WriteString(valueStyle.Render("Loading the diff of this file..."))
+	}
+	b.WriteString("\n")
+	if stats := deferredFileStats(file); stats != "" {
+		b.WriteString("\n" + mutedStyle.Render(stats) + "\n")
+	}
+	return b.String()
+}
+
+// deferredFileStats returns the line counts listed for a deferred file, or
+// "" when the provider lists none.
+func deferredFileStats(file domain.FileDiff) string {
+	if file.Additions == 0 && file.Deletions == 0 {
+		return ""
+	}
+	return fmt.Sprintf("+%d -%d lines", file.Additions, file.Deletions)
+}
@@ -0,0 +1,272 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+type dependencyItem struct {
+	pr       domain.PullRequest
+	update   domain.DependencyUpdate
+	selected bool
+}
+
+// DependenciesViewModel lists the dependency bot PRs of the PR list so that
+// they can be approved and merged in one batch.
+type DependenciesViewModel struct {
+	active     bool
+	confirming bool
+	running    bool
+	width      int
+	height     int
+	cursor     int
+	items      []dependencyItem
+}
+
+func NewDependenciesView() *DependenciesViewModel {
+	return &DependenciesViewModel{}
+}
+
+func (m *DependenciesViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Activate lists the dependency updates among prs, all of them selected.
+func (m *DependenciesViewModel) Activate(prs []domain.PullRequest) {
+	m.active = true
+	m.confirming = false
+	m.running = false
+	m.cursor = 0
+	m.items = nil
+	for _, pr := range prs {
+		if update, ok := domain.ParseDependencyUpdate(pr); ok {
+			m.items = append(m.items, dependencyItem{pr: pr, update: update, selected: true})
+		}
+	}
+}
+
+func (m *DependenciesViewModel) Deactivate() {
+	m.active = false
+	m.confirming = false
+	m.running = false
+	m.items = nil
+}
+
+func (m *DependenciesViewModel) IsActive() bool {
+	return m.active
+}
+
+func (m *DependenciesViewModel) Next() {
+	if m.cursor < len(m.items)-1 {
+		m.cursor++
+	}
+}
+
+func (m *DependenciesViewModel) Prev() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+func (m *DependenciesViewModel) ToggleSelected() {
+	if m.cursor < len(m.items) {
+		m.items[m.cursor].selected = !m.items[m.cursor].selected
+	}
+}
+
+// ToggleAll selects every update, or clears the selection when all of them
+// are already selected.
+func (m *DependenciesViewModel) ToggleAll() {
+	all := len(m.SelectedPRs()) == len(m.items)
+	for i := range m.items {
+		m.items[i].selected = !all
+	}
+}
+
+func (m *DependenciesViewModel) SelectedPRs() []domain.PullRequest {
+	var prs []domain.PullRequest
+	for _, item := range m.items {
+		if item.selected {
+			prs = append(prs, item.pr)
+		}
+	}
+	return prs
+}
+
+// CurrentChangelogURL returns the changelog link of the update under the
+// cursor, falling back to the PR itself.
+func (m *DependenciesViewModel) CurrentChangelogURL() string {
+	if m.cursor >= len(m.items) {
+		return ""
+	}
+	item := m.items[m.cursor]
+	if item.update.ChangelogURL != "" {
+		return item.update.ChangelogURL
+	}
+	return item.pr.URL
+}
+
+// StartConfirm asks for confirmation before the selected updates are
+// approved and merged.
+func (m *DependenciesViewModel) StartConfirm() {
+	m.confirming = true
+}
+
+func (m *DependenciesViewModel) CancelConfirm() {
+	m.confirming = false
+}
+
+func (m *DependenciesViewModel) IsConfirming() bool {
+	return m.confirming
+}
+
+// SetRunning shows that the batch is in progress and ignores further input.
+func (m *DependenciesViewModel) SetRunning() {
+	m.confirming = false
+	m.running = true
+}
+
+func (m *DependenciesViewModel) IsRunning() bool {
+	return m.running
+}
+
+func (m *DependenciesViewModel) Update(msg tea.Msg) tea.Cmd {
+	return nil
+}
+
+func (m *DependenciesViewModel) View() string {
+	if !m.active {
+		return ""
+	}
+
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true).
+		Padding(1, 0)
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Dependency updates (%d)", len(m.items))))
+	b.WriteString("\n\n")
+
+	if len(m.items) == 0 {
+		b.WriteString(mutedStyle.Render("No dependency bot PRs in the list"))
+		b.WriteString("\n")
+	}
+	for i, item := range m.items {
+		b.WriteString(m.renderItem(item, i == m.cursor))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	selected := len(m.SelectedPRs())
+	switch {
+	case m.running:
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Render(fmt.Sprintf("Approving and merging %d PR(s)...", selected)))
+	case m.confirming:
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Bold(true).Render(fmt.Sprintf("Approve and merge %d PR(s)?", selected)))
+		b.WriteString("\n\n")
+		b.WriteString(mutedStyle.Render("Enter/y: Confirm | Esc/n: Back"))
+	default:
+		b.WriteString(mutedStyle.Render("↑↓: Navigate | Space: Toggle | a: Toggle all | o: Open changelog | Enter: Approve & merge | Esc: Close"))
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(min(100, m.width-4))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(b.String()))
+}
+
+func (m *DependenciesViewModel) renderItem(item dependencyItem, current bool) string {
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	marker := "  "
+	if current {
+		marker = "► "
+		nameStyle = nameStyle.Foreground(lipgloss.Color("#7C3AED")).Bold(true)
+	}
+	checkbox := "[ ]"
+	if item.selected {
+		checkbox = "[x]"
+	}
+
+	line := fmt.Sprintf("%s%s %s %s", marker, checkbox,
+		mutedStyle.Render(fmt.Sprintf("%s#%d", item.pr.Repository.FullName, item.pr.Number)),
+		nameStyle.Render(item.update.Package))
+	if delta := versionDelta(item.update); delta != "" {
+		line += " " + delta
+	}
+	if item.pr.ApprovalStatus == domain.ApprovalStatusChangesRequested {
+		line += lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render("  (changes requested)")
+	}
+
+	if current && item.update.ChangelogURL != "" {
+		line += "\n      " + mutedStyle.Render(item.update.ChangelogURL)
+	}
+	return line
+}
+
+// versionDelta renders "from → to" colored by the kind of semantic version
+// bump: red for major, yellow for minor and green for patch updates.
+func versionDelta(update domain.DependencyUpdate) string {
+	if update.To == "" {
+		return ""
+	}
+
+	color := lipgloss.Color("#D1D5DB")
+	switch versionBump(update.From, update.To) {
+	case "major":
+		color = lipgloss.Color("#EF4444")
+	case "minor":
+		color = lipgloss.Color("#F59E0B")
+	case "patch":
+		color = lipgloss.Color("#10B981")
+	}
+
+	delta := "→ " + update.To
+	if update.From != "" {
+		delta = update.From + " " + delta
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(delta)
+}
+
+// versionBump returns "major", "minor" or "patch" for the first differing
+// component of two dotted versions, or "" when they cannot be compared.
+func versionBump(from, to string) string {
+	if from == "" {
+		return ""
+	}
+	fromParts := strings.Split(from, ".")
+	toParts := strings.Split(to, ".")
+	for i := 0; i < len(fromParts) && i < len(toParts); i++ {
+		a, errA := strconv.Atoi(fromParts[i])
+		b, errB := strconv.Atoi(toParts[i])
+		if errA != nil || errB != nil {
+			return ""
+		}
+		if a == b {
+			continue
+		}
+		switch i {
+		case 0:
+			return "major"
+		case 1:
+			return "minor"
+		}
+		return "patch"
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestDependenciesView_SelectionAndConfirm(t *testing.T) {
+	view := NewDependenciesView()
+	view.SetSize(120, 40)
+	view.Activate([]domain.PullRequest{
+		{Number: 1, Title: "Bump lodash from 4.17.20 to 4.17.21", Author: domain.User{Username: "dependabot[bot]"}, Repository: domain.Repo{FullName: "acme/web"}},
+		{Number: 2, Title: "Fix login", Author: domain.User{Username: "alice"}},
+		{Number: 3, Title: "Update dependency react to v18.2.0", Author: domain.User{Username: "renovate[bot]"}, URL: "https://example.com/pr/3"},
+	})
+
+	if got := len(view.SelectedPRs()); got != 2 {
+		t.Fatalf("expected both bot PRs to be selected, got %d", got)
+	}
+	output := view.View()
+	if !strings.Contains(output, "lodash") || !strings.Contains(output, "4.17.20 → 4.17.21") {
+		t.Error("expected the package and version delta to be shown")
+	}
+	if strings.Contains(output, "Fix login") {
+		t.Error("expected PRs by people to be left out")
+	}
+
+	view.ToggleSelected()
+	if selected := view.SelectedPRs(); len(selected) != 1 || selected[0].Number != 3 {
+		t.Errorf("expected only #3 after deselecting #1, got %v", selected)
+	}
+	view.Next()
+	if got := view.CurrentChangelogURL(); got != "https://example.com/pr/3" {
+		t.Errorf("expected the PR URL without a changelog link, got %q", got)
+	}
+
+	view.ToggleAll()
+	if got := len(view.SelectedPRs()); got != 2 {
+		t.Errorf("expected toggle all to select everything, got %d", got)
+	}
+
+	view.StartConfirm()
+	if !strings.Contains(view.View(), "Approve and merge 2 PR(s)?") {
+		t.Error("expected a single confirmation for the batch")
+	}
+}
+
+func TestVersionBump(t *testing.T) {
+	tests := []struct{ from, to, want string }{
+		{"1.2.3", "2.0.0", "major"},
+		{"1.2.3", "1.3.0", "minor"},
+		{"1.2.3", "1.2.4", "patch"},
+		{"", "1.2.4", ""},
+		{"1.2.3-beta", "1.2.3", ""},
+	}
+	for _, tt := range tests {
+		if got := versionBump(tt.from, tt.to); got != tt.want {
+			t.Errorf("versionBump(%q, %q) = %q, want %q", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
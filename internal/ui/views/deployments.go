@@ -0,0 +1,72 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+// SetDeployments sets the deployments of the PR shown in the description
+// view.
+func (m *PRInspectViewModel) SetDeployments(deployments []domain.Deployment) {
+	m.deployments = deployments
+	m.updateViewport()
+}
+
+// PreviewURL returns the URL of the first active deployment of the PR, or ""
+// when none can be visited.
+func (m *PRInspectViewModel) PreviewURL() string {
+	for _, deployment := range m.deployments {
+		if deployment.IsActive() {
+			return deployment.URL
+		}
+	}
+	return ""
+}
+
+func deploymentStateLabel(state domain.DeploymentState) string {
+	switch state {
+	case domain.DeploymentSuccess:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Render("✓ deployed")
+	case domain.DeploymentFailure, domain.DeploymentError:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render("✗ failed")
+	case domain.DeploymentInactive:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280")).Render("○ inactive")
+	case domain.DeploymentInProgress:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Render("● deploying")
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Render("… queued")
+}
+
+// renderDeployments lists the environments a PR is deployed to for the
+// description view, pointing out the preview ctrl+o opens.
+func renderDeployments(deployments []domain.Deployment) string {
+	if len(deployments) == 0 {
+		return ""
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Bold(true)
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	urlStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#60A5FA")).Underline(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Deployments"))
+	b.WriteString("\n")
+
+	preview := false
+	for _, deployment := range deployments {
+		line := "  " + deploymentStateLabel(deployment.State) + " " + nameStyle.Render(deployment.Environment)
+		if deployment.URL != "" {
+			line += " " + urlStyle.Render(deployment.URL)
+		}
+		if deployment.IsActive() && !preview {
+			preview = true
+			line += mutedStyle.Render(" (ctrl+o to open)")
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
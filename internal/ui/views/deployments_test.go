@@ -0,0 +1,45 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestDeployments_ListedInDescriptionWithThePreviewToOpen(t *testing.T) {
+	view := NewPRInspectView()
+	view.SetSize(120, 40)
+	view.SetPR(&domain.PullRequest{Number: 1, Repository: domain.Repo{FullName: "acme/web"}})
+
+	if url := view.PreviewURL(); url != "" {
+		t.Errorf("expected no preview before deployments load, got %q", url)
+	}
+
+	view.SetDeployments([]domain.Deployment{
+		{Environment: "storybook", State: domain.DeploymentInProgress},
+		{Environment: "preview", State: domain.DeploymentSuccess, URL: "https://pr-1.preview.acme.dev"},
+	})
+	out := view.View()
+	for _, want := range []string{"Deployments", "storybook", "deploying", "https://pr-1.preview.acme.dev", "ctrl+o"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the description, got:\n%s", want, out)
+		}
+	}
+	if url := view.PreviewURL(); url != "https://pr-1.preview.acme.dev" {
+		t.Errorf("expected the live deployment as preview, got %q", url)
+	}
+
+	view.SetDeployments([]domain.Deployment{
+		{Environment: "local", State: domain.DeploymentSuccess, URL: "file:///etc/passwd"},
+		{Environment: "script", State: domain.DeploymentSuccess, URL: "javascript:alert(1)"},
+	})
+	if url := view.PreviewURL(); url != "" {
+		t.Errorf("expected only web URLs to be opened, got %q", url)
+	}
+
+	view.SetPR(&domain.PullRequest{Number: 2, Repository: domain.Repo{FullName: "acme/web"}})
+	if url := view.PreviewURL(); url != "" {
+		t.Errorf("expected the deployments to be cleared for another PR, got %q", url)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
 )
 
 type DescriptionEditViewModel struct {
@@ -13,6 +14,8 @@ type DescriptionEditViewModel struct {
 	width    int
 	height   int
 	active   bool
+	mentions mentionCompleter
+	history  editHistory
 }
 
 func NewDescriptionEditView() *DescriptionEditViewModel {
@@ -38,10 +41,13 @@ func (m *DescriptionEditViewModel) Activate(currentDescription string) {
 	m.active = true
 	m.textarea.Focus()
 	m.textarea.SetValue(currentDescription)
+	m.mentions.close()
+	m.history.reset(&m.textarea)
 }
 
 func (m *DescriptionEditViewModel) Deactivate() {
 	m.active = false
+	m.mentions.close()
 	m.textarea.Blur()
 	m.textarea.SetValue("")
 }
@@ -60,14 +66,34 @@ func (m *DescriptionEditViewModel) GetValue() string {
 
 func (m *DescriptionEditViewModel) SetValue(value string) {
 	m.textarea.SetValue(value)
+	m.history.record(&m.textarea, nil)
 }
 
 func (m *DescriptionEditViewModel) Update(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
-	m.textarea, cmd = m.textarea.Update(msg)
+	if !m.history.handleKey(msg, &m.textarea) {
+		m.textarea, cmd = m.textarea.Update(msg)
+		m.history.record(&m.textarea, msg)
+	}
+	m.mentions.refresh(&m.textarea)
 	return cmd
 }
 
+// SetMentionCandidates sets the users suggested after typing "@".
+func (m *DescriptionEditViewModel) SetMentionCandidates(candidates []domain.MentionCandidate) {
+	m.mentions.setCandidates(candidates)
+}
+
+// HandleMentionKey lets the open mention suggestions handle key, reporting
+// whether they did.
+func (m *DescriptionEditViewModel) HandleMentionKey(msg tea.KeyMsg) bool {
+	if !m.mentions.handleKey(msg, &m.textarea) {
+		return false
+	}
+	m.history.record(&m.textarea, nil)
+	return true
+}
+
 func (m *DescriptionEditViewModel) View() string {
 	if !m.active {
 		return ""
@@ -83,13 +109,18 @@ func (m *DescriptionEditViewModel) View() string {
 	b.WriteString(titleStyle.Render("Edit PR Description"))
 	b.WriteString("\n\n")
 	b.WriteString(m.textarea.View())
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if suggestions := m.mentions.view(); suggestions != "" {
+		b.WriteString(suggestions)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	helpStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#6B7280")).
 		Italic(true)
 
-	help := "Ctrl+S: Save | Ctrl+G: Open in editor | Esc: Cancel"
+	help := "Ctrl+S: Save | Ctrl+G: Open in editor | Esc: Cancel | Ctrl+Z/Y: Undo/Redo"
 	b.WriteString(helpStyle.Render(help))
 
 	boxStyle := lipgloss.NewStyle().
@@ -0,0 +1,42 @@
+package views
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// DiffStyles holds the styles used to render diff lines, which the theme
+// passes in with SetDiffStyles. The shaded variants add a subtle background
+// to added and deleted lines.
+type DiffStyles struct {
+	Add              lipgloss.Style
+	Delete           lipgloss.Style
+	Context          lipgloss.Style
+	AddShaded        lipgloss.Style
+	DeleteShaded     lipgloss.Style
+	CursorBackground lipgloss.TerminalColor
+}
+
+// LineStyle returns the style for a diff line of the given type.
+func (s DiffStyles) LineStyle(lineType string, shaded bool) lipgloss.Style {
+	switch lineType {
+	case "add":
+		if shaded {
+			return s.AddShaded
+		}
+		return s.Add
+	case "delete":
+		if shaded {
+			return s.DeleteShaded
+		}
+		return s.Delete
+	default:
+		return s.Context
+	}
+}
+
+// DiffShadingSupported reports whether the terminal has enough colors to show
+// background shading.
+func DiffShadingSupported() bool {
+	return lipgloss.ColorProfile() < termenv.ANSI
+}
@@ -0,0 +1,111 @@
+package views
+
+import (
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxEditHistory caps the number of undo steps kept per activation.
+const maxEditHistory = 500
+
+// editState is the text of a textarea and where its cursor was.
+type editState struct {
+	value string
+	line  int
+	col   int
+}
+
+// editHistory lets the edits made to a textarea since it was activated be
+// undone with Ctrl+Z and redone with Ctrl+Y. Typing a word is one step, so
+// undoing does not go back a character at a time.
+type editHistory struct {
+	undo    []editState
+	redo    []editState
+	current editState
+	// typing is set while the current step is still collecting the
+	// characters of a word.
+	typing bool
+}
+
+func stateOf(ta *textarea.Model) editState {
+	info := ta.LineInfo()
+	return editState{value: ta.Value(), line: ta.Line(), col: info.StartColumn + info.ColumnOffset}
+}
+
+// reset forgets the history, starting over from the text in ta.
+func (h *editHistory) reset(ta *textarea.Model) {
+	h.undo = nil
+	h.redo = nil
+	h.current = stateOf(ta)
+	h.typing = false
+}
+
+// handleKey undoes on Ctrl+Z and redoes on Ctrl+Y, and reports whether msg
+// was one of them.
+func (h *editHistory) handleKey(msg tea.Msg, ta *textarea.Model) bool {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return false
+	}
+	switch key.String() {
+	case "ctrl+z":
+		h.step(&h.undo, &h.redo, ta)
+		return true
+	case "ctrl+y":
+		h.step(&h.redo, &h.undo, ta)
+		return true
+	}
+	return false
+}
+
+// step restores the last state of from, keeping the current one in to.
+func (h *editHistory) step(from, to *[]editState, ta *textarea.Model) {
+	if len(*from) == 0 {
+		return
+	}
+	*to = append(*to, stateOf(ta))
+	state := (*from)[len(*from)-1]
+	*from = (*from)[:len(*from)-1]
+
+	ta.SetValue(state.value)
+	for ta.Line() > state.line {
+		ta.CursorUp()
+	}
+	ta.SetCursor(state.col)
+	h.current = state
+	h.typing = false
+}
+
+// record takes note of the text in ta after msg was applied to it. An edit
+// starts a new step unless it types the next character of a word.
+func (h *editHistory) record(ta *textarea.Model, msg tea.Msg) {
+	state := stateOf(ta)
+	typing := typesWordCharacter(msg)
+	if state.value == h.current.value {
+		h.current = state
+		h.typing = h.typing && typing
+		return
+	}
+
+	if !typing || !h.typing {
+		h.undo = append(h.undo, h.current)
+		if len(h.undo) > maxEditHistory {
+			h.undo = h.undo[len(h.undo)-maxEditHistory:]
+		}
+	}
+	h.redo = nil
+	h.current = state
+	h.typing = typing
+}
+
+// typesWordCharacter reports whether msg types a single character that is
+// part of a word.
+func typesWordCharacter(msg tea.Msg) bool {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok || key.Type != tea.KeyRunes || key.Paste || len(key.Runes) != 1 {
+		return false
+	}
+	return !unicode.IsSpace(key.Runes[0])
+}
@@ -0,0 +1,61 @@
+package views
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestEditHistory_UndoesAndRedoesWords(t *testing.T) {
+	view := NewInlineCommentView()
+	view.SetSize(80, 24)
+	view.Activate("file.go:1")
+
+	typeText(view, "hello world")
+	undo := tea.KeyMsg{Type: tea.KeyCtrlZ}
+	redo := tea.KeyMsg{Type: tea.KeyCtrlY}
+
+	for _, want := range []string{"hello ", "hello", "", ""} {
+		view.Update(undo)
+		if got := view.GetValue(); got != want {
+			t.Fatalf("expected %q after undo, got %q", want, got)
+		}
+	}
+	for _, want := range []string{"hello", "hello ", "hello world", "hello world"} {
+		view.Update(redo)
+		if got := view.GetValue(); got != want {
+			t.Fatalf("expected %q after redo, got %q", want, got)
+		}
+	}
+
+	view.Update(undo)
+	typeText(view, "there")
+	view.Update(redo)
+	if got := view.GetValue(); got != "hello there" {
+		t.Errorf("expected a new edit to drop the redo steps, got %q", got)
+	}
+}
+
+func TestEditHistory_RestoresCursorAndExternalValues(t *testing.T) {
+	view := NewInlineCommentView()
+	view.SetSize(80, 24)
+	view.ActivateEdit("file.go:1", "first line\nsecond line")
+
+	view.Update(tea.KeyMsg{Type: tea.KeyUp})
+	view.Update(tea.KeyMsg{Type: tea.KeyHome})
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	view.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	if got := view.GetValue(); got != "first line\nsecond line" {
+		t.Fatalf("expected the edit to be undone back to the original comment, got %q", got)
+	}
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Y")})
+	if got := view.GetValue(); got != "Yfirst line\nsecond line" {
+		t.Errorf("expected the cursor to be back where the edit was made, got %q", got)
+	}
+
+	view.SetValue("from the editor")
+	view.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	if got := view.GetValue(); got != "Yfirst line\nsecond line" {
+		t.Errorf("expected a value from the external editor to be undoable, got %q", got)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/spell"
 )
 
 type InlineCommentViewModel struct {
@@ -13,7 +15,11 @@ type InlineCommentViewModel struct {
 	width    int
 	height   int
 	active   bool
+	editing  bool
 	lineInfo string
+	mentions mentionCompleter
+	spelling spellChecker
+	history  editHistory
 }
 
 func NewInlineCommentView() *InlineCommentViewModel {
@@ -40,10 +46,32 @@ func (m *InlineCommentViewModel) Activate(lineInfo string) {
 	m.lineInfo = lineInfo
 	m.textarea.Focus()
 	m.textarea.SetValue("")
+	m.editing = false
+	m.mentions.close()
+	m.spelling.close()
+	m.spelling.refresh(&m.textarea)
+	m.history.reset(&m.textarea)
+}
+
+// ActivateEdit opens the editor on an existing pending comment.
+func (m *InlineCommentViewModel) ActivateEdit(lineInfo, body string) {
+	m.Activate(lineInfo)
+	m.textarea.SetValue(body)
+	m.spelling.refresh(&m.textarea)
+	m.history.reset(&m.textarea)
+	m.editing = true
+}
+
+// IsEditing reports whether the editor was opened on a pending comment.
+func (m *InlineCommentViewModel) IsEditing() bool {
+	return m.editing
 }
 
 func (m *InlineCommentViewModel) Deactivate() {
 	m.active = false
+	m.mentions.close()
+	m.spelling.close()
+	m.editing = false
 	m.textarea.Blur()
 	m.textarea.SetValue("")
 }
@@ -62,14 +90,52 @@ func (m *InlineCommentViewModel) GetValue() string {
 
 func (m *InlineCommentViewModel) SetValue(value string) {
 	m.textarea.SetValue(value)
+	m.spelling.refresh(&m.textarea)
+	m.history.record(&m.textarea, nil)
 }
 
 func (m *InlineCommentViewModel) Update(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
-	m.textarea, cmd = m.textarea.Update(msg)
+	if !m.history.handleKey(msg, &m.textarea) {
+		m.textarea, cmd = m.textarea.Update(msg)
+		m.history.record(&m.textarea, msg)
+	}
+	m.mentions.refresh(&m.textarea)
+	m.spelling.refresh(&m.textarea)
 	return cmd
 }
 
+// SetMentionCandidates sets the users suggested after typing "@".
+func (m *InlineCommentViewModel) SetMentionCandidates(candidates []domain.MentionCandidate) {
+	m.mentions.setCandidates(candidates)
+}
+
+// HandleMentionKey lets the open mention suggestions handle key, reporting
+// whether they did.
+func (m *InlineCommentViewModel) HandleMentionKey(msg tea.KeyMsg) bool {
+	if !m.mentions.handleKey(msg, &m.textarea) {
+		return false
+	}
+	m.history.record(&m.textarea, nil)
+	return true
+}
+
+// SetDictionary turns on spell-checking with dict, or turns it off when dict
+// is nil.
+func (m *InlineCommentViewModel) SetDictionary(dict *spell.Dictionary) {
+	m.spelling.setDictionary(dict, &m.textarea)
+}
+
+// HandleSpellKey lets the spell-checker handle key, reporting whether it did
+// and the word the user added to the dictionary, if any.
+func (m *InlineCommentViewModel) HandleSpellKey(msg tea.KeyMsg) (bool, string) {
+	handled, word := m.spelling.handleKey(msg, &m.textarea)
+	if handled {
+		m.history.record(&m.textarea, nil)
+	}
+	return handled, word
+}
+
 func (m *InlineCommentViewModel) View() string {
 	if !m.active {
 		return ""
@@ -83,20 +149,35 @@ func (m *InlineCommentViewModel) View() string {
 		Padding(1, 0)
 
 	title := "Add Inline Comment"
+	if m.editing {
+		title = "Edit Pending Comment"
+	}
 	if m.lineInfo != "" {
 		title += " - " + m.lineInfo
 	}
 
 	b.WriteString(titleStyle.Render(title))
 	b.WriteString("\n\n")
-	b.WriteString(m.textarea.View())
-	b.WriteString("\n\n")
+	b.WriteString(m.spelling.underline(m.textarea.View()))
+	b.WriteString("\n")
+	if suggestions := m.mentions.view(); suggestions != "" {
+		b.WriteString(suggestions)
+		b.WriteString("\n")
+	}
+	if spelling := m.spelling.view(); spelling != "" {
+		b.WriteString(spelling)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	helpStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#6B7280")).
 		Italic(true)
 
-	help := "Ctrl+S: Add Comment | Ctrl+G: Open in editor | Esc: Cancel"
+	help := "Ctrl+S: Add Comment | Ctrl+G: Open in editor | Esc: Cancel | Ctrl+Z/Y: Undo/Redo"
+	if m.editing {
+		help = "Ctrl+S: Save (empty deletes) | Ctrl+G: Open in editor | Esc: Cancel | Ctrl+Z/Y: Undo/Redo"
+	}
 	b.WriteString(helpStyle.Render(help))
 
 	boxStyle := lipgloss.NewStyle().
@@ -4,52 +4,237 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/johanforsgren/lgtmfaster/internal/logger"
 )
 
+// LogLevelFilter limits the logs view to entries of some levels.
+type LogLevelFilter int
+
+const (
+	LogLevelAll LogLevelFilter = iota
+	LogLevelErrors
+	LogLevelInfo
+	LogLevelFiles
+)
+
+func (f LogLevelFilter) String() string {
+	switch f {
+	case LogLevelErrors:
+		return "errors"
+	case LogLevelInfo:
+		return "info"
+	case LogLevelFiles:
+		return "file access"
+	}
+	return "all"
+}
+
+func (f LogLevelFilter) matches(entry logger.LogEntry) bool {
+	switch f {
+	case LogLevelErrors:
+		return entry.Level() == logger.LevelError
+	case LogLevelInfo:
+		return entry.Level() == logger.LevelInfo
+	case LogLevelFiles:
+		level := entry.Level()
+		return level == logger.LevelFileOpen || level == logger.LevelFileWrite
+	}
+	return true
+}
+
+// LogsViewModel shows the session logs. While it is open the logs are
+// refreshed periodically, and the view keeps following new entries as long
+// as it is scrolled to the bottom.
 type LogsViewModel struct {
 	width      int
 	height     int
 	offset     int
 	active     bool
 	logs       []logger.LogEntry
-	lastUpdate int
+	generation int
+	follow     bool
+	filter     LogLevelFilter
+
+	// visible holds the entries that pass the level filter.
+	visible []logger.LogEntry
+
+	searchInput textinput.Model
+	searching   bool
+	query       string
+	matches     []int
+	matchIdx    int
 }
 
 func NewLogsView() *LogsViewModel {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "Search logs..."
+	ti.CharLimit = 100
+
 	return &LogsViewModel{
-		active: false,
-		offset: 0,
+		active:      false,
+		offset:      0,
+		searchInput: ti,
 	}
 }
 
 func (m *LogsViewModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
+	m.keepOffsetInRange()
 }
 
 func (m *LogsViewModel) Activate() {
 	m.active = true
+	m.generation++
+	m.follow = true
+	m.filter = LogLevelAll
+	m.clearSearch()
 	m.logs = logger.GetLogs()
-	m.offset = 0
-	if len(m.logs) > m.getVisibleLines() {
-		m.offset = len(m.logs) - m.getVisibleLines()
-	}
+	m.applyFilter()
 }
 
 func (m *LogsViewModel) Deactivate() {
 	m.active = false
 	m.offset = 0
+	m.searching = false
+	m.searchInput.Blur()
 }
 
 func (m *LogsViewModel) IsActive() bool {
 	return m.active
 }
 
+// Generation changes every time the view is opened, so that the refresh
+// ticks of an earlier opening can be told apart and dropped.
+func (m *LogsViewModel) Generation() int {
+	return m.generation
+}
+
+// Refresh picks up the entries logged since the view was last refreshed.
+func (m *LogsViewModel) Refresh() {
+	if !m.active {
+		return
+	}
+	m.logs = logger.GetLogs()
+	m.applyFilter()
+}
+
+func (m *LogsViewModel) IsFollowing() bool {
+	return m.follow
+}
+
+// IsSearching reports whether the search query is being typed, in which
+// case every key goes to the search input.
+func (m *LogsViewModel) IsSearching() bool {
+	return m.searching
+}
+
+// ClearSearch removes the current search and reports whether there was one.
+func (m *LogsViewModel) ClearSearch() bool {
+	if m.query == "" {
+		return false
+	}
+	m.clearSearch()
+	return true
+}
+
+func (m *LogsViewModel) clearSearch() {
+	m.query = ""
+	m.matches = nil
+	m.matchIdx = 0
+	m.searching = false
+	m.searchInput.SetValue("")
+	m.searchInput.Blur()
+}
+
+func (m *LogsViewModel) SetFilter(filter LogLevelFilter) {
+	m.filter = filter
+	m.applyFilter()
+}
+
+func (m *LogsViewModel) Filter() LogLevelFilter {
+	return m.filter
+}
+
+func (m *LogsViewModel) applyFilter() {
+	m.visible = m.visible[:0]
+	for _, entry := range m.logs {
+		if m.filter.matches(entry) {
+			m.visible = append(m.visible, entry)
+		}
+	}
+	m.findMatches()
+	m.keepOffsetInRange()
+}
+
+// keepOffsetInRange sticks to the bottom while following, and otherwise
+// keeps the offset within the entries after they or the height changed.
+func (m *LogsViewModel) keepOffsetInRange() {
+	if m.follow {
+		m.offset = m.maxOffset()
+	} else {
+		m.offset = min(m.offset, m.maxOffset())
+	}
+}
+
+// findMatches collects the visible entries matching the search query.
+func (m *LogsViewModel) findMatches() {
+	m.matches = m.matches[:0]
+	if m.query == "" {
+		return
+	}
+	query := strings.ToLower(m.query)
+	for i, entry := range m.visible {
+		if strings.Contains(strings.ToLower(entry.String()), query) {
+			m.matches = append(m.matches, i)
+		}
+	}
+	if m.matchIdx >= len(m.matches) {
+		m.matchIdx = max(len(m.matches)-1, 0)
+	}
+}
+
 func (m *LogsViewModel) getVisibleLines() int {
-	return m.height - 8
+	lines := m.height - 8
+	if m.searching || m.query != "" {
+		lines--
+	}
+	return max(lines, 1)
+}
+
+func (m *LogsViewModel) maxOffset() int {
+	return max(len(m.visible)-m.getVisibleLines(), 0)
+}
+
+// scrollTo scrolls the view to offset, and stops following new entries
+// unless that leaves it at the bottom.
+func (m *LogsViewModel) scrollTo(offset int) {
+	m.offset = max(min(offset, m.maxOffset()), 0)
+	m.follow = m.offset == m.maxOffset()
+}
+
+// jumpToMatch shows the match at idx in the middle of the view.
+func (m *LogsViewModel) jumpToMatch(idx int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.matchIdx = (idx + len(m.matches)) % len(m.matches)
+	m.scrollTo(m.matches[m.matchIdx] - m.getVisibleLines()/2)
+}
+
+// firstMatchFrom returns the index of the first match at or below the top of
+// the view, wrapping around to the first one.
+func (m *LogsViewModel) firstMatchFrom(line int) int {
+	for i, match := range m.matches {
+		if match >= line {
+			return i
+		}
+	}
+	return 0
 }
 
 func (m *LogsViewModel) Update(msg tea.Msg) tea.Cmd {
@@ -57,44 +242,62 @@ func (m *LogsViewModel) Update(msg tea.Msg) tea.Cmd {
 		return nil
 	}
 
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "up", "k":
-			if m.offset > 0 {
-				m.offset--
-			}
-		case "down", "j":
-			maxOffset := len(m.logs) - m.getVisibleLines()
-			if maxOffset < 0 {
-				maxOffset = 0
-			}
-			if m.offset < maxOffset {
-				m.offset++
-			}
-		case "pgup":
-			m.offset -= m.getVisibleLines()
-			if m.offset < 0 {
-				m.offset = 0
-			}
-		case "pgdown":
-			m.offset += m.getVisibleLines()
-			maxOffset := len(m.logs) - m.getVisibleLines()
-			if maxOffset < 0 {
-				maxOffset = 0
-			}
-			if m.offset > maxOffset {
-				m.offset = maxOffset
-			}
-		case "g", "home":
-			m.offset = 0
-		case "G", "end":
-			maxOffset := len(m.logs) - m.getVisibleLines()
-			if maxOffset < 0 {
-				maxOffset = 0
-			}
-			m.offset = maxOffset
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	if m.searching {
+		switch keyMsg.String() {
+		case "esc":
+			m.searching = false
+			m.searchInput.SetValue(m.query)
+			m.searchInput.Blur()
+		case "enter":
+			m.searching = false
+			m.searchInput.Blur()
+			m.query = strings.TrimSpace(m.searchInput.Value())
+			m.matchIdx = 0
+			m.findMatches()
+			m.jumpToMatch(m.firstMatchFrom(m.offset))
+		default:
+			var cmd tea.Cmd
+			m.searchInput, cmd = m.searchInput.Update(msg)
+			return cmd
 		}
+		return nil
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		m.scrollTo(m.offset - 1)
+	case "down", "j":
+		m.scrollTo(m.offset + 1)
+	case "pgup":
+		m.scrollTo(m.offset - m.getVisibleLines())
+	case "pgdown":
+		m.scrollTo(m.offset + m.getVisibleLines())
+	case "g", "home":
+		m.scrollTo(0)
+	case "G", "end":
+		m.scrollTo(m.maxOffset())
+	case "/":
+		m.searching = true
+		m.searchInput.SetValue(m.query)
+		m.searchInput.CursorEnd()
+		return m.searchInput.Focus()
+	case "n":
+		m.jumpToMatch(m.matchIdx + 1)
+	case "N":
+		m.jumpToMatch(m.matchIdx - 1)
+	case "a":
+		m.SetFilter(LogLevelAll)
+	case "e":
+		m.SetFilter(LogLevelErrors)
+	case "i":
+		m.SetFilter(LogLevelInfo)
+	case "f":
+		m.SetFilter(LogLevelFiles)
 	}
 
 	return nil
@@ -112,41 +315,48 @@ func (m *LogsViewModel) View() string {
 		Bold(true).
 		Padding(1, 0)
 
-	b.WriteString(titleStyle.Render(fmt.Sprintf("Session Logs (%d entries)", len(m.logs))))
+	title := fmt.Sprintf("Session Logs (%d entries)", len(m.logs))
+	if m.filter != LogLevelAll {
+		title = fmt.Sprintf("Session Logs (%d of %d entries, %s)", len(m.visible), len(m.logs), m.filter)
+	}
+	b.WriteString(titleStyle.Render(title))
 	b.WriteString("\n\n")
 
-	if len(m.logs) == 0 {
-		emptyStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6B7280")).
-			Italic(true)
+	emptyStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	switch {
+	case len(m.logs) == 0:
 		b.WriteString(emptyStyle.Render("No logs yet"))
-	} else {
-		visibleLines := m.getVisibleLines()
-		start := m.offset
-		end := start + visibleLines
-		if end > len(m.logs) {
-			end = len(m.logs)
+		b.WriteString("\n")
+	case len(m.visible) == 0:
+		b.WriteString(emptyStyle.Render(fmt.Sprintf("No %s entries", m.filter)))
+		b.WriteString("\n")
+	default:
+		end := min(m.offset+m.getVisibleLines(), len(m.visible))
+		current := -1
+		if len(m.matches) > 0 {
+			current = m.matches[m.matchIdx]
 		}
-
-		for i := start; i < end; i++ {
-			entry := m.logs[i]
-			timestamp := entry.Timestamp.Format("15:04:05.000")
-
-			logColor := "#E5E7EB"
-			if strings.Contains(entry.Message, "[ERROR]") {
-				logColor = "#EF4444"
-			} else if strings.Contains(entry.Message, "[FILE_WRITE]") {
-				logColor = "#F59E0B"
-			} else if strings.Contains(entry.Message, "[FILE_OPEN]") {
-				logColor = "#10B981"
-			}
-
-			lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(logColor))
-			b.WriteString(lineStyle.Render(fmt.Sprintf("[%s] %s", timestamp, entry.Message)))
+		for i := m.offset; i < end; i++ {
+			b.WriteString(m.renderEntry(m.visible[i], i == current))
 			b.WriteString("\n")
 		}
 	}
 
+	if m.searching {
+		b.WriteString(m.searchInput.View())
+		b.WriteString("\n")
+	} else if m.query != "" {
+		searchInfo := fmt.Sprintf("/%s: no matches", m.query)
+		if len(m.matches) > 0 {
+			searchInfo = fmt.Sprintf("/%s: match %d of %d", m.query, m.matchIdx+1, len(m.matches))
+		}
+		b.WriteString(emptyStyle.Render(searchInfo))
+		b.WriteString("\n")
+	}
+
 	b.WriteString("\n")
 
 	helpStyle := lipgloss.NewStyle().
@@ -154,12 +364,18 @@ func (m *LogsViewModel) View() string {
 		Italic(true)
 
 	scrollInfo := ""
-	if len(m.logs) > m.getVisibleLines() {
-		scrollInfo = fmt.Sprintf(" | Showing %d-%d of %d", m.offset+1, m.offset+m.getVisibleLines(), len(m.logs))
+	if len(m.visible) > m.getVisibleLines() {
+		scrollInfo = fmt.Sprintf(" | Showing %d-%d of %d", m.offset+1, min(m.offset+m.getVisibleLines(), len(m.visible)), len(m.visible))
+	}
+	if m.follow {
+		scrollInfo += " | Following"
 	}
 
-	help := fmt.Sprintf("j/k: Scroll | PgUp/PgDn: Page | g/G: Top/Bottom | Esc: Close%s", scrollInfo)
-	b.WriteString(helpStyle.Render(help))
+	help := "j/k: Scroll | PgUp/PgDn: Page | g/G: Top/Bottom | /: Search | n/N: Next/Prev match | a/e/i/f: All/Errors/Info/Files | Esc: Close"
+	if m.searching {
+		help = "Enter: Search | Esc: Cancel"
+	}
+	b.WriteString(helpStyle.Render(help + scrollInfo))
 
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -169,3 +385,55 @@ func (m *LogsViewModel) View() string {
 
 	return boxStyle.Render(b.String())
 }
+
+func (m *LogsViewModel) renderEntry(entry logger.LogEntry, current bool) string {
+	logColor := "#E5E7EB"
+	switch entry.Level() {
+	case logger.LevelError:
+		logColor = "#EF4444"
+	case logger.LevelFileWrite:
+		logColor = "#F59E0B"
+	case logger.LevelFileOpen:
+		logColor = "#10B981"
+	}
+
+	lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(logColor))
+	matchStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#111827")).Background(lipgloss.Color("#F59E0B"))
+	if current {
+		matchStyle = matchStyle.Background(lipgloss.Color("#FBBF24")).Bold(true)
+	}
+	return highlightMatches(entry.String(), m.query, lineStyle, matchStyle)
+}
+
+// highlightMatches renders text with style, and every case-insensitive
+// occurrence of query in it with matchStyle.
+func highlightMatches(text, query string, style, matchStyle lipgloss.Style) string {
+	if query == "" {
+		return style.Render(text)
+	}
+	lower := strings.ToLower(text)
+	query = strings.ToLower(query)
+	// Lowercasing can change the byte length of some runes, in which case
+	// the offsets found in lower do not apply to text.
+	if len(lower) != len(text) {
+		return style.Render(text)
+	}
+
+	var b strings.Builder
+	for {
+		idx := strings.Index(lower, query)
+		if idx < 0 {
+			break
+		}
+		if idx > 0 {
+			b.WriteString(style.Render(text[:idx]))
+		}
+		b.WriteString(matchStyle.Render(text[idx : idx+len(query)]))
+		text = text[idx+len(query):]
+		lower = lower[idx+len(query):]
+	}
+	if text != "" {
+		b.WriteString(style.Render(text))
+	}
+	return b.String()
+}
@@ -0,0 +1,178 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+func newTestLogsView(messages ...string) *LogsViewModel {
+	m := NewLogsView()
+	m.SetSize(120, 18)
+	m.active = true
+	m.follow = true
+	start := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	for i, message := range messages {
+		m.logs = append(m.logs, logger.LogEntry{Timestamp: start.Add(time.Duration(i) * time.Second), Message: message})
+	}
+	m.applyFilter()
+	return m
+}
+
+func logsKey(m *LogsViewModel, keys ...string) {
+	for _, key := range keys {
+		switch key {
+		case "enter":
+			m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		case "esc":
+			m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		default:
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		}
+	}
+}
+
+func TestLogsView_FiltersByLevel(t *testing.T) {
+	m := newTestLogsView(
+		"[INFO] loaded PRs",
+		"[ERROR] LOAD: repo - boom",
+		"[FILE_OPEN] /tmp/a",
+		"[FILE_WRITE] /tmp/b",
+	)
+
+	logsKey(m, "e")
+	if len(m.visible) != 1 || m.visible[0].Level() != logger.LevelError {
+		t.Fatalf("expected only the error, got %+v", m.visible)
+	}
+
+	logsKey(m, "f")
+	if len(m.visible) != 2 {
+		t.Fatalf("expected both file entries, got %+v", m.visible)
+	}
+
+	logsKey(m, "i")
+	if len(m.visible) != 1 || m.visible[0].Level() != logger.LevelInfo {
+		t.Fatalf("expected only the info entry, got %+v", m.visible)
+	}
+
+	logsKey(m, "a")
+	if len(m.visible) != 4 {
+		t.Fatalf("expected all entries, got %d", len(m.visible))
+	}
+}
+
+func TestLogsView_SearchJumpsBetweenMatches(t *testing.T) {
+	var messages []string
+	for i := 0; i < 40; i++ {
+		message := fmt.Sprintf("[INFO] line %d", i)
+		if i == 5 || i == 30 {
+			message = fmt.Sprintf("[INFO] Needle %d", i)
+		}
+		messages = append(messages, message)
+	}
+	m := newTestLogsView(messages...)
+	logsKey(m, "g")
+
+	logsKey(m, "/")
+	if !m.IsSearching() {
+		t.Fatal("expected / to start a search")
+	}
+	logsKey(m, "n", "e", "e", "d", "l", "e", "enter")
+
+	if m.IsSearching() || m.query != "needle" {
+		t.Fatalf("expected the search to be applied, got searching=%v query=%q", m.IsSearching(), m.query)
+	}
+	if len(m.matches) != 2 || m.matchIdx != 0 {
+		t.Fatalf("expected two matches with the first selected, got %v at %d", m.matches, m.matchIdx)
+	}
+
+	logsKey(m, "n")
+	if m.matchIdx != 1 {
+		t.Errorf("expected n to select the second match, got %d", m.matchIdx)
+	}
+	if line := m.matches[m.matchIdx]; line < m.offset || line >= m.offset+m.getVisibleLines() {
+		t.Errorf("expected match on line %d to be scrolled into view, offset %d", line, m.offset)
+	}
+
+	logsKey(m, "N")
+	if m.matchIdx != 0 {
+		t.Errorf("expected N to go back to the first match, got %d", m.matchIdx)
+	}
+
+	if !m.ClearSearch() || m.query != "" || len(m.matches) != 0 {
+		t.Error("expected ClearSearch to remove the search")
+	}
+	if m.ClearSearch() {
+		t.Error("expected ClearSearch to report that there was nothing to clear")
+	}
+}
+
+func TestLogsView_FollowsNewEntriesOnlyAtBottom(t *testing.T) {
+	var messages []string
+	for i := 0; i < 40; i++ {
+		messages = append(messages, fmt.Sprintf("[INFO] line %d", i))
+	}
+	m := newTestLogsView(messages...)
+	if m.offset != m.maxOffset() || !m.IsFollowing() {
+		t.Fatalf("expected to start at the bottom and follow, offset %d", m.offset)
+	}
+
+	m.logs = append(m.logs, logger.LogEntry{Timestamp: time.Now(), Message: "[INFO] new"})
+	m.applyFilter()
+	if m.offset != m.maxOffset() {
+		t.Errorf("expected to follow the new entry, offset %d of %d", m.offset, m.maxOffset())
+	}
+
+	logsKey(m, "k")
+	if m.IsFollowing() {
+		t.Fatal("expected scrolling up to stop following")
+	}
+	offset := m.offset
+	m.logs = append(m.logs, logger.LogEntry{Timestamp: time.Now(), Message: "[INFO] newer"})
+	m.applyFilter()
+	if m.offset != offset {
+		t.Errorf("expected the view to stay at %d, got %d", offset, m.offset)
+	}
+
+	logsKey(m, "G")
+	if !m.IsFollowing() {
+		t.Error("expected G to resume following")
+	}
+}
+
+func TestHighlightMatches_IsCaseInsensitive(t *testing.T) {
+	plain := lipgloss.NewStyle()
+	marked := lipgloss.NewStyle().SetString("<").Underline(false)
+
+	got := highlightMatches("Error: error", "ERROR", plain, marked)
+	if strings.Count(got, "<") != 2 {
+		t.Errorf("expected both occurrences to be highlighted, got %q", got)
+	}
+	if !strings.Contains(got, "Error") || !strings.Contains(got, "error") {
+		t.Errorf("expected the original casing to be kept, got %q", got)
+	}
+}
+
+func TestLogsView_ResizeKeepsTheOffsetInRange(t *testing.T) {
+	var messages []string
+	for i := 0; i < 40; i++ {
+		messages = append(messages, fmt.Sprintf("[INFO] line %d", i))
+	}
+	m := newTestLogsView(messages...)
+
+	m.SetSize(120, 30)
+	if m.offset != m.maxOffset() {
+		t.Errorf("expected a taller view to stay at the bottom while following, offset %d of %d", m.offset, m.maxOffset())
+	}
+
+	logsKey(m, "k")
+	m.SetSize(120, 60)
+	if m.offset > m.maxOffset() {
+		t.Errorf("expected the offset to stay in range, got %d of %d", m.offset, m.maxOffset())
+	}
+}
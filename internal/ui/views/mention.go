@@ -0,0 +1,190 @@
+package views
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+const maxMentionSuggestions = 6
+
+// mentionCompleter suggests users to @-mention while typing in a textarea.
+// The editors embedding it refresh it after every edit and let it handle
+// the navigation keys while its list is open.
+type mentionCompleter struct {
+	candidates []domain.MentionCandidate
+	matches    []domain.MentionCandidate
+	cursor     int
+	query      string
+	open       bool
+	// dismissed keeps the list closed after Esc until the mention being
+	// typed ends.
+	dismissed bool
+}
+
+func (c *mentionCompleter) setCandidates(candidates []domain.MentionCandidate) {
+	c.candidates = candidates
+	c.close()
+}
+
+func (c *mentionCompleter) close() {
+	c.open = false
+	c.matches = nil
+	c.cursor = 0
+	c.query = ""
+}
+
+// refresh opens the list when the cursor is right after "@" or a partial
+// mention, and closes it otherwise.
+func (c *mentionCompleter) refresh(ta *textarea.Model) {
+	query, ok := mentionQuery(ta)
+	if !ok {
+		c.dismissed = false
+		c.close()
+		return
+	}
+	if c.dismissed || len(c.candidates) == 0 {
+		c.close()
+		return
+	}
+
+	if query != c.query || !c.open {
+		c.cursor = 0
+	}
+	c.query = query
+	c.matches = matchMentions(c.candidates, query)
+	c.open = len(c.matches) > 0
+	if c.cursor >= len(c.matches) {
+		c.cursor = 0
+	}
+}
+
+// handleKey moves through or accepts a suggestion. It reports whether the
+// key was used, which is never the case while the list is closed.
+func (c *mentionCompleter) handleKey(msg tea.KeyMsg, ta *textarea.Model) bool {
+	if !c.open {
+		return false
+	}
+	switch msg.String() {
+	case "up", "ctrl+p":
+		if c.cursor > 0 {
+			c.cursor--
+		}
+	case "down", "ctrl+n":
+		if c.cursor < len(c.matches)-1 {
+			c.cursor++
+		}
+	case "tab", "enter":
+		c.accept(ta)
+	case "esc":
+		c.dismissed = true
+		c.close()
+	default:
+		return false
+	}
+	return true
+}
+
+// accept replaces the "@query" before the cursor with the mention markup of
+// the selected candidate.
+func (c *mentionCompleter) accept(ta *textarea.Model) {
+	candidate := c.matches[c.cursor]
+	for range len([]rune(c.query)) + 1 {
+		*ta, _ = ta.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	ta.InsertString(candidate.Text + " ")
+	c.close()
+}
+
+func (c *mentionCompleter) view() string {
+	if !c.open {
+		return ""
+	}
+
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED")).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	var lines []string
+	for i, candidate := range c.matches {
+		marker := "  "
+		style := nameStyle
+		if i == c.cursor {
+			marker = "► "
+			style = selectedStyle
+		}
+		line := marker + style.Render("@"+candidate.User.Username)
+		if candidate.User.Email != "" {
+			line += " " + mutedStyle.Render(candidate.User.Email)
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, mutedStyle.Italic(true).Render("↑↓: Select | Tab/Enter: Insert | Esc: Dismiss"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("#374151")).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
+
+// mentionQuery returns the text typed after an "@" that starts a word and
+// ends at the cursor.
+func mentionQuery(ta *textarea.Model) (string, bool) {
+	lines := strings.Split(ta.Value(), "\n")
+	if ta.Line() >= len(lines) {
+		return "", false
+	}
+	line := []rune(lines[ta.Line()])
+	info := ta.LineInfo()
+	col := min(info.StartColumn+info.ColumnOffset, len(line))
+
+	start := col
+	for start > 0 && isMentionRune(line[start-1]) {
+		start--
+	}
+	if start == 0 || line[start-1] != '@' {
+		return "", false
+	}
+	// "name@example.com" is an email address, not a mention.
+	if start > 1 && (unicode.IsLetter(line[start-2]) || unicode.IsDigit(line[start-2])) {
+		return "", false
+	}
+	return string(line[start:col]), true
+}
+
+func isMentionRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' || r == '.'
+}
+
+// matchMentions keeps the candidates whose username, a word of it, or email
+// starts with query, case-insensitively. Username prefix matches come first.
+func matchMentions(candidates []domain.MentionCandidate, query string) []domain.MentionCandidate {
+	query = strings.ToLower(query)
+	var prefix, other []domain.MentionCandidate
+	for _, candidate := range candidates {
+		name := strings.ToLower(candidate.User.Username)
+		switch {
+		case strings.HasPrefix(name, query):
+			prefix = append(prefix, candidate)
+		case strings.HasPrefix(strings.ToLower(candidate.User.Email), query):
+			other = append(other, candidate)
+		default:
+			for _, word := range strings.FieldsFunc(name, func(r rune) bool { return !isMentionRune(r) || r == '.' }) {
+				if strings.HasPrefix(word, query) {
+					other = append(other, candidate)
+					break
+				}
+			}
+		}
+	}
+	matches := append(prefix, other...)
+	if len(matches) > maxMentionSuggestions {
+		matches = matches[:maxMentionSuggestions]
+	}
+	return matches
+}
@@ -0,0 +1,92 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func mentionTestEditor() *InlineCommentViewModel {
+	view := NewInlineCommentView()
+	view.SetSize(80, 30)
+	view.Activate("main.go:3")
+	view.SetMentionCandidates([]domain.MentionCandidate{
+		{User: domain.User{Username: "alice"}, Text: "@alice"},
+		{User: domain.User{Username: "Albert Smith", Email: "albert@example.com"}, Text: "@<a1b2>"},
+		{User: domain.User{Username: "bob"}, Text: "@bob"},
+	})
+	return view
+}
+
+func typeText(view *InlineCommentViewModel, text string) {
+	for _, r := range text {
+		view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestMentionCompleter_InsertsSelectedMention(t *testing.T) {
+	view := mentionTestEditor()
+
+	typeText(view, "Thanks @al")
+	if !strings.Contains(view.View(), "@alice") || !strings.Contains(view.View(), "@Albert Smith") || strings.Contains(view.View(), "@bob") {
+		t.Fatalf("expected the matching suggestions, got:\n%s", view.View())
+	}
+
+	if !view.HandleMentionKey(tea.KeyMsg{Type: tea.KeyDown}) || !view.HandleMentionKey(tea.KeyMsg{Type: tea.KeyTab}) {
+		t.Fatal("expected the open suggestions to handle the keys")
+	}
+	if got := view.GetValue(); got != "Thanks @<a1b2> " {
+		t.Errorf("expected the provider's mention markup, got %q", got)
+	}
+	if view.HandleMentionKey(tea.KeyMsg{Type: tea.KeyEnter}) {
+		t.Error("expected the suggestions to close after inserting")
+	}
+}
+
+func TestMentionCompleter_MatchesWordsAndEmail(t *testing.T) {
+	view := mentionTestEditor()
+
+	typeText(view, "@smi")
+	if !strings.Contains(view.View(), "@Albert Smith") {
+		t.Error("expected a match on the second word of the name")
+	}
+
+	view.SetValue("")
+	typeText(view, "@albert@")
+	if strings.Contains(view.View(), "►") {
+		t.Error("expected no suggestions after a second @")
+	}
+}
+
+func TestMentionCompleter_IgnoresEmailAddresses(t *testing.T) {
+	view := mentionTestEditor()
+
+	typeText(view, "mail me at me@al")
+
+	if view.HandleMentionKey(tea.KeyMsg{Type: tea.KeyTab}) {
+		t.Error("expected no suggestions inside an email address")
+	}
+}
+
+func TestMentionCompleter_EscDismissesUntilTheMentionEnds(t *testing.T) {
+	view := mentionTestEditor()
+
+	typeText(view, "@b")
+	if !view.HandleMentionKey(tea.KeyMsg{Type: tea.KeyEsc}) {
+		t.Fatal("expected esc to dismiss the suggestions")
+	}
+	typeText(view, "o")
+	if view.HandleMentionKey(tea.KeyMsg{Type: tea.KeyTab}) {
+		t.Error("expected the suggestions to stay closed for this mention")
+	}
+
+	typeText(view, " @b")
+	if !view.HandleMentionKey(tea.KeyMsg{Type: tea.KeyEnter}) {
+		t.Fatal("expected a new mention to open the suggestions again")
+	}
+	if got := view.GetValue(); got != "@bo @bob " {
+		t.Errorf("unexpected value %q", got)
+	}
+}
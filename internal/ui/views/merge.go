@@ -9,20 +9,35 @@ import (
 	"github.com/johanforsgren/lgtmfaster/internal/domain"
 )
 
+// maxConflictFiles caps the conflicting files listed in the merge view.
+const maxConflictFiles = 10
+
 type MergeViewModel struct {
-	active      bool
-	width       int
-	height      int
-	selectedIdx int
-	options     []MergeOption
-	pr          *domain.PullRequest
-	provider    domain.ProviderType
+	active       bool
+	width        int
+	height       int
+	selectedIdx  int
+	options      []MergeOption
+	pr           *domain.PullRequest
+	provider     domain.ProviderType
+	deleteBranch bool
+
+	requirements        *domain.MergeRequirements
+	requirementsErr     error
+	loadingRequirements bool
+
+	conflicts        []string
+	conflictsErr     error
+	loadingConflicts bool
 }
 
+// MergeOption is one merge method. Disabled options are rejected by the
+// repository's settings or policies and cannot be selected.
 type MergeOption struct {
 	method      string
 	label       string
 	description string
+	disabled    bool
 }
 
 func NewMergeView() *MergeViewModel {
@@ -37,12 +52,21 @@ func (m *MergeViewModel) SetSize(width, height int) {
 	m.height = height
 }
 
-func (m *MergeViewModel) Activate(pr *domain.PullRequest, provider domain.ProviderType) {
+// Activate opens the merge view. deleteBranch is the initial state of the
+// delete source branch checkbox.
+func (m *MergeViewModel) Activate(pr *domain.PullRequest, provider domain.ProviderType, deleteBranch bool) {
 	m.active = true
 	m.pr = pr
 	m.provider = provider
+	m.deleteBranch = deleteBranch
 	m.selectedIdx = 0
 	m.options = m.buildOptions()
+	m.requirements = nil
+	m.requirementsErr = nil
+	m.loadingRequirements = false
+	m.conflicts = nil
+	m.conflictsErr = nil
+	m.loadingConflicts = false
 }
 
 func (m *MergeViewModel) Deactivate() {
@@ -50,32 +74,94 @@ func (m *MergeViewModel) Deactivate() {
 	m.pr = nil
 	m.selectedIdx = 0
 	m.options = nil
+	m.requirements = nil
+	m.requirementsErr = nil
+	m.loadingRequirements = false
+	m.conflicts = nil
+	m.conflictsErr = nil
+	m.loadingConflicts = false
+}
+
+// SetRequirementsLoading shows that the merge requirements are being fetched.
+func (m *MergeViewModel) SetRequirementsLoading() {
+	m.loadingRequirements = true
+}
+
+// SetRequirements shows the merge requirements and disables the merge methods
+// they do not allow, moving the selection to the first allowed method.
+func (m *MergeViewModel) SetRequirements(requirements *domain.MergeRequirements, err error) {
+	m.loadingRequirements = false
+	m.requirements = requirements
+	m.requirementsErr = err
+	if requirements == nil {
+		return
+	}
+
+	for i := range m.options {
+		m.options[i].disabled = !requirements.MethodAllowed(m.options[i].method)
+	}
+	if m.selectedIdx < len(m.options) && m.options[m.selectedIdx].disabled {
+		for i, option := range m.options {
+			if !option.disabled {
+				m.selectedIdx = i
+				break
+			}
+		}
+	}
 }
 
 func (m *MergeViewModel) IsActive() bool {
 	return m.active
 }
 
+// SetConflictsLoading shows that the conflicting files are being fetched.
+func (m *MergeViewModel) SetConflictsLoading() {
+	m.loadingConflicts = true
+}
+
+func (m *MergeViewModel) SetConflicts(files []string, err error) {
+	m.loadingConflicts = false
+	m.conflicts = files
+	m.conflictsErr = err
+}
+
+// GetSelectedMethod returns the selected merge method, or "" when it is
+// disabled.
 func (m *MergeViewModel) GetSelectedMethod() string {
-	if m.selectedIdx >= 0 && m.selectedIdx < len(m.options) {
+	if m.selectedIdx >= 0 && m.selectedIdx < len(m.options) && !m.options[m.selectedIdx].disabled {
 		return m.options[m.selectedIdx].method
 	}
 	return ""
 }
 
+// DeleteBranch reports whether the source branch is deleted after merging.
+func (m *MergeViewModel) DeleteBranch() bool {
+	return m.deleteBranch
+}
+
+func (m *MergeViewModel) ToggleDeleteBranch() {
+	m.deleteBranch = !m.deleteBranch
+}
+
 func (m *MergeViewModel) GetPR() *domain.PullRequest {
 	return m.pr
 }
 
 func (m *MergeViewModel) NextOption() {
-	if m.selectedIdx < len(m.options)-1 {
-		m.selectedIdx++
+	for i := m.selectedIdx + 1; i < len(m.options); i++ {
+		if !m.options[i].disabled {
+			m.selectedIdx = i
+			return
+		}
 	}
 }
 
 func (m *MergeViewModel) PrevOption() {
-	if m.selectedIdx > 0 {
-		m.selectedIdx--
+	for i := m.selectedIdx - 1; i >= 0; i-- {
+		if !m.options[i].disabled {
+			m.selectedIdx = i
+			return
+		}
 	}
 }
 
@@ -83,6 +169,18 @@ func (m *MergeViewModel) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+// DefaultMergeMethod returns the first merge method the merge view offers for
+// provider that requirements allow, or "" when none is allowed.
+func DefaultMergeMethod(provider domain.ProviderType, requirements *domain.MergeRequirements) string {
+	options := (&MergeViewModel{provider: provider}).buildOptions()
+	for _, option := range options {
+		if requirements == nil || requirements.MethodAllowed(option.method) {
+			return option.method
+		}
+	}
+	return ""
+}
+
 func (m *MergeViewModel) buildOptions() []MergeOption {
 	if m.provider == domain.ProviderGitHub {
 		return []MergeOption{
@@ -120,8 +218,34 @@ func (m *MergeViewModel) buildOptions() []MergeOption {
 				description: "Rebase commits onto target branch",
 			},
 		}
+	} else if m.provider == domain.ProviderGerrit {
+		return []MergeOption{
+			{
+				method:      "submit",
+				label:       "Submit",
+				description: "Submit using the project's submit type",
+			},
+		}
+	}
+	// Provider plugins receive the method name and map it to their own
+	// merge strategies.
+	return []MergeOption{
+		{
+			method:      "merge",
+			label:       "Merge",
+			description: "Merge using the provider's default strategy",
+		},
+		{
+			method:      "squash",
+			label:       "Squash",
+			description: "Combine all commits into one",
+		},
+		{
+			method:      "rebase",
+			label:       "Rebase",
+			description: "Rebase commits onto target branch",
+		},
 	}
-	return []MergeOption{}
 }
 
 func (m *MergeViewModel) View() string {
@@ -154,8 +278,8 @@ func (m *MergeViewModel) View() string {
 
 		b.WriteString(warningStyle.Render("⚠ Warning: This PR has merge conflicts"))
 		b.WriteString("\n")
-		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("246")).Render("Resolve conflicts before merging"))
-		b.WriteString("\n\n")
+		b.WriteString(m.renderConflicts())
+		b.WriteString("\n")
 	} else {
 		successStyle := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("10"))
@@ -164,6 +288,8 @@ func (m *MergeViewModel) View() string {
 		b.WriteString("\n\n")
 	}
 
+	b.WriteString(m.renderRequirements())
+
 	mergeMethodTitle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("15")).
 		Bold(true)
@@ -175,7 +301,11 @@ func (m *MergeViewModel) View() string {
 		selected := i == m.selectedIdx
 		var optionStyle lipgloss.Style
 
-		if selected {
+		if option.disabled {
+			optionStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("240")).
+				Strikethrough(true)
+		} else if selected {
 			optionStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#7C3AED")).
 				Bold(true)
@@ -198,22 +328,32 @@ func (m *MergeViewModel) View() string {
 			Foreground(lipgloss.Color("246")).
 			PaddingLeft(4)
 
-		b.WriteString(descStyle.Render(option.description))
+		description := option.description
+		if option.disabled {
+			descStyle = descStyle.Foreground(lipgloss.Color("240"))
+			description = "Not allowed for this repository"
+		}
+
+		b.WriteString(descStyle.Render(description))
 		b.WriteString("\n\n")
 	}
 
-	noteStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("246")).
-		Italic(true)
-
-	b.WriteString(noteStyle.Render("Note: Source branch will be deleted after merge"))
-	b.WriteString("\n\n")
+	help := "↑↓: Navigate | Enter: Confirm | Esc: Cancel"
+	// Gerrit changes have no source branch to delete.
+	if m.provider != domain.ProviderGerrit {
+		checkbox := "[ ]"
+		if m.deleteBranch {
+			checkbox = "[x]"
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Render(fmt.Sprintf("%s Delete %s after merge", checkbox, m.pr.SourceBranch)))
+		b.WriteString("\n\n")
+		help = "↑↓: Navigate | d/Space: Toggle branch deletion | Enter: Confirm | Esc: Cancel"
+	}
 
 	helpStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#6B7280")).
 		Italic(true)
 
-	help := "↑↓: Navigate | Enter: Confirm | Esc: Cancel"
 	b.WriteString(helpStyle.Render(help))
 
 	boxStyle := lipgloss.NewStyle().
@@ -225,6 +365,77 @@ func (m *MergeViewModel) View() string {
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(b.String()))
 }
 
+// renderConflicts lists the conflicting files, falling back to the generic
+// advice when they are unknown.
+func (m *MergeViewModel) renderConflicts() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+
+	switch {
+	case m.loadingConflicts:
+		return mutedStyle.Render("Looking up conflicting files...") + "\n"
+	case m.conflictsErr != nil:
+		return mutedStyle.Render("Could not list conflicting files: "+m.conflictsErr.Error()) + "\n"
+	case len(m.conflicts) == 0:
+		return mutedStyle.Render("Resolve conflicts before merging") + "\n"
+	}
+
+	var b strings.Builder
+	heading := fmt.Sprintf("Rebase %s onto %s and resolve conflicts in:", m.pr.SourceBranch, m.pr.TargetBranch)
+	if m.provider == domain.ProviderGitHub {
+		// GitHub does not report conflicts; these are the files changed on both branches.
+		heading = fmt.Sprintf("Rebase %s onto %s; files changed on both branches:", m.pr.SourceBranch, m.pr.TargetBranch)
+	}
+	b.WriteString(mutedStyle.Render(heading))
+	b.WriteString("\n")
+	fileStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	shown := m.conflicts
+	if len(shown) > maxConflictFiles {
+		shown = shown[:maxConflictFiles]
+	}
+	for _, file := range shown {
+		b.WriteString(fileStyle.Render("  • " + file))
+		b.WriteString("\n")
+	}
+	if hidden := len(m.conflicts) - len(shown); hidden > 0 {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("  … and %d more", hidden)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderRequirements lists the unmet merge requirements, or nothing when the
+// provider does not report them.
+func (m *MergeViewModel) renderRequirements() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+
+	switch {
+	case m.loadingRequirements:
+		return mutedStyle.Render("Checking merge requirements...") + "\n\n"
+	case m.requirementsErr != nil:
+		return mutedStyle.Render("Could not check merge requirements: "+m.requirementsErr.Error()) + "\n\n"
+	case m.requirements == nil:
+		return ""
+	}
+
+	unmet := m.requirements.Unmet()
+	if len(unmet) == 0 {
+		if !m.requirements.Protected {
+			return ""
+		}
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render("✓ All merge requirements are met") + "\n\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true).Render("Unmet requirements:"))
+	b.WriteString("\n")
+	for _, requirement := range unmet {
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render("  ✗ " + requirement))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
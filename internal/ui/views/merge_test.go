@@ -0,0 +1,123 @@
+package views
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func newTestMergeView() *MergeViewModel {
+	view := NewMergeView()
+	view.SetSize(120, 60)
+	view.Activate(&domain.PullRequest{Title: "Test", Mergeable: true}, domain.ProviderGitHub, true)
+	return view
+}
+
+func TestMergeView_SetRequirementsDisablesMethods(t *testing.T) {
+	view := newTestMergeView()
+
+	view.SetRequirements(&domain.MergeRequirements{AllowedMethods: []string{"squash", "rebase"}}, nil)
+
+	if got := view.GetSelectedMethod(); got != "squash" {
+		t.Fatalf("expected selection to move to the first allowed method, got %q", got)
+	}
+	view.PrevOption()
+	if got := view.GetSelectedMethod(); got != "squash" {
+		t.Errorf("expected disabled merge commit to be skipped, got %q", got)
+	}
+	view.NextOption()
+	if got := view.GetSelectedMethod(); got != "rebase" {
+		t.Errorf("expected rebase after squash, got %q", got)
+	}
+}
+
+func TestMergeView_NoAllowedMethods(t *testing.T) {
+	view := newTestMergeView()
+
+	view.SetRequirements(&domain.MergeRequirements{AllowedMethods: []string{}}, nil)
+
+	if got := view.GetSelectedMethod(); got != "" {
+		t.Errorf("expected no selectable method, got %q", got)
+	}
+}
+
+func TestMergeView_RendersUnmetRequirements(t *testing.T) {
+	view := newTestMergeView()
+	view.SetRequirementsLoading()
+	if !strings.Contains(view.View(), "Checking merge requirements") {
+		t.Error("expected loading message while requirements are fetched")
+	}
+
+	view.SetRequirements(&domain.MergeRequirements{
+		Protected:         true,
+		RequiredApprovals: 2,
+		Approvals:         1,
+		FailingChecks:     []string{"build"},
+	}, nil)
+
+	output := view.View()
+	for _, want := range []string{"needs 1 more approval", "CI failing: build"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in merge view", want)
+		}
+	}
+}
+
+func TestMergeView_RequirementsError(t *testing.T) {
+	view := newTestMergeView()
+
+	view.SetRequirements(nil, errors.New("forbidden"))
+
+	if !strings.Contains(view.View(), "Could not check merge requirements: forbidden") {
+		t.Error("expected the error to be shown")
+	}
+	if got := view.GetSelectedMethod(); got != "merge" {
+		t.Errorf("expected all methods to stay enabled, got %q", got)
+	}
+}
+
+func TestMergeView_RendersConflictFiles(t *testing.T) {
+	view := NewMergeView()
+	view.SetSize(120, 80)
+	view.Activate(&domain.PullRequest{Title: "Test", SourceBranch: "feature", TargetBranch: "main"}, domain.ProviderAzureDevOps, true)
+	view.SetConflictsLoading()
+	if !strings.Contains(view.View(), "Looking up conflicting files") {
+		t.Error("expected loading message while conflicts are fetched")
+	}
+
+	files := []string{"go.mod"}
+	for i := 0; i < maxConflictFiles+2; i++ {
+		files = append(files, fmt.Sprintf("pkg/file%02d.go", i))
+	}
+	view.SetConflicts(files, nil)
+
+	output := view.View()
+	if !strings.Contains(output, "Rebase feature onto main") || !strings.Contains(output, "go.mod") {
+		t.Error("expected the conflicting files to be listed")
+	}
+	if !strings.Contains(output, "and 3 more") {
+		t.Error("expected files beyond the limit to be summarized")
+	}
+}
+
+func TestMergeView_ToggleDeleteBranch(t *testing.T) {
+	view := NewMergeView()
+	view.SetSize(120, 60)
+	view.Activate(&domain.PullRequest{Title: "Test", SourceBranch: "feature", Mergeable: true}, domain.ProviderGitHub, false)
+
+	if view.DeleteBranch() {
+		t.Fatal("expected the configured default to keep the branch")
+	}
+	if !strings.Contains(view.View(), "[ ] Delete feature after merge") {
+		t.Error("expected an unchecked delete branch checkbox")
+	}
+
+	view.ToggleDeleteBranch()
+
+	if !view.DeleteBranch() || !strings.Contains(view.View(), "[x] Delete feature after merge") {
+		t.Error("expected the checkbox to be checked after toggling")
+	}
+}
@@ -0,0 +1,129 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/components"
+)
+
+// MessagesViewModel lists the status bar messages of the session, so that
+// one replaced before it could be read can still be looked up.
+type MessagesViewModel struct {
+	width    int
+	height   int
+	offset   int
+	active   bool
+	messages []components.Notification
+}
+
+func NewMessagesView() *MessagesViewModel {
+	return &MessagesViewModel{}
+}
+
+func (m *MessagesViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Activate shows messages, oldest first, scrolled to the newest.
+func (m *MessagesViewModel) Activate(messages []components.Notification) {
+	m.active = true
+	m.messages = messages
+	m.offset = m.maxOffset()
+}
+
+func (m *MessagesViewModel) Deactivate() {
+	m.active = false
+	m.messages = nil
+	m.offset = 0
+}
+
+func (m *MessagesViewModel) IsActive() bool {
+	return m.active
+}
+
+func (m *MessagesViewModel) getVisibleLines() int {
+	return max(m.height-8, 1)
+}
+
+func (m *MessagesViewModel) maxOffset() int {
+	return max(len(m.messages)-m.getVisibleLines(), 0)
+}
+
+func (m *MessagesViewModel) Update(msg tea.Msg) tea.Cmd {
+	if !m.active {
+		return nil
+	}
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "up", "k":
+			m.offset--
+		case "down", "j":
+			m.offset++
+		case "pgup":
+			m.offset -= m.getVisibleLines()
+		case "pgdown":
+			m.offset += m.getVisibleLines()
+		case "g", "home":
+			m.offset = 0
+		case "G", "end":
+			m.offset = m.maxOffset()
+		}
+		m.offset = max(min(m.offset, m.maxOffset()), 0)
+	}
+
+	return nil
+}
+
+func (m *MessagesViewModel) View() string {
+	if !m.active {
+		return ""
+	}
+
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true).
+		Padding(1, 0)
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Messages (%d)", len(m.messages))))
+	b.WriteString("\n\n")
+
+	if len(m.messages) == 0 {
+		b.WriteString(mutedStyle.Render("No messages yet"))
+		b.WriteString("\n")
+	}
+	end := min(m.offset+m.getVisibleLines(), len(m.messages))
+	for _, message := range m.messages[m.offset:end] {
+		line := fmt.Sprintf("[%s] %s %s", message.At.Format("15:04:05"), message.Severity.Symbol(), message.Text)
+		if message.Count > 1 {
+			line += fmt.Sprintf(" (×%d)", message.Count)
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(message.Severity.Foreground()).Render(line))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+
+	scrollInfo := ""
+	if len(m.messages) > m.getVisibleLines() {
+		scrollInfo = fmt.Sprintf(" | Showing %d-%d of %d", m.offset+1, end, len(m.messages))
+	}
+	b.WriteString(mutedStyle.Render("j/k: Scroll | PgUp/PgDn: Page | g/G: Top/Bottom | Esc: Close" + scrollInfo))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(m.width - 4)
+
+	return boxStyle.Render(b.String())
+}
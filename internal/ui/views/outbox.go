@@ -0,0 +1,202 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/textwidth"
+)
+
+// OutboxViewModel lists the reviews whose submission failed and lets the
+// user retry or discard them.
+type OutboxViewModel struct {
+	active      bool
+	width       int
+	height      int
+	selectedIdx int
+	items       []domain.QueuedReview
+	sending     string
+	discarding  bool
+}
+
+func NewOutboxView() *OutboxViewModel {
+	return &OutboxViewModel{}
+}
+
+func (m *OutboxViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *OutboxViewModel) Activate(items []domain.QueuedReview) {
+	m.active = true
+	m.selectedIdx = 0
+	m.sending = ""
+	m.discarding = false
+	m.SetItems(items)
+}
+
+func (m *OutboxViewModel) Deactivate() {
+	m.active = false
+	m.items = nil
+	m.sending = ""
+	m.discarding = false
+}
+
+func (m *OutboxViewModel) IsActive() bool {
+	return m.active
+}
+
+// SetItems replaces the listed reviews, keeping the selection in range.
+func (m *OutboxViewModel) SetItems(items []domain.QueuedReview) {
+	m.items = items
+	if m.selectedIdx >= len(items) {
+		m.selectedIdx = max(len(items)-1, 0)
+	}
+}
+
+func (m *OutboxViewModel) GetSelected() *domain.QueuedReview {
+	if m.selectedIdx < 0 || m.selectedIdx >= len(m.items) {
+		return nil
+	}
+	return &m.items[m.selectedIdx]
+}
+
+func (m *OutboxViewModel) Next() {
+	if m.selectedIdx < len(m.items)-1 {
+		m.selectedIdx++
+	}
+	m.discarding = false
+}
+
+func (m *OutboxViewModel) Prev() {
+	if m.selectedIdx > 0 {
+		m.selectedIdx--
+	}
+	m.discarding = false
+}
+
+// SetSending marks the review with id as being sent; an empty id clears it.
+func (m *OutboxViewModel) SetSending(id string) {
+	m.sending = id
+}
+
+func (m *OutboxViewModel) IsSending() bool {
+	return m.sending != ""
+}
+
+// StartDiscard asks for confirmation before the selected review is
+// discarded.
+func (m *OutboxViewModel) StartDiscard() {
+	if m.GetSelected() != nil {
+		m.discarding = true
+	}
+}
+
+func (m *OutboxViewModel) CancelDiscard() {
+	m.discarding = false
+}
+
+func (m *OutboxViewModel) IsConfirmingDiscard() bool {
+	return m.discarding
+}
+
+func (m *OutboxViewModel) View() string {
+	if !m.active {
+		return ""
+	}
+
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true).
+		Padding(1, 0)
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Outbox (%d)", len(m.items))))
+	b.WriteString("\n\n")
+
+	if len(m.items) == 0 {
+		b.WriteString(mutedStyle.Render("Nothing waiting to be sent"))
+		b.WriteString("\n")
+	}
+	for i, item := range m.items {
+		b.WriteString(m.renderItem(item, i == m.selectedIdx))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	switch {
+	case m.discarding:
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Bold(true).
+			Render("Discard this review for good? y: Discard | n: Keep"))
+	case m.sending != "":
+		b.WriteString(mutedStyle.Render("Sending..."))
+	default:
+		b.WriteString(mutedStyle.Render("↑↓: Navigate | r/Enter: Retry | d: Discard | Esc: Close"))
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(min(80, m.width-4))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(b.String()))
+}
+
+func (m *OutboxViewModel) renderItem(item domain.QueuedReview, selected bool) string {
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	marker := "  "
+	if selected {
+		marker = "► "
+		nameStyle = nameStyle.Foreground(lipgloss.Color("#7C3AED")).Bold(true)
+	}
+
+	header := fmt.Sprintf("%s#%d", item.Identifier.Repository, item.Identifier.Number)
+	line := marker + nameStyle.Render(header) + " " + outboxActionLabel(item.Review.Action)
+	line += mutedStyle.Render("  " + item.QueuedAt.Local().Format("Jan 2 15:04"))
+	if item.Attempts > 1 {
+		line += mutedStyle.Render(fmt.Sprintf(", %d attempts", item.Attempts))
+	}
+	if item.ID == m.sending {
+		line += lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Render("  sending…")
+	}
+
+	summary := strings.TrimSpace(item.Review.Body)
+	if first, _, found := strings.Cut(summary, "\n"); found {
+		summary = first + " …"
+	}
+	if summary == "" {
+		summary = "(no summary)"
+	}
+	if n := len(item.Review.Comments); n > 0 {
+		summary += fmt.Sprintf(" (+%d inline comment(s))", n)
+	}
+	line += "\n    " + mutedStyle.Render(textwidth.Truncate(summary, 70))
+
+	if item.LastError != "" {
+		status := item.LastError
+		if item.RetryWhenOnline {
+			status = "waiting for the network: " + status
+		}
+		line += "\n    " + lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render(textwidth.Truncate(status, 70))
+	}
+	return line
+}
+
+func outboxActionLabel(action domain.ReviewAction) string {
+	switch action {
+	case domain.ReviewActionApprove:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Render("✓ approve")
+	case domain.ReviewActionRequestChanges:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render("✗ request changes")
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#60A5FA")).Render("💬 comment")
+}
@@ -0,0 +1,170 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/outline"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/textwidth"
+)
+
+// OutlineViewModel lists the functions and types the diff changes, grouped
+// by file, to jump to the changes of each.
+type OutlineViewModel struct {
+	active      bool
+	width       int
+	height      int
+	selectedIdx int
+	symbols     []outline.Symbol
+	loading     bool
+}
+
+func NewOutlineView() *OutlineViewModel {
+	return &OutlineViewModel{}
+}
+
+func (m *OutlineViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Activate lists symbols. loading is set while the files are still being
+// read, after which SetSymbols replaces them.
+func (m *OutlineViewModel) Activate(symbols []outline.Symbol, loading bool) {
+	m.active = true
+	m.selectedIdx = 0
+	m.symbols = symbols
+	m.loading = loading
+}
+
+// SetSymbols replaces the listed symbols once the files are read, keeping
+// the selected symbol when it is still listed.
+func (m *OutlineViewModel) SetSymbols(symbols []outline.Symbol) {
+	selected := m.GetSelected()
+	m.symbols = symbols
+	m.loading = false
+	m.selectedIdx = 0
+	if selected == nil {
+		return
+	}
+	for i, symbol := range symbols {
+		if symbol.FileIndex == selected.FileIndex && symbol.LineIndex <= selected.LineIndex {
+			m.selectedIdx = i
+		}
+	}
+}
+
+func (m *OutlineViewModel) Deactivate() {
+	m.active = false
+	m.symbols = nil
+	m.loading = false
+}
+
+func (m *OutlineViewModel) IsActive() bool {
+	return m.active
+}
+
+func (m *OutlineViewModel) GetSelected() *outline.Symbol {
+	if m.selectedIdx < 0 || m.selectedIdx >= len(m.symbols) {
+		return nil
+	}
+	return &m.symbols[m.selectedIdx]
+}
+
+func (m *OutlineViewModel) Next() {
+	if m.selectedIdx < len(m.symbols)-1 {
+		m.selectedIdx++
+	}
+}
+
+func (m *OutlineViewModel) Prev() {
+	if m.selectedIdx > 0 {
+		m.selectedIdx--
+	}
+}
+
+func (m *OutlineViewModel) View() string {
+	if !m.active {
+		return ""
+	}
+
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true).
+		Padding(1, 0)
+	fileStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#3B82F6")).
+		Bold(true)
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Changed symbols (%d)", len(m.symbols))))
+	b.WriteString("\n\n")
+
+	if len(m.symbols) == 0 {
+		message := "No changed functions or types found"
+		if m.loading {
+			message = "Reading the changed files..."
+		}
+		b.WriteString(mutedStyle.Render(message))
+		b.WriteString("\n")
+	}
+
+	width := min(100, m.width-4) - 6
+	from, to := m.visibleRange()
+	for i := from; i < to; i++ {
+		symbol := m.symbols[i]
+		if i == from || symbol.FileIndex != m.symbols[i-1].FileIndex {
+			b.WriteString(fileStyle.Render(textwidth.Truncate(symbol.FilePath, width)))
+			b.WriteString("\n")
+		}
+		b.WriteString(m.renderSymbol(symbol, i == m.selectedIdx, width))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if m.loading && len(m.symbols) > 0 {
+		b.WriteString(mutedStyle.Render("Reading the changed files..."))
+		b.WriteString("\n")
+	}
+	b.WriteString(mutedStyle.Render("↑↓: Navigate | Enter: Jump to the changes | Esc: Close"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(min(100, m.width-4))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(b.String()))
+}
+
+// visibleRange returns the symbols that fit the terminal, keeping the
+// selected one in view.
+func (m *OutlineViewModel) visibleRange() (int, int) {
+	// File headers take rows too, so only half the room is used for symbols.
+	rows := max((m.height-14)/2, 3)
+	if len(m.symbols) <= rows {
+		return 0, len(m.symbols)
+	}
+	from := max(m.selectedIdx-rows/2, 0)
+	to := min(from+rows, len(m.symbols))
+	return to - rows, to
+}
+
+func (m *OutlineViewModel) renderSymbol(symbol outline.Symbol, selected bool, width int) string {
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	marker := "  "
+	if selected {
+		marker = "► "
+		nameStyle = nameStyle.Foreground(lipgloss.Color("#7C3AED")).Bold(true)
+	}
+
+	changes := fmt.Sprintf("  %d line(s), from line %d", symbol.Changes, symbol.Line)
+	name := textwidth.Truncate(symbol.Name, max(width-len(changes)-2, 10))
+	return marker + nameStyle.Render(name) + mutedStyle.Render(changes)
+}
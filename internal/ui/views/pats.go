@@ -2,7 +2,10 @@ package views
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -12,9 +15,12 @@ import (
 )
 
 type PATItem struct {
-	pat domain.PAT
+	pat     domain.PAT
+	problem string
 }
 
+var patProblemStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Bold(true)
+
 func (i PATItem) FilterValue() string { return i.pat.Name }
 func (i PATItem) Title() string {
 	indicator := " "
@@ -23,9 +29,36 @@ func (i PATItem) Title() string {
 	} else if i.pat.IsSelected {
 		indicator = "✓"
 	}
-	return fmt.Sprintf("%s %s (%s)", indicator, i.pat.Name, i.pat.Provider)
+	provider := string(i.pat.Provider)
+	if i.pat.IsAnonymous() {
+		provider += ", no token"
+	} else if i.pat.OAuth != nil {
+		provider += ", OAuth"
+	}
+	title := fmt.Sprintf("%s %s (%s)", indicator, i.pat.Name, provider)
+	if i.problem != "" {
+		title += " " + patProblemStyle.Render("[✗ INVALID]")
+	}
+	return title
+}
+func (i PATItem) Description() string {
+	var description string
+	if i.pat.IsAnonymous() {
+		description = "Public: " + strings.Join(i.pat.Repositories, ", ")
+	} else {
+		description = i.pat.Username
+	}
+	if filter := formatOwnerFilter(i.pat.IncludeOwners, i.pat.ExcludeOwners); filter != "" {
+		description += " · orgs: " + filter
+	}
+	if !i.pat.ExpiresAt.IsZero() {
+		description += " · expires " + i.pat.ExpiresAt.Format(patExpiryLayout)
+	}
+	if i.problem != "" {
+		description += " · " + i.problem
+	}
+	return description
 }
-func (i PATItem) Description() string { return i.pat.Username }
 
 type PATMode int
 
@@ -35,18 +68,28 @@ const (
 	PATModeEdit
 )
 
+const patFormInputCount = 9
+
+const patExpiryLayout = "2006-01-02"
+
 type PATsViewModel struct {
 	list              list.Model
 	Mode              PATMode
 	nameInput         textinput.Model
 	tokenInput        textinput.Model
-	providerInput     textinput.Model
+	providers         []domain.ProviderType
+	providerIndex     int
 	usernameInput     textinput.Model
 	organizationInput textinput.Model
+	repositoriesInput textinput.Model
+	expiresInput      textinput.Model
+	scopesInput       textinput.Model
+	ownersInput       textinput.Model
 	inputFocus        int
 	width             int
 	height            int
 	editingPAT        *domain.PAT
+	problems          map[string]string
 }
 
 func NewPATsView() *PATsViewModel {
@@ -61,30 +104,46 @@ func NewPATsView() *PATsViewModel {
 	nameInput.CharLimit = 50
 
 	tokenInput := textinput.New()
-	tokenInput.Placeholder = "Token"
+	tokenInput.Placeholder = "Token (leave empty for read-only public GitHub)"
 	tokenInput.CharLimit = 256
 	tokenInput.EchoMode = textinput.EchoPassword
 
-	providerInput := textinput.New()
-	providerInput.Placeholder = "Provider (github/azuredevops)"
-	providerInput.CharLimit = 20
-
 	usernameInput := textinput.New()
-	usernameInput.Placeholder = "Username"
+	usernameInput.Placeholder = "Username (detected from the token when empty)"
 	usernameInput.CharLimit = 50
 
 	organizationInput := textinput.New()
-	organizationInput.Placeholder = "Organization (for Azure DevOps)"
+	organizationInput.Placeholder = "Organization (Azure DevOps) or server URL (Gerrit)"
 	organizationInput.CharLimit = 100
 
+	repositoriesInput := textinput.New()
+	repositoriesInput.Placeholder = "owner/repo, owner/other (public GitHub without token)"
+	repositoriesInput.CharLimit = 500
+
+	expiresInput := textinput.New()
+	expiresInput.Placeholder = "Expiry date YYYY-MM-DD (optional)"
+	expiresInput.CharLimit = 10
+
+	scopesInput := textinput.New()
+	scopesInput.Placeholder = "Scopes, e.g. repo, read:org (optional)"
+	scopesInput.CharLimit = 200
+
+	ownersInput := textinput.New()
+	ownersInput.Placeholder = "Orgs/projects to include, !name to exclude (optional)"
+	ownersInput.CharLimit = 500
+
 	return &PATsViewModel{
 		list:              l,
 		Mode:              PATModeList,
 		nameInput:         nameInput,
 		tokenInput:        tokenInput,
-		providerInput:     providerInput,
+		providers:         append([]domain.ProviderType(nil), builtinProviders...),
 		usernameInput:     usernameInput,
 		organizationInput: organizationInput,
+		repositoriesInput: repositoriesInput,
+		expiresInput:      expiresInput,
+		scopesInput:       scopesInput,
+		ownersInput:       ownersInput,
 		inputFocus:        0,
 	}
 }
@@ -98,7 +157,21 @@ func (m *PATsViewModel) SetSize(width, height int) {
 func (m *PATsViewModel) SetPATs(pats []domain.PAT) {
 	items := make([]list.Item, len(pats))
 	for i, pat := range pats {
-		items[i] = PATItem{pat: pat}
+		items[i] = PATItem{pat: pat, problem: m.problems[pat.ID]}
+	}
+	m.list.SetItems(items)
+}
+
+// SetPATProblems marks PATs that failed validation, keyed by PAT ID. They are
+// shown with a red badge until the next validation.
+func (m *PATsViewModel) SetPATProblems(problems map[string]string) {
+	m.problems = problems
+	items := m.list.Items()
+	for i, item := range items {
+		if patItem, ok := item.(PATItem); ok {
+			patItem.problem = problems[patItem.pat.ID]
+			items[i] = patItem
+		}
 	}
 	m.list.SetItems(items)
 }
@@ -107,34 +180,51 @@ func (m *PATsViewModel) EnterAddMode() {
 	m.Mode = PATModeAdd
 	m.editingPAT = nil
 	m.inputFocus = 0
+	m.hideToken()
 	m.nameInput.Focus()
 	m.nameInput.SetValue("")
 	m.tokenInput.SetValue("")
-	m.providerInput.SetValue("")
+	m.providerIndex = 0
 	m.usernameInput.SetValue("")
 	m.organizationInput.SetValue("")
+	m.repositoriesInput.SetValue("")
+	m.expiresInput.SetValue("")
+	m.scopesInput.SetValue("")
+	m.ownersInput.SetValue("")
 }
 
 func (m *PATsViewModel) EnterEditMode(pat domain.PAT) {
 	m.Mode = PATModeEdit
 	m.editingPAT = &pat
 	m.inputFocus = 0
+	m.hideToken()
 	m.nameInput.Focus()
 	m.nameInput.SetValue(pat.Name)
 	m.tokenInput.SetValue(pat.Token)
-	m.providerInput.SetValue(string(pat.Provider))
+	m.selectProvider(pat.Provider)
 	m.usernameInput.SetValue(pat.Username)
 	m.organizationInput.SetValue(pat.Organization)
+	m.repositoriesInput.SetValue(strings.Join(pat.Repositories, ", "))
+	m.expiresInput.SetValue("")
+	if !pat.ExpiresAt.IsZero() {
+		m.expiresInput.SetValue(pat.ExpiresAt.Format(patExpiryLayout))
+	}
+	m.scopesInput.SetValue(strings.Join(pat.Scopes, ", "))
+	m.ownersInput.SetValue(formatOwnerFilter(pat.IncludeOwners, pat.ExcludeOwners))
 }
 
 func (m *PATsViewModel) ExitEditMode() {
 	m.Mode = PATModeList
 	m.editingPAT = nil
+	m.hideToken()
 	m.nameInput.Blur()
 	m.tokenInput.Blur()
-	m.providerInput.Blur()
 	m.usernameInput.Blur()
 	m.organizationInput.Blur()
+	m.repositoriesInput.Blur()
+	m.expiresInput.Blur()
+	m.scopesInput.Blur()
+	m.ownersInput.Blur()
 }
 
 func (m *PATsViewModel) Update(msg tea.Msg) tea.Cmd {
@@ -160,6 +250,18 @@ func (m *PATsViewModel) updateFormMode(msg tea.Msg) tea.Cmd {
 		case "shift+tab", "up":
 			m.prevInput()
 			return nil
+		case "ctrl+r":
+			m.ToggleTokenReveal()
+			return nil
+		}
+		if m.inputFocus == patProviderInput {
+			switch msg.String() {
+			case "left", "h":
+				m.providerIndex = (m.providerIndex - 1 + len(m.providers)) % len(m.providers)
+			case "right", "l", " ":
+				m.providerIndex = (m.providerIndex + 1) % len(m.providers)
+			}
+			return nil
 		}
 	}
 
@@ -168,35 +270,133 @@ func (m *PATsViewModel) updateFormMode(msg tea.Msg) tea.Cmd {
 		m.nameInput, cmd = m.nameInput.Update(msg)
 	case 1:
 		m.tokenInput, cmd = m.tokenInput.Update(msg)
-	case 2:
-		m.providerInput, cmd = m.providerInput.Update(msg)
 	case 3:
 		m.usernameInput, cmd = m.usernameInput.Update(msg)
 	case 4:
 		m.organizationInput, cmd = m.organizationInput.Update(msg)
+	case 5:
+		m.repositoriesInput, cmd = m.repositoriesInput.Update(msg)
+	case 6:
+		m.expiresInput, cmd = m.expiresInput.Update(msg)
+	case 7:
+		m.scopesInput, cmd = m.scopesInput.Update(msg)
+	case 8:
+		m.ownersInput, cmd = m.ownersInput.Update(msg)
 	}
 
 	return cmd
 }
 
+// ToggleTokenReveal shows the token in plain text, or masks it again. The
+// token is masked again when focus leaves it or the form is closed.
+func (m *PATsViewModel) ToggleTokenReveal() {
+	if m.TokenRevealed() {
+		m.hideToken()
+		return
+	}
+	m.tokenInput.EchoMode = textinput.EchoNormal
+}
+
+func (m *PATsViewModel) TokenRevealed() bool {
+	return m.tokenInput.EchoMode == textinput.EchoNormal
+}
+
+func (m *PATsViewModel) hideToken() {
+	m.tokenInput.EchoMode = textinput.EchoPassword
+}
+
 func (m *PATsViewModel) nextInput() {
 	m.blurAll()
-	m.inputFocus = (m.inputFocus + 1) % 5
+	m.inputFocus = (m.inputFocus + 1) % patFormInputCount
+	if m.inputFocus == patOrganizationInput && !m.organizationVisible() {
+		m.inputFocus++
+	}
 	m.focusCurrent()
 }
 
 func (m *PATsViewModel) prevInput() {
 	m.blurAll()
-	m.inputFocus = (m.inputFocus - 1 + 5) % 5
+	m.inputFocus = (m.inputFocus - 1 + patFormInputCount) % patFormInputCount
+	if m.inputFocus == patOrganizationInput && !m.organizationVisible() {
+		m.inputFocus--
+	}
 	m.focusCurrent()
 }
 
+// Positions of the provider and organization fields in the form.
+const (
+	patProviderInput     = 2
+	patOrganizationInput = 4
+)
+
+// builtinProviders are offered in the form ahead of any installed plugins.
+var builtinProviders = []domain.ProviderType{
+	domain.ProviderGitHub,
+	domain.ProviderAzureDevOps,
+	domain.ProviderGerrit,
+}
+
+// SetPluginProviders offers the installed provider plugins in the form, after
+// the built-in providers.
+func (m *PATsViewModel) SetPluginProviders(names []string) {
+	selected := m.formProvider()
+	m.providers = append([]domain.ProviderType(nil), builtinProviders...)
+	for _, name := range names {
+		m.addProvider(domain.ProviderType(name))
+	}
+	m.selectProvider(selected)
+}
+
+// addProvider offers provider unless it already is, and returns its index.
+func (m *PATsViewModel) addProvider(provider domain.ProviderType) int {
+	for i, existing := range m.providers {
+		if strings.EqualFold(string(existing), string(provider)) {
+			return i
+		}
+	}
+	m.providers = append(m.providers, provider)
+	return len(m.providers) - 1
+}
+
+// selectProvider selects provider, offering it when it is not, such as the
+// provider of a PAT whose plugin has been removed, so that editing the PAT
+// keeps it.
+func (m *PATsViewModel) selectProvider(provider domain.ProviderType) {
+	if provider == "" {
+		m.providerIndex = 0
+		return
+	}
+	m.providerIndex = m.addProvider(provider)
+}
+
+func (m *PATsViewModel) formProvider() domain.ProviderType {
+	return m.providers[m.providerIndex]
+}
+
+// organizationVisible reports whether the provider entered uses the
+// organization field: Azure DevOps needs its organization, Gerrit its server
+// URL, and plugins may use it, but GitHub does not.
+func (m *PATsViewModel) organizationVisible() bool {
+	return m.formProvider() != domain.ProviderGitHub
+}
+
+func (m *PATsViewModel) organizationLabel() string {
+	if m.formProvider() == domain.ProviderGerrit {
+		return "Server URL:"
+	}
+	return "Organization:"
+}
+
 func (m *PATsViewModel) blurAll() {
+	m.hideToken()
 	m.nameInput.Blur()
 	m.tokenInput.Blur()
-	m.providerInput.Blur()
 	m.usernameInput.Blur()
 	m.organizationInput.Blur()
+	m.repositoriesInput.Blur()
+	m.expiresInput.Blur()
+	m.scopesInput.Blur()
+	m.ownersInput.Blur()
 }
 
 func (m *PATsViewModel) focusCurrent() {
@@ -205,12 +405,18 @@ func (m *PATsViewModel) focusCurrent() {
 		m.nameInput.Focus()
 	case 1:
 		m.tokenInput.Focus()
-	case 2:
-		m.providerInput.Focus()
 	case 3:
 		m.usernameInput.Focus()
 	case 4:
 		m.organizationInput.Focus()
+	case 5:
+		m.repositoriesInput.Focus()
+	case 6:
+		m.expiresInput.Focus()
+	case 7:
+		m.scopesInput.Focus()
+	case 8:
+		m.ownersInput.Focus()
 	}
 }
 
@@ -218,19 +424,133 @@ func (m *PATsViewModel) GetPATData() domain.PAT {
 	pat := domain.PAT{
 		Name:         m.nameInput.Value(),
 		Token:        m.tokenInput.Value(),
-		Provider:     domain.ProviderType(m.providerInput.Value()),
+		Provider:     m.formProvider(),
 		Username:     m.usernameInput.Value(),
-		Organization: m.organizationInput.Value(),
+		Organization: strings.TrimSpace(m.organizationInput.Value()),
+		Repositories: parseRepositoryList(m.repositoriesInput.Value()),
+		Scopes:       parseRepositoryList(m.scopesInput.Value()),
+	}
+	pat.IncludeOwners, pat.ExcludeOwners = parseOwnerFilter(m.ownersInput.Value())
+	if !m.organizationVisible() {
+		pat.Organization = ""
+	} else if pat.Provider == domain.ProviderAzureDevOps {
+		if organization, err := parseAzureDevOpsOrganization(pat.Organization); err == nil {
+			pat.Organization = organization
+		}
+	}
+	if expiresAt, err := parseExpiryDate(m.expiresInput.Value()); err == nil {
+		pat.ExpiresAt = expiresAt
 	}
 
 	if m.Mode == PATModeEdit && m.editingPAT != nil {
 		pat.ID = m.editingPAT.ID
 		pat.IsActive = m.editingPAT.IsActive
+		pat.IsSelected = m.editingPAT.IsSelected
+		pat.IsPrimary = m.editingPAT.IsPrimary
+		// Keep the device-flow refresh state unless a different token was pasted.
+		if pat.Token == m.editingPAT.Token {
+			pat.OAuth = m.editingPAT.OAuth
+		}
 	}
 
 	return pat
 }
 
+// ValidateForm reports form values that GetPATData cannot represent.
+func (m *PATsViewModel) ValidateForm() error {
+	if _, err := parseExpiryDate(m.expiresInput.Value()); err != nil {
+		return fmt.Errorf("invalid expiry date %q, expected YYYY-MM-DD", m.expiresInput.Value())
+	}
+	if m.formProvider() == domain.ProviderAzureDevOps {
+		if _, err := parseAzureDevOpsOrganization(m.organizationInput.Value()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseAzureDevOpsOrganization accepts an organization name or a URL of
+// the organization, as copied from the browser, and returns the name.
+func parseAzureDevOpsOrganization(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("Azure DevOps PATs need an organization")
+	}
+
+	organization := value
+	if strings.Contains(value, "://") {
+		u, err := url.Parse(value)
+		if err != nil || u.Host == "" {
+			return "", fmt.Errorf("invalid organization URL %q", value)
+		}
+		host := strings.ToLower(u.Host)
+		switch {
+		case host == "dev.azure.com":
+			organization, _, _ = strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+		case strings.HasSuffix(host, ".visualstudio.com"):
+			organization = strings.TrimSuffix(host, ".visualstudio.com")
+		default:
+			return "", fmt.Errorf("%q is not an Azure DevOps organization URL", value)
+		}
+	}
+
+	if !azureDevOpsOrganizationPattern.MatchString(organization) {
+		return "", fmt.Errorf("invalid Azure DevOps organization %q", organization)
+	}
+	return organization, nil
+}
+
+// azureDevOpsOrganizationPattern matches organization names: letters, digits
+// and hyphens, not starting or ending with a hyphen.
+var azureDevOpsOrganizationPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?$`)
+
+// parseExpiryDate parses an optional expiry date. Tokens stay valid for the
+// whole day, so the expiry is the end of that day in local time.
+func parseExpiryDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.ParseInLocation(patExpiryLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return date.Add(24*time.Hour - time.Second), nil
+}
+
+// parseOwnerFilter splits "work-org, !personal" into include and exclude
+// lists of organizations or projects.
+func parseOwnerFilter(value string) (include, exclude []string) {
+	for _, owner := range parseRepositoryList(value) {
+		if name, ok := strings.CutPrefix(owner, "!"); ok {
+			if name != "" {
+				exclude = append(exclude, name)
+			}
+			continue
+		}
+		include = append(include, owner)
+	}
+	return include, exclude
+}
+
+func formatOwnerFilter(include, exclude []string) string {
+	owners := append([]string(nil), include...)
+	for _, owner := range exclude {
+		owners = append(owners, "!"+owner)
+	}
+	return strings.Join(owners, ", ")
+}
+
+func parseRepositoryList(value string) []string {
+	var repositories []string
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' '
+	}) {
+		repositories = append(repositories, field)
+	}
+	return repositories
+}
+
 func (m *PATsViewModel) GetSelectedPAT() *domain.PAT {
 	item := m.list.SelectedItem()
 	if item == nil {
@@ -261,6 +581,30 @@ func (m *PATsViewModel) viewListMode() string {
 	return m.list.View() + help
 }
 
+// viewProviders shows the providers to choose from, with the selected one
+// highlighted.
+func (m *PATsViewModel) viewProviders() string {
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F9FAFB")).Background(lipgloss.Color("#7C3AED")).Bold(true)
+	if m.inputFocus != patProviderInput {
+		selectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED")).Bold(true)
+	}
+	optionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	prompt := "  "
+	if m.inputFocus == patProviderInput {
+		prompt = "> "
+	}
+	options := make([]string, len(m.providers))
+	for i, provider := range m.providers {
+		if i == m.providerIndex {
+			options[i] = selectedStyle.Render(" " + string(provider) + " ")
+		} else {
+			options[i] = optionStyle.Render(" " + string(provider) + " ")
+		}
+	}
+	return prompt + strings.Join(options, " ")
+}
+
 func (m *PATsViewModel) viewFormMode() string {
 	var b strings.Builder
 
@@ -277,19 +621,33 @@ func (m *PATsViewModel) viewFormMode() string {
 	b.WriteString(title)
 	b.WriteString("Name:\n")
 	b.WriteString(m.nameInput.View() + "\n\n")
-	b.WriteString("Token:\n")
+	if m.TokenRevealed() {
+		b.WriteString("Token (shown):\n")
+	} else {
+		b.WriteString("Token:\n")
+	}
 	b.WriteString(m.tokenInput.View() + "\n\n")
 	b.WriteString("Provider:\n")
-	b.WriteString(m.providerInput.View() + "\n\n")
+	b.WriteString(m.viewProviders() + "\n\n")
 	b.WriteString("Username:\n")
 	b.WriteString(m.usernameInput.View() + "\n\n")
-	b.WriteString("Organization:\n")
-	b.WriteString(m.organizationInput.View() + "\n\n")
+	if m.organizationVisible() {
+		b.WriteString(m.organizationLabel() + "\n")
+		b.WriteString(m.organizationInput.View() + "\n\n")
+	}
+	b.WriteString("Repositories:\n")
+	b.WriteString(m.repositoriesInput.View() + "\n\n")
+	b.WriteString("Expires:\n")
+	b.WriteString(m.expiresInput.View() + "\n\n")
+	b.WriteString("Scopes:\n")
+	b.WriteString(m.scopesInput.View() + "\n\n")
+	b.WriteString("Orgs/Projects:\n")
+	b.WriteString(m.ownersInput.View() + "\n\n")
 
 	help := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#6B7280")).
 		Italic(true).
-		Render("Tab: Next | Shift+Tab: Previous | Enter: Save | Esc: Cancel")
+		Render("Tab: Next | Shift+Tab: Previous | ←/→: Choose provider | Ctrl+R: Show/hide token | Ctrl+T: Test connection | Enter: Save | Esc: Cancel")
 
 	b.WriteString(help)
 
@@ -0,0 +1,250 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestPATForm_ExpiryAndScopes(t *testing.T) {
+	view := NewPATsView()
+	view.EnterAddMode()
+	view.nameInput.SetValue("work")
+	view.expiresInput.SetValue("2030-06-15")
+	view.scopesInput.SetValue("repo, read:org")
+
+	if err := view.ValidateForm(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pat := view.GetPATData()
+	if pat.ExpiresAt.Format(patExpiryLayout) != "2030-06-15" {
+		t.Errorf("unexpected expiry: %v", pat.ExpiresAt)
+	}
+	if pat.IsExpired(time.Date(2030, 6, 15, 18, 0, 0, 0, time.Local)) {
+		t.Error("expected the PAT to stay valid for the whole expiry day")
+	}
+	if len(pat.Scopes) != 2 || pat.Scopes[1] != "read:org" {
+		t.Errorf("unexpected scopes: %v", pat.Scopes)
+	}
+}
+
+func TestPATForm_InvalidExpiry(t *testing.T) {
+	view := NewPATsView()
+	view.EnterAddMode()
+	view.expiresInput.SetValue("15/06/2030")
+
+	if err := view.ValidateForm(); err == nil {
+		t.Error("expected an error for a malformed expiry date")
+	}
+}
+
+func TestPATItem_ShowsProblemBadge(t *testing.T) {
+	view := NewPATsView()
+	view.SetPATs([]domain.PAT{{ID: "1", Name: "work", Provider: domain.ProviderGitHub}})
+	view.SetPATProblems(map[string]string{"1": "validation failed"})
+
+	item := view.list.Items()[0].(PATItem)
+	if !strings.Contains(item.Title(), "INVALID") {
+		t.Errorf("expected an invalid badge, got %q", item.Title())
+	}
+	if !strings.Contains(item.Description(), "validation failed") {
+		t.Errorf("expected the problem in the description, got %q", item.Description())
+	}
+}
+
+func TestPATForm_OwnerFilter(t *testing.T) {
+	view := NewPATsView()
+	view.EnterAddMode()
+	view.ownersInput.SetValue("acme, !acme-archive,  tools ,")
+
+	pat := view.GetPATData()
+	if len(pat.IncludeOwners) != 2 || pat.IncludeOwners[0] != "acme" || pat.IncludeOwners[1] != "tools" {
+		t.Errorf("unexpected include list: %v", pat.IncludeOwners)
+	}
+	if len(pat.ExcludeOwners) != 1 || pat.ExcludeOwners[0] != "acme-archive" {
+		t.Errorf("unexpected exclude list: %v", pat.ExcludeOwners)
+	}
+
+	view.EnterEditMode(pat)
+	if got := view.ownersInput.Value(); got != "acme, tools, !acme-archive" {
+		t.Errorf("unexpected edit value: %q", got)
+	}
+
+	view.SetPATs([]domain.PAT{pat})
+	item := view.list.Items()[0].(PATItem)
+	if !strings.Contains(item.Description(), "orgs: acme, tools, !acme-archive") {
+		t.Errorf("expected the owner filter in the description, got %q", item.Description())
+	}
+}
+
+func TestPATForm_EditKeepsIdentityAndSelection(t *testing.T) {
+	view := NewPATsView()
+	original := domain.PAT{
+		ID:           "pat-1",
+		Name:         "work",
+		Token:        "secret-token",
+		Provider:     domain.ProviderAzureDevOps,
+		Username:     "Jane Doe",
+		Organization: "contoso",
+		IsActive:     true,
+		IsSelected:   true,
+		IsPrimary:    true,
+	}
+	view.EnterEditMode(original)
+
+	if view.nameInput.Value() != "work" || view.tokenInput.Value() != "secret-token" || view.organizationInput.Value() != "contoso" {
+		t.Fatalf("expected the form to be pre-populated, got name %q token %q organization %q",
+			view.nameInput.Value(), view.tokenInput.Value(), view.organizationInput.Value())
+	}
+
+	view.nameInput.SetValue("work (renamed)")
+	pat := view.GetPATData()
+	if pat.ID != "pat-1" || pat.Name != "work (renamed)" {
+		t.Errorf("expected the ID to be kept and the name changed, got %+v", pat)
+	}
+	if !pat.IsActive || !pat.IsSelected || !pat.IsPrimary {
+		t.Errorf("expected editing to keep the PAT selected and primary, got %+v", pat)
+	}
+}
+
+func TestPATForm_TokenReveal(t *testing.T) {
+	view := NewPATsView()
+	view.EnterAddMode()
+	view.tokenInput.SetValue("ghp_secret")
+	view.nextInput()
+
+	if strings.Contains(view.View(), "ghp_secret") {
+		t.Fatal("expected the token to be masked")
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if !view.TokenRevealed() || !strings.Contains(view.View(), "ghp_secret") {
+		t.Fatal("expected ctrl+r to show the token")
+	}
+	view.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if view.TokenRevealed() {
+		t.Error("expected ctrl+r to mask the token again")
+	}
+
+	view.ToggleTokenReveal()
+	view.nextInput()
+	if view.TokenRevealed() {
+		t.Error("expected the token to be masked once focus leaves it")
+	}
+
+	view.ToggleTokenReveal()
+	view.ExitEditMode()
+	view.EnterAddMode()
+	if view.TokenRevealed() {
+		t.Error("expected a new form to start with the token masked")
+	}
+}
+
+func TestPATForm_OrganizationOnlyForProvidersThatUseIt(t *testing.T) {
+	view := NewPATsView()
+	view.EnterAddMode()
+	view.selectProvider("github")
+	view.organizationInput.SetValue("left-over")
+
+	if strings.Contains(view.View(), "Organization:") {
+		t.Error("expected no organization field for GitHub")
+	}
+	view.inputFocus = 3
+	view.nextInput()
+	if view.inputFocus != 5 {
+		t.Errorf("expected tab to skip the hidden organization field, got focus %d", view.inputFocus)
+	}
+	view.prevInput()
+	if view.inputFocus != 3 {
+		t.Errorf("expected shift+tab to skip the hidden organization field, got focus %d", view.inputFocus)
+	}
+	if pat := view.GetPATData(); pat.Organization != "" {
+		t.Errorf("expected no organization for GitHub, got %q", pat.Organization)
+	}
+
+	view.selectProvider("gerrit")
+	if !strings.Contains(view.View(), "Server URL:") {
+		t.Error("expected the server URL field for Gerrit")
+	}
+
+	view.selectProvider("azuredevops")
+	if !strings.Contains(view.View(), "Organization:") {
+		t.Error("expected the organization field for Azure DevOps")
+	}
+}
+
+func TestPATForm_ValidatesAzureDevOpsOrganization(t *testing.T) {
+	view := NewPATsView()
+	view.EnterAddMode()
+	view.selectProvider("azuredevops")
+
+	if err := view.ValidateForm(); err == nil {
+		t.Error("expected an error without an organization")
+	}
+
+	for value, want := range map[string]string{
+		"contoso":       "contoso",
+		" contoso-dev ": "contoso-dev",
+		"https://dev.azure.com/contoso/Project/_git": "contoso",
+		"https://contoso.visualstudio.com/":          "contoso",
+	} {
+		view.organizationInput.SetValue(value)
+		if err := view.ValidateForm(); err != nil {
+			t.Errorf("unexpected error for %q: %v", value, err)
+			continue
+		}
+		if got := view.GetPATData().Organization; got != want {
+			t.Errorf("expected %q to be stored as %q, got %q", value, want, got)
+		}
+	}
+
+	for _, value := range []string{"contoso org", "-contoso", "https://github.com/contoso"} {
+		view.organizationInput.SetValue(value)
+		if err := view.ValidateForm(); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+}
+
+func TestPATForm_ProviderSelect(t *testing.T) {
+	view := NewPATsView()
+	view.SetPluginProviders([]string{"phabricator", "github"})
+	view.EnterAddMode()
+
+	if pat := view.GetPATData(); pat.Provider != domain.ProviderGitHub {
+		t.Errorf("expected GitHub to be selected by default, got %q", pat.Provider)
+	}
+
+	view.nextInput()
+	view.nextInput()
+	if !strings.Contains(view.View(), "> ") {
+		t.Error("expected the provider field to show it has focus")
+	}
+	var chosen []domain.ProviderType
+	for range 4 {
+		view.Update(tea.KeyMsg{Type: tea.KeyRight})
+		chosen = append(chosen, view.GetPATData().Provider)
+	}
+	want := []domain.ProviderType{domain.ProviderAzureDevOps, domain.ProviderGerrit, "phabricator", domain.ProviderGitHub}
+	if fmt.Sprint(chosen) != fmt.Sprint(want) {
+		t.Errorf("expected right to cycle through %v, got %v", want, chosen)
+	}
+	view.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if got := view.GetPATData().Provider; got != "phabricator" {
+		t.Errorf("expected left to go back, got %q", got)
+	}
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if got := view.GetPATData().Provider; got != "phabricator" {
+		t.Errorf("expected typing to leave the provider alone, got %q", got)
+	}
+
+	view.EnterEditMode(domain.PAT{ID: "1", Name: "old", Provider: "removed-plugin"})
+	if got := view.GetPATData().Provider; got != "removed-plugin" {
+		t.Errorf("expected editing to keep a provider that is no longer installed, got %q", got)
+	}
+}
@@ -0,0 +1,138 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/textwidth"
+)
+
+// perfColumnsWidth is the width of the columns after the endpoint.
+const perfColumnsWidth = 7 + 7 + 9 + 9 + 9
+
+// PerfViewModel lists the latency of the API calls made per endpoint,
+// slowest first, to diagnose slow loading.
+type PerfViewModel struct {
+	viewport  viewport.Model
+	width     int
+	height    int
+	active    bool
+	endpoints []domain.EndpointMetrics
+}
+
+func NewPerfView() *PerfViewModel {
+	return &PerfViewModel{
+		viewport: viewport.New(0, 0),
+	}
+}
+
+func (m *PerfViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.viewport.Width = width
+	m.viewport.Height = height - 10
+	if m.active {
+		m.updateViewport()
+	}
+}
+
+func (m *PerfViewModel) Activate(endpoints []domain.EndpointMetrics) {
+	m.active = true
+	m.endpoints = endpoints
+	m.viewport.GotoTop()
+	m.updateViewport()
+}
+
+// Refresh shows endpoints in place of the metrics shown, keeping the scroll
+// position.
+func (m *PerfViewModel) Refresh(endpoints []domain.EndpointMetrics) {
+	m.endpoints = endpoints
+	m.updateViewport()
+}
+
+func (m *PerfViewModel) Deactivate() {
+	m.active = false
+	m.endpoints = nil
+}
+
+func (m *PerfViewModel) IsActive() bool {
+	return m.active
+}
+
+func (m *PerfViewModel) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return cmd
+}
+
+func (m *PerfViewModel) View() string {
+	if !m.active {
+		return ""
+	}
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	help := helpStyle.Render("\nj/k: Scroll | r: Refresh | q/Esc: Close")
+
+	return m.viewport.View() + "\n" + help
+}
+
+func formatLatency(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
+
+func (m *PerfViewModel) updateViewport() {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true).
+		Padding(1, 0)
+	headerStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Bold(true)
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("15"))
+	failedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#EF4444"))
+
+	b.WriteString(titleStyle.Render("API Call Latency"))
+	b.WriteString("\n\n")
+
+	if len(m.endpoints) == 0 {
+		b.WriteString(lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#6B7280")).
+			Italic(true).
+			Render("No API calls made yet."))
+		m.viewport.SetContent(b.String())
+		return
+	}
+
+	endpointWidth := max(m.width-2-perfColumnsWidth, 20)
+	b.WriteString(headerStyle.Render(fmt.Sprintf("  %-*s %6s %6s %8s %8s %8s",
+		endpointWidth, "Endpoint", "Calls", "Failed", "p50", "p95", "Max")))
+	b.WriteString("\n")
+
+	for _, e := range m.endpoints {
+		style := labelStyle
+		if e.Failures > 0 {
+			style = failedStyle
+		}
+		b.WriteString(style.Render(fmt.Sprintf("  %s %6d %6d %8s %8s %8s",
+			textwidth.Pad(e.Endpoint, endpointWidth), e.Calls, e.Failures,
+			formatLatency(e.P50), formatLatency(e.P95), formatLatency(e.Max))))
+		b.WriteString("\n")
+	}
+
+	m.viewport.SetContent(b.String())
+}
@@ -0,0 +1,33 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestPerfView_ListsTheLatencyOfEachEndpoint(t *testing.T) {
+	view := NewPerfView()
+	view.SetSize(120, 30)
+	view.Activate([]domain.EndpointMetrics{
+		{Endpoint: "GET api.github.com/search/issues", Calls: 3, P50: 1200 * time.Millisecond, P95: 2500 * time.Millisecond, Max: 2500 * time.Millisecond},
+		{Endpoint: "GET api.github.com/repos/acme/api/pulls/:n", Calls: 12, Failures: 1, P50: 80 * time.Millisecond, P95: 310 * time.Millisecond, Max: 400 * time.Millisecond},
+	})
+
+	out := view.View()
+	for _, want := range []string{"search/issues", "1.2s", "2.5s", "pulls/:n", "80ms", "310ms"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the panel, got:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "search/issues") > strings.Index(out, "pulls/:n") {
+		t.Error("expected the endpoints in the order given")
+	}
+
+	view.Refresh(nil)
+	if !strings.Contains(view.View(), "No API calls made yet") {
+		t.Error("expected the empty state after refreshing without calls")
+	}
+}
@@ -0,0 +1,141 @@
+package views
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+const (
+	benchPRCount       = 5000
+	benchDiffLineCount = 50000
+	benchCursorLines   = 10000
+
+	prListRebuildBudget   = 250 * time.Millisecond
+	prInspectRenderBudget = time.Second
+	prInspectCursorBudget = 16 * time.Millisecond
+)
+
+func buildBenchPRs(n int) []domain.PullRequest {
+	categories := []domain.PRCategory{domain.PRCategoryAuthored, domain.PRCategoryAssigned, domain.PRCategoryOther}
+	now := time.Now()
+
+	prs := make([]domain.PullRequest, n)
+	for i := range prs {
+		prs[i] = domain.PullRequest{
+			ID:             fmt.Sprintf("pr-%d", i),
+			Number:         i + 1,
+			Title:          fmt.Sprintf("Refactor module %d to use the new provider interface", i),
+			Author:         domain.User{Username: fmt.Sprintf("user%d", i%50)},
+			Repository:     domain.Repo{FullName: fmt.Sprintf("org/repo-%d", i%100)},
+			Category:       categories[i%len(categories)],
+			ApprovalStatus: domain.ApprovalStatusPending,
+			CreatedAt:      now.Add(-time.Duration(i) * time.Hour),
+			UpdatedAt:      now.Add(-time.Duration(i) * time.Minute),
+		}
+	}
+	return prs
+}
+
+func buildBenchDiff(lineCount int) *domain.Diff {
+	const linesPerHunk = 50
+
+	file := domain.FileDiff{OldPath: "big/file.go", NewPath: "big/file.go"}
+	for start := 0; start < lineCount; start += linesPerHunk {
+		hunk := domain.DiffHunk{Header: fmt.Sprintf("@@ -%d,%d +%d,%d @@", start+1, linesPerHunk, start+1, linesPerHunk)}
+		for i := start; i < start+linesPerHunk && i < lineCount; i++ {
+			line := domain.DiffLine{OldLine: i + 1, NewLine: i + 1}
+			switch i % 3 {
+			case 0:
+				line.Type = "add"
+				line.Content = fmt.Sprintf("+\tvalue%d := compute(%d)", i, i)
+			case 1:
+				line.Type = "delete"
+				line.Content = fmt.Sprintf("-\tvalue%d := legacy(%d)", i, i)
+			default:
+				line.Type = "context"
+				line.Content = fmt.Sprintf(" \treturn value%d", i)
+			}
+			hunk.Lines = append(hunk.Lines, line)
+		}
+		file.Hunks = append(file.Hunks, hunk)
+	}
+	return &domain.Diff{Files: []domain.FileDiff{file}}
+}
+
+func BenchmarkPRListRebuild(b *testing.B) {
+	view := NewPRListView()
+	view.SetSize(200, 60)
+	prs := buildBenchPRs(benchPRCount)
+	view.SetPRs(prs)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		view.rebuild()
+	}
+}
+
+func BenchmarkPRInspectRenderDiff(b *testing.B) {
+	view := NewPRInspectView()
+	view.SetSize(200, 60)
+	view.SetDiff(buildBenchDiff(benchDiffLineCount))
+	view.SwitchToDiff()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		view.updateViewport()
+	}
+}
+
+// BenchmarkPRInspectMoveCursor measures a j key press on a long diff: moving
+// the cursor and drawing the view.
+func BenchmarkPRInspectMoveCursor(b *testing.B) {
+	view := NewPRInspectView()
+	view.SetSize(200, 60)
+	view.SetDiff(buildBenchDiff(benchCursorLines))
+	view.SwitchToDiff()
+	view.JumpToLine(0, benchCursorLines/2)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if i%2 == 0 {
+			view.NextLine()
+		} else {
+			view.PrevLine()
+		}
+		_ = view.View()
+	}
+}
+
+// Budgets are only enforced when LGTMFASTER_PERF_BUDGETS is set, so that
+// regular (and race-enabled) test runs are not sensitive to machine speed.
+func TestPerformanceBudgets(t *testing.T) {
+	if os.Getenv("LGTMFASTER_PERF_BUDGETS") == "" {
+		t.Skip("set LGTMFASTER_PERF_BUDGETS=1 to enforce performance budgets")
+	}
+
+	budgets := []struct {
+		name   string
+		budget time.Duration
+		bench  func(*testing.B)
+	}{
+		{"PRListRebuild", prListRebuildBudget, BenchmarkPRListRebuild},
+		{"PRInspectRenderDiff", prInspectRenderBudget, BenchmarkPRInspectRenderDiff},
+		{"PRInspectMoveCursor", prInspectCursorBudget, BenchmarkPRInspectMoveCursor},
+	}
+
+	for _, tc := range budgets {
+		result := testing.Benchmark(tc.bench)
+		perOp := time.Duration(result.NsPerOp())
+		t.Logf("%s: %v/op (budget %v)", tc.name, perOp, tc.budget)
+		if perOp > tc.budget {
+			t.Errorf("%s exceeded performance budget: %v/op > %v", tc.name, perOp, tc.budget)
+		}
+	}
+}
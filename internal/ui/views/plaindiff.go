@@ -0,0 +1,176 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/textwidth"
+)
+
+// plainLineLabels name the kind of each diff line in the plain rendering,
+// where color and +/- markers cannot be relied on.
+var plainLineLabels = map[string]string{
+	"add":     "ADDED",
+	"delete":  "REMOVED",
+	"context": "UNCHANGED",
+}
+
+// renderPlainDiff renders the current file for screen readers: one line of
+// plain text per diff line, saying what kind of line it is and its number,
+// with no colors, box drawing or symbols. Lines wrap rather than scroll
+// sideways, and comments, drafts and findings are spelled out beneath their
+// line.
+func (m *PRInspectViewModel) renderPlainDiff() string {
+	var b strings.Builder
+
+	file := m.diff.Files[m.currentFile]
+	fmt.Fprintf(&b, "File %d of %d: %s", m.currentFile+1, len(m.diff.Files), getFilePath(file))
+	if annotations := m.annotations[getFilePath(file)]; len(annotations) > 0 {
+		fmt.Fprintf(&b, ", %d finding(s)", len(annotations))
+	}
+	if owners := m.codeOwners.Owners(getFilePath(file)); len(owners) > 0 {
+		fmt.Fprintf(&b, ", owned by %s", strings.Join(owners, ", "))
+	}
+	b.WriteString("\n\n")
+
+	if file.Binary != nil {
+		m.cursorRow = 0
+		kind := "Binary file"
+		if strings.HasPrefix(binaryFileType(getFilePath(file)), "image/") {
+			kind = "Image"
+		}
+		change := "modified"
+		switch {
+		case file.IsNew:
+			change = "added"
+		case file.IsDeleted:
+			change = "deleted"
+		}
+		fmt.Fprintf(&b, "%s %s, type %s, size %s\n", kind, change, binaryFileType(getFilePath(file)),
+			strings.ReplaceAll(binarySizeChange(file), " → ", " to "))
+		return b.String()
+	}
+	if file.Deferred {
+		m.cursorRow = 0
+		if loadErr := m.fileLoadErrors[getFilePath(file)]; loadErr != "" {
+			b.WriteString("Could not load the diff of this file: " + loadErr + "\n")
+		} else {
+			b.WriteString("Loading the diff of this file.\n")
+		}
+		if deferredFileStats(file) != "" {
+			fmt.Fprintf(&b, "%d line(s) added, %d removed\n", file.Additions, file.Deletions)
+		}
+		return b.String()
+	}
+
+	row := 2
+	lineIdx := 0
+	for _, hunk := range file.Hunks {
+		if m.diffViewMode == DiffViewModeFull || m.hunkHasChanges(hunk) {
+			b.WriteString("Hunk " + hunk.Header + "\n")
+			row++
+		}
+
+		for _, line := range hunk.Lines {
+			if m.diffViewMode == DiffViewModeCompact && line.Type == "context" {
+				lineIdx++
+				continue
+			}
+			if lineIdx == m.currentLineIdx {
+				m.cursorRow = row
+			}
+			rendered := m.renderPlainDiffLine(line, lineIdx)
+			for _, comment := range m.commentsOnLine(line) {
+				rendered += plainComment(comment, false)
+			}
+			for _, idx := range m.pendingIndicesOnLine(m.currentFile, line) {
+				rendered += plainParagraph("PENDING COMMENT", m.pendingComments[idx].Body)
+			}
+			for _, annotation := range m.annotationsOnLine(m.currentFile, line) {
+				rendered += fmt.Sprintf("    %s from %s: %s\n", strings.ToUpper(string(annotation.Severity)), annotation.Source, annotation.Message)
+			}
+			b.WriteString(rendered)
+			row += strings.Count(rendered, "\n")
+			lineIdx++
+		}
+	}
+
+	if m.showComments {
+		b.WriteString("\nComments\n")
+		for _, comment := range m.comments {
+			if comment.FilePath == getFilePath(file) || comment.FilePath == "" {
+				b.WriteString(plainComment(comment, true))
+			}
+		}
+	}
+	return b.String()
+}
+
+// renderPlainDiffLine renders a line as "ADDED line 12: content", marking
+// the cursor line with a leading ">".
+func (m *PRInspectViewModel) renderPlainDiffLine(line domain.DiffLine, lineIdx int) string {
+	prefix := "  "
+	if lineIdx == m.currentLineIdx {
+		prefix = "> "
+	}
+	label := plainLineLabels[line.Type]
+	if label == "" {
+		label = strings.ToUpper(line.Type)
+	}
+	prefix += fmt.Sprintf("%s line %d: ", label, diffLineNumber(line))
+
+	// The label says what the +, - or space in front of the content did.
+	content := line.Content
+	if content != "" && strings.ContainsRune("+- ", rune(content[0])) {
+		content = content[1:]
+	}
+	content = expandTabs(content)
+	if strings.TrimSpace(content) == "" {
+		content = "blank"
+	}
+
+	width := m.diffContentWidth(prefix)
+	if width == 0 {
+		return prefix + content + "\n"
+	}
+	var b strings.Builder
+	for i, part := range textwidth.Wrap(content, width) {
+		if i == 0 {
+			b.WriteString(prefix + part + "\n")
+		} else {
+			b.WriteString("    continued: " + part + "\n")
+		}
+	}
+	return b.String()
+}
+
+// plainComment renders comment under its line, or with the line it is on
+// when withLine is set.
+func plainComment(comment domain.Comment, withLine bool) string {
+	label := "COMMENT by " + comment.Author.Username
+	if withLine && comment.Line > 0 {
+		label += fmt.Sprintf(" on line %d", comment.Line)
+	}
+	text := plainParagraph(label, comment.Body)
+	var reactions []string
+	for _, reaction := range comment.Reactions {
+		if reaction.Count > 0 {
+			reactions = append(reactions, fmt.Sprintf("%s %d", reaction.Content, reaction.Count))
+		}
+	}
+	if len(reactions) > 0 {
+		text += "    Reactions: " + strings.Join(reactions, ", ") + "\n"
+	}
+	return text
+}
+
+// plainParagraph renders a labeled block of text indented under its line.
+func plainParagraph(label, body string) string {
+	var b strings.Builder
+	b.WriteString("    " + label + ":\n")
+	for _, line := range strings.Split(strings.TrimRight(body, "\n"), "\n") {
+		b.WriteString("      " + line + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,75 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func newPlainDiffView() *PRInspectViewModel {
+	view := NewPRInspectView()
+	view.SetSize(80, 40)
+	view.SetDiff(&domain.Diff{
+		Files: []domain.FileDiff{
+			{
+				NewPath: "retry.go",
+				Hunks: []domain.DiffHunk{
+					{
+						Header: "@@ -1,3 +1,3 @@",
+						Lines: []domain.DiffLine{
+							{Type: "context", Content: " package retry", OldLine: 1, NewLine: 1},
+							{Type: "delete", Content: "-const max = 3", OldLine: 2},
+							{Type: "add", Content: "+const max = 5", NewLine: 2},
+							{Type: "add", Content: "+", NewLine: 3},
+						},
+					},
+				},
+			},
+		},
+	})
+	view.SwitchToDiff()
+	view.SetComments([]domain.Comment{{Author: domain.User{Username: "alice"}, Body: "Why 5?", FilePath: "retry.go", Line: 2}})
+	view.SetPlain(true)
+	return view
+}
+
+func TestPlainDiff_LabelsEveryLine(t *testing.T) {
+	view := newPlainDiffView()
+	out := view.View()
+
+	for _, want := range []string{
+		"File 1 of 1: retry.go",
+		"Hunk @@ -1,3 +1,3 @@",
+		"> UNCHANGED line 1: package retry",
+		"  REMOVED line 2: const max = 3",
+		"  ADDED line 2: const max = 5",
+		"  ADDED line 3: blank",
+		"COMMENT by alice:",
+		"Why 5?",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the plain diff:\n%s", want, out)
+		}
+	}
+	diff := out[strings.Index(out, "File 1 of 1"):strings.Index(out, "Why 5?")]
+	for _, symbol := range []string{"►", "│", "┃", "💭"} {
+		if strings.Contains(diff, symbol) {
+			t.Errorf("expected no %q in the plain diff", symbol)
+		}
+	}
+}
+
+func TestPlainDiff_WrapsLongLines(t *testing.T) {
+	view := newPlainDiffView()
+	view.diff.Files[0].Hunks[0].Lines[2].Content = "+" + strings.Repeat("x", 120)
+	view.SetPlain(true)
+
+	if !strings.Contains(view.View(), "continued: ") {
+		t.Error("expected a line wider than the view to wrap")
+	}
+	view.ScrollRight()
+	if view.HorizontalOffset() != 0 {
+		t.Error("expected the plain diff not to scroll sideways")
+	}
+}
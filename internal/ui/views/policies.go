@@ -0,0 +1,73 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+// SetPolicies sets the branch policy evaluations shown in the description
+// view.
+func (m *PRInspectViewModel) SetPolicies(policies []domain.PolicyEvaluation) {
+	m.policies = policies
+	m.updateViewport()
+}
+
+func policyStatusLabel(status domain.PolicyStatus) string {
+	switch status {
+	case domain.PolicyApproved:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Render("✓ passed")
+	case domain.PolicyRejected:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render("✗ failed")
+	case domain.PolicyBroken:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render("✗ broken")
+	case domain.PolicyRunning:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Render("● running")
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Render("… queued")
+}
+
+// renderPolicies lists the branch policies of a PR for the description view,
+// counting how many of the required ones pass.
+func renderPolicies(policies []domain.PolicyEvaluation) string {
+	if len(policies) == 0 {
+		return ""
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Bold(true)
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	required, passed := 0, 0
+	for _, policy := range policies {
+		if !policy.Optional {
+			required++
+			if policy.Passed() {
+				passed++
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Policies (%d/%d required passed)", passed, required)))
+	b.WriteString("\n")
+
+	for _, policy := range policies {
+		line := "  " + policyStatusLabel(policy.Status) + " " + nameStyle.Render(policy.Name)
+		var details []string
+		if policy.Detail != "" {
+			details = append(details, policy.Detail)
+		}
+		if policy.Optional {
+			details = append(details, "optional")
+		}
+		if len(details) > 0 {
+			line += mutedStyle.Render(" (" + strings.Join(details, ", ") + ")")
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
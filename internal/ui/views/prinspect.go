@@ -2,7 +2,9 @@ package views
 
 import (
 	"fmt"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -10,6 +12,7 @@ import (
 	"github.com/johanforsgren/lgtmfaster/internal/domain"
 	"github.com/johanforsgren/lgtmfaster/internal/logger"
 	"github.com/johanforsgren/lgtmfaster/internal/ui/markdown"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/textwidth"
 )
 
 type PRInspectMode int
@@ -26,6 +29,14 @@ const (
 	DiffViewModeCompact                     // Show only added/deleted lines
 )
 
+// horizontalScrollStep is the number of columns the diff scrolls sideways per
+// key press when lines are not wrapped.
+const horizontalScrollStep = 8
+
+// diffWindowMinMargin is the least number of rows styled above and below the
+// viewport, so that small scrolls do not need a new render.
+const diffWindowMinMargin = 20
+
 type PRInspectViewModel struct {
 	pr              *domain.PullRequest
 	diff            *domain.Diff
@@ -39,19 +50,66 @@ type PRInspectViewModel struct {
 	mode            PRInspectMode
 	diffViewMode    DiffViewMode
 	pendingComments []domain.Comment
-	contentLines    int
-	mdRenderer      *markdown.Renderer
+	// otherPending keeps the pending comments of the PRs that are not open
+	// by PR key, until the review of that PR is submitted.
+	otherPending map[string][]domain.Comment
+	contentLines int
+	mdRenderer   *markdown.Renderer
+	diffStyles   DiffStyles
+	shadeDiff    bool
+	// wrapLines wraps diff lines wider than the view; otherwise they are cut
+	// at the view's edge and scrolled sideways by hOffset columns.
+	wrapLines bool
+	hOffset   int
+	// plain renders the diff as labeled plain text, see renderPlainDiff.
+	plain    bool
+	lastSeen time.Time
+	// expandedThreads holds the "path:line" keys of inline comment threads
+	// shown in full rather than collapsed to one line.
+	expandedThreads map[string]bool
+	cursorRow       int
+	// diffLayout is the layout of the diff in the viewport, of which only
+	// the rows from windowFrom up to windowTo are styled. It is nil when
+	// the viewport holds fully rendered content.
+	diffLayout []diffSegment
+	windowFrom int
+	windowTo   int
+	reviewers  []domain.Reviewer
+	// annotations holds the findings of diff-analysis hooks by file path.
+	annotations map[string][]domain.Annotation
+	// commentsLoading is set while more pages of comments are loading, and
+	// commentsTotal is the number of comments expected, when known.
+	commentsLoading bool
+	commentsTotal   int
+	// fileLoadErrors holds why the hunks of a deferred file failed to load,
+	// by file path.
+	fileLoadErrors map[string]string
+	// codeOwners names the owners of each file, and ownedOnly limits moving
+	// between files to the ones the user owns.
+	codeOwners *domain.CodeOwners
+	ownedOnly  bool
+	// stack holds the PRs stacked with this one, bottom first.
+	stack []domain.StackMember
+	// policies holds how the branch policies evaluate against the PR.
+	policies []domain.PolicyEvaluation
+	// deployments holds the environments the PR is deployed to.
+	deployments []domain.Deployment
+	// me is the user of the PAT the PR was loaded with.
+	me string
 }
 
 func NewPRInspectView() *PRInspectViewModel {
 	vp := viewport.New(0, 0)
 
 	return &PRInspectViewModel{
-		viewport:     vp,
-		currentFile:  0,
-		showComments: false,
-		mode:         PRInspectModeDescription,
-		mdRenderer:   markdown.NewRenderer(markdown.DefaultStyles()),
+		viewport:        vp,
+		currentFile:     0,
+		showComments:    false,
+		mode:            PRInspectModeDescription,
+		mdRenderer:      markdown.NewRenderer(markdown.DefaultStyles()),
+		expandedThreads: make(map[string]bool),
+		annotations:     make(map[string][]domain.Annotation),
+		fileLoadErrors:  make(map[string]string),
 	}
 }
 
@@ -64,14 +122,58 @@ func (m *PRInspectViewModel) SetSize(width, height int) {
 }
 
 func (m *PRInspectViewModel) SetPR(pr *domain.PullRequest) {
+	if m.pr == nil || pr == nil || m.pr.Key() != pr.Key() {
+		m.commentsLoading = false
+		m.commentsTotal = 0
+		m.codeOwners = nil
+		m.ownedOnly = false
+		m.policies = nil
+		m.deployments = nil
+	}
+	if m.pr != nil && pr != nil && m.pr.Key() != pr.Key() {
+		m.swapPendingComments(m.pr.Key(), pr.Key())
+	}
 	m.pr = pr
 	m.mode = PRInspectModeDescription
 	m.updateViewport()
 }
 
+// SetLastSeen sets the UpdatedAt the PR had when it was last viewed; a zero
+// time means it was never viewed. It is used for the "changed" banner.
+// SetReviewers sets the reviewer states shown in the description view.
+func (m *PRInspectViewModel) SetReviewers(reviewers []domain.Reviewer, me string) {
+	m.reviewers = reviewers
+	m.me = me
+	m.updateViewport()
+}
+
+func (m *PRInspectViewModel) SetLastSeen(lastSeen time.Time) {
+	m.lastSeen = lastSeen
+	m.updateViewport()
+}
+
+// ChangedSinceLastView reports whether the PR was viewed before and has been
+// updated since.
+func (m *PRInspectViewModel) ChangedSinceLastView() bool {
+	return m.pr != nil && !m.lastSeen.IsZero() && m.pr.UpdatedAt.After(m.lastSeen)
+}
+
+func (m *PRInspectViewModel) newCommentCount() int {
+	count := 0
+	for _, comment := range m.comments {
+		if comment.CreatedAt.After(m.lastSeen) {
+			count++
+		}
+	}
+	return count
+}
+
 func (m *PRInspectViewModel) SetDiff(diff *domain.Diff) {
 	m.diff = diff
-	m.currentFile = 0
+	m.currentFile = m.firstOwnedFile()
+	m.hOffset = 0
+	m.annotations = make(map[string][]domain.Annotation)
+	m.fileLoadErrors = make(map[string]string)
 	logger.Log("PRInspectView: SetDiff called with %d files", len(diff.Files))
 	if len(diff.Files) > 0 {
 		for i, file := range diff.Files {
@@ -84,11 +186,103 @@ func (m *PRInspectViewModel) SetDiff(diff *domain.Diff) {
 	m.updateViewport()
 }
 
+// SetFileDiff fills in the hunks of a deferred file of the diff, matched by
+// path. It is ignored when the file is no longer deferred.
+func (m *PRInspectViewModel) SetFileDiff(file domain.FileDiff) {
+	if m.diff == nil {
+		return
+	}
+	path := getFilePath(file)
+	for i, listed := range m.diff.Files {
+		if listed.Deferred && getFilePath(listed) == path {
+			m.diff.Files[i] = file
+			delete(m.fileLoadErrors, path)
+			m.updateViewport()
+			return
+		}
+	}
+}
+
+// SetFileDiffError shows why the hunks of the deferred file at path could not
+// be loaded.
+func (m *PRInspectViewModel) SetFileDiffError(path string, err error) {
+	m.fileLoadErrors[path] = err.Error()
+	m.updateViewport()
+}
+
+// SetAnnotations replaces the diff-analysis findings for the file at path.
+func (m *PRInspectViewModel) SetAnnotations(path string, annotations []domain.Annotation) {
+	if len(annotations) == 0 {
+		delete(m.annotations, path)
+	} else {
+		m.annotations[path] = annotations
+	}
+	m.updateViewport()
+}
+
+// AnnotationCount returns the number of diff-analysis findings in all files.
+func (m *PRInspectViewModel) AnnotationCount() int {
+	count := 0
+	for _, annotations := range m.annotations {
+		count += len(annotations)
+	}
+	return count
+}
+
+// CurrentFile returns the file shown in the diff, if any.
+func (m *PRInspectViewModel) CurrentFile() (domain.FileDiff, bool) {
+	if m.diff == nil || len(m.diff.Files) == 0 {
+		return domain.FileDiff{}, false
+	}
+	return m.diff.Files[m.currentFile], true
+}
+
 func (m *PRInspectViewModel) SetComments(comments []domain.Comment) {
 	m.comments = comments
 	m.updateViewport()
 }
 
+// SetCommentsLoading marks the comments as partly loaded while more pages of
+// them are on their way, out of total when it is known.
+func (m *PRInspectViewModel) SetCommentsLoading(loading bool, total int) {
+	m.commentsLoading = loading
+	m.commentsTotal = total
+	m.updateViewport()
+}
+
+// commentSummary counts the comments and their threads for the PR header,
+// and how many are loaded while more are loading.
+func (m *PRInspectViewModel) commentSummary() string {
+	threads := commentThreadCount(m.comments)
+	switch {
+	case m.commentsLoading && m.commentsTotal > 0:
+		return fmt.Sprintf("💬 %d of %d comments loaded, %d thread(s) so far...", len(m.comments), m.commentsTotal, threads)
+	case m.commentsLoading:
+		return fmt.Sprintf("💬 %d comments loaded, %d thread(s) so far...", len(m.comments), threads)
+	case len(m.comments) > 0:
+		return fmt.Sprintf("💬 %d comment(s) in %d thread(s)", len(m.comments), threads)
+	}
+	return ""
+}
+
+// commentThreadCount counts the threads of comments. Comments sharing a
+// ThreadID, or the same file line, make up one thread, and any other
+// comment is a thread of its own.
+func commentThreadCount(comments []domain.Comment) int {
+	threads := make(map[string]bool)
+	for _, comment := range comments {
+		key := "id:" + comment.ID
+		switch {
+		case comment.ThreadID != "":
+			key = "thread:" + comment.ThreadID
+		case comment.FilePath != "":
+			key = fmt.Sprintf("line:%s:%d", comment.FilePath, comment.Line)
+		}
+		threads[key] = true
+	}
+	return len(threads)
+}
+
 func (m *PRInspectViewModel) GetPR() *domain.PullRequest {
 	return m.pr
 }
@@ -128,22 +322,180 @@ func (m *PRInspectViewModel) ToggleDiffViewMode() {
 	m.updateViewport()
 }
 
-func (m *PRInspectViewModel) NextFile() {
-	if m.diff != nil && m.currentFile < len(m.diff.Files)-1 {
-		m.currentFile++
-		m.currentLineIdx = 0
+// SetDiffStyles sets the colors of diff lines.
+func (m *PRInspectViewModel) SetDiffStyles(styles DiffStyles) {
+	m.diffStyles = styles
+	m.updateViewport()
+}
+
+// SetDiffShading enables background shading of added and deleted lines.
+func (m *PRInspectViewModel) SetDiffShading(enabled bool) {
+	m.shadeDiff = enabled
+	m.updateViewport()
+}
+
+func (m *PRInspectViewModel) DiffShading() bool {
+	return m.shadeDiff
+}
+
+// SetWrapLines wraps diff lines wider than the view instead of cutting them.
+func (m *PRInspectViewModel) SetWrapLines(enabled bool) {
+	m.wrapLines = enabled
+	m.hOffset = 0
+	m.updateViewport()
+	m.ensureLineVisible()
+}
+
+func (m *PRInspectViewModel) WrapLines() bool {
+	return m.wrapLines
+}
+
+// SetPlain switches the diff to labeled plain text for screen readers.
+func (m *PRInspectViewModel) SetPlain(enabled bool) {
+	m.plain = enabled
+	m.hOffset = 0
+	m.updateViewport()
+	m.ensureLineVisible()
+}
+
+func (m *PRInspectViewModel) Plain() bool {
+	return m.plain
+}
+
+// HorizontalOffset returns how many columns the diff lines are scrolled to
+// the right.
+func (m *PRInspectViewModel) HorizontalOffset() int {
+	return m.hOffset
+}
+
+// ScrollRight scrolls the diff lines sideways, up to where the longest line of
+// the file ends. It does nothing while lines are wrapped.
+func (m *PRInspectViewModel) ScrollRight() {
+	if m.wrapLines || m.plain {
+		return
+	}
+	offset := min(m.hOffset+horizontalScrollStep, m.maxHorizontalOffset())
+	if offset != m.hOffset {
+		m.hOffset = offset
 		m.updateViewport()
 	}
 }
 
+func (m *PRInspectViewModel) ScrollLeft() {
+	if m.wrapLines || m.plain || m.hOffset == 0 {
+		return
+	}
+	m.hOffset = max(m.hOffset-horizontalScrollStep, 0)
+	m.updateViewport()
+}
+
+func (m *PRInspectViewModel) maxHorizontalOffset() int {
+	if m.diff == nil || m.currentFile >= len(m.diff.Files) {
+		return 0
+	}
+	longest := 0
+	for _, hunk := range m.diff.Files[m.currentFile].Hunks {
+		for _, line := range hunk.Lines {
+			longest = max(longest, textwidth.Width(expandTabs(line.Content)))
+		}
+	}
+	return max(longest-m.diffContentWidth("  "), 0)
+}
+
+// diffContentWidth is the number of columns left for a line's content after
+// its gutter prefix, or 0 when the view has no width yet.
+func (m *PRInspectViewModel) diffContentWidth(prefix string) int {
+	return max(m.width-textwidth.Width(prefix), 0)
+}
+
+func (m *PRInspectViewModel) NextFile() {
+	if m.diff == nil {
+		return
+	}
+	for i := m.currentFile + 1; i < len(m.diff.Files); i++ {
+		if m.fileShown(i) {
+			m.currentFile = i
+			m.currentLineIdx = 0
+			m.hOffset = 0
+			m.updateViewport()
+			return
+		}
+	}
+}
+
 func (m *PRInspectViewModel) PrevFile() {
-	if m.currentFile > 0 {
-		m.currentFile--
-		m.currentLineIdx = 0
-		m.updateViewport()
+	if m.diff == nil {
+		return
+	}
+	for i := m.currentFile - 1; i >= 0; i-- {
+		if m.fileShown(i) {
+			m.currentFile = i
+			m.currentLineIdx = 0
+			m.hOffset = 0
+			m.updateViewport()
+			return
+		}
 	}
 }
 
+// JumpToLine switches to the diff and moves the cursor to the given line of
+// the given file. Compact mode hides context lines, so it falls back to the
+// full view when the target would otherwise be invisible.
+func (m *PRInspectViewModel) JumpToLine(fileIndex, lineIndex int) {
+	if m.diff == nil || fileIndex < 0 || fileIndex >= len(m.diff.Files) {
+		return
+	}
+	if lineIndex < 0 || lineIndex >= m.countTotalLines(m.diff.Files[fileIndex]) {
+		return
+	}
+
+	m.mode = PRInspectModeDiff
+	m.currentFile = fileIndex
+	m.currentLineIdx = lineIndex
+	if line := m.GetCurrentLineInfo(); line != nil && line.Type == "context" {
+		m.diffViewMode = DiffViewModeFull
+	}
+	m.updateViewport()
+	m.ensureLineVisible()
+}
+
+// Position returns the current file, the cursor line within it and the
+// scroll offset of the diff.
+func (m *PRInspectViewModel) Position() (fileIndex, lineIndex, scrollOffset int) {
+	return m.currentFile, m.currentLineIdx, m.viewport.YOffset
+}
+
+// RestorePosition jumps to a position returned by Position. The scroll offset
+// is only applied when it keeps the cursor line on screen.
+func (m *PRInspectViewModel) RestorePosition(fileIndex, lineIndex, scrollOffset int) {
+	m.JumpToLine(fileIndex, lineIndex)
+	if m.currentFile != fileIndex || m.currentLineIdx != lineIndex {
+		return
+	}
+	if scrollOffset <= m.cursorRow && m.cursorRow < scrollOffset+m.viewport.Height {
+		m.viewport.YOffset = scrollOffset
+		m.clampViewportOffset()
+	}
+}
+
+// ToggleThread expands or collapses the inline comments on the cursor line.
+// It returns false when the line has no comments.
+func (m *PRInspectViewModel) ToggleThread() bool {
+	line := m.GetCurrentLineInfo()
+	if line == nil || len(m.commentsOnLine(*line)) == 0 {
+		return false
+	}
+	key := m.threadKey(*line)
+	m.expandedThreads[key] = !m.expandedThreads[key]
+	m.updateViewport()
+	m.ensureLineVisible()
+	return true
+}
+
+func (m *PRInspectViewModel) threadKey(line domain.DiffLine) string {
+	return fmt.Sprintf("%s:%d", getFilePath(m.diff.Files[m.currentFile]), diffLineNumber(line))
+}
+
 func (m *PRInspectViewModel) ToggleComments() {
 	m.showComments = !m.showComments
 	m.updateViewport()
@@ -170,40 +522,26 @@ func (m *PRInspectViewModel) PrevLine() {
 	}
 }
 
+// ensureLineVisible scrolls the cursor row, as recorded by the last
+// renderDiff, into view.
 func (m *PRInspectViewModel) ensureLineVisible() {
 	if m.diff == nil || len(m.diff.Files) == 0 {
 		return
 	}
 
-	file := m.diff.Files[m.currentFile]
-	linePosition := 3
-	lineIdx := 0
-
-	for _, hunk := range file.Hunks {
-		linePosition += 2
-
-		for range hunk.Lines {
-			if lineIdx == m.currentLineIdx {
-				viewportHeight := m.viewport.Height
-				if viewportHeight <= 0 {
-					return
-				}
-
-				if linePosition > m.viewport.YOffset+viewportHeight-1 {
-					m.viewport.YOffset = linePosition - viewportHeight + 1
-				}
-				if linePosition < m.viewport.YOffset {
-					m.viewport.YOffset = linePosition
-				}
+	viewportHeight := m.viewport.Height
+	if viewportHeight <= 0 {
+		return
+	}
 
-				m.clampViewportOffset()
-				return
-			}
-			lineIdx++
-			linePosition++
-		}
-		linePosition++
+	if m.cursorRow > m.viewport.YOffset+viewportHeight-1 {
+		m.viewport.YOffset = m.cursorRow - viewportHeight + 1
+	}
+	if m.cursorRow < m.viewport.YOffset {
+		m.viewport.YOffset = m.cursorRow
 	}
+
+	m.clampViewportOffset()
 }
 
 func (m *PRInspectViewModel) clampViewportOffset() {
@@ -237,6 +575,18 @@ func (m *PRInspectViewModel) hunkHasChanges(hunk domain.DiffHunk) bool {
 	return false
 }
 
+// CurrentBinaryURL returns the blob URL when the current file is a binary
+// file in the diff view.
+func (m *PRInspectViewModel) CurrentBinaryURL() string {
+	if m.mode != PRInspectModeDiff || m.diff == nil || len(m.diff.Files) == 0 {
+		return ""
+	}
+	if binary := m.diff.Files[m.currentFile].Binary; binary != nil {
+		return binary.BlobURL
+	}
+	return ""
+}
+
 func (m *PRInspectViewModel) GetCurrentLineInfo() *domain.DiffLine {
 	if m.diff == nil || len(m.diff.Files) == 0 {
 		return nil
@@ -283,6 +633,7 @@ func (m *PRInspectViewModel) AddPendingComment(body string) {
 	}
 
 	m.pendingComments = append(m.pendingComments, comment)
+	m.updateViewport()
 }
 
 func (m *PRInspectViewModel) GetPendingComments() []domain.Comment {
@@ -297,6 +648,31 @@ func (m *PRInspectViewModel) GetPendingCommentCount() int {
 	return len(m.pendingComments)
 }
 
+// swapPendingComments puts the pending comments of the PR being left aside
+// and brings back those of the PR being opened.
+func (m *PRInspectViewModel) swapPendingComments(from, to string) {
+	if len(m.pendingComments) > 0 {
+		if m.otherPending == nil {
+			m.otherPending = make(map[string][]domain.Comment)
+		}
+		m.otherPending[from] = m.pendingComments
+	}
+	m.pendingComments = m.otherPending[to]
+	delete(m.otherPending, to)
+}
+
+// PendingReviewKeys returns the keys of the PRs that have pending comments.
+func (m *PRInspectViewModel) PendingReviewKeys() []string {
+	keys := make([]string, 0, len(m.otherPending)+1)
+	for key := range m.otherPending {
+		keys = append(keys, key)
+	}
+	if len(m.pendingComments) > 0 && m.pr != nil {
+		keys = append(keys, m.pr.Key())
+	}
+	return keys
+}
+
 func (m *PRInspectViewModel) Update(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
 	m.viewport, cmd = m.viewport.Update(msg)
@@ -304,12 +680,19 @@ func (m *PRInspectViewModel) Update(msg tea.Msg) tea.Cmd {
 }
 
 func (m *PRInspectViewModel) View() string {
+	if m.diffWindowStale() {
+		m.renderDiffWindow()
+	}
 	content := m.viewport.View()
 
 	var helpText string
 	switch m.mode {
 	case PRInspectModeDescription:
-		helpText = "\nd: View Diff | e: Edit Description | c: View Comments | ctrl+o: Open in Browser | q: Back"
+		stackHelp := ""
+		if m.stackIndex() >= 0 {
+			stackHelp = " | ]s/[s: Stack"
+		}
+		helpText = "\nd: View Diff | e: Edit Description | c: View Comments | v: Reviewers | t: Translate | ctrl+o: Open in Browser" + stackHelp + " | q: Back"
 	case PRInspectModeDiff:
 		pendingCount := m.GetPendingCommentCount()
 		countInfo := ""
@@ -320,7 +703,13 @@ func (m *PRInspectViewModel) View() string {
 		if m.diffViewMode == DiffViewModeCompact {
 			viewModeText = "compact"
 		}
-		helpText = fmt.Sprintf("\nFiles: n/p | Lines: j/k | f: Toggle view (%s) | y/Y: Yank | i: Comment%s | a: Approve | r: Request | ctrl+o: Browser | q: Back", viewModeText, countInfo)
+		wrapText := "off"
+		if m.wrapLines {
+			wrapText = "on"
+		} else if m.hOffset > 0 {
+			wrapText = fmt.Sprintf("off, col %d", m.hOffset+1)
+		}
+		helpText = fmt.Sprintf("\nFiles: n/p | Lines: j/k | ←/→: Scroll | w: Wrap (%s) | f: Toggle view (%s) | x: Expand comments | gc: Open thread | ]c/[c: Hunk | ]n/[n: Commented | ]p/[p: Pending | e/D: Edit/Delete pending | y/Y: Yank | ctrl+y/alt+y: Yank added | i: Comment%s | a: Approve | r: Request | ctrl+o: Browser | q: Back", wrapText, viewModeText, countInfo)
 	}
 
 	help := lipgloss.NewStyle().
@@ -332,6 +721,7 @@ func (m *PRInspectViewModel) View() string {
 }
 
 func (m *PRInspectViewModel) updateViewport() {
+	m.diffLayout = nil
 	var b strings.Builder
 
 	switch m.mode {
@@ -340,6 +730,11 @@ func (m *PRInspectViewModel) updateViewport() {
 			b.WriteString(m.renderPRHeader())
 		}
 	case PRInspectModeDiff:
+		if m.diff != nil && len(m.diff.Files) > 0 && !m.plain {
+			m.diffLayout = m.layoutDiff()
+			m.renderDiffWindow()
+			return
+		}
 		if m.diff != nil && len(m.diff.Files) > 0 {
 			b.WriteString(m.renderDiff())
 		}
@@ -357,6 +752,19 @@ func (m *PRInspectViewModel) renderPRHeader() string {
 
 	var b strings.Builder
 
+	if m.ChangedSinceLastView() {
+		bannerStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#3B82F6")).
+			Bold(true)
+
+		banner := fmt.Sprintf("● Changed since your last view (%s)", m.lastSeen.Local().Format("Mon Jan 2 15:04"))
+		if count := m.newCommentCount(); count > 0 {
+			banner += fmt.Sprintf(" | %d new comment(s)", count)
+		}
+		b.WriteString(bannerStyle.Render(banner))
+		b.WriteString("\n\n")
+	}
+
 	titleStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#7C3AED")).
 		Bold(true)
@@ -395,6 +803,31 @@ func (m *PRInspectViewModel) renderPRHeader() string {
 	b.WriteString(statusStyle.Render(statusText))
 	b.WriteString("\n")
 
+	if summary := m.commentSummary(); summary != "" {
+		b.WriteString(metaStyle.Render(summary))
+		b.WriteString("\n")
+	}
+
+	if reviewers := renderReviewerStatus(m.reviewers, m.me); reviewers != "" {
+		b.WriteString("\n")
+		b.WriteString(reviewers)
+	}
+
+	if policies := renderPolicies(m.policies); policies != "" {
+		b.WriteString("\n")
+		b.WriteString(policies)
+	}
+
+	if deployments := renderDeployments(m.deployments); deployments != "" {
+		b.WriteString("\n")
+		b.WriteString(deployments)
+	}
+
+	if stack := m.renderStack(); stack != "" {
+		b.WriteString("\n")
+		b.WriteString(stack)
+	}
+
 	if m.pr.Description != "" {
 		dividerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#374151"))
 		divider := strings.Repeat("─", m.width-4)
@@ -412,140 +845,674 @@ func (m *PRInspectViewModel) renderDiff() string {
 		logger.Log("PRInspectView: renderDiff - No diff available (diff nil: %v, files: %d)", m.diff == nil, 0)
 		return "No diff available"
 	}
+	if m.plain {
+		return m.renderPlainDiff()
+	}
+
+	result := m.renderSegments(m.layoutDiff(), 0, math.MaxInt)
+	logger.Log("PRInspectView: renderDiff - Generated %d bytes of content", len(result))
+	return result
+}
 
-	logger.Log("PRInspectView: renderDiff - Rendering file %d of %d", m.currentFile+1, len(m.diff.Files))
+// diffSegment is a run of rows of the rendered diff: either a diff line or a
+// block shown around the lines, such as a hunk header or an inline thread.
+// Diff lines are only styled once they come into view, see renderDiffWindow.
+type diffSegment struct {
+	rows int
+	// text is the rendered block, including its trailing newline. It is
+	// empty for diff lines.
+	text    string
+	line    *domain.DiffLine
+	lineIdx int
+}
 
-	var b strings.Builder
+// layoutDiff splits the current file into segments and records the row of
+// the cursor line. Row counts are worked out without styling the lines, so
+// the layout of a long diff is cheap to rebuild.
+func (m *PRInspectViewModel) layoutDiff() []diffSegment {
+	logger.Log("PRInspectView: layoutDiff - Laying out file %d of %d", m.currentFile+1, len(m.diff.Files))
 
 	fileHeaderStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#7C3AED")).
 		Bold(true).
 		Background(lipgloss.Color("#1F2937")).
 		Padding(0, 1)
+	hunkHeaderStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#3B82F6"))
 
 	file := m.diff.Files[m.currentFile]
+	segments := make([]diffSegment, 0, m.countTotalLines(file)+2*len(file.Hunks)+2)
+	row := 0
+	block := func(text string) {
+		if text != "" {
+			segments = append(segments, diffSegment{rows: strings.Count(text, "\n"), text: text, lineIdx: -1})
+			row += segments[len(segments)-1].rows
+		}
+	}
 
-	header := fmt.Sprintf("File %d/%d: %s",
+	header := fileHeaderStyle.Render(fmt.Sprintf("File %d/%d: %s",
 		m.currentFile+1,
 		len(m.diff.Files),
 		getFilePath(file),
-	)
-
-	b.WriteString(fileHeaderStyle.Render(header))
-	b.WriteString("\n\n")
+	))
+	if summary := m.annotationSummary(file); summary != "" {
+		header += "  " + summary
+	}
+	if owners := m.ownersSummary(file); owners != "" {
+		header += "  " + owners
+	}
+	block(header + "\n\n")
 
-	logger.Log("PRInspectView: renderDiff - File has %d hunks", len(file.Hunks))
+	if file.Binary != nil {
+		m.cursorRow = 0
+		block(renderBinaryFile(file))
+		return segments
+	}
+	if file.Deferred {
+		m.cursorRow = 0
+		block(renderDeferredFile(file, m.fileLoadErrors[getFilePath(file)]))
+		return segments
+	}
 
 	lineIdx := 0
-	for hunkIdx, hunk := range file.Hunks {
-		hunkHeaderStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#3B82F6"))
-
+	for _, hunk := range file.Hunks {
 		hasVisibleLines := m.diffViewMode == DiffViewModeFull || m.hunkHasChanges(hunk)
 		if hasVisibleLines {
-			b.WriteString(hunkHeaderStyle.Render(hunk.Header))
-			b.WriteString("\n")
+			block(hunkHeaderStyle.Render(hunk.Header) + "\n")
 		}
 
-		logger.Log("PRInspectView: renderDiff - Hunk %d has %d lines", hunkIdx+1, len(hunk.Lines))
-
-		for _, line := range hunk.Lines {
+		for i := range hunk.Lines {
+			line := &hunk.Lines[i]
 			if m.diffViewMode == DiffViewModeCompact && line.Type == "context" {
 				lineIdx++
 				continue
 			}
-			b.WriteString(m.renderDiffLine(line, lineIdx))
-			b.WriteString("\n")
+			if lineIdx == m.currentLineIdx {
+				m.cursorRow = row
+			}
+			comments := m.commentsOnLine(*line)
+			drafts := m.pendingIndicesOnLine(m.currentFile, *line)
+			rows := m.diffLineRows(*line, m.diffLinePrefix(false, len(drafts) > 0, len(comments)))
+			segments = append(segments, diffSegment{rows: rows, line: line, lineIdx: lineIdx})
+			row += rows
+
+			if len(comments) > 0 {
+				block(m.renderInlineThread(comments, m.expandedThreads[m.threadKey(*line)]))
+			}
+			if len(drafts) > 0 {
+				block(m.renderPendingComments(drafts))
+			}
+			if annotations := m.annotationsOnLine(m.currentFile, *line); len(annotations) > 0 {
+				block(renderAnnotations(annotations))
+			}
 			lineIdx++
 		}
 
 		if hasVisibleLines {
-			b.WriteString("\n")
+			block("\n")
 		}
 	}
 
 	if m.showComments {
-		b.WriteString(m.renderComments(getFilePath(file)))
+		block(m.renderComments(getFilePath(file)))
 	}
-
-	result := b.String()
-	logger.Log("PRInspectView: renderDiff - Generated %d bytes of content", len(result))
-	return result
+	return segments
 }
 
 func (m *PRInspectViewModel) renderDiffLine(line domain.DiffLine, lineIdx int) string {
-	style := lipgloss.NewStyle()
+	style := m.diffStyles.LineStyle(line.Type, m.shadeDiff)
 
-	switch line.Type {
-	case "add":
-		style = style.Foreground(lipgloss.Color("#10B981"))
-	case "delete":
-		style = style.Foreground(lipgloss.Color("#EF4444"))
+	isCursor := lineIdx == m.currentLineIdx
+	if isCursor {
+		style = style.Bold(true).Background(m.diffStyles.CursorBackground).Underline(true)
+	}
+	prefix := m.diffLinePrefix(isCursor, m.hasPendingCommentOnLine(line), len(m.commentsOnLine(line)))
+
+	// Tabs are expanded up front so that cutting and wrapping count the
+	// columns they take on screen.
+	content := expandTabs(line.Content)
+	var rows []string
+	switch width := m.diffContentWidth(prefix); {
+	case width == 0:
+		rows = []string{prefix + content}
+	case m.wrapLines:
+		indent := strings.Repeat(" ", textwidth.Width(prefix))
+		for i, part := range textwidth.Wrap(content, width) {
+			if i == 0 {
+				rows = append(rows, prefix+part)
+			} else {
+				rows = append(rows, indent+part)
+			}
+		}
 	default:
-		style = style.Foreground(lipgloss.Color("#6B7280"))
+		rows = []string{prefix + textwidth.Slice(content, m.hOffset, width)}
 	}
 
-	isCursor := lineIdx == m.currentLineIdx
-	hasPendingComment := m.hasPendingCommentOnLine(line)
-	hasSubmittedComment := m.hasSubmittedCommentOnLine(line)
+	for i, text := range rows {
+		// Shade the full row so changed lines read as blocks, not just text.
+		if m.shadeDiff && line.Type != "context" {
+			if padding := m.width - lipgloss.Width(text); padding > 0 {
+				text += strings.Repeat(" ", padding)
+			}
+		}
+		rows[i] = style.Render(text)
+	}
+	return strings.Join(rows, "\n")
+}
 
-	prefix := ""
+// diffLinePrefix returns the gutter of a diff line: the cursor marker and a
+// note of its pending or submitted comments.
+func (m *PRInspectViewModel) diffLinePrefix(isCursor, hasPending bool, submitted int) string {
+	prefix := "  "
 	if isCursor {
 		prefix = "► "
-		style = style.Bold(true).Background(lipgloss.Color("#374151")).Underline(true)
-	} else {
-		prefix = "  "
 	}
-
-	if hasPendingComment {
+	if hasPending {
 		prefix += "💬 "
-	} else if hasSubmittedComment {
-		prefix += "💭 "
+	} else if submitted > 0 {
+		prefix += fmt.Sprintf("💭%d ", submitted)
 	}
+	return prefix
+}
 
-	return style.Render(prefix + line.Content)
+// diffLineRows returns the number of rows renderDiffLine takes for a line
+// with the given gutter.
+func (m *PRInspectViewModel) diffLineRows(line domain.DiffLine, prefix string) int {
+	width := m.diffContentWidth(prefix)
+	if !m.wrapLines || width == 0 {
+		return 1
+	}
+	return len(textwidth.Wrap(expandTabs(line.Content), width))
+}
+
+// renderSegments renders the rows from up to to of a diff layout. Segments
+// outside that window are left as blank rows, so the content keeps its
+// height and scroll offsets stay meaningful.
+func (m *PRInspectViewModel) renderSegments(segments []diffSegment, from, to int) string {
+	var b strings.Builder
+	row := 0
+	for _, segment := range segments {
+		switch {
+		case row >= to || row+segment.rows <= from:
+			b.WriteString(strings.Repeat("\n", segment.rows))
+		case segment.lineIdx < 0:
+			b.WriteString(segment.text)
+		default:
+			b.WriteString(m.renderDiffLine(*segment.line, segment.lineIdx))
+			b.WriteString("\n")
+		}
+		row += segment.rows
+	}
+	return b.String()
+}
+
+// renderDiffWindow puts the diff layout into the viewport with only the rows
+// on screen, and a screen above and below, styled. View renders a new window
+// once the viewport scrolls out of it.
+func (m *PRInspectViewModel) renderDiffWindow() {
+	margin := max(m.viewport.Height, diffWindowMinMargin)
+	m.windowFrom = max(m.viewport.YOffset-margin, 0)
+	m.windowTo = m.viewport.YOffset + m.viewport.Height + margin
+
+	content := m.renderSegments(m.diffLayout, m.windowFrom, m.windowTo)
+	m.contentLines = strings.Count(content, "\n") + 1
+	m.viewport.SetContent(content)
+}
+
+// diffWindowStale reports whether the viewport shows rows of the diff that
+// renderDiffWindow left blank.
+func (m *PRInspectViewModel) diffWindowStale() bool {
+	if m.diffLayout == nil {
+		return false
+	}
+	return m.viewport.YOffset < m.windowFrom ||
+		m.viewport.YOffset+m.viewport.Height > min(m.windowTo, m.contentLines)
+}
+
+func expandTabs(s string) string {
+	return strings.ReplaceAll(s, "\t", "    ")
 }
 
 func (m *PRInspectViewModel) hasPendingCommentOnLine(line domain.DiffLine) bool {
-	if m.diff == nil || len(m.diff.Files) == 0 {
+	return len(m.pendingIndicesOnLine(m.currentFile, line)) > 0
+}
+
+// pendingIndicesOnLine returns the indices into pendingComments of the drafts
+// anchored to a line of the given file.
+func (m *PRInspectViewModel) pendingIndicesOnLine(fileIndex int, line domain.DiffLine) []int {
+	if m.diff == nil || fileIndex < 0 || fileIndex >= len(m.diff.Files) {
+		return nil
+	}
+
+	filePath := getFilePath(m.diff.Files[fileIndex])
+
+	var indices []int
+	for i, comment := range m.pendingComments {
+		if comment.FilePath == filePath && anchoredTo(comment, line) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// CurrentPendingComment returns the first draft on the cursor line.
+func (m *PRInspectViewModel) CurrentPendingComment() (domain.Comment, bool) {
+	idx := m.currentPendingIndex()
+	if idx < 0 {
+		return domain.Comment{}, false
+	}
+	return m.pendingComments[idx], true
+}
+
+func (m *PRInspectViewModel) currentPendingIndex() int {
+	line := m.GetCurrentLineInfo()
+	if line == nil {
+		return -1
+	}
+	indices := m.pendingIndicesOnLine(m.currentFile, *line)
+	if len(indices) == 0 {
+		return -1
+	}
+	return indices[0]
+}
+
+// UpdatePendingComment replaces the body of the first draft on the cursor
+// line. It returns false when the line has no draft.
+func (m *PRInspectViewModel) UpdatePendingComment(body string) bool {
+	idx := m.currentPendingIndex()
+	if idx < 0 {
 		return false
 	}
+	m.pendingComments[idx].Body = body
+	m.updateViewport()
+	return true
+}
 
-	file := m.diff.Files[m.currentFile]
-	filePath := getFilePath(file)
+// DeletePendingComment discards the first draft on the cursor line. It
+// returns false when the line has no draft.
+func (m *PRInspectViewModel) DeletePendingComment() bool {
+	idx := m.currentPendingIndex()
+	if idx < 0 {
+		return false
+	}
+	m.pendingComments = append(m.pendingComments[:idx], m.pendingComments[idx+1:]...)
+	m.updateViewport()
+	return true
+}
 
-	lineNumber := line.NewLine
-	if line.Type == "delete" {
-		lineNumber = line.OldLine
+// NextPendingComment moves the cursor to the next line holding a draft,
+// continuing into the following files. It returns false when there is none.
+func (m *PRInspectViewModel) NextPendingComment() bool {
+	return m.jumpForward(m.pendingPositions())
+}
+
+// PrevPendingComment moves the cursor to the previous line holding a draft.
+func (m *PRInspectViewModel) PrevPendingComment() bool {
+	return m.jumpBackward(m.pendingPositions())
+}
+
+// NextHunk moves the cursor to the first changed line of the next hunk,
+// continuing in the following files.
+func (m *PRInspectViewModel) NextHunk() bool {
+	return m.jumpForward(m.hunkPositions())
+}
+
+// PrevHunk moves the cursor to the first changed line of the previous hunk,
+// or of the current one when the cursor is further down in it.
+func (m *PRInspectViewModel) PrevHunk() bool {
+	return m.jumpBackward(m.hunkPositions())
+}
+
+// NextCommentedLine moves the cursor to the next line with submitted comments.
+func (m *PRInspectViewModel) NextCommentedLine() bool {
+	return m.jumpForward(m.commentedPositions())
+}
+
+func (m *PRInspectViewModel) PrevCommentedLine() bool {
+	return m.jumpBackward(m.commentedPositions())
+}
+
+// jumpForward moves the cursor to the first of positions, given in diff order
+// as file and line indices, that comes after the cursor.
+func (m *PRInspectViewModel) jumpForward(positions [][2]int) bool {
+	for _, pos := range positions {
+		if pos[0] > m.currentFile || (pos[0] == m.currentFile && pos[1] > m.currentLineIdx) {
+			m.JumpToLine(pos[0], pos[1])
+			return true
+		}
 	}
+	return false
+}
 
-	for _, comment := range m.pendingComments {
-		if comment.FilePath == filePath && comment.Line == lineNumber {
+func (m *PRInspectViewModel) jumpBackward(positions [][2]int) bool {
+	for i := len(positions) - 1; i >= 0; i-- {
+		pos := positions[i]
+		if pos[0] < m.currentFile || (pos[0] == m.currentFile && pos[1] < m.currentLineIdx) {
+			m.JumpToLine(pos[0], pos[1])
 			return true
 		}
 	}
 	return false
 }
 
-func (m *PRInspectViewModel) hasSubmittedCommentOnLine(line domain.DiffLine) bool {
+// hunkPositions lists the first added or deleted line of every hunk. Hunks
+// with only context lines are skipped.
+func (m *PRInspectViewModel) hunkPositions() [][2]int {
+	if m.diff == nil {
+		return nil
+	}
+
+	var positions [][2]int
+	for fileIdx, file := range m.diff.Files {
+		lineIdx := 0
+		for _, hunk := range file.Hunks {
+			found := false
+			for _, line := range hunk.Lines {
+				if !found && (line.Type == "add" || line.Type == "delete") {
+					positions = append(positions, [2]int{fileIdx, lineIdx})
+					found = true
+				}
+				lineIdx++
+			}
+		}
+	}
+	return positions
+}
+
+// commentedPositions lists the lines that submitted comments are anchored to.
+func (m *PRInspectViewModel) commentedPositions() [][2]int {
+	if m.diff == nil || len(m.comments) == 0 {
+		return nil
+	}
+
+	commented := make(map[string]bool)
+	for _, comment := range m.comments {
+		if comment.FilePath != "" && comment.Line != 0 {
+			commented[fmt.Sprintf("%s:%d", comment.FilePath, comment.Line)] = true
+		}
+	}
+
+	var positions [][2]int
+	for fileIdx, file := range m.diff.Files {
+		path := getFilePath(file)
+		lineIdx := 0
+		for _, hunk := range file.Hunks {
+			for _, line := range hunk.Lines {
+				if commented[fmt.Sprintf("%s:%d", path, diffLineNumber(line))] {
+					positions = append(positions, [2]int{fileIdx, lineIdx})
+				}
+				lineIdx++
+			}
+		}
+	}
+	return positions
+}
+
+// annotationPositions lists the lines that diff-analysis findings are
+// anchored to, in diff order.
+func (m *PRInspectViewModel) annotationPositions() [][2]int {
+	if m.diff == nil || len(m.annotations) == 0 {
+		return nil
+	}
+
+	var positions [][2]int
+	for fileIdx, file := range m.diff.Files {
+		lineIdx := 0
+		for _, hunk := range file.Hunks {
+			for _, line := range hunk.Lines {
+				if len(m.annotationsOnLine(fileIdx, line)) > 0 {
+					positions = append(positions, [2]int{fileIdx, lineIdx})
+				}
+				lineIdx++
+			}
+		}
+	}
+	return positions
+}
+
+func (m *PRInspectViewModel) NextAnnotation() bool {
+	return m.jumpForward(m.annotationPositions())
+}
+
+func (m *PRInspectViewModel) PrevAnnotation() bool {
+	return m.jumpBackward(m.annotationPositions())
+}
+
+// pendingPositions lists the file and line indices of every line holding a
+// draft, in diff order.
+func (m *PRInspectViewModel) pendingPositions() [][2]int {
+	if m.diff == nil || len(m.pendingComments) == 0 {
+		return nil
+	}
+
+	var positions [][2]int
+	for fileIdx, file := range m.diff.Files {
+		lineIdx := 0
+		for _, hunk := range file.Hunks {
+			for _, line := range hunk.Lines {
+				if len(m.pendingIndicesOnLine(fileIdx, line)) > 0 {
+					positions = append(positions, [2]int{fileIdx, lineIdx})
+				}
+				lineIdx++
+			}
+		}
+	}
+	return positions
+}
+
+// LocateComment returns the file and line index of the diff line a comment is
+// anchored to.
+func (m *PRInspectViewModel) LocateComment(comment domain.Comment) (int, int, bool) {
+	if m.diff == nil || comment.FilePath == "" || comment.Line == 0 {
+		return 0, 0, false
+	}
+
+	for fileIdx, file := range m.diff.Files {
+		if getFilePath(file) != comment.FilePath {
+			continue
+		}
+		lineIdx := 0
+		for _, hunk := range file.Hunks {
+			for _, line := range hunk.Lines {
+				if anchoredTo(comment, line) {
+					return fileIdx, lineIdx, true
+				}
+				lineIdx++
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// commentsOnLine returns the submitted comments anchored to a line of the
+// current file.
+func (m *PRInspectViewModel) commentsOnLine(line domain.DiffLine) []domain.Comment {
 	if m.diff == nil || len(m.diff.Files) == 0 {
-		return false
+		return nil
 	}
 
-	file := m.diff.Files[m.currentFile]
-	filePath := getFilePath(file)
+	filePath := getFilePath(m.diff.Files[m.currentFile])
+
+	var comments []domain.Comment
+	for _, comment := range m.comments {
+		if comment.FilePath == filePath && anchoredTo(comment, line) {
+			comments = append(comments, comment)
+		}
+	}
+	return comments
+}
 
-	lineNumber := line.NewLine
+func diffLineNumber(line domain.DiffLine) int {
 	if line.Type == "delete" {
-		lineNumber = line.OldLine
+		return line.OldLine
 	}
+	return line.NewLine
+}
 
-	for _, comment := range m.comments {
-		if comment.FilePath == filePath && comment.Line == lineNumber {
-			return true
+// anchoredTo reports whether a comment is anchored to a diff line. A deleted
+// and an added line can share a number, so the side has to match too unless
+// the comment does not say which side it is on.
+func anchoredTo(comment domain.Comment, line domain.DiffLine) bool {
+	side := "RIGHT"
+	if line.Type == "delete" {
+		side = "LEFT"
+	}
+	return comment.Line == diffLineNumber(line) && (comment.Side == "" || comment.Side == side)
+}
+
+func (m *PRInspectViewModel) GetCurrentLineComments() []domain.Comment {
+	lineInfo := m.GetCurrentLineInfo()
+	if lineInfo == nil {
+		return nil
+	}
+	return m.commentsOnLine(*lineInfo)
+}
+
+// annotationsOnLine returns the findings for a line of the new version of
+// the file. Deleted lines have no findings.
+func (m *PRInspectViewModel) annotationsOnLine(fileIndex int, line domain.DiffLine) []domain.Annotation {
+	if m.diff == nil || line.Type == "delete" || line.NewLine == 0 {
+		return nil
+	}
+
+	var annotations []domain.Annotation
+	for _, annotation := range m.annotations[getFilePath(m.diff.Files[fileIndex])] {
+		if annotation.Line == line.NewLine {
+			annotations = append(annotations, annotation)
 		}
 	}
-	return false
+	return annotations
+}
+
+// annotationSummary counts the findings of file for its header, noting those
+// on lines the diff does not show.
+func (m *PRInspectViewModel) annotationSummary(file domain.FileDiff) string {
+	annotations := m.annotations[getFilePath(file)]
+	if len(annotations) == 0 {
+		return ""
+	}
+
+	shown := make(map[int]bool)
+	for _, hunk := range file.Hunks {
+		for _, line := range hunk.Lines {
+			if line.Type != "delete" && line.NewLine != 0 {
+				shown[line.NewLine] = true
+			}
+		}
+	}
+	outside := 0
+	for _, annotation := range annotations {
+		if !shown[annotation.Line] {
+			outside++
+		}
+	}
+
+	summary := fmt.Sprintf("⚑ %d finding(s)", len(annotations))
+	if outside > 0 {
+		summary += fmt.Sprintf(", %d outside the diff", outside)
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Render(summary)
+}
+
+// renderAnnotations renders the findings of diff-analysis hooks beneath the
+// line they were reported for.
+func renderAnnotations(annotations []domain.Annotation) string {
+	sourceStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	bodyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB"))
+
+	const indent = "    "
+
+	var b strings.Builder
+	for _, annotation := range annotations {
+		color, icon := annotationSeverity(annotation.Severity)
+		severityStyle := lipgloss.NewStyle().Foreground(color)
+		b.WriteString(indent + severityStyle.Render("┆ "+icon+" ") +
+			sourceStyle.Render("["+annotation.Source+"] ") +
+			bodyStyle.Render(annotation.Message) + "\n")
+	}
+	return b.String()
+}
+
+// annotationSeverity returns the color and icon findings of severity are
+// marked with.
+func annotationSeverity(severity domain.AnnotationSeverity) (lipgloss.Color, string) {
+	switch severity {
+	case domain.AnnotationError:
+		return lipgloss.Color("#EF4444"), "✖"
+	case domain.AnnotationInfo:
+		return lipgloss.Color("#3B82F6"), "ℹ"
+	}
+	return lipgloss.Color("#F59E0B"), "⚠"
+}
+
+// renderPendingComments renders the unsubmitted drafts of a diff line beneath
+// it, in full so they can be proofread before the review is submitted.
+func (m *PRInspectViewModel) renderPendingComments(indices []int) string {
+	gutterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Bold(true)
+	bodyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#A7F3D0"))
+
+	const indent = "    "
+	gutter := gutterStyle.Render("┃ ")
+
+	var b strings.Builder
+	for _, idx := range indices {
+		b.WriteString(indent + gutter + labelStyle.Render("💬 pending") + "\n")
+		for _, bodyLine := range strings.Split(strings.TrimRight(m.pendingComments[idx].Body, "\n"), "\n") {
+			b.WriteString(indent + gutter + bodyStyle.Render(bodyLine) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// renderInlineThread renders the comments of a diff line beneath it, either
+// collapsed to a single summary line or in full.
+func (m *PRInspectViewModel) renderInlineThread(comments []domain.Comment, expanded bool) string {
+	gutterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+	authorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED")).Bold(true)
+	bodyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280")).Italic(true)
+
+	const indent = "    "
+	gutter := gutterStyle.Render("│ ")
+
+	status := ""
+	if badge := threadStatusBadge(comments[0].ThreadStatus); badge != "" {
+		status = badge + " "
+	}
+
+	if !expanded {
+		first := comments[0]
+		summary := strings.TrimSpace(strings.SplitN(strings.TrimSpace(first.Body), "\n", 2)[0])
+		more := ""
+		if len(comments) > 1 {
+			more = fmt.Sprintf(" (+%d more)", len(comments)-1)
+		}
+		available := m.width - len(indent) - 2 - lipgloss.Width(status) - lipgloss.Width(first.Author.Username) - 2 - lipgloss.Width(more)
+		return indent + gutter + status +
+			authorStyle.Render(first.Author.Username) + ": " +
+			bodyStyle.Render(textwidth.Truncate(summary, max(available, 10))) +
+			mutedStyle.Render(more) + "\n"
+	}
+
+	var b strings.Builder
+	if status != "" {
+		b.WriteString(indent + gutter + status + "\n")
+	}
+	for _, comment := range comments {
+		b.WriteString(indent + gutter + authorStyle.Render(comment.Author.Username))
+		if !comment.CreatedAt.IsZero() {
+			b.WriteString(mutedStyle.Render(" " + comment.CreatedAt.Local().Format("Jan 2 15:04")))
+		}
+		b.WriteString("\n")
+		for _, bodyLine := range strings.Split(m.mdRenderer.Render(strings.TrimRight(comment.Body, "\n")), "\n") {
+			b.WriteString(indent + gutter + bodyLine + "\n")
+		}
+		if reactions := formatReactions(comment.Reactions); reactions != "" {
+			b.WriteString(indent + gutter + reactions + "\n")
+		}
+	}
+	return b.String()
 }
 
 func (m *PRInspectViewModel) renderComments(filePath string) string {
@@ -585,9 +1552,8 @@ func (m *PRInspectViewModel) renderComments(filePath string) string {
 		b.WriteString(":\n")
 
 		commentStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#F9FAFB")).
 			PaddingLeft(2)
-		b.WriteString(commentStyle.Render(comment.Body))
+		b.WriteString(commentStyle.Render(m.mdRenderer.Render(comment.Body)))
 		b.WriteString("\n\n")
 	}
 
@@ -617,7 +1583,57 @@ func (m *PRInspectViewModel) GetAllFilesDiffText() string {
 	return b.String()
 }
 
+// GetCurrentHunkAddedLines returns the added lines of the hunk under the
+// cursor with the leading '+' stripped, ready to paste as plain code.
+func (m *PRInspectViewModel) GetCurrentHunkAddedLines() string {
+	if m.diff == nil || len(m.diff.Files) == 0 {
+		return ""
+	}
+
+	file := m.diff.Files[m.currentFile]
+	lineIdx := 0
+	for _, hunk := range file.Hunks {
+		if m.currentLineIdx < lineIdx+len(hunk.Lines) {
+			return addedLinesText(hunk.Lines)
+		}
+		lineIdx += len(hunk.Lines)
+	}
+	return ""
+}
+
+// GetCurrentFileAddedLines returns the added lines of the current file with
+// the leading '+' stripped. Hunks are separated by a blank line.
+func (m *PRInspectViewModel) GetCurrentFileAddedLines() string {
+	if m.diff == nil || len(m.diff.Files) == 0 {
+		return ""
+	}
+
+	var parts []string
+	for _, hunk := range m.diff.Files[m.currentFile].Hunks {
+		if text := addedLinesText(hunk.Lines); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func addedLinesText(lines []domain.DiffLine) string {
+	var b strings.Builder
+	for _, line := range lines {
+		if line.Type != "add" {
+			continue
+		}
+		b.WriteString(strings.TrimPrefix(line.Content, "+"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
 func (m *PRInspectViewModel) generateFileDiffText(file domain.FileDiff) string {
+	if file.Binary != nil {
+		return fmt.Sprintf("Binary file %s differs\n", getFilePath(file))
+	}
+
 	var b strings.Builder
 
 	for _, hunk := range file.Hunks {
@@ -1,8 +1,11 @@
 package views
 
 import (
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/johanforsgren/lgtmfaster/internal/domain"
 )
 
@@ -462,6 +465,76 @@ func TestGetCurrentFileDiffText_RespectsCompactMode(t *testing.T) {
 	}
 }
 
+func buildAddedLinesTestDiff() *domain.Diff {
+	return &domain.Diff{
+		Files: []domain.FileDiff{
+			{
+				NewPath: "main.go",
+				Hunks: []domain.DiffHunk{
+					{
+						Header: "@@ -1,3 +1,4 @@",
+						Lines: []domain.DiffLine{
+							{Type: "context", Content: " package main"},
+							{Type: "delete", Content: "-var old = 1"},
+							{Type: "add", Content: "+var fresh = 2"},
+							{Type: "add", Content: "+var plus = \"+\""},
+						},
+					},
+					{
+						Header: "@@ -10,2 +11,2 @@",
+						Lines: []domain.DiffLine{
+							{Type: "context", Content: " func main() {"},
+							{Type: "add", Content: "+\tprintln(fresh)"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetCurrentHunkAddedLines_StripsPlusAndSkipsOtherLines(t *testing.T) {
+	view := NewPRInspectView()
+	view.SetSize(80, 24)
+	view.SetDiff(buildAddedLinesTestDiff())
+	view.SwitchToDiff()
+
+	expected := "var fresh = 2\nvar plus = \"+\"\n"
+	if result := view.GetCurrentHunkAddedLines(); result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+
+	for i := 0; i < 4; i++ {
+		view.NextLine()
+	}
+	expected = "\tprintln(fresh)\n"
+	if result := view.GetCurrentHunkAddedLines(); result != expected {
+		t.Errorf("expected second hunk %q, got %q", expected, result)
+	}
+}
+
+func TestGetCurrentFileAddedLines_JoinsHunks(t *testing.T) {
+	view := NewPRInspectView()
+	view.SetSize(80, 24)
+	view.SetDiff(buildAddedLinesTestDiff())
+
+	expected := "var fresh = 2\nvar plus = \"+\"\n\n\tprintln(fresh)\n"
+	if result := view.GetCurrentFileAddedLines(); result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+}
+
+func TestGetCurrentFileAddedLines_ReturnsEmptyWhenNoDiff(t *testing.T) {
+	view := NewPRInspectView()
+
+	if result := view.GetCurrentFileAddedLines(); result != "" {
+		t.Errorf("expected empty string when no diff, got %q", result)
+	}
+	if result := view.GetCurrentHunkAddedLines(); result != "" {
+		t.Errorf("expected empty string when no diff, got %q", result)
+	}
+}
+
 func TestGetAllFilesDiffText_ReturnsEmptyWhenNoDiff(t *testing.T) {
 	view := NewPRInspectView()
 
@@ -701,3 +774,560 @@ func TestHelpText_ShowsEditDescriptionInDescriptionMode(t *testing.T) {
 		t.Error("expected help text to show edit description shortcut in description mode")
 	}
 }
+
+func TestJumpToLine_SwitchesToDiffAndFullMode(t *testing.T) {
+	view := NewPRInspectView()
+	view.SetSize(100, 40)
+	view.SetDiff(codeLensTestDiff())
+	view.ToggleDiffViewMode()
+
+	view.JumpToLine(0, 0)
+
+	if view.GetMode() != PRInspectModeDiff {
+		t.Error("expected diff mode after jumping")
+	}
+	if view.GetDiffViewMode() != DiffViewModeFull {
+		t.Error("expected full mode when jumping to a context line")
+	}
+	if line := view.GetCurrentLineInfo(); line == nil || line.Content != " package cache" {
+		t.Errorf("unexpected current line: %+v", line)
+	}
+
+	view.JumpToLine(5, 0)
+	if line := view.GetCurrentLineInfo(); line == nil || line.Content != " package cache" {
+		t.Error("expected out of range jumps to be ignored")
+	}
+}
+
+func TestDiffStyles_LineStyleShading(t *testing.T) {
+	styles := DiffStyles{
+		Add:          lipgloss.NewStyle().Foreground(lipgloss.Color("2")),
+		Delete:       lipgloss.NewStyle().Foreground(lipgloss.Color("1")),
+		Context:      lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
+		AddShaded:    lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Background(lipgloss.Color("22")),
+		DeleteShaded: lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Background(lipgloss.Color("52")),
+	}
+
+	if _, ok := styles.LineStyle("add", false).GetBackground().(lipgloss.NoColor); !ok {
+		t.Error("expected unshaded add line to have no background")
+	}
+	if _, ok := styles.LineStyle("add", true).GetBackground().(lipgloss.NoColor); ok {
+		t.Error("expected shaded add line to have a background")
+	}
+	if _, ok := styles.LineStyle("delete", true).GetBackground().(lipgloss.NoColor); ok {
+		t.Error("expected shaded delete line to have a background")
+	}
+	if _, ok := styles.LineStyle("context", true).GetBackground().(lipgloss.NoColor); !ok {
+		t.Error("expected context line to stay unshaded")
+	}
+}
+
+func TestSetDiffShading(t *testing.T) {
+	view := NewPRInspectView()
+	view.SetDiffShading(true)
+
+	if !view.DiffShading() {
+		t.Error("expected diff shading to be enabled")
+	}
+}
+
+func TestChangedSinceLastView_Banner(t *testing.T) {
+	lastSeen := time.Now().Add(-time.Hour)
+	view := NewPRInspectView()
+	view.SetSize(120, 40)
+	view.SetLastSeen(lastSeen)
+	view.SetPR(&domain.PullRequest{Title: "Test", UpdatedAt: time.Now()})
+	view.SetComments([]domain.Comment{
+		{Body: "old", CreatedAt: lastSeen.Add(-time.Minute)},
+		{Body: "new", CreatedAt: lastSeen.Add(time.Minute)},
+	})
+
+	if !view.ChangedSinceLastView() {
+		t.Fatal("expected PR to be changed since last view")
+	}
+	if !strings.Contains(view.renderPRHeader(), "1 new comment(s)") {
+		t.Error("expected banner to count new comments")
+	}
+
+	view.SetLastSeen(time.Time{})
+	if view.ChangedSinceLastView() {
+		t.Error("expected first view not to show the banner")
+	}
+}
+
+func TestInlineThread_CollapsedAndExpanded(t *testing.T) {
+	view := NewPRInspectView()
+	view.SetSize(120, 40)
+	view.SetDiff(&domain.Diff{
+		Files: []domain.FileDiff{
+			{
+				NewPath: "main.go",
+				Hunks: []domain.DiffHunk{
+					{
+						Header: "@@ -1,2 +1,2 @@",
+						Lines: []domain.DiffLine{
+							{Type: "context", Content: " package main", OldLine: 1, NewLine: 1},
+							{Type: "add", Content: "+var x = 1", NewLine: 2},
+						},
+					},
+				},
+			},
+		},
+	})
+	view.SetComments([]domain.Comment{
+		{Body: "first remark\nsecond line", FilePath: "main.go", Line: 2, Author: domain.User{Username: "alice"}},
+		{Body: "reply", FilePath: "main.go", Line: 2, Author: domain.User{Username: "bob"}},
+	})
+	view.SwitchToDiff()
+
+	collapsed := view.renderDiff()
+	if !strings.Contains(collapsed, "💭2") {
+		t.Error("expected gutter marker with comment count")
+	}
+	if !strings.Contains(collapsed, "first remark") || !strings.Contains(collapsed, "(+1 more)") {
+		t.Error("expected collapsed thread summary")
+	}
+	if strings.Contains(collapsed, "second line") {
+		t.Error("expected collapsed thread to hide the rest of the body")
+	}
+
+	if view.ToggleThread() {
+		t.Error("expected toggling a line without comments to fail")
+	}
+	view.NextLine()
+	if !view.ToggleThread() {
+		t.Fatal("expected thread to toggle")
+	}
+
+	expanded := view.renderDiff()
+	if !strings.Contains(expanded, "second line") || !strings.Contains(expanded, "reply") {
+		t.Error("expected expanded thread to show all comments")
+	}
+}
+
+func buildPendingTestDiff() *domain.Diff {
+	return &domain.Diff{
+		Files: []domain.FileDiff{
+			{
+				NewPath: "a.go",
+				Hunks: []domain.DiffHunk{
+					{
+						Header: "@@ -1,2 +1,2 @@",
+						Lines: []domain.DiffLine{
+							{Type: "context", Content: " package a", OldLine: 1, NewLine: 1},
+							{Type: "add", Content: "+var a = 1", NewLine: 2},
+						},
+					},
+				},
+			},
+			{
+				NewPath: "b.go",
+				Hunks: []domain.DiffHunk{
+					{
+						Header: "@@ -1,2 +1,1 @@",
+						Lines: []domain.DiffLine{
+							{Type: "context", Content: " package b", OldLine: 1, NewLine: 1},
+							{Type: "delete", Content: "-var b = 1", OldLine: 2},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestPendingComments_NavigateEditDelete(t *testing.T) {
+	view := NewPRInspectView()
+	view.SetSize(120, 40)
+	view.SetDiff(buildPendingTestDiff())
+	view.SwitchToDiff()
+
+	view.NextLine()
+	view.AddPendingComment("draft a")
+	view.NextFile()
+	view.NextLine()
+	view.AddPendingComment("draft b")
+
+	if !strings.Contains(view.renderDiff(), "draft b") {
+		t.Error("expected pending comment to be rendered inline")
+	}
+
+	view.JumpToLine(0, 0)
+	if !view.NextPendingComment() || view.currentFile != 0 || view.currentLineIdx != 1 {
+		t.Fatalf("expected jump to first draft, got file %d line %d", view.currentFile, view.currentLineIdx)
+	}
+	if !view.NextPendingComment() || view.currentFile != 1 || view.currentLineIdx != 1 {
+		t.Fatalf("expected jump to second draft, got file %d line %d", view.currentFile, view.currentLineIdx)
+	}
+	if view.NextPendingComment() {
+		t.Error("expected no draft after the last one")
+	}
+	if !view.PrevPendingComment() || view.currentFile != 0 {
+		t.Error("expected jump back to the first draft")
+	}
+
+	if !view.UpdatePendingComment("edited a") {
+		t.Fatal("expected draft to be updated")
+	}
+	if comment, ok := view.CurrentPendingComment(); !ok || comment.Body != "edited a" {
+		t.Errorf("expected edited body, got %q", comment.Body)
+	}
+
+	if !view.DeletePendingComment() {
+		t.Fatal("expected draft to be deleted")
+	}
+	if view.GetPendingCommentCount() != 1 || view.GetPendingComments()[0].Body != "draft b" {
+		t.Errorf("expected only the second draft to remain, got %+v", view.GetPendingComments())
+	}
+	if view.DeletePendingComment() {
+		t.Error("expected no draft left on this line")
+	}
+}
+
+func TestComments_MatchSideOfLinesSharingANumber(t *testing.T) {
+	view := NewPRInspectView()
+	view.SetSize(120, 40)
+	view.SetDiff(&domain.Diff{
+		Files: []domain.FileDiff{
+			{
+				OldPath: "a.go",
+				NewPath: "a.go",
+				Hunks: []domain.DiffHunk{
+					{
+						Header: "@@ -1,2 +1,2 @@",
+						Lines: []domain.DiffLine{
+							{Type: "context", Content: " package a", OldLine: 1, NewLine: 1},
+							{Type: "delete", Content: "-var a = 1", OldLine: 2},
+							{Type: "add", Content: "+var a = 2", NewLine: 2},
+						},
+					},
+				},
+			},
+		},
+	})
+	removed := domain.Comment{ID: "1", FilePath: "a.go", Line: 2, Side: "LEFT", Body: "on the removed line"}
+	added := domain.Comment{ID: "2", FilePath: "a.go", Line: 2, Side: "RIGHT", Body: "on the added line"}
+	view.SetComments([]domain.Comment{removed, added})
+	view.SwitchToDiff()
+
+	view.JumpToLine(0, 1)
+	if comments := view.GetCurrentLineComments(); len(comments) != 1 || comments[0].ID != removed.ID {
+		t.Errorf("expected only the comment on the removed line, got %+v", comments)
+	}
+	view.JumpToLine(0, 2)
+	if comments := view.GetCurrentLineComments(); len(comments) != 1 || comments[0].ID != added.ID {
+		t.Errorf("expected only the comment on the added line, got %+v", comments)
+	}
+
+	if _, lineIdx, ok := view.LocateComment(removed); !ok || lineIdx != 1 {
+		t.Errorf("expected the removed line's comment at line 1, got %d (found %v)", lineIdx, ok)
+	}
+	if _, lineIdx, ok := view.LocateComment(added); !ok || lineIdx != 2 {
+		t.Errorf("expected the added line's comment at line 2, got %d (found %v)", lineIdx, ok)
+	}
+}
+
+func TestRenderDiff_BinaryFileShowsMetadata(t *testing.T) {
+	view := NewPRInspectView()
+	view.SetSize(120, 40)
+	view.SetDiff(&domain.Diff{
+		Files: []domain.FileDiff{
+			{
+				OldPath: "assets/logo.png",
+				NewPath: "assets/logo.png",
+				Binary:  &domain.BinaryInfo{OldSize: 2048, NewSize: 1024, BlobURL: "https://example.com/logo.png"},
+			},
+		},
+	})
+	view.SwitchToDiff()
+
+	output := view.renderDiff()
+	for _, want := range []string{"Image modified", "image/png", "2.0 KB → 1.0 KB (-1.0 KB)", "ctrl+o"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected binary file view to contain %q, got:\n%s", want, output)
+		}
+	}
+	if view.CurrentBinaryURL() != "https://example.com/logo.png" {
+		t.Errorf("expected blob URL, got %q", view.CurrentBinaryURL())
+	}
+
+	view.SwitchToDescription()
+	if view.CurrentBinaryURL() != "" {
+		t.Error("expected no blob URL outside the diff view")
+	}
+}
+
+func TestFormatFileSize(t *testing.T) {
+	tests := map[int64]string{
+		-1:              "unknown",
+		512:             "512 B",
+		1536:            "1.5 KB",
+		5 * 1024 * 1024: "5.0 MB",
+	}
+	for size, want := range tests {
+		if got := formatFileSize(size); got != want {
+			t.Errorf("formatFileSize(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
+
+func TestReviewerStatus_LastApprovalNeeded(t *testing.T) {
+	reviewer := func(name string, state domain.ReviewerState, required bool) domain.Reviewer {
+		return domain.Reviewer{User: domain.User{Username: name}, State: state, Required: required}
+	}
+
+	allOthersApproved := []domain.Reviewer{
+		reviewer("alice", domain.ReviewerApproved, false),
+		reviewer("me", domain.ReviewerPending, false),
+	}
+	if !isLastApprovalNeeded(allOthersApproved, "me") {
+		t.Error("expected my approval to be the last one needed")
+	}
+	if isLastApprovalNeeded(allOthersApproved, "bob") {
+		t.Error("expected no note for a user who is not a reviewer")
+	}
+
+	optionalPending := []domain.Reviewer{
+		reviewer("alice", domain.ReviewerApproved, true),
+		reviewer("carl", domain.ReviewerPending, false),
+		reviewer("me", domain.ReviewerPending, true),
+	}
+	if !isLastApprovalNeeded(optionalPending, "me") {
+		t.Error("expected optional reviewers to be ignored when some are required")
+	}
+
+	othersPending := []domain.Reviewer{
+		reviewer("alice", domain.ReviewerChangesRequested, false),
+		reviewer("me", domain.ReviewerPending, false),
+	}
+	if isLastApprovalNeeded(othersPending, "me") {
+		t.Error("expected no note while other reviewers have not approved")
+	}
+}
+
+func TestRenderPRHeader_ShowsReviewers(t *testing.T) {
+	view := NewPRInspectView()
+	view.SetSize(120, 40)
+	view.SetPR(&domain.PullRequest{Title: "Test"})
+	view.SetReviewers([]domain.Reviewer{
+		{User: domain.User{Username: "alice"}, State: domain.ReviewerApproved, Vote: 10},
+		{User: domain.User{Username: "me"}, State: domain.ReviewerPending, Required: true},
+	}, "me")
+
+	header := view.renderPRHeader()
+	for _, want := range []string{"Reviewers (1/2 approved)", "alice", "vote +10", "required, you", "last one outstanding"} {
+		if !strings.Contains(header, want) {
+			t.Errorf("expected header to contain %q, got:\n%s", want, header)
+		}
+	}
+}
+
+func TestLongDiffLines_ScrollSidewaysOrWrap(t *testing.T) {
+	long := "+" + strings.Repeat("abcdefghij", 6) + "END"
+	view := NewPRInspectView()
+	view.SetSize(40, 20)
+	view.SetDiff(&domain.Diff{Files: []domain.FileDiff{{
+		NewPath: "long.go",
+		Hunks: []domain.DiffHunk{{Header: "@@ -0,0 +1,2 @@", Lines: []domain.DiffLine{
+			{Type: "add", Content: long, NewLine: 1},
+			{Type: "add", Content: "+short", NewLine: 2},
+		}}},
+	}}})
+	view.SwitchToDiff()
+
+	diff := view.renderDiff()
+	if strings.Contains(diff, "END") {
+		t.Error("expected the long line to be cut at the view's edge")
+	}
+
+	for i := 0; i < 10; i++ {
+		view.ScrollRight()
+	}
+	if max := len(long) - 38; view.HorizontalOffset() != max {
+		t.Errorf("expected offset to stop at %d, got %d", max, view.HorizontalOffset())
+	}
+	if diff := view.renderDiff(); !strings.Contains(diff, "END") || !strings.Contains(diff, "► ") {
+		t.Error("expected the end of the line to be shown with the gutter kept in place")
+	}
+
+	view.SetWrapLines(true)
+	if view.HorizontalOffset() != 0 {
+		t.Error("expected wrapping to reset the horizontal offset")
+	}
+	view.ScrollRight()
+	if view.HorizontalOffset() != 0 {
+		t.Error("expected no sideways scrolling while wrapped")
+	}
+	diff = view.renderDiff()
+	if !strings.Contains(diff, "+abcdefghij") || !strings.Contains(diff, "END") {
+		t.Error("expected the whole line to be shown when wrapped")
+	}
+
+	view.NextLine()
+	view.renderDiff()
+	// Header, blank line and hunk header, then the long line on two rows.
+	if view.cursorRow != 5 {
+		t.Errorf("expected the cursor below the wrapped rows at row 5, got %d", view.cursorRow)
+	}
+}
+
+func TestDiffWindow_StylesOnlyRowsNearTheViewport(t *testing.T) {
+	view := NewPRInspectView()
+	view.SetSize(60, 40)
+	view.SetDiff(buildBenchDiff(2000))
+	view.SwitchToDiff()
+
+	for _, wrap := range []bool{false, true} {
+		view.SetWrapLines(wrap)
+		full := strings.Split(view.renderDiff(), "\n")
+		windowed := strings.Split(view.renderSegments(view.diffLayout, view.windowFrom, view.windowTo), "\n")
+		if len(windowed) != len(full) || view.contentLines != len(full) {
+			t.Fatalf("wrap %v: expected the window to keep all %d rows, got %d", wrap, len(full), len(windowed))
+		}
+		for row := 0; row < view.viewport.Height; row++ {
+			if windowed[row] != full[row] {
+				t.Errorf("wrap %v: expected row %d to be rendered as %q, got %q", wrap, row, full[row], windowed[row])
+			}
+		}
+		if far := len(full) - 10; windowed[far] != "" {
+			t.Errorf("wrap %v: expected row %d far below the viewport to be left blank, got %q", wrap, far, windowed[far])
+		}
+	}
+
+	view.JumpToLine(0, 1500)
+	if !strings.Contains(view.View(), "value1500 := compute") {
+		t.Error("expected the rows around a far cursor to be rendered")
+	}
+	view.viewport.GotoTop()
+	if !strings.Contains(view.View(), "value0 := compute") {
+		t.Error("expected scrolling the viewport out of the window to render the rows it shows")
+	}
+}
+
+func TestHunkAndCommentedLineNavigation(t *testing.T) {
+	view := NewPRInspectView()
+	view.SetSize(100, 40)
+	view.SetDiff(&domain.Diff{Files: []domain.FileDiff{
+		{
+			NewPath: "a.go",
+			Hunks: []domain.DiffHunk{
+				{Header: "@@ -1,3 +1,3 @@", Lines: []domain.DiffLine{
+					{Type: "context", Content: " one", OldLine: 1, NewLine: 1},
+					{Type: "delete", Content: "-two", OldLine: 2},
+					{Type: "add", Content: "+two", NewLine: 2},
+				}},
+				{Header: "@@ -20,2 +20,2 @@", Lines: []domain.DiffLine{
+					{Type: "context", Content: " twenty", OldLine: 20, NewLine: 20},
+					{Type: "add", Content: "+twenty-one", NewLine: 21},
+				}},
+			},
+		},
+		{
+			NewPath: "b.go",
+			Hunks: []domain.DiffHunk{{Header: "@@ -0,0 +1,1 @@", Lines: []domain.DiffLine{
+				{Type: "add", Content: "+new", NewLine: 1},
+			}}},
+		},
+	}})
+	view.SetComments([]domain.Comment{
+		{ID: "1", FilePath: "a.go", Line: 20},
+		{ID: "2", FilePath: "b.go", Line: 1},
+	})
+	view.SwitchToDiff()
+
+	position := func() [2]int {
+		file, line, _ := view.Position()
+		return [2]int{file, line}
+	}
+
+	for _, want := range [][2]int{{0, 1}, {0, 4}, {1, 0}} {
+		if !view.NextHunk() || position() != want {
+			t.Fatalf("expected next hunk at %v, got %v", want, position())
+		}
+	}
+	if view.NextHunk() {
+		t.Error("expected no hunk after the last one")
+	}
+
+	view.JumpToLine(0, 2)
+	if !view.PrevHunk() || position() != [2]int{0, 1} {
+		t.Errorf("expected to return to the start of the current hunk, got %v", position())
+	}
+
+	if !view.NextCommentedLine() || position() != [2]int{0, 3} {
+		t.Errorf("expected the commented context line, got %v", position())
+	}
+	if !view.NextCommentedLine() || position() != [2]int{1, 0} {
+		t.Errorf("expected the commented line of the next file, got %v", position())
+	}
+	if !view.PrevCommentedLine() || position() != [2]int{0, 3} {
+		t.Errorf("expected to go back to the first commented line, got %v", position())
+	}
+	if view.PrevCommentedLine() {
+		t.Error("expected no commented line before the first one")
+	}
+}
+
+func TestAnnotations_RenderAndNavigate(t *testing.T) {
+	view := NewPRInspectView()
+	view.SetSize(100, 40)
+	view.SetDiff(&domain.Diff{Files: []domain.FileDiff{
+		{
+			NewPath: "a.go",
+			Hunks: []domain.DiffHunk{{Header: "@@ -1,2 +1,2 @@", Lines: []domain.DiffLine{
+				{Type: "context", Content: " one", OldLine: 1, NewLine: 1},
+				{Type: "delete", Content: "-two", OldLine: 2},
+				{Type: "add", Content: "+panic()", NewLine: 2},
+			}}},
+		},
+		{
+			NewPath: "b.go",
+			Hunks: []domain.DiffHunk{{Header: "@@ -0,0 +1,1 @@", Lines: []domain.DiffLine{
+				{Type: "add", Content: "+new", NewLine: 1},
+			}}},
+		},
+	}})
+	view.SwitchToDiff()
+
+	view.SetAnnotations("a.go", []domain.Annotation{
+		{Source: "lint", Path: "a.go", Line: 2, Severity: domain.AnnotationError, Message: "do not panic"},
+		{Source: "lint", Path: "a.go", Line: 40, Severity: domain.AnnotationWarning, Message: "far away"},
+	})
+	view.SetAnnotations("b.go", []domain.Annotation{{Source: "spell", Path: "b.go", Line: 1, Message: "typo"}})
+
+	content := view.renderDiff()
+	if !strings.Contains(content, "✖ [lint] do not panic") {
+		t.Errorf("expected the finding under its line, got:\n%s", content)
+	}
+	if !strings.Contains(content, "2 finding(s), 1 outside the diff") {
+		t.Errorf("expected the findings to be counted in the header, got:\n%s", content)
+	}
+	if strings.Count(content, "do not panic") != 1 {
+		t.Error("expected the finding on the added line only, not the deleted line with the same number")
+	}
+	if view.AnnotationCount() != 3 {
+		t.Errorf("AnnotationCount() = %d, want 3", view.AnnotationCount())
+	}
+
+	position := func() [2]int {
+		file, line, _ := view.Position()
+		return [2]int{file, line}
+	}
+	if !view.NextAnnotation() || position() != [2]int{0, 2} {
+		t.Errorf("expected the first finding, got %v", position())
+	}
+	if !view.NextAnnotation() || position() != [2]int{1, 0} {
+		t.Errorf("expected the finding in the next file, got %v", position())
+	}
+	if view.NextAnnotation() {
+		t.Error("expected no finding after the last one")
+	}
+	if !view.PrevAnnotation() || position() != [2]int{0, 2} {
+		t.Errorf("expected to go back to the first finding, got %v", position())
+	}
+
+	view.SetAnnotations("b.go", nil)
+	if view.AnnotationCount() != 2 {
+		t.Errorf("expected clearing a file to drop its findings, got %d", view.AnnotationCount())
+	}
+}
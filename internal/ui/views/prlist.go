@@ -12,11 +12,13 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/johanforsgren/lgtmfaster/internal/domain"
-	"github.com/mattn/go-runewidth"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/textwidth"
 )
 
 func getCategoryIndicator(category domain.PRCategory) string {
 	switch category {
+	case domain.PRCategoryReviewRequested:
+		return " ★ "
 	case domain.PRCategoryAuthored:
 		return " ✎ "
 	case domain.PRCategoryAssigned:
@@ -26,6 +28,22 @@ func getCategoryIndicator(category domain.PRCategory) string {
 	}
 }
 
+// dependencyIndicator replaces the category indicator of dependency bot PRs.
+const dependencyIndicator = " ⬆ "
+
+// pinnedBadge marks pinned PRs and the PRs of pinned repositories.
+const pinnedBadge = "★"
+
+// startedReviewBadge marks PRs with pending comments or a draft review that
+// was never submitted.
+const startedReviewBadge = "✍"
+
+// Markers of the drafts section heading when it is collapsed and expanded.
+const (
+	draftsCollapsedMarker = " ▸ "
+	draftsExpandedMarker  = " ▾ "
+)
+
 func getApprovalBadge(status domain.ApprovalStatus) string {
 	switch status {
 	case domain.ApprovalStatusApproved:
@@ -40,7 +58,8 @@ func getApprovalBadge(status domain.ApprovalStatus) string {
 }
 
 type PRListViewModel struct {
-	table table.Model
+	table  table.Model
+	styles table.Styles
 
 	// Source data (never mutated by sorting/filtering)
 	sourcePRs    []domain.PullRequest
@@ -55,6 +74,40 @@ type PRListViewModel struct {
 	filterInput textinput.Model
 	filtering   bool
 	filterText  string
+	searchQuery string
+
+	// Snoozed PRs are hidden unless showSnoozed is set
+	snoozes     map[string]domain.PRSnooze
+	showSnoozed bool
+	hiddenCount int
+
+	// seen maps PR keys to their UpdatedAt when last viewed
+	seen map[string]time.Time
+
+	// Pinned PRs, and the PRs of pinned repositories, are listed first
+	pins domain.Pins
+
+	// startedReviews holds the keys of the PRs with an unsubmitted review
+	startedReviews map[string]bool
+
+	// In detailed mode each PR takes two lines; detailOffset is the first
+	// table row shown below the header row.
+	detailed     bool
+	detailOffset int
+
+	// Draft PRs are listed according to drafts. In a section, they follow
+	// the PRs at draftsAt in visiblePRs, listed only when draftsExpanded.
+	drafts         domain.DraftDisplay
+	draftsExpanded bool
+	draftsAt       int
+	draftCount     int
+
+	// rowCache holds the rendered rows of the listed PRs, see prsToRows.
+	rowCache map[prRowKey]cachedPRRow
+
+	// filterGeneration changes with every edit of the filter input, so that
+	// a debounced apply can tell whether typing went on.
+	filterGeneration int
 }
 
 func NewPRListView() *PRListViewModel {
@@ -63,10 +116,11 @@ func NewPRListView() *PRListViewModel {
 		{Title: "", Width: 4},
 		{Title: "", Width: 50},
 		{Title: "", Width: 22},
+		{Title: "", Width: 16},
 		{Title: "", Width: 7},
 		{Title: "", Width: 15},
 		{Title: "", Width: 14},
-		{Title: "", Width: 4},
+		{Title: "", Width: 5},
 	}
 
 	t := table.New(
@@ -88,12 +142,14 @@ func NewPRListView() *PRListViewModel {
 	t.SetStyles(s)
 
 	ti := textinput.New()
-	ti.Placeholder = "Filter by title, author, or PR number..."
+	ti.Placeholder = "Filter by title, author, PR number or target:release/*..."
 	ti.CharLimit = 100
 
 	return &PRListViewModel{
 		table:       t,
+		styles:      s,
 		filterInput: ti,
+		draftsAt:    -1,
 	}
 }
 
@@ -109,16 +165,17 @@ func (m *PRListViewModel) updateColumnWidths() {
 		categoryWidth = 4
 		approvalWidth = 4
 		repoWidth     = 22
+		targetWidth   = 16
 		numberWidth   = 7
 		authorWidth   = 15
 		ageWidth      = 14
-		rightPadWidth = 4
+		rightPadWidth = 5
 		minTitleWidth = 20
 		maxTitleWidth = 100
 		padding       = 0
 	)
 
-	fixed := categoryWidth + approvalWidth + repoWidth + numberWidth +
+	fixed := categoryWidth + approvalWidth + repoWidth + targetWidth + numberWidth +
 		authorWidth + ageWidth + rightPadWidth + padding
 
 	available := max(0, m.width-fixed)
@@ -129,6 +186,7 @@ func (m *PRListViewModel) updateColumnWidths() {
 		{Title: "", Width: approvalWidth},
 		{Title: "", Width: titleWidth},
 		{Title: "", Width: repoWidth},
+		{Title: "", Width: targetWidth},
 		{Title: "", Width: numberWidth},
 		{Title: "", Width: authorWidth},
 		{Title: "", Width: ageWidth},
@@ -138,53 +196,324 @@ func (m *PRListViewModel) updateColumnWidths() {
 }
 
 func (m *PRListViewModel) SetPRs(prs []domain.PullRequest) {
+	m.searchQuery = ""
 	m.sourceGroups = nil
 	m.sourcePRs = append([]domain.PullRequest(nil), prs...)
 	m.rebuild()
 }
 
 func (m *PRListViewModel) SetPRGroups(groups []domain.PRGroup) {
+	m.searchQuery = ""
 	m.sourceGroups = groups
 	m.sourcePRs = flattenGroups(groups)
 	m.rebuild()
 }
 
-// source → filter → sort → visible → rows
+// SetSearchResults shows the results of a provider-wide search instead of
+// the user's own pull requests until the list is next reloaded.
+func (m *PRListViewModel) SetSearchResults(query string, groups []domain.PRGroup) {
+	m.SetPRGroups(groups)
+	m.searchQuery = query
+}
+
+func (m *PRListViewModel) SearchQuery() string {
+	return m.searchQuery
+}
+
+// SetSnoozes replaces the snoozes used to hide PRs, keeping the cursor.
+func (m *PRListViewModel) SetSnoozes(snoozes []domain.PRSnooze) {
+	m.snoozes = make(map[string]domain.PRSnooze, len(snoozes))
+	for _, s := range snoozes {
+		m.snoozes[s.PRIdentifier] = s
+	}
+	cursor := m.table.Cursor()
+	m.rebuild()
+	m.RestoreCursor(cursor)
+}
+
+// IsSnoozed reports whether pr is currently hidden by a snooze.
+func (m *PRListViewModel) IsSnoozed(pr domain.PullRequest) bool {
+	s, ok := m.snoozes[pr.Key()]
+	return ok && s.IsActive(pr, time.Now())
+}
+
+// ToggleShowSnoozed switches between hiding and listing snoozed PRs and
+// returns whether they are now listed.
+func (m *PRListViewModel) ToggleShowSnoozed() bool {
+	m.showSnoozed = !m.showSnoozed
+	m.rebuild()
+	return m.showSnoozed
+}
+
+// WokenSnoozes returns the snoozes of listed PRs that no longer apply, for
+// example because the PR was updated.
+func (m *PRListViewModel) WokenSnoozes() []domain.PRSnooze {
+	var woken []domain.PRSnooze
+	now := time.Now()
+	for _, pr := range m.sourcePRs {
+		if s, ok := m.snoozes[pr.Key()]; ok && !s.IsActive(pr, now) {
+			woken = append(woken, s)
+		}
+	}
+	return woken
+}
+
+// SetSeen replaces the last-seen timestamps used for unread markers.
+func (m *PRListViewModel) SetSeen(seen map[string]time.Time) {
+	m.seen = seen
+	m.table.SetRows(m.prsToRows(m.visiblePRs))
+}
+
+// SetPins replaces the pinned repositories and PRs, keeping the cursor on
+// the selected PR.
+func (m *PRListViewModel) SetPins(pins domain.Pins) {
+	m.pins = pins
+	selected := m.GetSelectedPR()
+	cursor := m.table.Cursor()
+	m.rebuild()
+	if selected == nil || !m.SelectPR(selected.Key()) {
+		m.RestoreCursor(cursor)
+	}
+}
+
+// SetStartedReviews replaces the PRs marked as having a review that was
+// started but not submitted.
+func (m *PRListViewModel) SetStartedReviews(keys []string) {
+	m.startedReviews = make(map[string]bool, len(keys))
+	for _, key := range keys {
+		m.startedReviews[key] = true
+	}
+	m.table.SetRows(m.prsToRows(m.visiblePRs))
+}
+
+// HasStartedReview reports whether pr has a review that was started but not
+// submitted.
+func (m *PRListViewModel) HasStartedReview(pr domain.PullRequest) bool {
+	return m.startedReviews[pr.Key()]
+}
+
+func (m *PRListViewModel) Pins() domain.Pins {
+	return m.pins
+}
+
+// SetDetailed switches between one line per PR and a second, dim line with
+// its repository, branches and labels.
+func (m *PRListViewModel) SetDetailed(detailed bool) {
+	m.detailed = detailed
+	m.detailOffset = 0
+}
+
+func (m *PRListViewModel) Detailed() bool {
+	return m.detailed
+}
+
+// SetDraftDisplay changes how draft PRs are listed. A drafts section starts
+// collapsed.
+func (m *PRListViewModel) SetDraftDisplay(drafts domain.DraftDisplay) {
+	if drafts == m.drafts {
+		return
+	}
+	m.drafts = drafts
+	m.draftsExpanded = false
+	m.rebuild()
+}
+
+func (m *PRListViewModel) DraftDisplay() domain.DraftDisplay {
+	return m.drafts
+}
+
+// DraftCount returns how many listed PRs, hidden or collapsed ones
+// included, are drafts.
+func (m *PRListViewModel) DraftCount() int {
+	return m.draftCount
+}
+
+// ToggleDrafts expands or collapses the drafts section, keeping the cursor
+// on the section, and returns whether it is now expanded.
+func (m *PRListViewModel) ToggleDrafts() bool {
+	if m.drafts != domain.DraftsSection {
+		return false
+	}
+	m.draftsExpanded = !m.draftsExpanded
+	cursor := m.table.Cursor()
+	m.rebuild()
+	if m.draftsAt >= 0 {
+		cursor = m.draftsAt + 1
+	}
+	m.RestoreCursor(cursor)
+	return m.draftsExpanded
+}
+
+// DraftsSectionSelected reports whether the cursor is on the heading of the
+// drafts section.
+func (m *PRListViewModel) DraftsSectionSelected() bool {
+	return m.draftsAt >= 0 && m.table.Cursor() == m.draftsAt+1
+}
+
+// ListedPRs returns every PR of the list, including the ones the filter,
+// snoozes or collapsed drafts hide.
+func (m *PRListViewModel) ListedPRs() []domain.PullRequest {
+	return append([]domain.PullRequest(nil), m.sourcePRs...)
+}
+
+// VisiblePRs returns the listed PRs in list order.
+func (m *PRListViewModel) VisiblePRs() []domain.PullRequest {
+	return append([]domain.PullRequest(nil), m.visiblePRs...)
+}
+
+// DependencyPRs returns the listed PRs opened by dependency bots, in list
+// order.
+func (m *PRListViewModel) DependencyPRs() []domain.PullRequest {
+	var prs []domain.PullRequest
+	for _, pr := range m.visiblePRs {
+		if domain.DependencyBot(pr) != "" {
+			prs = append(prs, pr)
+		}
+	}
+	return prs
+}
+
+// IsUnread reports whether pr was never viewed or changed since it was.
+func (m *PRListViewModel) IsUnread(pr domain.PullRequest) bool {
+	seen, ok := m.seen[pr.Key()]
+	return !ok || pr.UpdatedAt.After(seen)
+}
+
+// UnreadPRs returns the listed PRs, snoozed ones included, that are unread.
+func (m *PRListViewModel) UnreadPRs() []domain.PullRequest {
+	var unread []domain.PullRequest
+	for _, pr := range m.sourcePRs {
+		if m.IsUnread(pr) {
+			unread = append(unread, pr)
+		}
+	}
+	return unread
+}
+
+// source → snooze → filter → drafts → sort → visible → rows
 func (m *PRListViewModel) rebuild() {
-	filtered := m.filterPRs(m.sourcePRs)
-	sorted := sortPRs(filtered)
+	filtered := m.filterPRs(m.hideSnoozed(m.sourcePRs))
+	prs, drafts := m.separateDrafts(filtered)
+	sorted := m.pinnedFirst(sortPRs(prs))
+	m.draftsAt = -1
+	if m.drafts == domain.DraftsSection && len(drafts) > 0 {
+		m.draftsAt = len(sorted)
+		if m.draftsExpanded {
+			sorted = append(sorted, m.pinnedFirst(sortPRs(drafts))...)
+		}
+	}
 	m.visiblePRs = sorted
 	m.table.SetRows(m.prsToRows(sorted))
-	if len(sorted) > 0 {
+	if len(m.table.Rows()) > 1 {
 		m.table.SetCursor(1)
 	}
 }
 
+// separateDrafts returns the PRs listed among the others and the drafts set
+// apart from them, counting the drafts for DraftCount.
+func (m *PRListViewModel) separateDrafts(prs []domain.PullRequest) ([]domain.PullRequest, []domain.PullRequest) {
+	m.draftCount = 0
+	for _, pr := range prs {
+		if pr.IsDraft {
+			m.draftCount++
+		}
+	}
+	if m.drafts == domain.DraftsShown || m.draftCount == 0 {
+		return prs, nil
+	}
+
+	out := make([]domain.PullRequest, 0, len(prs)-m.draftCount)
+	drafts := make([]domain.PullRequest, 0, m.draftCount)
+	for _, pr := range prs {
+		if pr.IsDraft {
+			drafts = append(drafts, pr)
+		} else {
+			out = append(out, pr)
+		}
+	}
+	return out, drafts
+}
+
+// categoryOrder is the order in which sortPRs lists the categories.
+var categoryOrder = map[domain.PRCategory]int{
+	domain.PRCategoryReviewRequested: 0,
+	domain.PRCategoryAuthored:        1,
+	domain.PRCategoryAssigned:        2,
+	domain.PRCategoryOther:           3,
+}
+
+// sortPRs orders PRs by category and then by last update, grouping the PRs
+// of dependency bots at the end.
 func sortPRs(prs []domain.PullRequest) []domain.PullRequest {
 	out := append([]domain.PullRequest(nil), prs...)
 	sort.SliceStable(out, func(i, j int) bool {
+		iBot, jBot := domain.DependencyBot(out[i]) != "", domain.DependencyBot(out[j]) != ""
+		if iBot != jBot {
+			return jBot
+		}
 		if out[i].Category != out[j].Category {
-			order := map[domain.PRCategory]int{
-				domain.PRCategoryAuthored: 0,
-				domain.PRCategoryAssigned: 1,
-				domain.PRCategoryOther:    2,
-			}
-			return order[out[i].Category] < order[out[j].Category]
+			return categoryOrder[out[i].Category] < categoryOrder[out[j].Category]
 		}
 		return out[i].UpdatedAt.After(out[j].UpdatedAt)
 	})
 	return out
 }
 
+// pinnedFirst moves pinned PRs, and the PRs of pinned repositories, to the
+// top, keeping the order of sorted otherwise.
+func (m *PRListViewModel) pinnedFirst(sorted []domain.PullRequest) []domain.PullRequest {
+	if len(m.pins.Repositories) == 0 && len(m.pins.PullRequests) == 0 {
+		return sorted
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return m.pins.IsPinned(sorted[i]) && !m.pins.IsPinned(sorted[j])
+	})
+	return sorted
+}
+
+func (m *PRListViewModel) hideSnoozed(prs []domain.PullRequest) []domain.PullRequest {
+	m.hiddenCount = 0
+	if m.showSnoozed || len(m.snoozes) == 0 {
+		return prs
+	}
+
+	out := make([]domain.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if m.IsSnoozed(pr) {
+			m.hiddenCount++
+			continue
+		}
+		out = append(out, pr)
+	}
+	return out
+}
+
+// targetFilterPrefix starts a filter term that matches the target branch,
+// such as target:release/*.
+const targetFilterPrefix = "target:"
+
+// filterPRs keeps the PRs into a branch matching one of the target: terms of
+// the filter, if any, whose title, author or number contains the rest.
 func (m *PRListViewModel) filterPRs(prs []domain.PullRequest) []domain.PullRequest {
 	if m.filterText == "" {
 		return prs
 	}
 
-	filter := strings.ToLower(m.filterText)
+	var targets, words []string
+	for _, field := range strings.Fields(m.filterText) {
+		if pattern, ok := strings.CutPrefix(strings.ToLower(field), targetFilterPrefix); ok {
+			targets = append(targets, pattern)
+		} else {
+			words = append(words, field)
+		}
+	}
+	filter := strings.ToLower(strings.Join(words, " "))
 	var out []domain.PullRequest
 
 	for _, pr := range prs {
+		if len(targets) > 0 && !matchesAnyBranch(targets, pr.TargetBranch) {
+			continue
+		}
 		if strings.Contains(strings.ToLower(pr.Title), filter) ||
 			strings.Contains(strings.ToLower(pr.Author.Username), filter) ||
 			strings.Contains(strconv.Itoa(pr.Number), filter) {
@@ -194,45 +523,181 @@ func (m *PRListViewModel) filterPRs(prs []domain.PullRequest) []domain.PullReque
 	return out
 }
 
+// matchesAnyBranch reports whether branch matches one of the patterns, in
+// which * matches any run of characters, slashes included. Matching is
+// case-insensitive.
+func matchesAnyBranch(patterns []string, branch string) bool {
+	branch = strings.ToLower(branch)
+	for _, pattern := range patterns {
+		if matchWildcard(pattern, branch) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchWildcard(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
+
 func (m *PRListViewModel) prsToRows(prs []domain.PullRequest) []table.Row {
 	cols := m.table.Columns()
-	rows := make([]table.Row, len(prs)+1)
+	rows := make([]table.Row, 1, len(prs)+2)
 
 	rows[0] = m.headerRow(cols)
 
+	// Rows are only restyled when what they show changed; the cache is
+	// rebuilt from the rows in use, so PRs that left the list drop out.
+	cache := make(map[prRowKey]cachedPRRow, len(prs))
 	for i, pr := range prs {
-		rows[i+1] = table.Row{
-			padToWidth(getCategoryIndicator(pr.Category), cols[0].Width),
-			padToWidth(getApprovalBadge(pr.ApprovalStatus), cols[1].Width),
-			padToWidth(truncateString(pr.Title, cols[2].Width), cols[2].Width),
-			padToWidth(truncateString(pr.Repository.FullName, cols[3].Width), cols[3].Width),
-			padToWidth(truncateString(fmt.Sprintf("#%d", pr.Number), cols[4].Width), cols[4].Width),
-			padToWidth(truncateString(pr.Author.Username, cols[5].Width), cols[5].Width),
-			padToWidth(truncateString(formatAge(pr.CreatedAt), cols[6].Width), cols[6].Width),
-			padToWidth("", cols[7].Width),
+		if i == m.draftsAt {
+			rows = append(rows, m.draftsRow(cols))
+		}
+		key := prRowKey{pr: pr.Key(), titleWidth: cols[2].Width}
+		cells := m.rowCells(pr)
+		cached, ok := m.rowCache[key]
+		if !ok || cached.cells != cells {
+			cached = cachedPRRow{cells: cells, row: cells.render(cols)}
 		}
+		cache[key] = cached
+		rows = append(rows, cached.row)
+	}
+	if m.draftsAt == len(prs) {
+		rows = append(rows, m.draftsRow(cols))
 	}
+	m.rowCache = cache
 	return rows
 }
 
+// prRowCells holds the text of the cells of a PR's row before it is fitted
+// to the columns.
+type prRowCells struct {
+	indicator, approval, title, repo, target, number, author, age, badges string
+}
+
+// prRowKey identifies a cached row. Only the title column changes width
+// with the terminal.
+type prRowKey struct {
+	pr         string
+	titleWidth int
+}
+
+type cachedPRRow struct {
+	cells prRowCells
+	row   table.Row
+}
+
+func (m *PRListViewModel) rowCells(pr domain.PullRequest) prRowCells {
+	badges := " "
+	if m.pins.IsPinned(pr) {
+		badges += pinnedBadge
+	}
+	if m.IsUnread(pr) {
+		badges += "●"
+	}
+	if m.showSnoozed && m.IsSnoozed(pr) {
+		badges += "z"
+	}
+	if m.HasStartedReview(pr) {
+		badges += startedReviewBadge
+	}
+	indicator := getCategoryIndicator(pr.Category)
+	if domain.DependencyBot(pr) != "" {
+		indicator = dependencyIndicator
+	}
+	return prRowCells{
+		indicator: indicator,
+		approval:  getApprovalBadge(pr.ApprovalStatus),
+		title:     pr.Title,
+		repo:      pr.Repository.FullName,
+		target:    pr.TargetBranch,
+		number:    "#" + strconv.Itoa(pr.Number),
+		author:    pr.Author.Username,
+		age:       formatAge(pr.CreatedAt),
+		badges:    badges,
+	}
+}
+
+func (c prRowCells) render(cols []table.Column) table.Row {
+	return table.Row{
+		textwidth.Pad(c.indicator, cols[0].Width),
+		textwidth.Pad(c.approval, cols[1].Width),
+		textwidth.Pad(textwidth.Truncate(c.title, cols[2].Width), cols[2].Width),
+		textwidth.Pad(textwidth.Truncate(c.repo, cols[3].Width), cols[3].Width),
+		textwidth.Pad(textwidth.Truncate(c.target, cols[4].Width), cols[4].Width),
+		textwidth.Pad(textwidth.Truncate(c.number, cols[5].Width), cols[5].Width),
+		textwidth.Pad(textwidth.Truncate(c.author, cols[6].Width), cols[6].Width),
+		textwidth.Pad(textwidth.Truncate(c.age, cols[7].Width), cols[7].Width),
+		textwidth.Pad(c.badges, cols[8].Width),
+	}
+}
+
+// draftsRow is the heading of the drafts section, which is expanded and
+// collapsed with enter.
+func (m *PRListViewModel) draftsRow(cols []table.Column) table.Row {
+	marker := draftsCollapsedMarker
+	if m.draftsExpanded {
+		marker = draftsExpandedMarker
+	}
+	row := make(table.Row, len(cols))
+	for i, col := range cols {
+		row[i] = textwidth.Pad("", col.Width)
+	}
+	row[0] = textwidth.Pad(marker, cols[0].Width)
+	row[2] = textwidth.Pad(textwidth.Truncate(fmt.Sprintf("Drafts (%d)", m.draftCount), cols[2].Width), cols[2].Width)
+	return row
+}
+
+// prIndex returns the index in visiblePRs of the PR on a table row, or -1
+// for the header row and the heading of the drafts section.
+func (m *PRListViewModel) prIndex(row int) int {
+	idx := row - 1
+	if m.draftsAt >= 0 && idx >= m.draftsAt {
+		if idx == m.draftsAt {
+			return -1
+		}
+		idx--
+	}
+	if idx < 0 || idx >= len(m.visiblePRs) {
+		return -1
+	}
+	return idx
+}
+
 // Hack to get header alignment to work properly  - create a "header row" at index 0
 func (m *PRListViewModel) headerRow(cols []table.Column) table.Row {
 	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
 	return table.Row{
-		padToWidth("", cols[0].Width),
-		padToWidth("", cols[1].Width),
-		padToWidth(headerStyle.Render("Title"), cols[2].Width),
-		padToWidth(headerStyle.Render("Repo"), cols[3].Width),
-		padToWidth(headerStyle.Render("#"), cols[4].Width),
-		padToWidth(headerStyle.Render("Author"), cols[5].Width),
-		padToWidth(headerStyle.Render("Age"), cols[6].Width),
-		padToWidth("", cols[7].Width),
+		textwidth.Pad("", cols[0].Width),
+		textwidth.Pad("", cols[1].Width),
+		textwidth.Pad(headerStyle.Render("Title"), cols[2].Width),
+		textwidth.Pad(headerStyle.Render("Repo"), cols[3].Width),
+		textwidth.Pad(headerStyle.Render("Target"), cols[4].Width),
+		textwidth.Pad(headerStyle.Render("#"), cols[5].Width),
+		textwidth.Pad(headerStyle.Render("Author"), cols[6].Width),
+		textwidth.Pad(headerStyle.Render("Age"), cols[7].Width),
+		textwidth.Pad("", cols[8].Width),
 	}
 }
 
 func (m *PRListViewModel) GetSelectedPR() *domain.PullRequest {
-	idx := m.table.Cursor() - 1
-	if idx < 0 || idx >= len(m.visiblePRs) {
+	idx := m.prIndex(m.table.Cursor())
+	if idx < 0 {
 		return nil
 	}
 	return &m.visiblePRs[idx]
@@ -258,13 +723,29 @@ func (m *PRListViewModel) RestoreCursor(index int) {
 	m.table.SetCursor(index)
 }
 
+// SelectPR moves the cursor to the visible PR with the given key and reports
+// whether it was found.
+func (m *PRListViewModel) SelectPR(key string) bool {
+	for i, pr := range m.visiblePRs {
+		if pr.Key() == key {
+			row := i + 1
+			if m.draftsAt >= 0 && i >= m.draftsAt {
+				row++
+			}
+			m.table.SetCursor(row)
+			return true
+		}
+	}
+	return false
+}
+
 func (m *PRListViewModel) Update(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
 	if m.filtering {
 		m.filterInput, cmd = m.filterInput.Update(msg)
 	} else {
 		m.table, cmd = m.table.Update(msg)
-		if m.table.Cursor() == 0 && len(m.visiblePRs) > 0 {
+		if m.table.Cursor() == 0 && len(m.table.Rows()) > 1 {
 			m.table.SetCursor(1)
 		}
 	}
@@ -303,7 +784,11 @@ func (m *PRListViewModel) View() string {
 		Italic(true).
 		Render("\n" + m.helpText())
 
-	tableView := m.colorizeTableRows(m.table.View())
+	tableView := m.table.View()
+	if m.detailed {
+		tableView = m.detailedTableView()
+	}
+	tableView = m.colorizeTableRows(tableView)
 
 	var content string
 	if m.filtering {
@@ -320,6 +805,89 @@ func (m *PRListViewModel) View() string {
 	return content
 }
 
+// detailedTableView renders the table with a dim line below each PR. The
+// table still moves the cursor, but only the rows that fit in its height
+// with their second lines are rendered, scrolling to keep the cursor shown.
+func (m *PRListViewModel) detailedTableView() string {
+	height := m.table.Height()
+	lines := strings.Split(m.table.View(), "\n")
+	headerLines := max(0, len(lines)-height)
+	lines = lines[:headerLines]
+
+	rows := m.table.Rows()
+	if len(rows) > 0 {
+		lines = append(lines, m.renderRow(0))
+
+		perPage := max(1, (height-1)/2)
+		cursor := max(1, m.table.Cursor())
+		if cursor < m.detailOffset {
+			m.detailOffset = cursor
+		}
+		if cursor >= m.detailOffset+perPage {
+			m.detailOffset = cursor - perPage + 1
+		}
+		m.detailOffset = max(1, m.detailOffset)
+
+		for r := m.detailOffset; r < len(rows) && r < m.detailOffset+perPage; r++ {
+			lines = append(lines, m.renderRow(r))
+			if idx := m.prIndex(r); idx >= 0 {
+				lines = append(lines, m.detailLine(m.visiblePRs[idx]))
+			}
+		}
+	}
+
+	for len(lines) < headerLines+height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines[:headerLines+height], "\n")
+}
+
+// renderRow renders a table row the way the table does.
+func (m *PRListViewModel) renderRow(r int) string {
+	cols := m.table.Columns()
+	cells := make([]string, 0, len(cols))
+	for i, value := range m.table.Rows()[r] {
+		if i >= len(cols) || cols[i].Width <= 0 {
+			continue
+		}
+		style := lipgloss.NewStyle().Width(cols[i].Width).MaxWidth(cols[i].Width).Inline(true)
+		cells = append(cells, m.styles.Cell.Render(style.Render(value)))
+	}
+
+	row := lipgloss.JoinHorizontal(lipgloss.Top, cells...)
+	if r == m.table.Cursor() {
+		return m.styles.Selected.Render(row)
+	}
+	return row
+}
+
+// detailLine is the second line of a PR in detailed mode, aligned with its
+// title: repository · branches · labels.
+func (m *PRListViewModel) detailLine(pr domain.PullRequest) string {
+	cols := m.table.Columns()
+	padding := m.styles.Cell.GetHorizontalPadding()
+	indent := cols[0].Width + cols[1].Width + 2*padding + m.styles.Cell.GetPaddingLeft()
+
+	parts := []string{pr.Repository.FullName}
+	if pr.SourceBranch != "" {
+		branches := pr.SourceBranch
+		if pr.TargetBranch != "" {
+			branches += " → " + pr.TargetBranch
+		}
+		parts = append(parts, branches)
+	}
+	if len(pr.Labels) > 0 {
+		parts = append(parts, strings.Join(pr.Labels, ", "))
+	}
+	if pr.IsDraft {
+		parts = append(parts, "draft")
+	}
+
+	text := textwidth.Truncate(strings.Join(parts, " · "), max(1, m.width-indent))
+	detailStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	return strings.Repeat(" ", indent) + detailStyle.Render(text)
+}
+
 // post effect render rows.
 func (m *PRListViewModel) colorizeTableRows(tableOutput string) string {
 	lines := strings.Split(tableOutput, "\n")
@@ -329,7 +897,7 @@ func (m *PRListViewModel) colorizeTableRows(tableOutput string) string {
 	for i, line := range lines {
 		if strings.Contains(line, " ✎ ") {
 			lines[i] = authoredStyle.Render(line)
-		} else if strings.Contains(line, " ○ ") {
+		} else if strings.Contains(line, " ○ ") || strings.Contains(line, draftsCollapsedMarker) || strings.Contains(line, draftsExpandedMarker) {
 			lines[i] = otherStyle.Render(line)
 		}
 	}
@@ -340,10 +908,46 @@ func (m *PRListViewModel) helpText() string {
 	if m.filtering {
 		return "Type to filter | Enter/Esc: Close"
 	}
-	if m.filterText != "" {
-		return "Enter: Inspect | r: Refresh | /: Filter | Esc: Clear filter | q: Back"
+
+	var help string
+	switch {
+	case m.searchQuery != "":
+		help = fmt.Sprintf("Search: %s | Enter: Inspect | /: Filter | r: Back to my PRs | q: Back", m.searchQuery)
+	case m.filterText != "":
+		help = "Enter: Inspect | r: Refresh | /: Filter | Esc: Clear filter | q: Back"
+	default:
+		help = "Enter: Inspect | r: Refresh | /: Filter | R: Mark all read | q: Back"
+	}
+
+	if deps := len(m.DependencyPRs()); deps > 0 {
+		help += fmt.Sprintf(" | B: %d dependency update(s)", deps)
+	}
+
+	help += " | */P: Pin PR/repo"
+	if m.detailed {
+		help += " | v: Compact rows"
+	} else {
+		help += " | v: Detailed rows"
+	}
+
+	switch {
+	case m.drafts == domain.DraftsHidden && m.draftCount > 0:
+		help += fmt.Sprintf(" | %d draft(s) hidden", m.draftCount)
+	case m.draftsAt >= 0 && m.draftsExpanded:
+		help += " | D: Collapse drafts"
+	case m.draftsAt >= 0:
+		help += " | D: Expand drafts"
 	}
-	return "Enter: Inspect | r: Refresh | /: Filter | q: Back"
+
+	switch {
+	case m.showSnoozed:
+		help += " | z: Snooze/Wake | Z: Hide snoozed"
+	case m.hiddenCount > 0:
+		help += fmt.Sprintf(" | z: Snooze | Z: Show %d snoozed", m.hiddenCount)
+	default:
+		help += " | z: Snooze"
+	}
+	return help
 }
 
 func (m *PRListViewModel) IsFiltering() bool {
@@ -352,38 +956,38 @@ func (m *PRListViewModel) IsFiltering() bool {
 
 func (m *PRListViewModel) UpdateFilterInput(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
+	before := m.filterInput.Value()
 	m.filterInput, cmd = m.filterInput.Update(msg)
+	if m.filterInput.Value() != before {
+		m.filterGeneration++
+	}
 	return cmd
 }
 
+// FilterGeneration changes with every edit of the filter input.
+func (m *PRListViewModel) FilterGeneration() int {
+	return m.filterGeneration
+}
+
+// ApplyFilterFromInput filters the list by the text typed so far, unless it
+// is already applied.
 func (m *PRListViewModel) ApplyFilterFromInput() {
+	if m.filterInput.Value() == m.filterText {
+		return
+	}
 	m.filterText = m.filterInput.Value()
 	m.rebuild()
 }
 
-func (m *PRListViewModel) GetFilterText() string {
-	return m.filterText
-}
-
-func truncateString(s string, maxWidth int) string {
-	if maxWidth <= 0 {
-		return ""
-	}
-	if runewidth.StringWidth(s) <= maxWidth {
-		return s
-	}
-	if maxWidth <= 3 {
-		return runewidth.Truncate(s, maxWidth, "")
-	}
-	return runewidth.Truncate(s, maxWidth-3, "") + "..."
+// SetFilterText applies a filter without going through the filter input.
+func (m *PRListViewModel) SetFilterText(text string) {
+	m.filterText = text
+	m.filterInput.SetValue(text)
+	m.rebuild()
 }
 
-func padToWidth(s string, width int) string {
-	w := runewidth.StringWidth(s)
-	if w >= width {
-		return runewidth.Truncate(s, width, "")
-	}
-	return s + strings.Repeat(" ", width-w)
+func (m *PRListViewModel) GetFilterText() string {
+	return m.filterText
 }
 
 func formatAge(t time.Time) string {
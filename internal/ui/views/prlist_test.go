@@ -0,0 +1,292 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestSortPRs_ReviewRequestedFirst(t *testing.T) {
+	now := time.Now()
+	prs := []domain.PullRequest{
+		{Number: 1, Category: domain.PRCategoryOther, UpdatedAt: now},
+		{Number: 2, Category: domain.PRCategoryAuthored, UpdatedAt: now},
+		{Number: 3, Category: domain.PRCategoryAssigned, UpdatedAt: now},
+		{Number: 4, Category: domain.PRCategoryReviewRequested, UpdatedAt: now.Add(-time.Hour)},
+		{Number: 5, Category: domain.PRCategoryReviewRequested, UpdatedAt: now},
+	}
+
+	sorted := sortPRs(prs)
+
+	want := []int{5, 4, 2, 3, 1}
+	for i, number := range want {
+		if sorted[i].Number != number {
+			t.Fatalf("position %d: expected PR #%d, got #%d", i, number, sorted[i].Number)
+		}
+	}
+}
+
+func TestSortPRs_DependencyUpdatesLast(t *testing.T) {
+	now := time.Now()
+	prs := []domain.PullRequest{
+		{Number: 1, Category: domain.PRCategoryReviewRequested, Title: "Bump lodash from 4.17.20 to 4.17.21", Author: domain.User{Username: "dependabot[bot]"}, UpdatedAt: now},
+		{Number: 2, Category: domain.PRCategoryOther, UpdatedAt: now},
+		{Number: 3, Category: domain.PRCategoryReviewRequested, Title: "Update dependency react to v18.2.0", Author: domain.User{Username: "renovate[bot]"}, UpdatedAt: now.Add(-time.Hour)},
+		{Number: 4, Category: domain.PRCategoryReviewRequested, UpdatedAt: now},
+	}
+
+	sorted := sortPRs(prs)
+
+	want := []int{4, 2, 1, 3}
+	for i, number := range want {
+		if sorted[i].Number != number {
+			t.Fatalf("position %d: expected PR #%d, got #%d", i, number, sorted[i].Number)
+		}
+	}
+
+	view := NewPRListView()
+	view.SetPRs(prs)
+	if deps := view.DependencyPRs(); len(deps) != 2 || deps[0].Number != 1 || deps[1].Number != 3 {
+		t.Errorf("expected the two bot PRs in list order, got %v", deps)
+	}
+}
+
+func TestIsUnread_ComparesLastSeen(t *testing.T) {
+	now := time.Now()
+	pr := domain.PullRequest{Number: 1, ProviderType: domain.ProviderGitHub, Repository: domain.Repo{FullName: "acme/api"}, UpdatedAt: now}
+
+	view := NewPRListView()
+	view.SetPRs([]domain.PullRequest{pr})
+
+	if !view.IsUnread(pr) {
+		t.Error("expected never-viewed PR to be unread")
+	}
+
+	view.SetSeen(map[string]time.Time{pr.Key(): now})
+	if view.IsUnread(pr) {
+		t.Error("expected PR to be read after viewing")
+	}
+
+	pr.UpdatedAt = now.Add(time.Minute)
+	if !view.IsUnread(pr) {
+		t.Error("expected updated PR to be unread again")
+	}
+}
+
+func TestDraftDisplay_HidesOrSetsApartDrafts(t *testing.T) {
+	now := time.Now()
+	prs := []domain.PullRequest{
+		{Number: 1, Category: domain.PRCategoryReviewRequested, UpdatedAt: now},
+		{Number: 2, Category: domain.PRCategoryReviewRequested, UpdatedAt: now, IsDraft: true},
+		{Number: 3, Category: domain.PRCategoryAuthored, UpdatedAt: now},
+	}
+	list := NewPRListView()
+	list.SetPRs(prs)
+	if len(list.visiblePRs) != 3 || list.DraftCount() != 1 {
+		t.Fatalf("expected drafts to be listed by default, got %d PRs", len(list.visiblePRs))
+	}
+
+	list.SetDraftDisplay(domain.DraftsHidden)
+	if len(list.visiblePRs) != 2 || len(list.table.Rows()) != 3 {
+		t.Errorf("expected the draft to be hidden, got %d PRs", len(list.visiblePRs))
+	}
+
+	list.SetDraftDisplay(domain.DraftsSection)
+	if len(list.visiblePRs) != 2 || len(list.table.Rows()) != 4 {
+		t.Fatalf("expected a collapsed drafts section, got %d PRs and %d rows", len(list.visiblePRs), len(list.table.Rows()))
+	}
+	list.RestoreCursor(3)
+	if !list.DraftsSectionSelected() || list.GetSelectedPR() != nil {
+		t.Fatal("expected the last row to be the drafts section")
+	}
+
+	if !list.ToggleDrafts() {
+		t.Fatal("expected the section to expand")
+	}
+	if !list.DraftsSectionSelected() {
+		t.Error("expected the cursor to stay on the section")
+	}
+	list.RestoreCursor(4)
+	if pr := list.GetSelectedPR(); pr == nil || pr.Number != 2 {
+		t.Errorf("expected the draft below the section, got %+v", pr)
+	}
+	list.RestoreCursor(2)
+	if pr := list.GetSelectedPR(); pr == nil || pr.Number != 3 {
+		t.Errorf("expected the PRs above the section to be unaffected, got %+v", pr)
+	}
+	if !list.SelectPR(prs[1].Key()) || list.table.Cursor() != 4 {
+		t.Errorf("expected to select the draft on row 4, got %d", list.table.Cursor())
+	}
+}
+
+func TestSetPins_ListsPinnedPRsFirst(t *testing.T) {
+	now := time.Now()
+	prs := []domain.PullRequest{
+		{Number: 1, Category: domain.PRCategoryReviewRequested, Repository: domain.Repo{FullName: "acme/api"}, UpdatedAt: now},
+		{Number: 2, Category: domain.PRCategoryOther, Repository: domain.Repo{FullName: "acme/web"}, UpdatedAt: now},
+		{Number: 3, Category: domain.PRCategoryOther, Repository: domain.Repo{FullName: "acme/cli"}, UpdatedAt: now.Add(-time.Hour)},
+		{Number: 4, Category: domain.PRCategoryOther, Repository: domain.Repo{FullName: "acme/web"}, UpdatedAt: now.Add(-time.Hour)},
+	}
+	list := NewPRListView()
+	list.SetPRs(prs)
+	list.RestoreCursor(3)
+
+	list.SetPins(domain.Pins{Repositories: []string{prs[1].RepositoryKey()}, PullRequests: []string{prs[2].Key()}})
+
+	want := []int{2, 3, 4, 1}
+	for i, number := range want {
+		if list.visiblePRs[i].Number != number {
+			t.Fatalf("position %d: expected PR #%d, got #%d", i, number, list.visiblePRs[i].Number)
+		}
+	}
+	if pr := list.GetSelectedPR(); pr == nil || pr.Number != 3 {
+		t.Errorf("expected the cursor to follow the selected PR, got %+v", pr)
+	}
+	if row := list.table.Rows()[1]; !strings.Contains(row[8], pinnedBadge) {
+		t.Errorf("expected pinned PRs to be marked, got %q", row[8])
+	}
+	if row := list.table.Rows()[4]; strings.Contains(row[8], pinnedBadge) {
+		t.Errorf("expected other PRs not to be marked, got %q", row[8])
+	}
+}
+
+func TestSetStartedReviews_MarksTheirRows(t *testing.T) {
+	now := time.Now()
+	prs := []domain.PullRequest{
+		{Number: 1, Repository: domain.Repo{FullName: "acme/api"}, UpdatedAt: now},
+		{Number: 2, Repository: domain.Repo{FullName: "acme/api"}, UpdatedAt: now.Add(-time.Hour)},
+	}
+	list := NewPRListView()
+	list.SetPRs(prs)
+	list.SetSeen(map[string]time.Time{prs[0].Key(): now, prs[1].Key(): now})
+	list.SetPins(domain.Pins{PullRequests: []string{prs[0].Key()}})
+
+	list.SetStartedReviews([]string{prs[0].Key()})
+
+	if row := list.table.Rows()[1]; !strings.Contains(row[8], startedReviewBadge) || !strings.Contains(row[8], pinnedBadge) {
+		t.Errorf("expected the started review to be marked next to the pin, got %q", row[8])
+	}
+	if row := list.table.Rows()[2]; strings.Contains(row[8], startedReviewBadge) {
+		t.Errorf("expected other PRs not to be marked, got %q", row[8])
+	}
+}
+
+func TestDetailedRows_ShowSecondLineAndScroll(t *testing.T) {
+	now := time.Now()
+	var prs []domain.PullRequest
+	for i := 1; i <= 10; i++ {
+		prs = append(prs, domain.PullRequest{
+			Number:       i,
+			Title:        fmt.Sprintf("PR %d", i),
+			Repository:   domain.Repo{FullName: "acme/api"},
+			SourceBranch: fmt.Sprintf("feature-%d", i),
+			TargetBranch: "main",
+			Labels:       []string{"bug", "ui"},
+			UpdatedAt:    now.Add(-time.Duration(i) * time.Minute),
+		})
+	}
+	list := NewPRListView()
+	list.SetSize(160, 17)
+	list.SetPRs(prs)
+	compactHeight := lipgloss.Height(list.table.View())
+
+	list.SetDetailed(true)
+	view := list.detailedTableView()
+	if lipgloss.Height(view) != compactHeight {
+		t.Errorf("expected detailed rows to keep the table height %d, got %d", compactHeight, lipgloss.Height(view))
+	}
+	if !strings.Contains(view, "acme/api · feature-1 → main · bug, ui") {
+		t.Errorf("expected a second line with repository, branches and labels, got:\n%s", view)
+	}
+
+	list.RestoreCursor(10)
+	view = list.detailedTableView()
+	if !strings.Contains(view, "PR 10") || strings.Contains(view, "PR 1 ") {
+		t.Errorf("expected the list to scroll to the cursor, got:\n%s", view)
+	}
+}
+
+func TestRows_WideCharactersKeepColumnsAligned(t *testing.T) {
+	now := time.Now()
+	prs := []domain.PullRequest{
+		{Number: 1, Title: "Plain title", Repository: domain.Repo{FullName: "acme/api"}, Author: domain.User{Username: "dev"}, CreatedAt: now},
+		{Number: 2, Title: "🚀 Ship the 🎉 release with a very long emoji title 🔥🔥🔥🔥", Repository: domain.Repo{FullName: "acme/api"}, Author: domain.User{Username: "dev"}, CreatedAt: now},
+		{Number: 3, Title: "修正: ログイン画面のバグを直す長いタイトルです", Repository: domain.Repo{FullName: "acme/api"}, Author: domain.User{Username: "開発者"}, CreatedAt: now},
+		{Number: 4, Title: "Cafe\u0301 menu 👩‍💻", Repository: domain.Repo{FullName: "acme/api"}, Author: domain.User{Username: "dev"}, CreatedAt: now},
+	}
+	list := NewPRListView()
+	list.SetSize(120, 20)
+	list.SetPRs(prs)
+
+	for _, row := range list.table.Rows() {
+		for i, col := range list.table.Columns() {
+			if got := lipgloss.Width(row[i]); got != col.Width {
+				t.Errorf("expected cell %q in column %d to be %d columns wide, got %d", row[i], i, col.Width, got)
+			}
+		}
+	}
+}
+
+func TestRows_ReuseCachedRowsOfUnchangedPRs(t *testing.T) {
+	now := time.Now()
+	prs := []domain.PullRequest{
+		{Number: 1, Title: "First", Repository: domain.Repo{FullName: "acme/api"}, CreatedAt: now, UpdatedAt: now},
+		{Number: 2, Title: "Second", Repository: domain.Repo{FullName: "acme/api"}, CreatedAt: now, UpdatedAt: now.Add(-time.Hour)},
+	}
+	list := NewPRListView()
+	list.SetSize(120, 20)
+	list.SetPRs(prs)
+	first := list.table.Rows()
+
+	prs[1].Title = "Second, renamed"
+	list.SetPRs(prs)
+	rows := list.table.Rows()
+	if &rows[1][0] != &first[1][0] {
+		t.Error("expected the row of the unchanged PR to be reused")
+	}
+	if &rows[2][0] == &first[2][0] || !strings.Contains(rows[2][2], "Second, renamed") {
+		t.Errorf("expected the row of the changed PR to be rendered again, got %q", rows[2][2])
+	}
+
+	list.SetSize(140, 20)
+	list.SetPRs(prs)
+	if rows := list.table.Rows(); &rows[1][0] == &first[1][0] || len(list.rowCache) != 2 {
+		t.Errorf("expected a new width to render the rows again and drop the old ones, cached %d", len(list.rowCache))
+	}
+}
+
+func TestFilterPRs_TargetBranch(t *testing.T) {
+	prs := []domain.PullRequest{
+		{Number: 1, Title: "Fix login", TargetBranch: "main"},
+		{Number: 2, Title: "Fix login", TargetBranch: "release/1.2"},
+		{Number: 3, Title: "Bump version", TargetBranch: "Release/2.0/hotfix"},
+		{Number: 4, Title: "Fix login"},
+	}
+	list := NewPRListView()
+	list.SetPRs(prs)
+
+	tests := []struct {
+		filter string
+		want   []int
+	}{
+		{"target:release/*", []int{2, 3}},
+		{"target:release/* login", []int{2}},
+		{"target:main target:*hotfix", []int{1, 3}},
+		{"target:release", nil},
+		{"login", []int{1, 2, 4}},
+	}
+	for _, tt := range tests {
+		list.SetFilterText(tt.filter)
+		var got []int
+		for _, pr := range list.visiblePRs {
+			got = append(got, pr.Number)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("filter %q: expected PRs %v, got %v", tt.filter, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/textwidth"
+)
+
+// RepoChoice is a repository offered by the repo picker. PATID is empty
+// when any PAT of the provider may list it, and Provider is empty for a
+// repository typed by the user, which any selected PAT may list.
+type RepoChoice struct {
+	Repository string
+	Provider   domain.ProviderType
+	PATID      string
+}
+
+// RepoPickerViewModel picks a repository whose open PRs are listed, from
+// the repositories known from the PR list, pins and PATs, or typed in.
+type RepoPickerViewModel struct {
+	active      bool
+	width       int
+	height      int
+	selectedIdx int
+	choices     []RepoChoice
+	matches     []RepoChoice
+	input       textinput.Model
+}
+
+func NewRepoPickerView() *RepoPickerViewModel {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.Placeholder = "Filter, or type owner/repo or project/repo"
+	ti.CharLimit = 200
+
+	return &RepoPickerViewModel{input: ti}
+}
+
+func (m *RepoPickerViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *RepoPickerViewModel) Activate(choices []RepoChoice) tea.Cmd {
+	m.active = true
+	m.choices = choices
+	m.input.SetValue("")
+	m.filter()
+	return m.input.Focus()
+}
+
+func (m *RepoPickerViewModel) Deactivate() {
+	m.active = false
+	m.choices = nil
+	m.matches = nil
+	m.input.Blur()
+}
+
+func (m *RepoPickerViewModel) IsActive() bool {
+	return m.active
+}
+
+// Update passes keys other than the navigation keys to the input and
+// filters the repositories by it.
+func (m *RepoPickerViewModel) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.filter()
+	return cmd
+}
+
+func (m *RepoPickerViewModel) filter() {
+	text := strings.ToLower(strings.TrimSpace(m.input.Value()))
+	m.matches = m.matches[:0]
+	for _, choice := range m.choices {
+		if strings.Contains(strings.ToLower(choice.Repository), text) {
+			m.matches = append(m.matches, choice)
+		}
+	}
+	m.selectedIdx = 0
+}
+
+// Selected returns the highlighted repository, or the typed one when none
+// matches it.
+func (m *RepoPickerViewModel) Selected() (RepoChoice, bool) {
+	if m.selectedIdx < len(m.matches) {
+		return m.matches[m.selectedIdx], true
+	}
+	if text := strings.TrimSpace(m.input.Value()); text != "" {
+		return RepoChoice{Repository: text}, true
+	}
+	return RepoChoice{}, false
+}
+
+func (m *RepoPickerViewModel) Next() {
+	if m.selectedIdx < len(m.matches)-1 {
+		m.selectedIdx++
+	}
+}
+
+func (m *RepoPickerViewModel) Prev() {
+	if m.selectedIdx > 0 {
+		m.selectedIdx--
+	}
+}
+
+func (m *RepoPickerViewModel) View() string {
+	if !m.active {
+		return ""
+	}
+
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true).
+		Padding(1, 0)
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	b.WriteString(titleStyle.Render("Browse the open PRs of a repository"))
+	b.WriteString("\n\n")
+	b.WriteString(m.input.View())
+	b.WriteString("\n\n")
+
+	width := min(80, m.width-4) - 6
+	if len(m.matches) == 0 {
+		message := "No known repository matches"
+		if strings.TrimSpace(m.input.Value()) != "" {
+			message += "; Enter lists the typed one"
+		}
+		b.WriteString(mutedStyle.Render(message))
+		b.WriteString("\n")
+	}
+	from, to := m.visibleRange()
+	for i := from; i < to; i++ {
+		b.WriteString(m.renderChoice(m.matches[i], i == m.selectedIdx, width))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("↑↓: Navigate | Enter: List its open PRs | Esc: Close"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(min(80, m.width-4))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(b.String()))
+}
+
+// visibleRange returns the repositories that fit the terminal, keeping the
+// selected one in view.
+func (m *RepoPickerViewModel) visibleRange() (int, int) {
+	rows := max(m.height-16, 3)
+	if len(m.matches) <= rows {
+		return 0, len(m.matches)
+	}
+	from := max(m.selectedIdx-rows/2, 0)
+	to := min(from+rows, len(m.matches))
+	return to - rows, to
+}
+
+func (m *RepoPickerViewModel) renderChoice(choice RepoChoice, selected bool, width int) string {
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	marker := "  "
+	if selected {
+		marker = "► "
+		nameStyle = nameStyle.Foreground(lipgloss.Color("#7C3AED")).Bold(true)
+	}
+
+	provider := fmt.Sprintf("  %s", choice.Provider)
+	name := textwidth.Truncate(choice.Repository, max(width-len(provider)-2, 10))
+	return marker + nameStyle.Render(name) + mutedStyle.Render(provider)
+}
@@ -0,0 +1,115 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+// ResumeViewModel offers to restore the session saved on the last exit.
+type ResumeViewModel struct {
+	active  bool
+	width   int
+	height  int
+	session *domain.Session
+}
+
+func NewResumeView() *ResumeViewModel {
+	return &ResumeViewModel{}
+}
+
+func (m *ResumeViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *ResumeViewModel) Activate(session domain.Session) {
+	m.active = true
+	m.session = &session
+}
+
+func (m *ResumeViewModel) Deactivate() {
+	m.active = false
+	m.session = nil
+}
+
+func (m *ResumeViewModel) IsActive() bool {
+	return m.active
+}
+
+func (m *ResumeViewModel) GetSession() *domain.Session {
+	return m.session
+}
+
+func (m *ResumeViewModel) Update(msg tea.Msg) tea.Cmd {
+	return nil
+}
+
+func (m *ResumeViewModel) View() string {
+	if !m.active || m.session == nil {
+		return ""
+	}
+
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true).
+		Padding(1, 0)
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("15"))
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280"))
+
+	b.WriteString(titleStyle.Render("Resume last session?"))
+	b.WriteString("\n\n")
+
+	for _, line := range describeSession(*m.session) {
+		b.WriteString(infoStyle.Render(line))
+		b.WriteString("\n")
+	}
+	if !m.session.SavedAt.IsZero() {
+		b.WriteString(mutedStyle.Render("Saved " + formatAge(m.session.SavedAt)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	b.WriteString(helpStyle.Render("Enter/y: Resume | Esc/n: Start fresh"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(min(70, m.width-4))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(b.String()))
+}
+
+func describeSession(session domain.Session) []string {
+	var lines []string
+	if pr := session.PR; pr != nil && session.View == domain.SessionViewPRInspect {
+		line := fmt.Sprintf("%s#%d", pr.Repository, pr.Number)
+		if pr.Title != "" {
+			line += " " + pr.Title
+		}
+		lines = append(lines, line)
+		if pr.DiffMode {
+			lines = append(lines, fmt.Sprintf("Diff, file %d, line %d", pr.FileIndex+1, pr.LineIndex+1))
+		} else {
+			lines = append(lines, "Description")
+		}
+	} else {
+		lines = append(lines, "PR list")
+	}
+	if session.Filter != "" {
+		lines = append(lines, fmt.Sprintf("Filter: %q", session.Filter))
+	}
+	return lines
+}
@@ -0,0 +1,34 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestDescribeSession(t *testing.T) {
+	inspect := describeSession(domain.Session{
+		View:   domain.SessionViewPRInspect,
+		Filter: "api",
+		PR: &domain.SessionPR{
+			Repository: "acme/api",
+			Number:     42,
+			Title:      "Add retries",
+			DiffMode:   true,
+			FileIndex:  1,
+			LineIndex:  9,
+		},
+	})
+	got := strings.Join(inspect, "\n")
+	for _, want := range []string{"acme/api#42 Add retries", "Diff, file 2, line 10", `Filter: "api"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in %q", want, got)
+		}
+	}
+
+	list := describeSession(domain.Session{View: domain.SessionViewPRList, SelectedPR: "github:acme/api/42"})
+	if len(list) != 1 || list[0] != "PR list" {
+		t.Errorf("expected only the PR list line, got %v", list)
+	}
+}
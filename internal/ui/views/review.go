@@ -1,12 +1,15 @@
 package views
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/spell"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/textwidth"
 )
 
 type ReviewMode int
@@ -17,12 +20,54 @@ const (
 	ReviewModeRequestChanges
 )
 
+// reviewVerdicts are the verdicts offered when submitting, in the order
+// they are shown.
+var reviewVerdicts = []struct {
+	mode  ReviewMode
+	label string
+	color lipgloss.Color
+}{
+	{ReviewModeComment, "Comment", lipgloss.Color("15")},
+	{ReviewModeApprove, "Approve", lipgloss.Color("#10B981")},
+	{ReviewModeRequestChanges, "Request changes", lipgloss.Color("#EF4444")},
+}
+
+type checklistEntry struct {
+	item    domain.ChecklistItem
+	checked bool
+}
+
+// ReviewAccount is an account a review can be submitted as.
+type ReviewAccount struct {
+	PATID    string
+	Name     string
+	Username string
+}
+
 type ReviewViewModel struct {
 	mode     ReviewMode
 	textarea textarea.Model
 	width    int
 	height   int
 	active   bool
+
+	// The confirmation step shown before submitting.
+	confirming bool
+	pending    []domain.Comment
+	note       string
+	// accounts are the accounts offered to submit as, the first being the
+	// one the PR was loaded with; the choice is only shown when there are
+	// several.
+	accounts   []ReviewAccount
+	accountIdx int
+	// checklist is what to check before approving, with the highlighted
+	// item at checklistIdx.
+	checklist    []checklistEntry
+	checklistIdx int
+
+	mentions mentionCompleter
+	spelling spellChecker
+	history  editHistory
 }
 
 func NewReviewView() *ReviewViewModel {
@@ -50,14 +95,131 @@ func (m *ReviewViewModel) Activate(mode ReviewMode) {
 	m.mode = mode
 	m.textarea.Focus()
 	m.textarea.SetValue("")
+	m.confirming = false
+	m.mentions.close()
+	m.spelling.close()
+	m.spelling.refresh(&m.textarea)
+	m.history.reset(&m.textarea)
+}
+
+// ShowConfirmation replaces the editor with a summary of what is about to be
+// submitted, including the pending inline comments. note, if set, is shown as
+// a warning.
+func (m *ReviewViewModel) ShowConfirmation(pending []domain.Comment, note string) {
+	m.confirming = true
+	m.pending = pending
+	m.note = note
+	m.textarea.Blur()
+}
+
+// BackToEdit leaves the confirmation and returns to the editor with the body
+// intact.
+func (m *ReviewViewModel) BackToEdit() {
+	m.confirming = false
+	m.pending = nil
+	m.note = ""
+	m.textarea.Focus()
+}
+
+func (m *ReviewViewModel) IsConfirming() bool {
+	return m.confirming
+}
+
+// CycleVerdict selects the verdict delta places after the current one, so
+// that the verdict can still change after the body is written.
+func (m *ReviewViewModel) CycleVerdict(delta int) {
+	for i, verdict := range reviewVerdicts {
+		if verdict.mode == m.mode {
+			n := len(reviewVerdicts)
+			m.mode = reviewVerdicts[((i+delta)%n+n)%n].mode
+			return
+		}
+	}
+}
+
+// SetAccounts offers accounts to submit the review as, selecting the first.
+func (m *ReviewViewModel) SetAccounts(accounts []ReviewAccount) {
+	m.accounts = accounts
+	m.accountIdx = 0
+}
+
+// CycleAccount selects the account delta places after the current one.
+func (m *ReviewViewModel) CycleAccount(delta int) {
+	if n := len(m.accounts); n > 0 {
+		m.accountIdx = ((m.accountIdx+delta)%n + n) % n
+	}
+}
+
+// SelectedAccount returns the account to submit the review as, or false
+// when none was offered.
+func (m *ReviewViewModel) SelectedAccount() (ReviewAccount, bool) {
+	if m.accountIdx >= len(m.accounts) {
+		return ReviewAccount{}, false
+	}
+	return m.accounts[m.accountIdx], true
+}
+
+// SetChecklist sets the checklist shown in the confirmation. Items already
+// checked stay checked, so that going back to edit loses nothing.
+func (m *ReviewViewModel) SetChecklist(items []domain.ChecklistItem) {
+	checked := make(map[string]bool)
+	for _, entry := range m.checklist {
+		checked[entry.item.Text] = entry.checked
+	}
+	m.checklist = make([]checklistEntry, len(items))
+	for i, item := range items {
+		m.checklist[i] = checklistEntry{item: item, checked: checked[item.Text]}
+	}
+	m.checklistIdx = min(m.checklistIdx, max(len(items)-1, 0))
+}
+
+// MoveChecklist highlights the checklist item delta places away.
+func (m *ReviewViewModel) MoveChecklist(delta int) {
+	m.checklistIdx = min(max(m.checklistIdx+delta, 0), max(len(m.checklist)-1, 0))
+}
+
+// ToggleChecklistItem checks or unchecks the highlighted checklist item.
+func (m *ReviewViewModel) ToggleChecklistItem() {
+	if m.checklistIdx < len(m.checklist) {
+		m.checklist[m.checklistIdx].checked = !m.checklist[m.checklistIdx].checked
+	}
+}
+
+// UncheckedRequired counts the required checklist items not checked yet.
+func (m *ReviewViewModel) UncheckedRequired() int {
+	unchecked := 0
+	for _, entry := range m.checklist {
+		if entry.item.Required && !entry.checked {
+			unchecked++
+		}
+	}
+	return unchecked
+}
+
+// SetNote replaces the warning shown in the confirmation.
+func (m *ReviewViewModel) SetNote(note string) {
+	m.note = note
 }
 
 func (m *ReviewViewModel) Deactivate() {
 	m.active = false
+	m.mentions.close()
+	m.spelling.close()
+	m.confirming = false
+	m.pending = nil
+	m.note = ""
+	m.accounts = nil
+	m.accountIdx = 0
+	m.checklist = nil
+	m.checklistIdx = 0
 	m.textarea.Blur()
 	m.textarea.SetValue("")
 }
 
+func (m *ReviewViewModel) GetMode() ReviewMode {
+	return m.mode
+}
+
 func (m *ReviewViewModel) IsActive() bool {
 	return m.active
 }
@@ -68,6 +230,8 @@ func (m *ReviewViewModel) GetValue() string {
 
 func (m *ReviewViewModel) SetValue(value string) {
 	m.textarea.SetValue(value)
+	m.spelling.refresh(&m.textarea)
+	m.history.record(&m.textarea, nil)
 }
 
 func (m *ReviewViewModel) GetReview() domain.Review {
@@ -88,10 +252,46 @@ func (m *ReviewViewModel) GetReview() domain.Review {
 
 func (m *ReviewViewModel) Update(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
-	m.textarea, cmd = m.textarea.Update(msg)
+	if !m.history.handleKey(msg, &m.textarea) {
+		m.textarea, cmd = m.textarea.Update(msg)
+		m.history.record(&m.textarea, msg)
+	}
+	m.mentions.refresh(&m.textarea)
+	m.spelling.refresh(&m.textarea)
 	return cmd
 }
 
+// SetMentionCandidates sets the users suggested after typing "@".
+func (m *ReviewViewModel) SetMentionCandidates(candidates []domain.MentionCandidate) {
+	m.mentions.setCandidates(candidates)
+}
+
+// HandleMentionKey lets the open mention suggestions handle key, reporting
+// whether they did.
+func (m *ReviewViewModel) HandleMentionKey(msg tea.KeyMsg) bool {
+	if !m.mentions.handleKey(msg, &m.textarea) {
+		return false
+	}
+	m.history.record(&m.textarea, nil)
+	return true
+}
+
+// SetDictionary turns on spell-checking with dict, or turns it off when dict
+// is nil.
+func (m *ReviewViewModel) SetDictionary(dict *spell.Dictionary) {
+	m.spelling.setDictionary(dict, &m.textarea)
+}
+
+// HandleSpellKey lets the spell-checker handle key, reporting whether it did
+// and the word the user added to the dictionary, if any.
+func (m *ReviewViewModel) HandleSpellKey(msg tea.KeyMsg) (bool, string) {
+	handled, word := m.spelling.handleKey(msg, &m.textarea)
+	if handled {
+		m.history.record(&m.textarea, nil)
+	}
+	return handled, word
+}
+
 func (m *ReviewViewModel) View() string {
 	if !m.active {
 		return ""
@@ -114,16 +314,144 @@ func (m *ReviewViewModel) View() string {
 		Bold(true).
 		Padding(1, 0)
 
+	if m.confirming {
+		return m.renderConfirmation(titleStyle.Render("Submit: " + title))
+	}
+
 	b.WriteString(titleStyle.Render(title))
 	b.WriteString("\n\n")
-	b.WriteString(m.textarea.View())
-	b.WriteString("\n\n")
+	b.WriteString(m.spelling.underline(m.textarea.View()))
+	b.WriteString("\n")
+	if suggestions := m.mentions.view(); suggestions != "" {
+		b.WriteString(suggestions)
+		b.WriteString("\n")
+	}
+	if spelling := m.spelling.view(); spelling != "" {
+		b.WriteString(spelling)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	helpStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#6B7280")).
 		Italic(true)
 
-	help := "Ctrl+S: Submit | Ctrl+G: Open in editor | Esc: Cancel"
+	help := "Ctrl+S: Choose verdict & submit | Ctrl+G: Open in editor | Esc: Cancel | Ctrl+Z/Y: Undo/Redo"
+	b.WriteString(helpStyle.Render(help))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(m.width - 4)
+
+	return boxStyle.Render(b.String())
+}
+
+func (m *ReviewViewModel) renderConfirmation(title string) string {
+	var b strings.Builder
+
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280")).Italic(true)
+	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Bold(true)
+
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	b.WriteString(labelStyle.Render("Verdict: "))
+	for i, verdict := range reviewVerdicts {
+		if i > 0 {
+			b.WriteString("  ")
+		}
+		if verdict.mode == m.mode {
+			b.WriteString(valueStyle.Bold(true).Foreground(verdict.color).Render("(•) " + verdict.label))
+		} else {
+			b.WriteString(mutedStyle.Render("( ) " + verdict.label))
+		}
+	}
+	b.WriteString("\n")
+	if len(m.accounts) > 1 {
+		b.WriteString(labelStyle.Render("Submit as: "))
+		for i, account := range m.accounts {
+			if i > 0 {
+				b.WriteString("  ")
+			}
+			label := account.Name
+			if account.Username != "" {
+				label += " (" + account.Username + ")"
+			}
+			if i == m.accountIdx {
+				b.WriteString(valueStyle.Bold(true).Render("(•) " + label))
+			} else {
+				b.WriteString(mutedStyle.Render("( ) " + label))
+			}
+		}
+		b.WriteString("\n")
+	}
+	if m.note != "" {
+		b.WriteString(warningStyle.Render("⚠ " + m.note))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if len(m.checklist) > 0 {
+		b.WriteString(labelStyle.Render("Checklist:"))
+		b.WriteString("\n")
+		for i, entry := range m.checklist {
+			marker := "  "
+			if i == m.checklistIdx {
+				marker = "► "
+			}
+			box := "[ ] "
+			style := valueStyle
+			if entry.checked {
+				box = "[x] "
+				style = mutedStyle
+			}
+			b.WriteString(marker + style.Render(box+entry.item.Text))
+			if entry.item.Required {
+				b.WriteString(labelStyle.Render(" (required)"))
+			}
+			b.WriteString("\n")
+		}
+		if unchecked := m.UncheckedRequired(); unchecked > 0 && m.mode == ReviewModeApprove {
+			b.WriteString(warningStyle.Render(fmt.Sprintf("⚠ Check the %d required item(s) to approve", unchecked)))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(labelStyle.Render("Body:"))
+	b.WriteString("\n")
+	if body := strings.TrimSpace(m.textarea.Value()); body != "" {
+		b.WriteString(valueStyle.Render(body))
+	} else {
+		b.WriteString(mutedStyle.Render("(empty)"))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(labelStyle.Render(fmt.Sprintf("%d pending inline comment(s) will be attached", len(m.pending))))
+	b.WriteString("\n")
+	for _, comment := range m.pending {
+		firstLine, _, _ := strings.Cut(strings.TrimSpace(comment.Body), "\n")
+		location := fmt.Sprintf("  %s:%d  ", comment.FilePath, comment.Line)
+		b.WriteString(mutedStyle.Render(location))
+		b.WriteString(valueStyle.Render(textwidth.Truncate(firstLine, max(m.width-lipgloss.Width(location)-12, 10))))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+	help := "←/→: Change verdict"
+	if len(m.accounts) > 1 {
+		help += " | a: Change account"
+	}
+	if len(m.checklist) > 0 {
+		help += " | ↑↓: Select item | Space: Check"
+	}
+	help += " | Enter/y: Submit | e/Esc: Back to edit"
 	b.WriteString(helpStyle.Render(help))
 
 	boxStyle := lipgloss.NewStyle().
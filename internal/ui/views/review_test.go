@@ -135,3 +135,33 @@ func TestReviewView_ViewShowsCorrectTitle(t *testing.T) {
 		}
 	}
 }
+
+func TestReviewView_CycleVerdictChangesTheSubmittedAction(t *testing.T) {
+	view := NewReviewView()
+	view.SetSize(100, 30)
+	view.Activate(ReviewModeComment)
+	view.SetValue("Looks good once the typo is fixed")
+	view.ShowConfirmation(nil, "")
+
+	if out := view.View(); !strings.Contains(out, "(•) Comment") || !strings.Contains(out, "( ) Approve") {
+		t.Fatalf("expected the verdicts with Comment selected, got:\n%s", out)
+	}
+
+	view.CycleVerdict(1)
+	if view.GetReview().Action != domain.ReviewActionApprove || !strings.Contains(view.View(), "(•) Approve") {
+		t.Errorf("expected Approve after moving right, got %v", view.GetReview().Action)
+	}
+	if view.GetReview().Body != "Looks good once the typo is fixed" {
+		t.Error("expected the body to be kept when the verdict changes")
+	}
+
+	view.CycleVerdict(1)
+	view.CycleVerdict(1)
+	if view.GetMode() != ReviewModeComment {
+		t.Errorf("expected the verdicts to wrap around, got %v", view.GetMode())
+	}
+	view.CycleVerdict(-1)
+	if view.GetMode() != ReviewModeRequestChanges {
+		t.Errorf("expected moving left from Comment to wrap to Request changes, got %v", view.GetMode())
+	}
+}
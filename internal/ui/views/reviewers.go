@@ -0,0 +1,303 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/textwidth"
+)
+
+const defaultDismissMessage = "Dismissed, please review the latest changes"
+
+// ReviewersViewModel lists the reviewers of the open PR and lets the user
+// dismiss a review or request a new one.
+type ReviewersViewModel struct {
+	active      bool
+	loading     bool
+	err         error
+	width       int
+	height      int
+	selectedIdx int
+	reviewers   []domain.Reviewer
+	pr          *domain.PullRequest
+
+	dismissing   bool
+	messageInput textinput.Model
+}
+
+func NewReviewersView() *ReviewersViewModel {
+	ti := textinput.New()
+	ti.Placeholder = "Reason for dismissing"
+	ti.CharLimit = 500
+
+	return &ReviewersViewModel{
+		messageInput: ti,
+	}
+}
+
+func (m *ReviewersViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.messageInput.Width = min(60, width-12)
+}
+
+// Activate opens the panel for pr while its reviewers are loaded.
+func (m *ReviewersViewModel) Activate(pr *domain.PullRequest) {
+	m.active = true
+	m.loading = true
+	m.err = nil
+	m.pr = pr
+	m.reviewers = nil
+	m.selectedIdx = 0
+	m.CancelDismiss()
+}
+
+func (m *ReviewersViewModel) Deactivate() {
+	m.active = false
+	m.pr = nil
+	m.reviewers = nil
+	m.CancelDismiss()
+}
+
+func (m *ReviewersViewModel) IsActive() bool {
+	return m.active
+}
+
+func (m *ReviewersViewModel) GetPR() *domain.PullRequest {
+	return m.pr
+}
+
+func (m *ReviewersViewModel) SetReviewers(reviewers []domain.Reviewer, err error) {
+	m.loading = false
+	m.reviewers = reviewers
+	m.err = err
+	if m.selectedIdx >= len(reviewers) {
+		m.selectedIdx = max(len(reviewers)-1, 0)
+	}
+}
+
+func (m *ReviewersViewModel) GetSelectedReviewer() *domain.Reviewer {
+	if m.selectedIdx < 0 || m.selectedIdx >= len(m.reviewers) {
+		return nil
+	}
+	return &m.reviewers[m.selectedIdx]
+}
+
+func (m *ReviewersViewModel) Next() {
+	if m.selectedIdx < len(m.reviewers)-1 {
+		m.selectedIdx++
+	}
+}
+
+func (m *ReviewersViewModel) Prev() {
+	if m.selectedIdx > 0 {
+		m.selectedIdx--
+	}
+}
+
+// StartDismiss asks for the dismissal message of the selected review.
+func (m *ReviewersViewModel) StartDismiss() {
+	m.dismissing = true
+	m.messageInput.SetValue(defaultDismissMessage)
+	m.messageInput.CursorEnd()
+	m.messageInput.Focus()
+}
+
+func (m *ReviewersViewModel) CancelDismiss() {
+	m.dismissing = false
+	m.messageInput.Blur()
+	m.messageInput.SetValue("")
+}
+
+func (m *ReviewersViewModel) IsDismissing() bool {
+	return m.dismissing
+}
+
+func (m *ReviewersViewModel) DismissMessage() string {
+	return strings.TrimSpace(m.messageInput.Value())
+}
+
+func (m *ReviewersViewModel) Update(msg tea.Msg) tea.Cmd {
+	if !m.dismissing {
+		return nil
+	}
+	var cmd tea.Cmd
+	m.messageInput, cmd = m.messageInput.Update(msg)
+	return cmd
+}
+
+func (m *ReviewersViewModel) View() string {
+	if !m.active || m.pr == nil {
+		return ""
+	}
+
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true).
+		Padding(1, 0)
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Reviewers of #%d", m.pr.Number)))
+	b.WriteString("\n\n")
+
+	switch {
+	case m.loading:
+		b.WriteString(mutedStyle.Render("Loading reviewers..."))
+		b.WriteString("\n")
+	case m.err != nil:
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render(m.err.Error()))
+		b.WriteString("\n")
+	case len(m.reviewers) == 0:
+		b.WriteString(mutedStyle.Render("No reviewers"))
+		b.WriteString("\n")
+	default:
+		for i, reviewer := range m.reviewers {
+			b.WriteString(m.renderReviewer(reviewer, i == m.selectedIdx))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+
+	if m.dismissing {
+		b.WriteString(m.messageInput.View())
+		b.WriteString("\n\n")
+		b.WriteString(mutedStyle.Render("Enter: Dismiss review | Esc: Cancel"))
+	} else {
+		b.WriteString(mutedStyle.Render("↑↓: Navigate | d: Dismiss review | r: Re-request review | Esc: Close"))
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(min(72, m.width-4))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(b.String()))
+}
+
+func (m *ReviewersViewModel) renderReviewer(reviewer domain.Reviewer, selected bool) string {
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	marker := "  "
+	if selected {
+		marker = "► "
+		nameStyle = nameStyle.Foreground(lipgloss.Color("#7C3AED")).Bold(true)
+	}
+
+	line := marker + nameStyle.Render(textwidth.Pad(textwidth.Truncate(reviewer.User.Username, 20), 20)) + " " + reviewerStateLabel(reviewer.State)
+	if reviewer.Stale {
+		line += lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Render("  (stale)")
+	}
+	if !reviewer.SubmittedAt.IsZero() {
+		line += lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280")).Render("  " + reviewer.SubmittedAt.Local().Format("Jan 2 15:04"))
+	}
+	return line
+}
+
+func reviewerStateLabel(state domain.ReviewerState) string {
+	switch state {
+	case domain.ReviewerApproved:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Render("✓ approved")
+	case domain.ReviewerChangesRequested:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Render("✗ changes requested")
+	case domain.ReviewerCommented:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#60A5FA")).Render("💬 commented")
+	case domain.ReviewerDismissed:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280")).Render("– dismissed")
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Render("… pending")
+}
+
+// renderReviewerStatus lists the reviewers of a PR for the description view,
+// ending with a note when me is the only reviewer left to approve.
+func renderReviewerStatus(reviewers []domain.Reviewer, me string) string {
+	if len(reviewers) == 0 {
+		return ""
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Bold(true)
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	approved := 0
+	for _, reviewer := range reviewers {
+		if reviewer.State == domain.ReviewerApproved {
+			approved++
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Reviewers (%d/%d approved)", approved, len(reviewers))))
+	b.WriteString("\n")
+
+	for _, reviewer := range reviewers {
+		line := "  " + reviewerStateLabel(reviewer.State) + " " + nameStyle.Render(reviewer.User.Username)
+		var details []string
+		if reviewer.Vote != 0 {
+			details = append(details, fmt.Sprintf("vote %+d", reviewer.Vote))
+		}
+		if reviewer.Required {
+			details = append(details, "required")
+		}
+		if reviewer.Stale {
+			details = append(details, "stale")
+		}
+		if strings.EqualFold(reviewer.User.Username, me) {
+			details = append(details, "you")
+		}
+		if len(details) > 0 {
+			line += mutedStyle.Render(" (" + strings.Join(details, ", ") + ")")
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	if isLastApprovalNeeded(reviewers, me) {
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Bold(true).
+			Render("  Your approval is the last one outstanding"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// isLastApprovalNeeded reports whether me is a reviewer who has not approved
+// while every other reviewer that counts has. When some reviewers are
+// required only those count, otherwise everyone does.
+func isLastApprovalNeeded(reviewers []domain.Reviewer, me string) bool {
+	if me == "" {
+		return false
+	}
+
+	anyRequired := false
+	for _, reviewer := range reviewers {
+		if reviewer.Required {
+			anyRequired = true
+			break
+		}
+	}
+
+	found := false
+	for _, reviewer := range reviewers {
+		if strings.EqualFold(reviewer.User.Username, me) {
+			if reviewer.State == domain.ReviewerApproved {
+				return false
+			}
+			found = true
+			continue
+		}
+		if anyRequired && !reviewer.Required {
+			continue
+		}
+		if reviewer.State != domain.ReviewerApproved {
+			return false
+		}
+	}
+	return found
+}
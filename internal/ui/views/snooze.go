@@ -0,0 +1,164 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+type SnoozeViewModel struct {
+	active      bool
+	width       int
+	height      int
+	selectedIdx int
+	options     []SnoozeOption
+	pr          *domain.PullRequest
+}
+
+// SnoozeOption computes the snooze for a PR relative to the time it is
+// confirmed.
+type SnoozeOption struct {
+	label  string
+	snooze func(pr domain.PullRequest, now time.Time) domain.PRSnooze
+}
+
+func NewSnoozeView() *SnoozeViewModel {
+	return &SnoozeViewModel{
+		options: defaultSnoozeOptions(),
+	}
+}
+
+func defaultSnoozeOptions() []SnoozeOption {
+	after := func(d time.Duration) func(domain.PullRequest, time.Time) domain.PRSnooze {
+		return func(pr domain.PullRequest, now time.Time) domain.PRSnooze {
+			return domain.PRSnooze{PRIdentifier: pr.Key(), Until: now.Add(d)}
+		}
+	}
+
+	return []SnoozeOption{
+		{label: "1 hour", snooze: after(time.Hour)},
+		{label: "4 hours", snooze: after(4 * time.Hour)},
+		{
+			label: "Until tomorrow morning",
+			snooze: func(pr domain.PullRequest, now time.Time) domain.PRSnooze {
+				next := now.AddDate(0, 0, 1)
+				morning := time.Date(next.Year(), next.Month(), next.Day(), 9, 0, 0, 0, now.Location())
+				return domain.PRSnooze{PRIdentifier: pr.Key(), Until: morning}
+			},
+		},
+		{label: "1 week", snooze: after(7 * 24 * time.Hour)},
+		{
+			label: "Until the PR is updated",
+			snooze: func(pr domain.PullRequest, now time.Time) domain.PRSnooze {
+				return domain.PRSnooze{PRIdentifier: pr.Key(), UntilUpdated: true, PRUpdatedAt: pr.UpdatedAt}
+			},
+		},
+	}
+}
+
+func (m *SnoozeViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *SnoozeViewModel) Activate(pr *domain.PullRequest) {
+	m.active = true
+	m.pr = pr
+	m.selectedIdx = 0
+}
+
+func (m *SnoozeViewModel) Deactivate() {
+	m.active = false
+	m.pr = nil
+	m.selectedIdx = 0
+}
+
+func (m *SnoozeViewModel) IsActive() bool {
+	return m.active
+}
+
+func (m *SnoozeViewModel) GetPR() *domain.PullRequest {
+	return m.pr
+}
+
+// GetSnooze returns the snooze for the selected option.
+func (m *SnoozeViewModel) GetSnooze(now time.Time) (domain.PRSnooze, bool) {
+	if m.pr == nil || m.selectedIdx < 0 || m.selectedIdx >= len(m.options) {
+		return domain.PRSnooze{}, false
+	}
+	snooze := m.options[m.selectedIdx].snooze(*m.pr, now)
+	snooze.CreatedAt = now
+	return snooze, true
+}
+
+func (m *SnoozeViewModel) NextOption() {
+	if m.selectedIdx < len(m.options)-1 {
+		m.selectedIdx++
+	}
+}
+
+func (m *SnoozeViewModel) PrevOption() {
+	if m.selectedIdx > 0 {
+		m.selectedIdx--
+	}
+}
+
+func (m *SnoozeViewModel) Update(msg tea.Msg) tea.Cmd {
+	return nil
+}
+
+func (m *SnoozeViewModel) View() string {
+	if !m.active || m.pr == nil {
+		return ""
+	}
+
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true).
+		Padding(1, 0)
+
+	b.WriteString(titleStyle.Render("Snooze Pull Request"))
+	b.WriteString("\n\n")
+
+	prInfoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("15"))
+
+	b.WriteString(prInfoStyle.Render(fmt.Sprintf("#%d %s", m.pr.Number, m.pr.Title)))
+	b.WriteString("\n\n")
+
+	for i, option := range m.options {
+		marker := "○"
+		optionStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("15"))
+		if i == m.selectedIdx {
+			marker = "●"
+			optionStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#7C3AED")).
+				Bold(true)
+		}
+
+		b.WriteString(optionStyle.Render(fmt.Sprintf(" %s %s", marker, option.label)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	b.WriteString(helpStyle.Render("↑↓: Navigate | Enter: Snooze | Esc: Cancel"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(min(60, m.width-4))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(b.String()))
+}
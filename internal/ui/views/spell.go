@@ -0,0 +1,252 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/spell"
+)
+
+const maxSpellSuggestions = 5
+
+// spellChecker underlines misspelled words in a textarea and offers
+// corrections for one of them in a popup. It does nothing until a
+// dictionary is set.
+type spellChecker struct {
+	dict         *spell.Dictionary
+	misspellings []spell.Misspelling
+	misspelled   map[string]bool
+
+	open        bool
+	target      spell.Misspelling
+	suggestions []string
+	cursor      int
+	note        string
+}
+
+func (c *spellChecker) setDictionary(dict *spell.Dictionary, ta *textarea.Model) {
+	c.dict = dict
+	c.close()
+	c.refresh(ta)
+}
+
+func (c *spellChecker) close() {
+	c.open = false
+	c.suggestions = nil
+	c.cursor = 0
+	c.note = ""
+}
+
+// refresh checks the text again after an edit.
+func (c *spellChecker) refresh(ta *textarea.Model) {
+	c.misspellings = nil
+	c.misspelled = nil
+	if c.dict == nil {
+		return
+	}
+	c.misspellings = c.dict.Check(ta.Value())
+	c.misspelled = make(map[string]bool, len(c.misspellings))
+	for _, misspelling := range c.misspellings {
+		c.misspelled[misspelling.Word] = true
+	}
+}
+
+// handleKey opens the corrections popup on Ctrl+L and, while it is open,
+// moves through and applies its entries. It reports whether the key was
+// used. The returned word, if any, was added to the dictionary.
+func (c *spellChecker) handleKey(msg tea.KeyMsg, ta *textarea.Model) (bool, string) {
+	if c.dict == nil {
+		return false, ""
+	}
+	c.note = ""
+	if !c.open {
+		if msg.String() != "ctrl+l" {
+			return false, ""
+		}
+		c.openAtCursor(ta)
+		return true, ""
+	}
+
+	switch msg.String() {
+	case "up", "ctrl+p":
+		if c.cursor > 0 {
+			c.cursor--
+		}
+	case "down", "ctrl+n":
+		if c.cursor < len(c.suggestions) {
+			c.cursor++
+		}
+	case "tab", "enter":
+		if c.cursor == len(c.suggestions) {
+			word := c.target.Word
+			c.dict.Add(word)
+			c.close()
+			c.refresh(ta)
+			return true, word
+		}
+		c.replace(ta, c.suggestions[c.cursor])
+		c.close()
+		c.refresh(ta)
+	case "esc", "ctrl+l":
+		c.close()
+	default:
+		// Typing goes on editing the text.
+		c.close()
+		return false, ""
+	}
+	return true, ""
+}
+
+// openAtCursor opens the popup on the misspelled word under or closest
+// before the cursor, or on the first one when the cursor is before all of
+// them.
+func (c *spellChecker) openAtCursor(ta *textarea.Model) {
+	if len(c.misspellings) == 0 {
+		c.note = "No misspelled words"
+		return
+	}
+
+	info := ta.LineInfo()
+	line, col := ta.Line(), info.StartColumn+info.ColumnOffset
+	c.target = c.misspellings[0]
+	for _, misspelling := range c.misspellings {
+		if misspelling.Line > line || (misspelling.Line == line && misspelling.Column > col) {
+			break
+		}
+		c.target = misspelling
+	}
+	c.suggestions = c.dict.Suggest(c.target.Word, maxSpellSuggestions)
+	c.cursor = 0
+	c.open = true
+}
+
+// replace swaps the target word for replacement, leaving the cursor after
+// it.
+func (c *spellChecker) replace(ta *textarea.Model, replacement string) {
+	// CursorUp and CursorDown move by wrapped rows, so step until the
+	// logical line is reached. The bound guards against a line that no
+	// longer exists.
+	for steps := 0; ta.Line() != c.target.Line && steps < ta.Length(); steps++ {
+		if ta.Line() > c.target.Line {
+			ta.CursorUp()
+		} else {
+			ta.CursorDown()
+		}
+	}
+	if ta.Line() != c.target.Line {
+		return
+	}
+
+	ta.SetCursor(c.target.Column + len([]rune(c.target.Word)))
+	for range len([]rune(c.target.Word)) {
+		*ta, _ = ta.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	ta.InsertString(replacement)
+}
+
+// underline marks the misspelled words in the rendered textarea. ANSI escape
+// sequences are copied untouched, so the styles of the textarea are kept.
+func (c *spellChecker) underline(rendered string) string {
+	if len(c.misspelled) == 0 {
+		return rendered
+	}
+
+	var b strings.Builder
+	runes := []rune(rendered)
+	flush := func(word []rune) {
+		text := string(word)
+		core := strings.TrimFunc(text, isSpellApostrophe)
+		if !c.misspelled[core] {
+			b.WriteString(text)
+			return
+		}
+		start := strings.Index(text, core)
+		b.WriteString(text[:start] + "\x1b[4m" + core + "\x1b[24m" + text[start+len(core):])
+	}
+
+	var word []rune
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\x1b':
+			flush(word)
+			word = word[:0]
+			end := i + 1
+			if end < len(runes) && runes[end] == '[' {
+				end++
+				for end < len(runes) && (runes[end] < 0x40 || runes[end] > 0x7e) {
+					end++
+				}
+			}
+			end = min(end, len(runes)-1)
+			b.WriteString(string(runes[i : end+1]))
+			i = end
+		case unicode.IsLetter(r) || isSpellApostrophe(r):
+			word = append(word, r)
+		default:
+			flush(word)
+			word = word[:0]
+			b.WriteRune(r)
+		}
+	}
+	flush(word)
+	return b.String()
+}
+
+func isSpellApostrophe(r rune) bool {
+	return r == '\'' || r == '’'
+}
+
+// status returns the summary shown under the textarea.
+func (c *spellChecker) status() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280")).Italic(true)
+	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+	switch {
+	case c.dict == nil:
+		return ""
+	case c.note != "":
+		return mutedStyle.Render(c.note)
+	case len(c.misspellings) > 0:
+		return warningStyle.Render(fmt.Sprintf("%d possible misspelling(s) - Ctrl+L: Suggestions", len(c.misspellings)))
+	}
+	return ""
+}
+
+func (c *spellChecker) view() string {
+	if !c.open {
+		return c.status()
+	}
+
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED")).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	lines := []string{mutedStyle.Render(fmt.Sprintf("%q:", c.target.Word))}
+	if len(c.suggestions) == 0 {
+		lines = append(lines, mutedStyle.Italic(true).Render("  No suggestions"))
+	}
+	entries := append(append([]string(nil), c.suggestions...), fmt.Sprintf("Add %q to dictionary", c.target.Word))
+	for i, entry := range entries {
+		marker := "  "
+		style := nameStyle
+		if i == len(c.suggestions) {
+			style = mutedStyle
+		}
+		if i == c.cursor {
+			marker = "► "
+			style = selectedStyle
+		}
+		lines = append(lines, marker+style.Render(entry))
+	}
+	lines = append(lines, mutedStyle.Italic(true).Render("↑↓: Select | Tab/Enter: Apply | Esc: Close"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("#374151")).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
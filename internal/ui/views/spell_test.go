@@ -0,0 +1,106 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/johanforsgren/lgtmfaster/internal/spell"
+)
+
+func spellTestEditor() *ReviewViewModel {
+	view := NewReviewView()
+	view.SetSize(80, 30)
+	view.Activate(ReviewModeComment)
+	view.SetDictionary(spell.New("the", "this", "looks", "good", "fix", "test", "please"))
+	return view
+}
+
+func TestSpellChecker_ReplacesWordBeforeCursor(t *testing.T) {
+	view := spellTestEditor()
+	view.SetValue("Pleese fix the tset\nthis looks good")
+
+	if !strings.Contains(view.View(), "2 possible misspelling(s)") {
+		t.Fatalf("expected the misspellings to be counted, got:\n%s", view.View())
+	}
+
+	// The cursor is at the end of the second line, so the last misspelling
+	// before it, on the first line, is offered.
+	if handled, _ := view.HandleSpellKey(tea.KeyMsg{Type: tea.KeyCtrlL}); !handled {
+		t.Fatal("expected Ctrl+L to open the suggestions")
+	}
+	if !strings.Contains(view.View(), `"tset":`) {
+		t.Fatalf("expected suggestions for tset, got:\n%s", view.View())
+	}
+	if handled, word := view.HandleSpellKey(tea.KeyMsg{Type: tea.KeyEnter}); !handled || word != "" {
+		t.Fatalf("HandleSpellKey(enter) = %v, %q", handled, word)
+	}
+	if got := view.GetValue(); got != "Pleese fix the test\nthis looks good" {
+		t.Errorf("expected tset to be corrected, got %q", got)
+	}
+	if !strings.Contains(view.View(), "1 possible misspelling(s)") {
+		t.Errorf("expected one misspelling left, got:\n%s", view.View())
+	}
+
+	view.HandleSpellKey(tea.KeyMsg{Type: tea.KeyCtrlL})
+	view.HandleSpellKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := view.GetValue(); got != "Please fix the test\nthis looks good" {
+		t.Errorf("expected the capitalized correction, got %q", got)
+	}
+}
+
+func TestSpellChecker_AddToDictionary(t *testing.T) {
+	view := spellTestEditor()
+	view.SetValue("looks gud")
+
+	view.HandleSpellKey(tea.KeyMsg{Type: tea.KeyCtrlL})
+	for range maxSpellSuggestions + 1 {
+		view.HandleSpellKey(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	handled, word := view.HandleSpellKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if !handled || word != "gud" {
+		t.Fatalf("HandleSpellKey(enter) = %v, %q, want the added word", handled, word)
+	}
+	if got := view.GetValue(); got != "looks gud" {
+		t.Errorf("expected the text to be unchanged, got %q", got)
+	}
+	if strings.Contains(view.View(), "misspelling") {
+		t.Error("expected no misspellings after adding the word")
+	}
+}
+
+func TestSpellChecker_OffWithoutDictionary(t *testing.T) {
+	view := spellTestEditor()
+	view.SetDictionary(nil)
+	view.SetValue("tset")
+
+	if handled, _ := view.HandleSpellKey(tea.KeyMsg{Type: tea.KeyCtrlL}); handled {
+		t.Error("expected Ctrl+L to be ignored without a dictionary")
+	}
+	if strings.Contains(view.View(), "misspelling") {
+		t.Error("expected no spell-check without a dictionary")
+	}
+}
+
+func TestSpellChecker_TypingClosesPopup(t *testing.T) {
+	view := spellTestEditor()
+	view.SetValue("tset")
+
+	view.HandleSpellKey(tea.KeyMsg{Type: tea.KeyCtrlL})
+	if handled, _ := view.HandleSpellKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}}); handled {
+		t.Error("expected typed characters to reach the textarea")
+	}
+	if strings.Contains(view.View(), "to dictionary") {
+		t.Error("expected the popup to close when typing")
+	}
+}
+
+func TestSpellChecker_Underline(t *testing.T) {
+	c := spellChecker{misspelled: map[string]bool{"teh": true}}
+
+	got := c.underline("\x1b[38;5;15mteh 'teh' tehx\x1b[0m")
+	want := "\x1b[38;5;15m\x1b[4mteh\x1b[24m '\x1b[4mteh\x1b[24m' tehx\x1b[0m"
+	if got != want {
+		t.Errorf("underline() = %q, want %q", got, want)
+	}
+}
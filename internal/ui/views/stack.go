@@ -0,0 +1,78 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+// SetStack sets the stack of related PRs shown in the description view,
+// bottom first, or nil when the PR is not part of one.
+func (m *PRInspectViewModel) SetStack(stack []domain.StackMember) {
+	m.stack = stack
+	m.updateViewport()
+}
+
+// stackIndex returns the position of the PR in its stack, or -1 when the
+// stack belongs to another PR or there is none.
+func (m *PRInspectViewModel) stackIndex() int {
+	if m.pr == nil {
+		return -1
+	}
+	for i, member := range m.stack {
+		if member.PR.Key() == m.pr.Key() {
+			return i
+		}
+	}
+	return -1
+}
+
+// StackNeighbor returns the PR delta positions up (positive) or down
+// (negative) the stack from the PR, and false when there is none.
+func (m *PRInspectViewModel) StackNeighbor(delta int) (domain.PullRequest, bool) {
+	index := m.stackIndex()
+	if index < 0 || index+delta < 0 || index+delta >= len(m.stack) {
+		return domain.PullRequest{}, false
+	}
+	return m.stack[index+delta].PR, true
+}
+
+// renderStack lists the PRs of the stack, marking the one being viewed.
+func (m *PRInspectViewModel) renderStack() string {
+	current := m.stackIndex()
+	if current < 0 {
+		return ""
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Bold(true)
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Stack (%d of %d)", current+1, len(m.stack))))
+	b.WriteString("\n")
+
+	for i, member := range m.stack {
+		marker := "  "
+		style := nameStyle
+		if i == current {
+			marker = "► "
+			style = style.Foreground(lipgloss.Color("#7C3AED")).Bold(true)
+		}
+		line := marker + mutedStyle.Render(fmt.Sprintf("#%d ", member.PR.Number))
+		if !member.Listed {
+			line += mutedStyle.Render("not in your PR list")
+		} else {
+			line += style.Render(member.PR.Title)
+			line += mutedStyle.Render(fmt.Sprintf(" (%s → %s)", member.PR.SourceBranch, member.PR.TargetBranch))
+			if member.PR.Status != "" && member.PR.Status != domain.PRStatusOpen {
+				line += mutedStyle.Render(" " + string(member.PR.Status))
+			}
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
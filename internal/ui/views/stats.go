@@ -0,0 +1,278 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+const (
+	statsDailyPeriods  = 7
+	statsWeeklyPeriods = 4
+)
+
+type activityCounts struct {
+	Reviews      int
+	Comments     int
+	Merged       int
+	TimeInReview time.Duration
+	timedReviews int
+	// TimeSpent is the time the review timer recorded on PRs.
+	TimeSpent time.Duration
+}
+
+func (c activityCounts) AverageTimeInReview() time.Duration {
+	if c.timedReviews == 0 {
+		return 0
+	}
+	return c.TimeInReview / time.Duration(c.timedReviews)
+}
+
+type statsPeriod struct {
+	label  string
+	from   time.Time
+	to     time.Time
+	counts map[domain.ProviderType]*activityCounts
+}
+
+type StatsViewModel struct {
+	viewport viewport.Model
+	width    int
+	height   int
+	active   bool
+	daily    []statsPeriod
+	weekly   []statsPeriod
+	total    int
+}
+
+func NewStatsView() *StatsViewModel {
+	return &StatsViewModel{
+		viewport: viewport.New(0, 0),
+	}
+}
+
+func (m *StatsViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.viewport.Width = width
+	m.viewport.Height = height - 10
+	if m.active {
+		m.updateViewport()
+	}
+}
+
+func (m *StatsViewModel) Activate(events []domain.ActivityEvent, now time.Time) {
+	m.active = true
+	m.total = len(events)
+	m.daily = buildDailyPeriods(events, now, statsDailyPeriods)
+	m.weekly = buildWeeklyPeriods(events, now, statsWeeklyPeriods)
+	m.viewport.GotoTop()
+	m.updateViewport()
+}
+
+func (m *StatsViewModel) Deactivate() {
+	m.active = false
+	m.daily = nil
+	m.weekly = nil
+}
+
+func (m *StatsViewModel) IsActive() bool {
+	return m.active
+}
+
+func (m *StatsViewModel) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return cmd
+}
+
+func (m *StatsViewModel) View() string {
+	if !m.active {
+		return ""
+	}
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	help := helpStyle.Render("\nj/k: Scroll | q/Esc: Close statistics")
+
+	return m.viewport.View() + "\n" + help
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns midnight of the Monday on or before t.
+func startOfWeek(t time.Time) time.Time {
+	day := startOfDay(t)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+func summarizeActivity(events []domain.ActivityEvent, from, to time.Time) map[domain.ProviderType]*activityCounts {
+	counts := make(map[domain.ProviderType]*activityCounts)
+	for _, e := range events {
+		if e.Timestamp.Before(from) || !e.Timestamp.Before(to) {
+			continue
+		}
+
+		c, ok := counts[e.Provider]
+		if !ok {
+			c = &activityCounts{}
+			counts[e.Provider] = c
+		}
+
+		switch e.Type {
+		case domain.ActivityReviewSubmitted:
+			c.Reviews++
+			if e.TimeInReview > 0 {
+				c.TimeInReview += e.TimeInReview
+				c.timedReviews++
+			}
+		case domain.ActivityPRMerged:
+			c.Merged++
+		case domain.ActivityReviewTime:
+			c.TimeSpent += e.TimeInReview
+		}
+		c.Comments += e.Comments
+	}
+	return counts
+}
+
+func buildDailyPeriods(events []domain.ActivityEvent, now time.Time, days int) []statsPeriod {
+	today := startOfDay(now)
+	periods := make([]statsPeriod, 0, days)
+	for i := 0; i < days; i++ {
+		from := today.AddDate(0, 0, -i)
+		to := from.AddDate(0, 0, 1)
+
+		label := from.Format("Mon Jan 2")
+		if i == 0 {
+			label = "Today"
+		}
+
+		periods = append(periods, statsPeriod{
+			label:  label,
+			from:   from,
+			to:     to,
+			counts: summarizeActivity(events, from, to),
+		})
+	}
+	return periods
+}
+
+func buildWeeklyPeriods(events []domain.ActivityEvent, now time.Time, weeks int) []statsPeriod {
+	thisWeek := startOfWeek(now)
+	periods := make([]statsPeriod, 0, weeks)
+	for i := 0; i < weeks; i++ {
+		from := thisWeek.AddDate(0, 0, -7*i)
+		to := from.AddDate(0, 0, 7)
+
+		label := "Week of " + from.Format("Jan 2")
+		if i == 0 {
+			label = "This week"
+		}
+
+		periods = append(periods, statsPeriod{
+			label:  label,
+			from:   from,
+			to:     to,
+			counts: summarizeActivity(events, from, to),
+		})
+	}
+	return periods
+}
+
+func formatTimeInReview(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func (m *StatsViewModel) renderPeriods(b *strings.Builder, title string, periods []statsPeriod) {
+	sectionStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#3B82F6")).
+		Bold(true)
+	headerStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Bold(true)
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("15"))
+	emptyStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280"))
+
+	b.WriteString(sectionStyle.Render(title))
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(fmt.Sprintf("  %-14s %-12s %8s %9s %7s %15s %10s",
+		"Period", "Provider", "Reviews", "Comments", "Merged", "Avg review time", "Time spent")))
+	b.WriteString("\n")
+
+	for _, period := range periods {
+		if len(period.counts) == 0 {
+			b.WriteString(emptyStyle.Render(fmt.Sprintf("  %-14s %-12s %8s %9s %7s %15s %10s",
+				period.label, "-", "0", "0", "0", "-", "-")))
+			b.WriteString("\n")
+			continue
+		}
+
+		providers := make([]string, 0, len(period.counts))
+		for provider := range period.counts {
+			providers = append(providers, string(provider))
+		}
+		sort.Strings(providers)
+
+		for i, provider := range providers {
+			c := period.counts[domain.ProviderType(provider)]
+			label := ""
+			if i == 0 {
+				label = period.label
+			}
+			b.WriteString(labelStyle.Render(fmt.Sprintf("  %-14s %-12s %8d %9d %7d %15s %10s",
+				label, provider, c.Reviews, c.Comments, c.Merged, formatTimeInReview(c.AverageTimeInReview()), formatTimeInReview(c.TimeSpent))))
+			b.WriteString("\n")
+		}
+	}
+}
+
+func (m *StatsViewModel) updateViewport() {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true).
+		Padding(1, 0)
+
+	b.WriteString(titleStyle.Render("Review Statistics"))
+	b.WriteString("\n\n")
+
+	if m.total == 0 {
+		b.WriteString(lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#6B7280")).
+			Italic(true).
+			Render("No review activity recorded yet. Submit a review or merge a PR to start tracking."))
+		m.viewport.SetContent(b.String())
+		return
+	}
+
+	m.renderPeriods(&b, "Daily", m.daily)
+	b.WriteString("\n")
+	m.renderPeriods(&b, "Weekly", m.weekly)
+
+	m.viewport.SetContent(b.String())
+}
@@ -0,0 +1,124 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func TestStartOfWeek_ReturnsMonday(t *testing.T) {
+	// 2026-10-17 is a Saturday
+	now := time.Date(2026, 10, 17, 15, 30, 0, 0, time.UTC)
+	got := startOfWeek(now)
+	want := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	sunday := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	if got := startOfWeek(sunday); !got.Equal(want) {
+		t.Errorf("expected Sunday to belong to week of %v, got %v", want, got)
+	}
+}
+
+func TestSummarizeActivity_CountsPerProvider(t *testing.T) {
+	now := time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC)
+	events := []domain.ActivityEvent{
+		{Type: domain.ActivityReviewSubmitted, Provider: domain.ProviderGitHub, Comments: 3, TimeInReview: 10 * time.Minute, Timestamp: now.Add(-time.Hour)},
+		{Type: domain.ActivityReviewSubmitted, Provider: domain.ProviderGitHub, Comments: 1, TimeInReview: 20 * time.Minute, Timestamp: now.Add(-2 * time.Hour)},
+		{Type: domain.ActivityPRMerged, Provider: domain.ProviderGitHub, Timestamp: now.Add(-3 * time.Hour)},
+		{Type: domain.ActivityReviewSubmitted, Provider: domain.ProviderAzureDevOps, Timestamp: now.Add(-4 * time.Hour)},
+		{Type: domain.ActivityReviewSubmitted, Provider: domain.ProviderGitHub, Timestamp: now.Add(-48 * time.Hour)},
+	}
+
+	counts := summarizeActivity(events, startOfDay(now), now.Add(time.Hour))
+
+	gh := counts[domain.ProviderGitHub]
+	if gh == nil {
+		t.Fatal("expected github counts")
+	}
+	if gh.Reviews != 2 || gh.Comments != 4 || gh.Merged != 1 {
+		t.Errorf("unexpected github counts: %+v", gh)
+	}
+	if avg := gh.AverageTimeInReview(); avg != 15*time.Minute {
+		t.Errorf("expected average time in review 15m, got %v", avg)
+	}
+
+	ado := counts[domain.ProviderAzureDevOps]
+	if ado == nil || ado.Reviews != 1 {
+		t.Errorf("unexpected azure devops counts: %+v", ado)
+	}
+	if ado != nil && ado.AverageTimeInReview() != 0 {
+		t.Errorf("expected no average time for untimed reviews, got %v", ado.AverageTimeInReview())
+	}
+}
+
+func TestSummarizeActivity_AddsTimerTimeWithoutCountingReviews(t *testing.T) {
+	now := time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC)
+	events := []domain.ActivityEvent{
+		{Type: domain.ActivityReviewTime, Provider: domain.ProviderGitHub, TimeInReview: 30 * time.Minute, Timestamp: now.Add(-time.Hour)},
+		{Type: domain.ActivityReviewTime, Provider: domain.ProviderGitHub, TimeInReview: 15 * time.Minute, Timestamp: now.Add(-2 * time.Hour)},
+		{Type: domain.ActivityReviewSubmitted, Provider: domain.ProviderGitHub, TimeInReview: 10 * time.Minute, Timestamp: now.Add(-2 * time.Hour)},
+	}
+
+	gh := summarizeActivity(events, startOfDay(now), now.Add(time.Hour))[domain.ProviderGitHub]
+	if gh == nil {
+		t.Fatal("expected github counts")
+	}
+	if gh.Reviews != 1 || gh.TimeSpent != 45*time.Minute {
+		t.Errorf("expected 1 review and 45m spent, got %+v", gh)
+	}
+	if avg := gh.AverageTimeInReview(); avg != 10*time.Minute {
+		t.Errorf("expected the timer to leave the average at 10m, got %v", avg)
+	}
+}
+
+func TestBuildWeeklyPeriods(t *testing.T) {
+	now := time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC)
+	events := []domain.ActivityEvent{
+		{Type: domain.ActivityReviewSubmitted, Provider: domain.ProviderGitHub, Timestamp: time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC)},
+		{Type: domain.ActivityReviewSubmitted, Provider: domain.ProviderGitHub, Timestamp: time.Date(2026, 10, 11, 23, 0, 0, 0, time.UTC)},
+	}
+
+	periods := buildWeeklyPeriods(events, now, 2)
+	if len(periods) != 2 {
+		t.Fatalf("expected 2 periods, got %d", len(periods))
+	}
+	if periods[0].label != "This week" {
+		t.Errorf("expected first period label 'This week', got %q", periods[0].label)
+	}
+	if c := periods[0].counts[domain.ProviderGitHub]; c == nil || c.Reviews != 1 {
+		t.Errorf("expected 1 review this week, got %+v", c)
+	}
+	if c := periods[1].counts[domain.ProviderGitHub]; c == nil || c.Reviews != 1 {
+		t.Errorf("expected 1 review last week, got %+v", c)
+	}
+}
+
+func TestStatsView_RendersEmptyState(t *testing.T) {
+	view := NewStatsView()
+	view.SetSize(120, 40)
+	view.Activate(nil, time.Now())
+
+	if !strings.Contains(view.View(), "No review activity recorded yet") {
+		t.Error("expected empty state message")
+	}
+}
+
+func TestStatsView_RendersProviderRows(t *testing.T) {
+	now := time.Now()
+	view := NewStatsView()
+	view.SetSize(120, 40)
+	view.Activate([]domain.ActivityEvent{
+		{Type: domain.ActivityReviewSubmitted, Provider: domain.ProviderGitHub, Timestamp: now},
+	}, now)
+
+	output := view.View()
+	for _, want := range []string{"Review Statistics", "Daily", "Weekly", "Today", "This week", "github"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
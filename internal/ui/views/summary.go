@@ -0,0 +1,125 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/markdown"
+)
+
+// SummaryViewModel shows a generated summary of a PR's changes with the
+// suggested review focus. The last summary is kept so that reopening it for
+// an unchanged diff does not ask the summarizer again.
+type SummaryViewModel struct {
+	viewport   viewport.Model
+	title      string
+	text       string
+	truncated  bool
+	key        string
+	width      int
+	height     int
+	active     bool
+	mdRenderer *markdown.Renderer
+}
+
+func NewSummaryView() *SummaryViewModel {
+	return &SummaryViewModel{
+		viewport:   viewport.New(0, 0),
+		mdRenderer: markdown.NewRenderer(markdown.DefaultStyles()),
+	}
+}
+
+func (m *SummaryViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.viewport.Width = width
+	m.viewport.Height = height - 10
+	m.mdRenderer.SetWidth(width)
+	if m.active {
+		m.updateViewport()
+	}
+}
+
+// Activate shows text, the summary of the diff identified by key.
+// truncated notes that only part of the diff was summarized.
+func (m *SummaryViewModel) Activate(key, title, text string, truncated bool) {
+	m.active = true
+	m.key = key
+	m.title = title
+	m.text = text
+	m.truncated = truncated
+	m.viewport.GotoTop()
+	m.updateViewport()
+}
+
+// Reopen shows the last summary again if it was made for key, reporting
+// whether it was.
+func (m *SummaryViewModel) Reopen(key string) bool {
+	if m.key == "" || m.key != key {
+		return false
+	}
+	m.active = true
+	m.viewport.GotoTop()
+	m.updateViewport()
+	return true
+}
+
+func (m *SummaryViewModel) Deactivate() {
+	m.active = false
+}
+
+func (m *SummaryViewModel) IsActive() bool {
+	return m.active
+}
+
+func (m *SummaryViewModel) GetText() string {
+	return m.text
+}
+
+func (m *SummaryViewModel) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return cmd
+}
+
+func (m *SummaryViewModel) View() string {
+	if !m.active {
+		return ""
+	}
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	help := helpStyle.Render("\nj/k: Scroll | r: Regenerate | q/Esc: Close summary")
+
+	return m.viewport.View() + "\n" + help
+}
+
+func (m *SummaryViewModel) updateViewport() {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true).
+		Padding(1, 0)
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	b.WriteString(titleStyle.Render("Summary of changes: " + m.title))
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("Generated by the configured summarizer; verify it against the diff."))
+	b.WriteString("\n")
+	if m.truncated {
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).
+			Render("⚠ The diff was too large and only its beginning was summarized."))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(m.mdRenderer.Render(m.text))
+
+	m.viewport.SetContent(b.String())
+}
@@ -0,0 +1,209 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/textwidth"
+)
+
+// TeamGroup is a teammate of the team board with their open PRs.
+type TeamGroup struct {
+	Member string
+	PRs    []domain.PullRequest
+}
+
+// TeamBoardViewModel lists the open PRs of each teammate with their approval
+// status, for picking the next review of a rotation.
+type TeamBoardViewModel struct {
+	active      bool
+	width       int
+	height      int
+	loading     bool
+	selectedIdx int
+	groups      []TeamGroup
+	// rows holds the group and PR index of each listed PR, in order
+	rows [][2]int
+}
+
+func NewTeamBoardView() *TeamBoardViewModel {
+	return &TeamBoardViewModel{}
+}
+
+func (m *TeamBoardViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Activate shows the board while the PRs of members load.
+func (m *TeamBoardViewModel) Activate(members []string) {
+	m.active = true
+	m.loading = true
+	groups := make([]TeamGroup, len(members))
+	for i, member := range members {
+		groups[i] = TeamGroup{Member: member}
+	}
+	m.setGroups(groups)
+}
+
+// SetGroups replaces the board once the PRs are loaded, keeping the selected
+// PR when it is still listed.
+func (m *TeamBoardViewModel) SetGroups(groups []TeamGroup) {
+	var selected string
+	if pr := m.GetSelected(); pr != nil {
+		selected = pr.Key()
+	}
+	m.loading = false
+	m.setGroups(groups)
+	for i, row := range m.rows {
+		if m.groups[row[0]].PRs[row[1]].Key() == selected {
+			m.selectedIdx = i
+		}
+	}
+}
+
+func (m *TeamBoardViewModel) setGroups(groups []TeamGroup) {
+	m.groups = groups
+	m.rows = nil
+	for g, group := range groups {
+		for p := range group.PRs {
+			m.rows = append(m.rows, [2]int{g, p})
+		}
+	}
+	m.selectedIdx = 0
+}
+
+func (m *TeamBoardViewModel) Deactivate() {
+	m.active = false
+	m.groups = nil
+	m.rows = nil
+}
+
+func (m *TeamBoardViewModel) IsActive() bool {
+	return m.active
+}
+
+func (m *TeamBoardViewModel) IsLoading() bool {
+	return m.loading
+}
+
+func (m *TeamBoardViewModel) GetSelected() *domain.PullRequest {
+	if m.selectedIdx < 0 || m.selectedIdx >= len(m.rows) {
+		return nil
+	}
+	row := m.rows[m.selectedIdx]
+	return &m.groups[row[0]].PRs[row[1]]
+}
+
+func (m *TeamBoardViewModel) Next() {
+	if m.selectedIdx < len(m.rows)-1 {
+		m.selectedIdx++
+	}
+}
+
+func (m *TeamBoardViewModel) Prev() {
+	if m.selectedIdx > 0 {
+		m.selectedIdx--
+	}
+}
+
+func (m *TeamBoardViewModel) View() string {
+	if !m.active {
+		return ""
+	}
+
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true).
+		Padding(1, 0)
+	memberStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#3B82F6")).
+		Bold(true)
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Team board (%d open PRs)", len(m.rows))))
+	b.WriteString("\n\n")
+
+	// The board is built line by line and scrolled to keep the selected PR
+	// in view.
+	width := min(110, m.width-4) - 6
+	var lines []string
+	selectedLine := 0
+	row := 0
+	for _, group := range m.groups {
+		lines = append(lines, memberStyle.Render(group.Member)+mutedStyle.Render("  "+teamSummary(group.PRs)))
+		if len(group.PRs) == 0 {
+			message := "  No open PRs"
+			if m.loading {
+				message = "  Loading..."
+			}
+			lines = append(lines, mutedStyle.Render(message))
+		}
+		for _, pr := range group.PRs {
+			if row == m.selectedIdx {
+				selectedLine = len(lines)
+			}
+			lines = append(lines, renderTeamPR(pr, row == m.selectedIdx, width))
+			row++
+		}
+	}
+	if rows := max(m.height-16, 4); len(lines) > rows {
+		from := min(max(selectedLine-rows/2, 0), len(lines)-rows)
+		lines = lines[from : from+rows]
+	}
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("x approved  # changes requested  o waiting  · no reviews"))
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("↑↓: Navigate | Enter: Open | r: Refresh | Esc: Close"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(min(110, m.width-4))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(b.String()))
+}
+
+// teamSummary counts the PRs of a teammate by approval status.
+func teamSummary(prs []domain.PullRequest) string {
+	var approved, changes int
+	for _, pr := range prs {
+		switch pr.ApprovalStatus {
+		case domain.ApprovalStatusApproved:
+			approved++
+		case domain.ApprovalStatusChangesRequested:
+			changes++
+		}
+	}
+	return fmt.Sprintf("%d open, %d approved, %d with changes requested", len(prs), approved, changes)
+}
+
+func renderTeamPR(pr domain.PullRequest, selected bool, width int) string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	marker := "  "
+	if selected {
+		marker = "► "
+		titleStyle = titleStyle.Foreground(lipgloss.Color("#7C3AED")).Bold(true)
+	}
+
+	badge := strings.TrimSpace(getApprovalBadge(pr.ApprovalStatus))
+	if badge == "" {
+		badge = "·"
+	}
+	ref := fmt.Sprintf("%s#%d", pr.Repository.FullName, pr.Number)
+	age := formatAge(pr.CreatedAt)
+	title := textwidth.Truncate(pr.Title, max(width-textwidth.Width(ref)-textwidth.Width(age)-10, 10))
+	return marker + badge + " " + mutedStyle.Render(ref) + "  " + titleStyle.Render(title) + mutedStyle.Render("  "+age)
+}
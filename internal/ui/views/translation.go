@@ -0,0 +1,105 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/johanforsgren/lgtmfaster/internal/ui/markdown"
+)
+
+type TranslationViewModel struct {
+	viewport   viewport.Model
+	title      string
+	language   string
+	text       string
+	width      int
+	height     int
+	active     bool
+	mdRenderer *markdown.Renderer
+}
+
+func NewTranslationView() *TranslationViewModel {
+	return &TranslationViewModel{
+		viewport:   viewport.New(0, 0),
+		mdRenderer: markdown.NewRenderer(markdown.DefaultStyles()),
+	}
+}
+
+func (m *TranslationViewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.viewport.Width = width
+	m.viewport.Height = height - 10
+	m.mdRenderer.SetWidth(width)
+	if m.active {
+		m.updateViewport()
+	}
+}
+
+func (m *TranslationViewModel) Activate(title, language, text string) {
+	m.active = true
+	m.title = title
+	m.language = language
+	m.text = text
+	m.viewport.GotoTop()
+	m.updateViewport()
+}
+
+func (m *TranslationViewModel) Deactivate() {
+	m.active = false
+	m.text = ""
+}
+
+func (m *TranslationViewModel) IsActive() bool {
+	return m.active
+}
+
+func (m *TranslationViewModel) GetText() string {
+	return m.text
+}
+
+func (m *TranslationViewModel) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return cmd
+}
+
+func (m *TranslationViewModel) View() string {
+	if !m.active {
+		return ""
+	}
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6B7280")).
+		Italic(true)
+
+	help := helpStyle.Render("\nj/k: Scroll | q/Esc: Close translation")
+
+	return m.viewport.View() + "\n" + help
+}
+
+func (m *TranslationViewModel) updateViewport() {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true).
+		Padding(1, 0)
+
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Translation: %s (%s)", m.title, m.language)))
+	b.WriteString("\n\n")
+
+	if strings.TrimSpace(m.text) == "" {
+		b.WriteString(lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#6B7280")).
+			Italic(true).
+			Render("Nothing to translate"))
+	} else {
+		b.WriteString(m.mdRenderer.Render(m.text))
+	}
+
+	m.viewport.SetContent(b.String())
+}
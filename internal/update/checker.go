@@ -0,0 +1,166 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+const (
+	// DisableEnv turns the update check off entirely when set to a non-empty
+	// value, regardless of the configuration file.
+	DisableEnv = "LGTMFASTER_NO_UPDATE_CHECK"
+
+	// CheckInterval is the minimum time between two queries of the releases API.
+	CheckInterval = 24 * time.Hour
+
+	defaultReleasesURL = "https://api.github.com/repos/jaforsgren/LGTMFaster/releases/latest"
+)
+
+type Release struct {
+	Version   string
+	URL       string
+	Changelog string
+}
+
+type Checker struct {
+	releasesURL string
+	httpClient  *http.Client
+	now         func() time.Time
+}
+
+func NewChecker() *Checker {
+	return &Checker{
+		releasesURL: defaultReleasesURL,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		now:         time.Now,
+	}
+}
+
+// Enabled reports whether the startup check should run at all.
+func Enabled(settings domain.UpdateSettings) bool {
+	return settings.CheckOnStartup && os.Getenv(DisableEnv) == ""
+}
+
+// Check returns the latest release, querying the releases API only when the
+// cached result in settings is older than CheckInterval. The returned settings
+// carry the refreshed cache and should be saved by the caller.
+func (c *Checker) Check(ctx context.Context, settings domain.UpdateSettings) (Release, domain.UpdateSettings, error) {
+	now := c.now()
+	if !settings.LastChecked.IsZero() && now.Sub(settings.LastChecked) < CheckInterval {
+		return cachedRelease(settings), settings, nil
+	}
+
+	release, err := c.fetchLatest(ctx)
+	if err != nil {
+		return Release{}, settings, err
+	}
+
+	settings.LastChecked = now
+	settings.LatestVersion = release.Version
+	settings.ReleaseURL = release.URL
+	settings.Changelog = release.Changelog
+	return release, settings, nil
+}
+
+func cachedRelease(settings domain.UpdateSettings) Release {
+	return Release{
+		Version:   settings.LatestVersion,
+		URL:       settings.ReleaseURL,
+		Changelog: settings.Changelog,
+	}
+}
+
+type releaseResponse struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+	Body    string `json:"body"`
+}
+
+func (c *Checker) fetchLatest(ctx context.Context) (Release, error) {
+	logger.Log("Update: Checking %s for new releases", c.releasesURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.releasesURL, nil)
+	if err != nil {
+		return Release{}, fmt.Errorf("failed to create update check request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		logger.LogError("UPDATE_CHECK", c.releasesURL, err)
+		return Release{}, fmt.Errorf("update check failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Release{}, fmt.Errorf("update check failed with status %d", resp.StatusCode)
+	}
+
+	var result releaseResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return Release{}, fmt.Errorf("failed to decode release: %w", err)
+	}
+
+	return Release{
+		Version:   result.TagName,
+		URL:       result.HTMLURL,
+		Changelog: result.Body,
+	}, nil
+}
+
+// IsNewer reports whether latest is a higher version than current. Versions
+// that cannot be parsed, such as development builds, are never outdated.
+func IsNewer(latest, current string) bool {
+	l, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	c, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+
+	for i := 0; i < len(l) || i < len(c); i++ {
+		var lv, cv int
+		if i < len(l) {
+			lv = l[i]
+		}
+		if i < len(c) {
+			cv = c[i]
+		}
+		if lv != cv {
+			return lv > cv
+		}
+	}
+	return false
+}
+
+func parseVersion(v string) ([]int, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	if v == "" {
+		return nil, false
+	}
+
+	parts := strings.Split(v, ".")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		numbers[i] = n
+	}
+	return numbers, true
+}
@@ -0,0 +1,119 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func newTestChecker(url string, now time.Time) *Checker {
+	return &Checker{
+		releasesURL: url,
+		httpClient:  http.DefaultClient,
+		now:         func() time.Time { return now },
+	}
+}
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		latest  string
+		current string
+		want    bool
+	}{
+		{"v0.5.0", "v0.4.2", true},
+		{"v0.4.2", "v0.4.2", false},
+		{"v0.4.10", "0.4.9", true},
+		{"v1.0", "v1.0.1", false},
+		{"v0.5.0", "dev", false},
+		{"", "v0.4.0", false},
+		{"v0.5.0-rc.1", "v0.4.0", true},
+	}
+
+	for _, tt := range tests {
+		if got := IsNewer(tt.latest, tt.current); got != tt.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestEnabled_RespectsEnvironment(t *testing.T) {
+	settings := domain.UpdateSettings{CheckOnStartup: true}
+
+	t.Setenv(DisableEnv, "")
+	if !Enabled(settings) {
+		t.Error("expected check to be enabled when opted in")
+	}
+
+	t.Setenv(DisableEnv, "1")
+	if Enabled(settings) {
+		t.Error("expected environment variable to disable the check")
+	}
+
+	t.Setenv(DisableEnv, "")
+	if Enabled(domain.UpdateSettings{}) {
+		t.Error("expected check to be disabled by default")
+	}
+}
+
+func TestCheck_FetchesAndCachesRelease(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"tag_name":"v0.6.0","html_url":"https://example.com/v0.6.0","body":"- Faster diffs"}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	checker := newTestChecker(server.URL, now)
+
+	release, settings, err := checker.Check(context.Background(), domain.UpdateSettings{CheckOnStartup: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if release.Version != "v0.6.0" || release.Changelog != "- Faster diffs" {
+		t.Errorf("unexpected release: %+v", release)
+	}
+	if !settings.LastChecked.Equal(now) || settings.LatestVersion != "v0.6.0" {
+		t.Errorf("expected cache to be refreshed, got %+v", settings)
+	}
+
+	checker.now = func() time.Time { return now.Add(time.Hour) }
+	cached, _, err := checker.Check(context.Background(), settings)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected cached result within a day, got %d requests", requests)
+	}
+	if cached.Version != "v0.6.0" {
+		t.Errorf("expected cached version v0.6.0, got %q", cached.Version)
+	}
+
+	checker.now = func() time.Time { return now.Add(CheckInterval) }
+	if _, _, err := checker.Check(context.Background(), settings); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected a new request after a day, got %d requests", requests)
+	}
+}
+
+func TestCheck_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	checker := newTestChecker(server.URL, time.Now())
+	_, settings, err := checker.Check(context.Background(), domain.UpdateSettings{CheckOnStartup: true})
+	if err == nil {
+		t.Fatal("expected an error for a non-200 response")
+	}
+	if !settings.LastChecked.IsZero() {
+		t.Error("expected failed check not to update the cache")
+	}
+}
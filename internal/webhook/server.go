@@ -0,0 +1,259 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+	"github.com/johanforsgren/lgtmfaster/internal/logger"
+)
+
+const (
+	eventBufferSize = 64
+	maxPayloadBytes = 5 << 20
+)
+
+var ErrNotConfigured = errors.New("webhook server not configured: set settings.Webhook.ListenAddr in config.json")
+
+// Event is a provider-neutral notification that something changed on a pull
+// request. Number is zero when the event only identifies a repository.
+type Event struct {
+	Provider   domain.ProviderType
+	Kind       string
+	Action     string
+	Repository string
+	Number     int
+}
+
+// Matches reports whether the event refers to the given pull request.
+func (e Event) Matches(pr domain.PullRequest) bool {
+	return e.Provider == pr.ProviderType &&
+		e.Number == pr.Number &&
+		strings.EqualFold(e.Repository, pr.Repository.FullName)
+}
+
+type Server struct {
+	addr     string
+	secret   string
+	events   chan Event
+	server   *http.Server
+	listener net.Listener
+}
+
+func NewServer(settings domain.WebhookSettings) (*Server, error) {
+	if settings.ListenAddr == "" {
+		return nil, ErrNotConfigured
+	}
+
+	s := &Server{
+		addr:   settings.ListenAddr,
+		secret: settings.Secret,
+		events: make(chan Event, eventBufferSize),
+	}
+	s.server = &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s, nil
+}
+
+// Start binds the listen address and serves webhooks in the background.
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		logger.LogError("WEBHOOK_LISTEN", s.addr, err)
+		return fmt.Errorf("failed to start webhook listener on %s: %w", s.addr, err)
+	}
+	s.listener = listener
+
+	logger.Log("Webhook: Listening on %s", listener.Addr())
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.LogError("WEBHOOK_SERVE", s.addr, err)
+		}
+	}()
+	return nil
+}
+
+// Addr returns the bound address, which differs from the configured one when
+// listening on port 0.
+func (s *Server) Addr() string {
+	if s.listener != nil {
+		return s.listener.Addr().String()
+	}
+	return s.addr
+}
+
+func (s *Server) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Server) Close() error {
+	return s.server.Close()
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxPayloadBytes)
+
+	var (
+		event *Event
+		err   error
+	)
+	if github.WebHookType(r) != "" {
+		event, err = s.parseGitHub(r)
+	} else {
+		event, err = s.parseAzureDevOps(r)
+	}
+
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errUnauthorized) {
+			status = http.StatusUnauthorized
+		}
+		logger.LogError("WEBHOOK_PARSE", r.URL.Path, err)
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	if event == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	logger.Log("Webhook: %s %s %s on %s#%d", event.Provider, event.Kind, event.Action, event.Repository, event.Number)
+	select {
+	case s.events <- *event:
+	default:
+		logger.Log("Webhook: Event buffer full, dropping %s event for %s", event.Kind, event.Repository)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+var errUnauthorized = errors.New("invalid webhook credentials")
+
+func (s *Server) parseGitHub(r *http.Request) (*Event, error) {
+	var secret []byte
+	if s.secret != "" {
+		secret = []byte(s.secret)
+	}
+
+	payload, err := github.ValidatePayload(r, secret)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUnauthorized, err)
+	}
+
+	kind := github.WebHookType(r)
+	parsed, err := github.ParseWebHook(kind, payload)
+	if err != nil {
+		// Unknown event types are not errors; the hook may simply be
+		// subscribed to more than we care about.
+		return nil, nil
+	}
+
+	event := &Event{Provider: domain.ProviderGitHub, Kind: kind}
+	switch e := parsed.(type) {
+	case *github.PullRequestEvent:
+		event.Action = e.GetAction()
+		event.Repository = e.GetRepo().GetFullName()
+		event.Number = e.GetNumber()
+	case *github.PullRequestReviewEvent:
+		event.Action = e.GetAction()
+		event.Repository = e.GetRepo().GetFullName()
+		event.Number = e.GetPullRequest().GetNumber()
+	case *github.PullRequestReviewCommentEvent:
+		event.Action = e.GetAction()
+		event.Repository = e.GetRepo().GetFullName()
+		event.Number = e.GetPullRequest().GetNumber()
+	case *github.IssueCommentEvent:
+		if !e.GetIssue().IsPullRequest() {
+			return nil, nil
+		}
+		event.Action = e.GetAction()
+		event.Repository = e.GetRepo().GetFullName()
+		event.Number = e.GetIssue().GetNumber()
+	case *github.CheckSuiteEvent:
+		event.Action = e.GetAction()
+		event.Repository = e.GetRepo().GetFullName()
+		if prs := e.GetCheckSuite().PullRequests; len(prs) > 0 {
+			event.Number = prs[0].GetNumber()
+		}
+	case *github.StatusEvent:
+		event.Action = e.GetState()
+		event.Repository = e.GetRepo().GetFullName()
+	default:
+		return nil, nil
+	}
+
+	return event, nil
+}
+
+type adoRepository struct {
+	Name    string `json:"name"`
+	Project struct {
+		Name string `json:"name"`
+	} `json:"project"`
+}
+
+type adoPullRequest struct {
+	PullRequestID int           `json:"pullRequestId"`
+	Status        string        `json:"status"`
+	Repository    adoRepository `json:"repository"`
+}
+
+type adoPayload struct {
+	EventType string `json:"eventType"`
+	Resource  struct {
+		adoPullRequest
+		PullRequest *adoPullRequest `json:"pullRequest"`
+	} `json:"resource"`
+}
+
+// parseAzureDevOps handles Azure DevOps service hooks. A configured secret is
+// expected as the basic authentication password of the service hook.
+func (s *Server) parseAzureDevOps(r *http.Request) (*Event, error) {
+	if s.secret != "" {
+		_, password, ok := r.BasicAuth()
+		if !ok || subtle.ConstantTimeCompare([]byte(password), []byte(s.secret)) != 1 {
+			return nil, errUnauthorized
+		}
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook payload: %w", err)
+	}
+
+	var payload adoPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook payload: %w", err)
+	}
+
+	if !strings.Contains(payload.EventType, "pullrequest") {
+		return nil, nil
+	}
+
+	pr := payload.Resource.adoPullRequest
+	if payload.Resource.PullRequest != nil {
+		pr = *payload.Resource.PullRequest
+	}
+
+	return &Event{
+		Provider:   domain.ProviderAzureDevOps,
+		Kind:       payload.EventType,
+		Action:     pr.Status,
+		Repository: fmt.Sprintf("%s/%s", pr.Repository.Project.Name, pr.Repository.Name),
+		Number:     pr.PullRequestID,
+	}, nil
+}
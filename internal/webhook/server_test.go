@@ -0,0 +1,213 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/johanforsgren/lgtmfaster/internal/domain"
+)
+
+func newTestServer(t *testing.T, secret string) *Server {
+	t.Helper()
+	s, err := NewServer(domain.WebhookSettings{ListenAddr: "127.0.0.1:0", Secret: secret})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return s
+}
+
+func githubRequest(eventType, body, secret string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", eventType)
+	if secret != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(body))
+		req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	return req
+}
+
+func receive(t *testing.T, s *Server) Event {
+	t.Helper()
+	select {
+	case e := <-s.Events():
+		return e
+	default:
+		t.Fatal("expected an event to be queued")
+		return Event{}
+	}
+}
+
+func TestNewServer_NotConfigured(t *testing.T) {
+	_, err := NewServer(domain.WebhookSettings{})
+	if !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("expected ErrNotConfigured, got %v", err)
+	}
+}
+
+func TestServeHTTP_GitHubPullRequest(t *testing.T) {
+	s := newTestServer(t, "s3cret")
+	body := `{"action":"synchronize","number":42,"pull_request":{"number":42},"repository":{"full_name":"owner/repo"}}`
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, githubRequest("pull_request", body, "s3cret"))
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	event := receive(t, s)
+	if event.Provider != domain.ProviderGitHub || event.Repository != "owner/repo" || event.Number != 42 || event.Action != "synchronize" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+}
+
+func TestServeHTTP_GitHubInvalidSignature(t *testing.T) {
+	s := newTestServer(t, "s3cret")
+	body := `{"action":"opened","number":1,"repository":{"full_name":"owner/repo"}}`
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, githubRequest("pull_request", body, "wrong"))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+	if len(s.Events()) != 0 {
+		t.Error("expected no event for invalid signature")
+	}
+}
+
+func TestServeHTTP_GitHubIssueCommentOnIssueIgnored(t *testing.T) {
+	s := newTestServer(t, "")
+	body := `{"action":"created","issue":{"number":7},"repository":{"full_name":"owner/repo"}}`
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, githubRequest("issue_comment", body, ""))
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", rec.Code)
+	}
+	if len(s.Events()) != 0 {
+		t.Error("expected plain issue comments to be ignored")
+	}
+}
+
+func TestServeHTTP_GitHubPingIgnored(t *testing.T) {
+	s := newTestServer(t, "")
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, githubRequest("ping", `{"zen":"Keep it logically awesome."}`, ""))
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", rec.Code)
+	}
+	if len(s.Events()) != 0 {
+		t.Error("expected ping to produce no event")
+	}
+}
+
+func TestServeHTTP_AzureDevOpsPullRequestUpdated(t *testing.T) {
+	s := newTestServer(t, "s3cret")
+	body := `{"eventType":"git.pullrequest.updated","resource":{"pullRequestId":17,"status":"active","repository":{"name":"api","project":{"name":"Platform"}}}}`
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.SetBasicAuth("hook", "s3cret")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	event := receive(t, s)
+	if event.Provider != domain.ProviderAzureDevOps || event.Repository != "Platform/api" || event.Number != 17 {
+		t.Errorf("unexpected event: %+v", event)
+	}
+}
+
+func TestServeHTTP_AzureDevOpsCommentEvent(t *testing.T) {
+	s := newTestServer(t, "")
+	body := `{"eventType":"ms.vss-code.git-pullrequest-comment-event","resource":{"comment":{"content":"hi"},"pullRequest":{"pullRequestId":5,"repository":{"name":"web","project":{"name":"Apps"}}}}}`
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rec.Code)
+	}
+
+	event := receive(t, s)
+	if event.Repository != "Apps/web" || event.Number != 5 {
+		t.Errorf("unexpected event: %+v", event)
+	}
+}
+
+func TestServeHTTP_AzureDevOpsRejectsMissingCredentials(t *testing.T) {
+	s := newTestServer(t, "s3cret")
+	body := `{"eventType":"git.pullrequest.updated","resource":{"pullRequestId":1}}`
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+}
+
+func TestServeHTTP_RejectsNonPost(t *testing.T) {
+	s := newTestServer(t, "")
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rec.Code)
+	}
+}
+
+func TestEventMatches(t *testing.T) {
+	event := Event{Provider: domain.ProviderGitHub, Repository: "Owner/Repo", Number: 3}
+	pr := domain.PullRequest{
+		Number:       3,
+		ProviderType: domain.ProviderGitHub,
+		Repository:   domain.Repo{FullName: "owner/repo"},
+	}
+
+	if !event.Matches(pr) {
+		t.Error("expected event to match PR case-insensitively")
+	}
+
+	pr.Number = 4
+	if event.Matches(pr) {
+		t.Error("expected event not to match a different PR number")
+	}
+}
+
+func TestStartAndClose(t *testing.T) {
+	s := newTestServer(t, "")
+	if err := s.Start(); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+	defer s.Close()
+
+	body := `{"eventType":"git.pullrequest.created","resource":{"pullRequestId":9,"repository":{"name":"r","project":{"name":"p"}}}}`
+	resp, err := http.Post("http://"+s.Addr()+"/hooks", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", resp.StatusCode)
+	}
+	if e := <-s.Events(); e.Number != 9 {
+		t.Errorf("expected PR 9, got %+v", e)
+	}
+}